@@ -119,3 +119,7 @@ func (cache *RedisCache) Get(ctx context.Context, key string, returnValue interf
 
 	return returnValue, nil
 }
+
+func (cache *RedisCache) Delete(ctx context.Context, key string) error {
+	return cache.redisRemoteCache.Del(ctx, fmt.Sprintf("%s%s", cache.keyPrefix, key)).Err()
+}
@@ -21,6 +21,7 @@ type TieredCache struct {
 type cachedValue struct {
 	Version uint64      `json:"i"`
 	Timeout uint64      `json:"t"`
+	Refresh uint64      `json:"r,omitempty"`
 	Value   interface{} `json:"v"`
 }
 
@@ -38,6 +39,8 @@ type RemoteCache interface {
 	GetString(ctx context.Context, key string) (string, error)
 	GetUint64(ctx context.Context, key string) (uint64, error)
 	GetBool(ctx context.Context, key string) (bool, error)
+
+	Delete(ctx context.Context, key string) error
 }
 
 func NewTieredCache(cacheSize int, redisAddress string, redisPrefix string) (*TieredCache, error) {
@@ -61,6 +64,11 @@ func NewTieredCache(cacheSize int, redisAddress string, redisPrefix string) (*Ti
 }
 
 func (cache *TieredCache) Set(key string, value interface{}, expiration time.Duration) error {
+	return cache.SetWithRefresh(key, value, 0, expiration)
+}
+
+// SetWithRefresh stores a value that should be refreshed after refreshAfter, but can still be served until it expires
+func (cache *TieredCache) SetWithRefresh(key string, value interface{}, refreshAfter time.Duration, expiration time.Duration) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 	cacheValue := cachedValue{
@@ -70,6 +78,9 @@ func (cache *TieredCache) Set(key string, value interface{}, expiration time.Dur
 	if expiration > 0 {
 		cacheValue.Timeout = uint64(time.Now().Add(expiration).Unix())
 	}
+	if refreshAfter > 0 {
+		cacheValue.Refresh = uint64(time.Now().Add(refreshAfter).Unix())
+	}
 
 	valueMarshal, err := json.Marshal(cacheValue)
 	if err != nil {
@@ -82,7 +93,24 @@ func (cache *TieredCache) Set(key string, value interface{}, expiration time.Dur
 	return nil
 }
 
+// Delete removes a key from the local & remote cache
+func (cache *TieredCache) Delete(key string) error {
+	cache.localGoCache.Del([]byte(key))
+	if cache.remoteCache == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+	return cache.remoteCache.Delete(ctx, key)
+}
+
 func (cache *TieredCache) Get(key string, returnValue interface{}) (interface{}, error) {
+	value, _, err := cache.GetWithRefresh(key, returnValue)
+	return value, err
+}
+
+// GetWithRefresh returns the cached value and the time after which it should be refreshed (zero time if the value has no refresh time)
+func (cache *TieredCache) GetWithRefresh(key string, returnValue interface{}) (interface{}, time.Time, error) {
 	cacheValue := &cachedValue{
 		Value: returnValue,
 	}
@@ -93,14 +121,14 @@ func (cache *TieredCache) Get(key string, returnValue interface{}) (interface{},
 		err = json.Unmarshal([]byte(wanted), cacheValue)
 		if err != nil {
 			utils.LogError(err, "error unmarshalling data for key", 0, map[string]interface{}{"key": key})
-			return nil, err
+			return nil, time.Time{}, err
 		}
 
-		return returnValue, nil
+		return returnValue, cacheValue.getRefreshTime(), nil
 	}
 
 	if cache.remoteCache == nil {
-		return nil, CacheMissError
+		return nil, time.Time{}, CacheMissError
 	}
 
 	// retrieve the key from the remote cache
@@ -109,13 +137,13 @@ func (cache *TieredCache) Get(key string, returnValue interface{}) (interface{},
 
 	_, err = cache.remoteCache.Get(ctx, key, cacheValue)
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 
 	if cacheValue.Timeout == 0 || cacheValue.Timeout > uint64(time.Now().Add(2*time.Second).Unix()) {
 		valueMarshal, err := json.Marshal(cacheValue)
 		if err != nil {
-			return nil, err
+			return nil, time.Time{}, err
 		}
 		var timeout uint64
 		if cacheValue.Timeout == 0 {
@@ -125,5 +153,12 @@ func (cache *TieredCache) Get(key string, returnValue interface{}) (interface{},
 		}
 		cache.localGoCache.Set([]byte(key), valueMarshal, int(timeout))
 	}
-	return returnValue, nil
+	return returnValue, cacheValue.getRefreshTime(), nil
+}
+
+func (value *cachedValue) getRefreshTime() time.Time {
+	if value.Refresh == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(value.Refresh), 0)
 }
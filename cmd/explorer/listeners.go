@@ -0,0 +1,87 @@
+package main
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/pk910/dora/utils"
+)
+
+// route groups that can be assigned to the listeners
+const (
+	routeGroupFrontend = "frontend"
+	routeGroupApi      = "api"
+	routeGroupMetrics  = "metrics"
+	routeGroupPprof    = "pprof"
+)
+
+var acmeManager *autocert.Manager
+
+func startListener(name string, host string, port string, withTls bool, handler http.Handler) {
+	if utils.Config.Frontend.HttpWriteTimeout == 0 {
+		utils.Config.Frontend.HttpWriteTimeout = time.Second * 15
+	}
+	if utils.Config.Frontend.HttpReadTimeout == 0 {
+		utils.Config.Frontend.HttpReadTimeout = time.Second * 15
+	}
+	if utils.Config.Frontend.HttpIdleTimeout == 0 {
+		utils.Config.Frontend.HttpIdleTimeout = time.Second * 60
+	}
+	srv := &http.Server{
+		Addr:         host + ":" + port,
+		WriteTimeout: utils.Config.Frontend.HttpWriteTimeout,
+		ReadTimeout:  utils.Config.Frontend.HttpReadTimeout,
+		IdleTimeout:  utils.Config.Frontend.HttpIdleTimeout,
+		Handler:      handler,
+	}
+
+	tlsConfig := utils.Config.Server.Tls
+	useAcme := withTls && len(tlsConfig.AcmeDomains) > 0
+	useCertFile := withTls && !useAcme && tlsConfig.CertFile != "" && tlsConfig.KeyFile != ""
+	if useAcme {
+		srv.TLSConfig = getAcmeManager().TLSConfig()
+	} else if useCertFile {
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	logger.WithField("listener", name).Printf("http server listening on %v (tls: %v)", srv.Addr, useAcme || useCertFile)
+	go func() {
+		var err error
+		switch {
+		case useAcme:
+			// certificates are provided by the acme manager
+			err = srv.ListenAndServeTLS("", "")
+		case useCertFile:
+			err = srv.ListenAndServeTLS(tlsConfig.CertFile, tlsConfig.KeyFile)
+		default:
+			err = srv.ListenAndServe()
+		}
+		if err != nil {
+			logger.WithError(err).Fatalf("Error serving %v listener", name)
+		}
+	}()
+}
+
+// getAcmeManager returns the shared acme manager, which obtains certificates for the configured domains
+// via the tls-alpn-01 challenge, so the tls listener needs to be reachable on port 443
+func getAcmeManager() *autocert.Manager {
+	if acmeManager != nil {
+		return acmeManager
+	}
+	tlsConfig := utils.Config.Server.Tls
+	cacheDir := tlsConfig.AcmeCacheDir
+	if cacheDir == "" {
+		cacheDir = "acme-cache"
+	}
+	acmeManager = &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(tlsConfig.AcmeDomains...),
+		Cache:      autocert.DirCache(cacheDir),
+		Email:      tlsConfig.AcmeEmail,
+	}
+	return acmeManager
+}
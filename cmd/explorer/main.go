@@ -2,9 +2,10 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"math"
 	"net/http"
 	_ "net/http/pprof"
-	"time"
 
 	"github.com/gorilla/mux"
 	logger "github.com/sirupsen/logrus"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/pk910/dora/db"
 	"github.com/pk910/dora/handlers"
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/rpc"
 	"github.com/pk910/dora/services"
 	"github.com/pk910/dora/static"
 	"github.com/pk910/dora/types"
@@ -20,6 +23,12 @@ import (
 
 func main() {
 	configPath := flag.String("config", "", "Path to the config file, if empty string defaults will be used")
+	rebuildEpochs := flag.String("rebuild-epochs", "", "Rebuild the epoch aggregates of the given epoch range (first-last) from the stored blocks and exit")
+	exportArchive := flag.String("export-archive", "", "Export the finalized history of the epoch range given by -from-epoch & -to-epoch to the given archive file (tar.gz) and exit")
+	importArchive := flag.String("import-archive", "", "Import an archive created with -export-archive into the database and exit")
+	snapshotDir := flag.String("snapshot", "", "Render the pages of the epoch range given by -from-epoch & -to-epoch as static html files into the given directory and exit")
+	fromEpoch := flag.Uint64("from-epoch", 0, "First epoch to export with -export-archive or -snapshot")
+	toEpoch := flag.Int64("to-epoch", -1, "Last epoch to export with -export-archive or -snapshot (defaults to the latest finalized epoch in the db)")
 	flag.Parse()
 
 	cfg := &types.Config{}
@@ -46,6 +55,92 @@ func main() {
 	if err != nil {
 		logger.Fatalf("error initializing db schema: %v", err)
 	}
+
+	if *rebuildEpochs != "" {
+		var firstEpoch, lastEpoch uint64
+		if _, err := fmt.Sscanf(*rebuildEpochs, "%d-%d", &firstEpoch, &lastEpoch); err != nil || firstEpoch > lastEpoch {
+			logger.Fatalf("invalid epoch range for rebuild-epochs: %v", *rebuildEpochs)
+		}
+		err = indexer.RebuildEpochAggregates(firstEpoch, lastEpoch)
+		if err != nil {
+			logger.Fatalf("error rebuilding epoch aggregates: %v", err)
+		}
+		logger.Printf("rebuilt epoch aggregates for epochs %v-%v", firstEpoch, lastEpoch)
+		db.MustCloseDB()
+		return
+	}
+
+	if *exportArchive != "" {
+		lastEpoch := getExportLastEpoch(*toEpoch)
+		if *fromEpoch > lastEpoch {
+			logger.Fatalf("invalid epoch range for export-archive: %v-%v", *fromEpoch, lastEpoch)
+		}
+
+		// blocks that are not stored in the db are loaded from the configured endpoints
+		clients := []*rpc.BeaconClient{}
+		for _, endpoint := range utils.Config.BeaconApi.Endpoints {
+			client, err := rpc.NewBeaconClient(endpoint.Url, endpoint.Name, endpoint.Headers, endpoint.Ssh, endpoint.Fixture)
+			if err == nil {
+				err = client.Initialize()
+			}
+			if err != nil {
+				logger.Warnf("error initializing client %v for archive export: %v", endpoint.Name, err)
+				if client != nil {
+					client.Close()
+				}
+				continue
+			}
+			clients = append(clients, client)
+		}
+
+		err = indexer.ExportArchive(*exportArchive, *fromEpoch, lastEpoch, clients)
+		for _, client := range clients {
+			client.Close()
+		}
+		if err != nil {
+			logger.Fatalf("error exporting archive: %v", err)
+		}
+		logger.Printf("exported epochs %v-%v to %v", *fromEpoch, lastEpoch, *exportArchive)
+		db.MustCloseDB()
+		return
+	}
+
+	if *importArchive != "" {
+		err = indexer.ImportArchive(*importArchive)
+		if err != nil {
+			logger.Fatalf("error importing archive: %v", err)
+		}
+		logger.Printf("imported archive %v", *importArchive)
+		db.MustCloseDB()
+		return
+	}
+
+	if *snapshotDir != "" {
+		lastEpoch := getExportLastEpoch(*toEpoch)
+		if *fromEpoch > lastEpoch {
+			logger.Fatalf("invalid epoch range for snapshot: %v-%v", *fromEpoch, lastEpoch)
+		}
+
+		// the pages are rendered by the regular handlers, but nothing must be written to the db
+		utils.Config.Indexer.DisableIndexWriter = true
+		err = services.StartBeaconService()
+		if err == nil {
+			err = services.StartFrontendCache()
+		}
+		if err != nil {
+			logger.Fatalf("error starting services for snapshot: %v", err)
+		}
+
+		err = exportSnapshot(*snapshotDir, *fromEpoch, lastEpoch)
+		services.StopBeaconService()
+		if err != nil {
+			logger.Fatalf("error exporting snapshot: %v", err)
+		}
+		logger.Printf("exported snapshot of epochs %v-%v to %v", *fromEpoch, lastEpoch, *snapshotDir)
+		db.MustCloseDB()
+		return
+	}
+
 	err = services.StartBeaconService()
 	if err != nil {
 		logger.Fatalf("error starting beacon service: %v", err)
@@ -62,75 +157,196 @@ func main() {
 
 	utils.WaitForCtrlC()
 	logger.Println("exiting...")
+	services.StopBeaconService()
 	db.MustCloseDB()
 }
 
+// getExportLastEpoch returns the last epoch of an export, which defaults to the latest finalized epoch in the db
+func getExportLastEpoch(toEpoch int64) uint64 {
+	if toEpoch >= 0 {
+		return uint64(toEpoch)
+	}
+	latestEpochs := db.GetEpochs(math.MaxInt64, 1)
+	if len(latestEpochs) == 0 {
+		logger.Fatalf("no finalized epochs in db to export")
+	}
+	return latestEpochs[0].Epoch
+}
+
 func startFrontend() {
+	// the main listener serves the frontend & api, metrics and pprof only if enabled and not moved to a separate listener
+	mainGroups := map[string]bool{
+		routeGroupFrontend: true,
+		routeGroupApi:      true,
+		routeGroupMetrics:  utils.Config.Frontend.Pprof,
+		routeGroupPprof:    utils.Config.Frontend.Pprof,
+	}
+	for _, listener := range utils.Config.Server.Listeners {
+		for _, group := range listener.Routes {
+			if group == routeGroupMetrics || group == routeGroupPprof {
+				mainGroups[group] = false
+			}
+		}
+	}
+	startListener("main", utils.Config.Server.Host, utils.Config.Server.Port, true, buildRouter(mainGroups))
+
+	for _, listener := range utils.Config.Server.Listeners {
+		groups := map[string]bool{}
+		for _, group := range listener.Routes {
+			groups[group] = true
+		}
+		startListener(listener.Name, listener.Host, listener.Port, listener.Tls, buildRouter(groups))
+	}
+}
+
+func buildRouter(groups map[string]bool) http.Handler {
 	router := mux.NewRouter()
 
-	router.HandleFunc("/", handlers.Index).Methods("GET")
-	router.HandleFunc("/index", handlers.Index).Methods("GET")
-	router.HandleFunc("/index/data", handlers.IndexData).Methods("GET")
-	router.HandleFunc("/clients", handlers.Clients).Methods("GET")
-	router.HandleFunc("/forks", handlers.Forks).Methods("GET")
-	router.HandleFunc("/epochs", handlers.Epochs).Methods("GET")
-	router.HandleFunc("/epoch/{epoch}", handlers.Epoch).Methods("GET")
-	router.HandleFunc("/slots", handlers.Slots).Methods("GET")
-	router.HandleFunc("/slots/filtered", handlers.SlotsFiltered).Methods("GET")
-	router.HandleFunc("/slot/{slotOrHash}", handlers.Slot).Methods("GET")
-	router.HandleFunc("/slot/{root}/blob/{commitment}", handlers.SlotBlob).Methods("GET")
-	router.HandleFunc("/search", handlers.Search).Methods("GET")
-	router.HandleFunc("/search/{type}", handlers.SearchAhead).Methods("GET")
-	router.HandleFunc("/validators", handlers.Validators).Methods("GET")
-	router.HandleFunc("/validator/{idxOrPubKey}", handlers.Validator).Methods("GET")
-	router.HandleFunc("/validator/{index}/slots", handlers.ValidatorSlots).Methods("GET")
-
-	if utils.Config.Frontend.Pprof {
-		// add pprof handler
+	if groups[routeGroupApi] {
+		router.HandleFunc("/index/data", handlers.IndexData).Methods("GET")
+		router.HandleFunc("/slots/filtered/data", handlers.SlotsFilteredData).Methods("GET")
+		router.HandleFunc("/slots/pending/data", handlers.SlotsPendingData).Methods("GET")
+		router.HandleFunc("/validators/uptime/data", handlers.ValidatorsUptimeData).Methods("GET")
+		router.HandleFunc("/validators/committees/data", handlers.ValidatorsCommitteesData).Methods("GET")
+		router.HandleFunc("/validators/lifecycle/data", handlers.ValidatorsLifecycleData).Methods("GET")
+		router.HandleFunc("/validators/projection/data", handlers.ValidatorsProjectionData).Methods("GET")
+		router.HandleFunc("/epochs/daily/data", handlers.DailyStatsData).Methods("GET")
+		router.HandleFunc("/validators/fee_recipients/data", handlers.FeeRecipientsData).Methods("GET")
+		router.HandleFunc("/validators/operator/data", handlers.ValidatorsOperatorData).Methods("GET")
+		router.HandleFunc("/validators/withdrawal_addresses/data", handlers.WithdrawalAddressesData).Methods("GET")
+		router.HandleFunc("/validators/consolidations/data", handlers.ValidatorsConsolidationsData).Methods("GET")
+		if len(utils.Config.Frontend.ActivityApiTokens) > 0 {
+			router.HandleFunc("/validators/activity/ws", handlers.ValidatorActivityWs).Methods("GET")
+		}
+		if utils.Config.Frontend.NameClaimsEnabled {
+			router.HandleFunc("/validators/name_claims/submit", handlers.NameClaimsSubmit).Methods("POST")
+		}
+		if len(utils.Config.Frontend.AnnotationTokens) > 0 {
+			router.HandleFunc("/annotations/submit", handlers.AnnotationsSubmit).Methods("POST")
+		}
+		if utils.Config.Frontend.CheckpointApiEnabled {
+			router.HandleFunc("/api/v1/checkpoint", handlers.Checkpoint).Methods("GET")
+		}
+		if utils.Config.Frontend.OverviewApiEnabled {
+			router.HandleFunc("/api/v1/overview", handlers.Overview).Methods("GET")
+		}
+		if utils.Config.Frontend.ProofApiEnabled {
+			router.HandleFunc("/api/v1/proof/block/{root}", handlers.BlockProof).Methods("GET")
+			router.HandleFunc("/api/v1/proof/block/{root}/{operation:deposit|withdrawal}/{index}", handlers.OperationProof).Methods("GET")
+		}
+		if utils.Config.Frontend.AttestationExportEnabled {
+			router.HandleFunc("/epoch/{epoch}/attestations/csv", handlers.EpochAttestationExport).Methods("GET")
+		}
+		if utils.Config.Federation.ServeArchiveApi {
+			router.HandleFunc("/api/archive/epochs", handlers.ArchiveEpochs).Methods("GET")
+			router.HandleFunc("/api/archive/blocks", handlers.ArchiveBlocks).Methods("GET")
+		}
+	}
+
+	if groups[routeGroupMetrics] {
+		router.HandleFunc("/debug/db", handlers.DebugDbStats).Methods("GET")
+		if len(utils.Config.Frontend.IndexerStateTokens) > 0 {
+			router.HandleFunc("/debug/indexer", handlers.DebugIndexerState).Methods("GET")
+		}
+		router.HandleFunc("/debug/bls", handlers.DebugBlsSpotChecks).Methods("GET")
+		router.HandleFunc("/debug/beaconroots", handlers.DebugBeaconRootChecks).Methods("GET")
+		router.HandleFunc("/debug/crashes", handlers.DebugCrashes).Methods("GET")
+	}
+	if groups[routeGroupPprof] {
+		// add pprof handler & runtime diagnostics
+		router.HandleFunc("/debug/runtime", handlers.DebugRuntime).Methods("GET")
+		router.HandleFunc("/debug/runtime/data", handlers.DebugRuntimeData).Methods("GET")
+		router.HandleFunc("/debug/rpc", handlers.DebugRpc).Methods("GET")
+		router.HandleFunc("/debug/rpc/data", handlers.DebugRpcData).Methods("GET")
+		// re-executing blocks loads full states from the beacon nodes, so it's limited to the operator listener
+		router.HandleFunc("/slot/{root}/transition", handlers.SlotTransition).Methods("GET")
 		router.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux)
 	}
 
-	if utils.Config.Frontend.Debug {
-		// serve files from local directory when debugging, instead of from go embed file
-		templatesHandler := http.FileServer(http.Dir("templates"))
-		router.PathPrefix("/templates").Handler(http.StripPrefix("/templates/", templatesHandler))
+	if groups[routeGroupFrontend] {
+		router.HandleFunc("/", handlers.Index).Methods("GET")
+		router.HandleFunc("/index", handlers.Index).Methods("GET")
+		router.HandleFunc("/clients", handlers.Clients).Methods("GET")
+		router.HandleFunc("/forks", handlers.Forks).Methods("GET")
+		router.HandleFunc("/epochs", handlers.Epochs).Methods("GET")
+		router.HandleFunc("/epochs/filtered", handlers.EpochsFiltered).Methods("GET")
+		router.HandleFunc("/epochs/daily", handlers.DailyStats).Methods("GET")
+		router.HandleFunc("/epochs/finality", handlers.Finality).Methods("GET")
+		router.HandleFunc("/epoch/{epoch}", handlers.Epoch).Methods("GET")
+		router.HandleFunc("/slots", handlers.Slots).Methods("GET")
+		router.HandleFunc("/slots/filtered", handlers.SlotsFiltered).Methods("GET")
+		router.HandleFunc("/slots/graffiti/{query}", handlers.SlotsGraffiti).Methods("GET")
+		router.HandleFunc("/blobs/gas", handlers.BlobGas).Methods("GET")
+		router.HandleFunc("/slots/witnesses", handlers.Witnesses).Methods("GET")
+		router.HandleFunc("/roots", handlers.Roots).Methods("GET")
+		if len(utils.Config.Frontend.AnnotationTokens) > 0 {
+			router.HandleFunc("/annotations", handlers.Annotations).Methods("GET", "POST")
+		}
+		router.HandleFunc("/slot/{slotOrHash}", handlers.Slot).Methods("GET")
+		router.HandleFunc("/slot/{root}/blob/{commitment}", handlers.SlotBlob).Methods("GET")
+		router.HandleFunc("/slot/{root}/raw", handlers.SlotRaw).Methods("GET")
+		router.HandleFunc("/slot/{slot:[0-9]+}/{rootPrefix:(?:0x)?[0-9a-fA-F]+}", handlers.SlotPermalink).Methods("GET")
+		router.HandleFunc("/search", handlers.Search).Methods("GET")
+		router.HandleFunc("/search/{type}", handlers.SearchAhead).Methods("GET")
+		router.HandleFunc("/validators", handlers.Validators).Methods("GET")
+		router.HandleFunc("/validators/credentials", handlers.WithdrawalCredentials).Methods("GET")
+		router.HandleFunc("/validators/uptime", handlers.ValidatorsUptime).Methods("GET")
+		router.HandleFunc("/validators/committees", handlers.ValidatorsCommittees).Methods("GET")
+		router.HandleFunc("/validators/lifecycle", handlers.ValidatorsLifecycle).Methods("GET")
+		router.HandleFunc("/validators/projection", handlers.ValidatorsProjection).Methods("GET")
+		router.HandleFunc("/validators/fee_recipients", handlers.FeeRecipients).Methods("GET")
+		router.HandleFunc("/validators/deposit_receipts", handlers.DepositReceipts).Methods("GET")
+		if len(utils.Config.Frontend.ValidatorClients) > 0 {
+			router.HandleFunc("/validators/operator", handlers.ValidatorsOperator).Methods("GET")
+		}
+		if utils.Config.Frontend.NameClaimsEnabled {
+			router.HandleFunc("/validators/name_claims", handlers.NameClaims).Methods("GET", "POST")
+			router.HandleFunc("/validators/name_claims/admin", handlers.NameClaimsAdmin).Methods("GET", "POST")
+		}
+		router.HandleFunc("/validators/withdrawal_addresses", handlers.WithdrawalAddresses).Methods("GET")
+		router.HandleFunc("/validators/consolidations", handlers.ValidatorsConsolidations).Methods("GET")
+		router.HandleFunc("/validators/proposer_rewards", handlers.ProposerRewards).Methods("GET")
+		router.HandleFunc("/validators/metadata/{key}", handlers.ValidatorMetadataGroups).Methods("GET")
+		router.HandleFunc("/validators/consolidation_requests", handlers.ConsolidationRequests).Methods("GET")
+		router.HandleFunc("/validator/{idxOrPubKey}", handlers.Validator).Methods("GET")
+		router.HandleFunc("/validator/{index}/slots", handlers.ValidatorSlots).Methods("GET")
+		router.HandleFunc("/validator/{index}/cluster", handlers.ValidatorCluster).Methods("GET")
+		router.HandleFunc("/validator/{index}/names", handlers.ValidatorNames).Methods("GET")
 
-		cssHandler := http.FileServer(http.Dir("static/css"))
-		router.PathPrefix("/css").Handler(http.StripPrefix("/css/", cssHandler))
+		if utils.Config.Frontend.Debug {
+			// serve files from local directory when debugging, instead of from go embed file
+			templatesHandler := http.FileServer(http.Dir("templates"))
+			router.PathPrefix("/templates").Handler(http.StripPrefix("/templates/", templatesHandler))
 
-		jsHandler := http.FileServer(http.Dir("static/js"))
-		router.PathPrefix("/js").Handler(http.StripPrefix("/js/", jsHandler))
-	}
+			cssHandler := http.FileServer(http.Dir("static/css"))
+			router.PathPrefix("/css").Handler(http.StripPrefix("/css/", cssHandler))
 
-	fileSys := http.FS(static.Files)
-	router.PathPrefix("/").Handler(handlers.CustomFileServer(http.FileServer(fileSys), fileSys, handlers.NotFound))
+			jsHandler := http.FileServer(http.Dir("static/js"))
+			router.PathPrefix("/js").Handler(http.StripPrefix("/js/", jsHandler))
+		}
+
+		fileSys := http.FS(static.Files)
+		router.PathPrefix("/").Handler(handlers.CustomFileServer(http.FileServer(fileSys), fileSys, handlers.NotFound))
+	}
 
 	n := negroni.New()
-	n.Use(negroni.NewRecovery())
+	n.Use(negroni.HandlerFunc(handlers.RequestIdMiddleware))
+	n.Use(negroni.HandlerFunc(handlers.RecoveryMiddleware))
 	//n.Use(gzip.Gzip(gzip.DefaultCompression))
 	n.UseHandler(router)
 
-	if utils.Config.Frontend.HttpWriteTimeout == 0 {
-		utils.Config.Frontend.HttpWriteTimeout = time.Second * 15
-	}
-	if utils.Config.Frontend.HttpReadTimeout == 0 {
-		utils.Config.Frontend.HttpReadTimeout = time.Second * 15
+	if groups[routeGroupApi] && utils.Config.Frontend.EventStreamEnabled {
+		// the event stream lifts the write timeout of the http server, which is not possible through the
+		// response writer of negroni, so it's served in front of it with the request id & recovery middlewares only
+		streamRouter := mux.NewRouter()
+		streamRouter.HandleFunc("/api/v1/events", func(w http.ResponseWriter, r *http.Request) {
+			handlers.RequestIdMiddleware(w, r, func(w http.ResponseWriter, r *http.Request) {
+				handlers.RecoveryMiddleware(w, r, handlers.EventStream)
+			})
+		}).Methods("GET")
+		streamRouter.NotFoundHandler = n
+		streamRouter.MethodNotAllowedHandler = n
+		return streamRouter
 	}
-	if utils.Config.Frontend.HttpIdleTimeout == 0 {
-		utils.Config.Frontend.HttpIdleTimeout = time.Second * 60
-	}
-	srv := &http.Server{
-		Addr:         utils.Config.Server.Host + ":" + utils.Config.Server.Port,
-		WriteTimeout: utils.Config.Frontend.HttpWriteTimeout,
-		ReadTimeout:  utils.Config.Frontend.HttpReadTimeout,
-		IdleTimeout:  utils.Config.Frontend.HttpIdleTimeout,
-		Handler:      n,
-	}
-
-	logger.Printf("http server listening on %v", srv.Addr)
-	go func() {
-		if err := srv.ListenAndServe(); err != nil {
-			logger.WithError(err).Fatal("Error serving frontend")
-		}
-	}()
+	return n
 }
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/static"
+	"github.com/pk910/dora/utils"
+)
+
+// snapshotManifest is written to the snapshot root and describes its content
+type snapshotManifest struct {
+	ChainName   string    `json:"chain_name"`
+	GenesisTime uint64    `json:"genesis_time"`
+	FirstEpoch  uint64    `json:"first_epoch"`
+	LastEpoch   uint64    `json:"last_epoch"`
+	Created     time.Time `json:"created"`
+	PageCount   uint64    `json:"page_count"`
+	FailedPages []string  `json:"failed_pages,omitempty"`
+}
+
+type snapshotWriter struct {
+	outDir   string
+	router   http.Handler
+	manifest *snapshotManifest
+}
+
+// exportSnapshot renders the index, epoch & slot pages of a finalized epoch range into static html files.
+// Each page is written to <path>/index.html, so the snapshot can be served by any static web server
+// from the web root and the absolute links between the pages keep working.
+// The epoch & block rows are written as json next to the pages.
+func exportSnapshot(outDir string, firstEpoch uint64, lastEpoch uint64) error {
+	// wait for the clients to report the finalization checkpoints, otherwise all pages show the slots as unfinalized
+	for i := 0; i < 60; i++ {
+		if finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch(); finalizedEpoch >= 0 {
+			break
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	writer := &snapshotWriter{
+		outDir: outDir,
+		router: buildRouter(map[string]bool{routeGroupFrontend: true}),
+		manifest: &snapshotManifest{
+			ChainName:   utils.Config.Chain.Name,
+			GenesisTime: utils.Config.Chain.GenesisTimestamp,
+			FirstEpoch:  firstEpoch,
+			LastEpoch:   lastEpoch,
+			Created:     time.Now(),
+		},
+	}
+	if err := writer.copyStaticFiles(); err != nil {
+		return err
+	}
+
+	slotsPerEpoch := utils.Config.Chain.Config.SlotsPerEpoch
+	writer.renderPage("/", "index.html")
+	writer.renderPage(fmt.Sprintf("/epochs?epoch=%v&count=100", lastEpoch), "epochs/index.html")
+	writer.renderPage(fmt.Sprintf("/slots?s=%v&c=100", (lastEpoch+1)*slotsPerEpoch-1), "slots/index.html")
+
+	epochs := db.GetEpochs(lastEpoch, uint32(lastEpoch-firstEpoch+1))
+	for _, epoch := range epochs {
+		if epoch.Epoch < firstEpoch {
+			continue
+		}
+		writer.renderPage(fmt.Sprintf("/epoch/%v", epoch.Epoch), fmt.Sprintf("epoch/%v/index.html", epoch.Epoch))
+	}
+
+	firstSlot := firstEpoch * slotsPerEpoch
+	lastSlot := (lastEpoch+1)*slotsPerEpoch - 1
+	blocks := db.GetBlocksForSlots(lastSlot, firstSlot, true)
+	blockSlots := map[uint64]bool{}
+	for _, block := range blocks {
+		// blocks are linked by slot & root, the pages of canonical blocks are written to both locations
+		rootPage := fmt.Sprintf("slot/0x%x/index.html", block.Root)
+		if !writer.renderPage(fmt.Sprintf("/slot/0x%x", block.Root), rootPage) || block.Orphaned == 1 {
+			continue
+		}
+		blockSlots[block.Slot] = true
+		if err := writer.copyFile(rootPage, fmt.Sprintf("slot/%v/index.html", block.Slot)); err != nil {
+			return err
+		}
+	}
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		if !blockSlots[slot] {
+			writer.renderPage(fmt.Sprintf("/slot/%v", slot), fmt.Sprintf("slot/%v/index.html", slot))
+		}
+	}
+
+	if err := writer.writeJson("data/epochs.json", epochs); err != nil {
+		return err
+	}
+	if err := writer.writeJson("data/blocks.json", blocks); err != nil {
+		return err
+	}
+	if err := writer.writeJson("snapshot.json", writer.manifest); err != nil {
+		return err
+	}
+	logger.Infof("snapshot export: rendered %v pages (%v failed)", writer.manifest.PageCount, len(writer.manifest.FailedPages))
+	return nil
+}
+
+// renderPage calls the frontend handler of the url and writes the response to the file, failed pages are skipped
+func (sw *snapshotWriter) renderPage(url string, fileName string) bool {
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	rsp := httptest.NewRecorder()
+	sw.router.ServeHTTP(rsp, req)
+	if rsp.Code != http.StatusOK {
+		logger.Warnf("snapshot export: could not render %v (status %v)", url, rsp.Code)
+		sw.manifest.FailedPages = append(sw.manifest.FailedPages, url)
+		return false
+	}
+	if err := sw.writeFile(fileName, rsp.Body.Bytes()); err != nil {
+		logger.Warnf("snapshot export: could not write %v: %v", fileName, err)
+		sw.manifest.FailedPages = append(sw.manifest.FailedPages, url)
+		return false
+	}
+	sw.manifest.PageCount++
+	return true
+}
+
+// copyStaticFiles writes the embedded css, js & font files
+func (sw *snapshotWriter) copyStaticFiles() error {
+	return fs.WalkDir(static.Files, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || path.Ext(filePath) == ".go" {
+			return err
+		}
+		data, err := static.Files.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		return sw.writeFile(filePath, data)
+	})
+}
+
+func (sw *snapshotWriter) copyFile(srcName string, dstName string) error {
+	data, err := os.ReadFile(filepath.Join(sw.outDir, filepath.FromSlash(srcName)))
+	if err != nil {
+		return fmt.Errorf("error reading %v: %v", srcName, err)
+	}
+	return sw.writeFile(dstName, data)
+}
+
+func (sw *snapshotWriter) writeJson(fileName string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error encoding %v: %v", fileName, err)
+	}
+	return sw.writeFile(fileName, data)
+}
+
+func (sw *snapshotWriter) writeFile(fileName string, data []byte) error {
+	filePath := filepath.Join(sw.outDir, filepath.FromSlash(fileName))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("error creating directory for %v: %v", fileName, err)
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("error writing %v: %v", fileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/pk910/dora/dbtypes"
+)
+
+func InsertAnnotation(annotation *dbtypes.Annotation, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`
+		INSERT INTO annotations (id, type, target, root, author, note, created)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		annotation.Id, annotation.Type, annotation.Target, annotation.Root, annotation.Author, annotation.Note, annotation.Created)
+	return err
+}
+
+func DeleteAnnotation(id uint64, tx *sqlx.Tx) error {
+	res, err := tx.Exec(`DELETE FROM annotations WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("annotation not found")
+	}
+	return nil
+}
+
+// GetAnnotations returns the annotations of the given types with a target in the range, oldest first
+func GetAnnotations(firstTarget uint64, lastTarget uint64, annotationTypes ...uint8) []*dbtypes.Annotation {
+	var sql strings.Builder
+	args := []any{firstTarget, lastTarget}
+	fmt.Fprint(&sql, `
+	SELECT id, type, target, root, author, note, created
+	FROM annotations
+	WHERE target >= $1 AND target <= $2`)
+	if len(annotationTypes) > 0 {
+		fmt.Fprint(&sql, ` AND type IN (`)
+		for idx, annotationType := range annotationTypes {
+			if idx > 0 {
+				fmt.Fprint(&sql, ", ")
+			}
+			args = append(args, annotationType)
+			fmt.Fprintf(&sql, "$%v", len(args))
+		}
+		fmt.Fprint(&sql, `)`)
+	}
+	fmt.Fprint(&sql, `
+	ORDER BY target ASC, created ASC`)
+
+	annotations := []*dbtypes.Annotation{}
+	err := ReaderDb.Select(&annotations, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching annotations: %v", err)
+		return nil
+	}
+	return annotations
+}
+
+// GetRecentAnnotations returns the latest annotations, newest first
+func GetRecentAnnotations(limit uint64) []*dbtypes.Annotation {
+	annotations := []*dbtypes.Annotation{}
+	err := ReaderDb.Select(&annotations, `
+	SELECT id, type, target, root, author, note, created
+	FROM annotations
+	ORDER BY created DESC
+	LIMIT $1
+	`, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching annotations: %v", err)
+		return nil
+	}
+	return annotations
+}
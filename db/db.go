@@ -67,14 +67,20 @@ func mustInitSqlite(config *types.SqliteDatabaseConfig) (*sqlx.DB, *sqlx.DB) {
 	}
 
 	logger.Infof("initializing sqlite connection to %v with %v/%v conn limit", config.File, config.MaxIdleConns, config.MaxOpenConns)
-	dbConn, err := sqlx.Open("sqlite3", fmt.Sprintf("%s?cache=shared", config.File))
+	dbFile := config.File
+	if strings.Contains(dbFile, "?") {
+		dbFile += "&cache=shared"
+	} else {
+		dbFile += "?cache=shared"
+	}
+	dbConn, err := openMetricsDb(sqliteDriverName, dbFile)
 	if err != nil {
 		utils.LogFatal(err, "error opening sqlite database", 0)
 	}
 
 	checkDbConn(dbConn, "database")
-	dbConn.SetConnMaxIdleTime(0)
-	dbConn.SetConnMaxLifetime(0)
+	dbConn.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+	dbConn.SetConnMaxLifetime(config.ConnMaxLifetime)
 	dbConn.SetMaxOpenConns(config.MaxOpenConns)
 	dbConn.SetMaxIdleConns(config.MaxIdleConns)
 
@@ -93,6 +99,12 @@ func mustInitPgsql(writer *types.PgsqlDatabaseConfig, reader *types.PgsqlDatabas
 	if writer.MaxOpenConns < writer.MaxIdleConns {
 		writer.MaxIdleConns = writer.MaxOpenConns
 	}
+	if writer.ConnMaxIdleTime == 0 {
+		writer.ConnMaxIdleTime = time.Second * 30
+	}
+	if writer.ConnMaxLifetime == 0 {
+		writer.ConnMaxLifetime = time.Second * 60
+	}
 
 	if reader.MaxOpenConns == 0 {
 		reader.MaxOpenConns = 50
@@ -103,28 +115,34 @@ func mustInitPgsql(writer *types.PgsqlDatabaseConfig, reader *types.PgsqlDatabas
 	if reader.MaxOpenConns < reader.MaxIdleConns {
 		reader.MaxIdleConns = reader.MaxOpenConns
 	}
+	if reader.ConnMaxIdleTime == 0 {
+		reader.ConnMaxIdleTime = time.Second * 30
+	}
+	if reader.ConnMaxLifetime == 0 {
+		reader.ConnMaxLifetime = time.Second * 60
+	}
 
 	logger.Infof("initializing pgsql writer connection to %v with %v/%v conn limit", writer.Host, writer.MaxIdleConns, writer.MaxOpenConns)
-	dbConnWriter, err := sqlx.Open("pgx", fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", writer.Username, writer.Password, writer.Host, writer.Port, writer.Name))
+	dbConnWriter, err := openMetricsDb("pgx", fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", writer.Username, writer.Password, writer.Host, writer.Port, writer.Name))
 	if err != nil {
 		utils.LogFatal(err, "error getting pgsql writer database", 0)
 	}
 
 	checkDbConn(dbConnWriter, "database")
-	dbConnWriter.SetConnMaxIdleTime(time.Second * 30)
-	dbConnWriter.SetConnMaxLifetime(time.Second * 60)
+	dbConnWriter.SetConnMaxIdleTime(writer.ConnMaxIdleTime)
+	dbConnWriter.SetConnMaxLifetime(writer.ConnMaxLifetime)
 	dbConnWriter.SetMaxOpenConns(writer.MaxOpenConns)
 	dbConnWriter.SetMaxIdleConns(writer.MaxIdleConns)
 
 	logger.Infof("initializing pgsql reader connection to %v with %v/%v conn limit", writer.Host, reader.MaxIdleConns, reader.MaxOpenConns)
-	dbConnReader, err := sqlx.Open("pgx", fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", reader.Username, reader.Password, reader.Host, reader.Port, reader.Name))
+	dbConnReader, err := openMetricsDb("pgx", fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", reader.Username, reader.Password, reader.Host, reader.Port, reader.Name))
 	if err != nil {
 		utils.LogFatal(err, "error getting pgsql reader database", 0)
 	}
 
 	checkDbConn(dbConnReader, "read replica database")
-	dbConnReader.SetConnMaxIdleTime(time.Second * 30)
-	dbConnReader.SetConnMaxLifetime(time.Second * 60)
+	dbConnReader.SetConnMaxIdleTime(reader.ConnMaxIdleTime)
+	dbConnReader.SetConnMaxLifetime(reader.ConnMaxLifetime)
 	dbConnReader.SetMaxOpenConns(reader.MaxOpenConns)
 	dbConnReader.SetMaxIdleConns(reader.MaxIdleConns)
 	return dbConnWriter, dbConnReader
@@ -143,6 +161,15 @@ func MustInitDB() {
 		}
 		DbEngine = dbtypes.DBEnginePgsql
 		WriterDb, ReaderDb = mustInitPgsql(writerConfig, readerConfig)
+	} else if utils.Config.Database.Engine == "memory" || (utils.Config.Database.Engine == "" && utils.Config.Indexer.DisableIndexWriter) {
+		// light mode: a throwaway in-memory sqlite db, so the db queries work but nothing is persisted.
+		// the shared cache keeps the db alive as long as one connection is open, so idle connections must not expire.
+		DbEngine = dbtypes.DBEngineSqlite
+		WriterDb, ReaderDb = mustInitSqlite(&types.SqliteDatabaseConfig{
+			File:         "file:dora-light-mode?mode=memory",
+			MaxOpenConns: 10,
+			MaxIdleConns: 10,
+		})
 	} else {
 		logger.Fatalf("unknown database engine type: %s", utils.Config.Database.Engine)
 	}
@@ -236,9 +263,32 @@ func SetExplorerState(key string, value interface{}, tx *sqlx.Tx) error {
 	return nil
 }
 
+// chainDataTables are all tables holding data of the indexed chain, cleared when the chain has been reset
+var chainDataTables = []string{
+	"blocks", "orphaned_blocks", "unfinalized_blocks", "unfinalized_epochs",
+	"epochs", "epoch_credential_stats", "epoch_target_votes", "consolidation_requests",
+	"slot_assignments", "sync_assignments", "validator_uptime",
+	"blobs", "blob_assignments", "watched_withdrawals", "slot_rewards", "blob_gas",
+	"archived_blocks", "block_arrivals", "block_witnesses", "slot_roots", "validator_vote_stats", "deposits", "validator_doppelgangers", "daily_stats",
+	"validator_status_changes", "deposit_receipts", "block_rewards", "annotations", "epoch_aggregation_stats", "validator_summary",
+	"epoch_committee_stats", "block_data_columns", "slot_committee_participation",
+	"finality_checkpoints", "validator_name_claims", "validator_name_history", "validator_metadata",
+	"explorer_state",
+}
+
+func ClearChainData(tx *sqlx.Tx) error {
+	for _, table := range chainDataTables {
+		_, err := tx.Exec(fmt.Sprintf("DELETE FROM %v", table))
+		if err != nil {
+			return fmt.Errorf("error clearing %v: %v", table, err)
+		}
+	}
+	return nil
+}
+
 func GetValidatorNames(minIdx uint64, maxIdx uint64, tx *sqlx.Tx) []*dbtypes.ValidatorName {
 	names := []*dbtypes.ValidatorName{}
-	err := ReaderDb.Select(&names, `SELECT "index", "name" FROM validator_names WHERE "index" >= $1 AND "index" <= $2`, minIdx, maxIdx)
+	err := ReaderDb.Select(&names, `SELECT "index", "name", "source", "source_name", "updated" FROM validator_names WHERE "index" >= $1 AND "index" <= $2`, minIdx, maxIdx)
 	if err != nil {
 		logger.Errorf("Error while fetching validator names: %v", err)
 		return nil
@@ -249,22 +299,25 @@ func GetValidatorNames(minIdx uint64, maxIdx uint64, tx *sqlx.Tx) []*dbtypes.Val
 func InsertValidatorNames(validatorNames []*dbtypes.ValidatorName, tx *sqlx.Tx) error {
 	var sql strings.Builder
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
-		dbtypes.DBEnginePgsql:  `INSERT INTO validator_names ("index", "name") VALUES `,
-		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO validator_names ("index", "name") VALUES `,
+		dbtypes.DBEnginePgsql:  `INSERT INTO validator_names ("index", "name", "source", "source_name", "updated") VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO validator_names ("index", "name", "source", "source_name", "updated") VALUES `,
 	}))
 	argIdx := 0
-	args := make([]any, len(validatorNames)*2)
+	args := make([]any, len(validatorNames)*5)
 	for i, validatorName := range validatorNames {
 		if i > 0 {
 			fmt.Fprintf(&sql, ", ")
 		}
-		fmt.Fprintf(&sql, "($%v, $%v)", argIdx+1, argIdx+2)
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5)
 		args[argIdx] = validatorName.Index
 		args[argIdx+1] = validatorName.Name
-		argIdx += 2
+		args[argIdx+2] = validatorName.Source
+		args[argIdx+3] = validatorName.SourceName
+		args[argIdx+4] = validatorName.Updated
+		argIdx += 5
 	}
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
-		dbtypes.DBEnginePgsql:  ` ON CONFLICT ("index") DO UPDATE SET name = excluded.name`,
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT ("index") DO UPDATE SET name = excluded.name, source = excluded.source, source_name = excluded.source_name, updated = excluded.updated`,
 		dbtypes.DBEngineSqlite: "",
 	}))
 	_, err := tx.Exec(sql.String(), args...)
@@ -295,6 +348,285 @@ func DeleteValidatorNames(validatorNames []uint64, tx *sqlx.Tx) error {
 	return nil
 }
 
+func InsertValidatorNameHistory(history []*dbtypes.ValidatorNameHistory, tx *sqlx.Tx) error {
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO validator_name_history ("index", "changed", "name", "source", "source_name") VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO validator_name_history ("index", "changed", "name", "source", "source_name") VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(history)*5)
+	for i, entry := range history {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5)
+		args[argIdx] = entry.Index
+		args[argIdx+1] = entry.Changed
+		args[argIdx+2] = entry.Name
+		args[argIdx+3] = entry.Source
+		args[argIdx+4] = entry.SourceName
+		argIdx += 5
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT ("index", "changed") DO UPDATE SET name = excluded.name, source = excluded.source, source_name = excluded.source_name`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetValidatorNameHistory returns the name changes of a validator, newest first
+func GetValidatorNameHistory(index uint64, offset uint64, limit uint32) ([]*dbtypes.ValidatorNameHistory, uint64) {
+	var totalCount uint64
+	err := ReaderDb.Get(&totalCount, `SELECT COUNT(*) FROM validator_name_history WHERE "index" = $1`, index)
+	if err != nil {
+		logger.Errorf("Error while counting validator name history: %v", err)
+		return nil, 0
+	}
+	history := []*dbtypes.ValidatorNameHistory{}
+	err = ReaderDb.Select(&history, `
+		SELECT "index", "changed", "name", "source", "source_name"
+		FROM validator_name_history
+		WHERE "index" = $1
+		ORDER BY "changed" DESC
+		LIMIT $2 OFFSET $3`, index, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching validator name history: %v", err)
+		return nil, 0
+	}
+	return history, totalCount
+}
+
+func GetValidatorMetadata(minIdx uint64, maxIdx uint64) []*dbtypes.ValidatorMetadata {
+	metadata := []*dbtypes.ValidatorMetadata{}
+	err := ReaderDb.Select(&metadata, `SELECT "index", "key", "value" FROM validator_metadata WHERE "index" >= $1 AND "index" <= $2 ORDER BY "index", "key"`, minIdx, maxIdx)
+	if err != nil {
+		logger.Errorf("Error while fetching validator metadata: %v", err)
+		return nil
+	}
+	return metadata
+}
+
+func InsertValidatorMetadata(metadata []*dbtypes.ValidatorMetadata, tx *sqlx.Tx) error {
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO validator_metadata ("index", "key", "value") VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO validator_metadata ("index", "key", "value") VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(metadata)*3)
+	for i, entry := range metadata {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3)
+		args[argIdx] = entry.Index
+		args[argIdx+1] = entry.Key
+		args[argIdx+2] = entry.Value
+		argIdx += 3
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT ("index", "key") DO UPDATE SET value = excluded.value`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeleteValidatorMetadata(minIdx uint64, maxIdx uint64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`DELETE FROM validator_metadata WHERE "index" >= $1 AND "index" <= $2`, minIdx, maxIdx)
+	return err
+}
+
+func InsertValidatorUptime(uptimes []*dbtypes.ValidatorUptime, tx *sqlx.Tx) error {
+	if len(uptimes) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO validator_uptime (epoch, name, day, duties, attested, correct_target, correct_head, late) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO validator_uptime (epoch, name, day, duties, attested, correct_target, correct_head, late) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(uptimes)*8)
+	for i, uptime := range uptimes {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7, argIdx+8)
+		args[argIdx] = uptime.Epoch
+		args[argIdx+1] = uptime.Name
+		args[argIdx+2] = uptime.Day
+		args[argIdx+3] = uptime.Duties
+		args[argIdx+4] = uptime.Attested
+		args[argIdx+5] = uptime.CorrectTarget
+		args[argIdx+6] = uptime.CorrectHead
+		args[argIdx+7] = uptime.Late
+		argIdx += 8
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (epoch, name) DO UPDATE SET day = excluded.day, duties = excluded.duties, attested = excluded.attested, correct_target = excluded.correct_target, correct_head = excluded.correct_head, late = excluded.late`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetValidatorUptimeDays(firstDay uint64, lastDay uint64) []*dbtypes.ValidatorUptimeDay {
+	uptimes := []*dbtypes.ValidatorUptimeDay{}
+	err := ReaderDb.Select(&uptimes, `
+	SELECT day, name, SUM(duties) AS duties, SUM(attested) AS attested, SUM(correct_target) AS correct_target, SUM(correct_head) AS correct_head
+	FROM validator_uptime
+	WHERE day >= $1 AND day <= $2
+	GROUP BY day, name
+	ORDER BY name ASC, day DESC
+	`, firstDay, lastDay)
+	if err != nil {
+		logger.Errorf("Error while fetching validator uptime: %v", err)
+		return nil
+	}
+	return uptimes
+}
+
+// GetOperatorVoteDelays returns the missing & late attestations per validator name within the epoch range
+func GetOperatorVoteDelays(firstEpoch uint64, lastEpoch uint64) []*dbtypes.OperatorVoteDelays {
+	delays := []*dbtypes.OperatorVoteDelays{}
+	err := ReaderDb.Select(&delays, `
+	SELECT name, SUM(duties) AS duties, SUM(attested) AS attested, SUM(late) AS late
+	FROM validator_uptime
+	WHERE epoch >= $1 AND epoch <= $2
+	GROUP BY name
+	`, firstEpoch, lastEpoch)
+	if err != nil {
+		logger.Errorf("Error while fetching operator vote delays: %v", err)
+		return nil
+	}
+	return delays
+}
+
+// InsertValidatorVoteStats adds the counters of an epoch to the per validator totals.
+// epochs that have already been counted for a validator are skipped, so resyncing an epoch does not count it twice.
+func InsertValidatorVoteStats(voteStats []*dbtypes.ValidatorVoteStats, tx *sqlx.Tx) error {
+	if len(voteStats) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, `INSERT INTO validator_vote_stats (validator_index, first_epoch, last_epoch, duties, attested, correct_target, correct_head) VALUES `)
+	argIdx := 0
+	args := make([]any, len(voteStats)*7)
+	for i, stats := range voteStats {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7)
+		args[argIdx] = stats.ValidatorIndex
+		args[argIdx+1] = stats.FirstEpoch
+		args[argIdx+2] = stats.LastEpoch
+		args[argIdx+3] = stats.Duties
+		args[argIdx+4] = stats.Attested
+		args[argIdx+5] = stats.CorrectTarget
+		args[argIdx+6] = stats.CorrectHead
+		argIdx += 7
+	}
+	fmt.Fprint(&sql, ` ON CONFLICT (validator_index) DO UPDATE SET
+		last_epoch = excluded.last_epoch,
+		duties = validator_vote_stats.duties + excluded.duties,
+		attested = validator_vote_stats.attested + excluded.attested,
+		correct_target = validator_vote_stats.correct_target + excluded.correct_target,
+		correct_head = validator_vote_stats.correct_head + excluded.correct_head
+	WHERE validator_vote_stats.last_epoch < excluded.last_epoch`)
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetValidatorVoteStats(validatorIndex uint64) *dbtypes.ValidatorVoteStats {
+	voteStats := dbtypes.ValidatorVoteStats{}
+	err := ReaderDb.Get(&voteStats, `
+	SELECT validator_index, first_epoch, last_epoch, duties, attested, correct_target, correct_head
+	FROM validator_vote_stats
+	WHERE validator_index = $1
+	`, validatorIndex)
+	if err != nil {
+		return nil
+	}
+	return &voteStats
+}
+
+// InsertValidatorSummaries adds the proposals, withdrawals & attestations of an epoch to the per validator summary.
+// like the vote stats, rows that already include the epoch are left untouched.
+func InsertValidatorSummaries(summaries []*dbtypes.ValidatorSummary, tx *sqlx.Tx) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, `INSERT INTO validator_summary (validator_index, last_epoch, proposed_blocks, missed_blocks, last_attestation_epoch, withdrawal_count, withdrawn_amount) VALUES `)
+	argIdx := 0
+	args := make([]any, len(summaries)*7)
+	for i, summary := range summaries {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7)
+		args[argIdx] = summary.ValidatorIndex
+		args[argIdx+1] = summary.LastEpoch
+		args[argIdx+2] = summary.ProposedBlocks
+		args[argIdx+3] = summary.MissedBlocks
+		args[argIdx+4] = summary.LastAttestationEpoch
+		args[argIdx+5] = summary.WithdrawalCount
+		args[argIdx+6] = summary.WithdrawnAmount
+		argIdx += 7
+	}
+	fmt.Fprint(&sql, ` ON CONFLICT (validator_index) DO UPDATE SET
+		last_epoch = excluded.last_epoch,
+		proposed_blocks = validator_summary.proposed_blocks + excluded.proposed_blocks,
+		missed_blocks = validator_summary.missed_blocks + excluded.missed_blocks,
+		last_attestation_epoch = COALESCE(excluded.last_attestation_epoch, validator_summary.last_attestation_epoch),
+		withdrawal_count = validator_summary.withdrawal_count + excluded.withdrawal_count,
+		withdrawn_amount = validator_summary.withdrawn_amount + excluded.withdrawn_amount
+	WHERE validator_summary.last_epoch < excluded.last_epoch`)
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddValidatorSummaryIncome adds the consensus rewards of a proposed block to the proposer summary.
+// the block rewards are processed after the epoch, so these are not bound to the epoch guard of the summary.
+func AddValidatorSummaryIncome(validatorIndex uint64, amount uint64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`
+	INSERT INTO validator_summary (validator_index, last_epoch, proposer_income) VALUES ($1, 0, $2)
+	ON CONFLICT (validator_index) DO UPDATE SET proposer_income = validator_summary.proposer_income + excluded.proposer_income
+	`, validatorIndex, amount)
+	return err
+}
+
+func GetValidatorSummary(validatorIndex uint64) *dbtypes.ValidatorSummary {
+	summary := dbtypes.ValidatorSummary{}
+	err := ReaderDb.Get(&summary, `
+	SELECT validator_index, last_epoch, proposed_blocks, missed_blocks, last_attestation_epoch, withdrawal_count, withdrawn_amount, proposer_income
+	FROM validator_summary
+	WHERE validator_index = $1
+	`, validatorIndex)
+	if err != nil {
+		return nil
+	}
+	return &summary
+}
+
 func IsEpochSynchronized(epoch uint64) bool {
 	var count uint64
 	err := ReaderDb.Get(&count, `SELECT COUNT(*) FROM epochs WHERE epoch = $1`, epoch)
@@ -376,20 +708,22 @@ func InsertBlock(block *dbtypes.Block, tx *sqlx.Tx) error {
 			INSERT INTO blocks (
 				root, slot, parent_root, state_root, orphaned, proposer, graffiti, graffiti_text,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, sync_participation
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, eth_fee_recipient, eth_extra_data, cl_client, el_client, proposer_dependent_root, sync_participation, body_root
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
 			ON CONFLICT (root) DO UPDATE SET
-				orphaned = excluded.orphaned`,
+				orphaned = excluded.orphaned,
+				proposer_dependent_root = COALESCE(excluded.proposer_dependent_root, blocks.proposer_dependent_root),
+				body_root = COALESCE(excluded.body_root, blocks.body_root)`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR REPLACE INTO blocks (
 				root, slot, parent_root, state_root, orphaned, proposer, graffiti, graffiti_text,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, sync_participation
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`,
+				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, eth_fee_recipient, eth_extra_data, cl_client, el_client, proposer_dependent_root, sync_participation, body_root
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)`,
 	}),
 		block.Root, block.Slot, block.ParentRoot, block.StateRoot, block.Orphaned, block.Proposer, block.Graffiti, block.GraffitiText,
 		block.AttestationCount, block.DepositCount, block.ExitCount, block.WithdrawCount, block.WithdrawAmount, block.AttesterSlashingCount,
-		block.ProposerSlashingCount, block.BLSChangeCount, block.EthTransactionCount, block.EthBlockNumber, block.EthBlockHash, block.SyncParticipation)
+		block.ProposerSlashingCount, block.BLSChangeCount, block.EthTransactionCount, block.EthBlockNumber, block.EthBlockHash, block.EthFeeRecipient, block.EthExtraData, block.ClClient, block.ElClient, block.ProposerDependentRoot, block.SyncParticipation, block.BodyRoot)
 	if err != nil {
 		return err
 	}
@@ -402,8 +736,9 @@ func InsertEpoch(epoch *dbtypes.Epoch, tx *sqlx.Tx) error {
 			INSERT INTO epochs (
 				epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-				proposer_slashing_count, bls_change_count, eth_transaction_count, sync_participation
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+				proposer_slashing_count, bls_change_count, eth_transaction_count, blob_count, sync_participation, partial,
+				tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
 			ON CONFLICT (epoch) DO UPDATE SET
 				validator_count = excluded.validator_count,
 				validator_balance = excluded.validator_balance,
@@ -422,17 +757,26 @@ func InsertEpoch(epoch *dbtypes.Epoch, tx *sqlx.Tx) error {
 				proposer_slashing_count = excluded.proposer_slashing_count, 
 				bls_change_count = excluded.bls_change_count, 
 				eth_transaction_count = excluded.eth_transaction_count, 
-				sync_participation = excluded.sync_participation`,
+				blob_count = excluded.blob_count, 
+				sync_participation = excluded.sync_participation,
+				partial = excluded.partial,
+				tx_legacy_count = excluded.tx_legacy_count,
+				tx_access_list_count = excluded.tx_access_list_count,
+				tx_dynamic_fee_count = excluded.tx_dynamic_fee_count,
+				tx_blob_count = excluded.tx_blob_count,
+				tx_setcode_count = excluded.tx_setcode_count`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR REPLACE INTO epochs (
 				epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-				proposer_slashing_count, bls_change_count, eth_transaction_count, sync_participation
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+				proposer_slashing_count, bls_change_count, eth_transaction_count, blob_count, sync_participation, partial,
+				tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)`,
 	}),
 		epoch.Epoch, epoch.ValidatorCount, epoch.ValidatorBalance, epoch.Eligible, epoch.VotedTarget, epoch.VotedHead, epoch.VotedTotal, epoch.BlockCount, epoch.OrphanedCount,
 		epoch.AttestationCount, epoch.DepositCount, epoch.ExitCount, epoch.WithdrawCount, epoch.WithdrawAmount, epoch.AttesterSlashingCount, epoch.ProposerSlashingCount,
-		epoch.BLSChangeCount, epoch.EthTransactionCount, epoch.SyncParticipation)
+		epoch.BLSChangeCount, epoch.EthTransactionCount, epoch.BlobCount, epoch.SyncParticipation, epoch.Partial,
+		epoch.TxLegacyCount, epoch.TxAccessListCount, epoch.TxDynamicFeeCount, epoch.TxBlobCount, epoch.TxSetCodeCount)
 	if err != nil {
 		return err
 	}
@@ -447,7 +791,7 @@ func InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx *sqlx.Tx) error {
 			) VALUES ($1, $2, $3, $4, $5)
 			ON CONFLICT (root) DO NOTHING`,
 		dbtypes.DBEngineSqlite: `
-			INSERT OR IGNORE orphaned_blocks (
+			INSERT OR IGNORE INTO orphaned_blocks (
 				root, header_ver, header_ssz, block_ver, block_ssz
 			) VALUES ($1, $2, $3, $4, $5)`,
 	}),
@@ -468,26 +812,1020 @@ func GetOrphanedBlock(root []byte) *dbtypes.OrphanedBlock {
 	if err != nil {
 		return nil
 	}
-	return &block
+	return &block
+}
+
+func InsertArchivedBlock(block *dbtypes.ArchivedBlock, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO archived_blocks (
+				root, slot, header_ver, header_ssz, block_ver, block_ssz
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (root) DO NOTHING`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR IGNORE INTO archived_blocks (
+				root, slot, header_ver, header_ssz, block_ver, block_ssz
+			) VALUES ($1, $2, $3, $4, $5, $6)`,
+	}),
+		block.Root, block.Slot, block.HeaderVer, block.HeaderSSZ, block.BlockVer, block.BlockSSZ)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetArchivedBlock(root []byte) *dbtypes.ArchivedBlock {
+	block := dbtypes.ArchivedBlock{}
+	err := ReaderDb.Get(&block, `
+	SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz
+	FROM archived_blocks
+	WHERE root = $1
+	`, root)
+	if err != nil {
+		return nil
+	}
+	return &block
+}
+
+func GetArchivedBlockBySlot(slot uint64) *dbtypes.ArchivedBlock {
+	block := dbtypes.ArchivedBlock{}
+	err := ReaderDb.Get(&block, `
+	SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz
+	FROM archived_blocks
+	WHERE slot = $1
+	LIMIT 1
+	`, slot)
+	if err != nil {
+		return nil
+	}
+	return &block
+}
+
+func GetEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch {
+	epochs := []*dbtypes.Epoch{}
+	err := ReaderDb.Select(&epochs, `
+	SELECT
+		epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
+		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count,
+		proposer_slashing_count, bls_change_count, eth_transaction_count, blob_count, sync_participation, partial,
+		tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+	FROM epochs
+	WHERE epoch <= $1
+	ORDER BY epoch DESC
+	LIMIT $2
+	`, firstEpoch, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching epochs: %v", err)
+		return nil
+	}
+	return epochs
+}
+
+// GetFilteredEpochs returns the epochs below firstEpoch that match the filter, newest first.
+// All epochs in the db are finalized, so nothing is returned when filtering for unfinalized epochs.
+func GetFilteredEpochs(filter *dbtypes.EpochFilter, firstEpoch uint64, offset uint64, limit uint32) []*dbtypes.Epoch {
+	epochs := []*dbtypes.Epoch{}
+	if filter.Finalized == 2 {
+		return epochs
+	}
+
+	var sql strings.Builder
+	fmt.Fprintf(&sql, `
+	SELECT
+		epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
+		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count,
+		proposer_slashing_count, bls_change_count, eth_transaction_count, blob_count, sync_participation, partial,
+		tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+	FROM epochs
+	WHERE epoch < $1 `)
+	argIdx := 1
+	args := []any{firstEpoch}
+
+	if filter.MinEpoch != nil {
+		argIdx++
+		fmt.Fprintf(&sql, ` AND epoch >= $%v `, argIdx)
+		args = append(args, *filter.MinEpoch)
+	}
+	if filter.MaxEpoch != nil {
+		argIdx++
+		fmt.Fprintf(&sql, ` AND epoch <= $%v `, argIdx)
+		args = append(args, *filter.MaxEpoch)
+	}
+	if filter.WithOrphaned == 0 {
+		fmt.Fprintf(&sql, ` AND orphaned_count = 0 `)
+	} else if filter.WithOrphaned == 2 {
+		fmt.Fprintf(&sql, ` AND orphaned_count > 0 `)
+	}
+	// participation is compared in basis points, so the expression index on the epochs table can be used
+	if filter.MinParticipation != nil {
+		argIdx++
+		fmt.Fprintf(&sql, ` AND voted_target * 10000 / NULLIF(eligible, 0) >= $%v `, argIdx)
+		args = append(args, uint64(*filter.MinParticipation*100))
+	}
+	if filter.MaxParticipation != nil {
+		argIdx++
+		fmt.Fprintf(&sql, ` AND voted_target * 10000 / NULLIF(eligible, 0) <= $%v `, argIdx)
+		args = append(args, uint64(*filter.MaxParticipation*100))
+	}
+
+	fmt.Fprintf(&sql, ` ORDER BY epoch DESC LIMIT $%v OFFSET $%v `, argIdx+1, argIdx+2)
+	args = append(args, limit, offset)
+
+	err := ReaderDb.Select(&epochs, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching filtered epochs: %v", err)
+		return nil
+	}
+	return epochs
+}
+
+// GetLowestEpoch returns the lowest epoch in the db, or -1 if there are no epochs yet
+func GetLowestEpoch() int64 {
+	epochs := []uint64{}
+	err := ReaderDb.Select(&epochs, `SELECT epoch FROM epochs ORDER BY epoch ASC LIMIT 1`)
+	if err != nil {
+		logger.Errorf("Error while fetching lowest epoch: %v", err)
+		return -1
+	}
+	if len(epochs) == 0 {
+		return -1
+	}
+	return int64(epochs[0])
+}
+
+// GetPartialEpochs returns the epochs below maxEpoch that have been persisted with missing validator stats or duties, newest first
+func GetPartialEpochs(maxEpoch uint64, limit uint32) []*dbtypes.Epoch {
+	epochs := []*dbtypes.Epoch{}
+	err := ReaderDb.Select(&epochs, `
+	SELECT
+		epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
+		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count,
+		proposer_slashing_count, bls_change_count, eth_transaction_count, blob_count, sync_participation, partial,
+		tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+	FROM epochs
+	WHERE partial > 0 AND epoch < $1
+	ORDER BY epoch DESC
+	LIMIT $2
+	`, maxEpoch, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching partial epochs: %v", err)
+		return nil
+	}
+	return epochs
+}
+
+func InsertEpochCredentialStats(stats *dbtypes.EpochCredentialStats, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO epoch_credential_stats (
+				epoch, bls_count, execution_count, compounding_count, other_count
+			) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (epoch) DO UPDATE SET
+				bls_count = excluded.bls_count,
+				execution_count = excluded.execution_count,
+				compounding_count = excluded.compounding_count,
+				other_count = excluded.other_count`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO epoch_credential_stats (
+				epoch, bls_count, execution_count, compounding_count, other_count
+			) VALUES ($1, $2, $3, $4, $5)`,
+	}),
+		stats.Epoch, stats.BlsCount, stats.ExecutionCount, stats.CompoundingCount, stats.OtherCount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func InsertWatchedWithdrawals(withdrawals []*dbtypes.WatchedWithdrawal, tx *sqlx.Tx) error {
+	if len(withdrawals) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO watched_withdrawals (address, epoch, count, amount) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO watched_withdrawals (address, epoch, count, amount) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(withdrawals)*4)
+	for i, withdrawal := range withdrawals {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4)
+		args[argIdx] = withdrawal.Address
+		args[argIdx+1] = withdrawal.Epoch
+		args[argIdx+2] = withdrawal.Count
+		args[argIdx+3] = withdrawal.Amount
+		argIdx += 4
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (address, epoch) DO UPDATE SET count = excluded.count, amount = excluded.amount`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetWatchedWithdrawalStats() []*dbtypes.WatchedWithdrawalStats {
+	stats := []*dbtypes.WatchedWithdrawalStats{}
+	err := ReaderDb.Select(&stats, `
+	SELECT
+		address, CAST(SUM(count) AS bigint) AS count, CAST(SUM(amount) AS bigint) AS amount,
+		MIN(epoch) AS first_epoch, MAX(epoch) AS last_epoch
+	FROM watched_withdrawals
+	GROUP BY address
+	`)
+	if err != nil {
+		logger.Errorf("Error while fetching watched withdrawal stats: %v", err)
+		return nil
+	}
+	return stats
+}
+
+func InsertEpochTargetVotes(targetVotes []*dbtypes.EpochTargetVote, tx *sqlx.Tx) error {
+	if len(targetVotes) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO epoch_target_votes (epoch, target_root, vote_amount) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO epoch_target_votes (epoch, target_root, vote_amount) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(targetVotes)*3)
+	for i, targetVote := range targetVotes {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3)
+		args[argIdx] = targetVote.Epoch
+		args[argIdx+1] = targetVote.TargetRoot
+		args[argIdx+2] = targetVote.VoteAmount
+		argIdx += 3
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (epoch, target_root) DO UPDATE SET vote_amount = excluded.vote_amount`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetEpochTargetVotes(firstEpoch uint64, lastEpoch uint64) []*dbtypes.EpochTargetVote {
+	targetVotes := []*dbtypes.EpochTargetVote{}
+	err := ReaderDb.Select(&targetVotes, `
+	SELECT
+		epoch, target_root, vote_amount
+	FROM epoch_target_votes
+	WHERE epoch >= $1 AND epoch <= $2
+	ORDER BY epoch DESC, vote_amount DESC
+	`, firstEpoch, lastEpoch)
+	if err != nil {
+		logger.Errorf("Error while fetching epoch target votes: %v", err)
+		return nil
+	}
+	return targetVotes
+}
+
+func InsertEpochAggregationStats(stats *dbtypes.EpochAggregationStats, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO epoch_aggregation_stats (
+				epoch, aggregate_count, committee_count, redundant_count, vote_count, duplicate_vote_count
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (epoch) DO UPDATE SET
+				aggregate_count = excluded.aggregate_count,
+				committee_count = excluded.committee_count,
+				redundant_count = excluded.redundant_count,
+				vote_count = excluded.vote_count,
+				duplicate_vote_count = excluded.duplicate_vote_count`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO epoch_aggregation_stats (
+				epoch, aggregate_count, committee_count, redundant_count, vote_count, duplicate_vote_count
+			) VALUES ($1, $2, $3, $4, $5, $6)`,
+	}),
+		stats.Epoch, stats.AggregateCount, stats.CommitteeCount, stats.RedundantCount, stats.VoteCount, stats.DuplicateVoteCount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetEpochAggregationStats(epoch uint64) *dbtypes.EpochAggregationStats {
+	stats := dbtypes.EpochAggregationStats{}
+	err := ReaderDb.Get(&stats, `
+	SELECT
+		epoch, aggregate_count, committee_count, redundant_count, vote_count, duplicate_vote_count
+	FROM epoch_aggregation_stats
+	WHERE epoch = $1
+	`, epoch)
+	if err != nil {
+		return nil
+	}
+	return &stats
+}
+
+func InsertEpochCommitteeStats(committeeStats []*dbtypes.EpochCommitteeStats, tx *sqlx.Tx) error {
+	if len(committeeStats) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO epoch_committee_stats (epoch, slot, committee_index, duties, missed, late, top_name, top_name_count) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO epoch_committee_stats (epoch, slot, committee_index, duties, missed, late, top_name, top_name_count) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(committeeStats)*8)
+	for i, stats := range committeeStats {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7, argIdx+8)
+		args[argIdx] = stats.Epoch
+		args[argIdx+1] = stats.Slot
+		args[argIdx+2] = stats.CommitteeIndex
+		args[argIdx+3] = stats.Duties
+		args[argIdx+4] = stats.Missed
+		args[argIdx+5] = stats.Late
+		args[argIdx+6] = stats.TopName
+		args[argIdx+7] = stats.TopNameCount
+		argIdx += 8
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot, committee_index) DO UPDATE SET epoch = excluded.epoch, duties = excluded.duties, missed = excluded.missed, late = excluded.late, top_name = excluded.top_name, top_name_count = excluded.top_name_count`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetEpochCommitteeStats returns the stats of all committees with missing or late votes within the epoch range
+func GetEpochCommitteeStats(firstEpoch uint64, lastEpoch uint64) []*dbtypes.EpochCommitteeStats {
+	committeeStats := []*dbtypes.EpochCommitteeStats{}
+	err := ReaderDb.Select(&committeeStats, `
+	SELECT epoch, slot, committee_index, duties, missed, late, top_name, top_name_count
+	FROM epoch_committee_stats
+	WHERE epoch >= $1 AND epoch <= $2 AND (missed > 0 OR late > 0)
+	ORDER BY slot DESC, committee_index ASC
+	`, firstEpoch, lastEpoch)
+	if err != nil {
+		logger.Errorf("Error while fetching epoch committee stats: %v", err)
+		return nil
+	}
+	return committeeStats
+}
+
+func InsertSlotCommitteeParticipation(participation []*dbtypes.SlotCommitteeParticipation, tx *sqlx.Tx) error {
+	if len(participation) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO slot_committee_participation (slot, epoch, participation) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO slot_committee_participation (slot, epoch, participation) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(participation)*3)
+	for i, slotParticipation := range participation {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3)
+		args[argIdx] = slotParticipation.Slot
+		args[argIdx+1] = slotParticipation.Epoch
+		args[argIdx+2] = slotParticipation.Participation
+		argIdx += 3
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot) DO UPDATE SET epoch = excluded.epoch, participation = excluded.participation`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSlotCommitteeParticipation returns the per committee participation of all slots in the epoch
+func GetSlotCommitteeParticipation(epoch uint64) []*dbtypes.SlotCommitteeParticipation {
+	participation := []*dbtypes.SlotCommitteeParticipation{}
+	err := ReaderDb.Select(&participation, `
+	SELECT slot, epoch, participation
+	FROM slot_committee_participation
+	WHERE epoch = $1
+	ORDER BY slot ASC
+	`, epoch)
+	if err != nil {
+		logger.Errorf("Error while fetching slot committee participation: %v", err)
+		return nil
+	}
+	return participation
+}
+
+// InsertFinalityCheckpoint records a checkpoint transition, the first time a checkpoint was seen is kept
+func InsertFinalityCheckpoint(checkpoint *dbtypes.FinalityCheckpoint, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO finality_checkpoints (checkpoint_type, epoch, root, prev_epoch, seen_time)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (checkpoint_type, epoch) DO NOTHING`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR IGNORE INTO finality_checkpoints (checkpoint_type, epoch, root, prev_epoch, seen_time)
+			VALUES ($1, $2, $3, $4, $5)`,
+	}),
+		checkpoint.CheckpointType, checkpoint.Epoch, checkpoint.Root, checkpoint.PrevEpoch, checkpoint.SeenTime)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetFinalityCheckpoints returns the checkpoint transitions that cover any epoch in the given range
+func GetFinalityCheckpoints(firstEpoch uint64, lastEpoch uint64) []*dbtypes.FinalityCheckpoint {
+	checkpoints := []*dbtypes.FinalityCheckpoint{}
+	err := ReaderDb.Select(&checkpoints, `
+	SELECT checkpoint_type, epoch, root, prev_epoch, seen_time
+	FROM finality_checkpoints
+	WHERE epoch >= $1 AND prev_epoch < $2
+	ORDER BY epoch ASC
+	`, firstEpoch, lastEpoch)
+	if err != nil {
+		logger.Errorf("Error while fetching finality checkpoints: %v", err)
+		return nil
+	}
+	return checkpoints
+}
+
+// GetEpochCommitteeDuties returns the number of attestation duties per committee index within the epoch range
+func GetEpochCommitteeDuties(firstEpoch uint64, lastEpoch uint64) map[uint64]uint64 {
+	rows := []struct {
+		CommitteeIndex uint64 `db:"committee_index"`
+		Duties         uint64 `db:"duties"`
+	}{}
+	err := ReaderDb.Select(&rows, `
+	SELECT committee_index, SUM(duties) AS duties
+	FROM epoch_committee_stats
+	WHERE epoch >= $1 AND epoch <= $2
+	GROUP BY committee_index
+	`, firstEpoch, lastEpoch)
+	if err != nil {
+		logger.Errorf("Error while fetching epoch committee duties: %v", err)
+		return nil
+	}
+	duties := make(map[uint64]uint64, len(rows))
+	for _, row := range rows {
+		duties[row.CommitteeIndex] = row.Duties
+	}
+	return duties
+}
+
+func InsertSlotRewards(rewards []*dbtypes.SlotReward, tx *sqlx.Tx) error {
+	if len(rewards) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO slot_rewards (slot, proposer, root, cl_reward, mev_bid, mev_payment, mev_relay, expected_reward, actual_reward) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO slot_rewards (slot, proposer, root, cl_reward, mev_bid, mev_payment, mev_relay, expected_reward, actual_reward) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(rewards)*9)
+	for i, reward := range rewards {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7, argIdx+8, argIdx+9)
+		args[argIdx] = reward.Slot
+		args[argIdx+1] = reward.Proposer
+		args[argIdx+2] = reward.Root
+		args[argIdx+3] = reward.ClReward
+		args[argIdx+4] = reward.MevBid
+		args[argIdx+5] = reward.MevPayment
+		args[argIdx+6] = reward.MevRelay
+		args[argIdx+7] = reward.ExpectedReward
+		args[argIdx+8] = reward.ActualReward
+		argIdx += 9
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot) DO UPDATE SET proposer = excluded.proposer, root = excluded.root, cl_reward = excluded.cl_reward, mev_bid = excluded.mev_bid, mev_payment = excluded.mev_payment, mev_relay = excluded.mev_relay, expected_reward = excluded.expected_reward, actual_reward = excluded.actual_reward`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetSlotReward(slot uint64) *dbtypes.SlotReward {
+	reward := dbtypes.SlotReward{}
+	err := ReaderDb.Get(&reward, `
+	SELECT
+		slot, proposer, root, cl_reward, mev_bid, mev_payment, mev_relay, expected_reward, actual_reward
+	FROM slot_rewards
+	WHERE slot = $1
+	`, slot)
+	if err != nil {
+		return nil
+	}
+	return &reward
+}
+
+func InsertBlockRewards(rewards []*dbtypes.BlockReward, tx *sqlx.Tx) error {
+	if len(rewards) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO block_rewards (slot, root, proposer, total, attestations, sync_aggregate, proposer_slashings, attester_slashings, sync_rewards, sync_penalties, sync_missed) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO block_rewards (slot, root, proposer, total, attestations, sync_aggregate, proposer_slashings, attester_slashings, sync_rewards, sync_penalties, sync_missed) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(rewards)*11)
+	for i, reward := range rewards {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7, argIdx+8, argIdx+9, argIdx+10, argIdx+11)
+		args[argIdx] = reward.Slot
+		args[argIdx+1] = reward.Root
+		args[argIdx+2] = reward.Proposer
+		args[argIdx+3] = reward.Total
+		args[argIdx+4] = reward.Attestations
+		args[argIdx+5] = reward.SyncAggregate
+		args[argIdx+6] = reward.ProposerSlashings
+		args[argIdx+7] = reward.AttesterSlashings
+		args[argIdx+8] = reward.SyncRewards
+		args[argIdx+9] = reward.SyncPenalties
+		args[argIdx+10] = reward.SyncMissed
+		argIdx += 11
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot) DO UPDATE SET root = excluded.root, proposer = excluded.proposer, total = excluded.total, attestations = excluded.attestations, sync_aggregate = excluded.sync_aggregate, proposer_slashings = excluded.proposer_slashings, attester_slashings = excluded.attester_slashings, sync_rewards = excluded.sync_rewards, sync_penalties = excluded.sync_penalties, sync_missed = excluded.sync_missed`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetBlockReward(root []byte) *dbtypes.BlockReward {
+	reward := dbtypes.BlockReward{}
+	err := ReaderDb.Get(&reward, `
+	SELECT
+		slot, root, proposer, total, attestations, sync_aggregate, proposer_slashings, attester_slashings, sync_rewards, sync_penalties, sync_missed
+	FROM block_rewards
+	WHERE root = $1
+	`, root)
+	if err != nil {
+		return nil
+	}
+	return &reward
+}
+
+func GetProposerRewardStats(offset uint64, limit uint32) []*dbtypes.ProposerRewardStats {
+	stats := []*dbtypes.ProposerRewardStats{}
+	err := ReaderDb.Select(&stats, `
+	SELECT
+		proposer, COUNT(*) AS slot_count, CAST(SUM(CASE WHEN root IS NULL THEN 0 ELSE 1 END) AS bigint) AS proposed_count,
+		CAST(SUM(expected_reward) AS bigint) AS expected_reward, CAST(SUM(actual_reward) AS bigint) AS actual_reward
+	FROM slot_rewards
+	GROUP BY proposer
+	ORDER BY actual_reward DESC, proposer ASC
+	LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching proposer reward stats: %v", err)
+		return nil
+	}
+	return stats
+}
+
+func GetProposerRewardStatsCount() uint64 {
+	var count uint64
+	err := ReaderDb.Get(&count, `SELECT COUNT(DISTINCT proposer) FROM slot_rewards`)
+	if err != nil {
+		logger.Errorf("Error while fetching proposer reward stats count: %v", err)
+		return 0
+	}
+	return count
+}
+
+func InsertBlobGas(blobGas []*dbtypes.BlobGas, tx *sqlx.Tx) error {
+	if len(blobGas) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO blob_gas (slot, root, blob_gas_used, excess_blob_gas) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO blob_gas (slot, root, blob_gas_used, excess_blob_gas) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(blobGas)*4)
+	for i, entry := range blobGas {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4)
+		args[argIdx] = entry.Slot
+		args[argIdx+1] = entry.Root
+		args[argIdx+2] = entry.BlobGasUsed
+		args[argIdx+3] = entry.ExcessBlobGas
+		argIdx += 4
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot) DO UPDATE SET root = excluded.root, blob_gas_used = excluded.blob_gas_used, excess_blob_gas = excluded.excess_blob_gas`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func InsertBlockArrivals(arrivals []*dbtypes.BlockArrival, tx *sqlx.Tx) error {
+	if len(arrivals) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO block_arrivals (root, slot, client, delay) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO block_arrivals (root, slot, client, delay) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(arrivals)*4)
+	for i, arrival := range arrivals {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4)
+		args[argIdx] = arrival.Root
+		args[argIdx+1] = arrival.Slot
+		args[argIdx+2] = arrival.Client
+		args[argIdx+3] = arrival.Delay
+		argIdx += 4
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (root, client) DO UPDATE SET delay = excluded.delay`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetBlockArrivals(root []byte) []*dbtypes.BlockArrival {
+	arrivals := []*dbtypes.BlockArrival{}
+	err := ReaderDb.Select(&arrivals, `
+	SELECT root, slot, client, delay
+	FROM block_arrivals
+	WHERE root = $1
+	ORDER BY delay ASC
+	`, root)
+	if err != nil {
+		logger.Errorf("Error while fetching block arrivals: %v", err)
+		return nil
+	}
+	return arrivals
+}
+
+func InsertBlockDataColumns(dataColumns []*dbtypes.BlockDataColumns, tx *sqlx.Tx) error {
+	if len(dataColumns) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO block_data_columns (root, slot, client, columns) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO block_data_columns (root, slot, client, columns) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(dataColumns)*4)
+	for i, columns := range dataColumns {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4)
+		args[argIdx] = columns.Root
+		args[argIdx+1] = columns.Slot
+		args[argIdx+2] = columns.Client
+		args[argIdx+3] = columns.Columns
+		argIdx += 4
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (root, client) DO UPDATE SET columns = excluded.columns`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetBlockDataColumns(root []byte) []*dbtypes.BlockDataColumns {
+	dataColumns := []*dbtypes.BlockDataColumns{}
+	err := ReaderDb.Select(&dataColumns, `
+	SELECT root, slot, client, columns
+	FROM block_data_columns
+	WHERE root = $1
+	ORDER BY client ASC
+	`, root)
+	if err != nil {
+		logger.Errorf("Error while fetching block data columns: %v", err)
+		return nil
+	}
+	return dataColumns
+}
+
+// GetBlockArrivalsForSlots returns the arrivals of all blocks in the slot range, ordered by delay
+func GetBlockArrivalsForSlots(firstSlot uint64, lastSlot uint64) []*dbtypes.BlockArrival {
+	arrivals := []*dbtypes.BlockArrival{}
+	err := ReaderDb.Select(&arrivals, `
+	SELECT root, slot, client, delay
+	FROM block_arrivals
+	WHERE slot >= $1 AND slot <= $2
+	ORDER BY delay ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching block arrivals: %v", err)
+		return nil
+	}
+	return arrivals
+}
+
+func InsertBlockWitnesses(witnesses []*dbtypes.BlockWitness, tx *sqlx.Tx) error {
+	if len(witnesses) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO block_witnesses (slot, root, stem_count, key_count, write_count, proof_size, witness_size) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO block_witnesses (slot, root, stem_count, key_count, write_count, proof_size, witness_size) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(witnesses)*7)
+	for i, witness := range witnesses {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7)
+		args[argIdx] = witness.Slot
+		args[argIdx+1] = witness.Root
+		args[argIdx+2] = witness.StemCount
+		args[argIdx+3] = witness.KeyCount
+		args[argIdx+4] = witness.WriteCount
+		args[argIdx+5] = witness.ProofSize
+		args[argIdx+6] = witness.WitnessSize
+		argIdx += 7
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot) DO UPDATE SET root = excluded.root, stem_count = excluded.stem_count, key_count = excluded.key_count, write_count = excluded.write_count, proof_size = excluded.proof_size, witness_size = excluded.witness_size`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func InsertSlotRoots(slotRoots []*dbtypes.SlotRoot, tx *sqlx.Tx) error {
+	if len(slotRoots) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO slot_roots (slot, block_root, state_root, missed) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO slot_roots (slot, block_root, state_root, missed) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(slotRoots)*4)
+	for i, slotRoot := range slotRoots {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4)
+		args[argIdx] = slotRoot.Slot
+		args[argIdx+1] = slotRoot.BlockRoot
+		args[argIdx+2] = slotRoot.StateRoot
+		args[argIdx+3] = slotRoot.Missed
+		argIdx += 4
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot) DO UPDATE SET block_root = excluded.block_root, state_root = excluded.state_root, missed = excluded.missed`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetLastSlotRoot returns the slot roots of the latest slot before the given slot
+func GetLastSlotRoot(beforeSlot uint64, tx *sqlx.Tx) *dbtypes.SlotRoot {
+	slotRoot := dbtypes.SlotRoot{}
+	err := tx.Get(&slotRoot, `
+	SELECT slot, block_root, state_root, missed
+	FROM slot_roots
+	WHERE slot < $1
+	ORDER BY slot DESC
+	LIMIT 1
+	`, beforeSlot)
+	if err != nil {
+		return nil
+	}
+	return &slotRoot
+}
+
+// GetSlotRoots returns the slot roots between firstSlot and lastSlot, newest first
+func GetSlotRoots(firstSlot uint64, lastSlot uint64) []*dbtypes.SlotRoot {
+	slotRoots := []*dbtypes.SlotRoot{}
+	err := ReaderDb.Select(&slotRoots, `
+	SELECT slot, block_root, state_root, missed
+	FROM slot_roots
+	WHERE slot >= $1 AND slot <= $2
+	ORDER BY slot DESC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching slot roots: %v", err)
+		return nil
+	}
+	return slotRoots
+}
+
+func GetBlockWitness(root []byte) *dbtypes.BlockWitness {
+	witness := dbtypes.BlockWitness{}
+	err := ReaderDb.Get(&witness, `
+	SELECT slot, root, stem_count, key_count, write_count, proof_size, witness_size
+	FROM block_witnesses
+	WHERE root = $1
+	`, root)
+	if err != nil {
+		return nil
+	}
+	return &witness
+}
+
+// GetBlockWitnessStats aggregates the execution witness sizes of all blocks between firstSlot and lastSlot in periods of periodSlots slots
+func GetBlockWitnessStats(firstSlot uint64, lastSlot uint64, periodSlots uint64) []*dbtypes.BlockWitnessStats {
+	stats := []*dbtypes.BlockWitnessStats{}
+	err := ReaderDb.Select(&stats, `
+	SELECT
+		slot / $1 AS period, COUNT(*) AS block_count,
+		CAST(AVG(stem_count) AS double precision) AS stem_count, CAST(AVG(key_count) AS double precision) AS key_count,
+		CAST(AVG(proof_size) AS double precision) AS proof_size, CAST(AVG(witness_size) AS double precision) AS witness_size,
+		MAX(witness_size) AS max_size
+	FROM block_witnesses
+	WHERE slot >= $2 AND slot <= $3
+	GROUP BY period
+	ORDER BY period ASC
+	`, periodSlots, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching block witness stats: %v", err)
+		return nil
+	}
+	return stats
+}
+
+// GetBlobGasForSlots returns the blob gas of all blocks between firstSlot and lastSlot
+func GetBlobGasForSlots(firstSlot uint64, lastSlot uint64) []*dbtypes.BlobGas {
+	blobGas := []*dbtypes.BlobGas{}
+	err := ReaderDb.Select(&blobGas, `
+	SELECT slot, root, blob_gas_used, excess_blob_gas
+	FROM blob_gas
+	WHERE slot >= $1 AND slot <= $2
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching blob gas: %v", err)
+		return nil
+	}
+	return blobGas
+}
+
+// GetBlobGasStats aggregates the blob gas of all blocks between firstSlot and lastSlot in periods of periodSlots slots
+func GetBlobGasStats(firstSlot uint64, lastSlot uint64, periodSlots uint64) []*dbtypes.BlobGasStats {
+	stats := []*dbtypes.BlobGasStats{}
+	err := ReaderDb.Select(&stats, `
+	SELECT
+		slot / $1 AS period, COUNT(*) AS block_count, CAST(SUM(blob_gas_used) AS bigint) AS blob_gas_used,
+		CAST(AVG(excess_blob_gas) AS double precision) AS excess_blob_gas
+	FROM blob_gas
+	WHERE slot >= $2 AND slot <= $3
+	GROUP BY period
+	ORDER BY period ASC
+	`, periodSlots, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching blob gas stats: %v", err)
+		return nil
+	}
+	return stats
+}
+
+func GetEpochCredentialStats(firstEpoch uint64, limit uint32) []*dbtypes.EpochCredentialStats {
+	stats := []*dbtypes.EpochCredentialStats{}
+	err := ReaderDb.Select(&stats, `
+	SELECT
+		epoch, bls_count, execution_count, compounding_count, other_count
+	FROM epoch_credential_stats
+	WHERE epoch <= $1
+	ORDER BY epoch DESC
+	LIMIT $2
+	`, firstEpoch, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching epoch credential stats: %v", err)
+		return nil
+	}
+	return stats
+}
+
+func InsertConsolidationRequests(requests []*dbtypes.ConsolidationRequest, tx *sqlx.Tx) error {
+	if len(requests) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO consolidation_requests (slot, idx, block_root, block_number, source_address, source_pubkey, source_index, source_balance, target_pubkey, target_index, target_balance) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO consolidation_requests (slot, idx, block_root, block_number, source_address, source_pubkey, source_index, source_balance, target_pubkey, target_index, target_balance) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(requests)*11)
+	for i, request := range requests {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7, argIdx+8, argIdx+9, argIdx+10, argIdx+11)
+		args[argIdx] = request.Slot
+		args[argIdx+1] = request.Index
+		args[argIdx+2] = request.BlockRoot
+		args[argIdx+3] = request.BlockNumber
+		args[argIdx+4] = request.SourceAddress
+		args[argIdx+5] = request.SourcePubkey
+		args[argIdx+6] = request.SourceIndex
+		args[argIdx+7] = request.SourceBalance
+		args[argIdx+8] = request.TargetPubkey
+		args[argIdx+9] = request.TargetIndex
+		args[argIdx+10] = request.TargetBalance
+		argIdx += 11
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot, idx) DO UPDATE SET block_root = excluded.block_root, block_number = excluded.block_number, source_address = excluded.source_address, source_pubkey = excluded.source_pubkey, source_index = excluded.source_index, source_balance = excluded.source_balance, target_pubkey = excluded.target_pubkey, target_index = excluded.target_index, target_balance = excluded.target_balance`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetConsolidationRequests returns the latest indexed consolidation requests, newest first
+func GetConsolidationRequests(offset uint64, limit uint32) []*dbtypes.ConsolidationRequest {
+	requests := []*dbtypes.ConsolidationRequest{}
+	err := ReaderDb.Select(&requests, `
+	SELECT slot, idx, block_root, block_number, source_address, source_pubkey, source_index, source_balance, target_pubkey, target_index, target_balance
+	FROM consolidation_requests
+	ORDER BY slot DESC, idx DESC
+	LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching consolidation requests: %v", err)
+		return nil
+	}
+	return requests
 }
 
-func GetEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch {
-	epochs := []*dbtypes.Epoch{}
-	err := ReaderDb.Select(&epochs, `
-	SELECT
-		epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
-		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count,
-		proposer_slashing_count, bls_change_count, eth_transaction_count, sync_participation
-	FROM epochs
-	WHERE epoch <= $1
-	ORDER BY epoch DESC
+// GetConsolidationRequestsByValidator returns the consolidation requests with the validator as source or target, newest first
+func GetConsolidationRequestsByValidator(validatorIndex uint64, limit uint32) []*dbtypes.ConsolidationRequest {
+	requests := []*dbtypes.ConsolidationRequest{}
+	err := ReaderDb.Select(&requests, `
+	SELECT slot, idx, block_root, block_number, source_address, source_pubkey, source_index, source_balance, target_pubkey, target_index, target_balance
+	FROM consolidation_requests
+	WHERE source_index = $1 OR target_index = $1
+	ORDER BY slot DESC, idx DESC
 	LIMIT $2
-	`, firstEpoch, limit)
+	`, validatorIndex, limit)
 	if err != nil {
-		logger.Errorf("Error while fetching epochs: %v", err)
+		logger.Errorf("Error while fetching consolidation requests: %v", err)
 		return nil
 	}
-	return epochs
+	return requests
 }
 
 func GetBlocks(firstBlock uint64, limit uint32, withOrphaned bool) []*dbtypes.Block {
@@ -500,7 +1838,7 @@ func GetBlocks(firstBlock uint64, limit uint32, withOrphaned bool) []*dbtypes.Bl
 	SELECT
 		root, slot, parent_root, state_root, orphaned, proposer, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, sync_participation
+		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, eth_fee_recipient, eth_extra_data, cl_client, el_client, proposer_dependent_root, sync_participation
 	FROM blocks
 	WHERE slot <= $1 `+orphanedLimit+`
 	ORDER BY slot DESC
@@ -523,7 +1861,7 @@ func GetBlocksForSlots(firstSlot uint64, lastSlot uint64, withOrphaned bool) []*
 	SELECT
 		root, slot, parent_root, state_root, orphaned, proposer, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, sync_participation
+		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, eth_fee_recipient, eth_extra_data, cl_client, el_client, proposer_dependent_root, sync_participation, body_root
 	FROM blocks
 	WHERE slot <= $1 AND slot >= $2 `+orphanedLimit+`
 	ORDER BY slot DESC
@@ -541,7 +1879,7 @@ func GetBlocksByParentRoot(parentRoot []byte) []*dbtypes.Block {
 	SELECT
 		root, slot, parent_root, state_root, orphaned, proposer, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, sync_participation
+		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, eth_fee_recipient, eth_extra_data, cl_client, el_client, proposer_dependent_root, sync_participation
 	FROM blocks
 	WHERE parent_root = $1
 	ORDER BY slot DESC
@@ -559,7 +1897,7 @@ func GetBlockByRoot(root []byte) *dbtypes.Block {
 	SELECT
 		root, slot, parent_root, state_root, orphaned, proposer, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, sync_participation
+		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, eth_fee_recipient, eth_extra_data, cl_client, el_client, proposer_dependent_root, sync_participation
 	FROM blocks
 	WHERE root = $1
 	`, root)
@@ -570,6 +1908,64 @@ func GetBlockByRoot(root []byte) *dbtypes.Block {
 	return &block
 }
 
+func GetBlockByStateRoot(stateRoot []byte) *dbtypes.Block {
+	block := dbtypes.Block{}
+	err := ReaderDb.Get(&block, `
+	SELECT
+		root, slot, parent_root, state_root, orphaned, proposer, graffiti, graffiti_text,
+		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
+		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, eth_fee_recipient, eth_extra_data, cl_client, el_client, proposer_dependent_root, sync_participation
+	FROM blocks
+	WHERE state_root = $1
+	ORDER BY orphaned ASC
+	LIMIT 1
+	`, stateRoot)
+	if err != nil {
+		return nil
+	}
+	return &block
+}
+
+// GetBlockByBodyRoot returns the block with the given body root, canonical blocks first
+func GetBlockByBodyRoot(bodyRoot []byte) *dbtypes.Block {
+	block := dbtypes.Block{}
+	err := ReaderDb.Get(&block, `
+	SELECT
+		root, slot, parent_root, state_root, orphaned, proposer, graffiti, graffiti_text,
+		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
+		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, eth_fee_recipient, eth_extra_data, cl_client, el_client, proposer_dependent_root, sync_participation
+	FROM blocks
+	WHERE body_root = $1
+	ORDER BY orphaned ASC
+	LIMIT 1
+	`, bodyRoot)
+	if err != nil {
+		return nil
+	}
+	return &block
+}
+
+func GetBlockByExecutionHash(hash []byte) *dbtypes.Block {
+	block := dbtypes.Block{}
+	err := ReaderDb.Get(&block, `
+	SELECT
+		root, slot, parent_root, state_root, orphaned, proposer, graffiti, graffiti_text,
+		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
+		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, eth_fee_recipient, eth_extra_data, cl_client, el_client, proposer_dependent_root, sync_participation
+	FROM blocks
+	WHERE eth_block_hash = $1
+	ORDER BY orphaned ASC
+	LIMIT 1
+	`, hash)
+	if err != nil {
+		return nil
+	}
+	return &block
+}
+
+// graffitiLikeEscaper escapes the LIKE wildcards, so a graffiti prefix is matched literally
+var graffitiLikeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 func GetFilteredBlocks(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint64, limit uint32) []*dbtypes.AssignedBlock {
 	blockAssignments := []*dbtypes.AssignedBlock{}
 	var sql strings.Builder
@@ -577,14 +1973,14 @@ func GetFilteredBlocks(filter *dbtypes.BlockFilter, firstSlot uint64, offset uin
 	blockFields := []string{
 		"root", "slot", "parent_root", "state_root", "orphaned", "proposer", "graffiti", "graffiti_text",
 		"attestation_count", "deposit_count", "exit_count", "withdraw_count", "withdraw_amount", "attester_slashing_count",
-		"proposer_slashing_count", "bls_change_count", "eth_transaction_count", "eth_block_number", "eth_block_hash", "sync_participation",
+		"proposer_slashing_count", "bls_change_count", "eth_transaction_count", "eth_block_number", "eth_block_hash", "eth_fee_recipient", "eth_extra_data", "cl_client", "el_client", "proposer_dependent_root", "sync_participation",
 	}
 	for _, blockField := range blockFields {
 		fmt.Fprintf(&sql, ", blocks.%v AS \"block.%v\"", blockField, blockField)
 	}
 	fmt.Fprintf(&sql, ` FROM slot_assignments `)
 	fmt.Fprintf(&sql, ` LEFT JOIN blocks ON blocks.slot = slot_assignments.slot `)
-	if filter.ProposerName != "" {
+	if filter.ProposerName != "" && filter.ProposerIndices == nil {
 		fmt.Fprintf(&sql, ` LEFT JOIN validator_names ON validator_names."index" = COALESCE(blocks.proposer, slot_assignments.proposer) `)
 	}
 
@@ -614,7 +2010,31 @@ func GetFilteredBlocks(filter *dbtypes.BlockFilter, firstSlot uint64, offset uin
 		fmt.Fprintf(&sql, ` AND (slot_assignments.proposer = $%v OR blocks.proposer = $%v) `, argIdx, argIdx)
 		args = append(args, *filter.ProposerIndex)
 	}
-	if filter.Graffiti != "" {
+	if filter.MinSlot != nil {
+		argIdx++
+		fmt.Fprintf(&sql, ` AND slot_assignments.slot >= $%v `, argIdx)
+		args = append(args, *filter.MinSlot)
+	}
+	if filter.MaxSlot != nil {
+		argIdx++
+		fmt.Fprintf(&sql, ` AND slot_assignments.slot <= $%v `, argIdx)
+		args = append(args, *filter.MaxSlot)
+	}
+	if filter.Graffiti != "" && filter.GraffitiRegex {
+		argIdx++
+		fmt.Fprintf(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  ` AND blocks.graffiti_text ~* $%v `,
+			dbtypes.DBEngineSqlite: ` AND blocks.graffiti_text REGEXP $%v `,
+		}), argIdx)
+		args = append(args, filter.Graffiti)
+	} else if filter.Graffiti != "" && filter.GraffitiPrefix {
+		argIdx++
+		fmt.Fprintf(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  ` AND lower(blocks.graffiti_text) LIKE $%v `,
+			dbtypes.DBEngineSqlite: ` AND blocks.graffiti_text LIKE $%v ESCAPE '\' `,
+		}), argIdx)
+		args = append(args, graffitiLikeEscaper.Replace(strings.ToLower(filter.Graffiti))+"%")
+	} else if filter.Graffiti != "" {
 		argIdx++
 		fmt.Fprintf(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
 			dbtypes.DBEnginePgsql:  ` AND blocks.graffiti_text ilike $%v `,
@@ -622,7 +2042,16 @@ func GetFilteredBlocks(filter *dbtypes.BlockFilter, firstSlot uint64, offset uin
 		}), argIdx)
 		args = append(args, "%"+filter.Graffiti+"%")
 	}
-	if filter.ProposerName != "" {
+	if filter.ProposerIndices != nil {
+		// validator names are usually assigned to consecutive index ranges, so match by ranges to keep the query short
+		fmt.Fprintf(&sql, ` AND (1=0`)
+		for _, indexRange := range getIndexRanges(filter.ProposerIndices) {
+			argIdx += 2
+			fmt.Fprintf(&sql, ` OR COALESCE(blocks.proposer, slot_assignments.proposer) BETWEEN $%v AND $%v`, argIdx-1, argIdx)
+			args = append(args, indexRange[0], indexRange[1])
+		}
+		fmt.Fprintf(&sql, `) `)
+	} else if filter.ProposerName != "" {
 		argIdx++
 		fmt.Fprintf(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
 			dbtypes.DBEnginePgsql:  ` AND validator_names.name ilike $%v `,
@@ -682,6 +2111,18 @@ func GetFilteredBlocks(filter *dbtypes.BlockFilter, firstSlot uint64, offset uin
 	return blockAssignments
 }
 
+func getIndexRanges(indices []uint64) [][2]uint64 {
+	ranges := [][2]uint64{}
+	for _, index := range indices {
+		if len(ranges) > 0 && ranges[len(ranges)-1][1]+1 == index {
+			ranges[len(ranges)-1][1] = index
+		} else {
+			ranges = append(ranges, [2]uint64{index, index})
+		}
+	}
+	return ranges
+}
+
 func GetSlotAssignmentsForSlots(firstSlot uint64, lastSlot uint64) []*dbtypes.SlotAssignment {
 	assignments := []*dbtypes.SlotAssignment{}
 	err := ReaderDb.Select(&assignments, `
@@ -827,8 +2268,9 @@ func InsertUnfinalizedEpoch(epoch *dbtypes.Epoch, tx *sqlx.Tx) error {
 			INSERT INTO unfinalized_epochs (
 				epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-				proposer_slashing_count, bls_change_count, eth_transaction_count, sync_participation
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+				proposer_slashing_count, bls_change_count, eth_transaction_count, blob_count, sync_participation, partial,
+				tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
 			ON CONFLICT (epoch) DO UPDATE SET
 				validator_count = excluded.validator_count,
 				validator_balance = excluded.validator_balance,
@@ -847,17 +2289,26 @@ func InsertUnfinalizedEpoch(epoch *dbtypes.Epoch, tx *sqlx.Tx) error {
 				proposer_slashing_count = excluded.proposer_slashing_count, 
 				bls_change_count = excluded.bls_change_count, 
 				eth_transaction_count = excluded.eth_transaction_count, 
-				sync_participation = excluded.sync_participation`,
+				blob_count = excluded.blob_count, 
+				sync_participation = excluded.sync_participation,
+				partial = excluded.partial,
+				tx_legacy_count = excluded.tx_legacy_count,
+				tx_access_list_count = excluded.tx_access_list_count,
+				tx_dynamic_fee_count = excluded.tx_dynamic_fee_count,
+				tx_blob_count = excluded.tx_blob_count,
+				tx_setcode_count = excluded.tx_setcode_count`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR REPLACE INTO unfinalized_epochs (
 				epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-				proposer_slashing_count, bls_change_count, eth_transaction_count, sync_participation
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+				proposer_slashing_count, bls_change_count, eth_transaction_count, blob_count, sync_participation, partial,
+				tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)`,
 	}),
 		epoch.Epoch, epoch.ValidatorCount, epoch.ValidatorBalance, epoch.Eligible, epoch.VotedTarget, epoch.VotedHead, epoch.VotedTotal, epoch.BlockCount, epoch.OrphanedCount,
 		epoch.AttestationCount, epoch.DepositCount, epoch.ExitCount, epoch.WithdrawCount, epoch.WithdrawAmount, epoch.AttesterSlashingCount, epoch.ProposerSlashingCount,
-		epoch.BLSChangeCount, epoch.EthTransactionCount, epoch.SyncParticipation)
+		epoch.BLSChangeCount, epoch.EthTransactionCount, epoch.BlobCount, epoch.SyncParticipation, epoch.Partial,
+		epoch.TxLegacyCount, epoch.TxAccessListCount, epoch.TxDynamicFeeCount, epoch.TxBlobCount, epoch.TxSetCodeCount)
 	if err != nil {
 		return err
 	}
@@ -870,7 +2321,8 @@ func GetUnfinalizedEpoch(epoch uint64) *dbtypes.Epoch {
 	SELECT
 		epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count,
-		proposer_slashing_count, bls_change_count, eth_transaction_count, sync_participation
+		proposer_slashing_count, bls_change_count, eth_transaction_count, blob_count, sync_participation, partial,
+		tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
 	FROM unfinalized_epochs
 	WHERE epoch = $1
 	`, epoch)
@@ -892,6 +2344,19 @@ func DeleteUnfinalizedBefore(slot uint64, tx *sqlx.Tx) error {
 	return nil
 }
 
+// DeleteUnfinalizedFrom deletes the persisted unfinalized blocks & epochs from the given slot on
+func DeleteUnfinalizedFrom(slot uint64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`DELETE FROM unfinalized_blocks WHERE slot >= $1`, slot)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM unfinalized_epochs WHERE epoch >= $1`, utils.EpochOfSlot(slot))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func InsertBlob(blob *dbtypes.Blob, tx *sqlx.Tx) error {
 	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
@@ -955,3 +2420,456 @@ func GetLatestBlobAssignment(commitment []byte) *dbtypes.BlobAssignment {
 	}
 	return &blobAssignment
 }
+
+func GetBlobAssignmentsForSlots(firstSlot uint64, lastSlot uint64) []*dbtypes.BlobAssignment {
+	blobAssignments := []*dbtypes.BlobAssignment{}
+	err := ReaderDb.Select(&blobAssignments, `
+	SELECT root, commitment, slot
+	FROM blob_assignments
+	WHERE slot >= $1 AND slot <= $2
+	ORDER BY slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching blob assignments: %v", err)
+		return nil
+	}
+	return blobAssignments
+}
+
+func GetBlobCountsForSlots(firstSlot uint64, lastSlot uint64) []*dbtypes.BlockBlobCount {
+	blobCounts := []*dbtypes.BlockBlobCount{}
+	err := ReaderDb.Select(&blobCounts, `
+	SELECT root, COUNT(*) AS count
+	FROM blob_assignments
+	WHERE slot >= $1 AND slot <= $2
+	GROUP BY root
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching blob counts: %v", err)
+		return nil
+	}
+	return blobCounts
+}
+
+func InsertDeposits(deposits []*dbtypes.Deposit, tx *sqlx.Tx) error {
+	if len(deposits) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO deposits (slot, idx, block_root, pubkey, withdrawal_credentials, amount) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO deposits (slot, idx, block_root, pubkey, withdrawal_credentials, amount) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(deposits)*6)
+	for i, deposit := range deposits {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6)
+		args[argIdx] = deposit.Slot
+		args[argIdx+1] = deposit.Index
+		args[argIdx+2] = deposit.BlockRoot
+		args[argIdx+3] = deposit.Pubkey
+		args[argIdx+4] = deposit.WithdrawalCredentials
+		args[argIdx+5] = deposit.Amount
+		argIdx += 6
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot, idx) DO UPDATE SET block_root = excluded.block_root, pubkey = excluded.pubkey, withdrawal_credentials = excluded.withdrawal_credentials, amount = excluded.amount`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDepositsByPubkey returns all indexed deposits for the pubkey, oldest first
+func GetDepositsByPubkey(pubkey []byte) []*dbtypes.Deposit {
+	deposits := []*dbtypes.Deposit{}
+	err := ReaderDb.Select(&deposits, `
+	SELECT slot, idx, block_root, pubkey, withdrawal_credentials, amount
+	FROM deposits
+	WHERE pubkey = $1
+	ORDER BY slot ASC, idx ASC
+	`, pubkey)
+	if err != nil {
+		logger.Errorf("Error while fetching deposits: %v", err)
+		return nil
+	}
+	return deposits
+}
+
+func InsertDepositReceipts(receipts []*dbtypes.DepositReceipt, tx *sqlx.Tx) error {
+	if len(receipts) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO deposit_receipts (slot, idx, block_root, block_number, deposit_index, pubkey, withdrawal_credentials, amount) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO deposit_receipts (slot, idx, block_root, block_number, deposit_index, pubkey, withdrawal_credentials, amount) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(receipts)*8)
+	for i, receipt := range receipts {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7, argIdx+8)
+		args[argIdx] = receipt.Slot
+		args[argIdx+1] = receipt.Index
+		args[argIdx+2] = receipt.BlockRoot
+		args[argIdx+3] = receipt.BlockNumber
+		args[argIdx+4] = receipt.DepositIndex
+		args[argIdx+5] = receipt.Pubkey
+		args[argIdx+6] = receipt.WithdrawalCredentials
+		args[argIdx+7] = receipt.Amount
+		argIdx += 8
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (slot, idx) DO UPDATE SET block_root = excluded.block_root, block_number = excluded.block_number, deposit_index = excluded.deposit_index, pubkey = excluded.pubkey, withdrawal_credentials = excluded.withdrawal_credentials, amount = excluded.amount`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDepositReceipts returns the latest indexed deposit receipts, newest first
+func GetDepositReceipts(offset uint64, limit uint32) []*dbtypes.DepositReceipt {
+	receipts := []*dbtypes.DepositReceipt{}
+	err := ReaderDb.Select(&receipts, `
+	SELECT slot, idx, block_root, block_number, deposit_index, pubkey, withdrawal_credentials, amount
+	FROM deposit_receipts
+	ORDER BY slot DESC, idx DESC
+	LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching deposit receipts: %v", err)
+		return nil
+	}
+	return receipts
+}
+
+// GetDepositReceiptsByPubkey returns all indexed deposit receipts for the pubkey, oldest first
+func GetDepositReceiptsByPubkey(pubkey []byte) []*dbtypes.DepositReceipt {
+	receipts := []*dbtypes.DepositReceipt{}
+	err := ReaderDb.Select(&receipts, `
+	SELECT slot, idx, block_root, block_number, deposit_index, pubkey, withdrawal_credentials, amount
+	FROM deposit_receipts
+	WHERE pubkey = $1
+	ORDER BY slot ASC, idx ASC
+	`, pubkey)
+	if err != nil {
+		logger.Errorf("Error while fetching deposit receipts: %v", err)
+		return nil
+	}
+	return receipts
+}
+
+// GetDepositPubkeysByWithdrawalCredentials returns the distinct pubkeys of the deposits & deposit receipts that
+// were made with one of the given withdrawal credentials
+func GetDepositPubkeysByWithdrawalCredentials(credentials [][]byte, limit uint32) [][]byte {
+	if len(credentials) == 0 {
+		return [][]byte{}
+	}
+	var sql strings.Builder
+	args := make([]any, 0, len(credentials)+1)
+	credsFilter := strings.Builder{}
+	for i, creds := range credentials {
+		if i > 0 {
+			fmt.Fprintf(&credsFilter, ", ")
+		}
+		args = append(args, creds)
+		fmt.Fprintf(&credsFilter, "$%v", len(args))
+	}
+	args = append(args, limit)
+	fmt.Fprintf(&sql, `
+	SELECT pubkey FROM deposits WHERE withdrawal_credentials IN (%v)
+	UNION
+	SELECT pubkey FROM deposit_receipts WHERE withdrawal_credentials IN (%v)
+	LIMIT $%v
+	`, credsFilter.String(), credsFilter.String(), len(args))
+
+	pubkeys := [][]byte{}
+	err := ReaderDb.Select(&pubkeys, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching deposit pubkeys: %v", err)
+		return nil
+	}
+	return pubkeys
+}
+
+func InsertValidatorDoppelgangers(doppelgangers []*dbtypes.ValidatorDoppelganger, tx *sqlx.Tx) error {
+	if len(doppelgangers) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO validator_doppelgangers (validator_index, epoch, slot, block_root, vote_root, conflict_slot, conflict_block_root, conflict_vote_root) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO validator_doppelgangers (validator_index, epoch, slot, block_root, vote_root, conflict_slot, conflict_block_root, conflict_vote_root) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(doppelgangers)*8)
+	for i, doppelganger := range doppelgangers {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4, argIdx+5, argIdx+6, argIdx+7, argIdx+8)
+		args[argIdx] = doppelganger.ValidatorIndex
+		args[argIdx+1] = doppelganger.Epoch
+		args[argIdx+2] = doppelganger.Slot
+		args[argIdx+3] = doppelganger.BlockRoot
+		args[argIdx+4] = doppelganger.VoteRoot
+		args[argIdx+5] = doppelganger.ConflictSlot
+		args[argIdx+6] = doppelganger.ConflictBlockRoot
+		args[argIdx+7] = doppelganger.ConflictVoteRoot
+		argIdx += 8
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (validator_index, epoch) DO NOTHING`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetValidatorDoppelgangers returns the latest conflicting votes of the validator, newest first
+func GetValidatorDoppelgangers(validatorIndex uint64, limit uint64) []*dbtypes.ValidatorDoppelganger {
+	doppelgangers := []*dbtypes.ValidatorDoppelganger{}
+	err := ReaderDb.Select(&doppelgangers, `
+	SELECT validator_index, epoch, slot, block_root, vote_root, conflict_slot, conflict_block_root, conflict_vote_root
+	FROM validator_doppelgangers
+	WHERE validator_index = $1
+	ORDER BY epoch DESC
+	LIMIT $2
+	`, validatorIndex, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching validator doppelgangers: %v", err)
+		return nil
+	}
+	return doppelgangers
+}
+
+// UpdateDailyStats rebuilds the rollup of a day from the epochs of the day (firstEpoch - lastEpoch).
+// it's called for every persisted epoch, so the rollup stays in sync when epochs are rewritten.
+func UpdateDailyStats(day uint64, firstEpoch uint64, lastEpoch uint64, slotsPerEpoch uint64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO daily_stats (
+				day, epoch_count, first_epoch, last_epoch, eligible, voted_target, voted_head, voted_total, block_count,
+				orphaned_count, missed_count, deposit_count, exit_count, withdraw_count, withdraw_amount, eth_transaction_count, blob_count,
+				tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+			)
+			SELECT
+				$1, COUNT(*), MIN(epoch), MAX(epoch), SUM(eligible), SUM(voted_target), SUM(voted_head), SUM(voted_total), SUM(block_count),
+				SUM(orphaned_count), COUNT(*) * $4 - SUM(block_count), SUM(deposit_count), SUM(exit_count), SUM(withdraw_count), SUM(withdraw_amount),
+				SUM(eth_transaction_count), SUM(blob_count),
+				SUM(tx_legacy_count), SUM(tx_access_list_count), SUM(tx_dynamic_fee_count), SUM(tx_blob_count), SUM(tx_setcode_count)
+			FROM epochs
+			WHERE epoch >= $2 AND epoch <= $3
+			HAVING COUNT(*) > 0
+			ON CONFLICT (day) DO UPDATE SET
+				epoch_count = excluded.epoch_count,
+				first_epoch = excluded.first_epoch,
+				last_epoch = excluded.last_epoch,
+				eligible = excluded.eligible,
+				voted_target = excluded.voted_target,
+				voted_head = excluded.voted_head,
+				voted_total = excluded.voted_total,
+				block_count = excluded.block_count,
+				orphaned_count = excluded.orphaned_count,
+				missed_count = excluded.missed_count,
+				deposit_count = excluded.deposit_count,
+				exit_count = excluded.exit_count,
+				withdraw_count = excluded.withdraw_count,
+				withdraw_amount = excluded.withdraw_amount,
+				eth_transaction_count = excluded.eth_transaction_count,
+				blob_count = excluded.blob_count,
+				tx_legacy_count = excluded.tx_legacy_count,
+				tx_access_list_count = excluded.tx_access_list_count,
+				tx_dynamic_fee_count = excluded.tx_dynamic_fee_count,
+				tx_blob_count = excluded.tx_blob_count,
+				tx_setcode_count = excluded.tx_setcode_count`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO daily_stats (
+				day, epoch_count, first_epoch, last_epoch, eligible, voted_target, voted_head, voted_total, block_count,
+				orphaned_count, missed_count, deposit_count, exit_count, withdraw_count, withdraw_amount, eth_transaction_count, blob_count,
+				tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+			)
+			SELECT
+				$1, COUNT(*), MIN(epoch), MAX(epoch), SUM(eligible), SUM(voted_target), SUM(voted_head), SUM(voted_total), SUM(block_count),
+				SUM(orphaned_count), COUNT(*) * $4 - SUM(block_count), SUM(deposit_count), SUM(exit_count), SUM(withdraw_count), SUM(withdraw_amount),
+				SUM(eth_transaction_count), SUM(blob_count),
+				SUM(tx_legacy_count), SUM(tx_access_list_count), SUM(tx_dynamic_fee_count), SUM(tx_blob_count), SUM(tx_setcode_count)
+			FROM epochs
+			WHERE epoch >= $2 AND epoch <= $3
+			HAVING COUNT(*) > 0`,
+	}), day, firstEpoch, lastEpoch, slotsPerEpoch)
+	return err
+}
+
+// GetDailyStats returns the daily rollups of the day range, oldest first
+func GetDailyStats(firstDay uint64, lastDay uint64) []*dbtypes.DailyStats {
+	dailyStats := []*dbtypes.DailyStats{}
+	err := ReaderDb.Select(&dailyStats, `
+	SELECT
+		day, epoch_count, first_epoch, last_epoch, eligible, voted_target, voted_head, voted_total, block_count,
+		orphaned_count, missed_count, deposit_count, exit_count, withdraw_count, withdraw_amount, eth_transaction_count, blob_count,
+		tx_legacy_count, tx_access_list_count, tx_dynamic_fee_count, tx_blob_count, tx_setcode_count
+	FROM daily_stats
+	WHERE day >= $1 AND day <= $2
+	ORDER BY day ASC
+	`, firstDay, lastDay)
+	if err != nil {
+		logger.Errorf("Error while fetching daily stats: %v", err)
+		return nil
+	}
+	return dailyStats
+}
+
+// InsertValidatorNameClaim adds a name claim to the moderation queue, a repeated claim for the same name is queued again
+func InsertValidatorNameClaim(claim *dbtypes.ValidatorNameClaim, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO validator_name_claims (validator_index, name, signature, status, created, reviewed)
+			VALUES ($1, $2, $3, $4, $5, 0)
+			ON CONFLICT (validator_index, name) DO UPDATE SET
+				signature = excluded.signature,
+				status = excluded.status,
+				created = excluded.created,
+				reviewed = 0`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO validator_name_claims (validator_index, name, signature, status, created, reviewed)
+			VALUES ($1, $2, $3, $4, $5, 0)`,
+	}), claim.ValidatorIndex, claim.Name, claim.Signature, claim.Status, claim.Created)
+	return err
+}
+
+// GetValidatorNameClaims returns the name claims with the given status, oldest first
+func GetValidatorNameClaims(status uint8, limit uint64) []*dbtypes.ValidatorNameClaim {
+	claims := []*dbtypes.ValidatorNameClaim{}
+	err := ReaderDb.Select(&claims, `
+	SELECT validator_index, name, signature, status, created, reviewed
+	FROM validator_name_claims
+	WHERE status = $1
+	ORDER BY created ASC
+	LIMIT $2
+	`, status, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching validator name claims: %v", err)
+		return nil
+	}
+	return claims
+}
+
+// UpdateValidatorNameClaimStatus sets the moderation result of a claim.
+// Approving a claim supersedes all previously approved claims of the validator.
+func UpdateValidatorNameClaimStatus(validatorIndex uint64, name string, status uint8, reviewed uint64, tx *sqlx.Tx) error {
+	res, err := tx.Exec(`
+		UPDATE validator_name_claims SET status = $3, reviewed = $4
+		WHERE validator_index = $1 AND name = $2`,
+		validatorIndex, name, status, reviewed)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("name claim not found")
+	}
+	if status != dbtypes.NameClaimStatusApproved {
+		return nil
+	}
+	_, err = tx.Exec(`
+		UPDATE validator_name_claims SET status = $3
+		WHERE validator_index = $1 AND name != $2 AND status = $4`,
+		validatorIndex, name, dbtypes.NameClaimStatusSuperseded, dbtypes.NameClaimStatusApproved)
+	return err
+}
+
+// InsertValidatorStatusChanges adds lifecycle status changes, the first recorded epoch of a status is kept
+func InsertValidatorStatusChanges(changes []*dbtypes.ValidatorStatusChange, tx *sqlx.Tx) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO validator_status_changes (validator_index, status, epoch) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR IGNORE INTO validator_status_changes (validator_index, status, epoch) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(changes)*3)
+	for i, change := range changes {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3)
+		args[argIdx] = change.ValidatorIndex
+		args[argIdx+1] = change.Status
+		args[argIdx+2] = change.Epoch
+		argIdx += 3
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (validator_index, status) DO NOTHING`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetValidatorStatusChanges returns the lifecycle status changes of the given validators, ordered by validator & status
+func GetValidatorStatusChanges(validatorIndexes []uint64) []*dbtypes.ValidatorStatusChange {
+	changes := []*dbtypes.ValidatorStatusChange{}
+	if len(validatorIndexes) == 0 {
+		return changes
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, `
+	SELECT validator_index, status, epoch
+	FROM validator_status_changes
+	WHERE validator_index IN (`)
+	args := make([]any, len(validatorIndexes))
+	for i, validatorIndex := range validatorIndexes {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "$%v", i+1)
+		args[i] = validatorIndex
+	}
+	fmt.Fprint(&sql, `)
+	ORDER BY validator_index ASC, status ASC`)
+	err := ReaderDb.Select(&changes, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching validator status changes: %v", err)
+		return nil
+	}
+	return changes
+}
+
+// GetValidatorLatestStatuses returns the latest recorded lifecycle status of all validators
+func GetValidatorLatestStatuses() map[uint64]uint8 {
+	changes := []*dbtypes.ValidatorStatusChange{}
+	err := ReaderDb.Select(&changes, `
+	SELECT validator_index, MAX(status) AS status, MAX(epoch) AS epoch
+	FROM validator_status_changes
+	GROUP BY validator_index
+	`)
+	if err != nil {
+		logger.Errorf("Error while fetching validator statuses: %v", err)
+		return nil
+	}
+	statuses := make(map[uint64]uint8, len(changes))
+	for _, change := range changes {
+		statuses[change.ValidatorIndex] = change.Status
+	}
+	return statuses
+}
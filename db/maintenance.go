@@ -0,0 +1,56 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pk910/dora/dbtypes"
+)
+
+// VacuumDatabase reclaims the space of deleted rows and refreshes the planner statistics.
+// sqlite dbs that haven't been created in incremental auto vacuum mode are converted by a full vacuum on the first run.
+func VacuumDatabase() error {
+	switch DbEngine {
+	case dbtypes.DBEnginePgsql:
+		if _, err := WriterDb.Exec(`VACUUM (ANALYZE)`); err != nil {
+			return fmt.Errorf("error running vacuum: %v", err)
+		}
+	case dbtypes.DBEngineSqlite:
+		autoVacuum := 0
+		if err := WriterDb.Get(&autoVacuum, `PRAGMA auto_vacuum`); err != nil {
+			return fmt.Errorf("error reading auto vacuum mode: %v", err)
+		}
+		if autoVacuum != 2 {
+			// the mode change only takes effect with a vacuum on the same connection
+			logger.Infof("converting sqlite db to incremental auto vacuum mode")
+			if _, err := WriterDb.Exec(`PRAGMA auto_vacuum = INCREMENTAL; VACUUM;`); err != nil {
+				return fmt.Errorf("error converting to incremental vacuum: %v", err)
+			}
+		}
+		if _, err := WriterDb.Exec(`PRAGMA incremental_vacuum; ANALYZE;`); err != nil {
+			return fmt.Errorf("error running incremental vacuum: %v", err)
+		}
+	}
+	return nil
+}
+
+// ReindexDatabase rebuilds the indexes of all tables. pgsql rebuilds them concurrently, so the tables stay writable.
+func ReindexDatabase() error {
+	switch DbEngine {
+	case dbtypes.DBEnginePgsql:
+		tableNames := []string{}
+		if err := ReaderDb.Select(&tableNames, `SELECT relname FROM pg_stat_user_tables WHERE schemaname = 'public' ORDER BY relname`); err != nil {
+			return fmt.Errorf("error loading table names: %v", err)
+		}
+		for _, tableName := range tableNames {
+			if _, err := WriterDb.Exec(fmt.Sprintf(`REINDEX TABLE CONCURRENTLY public."%v"`, strings.ReplaceAll(tableName, `"`, `""`))); err != nil {
+				return fmt.Errorf("error rebuilding indexes of %v: %v", tableName, err)
+			}
+		}
+	case dbtypes.DBEngineSqlite:
+		if _, err := WriterDb.Exec(`REINDEX`); err != nil {
+			return fmt.Errorf("error rebuilding indexes: %v", err)
+		}
+	}
+	return nil
+}
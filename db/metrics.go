@@ -0,0 +1,246 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/pk910/dora/utils"
+)
+
+// QueryStats holds the execution metrics of a single sql statement
+type QueryStats struct {
+	Query    string        `json:"query"`
+	Count    uint64        `json:"count"`
+	Errors   uint64        `json:"errors"`
+	Slow     uint64        `json:"slow"`
+	Total    time.Duration `json:"total"`
+	Max      time.Duration `json:"max"`
+	LastSlow time.Time     `json:"last_slow"`
+}
+
+// PoolStats holds the connection pool metrics of a database connection
+type PoolStats struct {
+	Name  string      `json:"name"`
+	Stats sql.DBStats `json:"stats"`
+}
+
+var queryStatsMutex sync.Mutex
+var queryStats = map[string]*QueryStats{}
+
+var queryWhitespacePattern = regexp.MustCompile(`\s+`)
+var queryPlaceholderPattern = regexp.MustCompile(`\$[0-9]+`)
+var queryParamListPattern = regexp.MustCompile(`\?(?: ?, ?\?)+`)
+var queryTupleListPattern = regexp.MustCompile(`\(\?, \.\.\.\)(?: ?, ?\(\?, \.\.\.\))+`)
+
+const maxQueryStatsLength = 200
+
+// maxQueryStats limits the number of tracked statements, statements beyond the limit are counted as queryStatsOther
+const maxQueryStats = 500
+const queryStatsOther = "(other)"
+
+// normalizeQuery collapses the whitespace, placeholders & parameter lists of a statement, so the statements built
+// for a variable number of parameters (IN lists, multi row inserts) are tracked as a single statement
+func normalizeQuery(query string) string {
+	query = queryWhitespacePattern.ReplaceAllString(query, " ")
+	query = queryPlaceholderPattern.ReplaceAllString(query, "?")
+	query = queryParamListPattern.ReplaceAllString(query, "?, ...")
+	query = queryTupleListPattern.ReplaceAllString(query, "(?, ...), ...")
+	if len(query) > maxQueryStatsLength {
+		query = query[:maxQueryStatsLength]
+	}
+	return query
+}
+
+func trackQuery(query string, duration time.Duration, err error) {
+	query = normalizeQuery(query)
+
+	slowThreshold := utils.Config.Database.SlowQueryThreshold
+	isSlow := slowThreshold > 0 && duration >= slowThreshold
+	if isSlow {
+		logger.Warnf("slow query (%v ms): %v", duration.Milliseconds(), query)
+	}
+
+	queryStatsMutex.Lock()
+	defer queryStatsMutex.Unlock()
+	stats := queryStats[query]
+	if stats == nil && len(queryStats) >= maxQueryStats {
+		query = queryStatsOther
+		stats = queryStats[query]
+	}
+	if stats == nil {
+		stats = &QueryStats{
+			Query: query,
+		}
+		queryStats[query] = stats
+	}
+	stats.Count++
+	stats.Total += duration
+	if duration > stats.Max {
+		stats.Max = duration
+	}
+	if err != nil {
+		stats.Errors++
+	}
+	if isSlow {
+		stats.Slow++
+		stats.LastSlow = time.Now()
+	}
+}
+
+// GetQueryStats returns the execution metrics of all statements sorted by total execution time
+func GetQueryStats() []*QueryStats {
+	queryStatsMutex.Lock()
+	stats := make([]*QueryStats, 0, len(queryStats))
+	for _, queryStat := range queryStats {
+		statsCopy := *queryStat
+		stats = append(stats, &statsCopy)
+	}
+	queryStatsMutex.Unlock()
+
+	sort.Slice(stats, func(a, b int) bool {
+		return stats[a].Total > stats[b].Total
+	})
+	return stats
+}
+
+// GetPoolStats returns the connection pool metrics of the reader & writer connections
+func GetPoolStats() []*PoolStats {
+	stats := []*PoolStats{
+		{Name: "writer", Stats: WriterDb.Stats()},
+	}
+	if ReaderDb != WriterDb {
+		stats = append(stats, &PoolStats{Name: "reader", Stats: ReaderDb.Stats()})
+	}
+	return stats
+}
+
+// openMetricsDb opens a database connection that records the execution time of all statements
+func openMetricsDb(driverName string, dsn string) (*sqlx.DB, error) {
+	baseDb, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	baseDriver := baseDb.Driver()
+	baseDb.Close()
+
+	var connector driver.Connector
+	if driverCtx, isDriverCtx := baseDriver.(driver.DriverContext); isDriverCtx {
+		connector, err = driverCtx.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		connector = &dsnConnector{dsn: dsn, driver: baseDriver}
+	}
+
+	return sqlx.NewDb(sql.OpenDB(&metricsConnector{connector}), driverName), nil
+}
+
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c *dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type metricsConnector struct {
+	connector driver.Connector
+}
+
+func (c *metricsConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsConn{conn}, nil
+}
+
+func (c *metricsConnector) Driver() driver.Driver {
+	return c.connector.Driver()
+}
+
+// metricsConn wraps a driver connection and forwards all optional driver interfaces to it
+type metricsConn struct {
+	driver.Conn
+}
+
+func (c *metricsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, isQueryer := c.Conn.(driver.QueryerContext)
+	if !isQueryer {
+		return nil, driver.ErrSkip
+	}
+	t0 := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		trackQuery(query, time.Since(t0), err)
+	}
+	return rows, err
+}
+
+func (c *metricsConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, isExecer := c.Conn.(driver.ExecerContext)
+	if !isExecer {
+		return nil, driver.ErrSkip
+	}
+	t0 := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	if err != driver.ErrSkip {
+		trackQuery(query, time.Since(t0), err)
+	}
+	return result, err
+}
+
+func (c *metricsConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, isPreparer := c.Conn.(driver.ConnPrepareContext); isPreparer {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *metricsConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, isBeginner := c.Conn.(driver.ConnBeginTx); isBeginner {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *metricsConn) Ping(ctx context.Context) error {
+	if pinger, isPinger := c.Conn.(driver.Pinger); isPinger {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *metricsConn) ResetSession(ctx context.Context) error {
+	if resetter, isResetter := c.Conn.(driver.SessionResetter); isResetter {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *metricsConn) IsValid() bool {
+	if validator, isValidator := c.Conn.(driver.Validator); isValidator {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *metricsConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, isChecker := c.Conn.(driver.NamedValueChecker); isChecker {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
@@ -0,0 +1,42 @@
+package db
+
+import "testing"
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "whitespace",
+			query:    "SELECT root\n\t\tFROM blocks\n\t\tWHERE slot = $1",
+			expected: "SELECT root FROM blocks WHERE slot = ?",
+		},
+		{
+			name:     "in list",
+			query:    "SELECT root FROM blocks WHERE slot IN ($1, $2, $3) AND proposer = $4",
+			expected: "SELECT root FROM blocks WHERE slot IN (?, ...) AND proposer = ?",
+		},
+		{
+			name:     "sqlite in list",
+			query:    "SELECT root FROM blocks WHERE slot IN (?,?,?,?)",
+			expected: "SELECT root FROM blocks WHERE slot IN (?, ...)",
+		},
+		{
+			name:     "multi row insert",
+			query:    "INSERT INTO epoch_votes (epoch, validator, votes) VALUES ($1,$2,$3),($4,$5,$6),($7,$8,$9)",
+			expected: "INSERT INTO epoch_votes (epoch, validator, votes) VALUES (?, ...), ...",
+		},
+		{
+			name:     "single row insert",
+			query:    "INSERT INTO blocks (root, slot) VALUES ($1, $2)",
+			expected: "INSERT INTO blocks (root, slot) VALUES (?, ...)",
+		},
+	}
+	for _, test := range tests {
+		if normalized := normalizeQuery(test.query); normalized != test.expected {
+			t.Errorf("%v: unexpected query %q", test.name, normalized)
+		}
+	}
+}
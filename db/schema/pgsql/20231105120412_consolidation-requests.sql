@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."consolidation_requests"
+(
+    "slot" bigint NOT NULL,
+    "idx" integer NOT NULL,
+    "block_root" bytea NOT NULL,
+    "block_number" bigint NOT NULL,
+    "source_address" bytea NOT NULL,
+    "source_pubkey" bytea NOT NULL,
+    "source_index" bigint NULL,
+    "source_balance" bigint NOT NULL,
+    "target_pubkey" bytea NOT NULL,
+    "target_index" bigint NULL,
+    "target_balance" bigint NOT NULL,
+    CONSTRAINT "consolidation_requests_pkey" PRIMARY KEY ("slot", "idx")
+);
+
+CREATE INDEX IF NOT EXISTS "consolidation_requests_source_index_idx"
+    ON public."consolidation_requests"
+    ("source_index" ASC NULLS LAST);
+
+CREATE INDEX IF NOT EXISTS "consolidation_requests_target_index_idx"
+    ON public."consolidation_requests"
+    ("target_index" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."validator_uptime"
+(
+    "epoch" bigint NOT NULL,
+    "name" character varying(250) NOT NULL,
+    "day" bigint NOT NULL,
+    "duties" bigint NOT NULL DEFAULT 0,
+    "attested" bigint NOT NULL DEFAULT 0,
+    CONSTRAINT "validator_uptime_pkey" PRIMARY KEY ("epoch", "name")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_uptime_day_idx"
+    ON public."validator_uptime" 
+    ("day" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE IF EXISTS public."blocks"
+    ADD "eth_fee_recipient" bytea NULL;
+
+CREATE INDEX IF NOT EXISTS "blocks_eth_fee_recipient_idx"
+    ON public."blocks" 
+    ("eth_fee_recipient" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
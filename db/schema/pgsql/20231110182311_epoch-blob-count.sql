@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE IF EXISTS public."epochs"
+    ADD "blob_count" integer NOT NULL DEFAULT 0;
+
+ALTER TABLE IF EXISTS public."unfinalized_epochs"
+    ADD "blob_count" integer NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."validator_metadata"
+(
+    "index" bigint NOT NULL,
+    "key" character varying(100) NOT NULL,
+    "value" character varying(250) NOT NULL,
+    PRIMARY KEY ("index", "key")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_metadata_key_value_idx"
+    ON public."validator_metadata" 
+    ("key" ASC NULLS LAST, "value" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
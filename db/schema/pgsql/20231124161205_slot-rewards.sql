@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."slot_rewards"
+(
+    "slot" bigint NOT NULL,
+    "proposer" bigint NOT NULL,
+    "root" bytea NULL,
+    "cl_reward" bigint NOT NULL,
+    "mev_bid" bigint NOT NULL,
+    "mev_payment" bigint NOT NULL,
+    "mev_relay" TEXT NOT NULL,
+    "expected_reward" bigint NOT NULL,
+    "actual_reward" bigint NOT NULL,
+    PRIMARY KEY ("slot")
+);
+
+CREATE INDEX IF NOT EXISTS "slot_rewards_proposer_idx"
+    ON public."slot_rewards"
+    ("proposer" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
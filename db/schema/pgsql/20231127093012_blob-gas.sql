@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."blob_gas"
+(
+    "slot" bigint NOT NULL,
+    "root" bytea NOT NULL,
+    "blob_gas_used" bigint NOT NULL,
+    "excess_blob_gas" bigint NOT NULL,
+    PRIMARY KEY ("slot")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."archived_blocks"
+(
+    "root" bytea NOT NULL,
+    "slot" bigint NOT NULL,
+    "header_ver" int NOT NULL,
+    "header_ssz" bytea NOT NULL,
+    "block_ver" int NOT NULL,
+    "block_ssz" bytea NOT NULL,
+    CONSTRAINT "archived_blocks_pkey" PRIMARY KEY ("root")
+);
+
+CREATE INDEX IF NOT EXISTS "archived_blocks_slot_idx"
+    ON public."archived_blocks"
+    ("slot" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
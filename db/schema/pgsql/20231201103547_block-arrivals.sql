@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."block_arrivals"
+(
+    "root" bytea NOT NULL,
+    "slot" bigint NOT NULL,
+    "client" character varying(100) NOT NULL,
+    "delay" bigint NOT NULL,
+    CONSTRAINT "block_arrivals_pkey" PRIMARY KEY ("root", "client")
+);
+
+CREATE INDEX IF NOT EXISTS "block_arrivals_slot_idx"
+    ON public."block_arrivals"
+    ("slot" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE INDEX IF NOT EXISTS "validator_names_name_trgm_idx"
+    ON public."validator_names" USING gin
+    ("name" gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "blocks_proposer_slot_idx"
+    ON public."blocks"
+    ("proposer" ASC NULLS LAST, "slot" DESC NULLS LAST);
+
+CREATE INDEX IF NOT EXISTS "slot_assignments_proposer_slot_idx"
+    ON public."slot_assignments"
+    ("proposer" ASC NULLS LAST, "slot" DESC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."block_witnesses"
+(
+    "slot" bigint NOT NULL,
+    "root" bytea NOT NULL,
+    "stem_count" bigint NOT NULL,
+    "key_count" bigint NOT NULL,
+    "write_count" bigint NOT NULL,
+    "proof_size" bigint NOT NULL,
+    "witness_size" bigint NOT NULL,
+    PRIMARY KEY ("slot")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."validator_uptime"
+    ADD COLUMN IF NOT EXISTS "correct_target" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "correct_head" bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS public."validator_vote_stats"
+(
+    "validator_index" bigint NOT NULL,
+    "first_epoch" bigint NOT NULL,
+    "last_epoch" bigint NOT NULL,
+    "duties" bigint NOT NULL DEFAULT 0,
+    "attested" bigint NOT NULL DEFAULT 0,
+    "correct_target" bigint NOT NULL DEFAULT 0,
+    "correct_head" bigint NOT NULL DEFAULT 0,
+    CONSTRAINT "validator_vote_stats_pkey" PRIMARY KEY ("validator_index")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."deposits"
+(
+    "slot" bigint NOT NULL,
+    "idx" integer NOT NULL,
+    "block_root" bytea NOT NULL,
+    "pubkey" bytea NOT NULL,
+    "withdrawal_credentials" bytea NOT NULL,
+    "amount" bigint NOT NULL,
+    CONSTRAINT "deposits_pkey" PRIMARY KEY ("slot", "idx")
+);
+
+CREATE INDEX IF NOT EXISTS "deposits_pubkey_idx"
+    ON public."deposits"
+    ("pubkey" ASC NULLS LAST);
+
+CREATE TABLE IF NOT EXISTS public."validator_doppelgangers"
+(
+    "validator_index" bigint NOT NULL,
+    "epoch" bigint NOT NULL,
+    "slot" bigint NOT NULL,
+    "block_root" bytea NOT NULL,
+    "vote_root" bytea NOT NULL,
+    "conflict_slot" bigint NOT NULL,
+    "conflict_block_root" bytea NOT NULL,
+    "conflict_vote_root" bytea NOT NULL,
+    CONSTRAINT "validator_doppelgangers_pkey" PRIMARY KEY ("validator_index", "epoch")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."validator_name_claims"
+(
+    "validator_index" bigint NOT NULL,
+    "name" character varying(250) NOT NULL,
+    "signature" bytea NOT NULL,
+    "status" smallint NOT NULL DEFAULT 0,
+    "created" bigint NOT NULL,
+    "reviewed" bigint NOT NULL DEFAULT 0,
+    CONSTRAINT "validator_name_claims_pkey" PRIMARY KEY ("validator_index", "name")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_name_claims_status_idx"
+    ON public."validator_name_claims"
+    ("status" ASC NULLS LAST, "created" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."validator_status_changes"
+(
+    "validator_index" bigint NOT NULL,
+    "status" smallint NOT NULL,
+    "epoch" bigint NOT NULL,
+    CONSTRAINT "validator_status_changes_pkey" PRIMARY KEY ("validator_index", "status")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."deposit_receipts"
+(
+    "slot" bigint NOT NULL,
+    "idx" integer NOT NULL,
+    "block_root" bytea NOT NULL,
+    "block_number" bigint NOT NULL,
+    "deposit_index" bigint NOT NULL,
+    "pubkey" bytea NOT NULL,
+    "withdrawal_credentials" bytea NOT NULL,
+    "amount" bigint NOT NULL,
+    CONSTRAINT "deposit_receipts_pkey" PRIMARY KEY ("slot", "idx")
+);
+
+CREATE INDEX IF NOT EXISTS "deposit_receipts_pubkey_idx"
+    ON public."deposit_receipts"
+    ("pubkey" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."table_size_stats"
+(
+    "day" bigint NOT NULL,
+    "table_name" character varying(100) NOT NULL,
+    "row_count" bigint NOT NULL,
+    "size" bigint NOT NULL,
+    CONSTRAINT "table_size_stats_pkey" PRIMARY KEY ("day", "table_name")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
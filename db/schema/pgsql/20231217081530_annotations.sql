@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."annotations"
+(
+    "id" bigint NOT NULL,
+    "type" smallint NOT NULL,
+    "target" bigint NOT NULL,
+    "root" bytea NULL,
+    "author" character varying(100) NOT NULL,
+    "note" text NOT NULL,
+    "created" bigint NOT NULL,
+    CONSTRAINT "annotations_pkey" PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "annotations_type_target_idx"
+    ON public."annotations"
+    ("type" ASC NULLS LAST, "target" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
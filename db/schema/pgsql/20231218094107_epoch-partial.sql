@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE IF EXISTS public."epochs"
+    ADD "partial" smallint NOT NULL DEFAULT 0;
+
+ALTER TABLE IF EXISTS public."unfinalized_epochs"
+    ADD "partial" smallint NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "epochs_partial_idx"
+    ON public."epochs"
+    ("partial" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
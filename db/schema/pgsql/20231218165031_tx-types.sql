@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."epochs"
+    ADD COLUMN IF NOT EXISTS "tx_legacy_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_access_list_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_dynamic_fee_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_blob_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_setcode_count" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE public."unfinalized_epochs"
+    ADD COLUMN IF NOT EXISTS "tx_legacy_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_access_list_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_dynamic_fee_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_blob_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_setcode_count" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE public."daily_stats"
+    ADD COLUMN IF NOT EXISTS "tx_legacy_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_access_list_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_dynamic_fee_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_blob_count" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "tx_setcode_count" bigint NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
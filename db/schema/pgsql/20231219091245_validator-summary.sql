@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."validator_summary"
+(
+    "validator_index" bigint NOT NULL,
+    "last_epoch" bigint NOT NULL,
+    "proposed_blocks" bigint NOT NULL DEFAULT 0,
+    "missed_blocks" bigint NOT NULL DEFAULT 0,
+    "last_attestation_epoch" bigint NULL,
+    "withdrawal_count" bigint NOT NULL DEFAULT 0,
+    "withdrawn_amount" bigint NOT NULL DEFAULT 0,
+    "proposer_income" bigint NOT NULL DEFAULT 0,
+    CONSTRAINT "validator_summary_pkey" PRIMARY KEY ("validator_index")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."validator_uptime"
+    ADD COLUMN IF NOT EXISTS "late" bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS public."epoch_committee_stats"
+(
+    "epoch" bigint NOT NULL,
+    "slot" bigint NOT NULL,
+    "committee_index" bigint NOT NULL,
+    "duties" bigint NOT NULL DEFAULT 0,
+    "missed" bigint NOT NULL DEFAULT 0,
+    "late" bigint NOT NULL DEFAULT 0,
+    "top_name" character varying(250) NULL,
+    "top_name_count" bigint NOT NULL DEFAULT 0,
+    CONSTRAINT "epoch_committee_stats_pkey" PRIMARY KEY ("slot", "committee_index")
+);
+
+CREATE INDEX IF NOT EXISTS "epoch_committee_stats_epoch_idx"
+    ON public."epoch_committee_stats"
+    ("epoch" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE IF EXISTS public."blocks"
+    ADD "eth_extra_data" bytea NULL,
+    ADD "cl_client" character varying(50) NOT NULL DEFAULT '',
+    ADD "el_client" character varying(50) NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
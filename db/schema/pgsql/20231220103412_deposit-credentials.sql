@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE INDEX IF NOT EXISTS "deposits_withdrawal_credentials_idx"
+    ON public."deposits"
+    ("withdrawal_credentials" ASC NULLS LAST);
+
+CREATE INDEX IF NOT EXISTS "deposit_receipts_withdrawal_credentials_idx"
+    ON public."deposit_receipts"
+    ("withdrawal_credentials" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE INDEX IF NOT EXISTS "epochs_orphaned_count_idx"
+    ON public."epochs"
+    ("orphaned_count" ASC NULLS LAST);
+
+-- target vote participation in basis points, matches the expression used by the epoch filters
+CREATE INDEX IF NOT EXISTS "epochs_participation_idx"
+    ON public."epochs"
+    ((voted_target * 10000 / NULLIF(eligible, 0)) ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
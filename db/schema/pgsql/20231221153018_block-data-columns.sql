@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."block_data_columns"
+(
+    "root" bytea NOT NULL,
+    "slot" bigint NOT NULL,
+    "client" character varying(100) NOT NULL,
+    "columns" bytea NOT NULL,
+    CONSTRAINT "block_data_columns_pkey" PRIMARY KEY ("root", "client")
+);
+
+CREATE INDEX IF NOT EXISTS "block_data_columns_slot_idx"
+    ON public."block_data_columns"
+    ("slot" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
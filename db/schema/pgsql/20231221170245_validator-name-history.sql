@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."validator_names"
+    ADD "source" character varying(50) NOT NULL DEFAULT '',
+    ADD "source_name" character varying(250) NOT NULL DEFAULT '',
+    ADD "updated" bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS public."validator_name_history"
+(
+    "index" bigint NOT NULL,
+    "changed" bigint NOT NULL,
+    "name" character varying(250) NOT NULL,
+    "source" character varying(50) NOT NULL,
+    "source_name" character varying(250) NOT NULL,
+    PRIMARY KEY ("index", "changed")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_name_history_changed_idx"
+    ON public."validator_name_history"
+    ("changed" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."slot_committee_participation"
+(
+    "slot" bigint NOT NULL,
+    "epoch" bigint NOT NULL,
+    "participation" bytea NOT NULL,
+    CONSTRAINT "slot_committee_participation_pkey" PRIMARY KEY ("slot")
+);
+
+CREATE INDEX IF NOT EXISTS "slot_committee_participation_epoch_idx"
+    ON public."slot_committee_participation"
+    ("epoch" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."finality_checkpoints"
+(
+    "checkpoint_type" smallint NOT NULL,
+    "epoch" bigint NOT NULL,
+    "root" bytea NOT NULL,
+    "prev_epoch" bigint NOT NULL,
+    "seen_time" bigint NOT NULL,
+    CONSTRAINT "finality_checkpoints_pkey" PRIMARY KEY ("checkpoint_type", "epoch")
+);
+
+CREATE INDEX IF NOT EXISTS "finality_checkpoints_epoch_idx"
+    ON public."finality_checkpoints"
+    ("epoch" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE IF EXISTS public."blocks"
+    ADD "body_root" bytea NULL;
+
+CREATE INDEX IF NOT EXISTS "blocks_body_root_idx"
+    ON public."blocks"
+    ("body_root" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "epoch_credential_stats"
+(
+    "epoch" BIGINT NOT NULL,
+    "bls_count" BIGINT NOT NULL DEFAULT 0,
+    "execution_count" BIGINT NOT NULL DEFAULT 0,
+    "compounding_count" BIGINT NOT NULL DEFAULT 0,
+    "other_count" BIGINT NOT NULL DEFAULT 0,
+    CONSTRAINT "epoch_credential_stats_pkey" PRIMARY KEY ("epoch")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
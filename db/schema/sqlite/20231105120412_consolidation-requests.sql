@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "consolidation_requests"
+(
+    "slot" bigint NOT NULL,
+    "idx" integer NOT NULL,
+    "block_root" BLOB NOT NULL,
+    "block_number" bigint NOT NULL,
+    "source_address" BLOB NOT NULL,
+    "source_pubkey" BLOB NOT NULL,
+    "source_index" bigint NULL,
+    "source_balance" bigint NOT NULL,
+    "target_pubkey" BLOB NOT NULL,
+    "target_index" bigint NULL,
+    "target_balance" bigint NOT NULL,
+    PRIMARY KEY ("slot", "idx")
+);
+
+CREATE INDEX IF NOT EXISTS "consolidation_requests_source_index_idx"
+    ON "consolidation_requests"
+    ("source_index" ASC);
+
+CREATE INDEX IF NOT EXISTS "consolidation_requests_target_index_idx"
+    ON "consolidation_requests"
+    ("target_index" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
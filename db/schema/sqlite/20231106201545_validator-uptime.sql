@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "validator_uptime"
+(
+    "epoch" BIGINT NOT NULL,
+    "name" TEXT NOT NULL,
+    "day" BIGINT NOT NULL,
+    "duties" BIGINT NOT NULL DEFAULT 0,
+    "attested" BIGINT NOT NULL DEFAULT 0,
+    CONSTRAINT "validator_uptime_pkey" PRIMARY KEY ("epoch", "name")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_uptime_day_idx"
+    ON "validator_uptime" 
+    ("day" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "blocks"
+    ADD "eth_fee_recipient" BLOB NULL;
+
+CREATE INDEX IF NOT EXISTS "blocks_eth_fee_recipient_idx"
+    ON "blocks" 
+    ("eth_fee_recipient" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "epochs"
+    ADD "blob_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "unfinalized_epochs"
+    ADD "blob_count" INTEGER NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "watched_withdrawals"
+(
+    "address" BLOB NOT NULL,
+    "epoch" bigint NOT NULL,
+    "count" integer NOT NULL,
+    "amount" bigint NOT NULL,
+    PRIMARY KEY ("address", "epoch")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
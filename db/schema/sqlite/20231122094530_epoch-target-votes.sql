@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "epoch_target_votes"
+(
+    "epoch" bigint NOT NULL,
+    "target_root" BLOB NOT NULL,
+    "vote_amount" bigint NOT NULL,
+    PRIMARY KEY ("epoch", "target_root")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "archived_blocks"
+(
+    "root" BLOB NOT NULL,
+    "slot" bigint NOT NULL,
+    "header_ver" int NOT NULL,
+    "header_ssz" BLOB NOT NULL,
+    "block_ver" int NOT NULL,
+    "block_ssz" BLOB NOT NULL,
+    PRIMARY KEY ("root")
+);
+
+CREATE INDEX IF NOT EXISTS "archived_blocks_slot_idx"
+    ON "archived_blocks"
+    ("slot" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
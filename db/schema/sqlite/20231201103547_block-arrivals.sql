@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "block_arrivals"
+(
+    "root" BLOB NOT NULL,
+    "slot" bigint NOT NULL,
+    "client" TEXT NOT NULL,
+    "delay" bigint NOT NULL,
+    PRIMARY KEY ("root", "client")
+);
+
+CREATE INDEX IF NOT EXISTS "block_arrivals_slot_idx"
+    ON "block_arrivals"
+    ("slot" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE INDEX IF NOT EXISTS "blocks_proposer_slot_idx"
+    ON "blocks"
+    ("proposer" ASC, "slot" DESC);
+
+CREATE INDEX IF NOT EXISTS "slot_assignments_proposer_slot_idx"
+    ON "slot_assignments"
+    ("proposer" ASC, "slot" DESC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
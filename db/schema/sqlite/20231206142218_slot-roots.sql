@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "slot_roots"
+(
+    "slot" bigint NOT NULL,
+    "block_root" BLOB NOT NULL,
+    "state_root" BLOB NULL,
+    "missed" smallint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("slot")
+);
+
+CREATE INDEX IF NOT EXISTS "slot_roots_block_root_idx"
+    ON "slot_roots"
+    ("block_root" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "validator_uptime"
+    ADD "correct_target" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "validator_uptime"
+    ADD "correct_head" INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "validator_vote_stats"
+(
+    "validator_index" bigint NOT NULL,
+    "first_epoch" bigint NOT NULL,
+    "last_epoch" bigint NOT NULL,
+    "duties" bigint NOT NULL DEFAULT 0,
+    "attested" bigint NOT NULL DEFAULT 0,
+    "correct_target" bigint NOT NULL DEFAULT 0,
+    "correct_head" bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("validator_index")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
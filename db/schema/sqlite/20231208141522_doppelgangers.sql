@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "deposits"
+(
+    "slot" bigint NOT NULL,
+    "idx" integer NOT NULL,
+    "block_root" BLOB NOT NULL,
+    "pubkey" BLOB NOT NULL,
+    "withdrawal_credentials" BLOB NOT NULL,
+    "amount" bigint NOT NULL,
+    PRIMARY KEY ("slot", "idx")
+);
+
+CREATE INDEX IF NOT EXISTS "deposits_pubkey_idx"
+    ON "deposits"
+    ("pubkey" ASC);
+
+CREATE TABLE IF NOT EXISTS "validator_doppelgangers"
+(
+    "validator_index" bigint NOT NULL,
+    "epoch" bigint NOT NULL,
+    "slot" bigint NOT NULL,
+    "block_root" BLOB NOT NULL,
+    "vote_root" BLOB NOT NULL,
+    "conflict_slot" bigint NOT NULL,
+    "conflict_block_root" BLOB NOT NULL,
+    "conflict_vote_root" BLOB NOT NULL,
+    PRIMARY KEY ("validator_index", "epoch")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "daily_stats"
+(
+    "day" bigint NOT NULL,
+    "epoch_count" bigint NOT NULL DEFAULT 0,
+    "first_epoch" bigint NOT NULL DEFAULT 0,
+    "last_epoch" bigint NOT NULL DEFAULT 0,
+    "eligible" bigint NOT NULL DEFAULT 0,
+    "voted_target" bigint NOT NULL DEFAULT 0,
+    "voted_head" bigint NOT NULL DEFAULT 0,
+    "voted_total" bigint NOT NULL DEFAULT 0,
+    "block_count" bigint NOT NULL DEFAULT 0,
+    "orphaned_count" bigint NOT NULL DEFAULT 0,
+    "missed_count" bigint NOT NULL DEFAULT 0,
+    "deposit_count" bigint NOT NULL DEFAULT 0,
+    "exit_count" bigint NOT NULL DEFAULT 0,
+    "withdraw_count" bigint NOT NULL DEFAULT 0,
+    "withdraw_amount" bigint NOT NULL DEFAULT 0,
+    "eth_transaction_count" bigint NOT NULL DEFAULT 0,
+    "blob_count" bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("day")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "deposit_receipts"
+(
+    "slot" bigint NOT NULL,
+    "idx" integer NOT NULL,
+    "block_root" BLOB NOT NULL,
+    "block_number" bigint NOT NULL,
+    "deposit_index" bigint NOT NULL,
+    "pubkey" BLOB NOT NULL,
+    "withdrawal_credentials" BLOB NOT NULL,
+    "amount" bigint NOT NULL,
+    PRIMARY KEY ("slot", "idx")
+);
+
+CREATE INDEX IF NOT EXISTS "deposit_receipts_pubkey_idx"
+    ON "deposit_receipts"
+    ("pubkey" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "block_rewards"
+(
+    "slot" bigint NOT NULL,
+    "root" BLOB NOT NULL,
+    "proposer" bigint NOT NULL,
+    "total" bigint NOT NULL,
+    "attestations" bigint NOT NULL,
+    "sync_aggregate" bigint NOT NULL,
+    "proposer_slashings" bigint NOT NULL,
+    "attester_slashings" bigint NOT NULL,
+    "sync_rewards" bigint NOT NULL,
+    "sync_penalties" bigint NOT NULL,
+    "sync_missed" integer NOT NULL,
+    PRIMARY KEY ("slot")
+);
+
+CREATE INDEX IF NOT EXISTS "block_rewards_root_idx"
+    ON "block_rewards"
+    ("root" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "table_size_stats"
+(
+    "day" bigint NOT NULL,
+    "table_name" TEXT NOT NULL,
+    "row_count" bigint NOT NULL,
+    "size" bigint NOT NULL,
+    PRIMARY KEY ("day", "table_name")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
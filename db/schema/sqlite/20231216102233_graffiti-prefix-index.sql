@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- LIKE is case insensitive in sqlite, so the prefix optimization needs a NOCASE index
+CREATE INDEX IF NOT EXISTS "blocks_graffiti_prefix_idx"
+    ON "blocks"
+    ("graffiti_text" COLLATE NOCASE);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "annotations"
+(
+    "id" bigint NOT NULL,
+    "type" smallint NOT NULL,
+    "target" bigint NOT NULL,
+    "root" BLOB NULL,
+    "author" TEXT NOT NULL,
+    "note" TEXT NOT NULL,
+    "created" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "annotations_type_target_idx"
+    ON "annotations"
+    ("type" ASC, "target" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "epochs"
+    ADD "partial" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "unfinalized_epochs"
+    ADD "partial" INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "epochs_partial_idx"
+    ON "epochs"
+    ("partial" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
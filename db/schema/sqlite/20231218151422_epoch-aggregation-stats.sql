@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "epoch_aggregation_stats"
+(
+    "epoch" bigint NOT NULL,
+    "aggregate_count" bigint NOT NULL DEFAULT 0,
+    "committee_count" bigint NOT NULL DEFAULT 0,
+    "redundant_count" bigint NOT NULL DEFAULT 0,
+    "vote_count" bigint NOT NULL DEFAULT 0,
+    "duplicate_vote_count" bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("epoch")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "epochs"
+    ADD "tx_legacy_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "epochs"
+    ADD "tx_access_list_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "epochs"
+    ADD "tx_dynamic_fee_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "epochs"
+    ADD "tx_blob_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "epochs"
+    ADD "tx_setcode_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "unfinalized_epochs"
+    ADD "tx_legacy_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "unfinalized_epochs"
+    ADD "tx_access_list_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "unfinalized_epochs"
+    ADD "tx_dynamic_fee_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "unfinalized_epochs"
+    ADD "tx_blob_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "unfinalized_epochs"
+    ADD "tx_setcode_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "daily_stats"
+    ADD "tx_legacy_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "daily_stats"
+    ADD "tx_access_list_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "daily_stats"
+    ADD "tx_dynamic_fee_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "daily_stats"
+    ADD "tx_blob_count" INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE "daily_stats"
+    ADD "tx_setcode_count" INTEGER NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "validator_uptime"
+    ADD "late" INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "epoch_committee_stats"
+(
+    "epoch" BIGINT NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "committee_index" BIGINT NOT NULL,
+    "duties" BIGINT NOT NULL DEFAULT 0,
+    "missed" BIGINT NOT NULL DEFAULT 0,
+    "late" BIGINT NOT NULL DEFAULT 0,
+    "top_name" TEXT NULL,
+    "top_name_count" BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY ("slot", "committee_index")
+);
+
+CREATE INDEX IF NOT EXISTS "epoch_committee_stats_epoch_idx"
+    ON "epoch_committee_stats"
+    ("epoch" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "blocks"
+    ADD "eth_extra_data" BLOB NULL;
+
+ALTER TABLE "blocks"
+    ADD "cl_client" TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE "blocks"
+    ADD "el_client" TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
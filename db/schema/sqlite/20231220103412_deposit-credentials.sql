@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE INDEX IF NOT EXISTS "deposits_withdrawal_credentials_idx"
+    ON "deposits"
+    ("withdrawal_credentials" ASC);
+
+CREATE INDEX IF NOT EXISTS "deposit_receipts_withdrawal_credentials_idx"
+    ON "deposit_receipts"
+    ("withdrawal_credentials" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
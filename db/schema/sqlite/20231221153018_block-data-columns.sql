@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "block_data_columns"
+(
+    "root" BLOB NOT NULL,
+    "slot" bigint NOT NULL,
+    "client" TEXT NOT NULL,
+    "columns" BLOB NOT NULL,
+    PRIMARY KEY ("root", "client")
+);
+
+CREATE INDEX IF NOT EXISTS "block_data_columns_slot_idx"
+    ON "block_data_columns"
+    ("slot" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
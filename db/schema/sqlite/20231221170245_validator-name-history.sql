@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "validator_names" ADD "source" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "validator_names" ADD "source_name" TEXT NOT NULL DEFAULT '';
+ALTER TABLE "validator_names" ADD "updated" BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "validator_name_history"
+(
+    "index" BIGINT NOT NULL,
+    "changed" BIGINT NOT NULL,
+    "name" TEXT NOT NULL,
+    "source" TEXT NOT NULL,
+    "source_name" TEXT NOT NULL,
+    PRIMARY KEY ("index", "changed")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_name_history_changed_idx"
+    ON "validator_name_history"
+    ("changed" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "slot_committee_participation"
+(
+    "slot" bigint NOT NULL,
+    "epoch" bigint NOT NULL,
+    "participation" BLOB NOT NULL,
+    PRIMARY KEY ("slot")
+);
+
+CREATE INDEX IF NOT EXISTS "slot_committee_participation_epoch_idx"
+    ON "slot_committee_participation"
+    ("epoch" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "finality_checkpoints"
+(
+    "checkpoint_type" smallint NOT NULL,
+    "epoch" bigint NOT NULL,
+    "root" BLOB NOT NULL,
+    "prev_epoch" bigint NOT NULL,
+    "seen_time" bigint NOT NULL,
+    PRIMARY KEY ("checkpoint_type", "epoch")
+);
+
+CREATE INDEX IF NOT EXISTS "finality_checkpoints_epoch_idx"
+    ON "finality_checkpoints"
+    ("epoch" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
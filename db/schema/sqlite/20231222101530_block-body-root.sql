@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "blocks"
+    ADD "body_root" BLOB NULL;
+
+CREATE INDEX IF NOT EXISTS "blocks_body_root_idx"
+    ON "blocks"
+    ("body_root" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
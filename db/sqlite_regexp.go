@@ -0,0 +1,40 @@
+package db
+
+import (
+	"database/sql"
+	"regexp"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriverName is the sqlite driver with the custom functions used by the explorer queries registered
+const sqliteDriverName = "sqlite3_dora"
+
+func init() {
+	sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			// sqlite does not ship an implementation for the REGEXP operator.
+			// matching is case insensitive to behave like the ~* operator on pgsql.
+			return conn.RegisterFunc("regexp", sqliteRegexp, true)
+		},
+	})
+	sqlx.BindDriver(sqliteDriverName, sqlx.QUESTION)
+}
+
+// the function is called for each row, so keep the compiled patterns of recent queries
+var sqliteRegexpCache = lru.NewCache[string, *regexp.Regexp](20)
+
+func sqliteRegexp(pattern string, value string) (bool, error) {
+	re, found := sqliteRegexpCache.Get(pattern)
+	if !found {
+		var err error
+		re, err = regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return false, err
+		}
+		sqliteRegexpCache.Add(pattern, re)
+	}
+	return re.MatchString(value), nil
+}
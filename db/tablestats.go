@@ -0,0 +1,128 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/pk910/dora/dbtypes"
+)
+
+// MeasureTableSizes returns the current row count & disk size (incl. indexes) of all explorer tables.
+// pgsql reports the estimated row count from the table statistics, sqlite counts the rows.
+func MeasureTableSizes(day uint64) ([]*dbtypes.TableSizeStats, error) {
+	tableSizes := []*dbtypes.TableSizeStats{}
+	switch DbEngine {
+	case dbtypes.DBEnginePgsql:
+		err := ReaderDb.Select(&tableSizes, `
+		SELECT
+			relname AS table_name, CAST(n_live_tup AS bigint) AS row_count, pg_total_relation_size(relid) AS size
+		FROM pg_stat_user_tables
+		WHERE schemaname = 'public'
+		ORDER BY relname
+		`)
+		if err != nil {
+			return nil, err
+		}
+	case dbtypes.DBEngineSqlite:
+		tableNames := []string{}
+		err := ReaderDb.Select(&tableNames, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`)
+		if err != nil {
+			return nil, err
+		}
+
+		// the dbstat table is only available if sqlite has been compiled with SQLITE_ENABLE_DBSTAT_VTAB
+		tableSizeMap := map[string]uint64{}
+		indexTables := map[string]string{}
+		indexRows := []struct {
+			Name      string `db:"name"`
+			TableName string `db:"tbl_name"`
+		}{}
+		if err := ReaderDb.Select(&indexRows, `SELECT name, tbl_name FROM sqlite_master WHERE type = 'index'`); err == nil {
+			for _, indexRow := range indexRows {
+				indexTables[indexRow.Name] = indexRow.TableName
+			}
+		}
+		sizeRows := []struct {
+			Name string `db:"name"`
+			Size uint64 `db:"size"`
+		}{}
+		if err := ReaderDb.Select(&sizeRows, `SELECT name, SUM(pgsize) AS size FROM dbstat GROUP BY name`); err == nil {
+			for _, sizeRow := range sizeRows {
+				tableName := sizeRow.Name
+				if indexTable := indexTables[tableName]; indexTable != "" {
+					tableName = indexTable
+				}
+				tableSizeMap[tableName] += sizeRow.Size
+			}
+		}
+
+		for _, tableName := range tableNames {
+			tableSize := &dbtypes.TableSizeStats{
+				TableName: tableName,
+				Size:      tableSizeMap[tableName],
+			}
+			err := ReaderDb.Get(&tableSize.RowCount, fmt.Sprintf(`SELECT COUNT(*) FROM "%v"`, strings.ReplaceAll(tableName, `"`, `""`)))
+			if err != nil {
+				return nil, err
+			}
+			tableSizes = append(tableSizes, tableSize)
+		}
+	}
+
+	for _, tableSize := range tableSizes {
+		tableSize.Day = day
+	}
+	return tableSizes, nil
+}
+
+func InsertTableSizeStats(tableSizes []*dbtypes.TableSizeStats, tx *sqlx.Tx) error {
+	if len(tableSizes) == 0 {
+		return nil
+	}
+	var sql strings.Builder
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  `INSERT INTO table_size_stats (day, table_name, row_count, size) VALUES `,
+		dbtypes.DBEngineSqlite: `INSERT OR REPLACE INTO table_size_stats (day, table_name, row_count, size) VALUES `,
+	}))
+	argIdx := 0
+	args := make([]any, len(tableSizes)*4)
+	for i, tableSize := range tableSizes {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "($%v, $%v, $%v, $%v)", argIdx+1, argIdx+2, argIdx+3, argIdx+4)
+		args[argIdx] = tableSize.Day
+		args[argIdx+1] = tableSize.TableName
+		args[argIdx+2] = tableSize.RowCount
+		args[argIdx+3] = tableSize.Size
+		argIdx += 4
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  ` ON CONFLICT (day, table_name) DO UPDATE SET row_count = excluded.row_count, size = excluded.size`,
+		dbtypes.DBEngineSqlite: "",
+	}))
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetTableSizeStats returns the table size samples since the given day, oldest first
+func GetTableSizeStats(firstDay uint64) []*dbtypes.TableSizeStats {
+	tableSizes := []*dbtypes.TableSizeStats{}
+	err := ReaderDb.Select(&tableSizes, `
+	SELECT
+		day, table_name, row_count, size
+	FROM table_size_stats
+	WHERE day >= $1
+	ORDER BY day ASC, table_name ASC
+	`, firstDay)
+	if err != nil {
+		logger.Errorf("Error while fetching table size stats: %v", err)
+		return nil
+	}
+	return tableSizes
+}
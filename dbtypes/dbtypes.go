@@ -6,8 +6,35 @@ type ExplorerState struct {
 }
 
 type ValidatorName struct {
+	Index      uint64 `db:"index"`
+	Name       string `db:"name"`
+	Source     string `db:"source"`      // provenance of the name (yaml, api, deposit_data, mnemonic, claim)
+	SourceName string `db:"source_name"` // name of the configured source
+	Updated    uint64 `db:"updated"`
+}
+
+// validator name provenances
+const (
+	ValidatorNameSourceYaml        = "yaml"
+	ValidatorNameSourceApi         = "api"
+	ValidatorNameSourceDepositData = "deposit_data"
+	ValidatorNameSourceMnemonic    = "mnemonic"
+	ValidatorNameSourceClaim       = "claim"
+)
+
+// ValidatorNameHistory is a change of a validator name, an empty name means the name was removed
+type ValidatorNameHistory struct {
+	Index      uint64 `db:"index"`
+	Changed    uint64 `db:"changed"`
+	Name       string `db:"name"`
+	Source     string `db:"source"`
+	SourceName string `db:"source_name"`
+}
+
+type ValidatorMetadata struct {
 	Index uint64 `db:"index"`
-	Name  string `db:"name"`
+	Key   string `db:"key"`
+	Value string `db:"value"`
 }
 
 type Block struct {
@@ -15,6 +42,7 @@ type Block struct {
 	Slot                  uint64  `db:"slot"`
 	ParentRoot            []byte  `db:"parent_root"`
 	StateRoot             []byte  `db:"state_root"`
+	BodyRoot              []byte  `db:"body_root"`
 	Orphaned              uint8   `db:"orphaned"`
 	Proposer              uint64  `db:"proposer"`
 	Graffiti              []byte  `db:"graffiti"`
@@ -30,6 +58,11 @@ type Block struct {
 	EthTransactionCount   uint64  `db:"eth_transaction_count"`
 	EthBlockNumber        *uint64 `db:"eth_block_number"`
 	EthBlockHash          []byte  `db:"eth_block_hash"`
+	EthFeeRecipient       []byte  `db:"eth_fee_recipient"`
+	EthExtraData          []byte  `db:"eth_extra_data"`
+	ClClient              string  `db:"cl_client"`
+	ElClient              string  `db:"el_client"`
+	ProposerDependentRoot []byte  `db:"proposer_dependent_root"`
 	SyncParticipation     float32 `db:"sync_participation"`
 }
 
@@ -57,9 +90,22 @@ type Epoch struct {
 	ProposerSlashingCount uint64  `db:"proposer_slashing_count"`
 	BLSChangeCount        uint64  `db:"bls_change_count"`
 	EthTransactionCount   uint64  `db:"eth_transaction_count"`
+	BlobCount             uint64  `db:"blob_count"`
 	SyncParticipation     float32 `db:"sync_participation"`
+	Partial               uint8   `db:"partial"`
+	TxLegacyCount         uint64  `db:"tx_legacy_count"`
+	TxAccessListCount     uint64  `db:"tx_access_list_count"`
+	TxDynamicFeeCount     uint64  `db:"tx_dynamic_fee_count"`
+	TxBlobCount           uint64  `db:"tx_blob_count"`
+	TxSetCodeCount        uint64  `db:"tx_setcode_count"`
 }
 
+// Epoch partial flags, set when the epoch has been aggregated without the validator set or the committee duties
+const (
+	EpochPartialValidators uint8 = 1 << iota
+	EpochPartialDuties
+)
+
 type OrphanedBlock struct {
 	Root      []byte `db:"root"`
 	HeaderVer uint64 `db:"header_ver"`
@@ -68,6 +114,15 @@ type OrphanedBlock struct {
 	BlockSSZ  []byte `db:"block_ssz"`
 }
 
+type ArchivedBlock struct {
+	Root      []byte `db:"root"`
+	Slot      uint64 `db:"slot"`
+	HeaderVer uint64 `db:"header_ver"`
+	HeaderSSZ []byte `db:"header_ssz"`
+	BlockVer  uint64 `db:"block_ver"`
+	BlockSSZ  []byte `db:"block_ssz"`
+}
+
 type SlotAssignment struct {
 	Slot     uint64 `db:"slot"`
 	Proposer uint64 `db:"proposer"`
@@ -100,3 +155,313 @@ type BlobAssignment struct {
 	Commitment []byte `db:"commitment"`
 	Slot       uint64 `db:"slot"`
 }
+
+type BlockBlobCount struct {
+	Root  []byte `db:"root"`
+	Count uint64 `db:"count"`
+}
+
+// ConsolidationRequest is an EIP-7251 consolidation request passed to the beacon chain by the execution layer.
+// The validator indexes are resolved when the epoch is persisted, the balances are the effective balances at that epoch.
+type ConsolidationRequest struct {
+	Slot          uint64  `db:"slot"`
+	Index         uint64  `db:"idx"` // position in the block
+	BlockRoot     []byte  `db:"block_root"`
+	BlockNumber   uint64  `db:"block_number"`
+	SourceAddress []byte  `db:"source_address"`
+	SourcePubkey  []byte  `db:"source_pubkey"`
+	SourceIndex   *uint64 `db:"source_index"`
+	SourceBalance uint64  `db:"source_balance"`
+	TargetPubkey  []byte  `db:"target_pubkey"`
+	TargetIndex   *uint64 `db:"target_index"`
+	TargetBalance uint64  `db:"target_balance"`
+}
+
+type EpochCredentialStats struct {
+	Epoch            uint64 `db:"epoch"`
+	BlsCount         uint64 `db:"bls_count"`
+	ExecutionCount   uint64 `db:"execution_count"`
+	CompoundingCount uint64 `db:"compounding_count"`
+	OtherCount       uint64 `db:"other_count"`
+}
+
+type ValidatorUptime struct {
+	Epoch         uint64 `db:"epoch"`
+	Name          string `db:"name"`
+	Day           uint64 `db:"day"`
+	Duties        uint64 `db:"duties"`
+	Attested      uint64 `db:"attested"`
+	CorrectTarget uint64 `db:"correct_target"`
+	CorrectHead   uint64 `db:"correct_head"`
+	Late          uint64 `db:"late"`
+}
+
+// ValidatorVoteStats holds the attestation counters of a validator, summed over all persisted epochs
+type ValidatorVoteStats struct {
+	ValidatorIndex uint64 `db:"validator_index"`
+	FirstEpoch     uint64 `db:"first_epoch"`
+	LastEpoch      uint64 `db:"last_epoch"`
+	Duties         uint64 `db:"duties"`
+	Attested       uint64 `db:"attested"`
+	CorrectTarget  uint64 `db:"correct_target"`
+	CorrectHead    uint64 `db:"correct_head"`
+}
+
+// ValidatorSummary holds the per validator totals of all finalized epochs, maintained incrementally by the epoch processing
+type ValidatorSummary struct {
+	ValidatorIndex       uint64  `db:"validator_index"`
+	LastEpoch            uint64  `db:"last_epoch"`
+	ProposedBlocks       uint64  `db:"proposed_blocks"`
+	MissedBlocks         uint64  `db:"missed_blocks"`
+	LastAttestationEpoch *uint64 `db:"last_attestation_epoch"`
+	WithdrawalCount      uint64  `db:"withdrawal_count"`
+	WithdrawnAmount      uint64  `db:"withdrawn_amount"`
+	ProposerIncome       uint64  `db:"proposer_income"`
+}
+
+type EpochTargetVote struct {
+	Epoch      uint64 `db:"epoch"`
+	TargetRoot []byte `db:"target_root"`
+	VoteAmount uint64 `db:"vote_amount"`
+}
+
+// EpochAggregationStats counts the attestation aggregates included for the committees of an epoch that
+// repeat votes which have already been included by another aggregate
+type EpochAggregationStats struct {
+	Epoch              uint64 `db:"epoch"`
+	AggregateCount     uint64 `db:"aggregate_count"`
+	CommitteeCount     uint64 `db:"committee_count"`
+	RedundantCount     uint64 `db:"redundant_count"`
+	VoteCount          uint64 `db:"vote_count"`
+	DuplicateVoteCount uint64 `db:"duplicate_vote_count"`
+}
+
+// EpochCommitteeStats counts the missing & late votes of an attestation committee.
+// TopName is the validator name with the most missing or late votes in the committee.
+type EpochCommitteeStats struct {
+	Epoch          uint64  `db:"epoch"`
+	Slot           uint64  `db:"slot"`
+	CommitteeIndex uint64  `db:"committee_index"`
+	Duties         uint64  `db:"duties"`
+	Missed         uint64  `db:"missed"`
+	Late           uint64  `db:"late"`
+	TopName        *string `db:"top_name"`
+	TopNameCount   uint64  `db:"top_name_count"`
+}
+
+// SlotCommitteeParticipation holds the vote participation of all attestation committees of a slot.
+// Participation has one byte per committee index with the percentage (0-100) of committee members whose vote was included.
+type SlotCommitteeParticipation struct {
+	Slot          uint64 `db:"slot"`
+	Epoch         uint64 `db:"epoch"`
+	Participation []byte `db:"participation"`
+}
+
+const (
+	FinalityCheckpointJustified uint8 = iota
+	FinalityCheckpointFinalized
+)
+
+// FinalityCheckpoint is an observed transition of the justified or finalized checkpoint.
+// The transition covers the epochs after PrevEpoch up to Epoch, SeenTime is the unix time it was seen by the indexer.
+type FinalityCheckpoint struct {
+	CheckpointType uint8  `db:"checkpoint_type"`
+	Epoch          uint64 `db:"epoch"`
+	Root           []byte `db:"root"`
+	PrevEpoch      uint64 `db:"prev_epoch"`
+	SeenTime       uint64 `db:"seen_time"`
+}
+
+type SlotReward struct {
+	Slot           uint64 `db:"slot"`
+	Proposer       uint64 `db:"proposer"`
+	Root           []byte `db:"root"`
+	ClReward       uint64 `db:"cl_reward"`
+	MevBid         uint64 `db:"mev_bid"`
+	MevPayment     uint64 `db:"mev_payment"`
+	MevRelay       string `db:"mev_relay"`
+	ExpectedReward uint64 `db:"expected_reward"`
+	ActualReward   uint64 `db:"actual_reward"`
+}
+
+// BlockReward is the consensus reward breakdown of a canonical block (block & sync committee rewards api) in gwei
+type BlockReward struct {
+	Slot              uint64 `db:"slot"`
+	Root              []byte `db:"root"`
+	Proposer          uint64 `db:"proposer"`
+	Total             uint64 `db:"total"`
+	Attestations      uint64 `db:"attestations"`
+	SyncAggregate     uint64 `db:"sync_aggregate"`
+	ProposerSlashings uint64 `db:"proposer_slashings"`
+	AttesterSlashings uint64 `db:"attester_slashings"`
+	SyncRewards       uint64 `db:"sync_rewards"`
+	SyncPenalties     uint64 `db:"sync_penalties"`
+	SyncMissed        uint32 `db:"sync_missed"`
+}
+
+type BlobGas struct {
+	Slot          uint64 `db:"slot"`
+	Root          []byte `db:"root"`
+	BlobGasUsed   uint64 `db:"blob_gas_used"`
+	ExcessBlobGas uint64 `db:"excess_blob_gas"`
+}
+
+type WatchedWithdrawal struct {
+	Address []byte `db:"address"`
+	Epoch   uint64 `db:"epoch"`
+	Count   uint64 `db:"count"`
+	Amount  uint64 `db:"amount"`
+}
+
+type BlockArrival struct {
+	Root   []byte `db:"root"`
+	Slot   uint64 `db:"slot"`
+	Client string `db:"client"`
+	Delay  int64  `db:"delay"` // ms since slot start
+}
+
+// BlockDataColumns holds the PeerDAS data columns of a block a client was able to serve
+type BlockDataColumns struct {
+	Root    []byte `db:"root"`
+	Slot    uint64 `db:"slot"`
+	Client  string `db:"client"`
+	Columns []byte `db:"columns"` // bitfield of the column indexes
+}
+
+// SlotRoot holds the entries of the block_roots & state_roots vectors of the beacon state for a slot
+type SlotRoot struct {
+	Slot      uint64 `db:"slot"`
+	BlockRoot []byte `db:"block_root"` // latest block root at the slot
+	StateRoot []byte `db:"state_root"` // post state root, unknown for missed slots
+	Missed    uint8  `db:"missed"`
+}
+
+type BlockWitness struct {
+	Slot        uint64 `db:"slot"`
+	Root        []byte `db:"root"`
+	StemCount   uint64 `db:"stem_count"`
+	KeyCount    uint64 `db:"key_count"`
+	WriteCount  uint64 `db:"write_count"`
+	ProofSize   uint64 `db:"proof_size"`
+	WitnessSize uint64 `db:"witness_size"`
+}
+
+// Deposit is a deposit included in a canonical block
+type Deposit struct {
+	Slot                  uint64 `db:"slot"`
+	Index                 uint64 `db:"idx"` // position in the block
+	BlockRoot             []byte `db:"block_root"`
+	Pubkey                []byte `db:"pubkey"`
+	WithdrawalCredentials []byte `db:"withdrawal_credentials"`
+	Amount                uint64 `db:"amount"`
+}
+
+// DepositReceipt is an EIP-6110 deposit passed to the beacon chain via the execution payload
+type DepositReceipt struct {
+	Slot                  uint64 `db:"slot"`
+	Index                 uint64 `db:"idx"` // position in the execution payload
+	BlockRoot             []byte `db:"block_root"`
+	BlockNumber           uint64 `db:"block_number"`
+	DepositIndex          uint64 `db:"deposit_index"`
+	Pubkey                []byte `db:"pubkey"`
+	WithdrawalCredentials []byte `db:"withdrawal_credentials"`
+	Amount                uint64 `db:"amount"`
+}
+
+// ValidatorDoppelganger is a pair of conflicting votes of a validator for the same target epoch
+type ValidatorDoppelganger struct {
+	ValidatorIndex    uint64 `db:"validator_index"`
+	Epoch             uint64 `db:"epoch"`
+	Slot              uint64 `db:"slot"` // slot of the block including the first vote
+	BlockRoot         []byte `db:"block_root"`
+	VoteRoot          []byte `db:"vote_root"`
+	ConflictSlot      uint64 `db:"conflict_slot"`
+	ConflictBlockRoot []byte `db:"conflict_block_root"`
+	ConflictVoteRoot  []byte `db:"conflict_vote_root"`
+}
+
+// DailyStats is the rollup of all finalized epochs starting on a day (days since genesis)
+type DailyStats struct {
+	Day                 uint64 `db:"day"`
+	EpochCount          uint64 `db:"epoch_count"`
+	FirstEpoch          uint64 `db:"first_epoch"`
+	LastEpoch           uint64 `db:"last_epoch"`
+	Eligible            uint64 `db:"eligible"`
+	VotedTarget         uint64 `db:"voted_target"`
+	VotedHead           uint64 `db:"voted_head"`
+	VotedTotal          uint64 `db:"voted_total"`
+	BlockCount          uint64 `db:"block_count"`
+	OrphanedCount       uint64 `db:"orphaned_count"`
+	MissedCount         uint64 `db:"missed_count"`
+	DepositCount        uint64 `db:"deposit_count"`
+	ExitCount           uint64 `db:"exit_count"`
+	WithdrawCount       uint64 `db:"withdraw_count"`
+	WithdrawAmount      uint64 `db:"withdraw_amount"`
+	EthTransactionCount uint64 `db:"eth_transaction_count"`
+	BlobCount           uint64 `db:"blob_count"`
+	TxLegacyCount       uint64 `db:"tx_legacy_count"`
+	TxAccessListCount   uint64 `db:"tx_access_list_count"`
+	TxDynamicFeeCount   uint64 `db:"tx_dynamic_fee_count"`
+	TxBlobCount         uint64 `db:"tx_blob_count"`
+	TxSetCodeCount      uint64 `db:"tx_setcode_count"`
+}
+
+const (
+	NameClaimStatusPending uint8 = iota
+	NameClaimStatusApproved
+	NameClaimStatusRejected
+	NameClaimStatusSuperseded // replaced by a newer approved claim for the same validator
+)
+
+// ValidatorNameClaim is a validator name requested by the validator operator, signed with the validator key
+type ValidatorNameClaim struct {
+	ValidatorIndex uint64 `db:"validator_index"`
+	Name           string `db:"name"`
+	Signature      []byte `db:"signature"`
+	Status         uint8  `db:"status"`
+	Created        uint64 `db:"created"`
+	Reviewed       uint64 `db:"reviewed"`
+}
+
+// validator lifecycle states, in the order a validator passes through them
+const (
+	ValidatorStatusDeposited uint8 = iota
+	ValidatorStatusPending
+	ValidatorStatusActive
+	ValidatorStatusExiting
+	ValidatorStatusExited
+	ValidatorStatusWithdrawable
+	ValidatorStatusWithdrawn
+)
+
+// ValidatorStatusChange is the epoch a validator entered a lifecycle state
+type ValidatorStatusChange struct {
+	ValidatorIndex uint64 `db:"validator_index"`
+	Status         uint8  `db:"status"`
+	Epoch          uint64 `db:"epoch"`
+}
+
+// TableSizeStats is the daily sample of the size of a db table (day = days since unix epoch)
+type TableSizeStats struct {
+	Day       uint64 `db:"day"`
+	TableName string `db:"table_name"`
+	RowCount  uint64 `db:"row_count"`
+	Size      uint64 `db:"size"`
+}
+
+const (
+	AnnotationTypeSlot uint8 = iota + 1
+	AnnotationTypeEpoch
+	AnnotationTypeReorg // target is the slot of the orphaned block, root its block root
+)
+
+// Annotation is an operator note attached to a slot, epoch or reorg
+type Annotation struct {
+	Id      uint64 `db:"id"`
+	Type    uint8  `db:"type"`
+	Target  uint64 `db:"target"`
+	Root    []byte `db:"root"`
+	Author  string `db:"author"`
+	Note    string `db:"note"`
+	Created uint64 `db:"created"`
+}
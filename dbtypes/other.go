@@ -14,9 +14,77 @@ type AssignedBlob struct {
 }
 
 type BlockFilter struct {
-	Graffiti      string
-	ProposerIndex *uint64
-	ProposerName  string
-	WithOrphaned  uint8
-	WithMissing   uint8
+	Graffiti       string
+	GraffitiRegex  bool
+	GraffitiPrefix bool // match Graffiti as case insensitive prefix, which can use the graffiti prefix index
+	ProposerIndex  *uint64
+	ProposerName   string
+	// ProposerIndices holds the validator indices matching ProposerName, resolved from the validator names
+	ProposerIndices []uint64
+	WithOrphaned    uint8
+	WithMissing     uint8
+	MinSlot         *uint64
+	MaxSlot         *uint64
+}
+
+type EpochFilter struct {
+	MinEpoch  *uint64
+	MaxEpoch  *uint64
+	Finalized uint8 // 0: all epochs, 1: finalized only, 2: unfinalized only
+	// MinParticipation & MaxParticipation are target vote participation bounds in percent
+	MinParticipation *float64
+	MaxParticipation *float64
+	WithOrphaned     uint8 // 0: hide epochs with orphaned blocks, 1: include, 2: only epochs with orphaned blocks
+}
+
+type ValidatorUptimeDay struct {
+	Day           uint64 `db:"day"`
+	Name          string `db:"name"`
+	Duties        uint64 `db:"duties"`
+	Attested      uint64 `db:"attested"`
+	CorrectTarget uint64 `db:"correct_target"`
+	CorrectHead   uint64 `db:"correct_head"`
+}
+
+// OperatorVoteDelays holds the missing & late attestations of a validator name over an epoch range
+type OperatorVoteDelays struct {
+	Name     string `db:"name"`
+	Duties   uint64 `db:"duties"`
+	Attested uint64 `db:"attested"`
+	Late     uint64 `db:"late"`
+}
+
+type WatchedWithdrawalStats struct {
+	Address    []byte `db:"address"`
+	Count      uint64 `db:"count"`
+	Amount     uint64 `db:"amount"`
+	FirstEpoch uint64 `db:"first_epoch"`
+	LastEpoch  uint64 `db:"last_epoch"`
+}
+
+type ProposerRewardStats struct {
+	Proposer       uint64 `db:"proposer"`
+	SlotCount      uint64 `db:"slot_count"`
+	ProposedCount  uint64 `db:"proposed_count"`
+	ExpectedReward uint64 `db:"expected_reward"`
+	ActualReward   uint64 `db:"actual_reward"`
+}
+
+// BlobGasStats are the aggregated blob gas values of the blocks within a period (epoch or day)
+type BlobGasStats struct {
+	Period        uint64  `db:"period"`
+	BlockCount    uint64  `db:"block_count"`
+	BlobGasUsed   uint64  `db:"blob_gas_used"`
+	ExcessBlobGas float64 `db:"excess_blob_gas"`
+}
+
+// BlockWitnessStats are the aggregated execution witness sizes of the blocks within a period (epoch or day)
+type BlockWitnessStats struct {
+	Period      uint64  `db:"period"`
+	BlockCount  uint64  `db:"block_count"`
+	StemCount   float64 `db:"stem_count"`
+	KeyCount    float64 `db:"key_count"`
+	ProofSize   float64 `db:"proof_size"`
+	WitnessSize float64 `db:"witness_size"`
+	MaxSize     uint64  `db:"max_size"`
 }
@@ -3,3 +3,8 @@ package dbtypes
 type IndexerSyncState struct {
 	Epoch uint64 `json:"epoch"`
 }
+
+type IndexerGenesisState struct {
+	GenesisTime           uint64 `json:"time"`
+	GenesisValidatorsRoot []byte `json:"valroot"`
+}
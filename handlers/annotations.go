@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+type annotationRequest struct {
+	Type   string `json:"type"`
+	Target uint64 `json:"target"`
+	Root   string `json:"root"`
+	Author string `json:"author"`
+	Note   string `json:"note"`
+}
+
+type annotationResponse struct {
+	Status string             `json:"status"`
+	Error  string             `json:"error,omitempty"`
+	Id     uint64             `json:"id,omitempty"`
+	Data   *models.Annotation `json:"data,omitempty"`
+}
+
+var annotationTypeNames = map[uint8]string{
+	dbtypes.AnnotationTypeSlot:  "slot",
+	dbtypes.AnnotationTypeEpoch: "epoch",
+	dbtypes.AnnotationTypeReorg: "reorg",
+}
+
+func parseAnnotationType(name string) (uint8, error) {
+	for annotationType, typeName := range annotationTypeNames {
+		if typeName == name {
+			return annotationType, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown annotation type '%v' (slot, epoch or reorg)", name)
+}
+
+// buildAnnotationModels converts the db annotations for the page models
+func buildAnnotationModels(annotations []*dbtypes.Annotation) []*models.Annotation {
+	result := make([]*models.Annotation, len(annotations))
+	for idx, annotation := range annotations {
+		result[idx] = &models.Annotation{
+			Id:      annotation.Id,
+			Type:    annotationTypeNames[annotation.Type],
+			Target:  annotation.Target,
+			Root:    annotation.Root,
+			Author:  annotation.Author,
+			Note:    annotation.Note,
+			Created: time.Unix(int64(annotation.Created), 0),
+		}
+	}
+	return result
+}
+
+// Annotations will return the operator annotations page using a go template.
+// New annotations are added (and existing ones deleted) by posting the form back to this page.
+func Annotations(w http.ResponseWriter, r *http.Request) {
+	if !checkPageToken(w, r, "annotations_token", utils.Config.Frontend.AnnotationTokens) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	pageData := &models.AnnotationsPageData{
+		Type:   r.FormValue("type"),
+		Root:   r.FormValue("root"),
+		Author: r.FormValue("author"),
+	}
+	if pageData.Type == "" {
+		pageData.Type = "slot"
+	}
+	if target, err := strconv.ParseUint(r.FormValue("target"), 10, 64); err == nil {
+		pageData.Target = target
+		pageData.HasTarget = true
+	}
+
+	if r.Method == http.MethodPost {
+		var err error
+		if r.FormValue("action") == "delete" {
+			var id uint64
+			id, err = strconv.ParseUint(r.FormValue("id"), 10, 64)
+			if err == nil {
+				err = services.DeleteAnnotation(id)
+			}
+		} else {
+			_, err = submitAnnotation(&annotationRequest{
+				Type:   pageData.Type,
+				Target: pageData.Target,
+				Root:   pageData.Root,
+				Author: pageData.Author,
+				Note:   r.FormValue("note"),
+			})
+		}
+		if err == nil {
+			http.Redirect(w, r, fmt.Sprintf("/annotations?author=%v", url.QueryEscape(pageData.Author)), http.StatusSeeOther)
+			return
+		}
+		pageData.Error = err.Error()
+	}
+
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"annotations/annotations.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/annotations", "Annotations", pageTemplateFiles)
+	pageData.Annotations = buildAnnotationModels(db.GetRecentAnnotations(100))
+	data.Data = pageData
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "annotations.go", "Annotations", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// AnnotationsSubmit adds an annotation, expects a json encoded {"type", "target", "root", "author", "note"} body
+func AnnotationsSubmit(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if !checkApiToken(r, utils.Config.Frontend.AnnotationTokens) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(&annotationResponse{Status: "error", Error: "unauthorized"})
+		return
+	}
+
+	request := &annotationRequest{}
+	var annotation *dbtypes.Annotation
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 8192)).Decode(request)
+	if err == nil {
+		annotation, err = submitAnnotation(request)
+	}
+
+	response := &annotationResponse{
+		Status: "ok",
+	}
+	if err != nil {
+		response.Status = "error"
+		response.Error = err.Error()
+		w.WriteHeader(http.StatusBadRequest)
+	} else {
+		response.Id = annotation.Id
+		response.Data = buildAnnotationModels([]*dbtypes.Annotation{annotation})[0]
+	}
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding annotation response")
+	}
+}
+
+// submitAnnotation returns the errors that can be shown to the user, internal errors are logged and replaced
+func submitAnnotation(request *annotationRequest) (*dbtypes.Annotation, error) {
+	annotationType, err := parseAnnotationType(request.Type)
+	if err != nil {
+		return nil, err
+	}
+	var root []byte
+	if request.Root != "" {
+		root, err = hex.DecodeString(strings.TrimPrefix(request.Root, "0x"))
+		if err != nil {
+			return nil, errors.New("root must be hex encoded")
+		}
+	}
+
+	annotation, err := services.AddAnnotation(annotationType, request.Target, root, request.Author, request.Note)
+	switch {
+	case err == nil:
+		return annotation, nil
+	case errors.Is(err, services.ErrAnnotationInvalid), errors.Is(err, services.ErrAnnotationBadTarget):
+		return nil, err
+	default:
+		logrus.WithError(err).Errorf("error adding %v annotation for %v", request.Type, request.Target)
+		return nil, errors.New("internal error, please try again later")
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+)
+
+const (
+	archiveApiMaxEpochs = 100
+	archiveApiMaxBlocks = 1000
+)
+
+// ArchiveEpochs returns the finalized epochs of the db for lightweight dora instances, newest first.
+// Query params: epoch (first epoch), limit
+func ArchiveEpochs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	firstEpoch, err := strconv.ParseUint(query.Get("epoch"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid epoch", http.StatusBadRequest)
+		return
+	}
+	limit, _ := strconv.ParseUint(query.Get("limit"), 10, 32)
+	if limit == 0 || limit > archiveApiMaxEpochs {
+		limit = archiveApiMaxEpochs
+	}
+
+	epochs := db.GetEpochs(firstEpoch, uint32(limit))
+	if epochs == nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeArchiveApiResponse(w, epochs)
+}
+
+// ArchiveBlocks returns the finalized blocks of the db for lightweight dora instances, newest first.
+// Query params: slot (first slot), limit or last_slot, orphaned
+func ArchiveBlocks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	firstSlot, err := strconv.ParseUint(query.Get("slot"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid slot", http.StatusBadRequest)
+		return
+	}
+	withOrphaned := query.Get("orphaned") == "true"
+
+	var blocks []*dbtypes.Block
+	if query.Has("last_slot") {
+		lastSlot, err := strconv.ParseUint(query.Get("last_slot"), 10, 64)
+		if err != nil || lastSlot > firstSlot || firstSlot-lastSlot >= archiveApiMaxBlocks {
+			http.Error(w, "Invalid last_slot", http.StatusBadRequest)
+			return
+		}
+		blocks = db.GetBlocksForSlots(firstSlot, lastSlot, withOrphaned)
+	} else {
+		limit, _ := strconv.ParseUint(query.Get("limit"), 10, 32)
+		if limit == 0 || limit > archiveApiMaxBlocks {
+			limit = archiveApiMaxBlocks
+		}
+		blocks = db.GetBlocks(firstSlot, uint32(limit), withOrphaned)
+	}
+	if blocks == nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeArchiveApiResponse(w, blocks)
+}
+
+func writeArchiveApiResponse(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(data)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding archive api response")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// checkApiToken accepts the token as bearer authorization header or as `token` query parameter for browser clients
+func checkApiToken(r *http.Request, apiTokens []string) bool {
+	token, isBearer := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !isBearer {
+		token = r.URL.Query().Get("token")
+	}
+	if token == "" {
+		return false
+	}
+	for _, apiToken := range apiTokens {
+		if subtle.ConstantTimeCompare([]byte(apiToken), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPageToken authenticates the operator pages with an api token (see checkApiToken) or the cookie that is set
+// for the page path after the first authenticated request, so forms & redirects don't carry the token in their urls.
+// The cookie is strict same-site, which keeps cross-site form posts unauthenticated.
+func checkPageToken(w http.ResponseWriter, r *http.Request, cookieName string, apiTokens []string) bool {
+	if checkApiToken(r, apiTokens) {
+		token, isBearer := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !isBearer {
+			token = r.URL.Query().Get("token")
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     cookieName,
+			Value:    token,
+			Path:     r.URL.Path,
+			HttpOnly: true,
+			Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+			SameSite: http.SameSiteStrictMode,
+		})
+		return true
+	}
+
+	cookie, err := r.Cookie(cookieName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	for _, apiToken := range apiTokens {
+		if subtle.ConstantTimeCompare([]byte(apiToken), []byte(cookie.Value)) == 1 {
+			return true
+		}
+	}
+	return false
+}
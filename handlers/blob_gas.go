@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+const (
+	blobGasPerBlob    = 131072
+	blobGasEpochCount = 100
+	blobGasDayCount   = 30
+)
+
+// BlobGas will return the "blob gas" market page using a go template
+func BlobGas(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"blob_gas/blob_gas.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/blobs/gas", "Blob Gas", pageTemplateFiles)
+
+	epochCount, dayCount := parseBlobGasRange(r)
+	var pageError error
+	data.Data, pageError = getBlobGasPageData(epochCount, dayCount)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "blob_gas.go", "BlobGas", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// parseBlobGasRange returns the number of epochs & days to show, so the chart ranges can be linked
+func parseBlobGasRange(r *http.Request) (uint64, uint64) {
+	urlArgs := r.URL.Query()
+	var epochCount uint64 = blobGasEpochCount
+	if urlArgs.Has("epochs") {
+		epochCount, _ = strconv.ParseUint(urlArgs.Get("epochs"), 10, 64)
+	}
+	if epochCount == 0 {
+		epochCount = blobGasEpochCount
+	} else if epochCount > 1000 {
+		epochCount = 1000
+	}
+	var dayCount uint64 = blobGasDayCount
+	if urlArgs.Has("days") {
+		dayCount, _ = strconv.ParseUint(urlArgs.Get("days"), 10, 64)
+	}
+	if dayCount == 0 {
+		dayCount = blobGasDayCount
+	} else if dayCount > 365 {
+		dayCount = 365
+	}
+	return epochCount, dayCount
+}
+
+func getBlobGasPageData(epochCount uint64, dayCount uint64) (*models.BlobGasPageData, error) {
+	pageData := &models.BlobGasPageData{}
+	pageCacheKey := fmt.Sprintf("blob_gas:%v:%v", epochCount, dayCount)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildBlobGasPageData(epochCount, dayCount)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.BlobGasPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildBlobGasPageData(epochCount uint64, dayCount uint64) (*models.BlobGasPageData, time.Duration) {
+	logrus.Debugf("blob gas page called: %v:%v", epochCount, dayCount)
+	pageData := &models.BlobGasPageData{
+		EpochCount: epochCount,
+		DayCount:   dayCount,
+	}
+
+	currentEpoch := utils.TimeToEpoch(time.Now())
+	if currentEpoch < 0 {
+		currentEpoch = 0
+	}
+	slotsPerEpoch := utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := uint64(currentEpoch+1)*slotsPerEpoch - 1
+
+	// blob gas is only persisted for finalized blocks, so the most recent epochs are missing
+	firstEpoch := uint64(0)
+	if uint64(currentEpoch) >= epochCount {
+		firstEpoch = uint64(currentEpoch) - epochCount + 1
+	}
+	epochs := buildBlobGasPeriods(firstEpoch*slotsPerEpoch, lastSlot, slotsPerEpoch, func(period uint64) time.Time {
+		return utils.EpochToTime(period)
+	})
+
+	slotsPerDay := 24 * 3600 / utils.Config.Chain.Config.SecondsPerSlot
+	currentDay := lastSlot / slotsPerDay
+	firstDay := uint64(0)
+	if currentDay >= dayCount {
+		firstDay = currentDay - dayCount + 1
+	}
+	days := buildBlobGasPeriods(firstDay*slotsPerDay, lastSlot, slotsPerDay, func(period uint64) time.Time {
+		return utils.DayToTime(int64(period))
+	})
+
+	pageData.Sections = []*models.BlobGasPageSection{
+		buildBlobGasSection("Per Epoch", "Epoch", "/epoch/", epochs),
+		buildBlobGasSection("Per Day", "Day", "", days),
+	}
+
+	return pageData, 5 * time.Minute
+}
+
+func buildBlobGasPeriods(firstSlot uint64, lastSlot uint64, periodSlots uint64, periodTime func(period uint64) time.Time) []*models.BlobGasPagePeriod {
+	dbStats := db.GetBlobGasStats(firstSlot, lastSlot, periodSlots)
+	periods := make([]*models.BlobGasPagePeriod, 0, len(dbStats))
+	for _, stats := range dbStats {
+		period := &models.BlobGasPagePeriod{
+			Period:        stats.Period,
+			Ts:            periodTime(stats.Period),
+			BlockCount:    stats.BlockCount,
+			BlobGasUsed:   stats.BlobGasUsed,
+			ExcessBlobGas: uint64(stats.ExcessBlobGas),
+		}
+		if stats.BlockCount > 0 {
+			period.BlobsPerBlock = float64(stats.BlobGasUsed) / float64(blobGasPerBlob) / float64(stats.BlockCount)
+		}
+		period.BlobBaseFee = utils.GetBlobBaseFee(period.ExcessBlobGas).Uint64()
+		periods = append(periods, period)
+	}
+	return periods
+}
+
+// buildBlobGasSection builds the blob usage & fee sparklines for the periods (sorted oldest first)
+func buildBlobGasSection(title string, periodLabel string, periodLink string, periods []*models.BlobGasPagePeriod) *models.BlobGasPageSection {
+	section := &models.BlobGasPageSection{
+		Title:       title,
+		PeriodLabel: periodLabel,
+		PeriodLink:  periodLink,
+		Periods:     make([]*models.BlobGasPagePeriod, len(periods)),
+	}
+
+	// show newest periods first in the table
+	for idx, period := range periods {
+		section.Periods[len(periods)-idx-1] = period
+	}
+
+	if len(periods) < 2 {
+		return section
+	}
+	blobsPerBlock := make([]float64, len(periods))
+	excessBlobGas := make([]float64, len(periods))
+	blobBaseFee := make([]float64, len(periods))
+	for idx, period := range periods {
+		blobsPerBlock[idx] = period.BlobsPerBlock
+		excessBlobGas[idx] = float64(period.ExcessBlobGas) / float64(blobGasPerBlob)
+		blobBaseFee[idx] = float64(period.BlobBaseFee) / 1e9
+	}
+	section.Charts = []*models.EpochsPageSparkline{
+		buildEpochsSparkline("Blobs per Block", "", blobsPerBlock, nil),
+		buildEpochsSparkline("Excess Blob Gas", " blobs", excessBlobGas, nil),
+		buildEpochsSparkline("Blob Base Fee", " gwei", blobBaseFee, nil),
+	}
+	return section
+}
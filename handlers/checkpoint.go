@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/utils"
+)
+
+// CheckpointResponse is the finalized checkpoint in the format used by checkpoint sync providers
+type CheckpointResponse struct {
+	Data *CheckpointResponseData `json:"data"`
+}
+
+type CheckpointResponseData struct {
+	Epoch     uint64                          `json:"epoch,string"`
+	Slot      uint64                          `json:"slot,string"`
+	BlockRoot string                          `json:"block_root"`
+	StateRoot string                          `json:"state_root"`
+	Header    *phase0.SignedBeaconBlockHeader `json:"header"`
+}
+
+// Checkpoint returns the latest finalized checkpoint block with its state root, so checkpoint synced nodes
+// of private networks can be verified against this instance (/api/v1/checkpoint)
+func Checkpoint(w http.ResponseWriter, r *http.Request) {
+	beaconIndexer := services.GlobalBeaconService.GetIndexer()
+	finalizedEpoch, finalizedRoot, _, _ := beaconIndexer.GetFinalizationCheckpoints()
+	if finalizedEpoch < 0 || finalizedRoot == nil {
+		http.Error(w, "no finalized checkpoint yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	var header *phase0.SignedBeaconBlockHeader
+	if cachedBlock := beaconIndexer.GetCachedBlock(finalizedRoot); cachedBlock != nil {
+		header = cachedBlock.GetHeader()
+	}
+	if header == nil {
+		if client := beaconIndexer.GetReadyClient(false, nil, nil); client != nil {
+			headerRsp, err := client.GetRpcClient().GetBlockHeaderByBlockroot(finalizedRoot)
+			if err != nil {
+				logrus.WithError(err).Warnf("error loading finalized checkpoint header 0x%x", finalizedRoot)
+			} else if headerRsp != nil {
+				header = headerRsp.Header
+			}
+		}
+	}
+	if header == nil {
+		http.Error(w, "finalized checkpoint block not found", http.StatusServiceUnavailable)
+		return
+	}
+
+	response := &CheckpointResponse{
+		Data: &CheckpointResponseData{
+			Epoch:     uint64(finalizedEpoch),
+			Slot:      uint64(header.Message.Slot),
+			BlockRoot: fmt.Sprintf("0x%x", finalizedRoot),
+			StateRoot: fmt.Sprintf("0x%x", header.Message.StateRoot[:]),
+			Header:    header,
+		},
+	}
+
+	// the checkpoint only changes with the next finalized epoch
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%v", utils.Config.Chain.Config.SecondsPerSlot))
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding checkpoint data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
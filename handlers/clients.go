@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/pk910/dora/rpc"
 	"github.com/pk910/dora/services"
 	"github.com/pk910/dora/templates"
 	"github.com/pk910/dora/types/models"
@@ -57,6 +61,7 @@ func buildClientsPageData() (*models.ClientsPageData, time.Duration) {
 	}
 	cacheTime := time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
 
+	arrivalStats := services.GlobalBeaconService.GetIndexer().GetClientArrivalStats()
 	for _, client := range services.GlobalBeaconService.GetClients() {
 		lastHeadSlot, lastHeadRoot := client.GetLastHead()
 		if lastHeadSlot < 0 {
@@ -70,9 +75,97 @@ func buildClientsPageData() (*models.ClientsPageData, time.Duration) {
 			HeadRoot: lastHeadRoot,
 			Status:   client.GetStatus(),
 		}
+		resClient.AvgLatency = client.GetAvgLatency().Milliseconds()
+		if stats := arrivalStats[client.GetIndex()]; stats != nil {
+			resClient.ArrivalBlocks = stats.BlockCount
+			resClient.ArrivalAvgBehind = stats.AvgBehind.Milliseconds()
+			resClient.ArrivalMaxBehind = stats.MaxBehind.Milliseconds()
+		}
+
+		if endpointStatus := services.GlobalBeaconService.GetEndpointStatus(client.GetIndex()); endpointStatus != nil {
+			resClient.StatusTime = endpointStatus.LastRefresh
+			resClient.StatusError = endpointStatus.Error
+			if endpointStatus.Version != "" {
+				resClient.Version = endpointStatus.Version
+			}
+			if endpointStatus.HasFork {
+				resClient.HasFork = true
+				resClient.ForkVersion = endpointStatus.ForkVersion[:]
+				resClient.ForkEpoch = endpointStatus.ForkEpoch
+				resClient.ForkDigest = endpointStatus.ForkDigest[:]
+			}
+		}
+
+		capabilities := client.GetRpcClient().GetCapabilities()
+		resClient.ClientType = capabilities.GetClientType().String()
+		resClient.Unsupported = strings.Join(capabilities.GetUnsupportedFeatures(), ", ")
+
+		breakerStatus := client.GetRpcClient().GetCircuitBreaker().GetStatus()
+		resClient.CircuitState = breakerStatus.State.String()
+		resClient.CircuitFailures = breakerStatus.Failures
+		resClient.CircuitOpenUntil = breakerStatus.OpenUntil
+		resClient.LastError = breakerStatus.LastError
+		resClient.LastErrorTime = breakerStatus.LastErrorTime
+		if breakerStatus.LastError != "" {
+			resClient.LastErrorClass = breakerStatus.LastClass.String()
+		}
+		errorCounts := []string{}
+		for class := rpc.ErrorClassTimeout; class <= rpc.ErrorClassOther; class++ {
+			if count := breakerStatus.ErrorCounts[class]; count > 0 {
+				errorCounts = append(errorCounts, fmt.Sprintf("%v: %v", class.String(), count))
+			}
+		}
+		resClient.ErrorCounts = strings.Join(errorCounts, ", ")
+
 		pageData.Clients = append(pageData.Clients, resClient)
 	}
 	pageData.ClientCount = uint64(len(pageData.Clients))
+	setClientsPageForkMismatch(pageData)
+
+	indexerState := services.GlobalBeaconService.GetIndexer().GetIndexerState()
+	pageData.Indexer = &models.ClientsPageDataIndexer{
+		HeadSlot:       indexerState.HeadSlot,
+		FinalizedEpoch: indexerState.FinalizedEpoch,
+		JustifiedEpoch: indexerState.JustifiedEpoch,
+		ProcessingBusy: indexerState.ProcessingBusy,
+		ProcessedEpoch: indexerState.ProcessedEpoch,
+		PersistEpoch:   indexerState.PersistEpoch,
+		CachedBlocks:   indexerState.CachedBlocks,
+		LowestSlot:     indexerState.LowestSlot,
+		HighestSlot:    indexerState.HighestSlot,
+		ReorgCount:     indexerState.ReorgCount,
+		SyncRunning:    indexerState.Synchronizer.Running,
+		SyncEpoch:      indexerState.Synchronizer.CurrentEpoch,
+	}
 
 	return pageData, cacheTime
 }
+
+// setClientsPageForkMismatch flags the endpoints that are on a different fork than the majority of the endpoints
+func setClientsPageForkMismatch(pageData *models.ClientsPageData) {
+	digestCounts := map[string]int{}
+	for _, client := range pageData.Clients {
+		if client.HasFork {
+			digestCounts[string(client.ForkDigest)]++
+		}
+	}
+	if len(digestCounts) == 0 {
+		return
+	}
+	majorityCount := 0
+	for digest, count := range digestCounts {
+		if count > majorityCount || (count == majorityCount && digest > string(pageData.ForkDigest)) {
+			majorityCount = count
+			pageData.ForkDigest = []byte(digest)
+		}
+	}
+	if len(digestCounts) == 1 {
+		return
+	}
+	pageData.ForkMismatch = true
+	for _, client := range pageData.Clients {
+		if client.HasFork && !bytes.Equal(client.ForkDigest, pageData.ForkDigest) {
+			client.ForkMismatch = true
+		}
+	}
+}
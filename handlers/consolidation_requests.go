@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+const consolidationRequestsPageSize = 50
+
+// ConsolidationRequests will return the EIP-7251 consolidation requests page using a go template.
+// It shows the moved amounts and the effective balance change of the target validators since the request.
+func ConsolidationRequests(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"consolidation_requests/consolidation_requests.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/consolidation_requests", "Consolidation Requests", pageTemplateFiles)
+
+	pageIdx, _ := strconv.ParseUint(r.URL.Query().Get("p"), 10, 64)
+
+	var pageError error
+	data.Data, pageError = getConsolidationRequestsPageData(pageIdx)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "consolidation_requests.go", "ConsolidationRequests", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getConsolidationRequestsPageData(pageIdx uint64) (*models.ConsolidationRequestsPageData, error) {
+	pageData := &models.ConsolidationRequestsPageData{}
+	pageCacheKey := fmt.Sprintf("consolidation_requests:%v", pageIdx)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildConsolidationRequestsPageData(pageIdx)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ConsolidationRequestsPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildConsolidationRequestsPageData(pageIdx uint64) (*models.ConsolidationRequestsPageData, time.Duration) {
+	logrus.Debugf("consolidation requests page called: %v", pageIdx)
+	pageData := &models.ConsolidationRequestsPageData{
+		PageIndex: pageIdx,
+		PageSize:  consolidationRequestsPageSize,
+		Requests:  []*models.ConsolidationRequestsPageDataRequest{},
+	}
+	if pageIdx > 0 {
+		pageData.HasPrev = true
+		pageData.PrevPage = pageIdx - 1
+	}
+
+	// load one more request than shown to know if there is a next page
+	requests := db.GetConsolidationRequests(pageIdx*consolidationRequestsPageSize, consolidationRequestsPageSize+1)
+	if len(requests) > consolidationRequestsPageSize {
+		pageData.HasNext = true
+		pageData.NextPage = pageIdx + 1
+		requests = requests[:consolidationRequestsPageSize]
+	}
+
+	validatorSet := services.GlobalBeaconService.GetCachedValidatorSet()
+	getValidator := func(index uint64) *v1.Validator {
+		if validatorSet == nil {
+			return nil
+		}
+		return validatorSet[phase0.ValidatorIndex(index)]
+	}
+
+	for _, request := range requests {
+		requestData := &models.ConsolidationRequestsPageDataRequest{
+			Slot:          request.Slot,
+			Time:          utils.SlotToTime(request.Slot),
+			BlockRoot:     request.BlockRoot,
+			BlockNumber:   request.BlockNumber,
+			SourceAddress: request.SourceAddress,
+			SourcePubkey:  request.SourcePubkey,
+			TargetPubkey:  request.TargetPubkey,
+			Amount:        request.SourceBalance,
+			TargetBalance: request.TargetBalance,
+		}
+		pageData.Requests = append(pageData.Requests, requestData)
+		pageData.TotalAmount += request.SourceBalance
+
+		if request.SourceIndex != nil {
+			requestData.HasSource = true
+			requestData.SourceIndex = *request.SourceIndex
+			requestData.SourceName = services.GlobalBeaconService.GetValidatorName(*request.SourceIndex)
+			if validator := getValidator(*request.SourceIndex); validator != nil {
+				requestData.SourceExited = uint64(validator.Validator.ExitEpoch) != math.MaxUint64
+			}
+			if !requestData.SourceExited {
+				pageData.PendingCount++
+			}
+		}
+		if request.TargetIndex != nil {
+			requestData.HasTarget = true
+			requestData.TargetIndex = *request.TargetIndex
+			requestData.TargetName = services.GlobalBeaconService.GetValidatorName(*request.TargetIndex)
+			if validator := getValidator(*request.TargetIndex); validator != nil {
+				requestData.TargetCurrent = uint64(validator.Validator.EffectiveBalance)
+				if requestData.TargetCurrent >= request.TargetBalance {
+					requestData.TargetChange = requestData.TargetCurrent - request.TargetBalance
+				} else {
+					requestData.TargetChange = request.TargetBalance - requestData.TargetCurrent
+					requestData.TargetDropped = true
+				}
+			}
+		}
+	}
+
+	return pageData, 1 * time.Minute
+}
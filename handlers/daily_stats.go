@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// DailyStats will return the "daily stats" charts page using a go template
+func DailyStats(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"daily_stats/daily_stats.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/epochs/daily", "Daily Stats", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getDailyStatsPageData(parseDailyStatsDays(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "daily_stats.go", "DailyStats", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// DailyStatsData will return the daily stats as json
+func DailyStatsData(w http.ResponseWriter, r *http.Request) {
+	pageData, pageError := getDailyStatsPageData(parseDailyStatsDays(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding daily stats data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func parseDailyStatsDays(r *http.Request) uint64 {
+	urlArgs := r.URL.Query()
+	var dayCount uint64 = 90
+	if urlArgs.Has("days") {
+		dayCount, _ = strconv.ParseUint(urlArgs.Get("days"), 10, 64)
+	}
+	if dayCount == 0 {
+		dayCount = 90
+	} else if dayCount > 3650 {
+		dayCount = 3650
+	}
+	return dayCount
+}
+
+func getDailyStatsPageData(dayCount uint64) (*models.DailyStatsPageData, error) {
+	pageData := &models.DailyStatsPageData{}
+	pageCacheKey := fmt.Sprintf("daily_stats:%v", dayCount)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildDailyStatsPageData(dayCount)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.DailyStatsPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildDailyStatsPageData(dayCount uint64) (*models.DailyStatsPageData, time.Duration) {
+	logrus.Debugf("daily stats page called: %v", dayCount)
+	pageData := &models.DailyStatsPageData{
+		DayCount: dayCount,
+	}
+
+	lastDay := utils.TimeToDay(uint64(time.Now().Unix()))
+	firstDay := uint64(0)
+	if lastDay+1 > dayCount {
+		firstDay = lastDay + 1 - dayCount
+	}
+
+	dailyStats := db.GetDailyStats(firstDay, lastDay)
+	pageData.Days = make([]*models.DailyStatsPageDay, len(dailyStats))
+	participation := make([]float64, len(dailyStats))
+	missed := make([]float64, len(dailyStats))
+	transactions := make([]float64, len(dailyStats))
+	withdrawals := make([]float64, len(dailyStats))
+	deposits := make([]float64, len(dailyStats))
+	blobs := make([]float64, len(dailyStats))
+	dynamicFeeShare := make([]float64, len(dailyStats))
+	blobTxs := make([]float64, len(dailyStats))
+	setCodeTxs := make([]float64, len(dailyStats))
+	for idx, stats := range dailyStats {
+		day := &models.DailyStatsPageDay{
+			Day:                 stats.Day,
+			Ts:                  utils.DayToTime(int64(stats.Day)),
+			FirstEpoch:          stats.FirstEpoch,
+			LastEpoch:           stats.LastEpoch,
+			EpochCount:          stats.EpochCount,
+			BlockCount:          stats.BlockCount,
+			MissedCount:         stats.MissedCount,
+			OrphanedCount:       stats.OrphanedCount,
+			DepositCount:        stats.DepositCount,
+			ExitCount:           stats.ExitCount,
+			WithdrawCount:       stats.WithdrawCount,
+			WithdrawAmount:      stats.WithdrawAmount,
+			EthTransactionCount: stats.EthTransactionCount,
+			BlobCount:           stats.BlobCount,
+			TxTypes: models.TransactionTypes{
+				Legacy:     stats.TxLegacyCount,
+				AccessList: stats.TxAccessListCount,
+				DynamicFee: stats.TxDynamicFeeCount,
+				Blob:       stats.TxBlobCount,
+				SetCode:    stats.TxSetCodeCount,
+			},
+		}
+		if stats.Eligible > 0 {
+			day.Participation = float64(stats.VotedTarget) * 100.0 / float64(stats.Eligible)
+		}
+		// show newest days first in the table
+		pageData.Days[len(dailyStats)-idx-1] = day
+
+		participation[idx] = day.Participation
+		missed[idx] = float64(stats.MissedCount)
+		transactions[idx] = float64(stats.EthTransactionCount)
+		withdrawals[idx] = float64(stats.WithdrawAmount) / 1e9
+		deposits[idx] = float64(stats.DepositCount)
+		blobs[idx] = float64(stats.BlobCount)
+		// transaction types are only counted for epochs indexed after the type breakdown was added
+		if typedCount := stats.TxLegacyCount + stats.TxAccessListCount + stats.TxDynamicFeeCount + stats.TxBlobCount + stats.TxSetCodeCount; typedCount > 0 {
+			dynamicFeeShare[idx] = float64(stats.TxDynamicFeeCount) * 100.0 / float64(typedCount)
+		}
+		blobTxs[idx] = float64(stats.TxBlobCount)
+		setCodeTxs[idx] = float64(stats.TxSetCodeCount)
+	}
+
+	if len(dailyStats) >= 2 {
+		pageData.Charts = []*models.EpochsPageSparkline{
+			buildEpochsSparkline("Target Participation", "%", participation, nil),
+			buildEpochsSparkline("Missed Slots", "", missed, nil),
+			buildEpochsSparkline("Transactions", "", transactions, nil),
+			buildEpochsSparkline("Withdrawals", " ETH", withdrawals, nil),
+			buildEpochsSparkline("Deposits", "", deposits, nil),
+			buildEpochsSparkline("Blobs", "", blobs, nil),
+			buildEpochsSparkline("EIP-1559 Share", "%", dynamicFeeShare, nil),
+			buildEpochsSparkline("Blob Transactions", "", blobTxs, nil),
+			buildEpochsSparkline("SetCode Transactions", "", setCodeTxs, nil),
+		}
+	}
+
+	return pageData, 10 * time.Minute
+}
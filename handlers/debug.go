@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/rpc"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+var processStartTime = time.Now()
+
+type debugDbStats struct {
+	Pools   []*db.PoolStats             `json:"pools"`
+	Queries []*db.QueryStats            `json:"queries"`
+	Storage *models.DebugRuntimeStorage `json:"storage"`
+}
+
+// DebugDbStats returns the database connection pool, per query & table size metrics as json
+func DebugDbStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(&debugDbStats{
+		Pools:   db.GetPoolStats(),
+		Queries: db.GetQueryStats(),
+		Storage: buildDebugRuntimeStorage(uint64(time.Now().Unix() / 86400)),
+	})
+	if err != nil {
+		logrus.WithError(err).Error("error encoding db stats")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+// DebugIndexerState returns a snapshot of the indexer state (cache bounds, processing progress, synchronizer & client heads) as json.
+// The snapshot exposes internals of the explorer, so it's only served to requests with one of the configured indexer state tokens.
+func DebugIndexerState(w http.ResponseWriter, r *http.Request) {
+	if !checkApiToken(r, utils.Config.Frontend.IndexerStateTokens) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(services.GlobalBeaconService.GetIndexer().GetIndexerState())
+	if err != nil {
+		logrus.WithError(err).Error("error encoding indexer state")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+// DebugBlsSpotChecks returns the signature spot check counters & the last failure as json
+func DebugBlsSpotChecks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(services.GlobalBeaconService.GetBlsSpotCheckStats())
+	if err != nil {
+		logrus.WithError(err).Error("error encoding bls spot check stats")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+// DebugBeaconRootChecks returns the EIP-4788 beacon root check counters & the recent mismatches as json
+func DebugBeaconRootChecks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(services.GlobalBeaconService.GetBeaconRootCheckStats())
+	if err != nil {
+		logrus.WithError(err).Error("error encoding beacon root check stats")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+// DebugCrashes returns the recovered panics of the indexer subroutines, page builders & request handlers as json
+func DebugCrashes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(utils.GetSubroutineCrashStats())
+	if err != nil {
+		logrus.WithError(err).Error("error encoding crash stats")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+// DebugRuntime returns a small standalone page with the runtime stats & links to the pprof profiles.
+// it doesn't use the page layout, as the operator listener usually doesn't serve the static files.
+func DebugRuntime(w http.ResponseWriter, r *http.Request) {
+	pageTemplate := templates.GetTemplate("debug/runtime.html")
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "debug.go", "DebugRuntime", "", pageTemplate.ExecuteTemplate(w, "runtime", buildDebugRuntimeData())) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// DebugRuntimeData returns the runtime stats as json
+func DebugRuntimeData(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(buildDebugRuntimeData())
+	if err != nil {
+		logrus.WithError(err).Error("error encoding runtime stats")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func buildDebugRuntimeData() *models.DebugRuntimePageData {
+	memStats := runtime.MemStats{}
+	runtime.ReadMemStats(&memStats)
+
+	now := time.Now()
+	pageData := &models.DebugRuntimePageData{
+		Ts:            now,
+		StartTime:     processStartTime,
+		Uptime:        now.Sub(processStartTime).Round(time.Second),
+		GoVersion:     runtime.Version(),
+		Version:       utils.GetExplorerVersion(),
+		NumCPU:        runtime.NumCPU(),
+		GoMaxProcs:    runtime.GOMAXPROCS(0),
+		Goroutines:    runtime.NumGoroutine(),
+		HeapAlloc:     memStats.HeapAlloc,
+		HeapInuse:     memStats.HeapInuse,
+		HeapIdle:      memStats.HeapIdle,
+		HeapReleased:  memStats.HeapReleased,
+		HeapSys:       memStats.HeapSys,
+		HeapObjects:   memStats.HeapObjects,
+		StackInuse:    memStats.StackInuse,
+		Sys:           memStats.Sys,
+		TotalAlloc:    memStats.TotalAlloc,
+		NumGC:         memStats.NumGC,
+		TotalGCPause:  time.Duration(memStats.PauseTotalNs),
+		GCCPUFraction: memStats.GCCPUFraction,
+		NextGC:        memStats.NextGC,
+	}
+	if memStats.NumGC > 0 {
+		pageData.LastGC = time.Unix(0, int64(memStats.LastGC))
+		pageData.LastGCPause = time.Duration(memStats.PauseNs[(memStats.NumGC+255)%256])
+	}
+
+	for _, crashStats := range utils.GetSubroutineCrashStats() {
+		pageData.Crashes = append(pageData.Crashes, &models.DebugRuntimeCrash{
+			Identifier: crashStats.Identifier,
+			Crashes:    crashStats.Crashes,
+			Restarts:   crashStats.Restarts,
+			LastCrash:  crashStats.LastCrash,
+			LastError:  crashStats.LastError,
+		})
+	}
+
+	if services.GlobalBeaconService != nil {
+		cacheStats := services.GlobalBeaconService.GetIndexer().GetCacheStats()
+		pageData.IndexerCache = &models.DebugRuntimeIndexerCache{
+			CachedSlots:      cacheStats.CachedSlots,
+			CachedBlocks:     cacheStats.CachedBlocks,
+			CachedEpochStats: cacheStats.CachedEpochStats,
+			LowestSlot:       cacheStats.LowestSlot,
+			HighestSlot:      cacheStats.HighestSlot,
+		}
+		pageData.Maintenance = buildDebugRuntimeMaintenance(services.GlobalBeaconService.GetDbMaintenanceStatus())
+	}
+	if db.ReaderDb != nil {
+		pageData.Storage = buildDebugRuntimeStorage(uint64(now.Unix() / 86400))
+	}
+	return pageData
+}
+
+// buildDebugRuntimeMaintenance converts the db maintenance schedule, a task is started on the next check within the window once it's due
+func buildDebugRuntimeMaintenance(status *services.DbMaintenanceStatus) *models.DebugRuntimeMaintenance {
+	maintenance := &models.DebugRuntimeMaintenance{
+		Enabled:  status.Enabled,
+		Window:   status.Window,
+		InWindow: status.InWindow,
+		Tasks:    make([]*models.DebugRuntimeMaintenanceTask, len(status.Tasks)),
+	}
+	for idx, task := range status.Tasks {
+		taskData := &models.DebugRuntimeMaintenanceTask{
+			Name:         task.Name,
+			Interval:     task.Interval,
+			Running:      task.Running,
+			LastRun:      task.LastRun,
+			LastDuration: task.LastDuration.Round(time.Second),
+			LastError:    task.LastError,
+		}
+		if !task.LastRun.IsZero() {
+			taskData.NextRun = task.LastRun.Add(task.Interval)
+		}
+		maintenance.Tasks[idx] = taskData
+	}
+	return maintenance
+}
+
+// buildDebugRuntimeStorage estimates the daily growth per table from the oldest & latest size sample of the last 30 days
+func buildDebugRuntimeStorage(today uint64) *models.DebugRuntimeStorage {
+	firstDay := uint64(0)
+	if today > 30 {
+		firstDay = today - 30
+	}
+	samples := db.GetTableSizeStats(firstDay)
+	if len(samples) == 0 {
+		return nil
+	}
+
+	firstSamples := map[string]*dbtypes.TableSizeStats{}
+	lastSamples := map[string]*dbtypes.TableSizeStats{}
+	tableNames := []string{}
+	for _, sample := range samples {
+		if firstSamples[sample.TableName] == nil {
+			firstSamples[sample.TableName] = sample
+			tableNames = append(tableNames, sample.TableName)
+		}
+		lastSamples[sample.TableName] = sample
+	}
+
+	lastDay := samples[len(samples)-1].Day
+	storage := &models.DebugRuntimeStorage{
+		SampleDay:  time.Unix(int64(lastDay*86400), 0).UTC(),
+		SampleDays: lastDay - samples[0].Day + 1,
+		Tables:     []*models.DebugRuntimeStorageTable{},
+	}
+	for _, tableName := range tableNames {
+		firstSample := firstSamples[tableName]
+		lastSample := lastSamples[tableName]
+		if lastSample.Day != lastDay {
+			// table has been dropped
+			continue
+		}
+		table := &models.DebugRuntimeStorageTable{
+			Name:     tableName,
+			RowCount: lastSample.RowCount,
+			Size:     lastSample.Size,
+		}
+		if sampleDays := int64(lastSample.Day - firstSample.Day); sampleDays > 0 {
+			table.DailyGrowth = (int64(lastSample.Size) - int64(firstSample.Size)) / sampleDays
+			table.DailyRows = (int64(lastSample.RowCount) - int64(firstSample.RowCount)) / sampleDays
+		}
+		storage.TotalSize += table.Size
+		storage.DailyGrowth += table.DailyGrowth
+		storage.Tables = append(storage.Tables, table)
+	}
+	sort.Slice(storage.Tables, func(a, b int) bool {
+		return storage.Tables[a].Size > storage.Tables[b].Size
+	})
+
+	projectSize := func(days int64) uint64 {
+		size := int64(storage.TotalSize) + storage.DailyGrowth*days
+		if size < 0 {
+			return 0
+		}
+		return uint64(size)
+	}
+	storage.Projected30d = projectSize(30)
+	storage.Projected90d = projectSize(90)
+	storage.Projected365d = projectSize(365)
+	return storage
+}
+
+// DebugRpc returns a small standalone page with the schema deviations of the beacon api responses per client,
+// including the raw payloads of the latest malformed responses.
+func DebugRpc(w http.ResponseWriter, r *http.Request) {
+	pageTemplate := templates.GetTemplate("debug/rpc.html")
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "debug.go", "DebugRpc", "", pageTemplate.ExecuteTemplate(w, "rpc", buildDebugRpcData())) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// DebugRpcData returns the beacon api response diagnostics as json
+func DebugRpcData(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(buildDebugRpcData())
+	if err != nil {
+		logrus.WithError(err).Error("error encoding rpc diagnostics")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func buildDebugRpcData() *models.DebugRpcPageData {
+	pageData := &models.DebugRpcPageData{
+		Ts:      time.Now(),
+		Enabled: utils.Config.BeaconApi.SchemaValidation,
+		Clients: []*models.DebugRpcPageClient{},
+	}
+	if services.GlobalBeaconService == nil {
+		return pageData
+	}
+
+	for _, client := range services.GlobalBeaconService.GetClients() {
+		schemaStats := client.GetRpcClient().GetSchemaStats()
+		clientData := &models.DebugRpcPageClient{
+			Name:      client.GetName(),
+			Version:   client.GetVersion(),
+			Endpoints: []*models.DebugRpcPageEndpoint{},
+			Captures:  []*models.DebugRpcPageCapture{},
+		}
+		for _, endpoint := range schemaStats.GetEndpoints() {
+			clientData.Checked += endpoint.Checked
+			clientData.Malformed += endpoint.Malformed
+			clientData.Endpoints = append(clientData.Endpoints, &models.DebugRpcPageEndpoint{
+				Endpoint:      endpoint.Endpoint,
+				Checked:       endpoint.Checked,
+				Malformed:     endpoint.Malformed,
+				ParseErrors:   endpoint.Deviations[rpc.SchemaDeviationParseError],
+				TypeMismatch:  endpoint.Deviations[rpc.SchemaDeviationTypeMismatch],
+				MissingFields: endpoint.Deviations[rpc.SchemaDeviationMissingField],
+				UnknownFields: endpoint.Deviations[rpc.SchemaDeviationUnknownField],
+				LastMalformed: endpoint.LastMalformed,
+			})
+		}
+		for _, capture := range schemaStats.GetCaptures() {
+			captureData := &models.DebugRpcPageCapture{
+				Time:       capture.Time,
+				Endpoint:   capture.Endpoint,
+				Url:        capture.Url,
+				Deviations: []*models.DebugRpcPageDeviation{},
+				Payload:    capture.Payload,
+				Truncated:  capture.Truncated,
+			}
+			for _, deviation := range capture.Deviations {
+				captureData.Deviations = append(captureData.Deviations, &models.DebugRpcPageDeviation{
+					Kind:   string(deviation.Kind),
+					Path:   deviation.Path,
+					Detail: deviation.Detail,
+				})
+			}
+			clientData.Captures = append(clientData.Captures, captureData)
+		}
+		pageData.Clients = append(pageData.Clients, clientData)
+	}
+	return pageData
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+const depositReceiptsPageSize = 50
+
+// DepositReceipts will return the EIP-6110 deposit flow page using a go template.
+// It follows the deposits from their inclusion in the execution payload to the validator activation.
+func DepositReceipts(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"deposit_receipts/deposit_receipts.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/deposit_receipts", "Deposit Flow", pageTemplateFiles)
+
+	pageIdx, _ := strconv.ParseUint(r.URL.Query().Get("p"), 10, 64)
+
+	var pageError error
+	data.Data, pageError = getDepositReceiptsPageData(pageIdx)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "deposit_receipts.go", "DepositReceipts", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getDepositReceiptsPageData(pageIdx uint64) (*models.DepositReceiptsPageData, error) {
+	pageData := &models.DepositReceiptsPageData{}
+	pageCacheKey := fmt.Sprintf("deposit_receipts:%v", pageIdx)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildDepositReceiptsPageData(pageIdx)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.DepositReceiptsPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildDepositReceiptsPageData(pageIdx uint64) (*models.DepositReceiptsPageData, time.Duration) {
+	logrus.Debugf("deposit receipts page called: %v", pageIdx)
+	pageData := &models.DepositReceiptsPageData{
+		PageIndex: pageIdx,
+		PageSize:  depositReceiptsPageSize,
+		Receipts:  []*models.DepositReceiptsPageDataReceipt{},
+	}
+	if pageIdx > 0 {
+		pageData.HasPrev = true
+		pageData.PrevPage = pageIdx - 1
+	}
+
+	// load one more receipt than shown to know if there is a next page
+	receipts := db.GetDepositReceipts(pageIdx*depositReceiptsPageSize, depositReceiptsPageSize+1)
+	if len(receipts) > depositReceiptsPageSize {
+		pageData.HasNext = true
+		pageData.NextPage = pageIdx + 1
+		receipts = receipts[:depositReceiptsPageSize]
+	}
+
+	validatorsByPubkey := map[string]*v1.Validator{}
+	for _, validator := range services.GlobalBeaconService.GetCachedValidatorSet() {
+		validatorsByPubkey[string(validator.Validator.PublicKey[:])] = validator
+	}
+
+	totalEligibleDelay := time.Duration(0)
+	totalActivatedDelay := time.Duration(0)
+	for _, receipt := range receipts {
+		receiptData := &models.DepositReceiptsPageDataReceipt{
+			Slot:                  receipt.Slot,
+			Time:                  utils.SlotToTime(receipt.Slot),
+			BlockRoot:             receipt.BlockRoot,
+			BlockNumber:           receipt.BlockNumber,
+			DepositIndex:          receipt.DepositIndex,
+			Pubkey:                receipt.Pubkey,
+			WithdrawalCredentials: receipt.WithdrawalCredentials,
+			Amount:                receipt.Amount,
+		}
+		pageData.Receipts = append(pageData.Receipts, receiptData)
+
+		validator := validatorsByPubkey[string(receipt.Pubkey)]
+		if validator == nil {
+			continue
+		}
+		validatorIndex := uint64(validator.Index)
+		receiptData.HasValidator = true
+		receiptData.ValidatorIndex = validatorIndex
+		receiptData.ValidatorName = services.GlobalBeaconService.GetValidatorName(validatorIndex)
+
+		// deposits for new validators get eligible with the next epoch processing,
+		// an earlier eligibility epoch means the deposit topped up an existing validator
+		eligibleEpoch := uint64(validator.Validator.ActivationEligibilityEpoch)
+		activationEpoch := uint64(validator.Validator.ActivationEpoch)
+		if eligibleEpoch <= utils.EpochOfSlot(receipt.Slot) {
+			receiptData.TopUp = true
+			pageData.TopUpCount++
+			continue
+		}
+		pageData.NewCount++
+		if eligibleEpoch != math.MaxUint64 {
+			receiptData.IsEligible = true
+			receiptData.EligibleEpoch = eligibleEpoch
+			receiptData.EligibleDelay = utils.EpochToTime(eligibleEpoch).Sub(receiptData.Time)
+			totalEligibleDelay += receiptData.EligibleDelay
+			pageData.EligibleCount++
+		}
+		if activationEpoch != math.MaxUint64 {
+			receiptData.IsActivated = true
+			receiptData.ActivationEpoch = activationEpoch
+			receiptData.ActivationDelay = utils.EpochToTime(activationEpoch).Sub(receiptData.Time)
+			totalActivatedDelay += receiptData.ActivationDelay
+			pageData.ActivatedCount++
+		}
+	}
+	if pageData.EligibleCount > 0 {
+		pageData.AvgEligibleDelay = totalEligibleDelay / time.Duration(pageData.EligibleCount)
+	}
+	if pageData.ActivatedCount > 0 {
+		pageData.AvgActivatedDelay = totalActivatedDelay / time.Duration(pageData.ActivatedCount)
+	}
+
+	return pageData, 1 * time.Minute
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -9,6 +10,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
 	"github.com/pk910/dora/services"
 	"github.com/pk910/dora/templates"
 	"github.com/pk910/dora/types/models"
@@ -35,7 +38,22 @@ func Epoch(w http.ResponseWriter, r *http.Request) {
 		epoch = uint64(utils.TimeToEpoch(time.Now()))
 	}
 
-	pageData, pageError := getEpochPageData(epoch)
+	if partial := getPagePartial(r, "epoch_slots"); partial != "" {
+		pageData, pageError := getEpochPageData(epoch, true)
+		if pageError != nil {
+			handlePartialError(w, r, pageError)
+			return
+		}
+		if pageData == nil {
+			http.Error(w, "Epoch not found", http.StatusNotFound)
+			return
+		}
+		handlePagePartial(w, r, "epoch.go", "Epoch", pageTemplate, partial, pageData)
+		return
+	}
+
+	// the page shell only contains the epoch overview, the slot table is loaded asynchronously
+	pageData, pageError := getEpochPageData(epoch, false)
 	if pageError != nil {
 		handlePageError(w, r, pageError)
 		return
@@ -57,11 +75,11 @@ func Epoch(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getEpochPageData(epoch uint64) (*models.EpochPageData, error) {
+func getEpochPageData(epoch uint64, withSlots bool) (*models.EpochPageData, error) {
 	pageData := &models.EpochPageData{}
-	pageCacheKey := fmt.Sprintf("epoch:%v", epoch)
+	pageCacheKey := fmt.Sprintf("epoch:%v:%v", epoch, withSlots)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildEpochPageData(epoch)
+		pageData, cacheTimeout := buildEpochPageData(epoch, withSlots)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -75,8 +93,10 @@ func getEpochPageData(epoch uint64) (*models.EpochPageData, error) {
 	return pageData, pageErr
 }
 
-func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
-	logrus.Debugf("epoch page called: %v", epoch)
+// buildEpochPageData builds the epoch overview and, if withSlots is set, the details of all slots in the epoch.
+// The overview only needs the slot counts, so the block details & slot annotations are skipped for the page shell.
+func buildEpochPageData(epoch uint64, withSlots bool) (*models.EpochPageData, time.Duration) {
+	logrus.Debugf("epoch page called: %v (slots: %v)", epoch, withSlots)
 
 	now := time.Now()
 	currentSlot := utils.TimeToSlot(uint64(now.Unix()))
@@ -101,6 +121,8 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 		Ts:            utils.EpochToTime(epoch),
 		Synchronized:  syncedEpochs[epoch],
 		Finalized:     finalizedEpoch >= int64(epoch),
+		// votes of an epoch can be included until the end of the next epoch
+		AttestationExport: utils.Config.Frontend.AttestationExportEnabled && epoch+1 < currentEpoch,
 	}
 
 	dbEpochs := services.GlobalBeaconService.GetDbEpochs(epoch, 1)
@@ -119,6 +141,15 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 		pageData.TotalVoted = dbEpoch.VotedTotal
 		pageData.SyncParticipation = float64(dbEpoch.SyncParticipation) * 100
 		pageData.ValidatorCount = dbEpoch.ValidatorCount
+		if dbEpoch.TxLegacyCount+dbEpoch.TxAccessListCount+dbEpoch.TxDynamicFeeCount+dbEpoch.TxBlobCount+dbEpoch.TxSetCodeCount > 0 {
+			pageData.TxTypes = &models.TransactionTypes{
+				Legacy:     dbEpoch.TxLegacyCount,
+				AccessList: dbEpoch.TxAccessListCount,
+				DynamicFee: dbEpoch.TxDynamicFeeCount,
+				Blob:       dbEpoch.TxBlobCount,
+				SetCode:    dbEpoch.TxSetCodeCount,
+			}
+		}
 		if dbEpoch.ValidatorCount > 0 {
 			pageData.AverageValidatorBalance = dbEpoch.ValidatorBalance / dbEpoch.ValidatorCount
 		}
@@ -132,21 +163,29 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 	// load slots
 	pageData.Slots = make([]*models.EpochPageDataSlot, 0)
 	dbSlots := services.GlobalBeaconService.GetDbBlocksForSlots(uint64(lastSlot), uint32(utils.Config.Chain.Config.SlotsPerEpoch), true)
+	// the arrivals are needed for the arrival chart of the page shell too
+	blobCounts, firstArrivals := getEpochPageBlockDetails(firstSlot, lastSlot, dbSlots)
 	dbIdx := 0
 	dbCnt := len(dbSlots)
 	blockCount := uint64(0)
+	var epochTarget []byte
 	for slotIdx := int64(lastSlot); slotIdx >= int64(firstSlot); slotIdx-- {
 		slot := uint64(slotIdx)
 		haveBlock := false
 		for dbIdx < dbCnt && dbSlots[dbIdx] != nil && dbSlots[dbIdx].Slot == slot {
 			dbSlot := dbSlots[dbIdx]
 			dbIdx++
-			blockStatus := uint8(1)
-			if dbSlot.Orphaned == 1 {
-				blockStatus = 2
+			blockStatus := models.NewSlotStatus(dbSlot.Orphaned)
+			if blockStatus.IsOrphaned() {
 				pageData.OrphanedCount++
 			} else {
 				pageData.CanonicalCount++
+				// slots are iterated backwards, so the last canonical block seen is the first one of the epoch
+				if dbSlot.Slot == firstSlot {
+					epochTarget = dbSlot.Root
+				} else {
+					epochTarget = dbSlot.ParentRoot
+				}
 			}
 
 			slotData := &models.EpochPageDataSlot{
@@ -165,6 +204,19 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 				EthTransactionCount:   dbSlot.EthTransactionCount,
 				Graffiti:              dbSlot.Graffiti,
 				BlockRoot:             dbSlot.Root,
+				ParentRoot:            dbSlot.ParentRoot,
+				StateRoot:             dbSlot.StateRoot,
+				BlobCount:             blobCounts[string(dbSlot.Root)],
+				WithdrawalCount:       dbSlot.WithdrawCount,
+				WithdrawalAmount:      dbSlot.WithdrawAmount,
+				BLSChangeCount:        dbSlot.BLSChangeCount,
+				EthBlockHash:          dbSlot.EthBlockHash,
+				EthFeeRecipient:       dbSlot.EthFeeRecipient,
+			}
+			if arrival := firstArrivals[string(dbSlot.Root)]; arrival != nil {
+				slotData.HasArrival = true
+				slotData.ArrivalDelay = arrival.Delay
+				slotData.ArrivalClient = arrival.Client
 			}
 			if dbSlot.EthBlockNumber != nil {
 				slotData.WithEthBlock = true
@@ -181,7 +233,7 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 				Epoch:        epoch,
 				Ts:           utils.SlotToTime(slot),
 				Scheduled:    slot >= currentSlot,
-				Status:       0,
+				Status:       models.SlotStatusMissed,
 				Proposer:     slotAssignments[slot],
 				ProposerName: services.GlobalBeaconService.GetValidatorName(slotAssignments[slot]),
 			}
@@ -195,6 +247,38 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 		}
 	}
 	pageData.BlockCount = uint64(blockCount)
+	if !withSlots {
+		pageData.ArrivalChart = buildEpochPageArrivalChart(firstSlot, pageData.Slots)
+		pageData.Slots = nil
+	}
+	setEpochPageAnnotations(pageData, firstSlot, lastSlot)
+
+	// load competing vote targets
+	if targetVotes := services.GlobalBeaconService.GetEpochTargetVotes(epoch, epoch)[epoch]; len(targetVotes) > 1 {
+		pageData.TargetSplit = true
+		pageData.TargetVotes = make([]*models.EpochPageDataVote, len(targetVotes))
+		for idx, targetVote := range targetVotes {
+			voteData := &models.EpochPageDataVote{
+				Root:      targetVote.TargetRoot,
+				Canonical: bytes.Equal(targetVote.TargetRoot, epochTarget),
+				Amount:    targetVote.VoteAmount,
+			}
+			if pageData.EligibleEther > 0 {
+				voteData.Participation = float64(targetVote.VoteAmount) * 100.0 / float64(pageData.EligibleEther)
+			}
+			pageData.TargetVotes[idx] = voteData
+		}
+	}
+
+	// load attestation aggregation redundancy
+	if aggregationStats := services.GlobalBeaconService.GetEpochAggregationStats(epoch); aggregationStats != nil {
+		pageData.Aggregation = buildAggregationStatsModel(aggregationStats.AggregateCount, aggregationStats.CommitteeCount, aggregationStats.RedundantCount, aggregationStats.VoteCount, aggregationStats.DuplicateVoteCount)
+	}
+
+	// the committee chart is part of the page shell, so it's not built again for the slot table
+	if !withSlots {
+		pageData.CommitteeChart = buildEpochPageCommitteeChart(firstSlot, services.GlobalBeaconService.GetSlotCommitteeParticipation(epoch))
+	}
 
 	var cacheTimeout time.Duration
 	if !pageData.Synchronized {
@@ -206,3 +290,183 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 	}
 	return pageData, cacheTimeout
 }
+
+// getEpochPageBlockDetails loads the blob counts & the first arrival of the blocks in the epoch.
+// unfinalized blocks are read from the indexer cache, the others from the db.
+// setEpochPageAnnotations adds the operator notes of the epoch and its slots.
+// Slot notes are shown on the first row of the slot, reorg notes on the row of the orphaned block.
+func setEpochPageAnnotations(pageData *models.EpochPageData, firstSlot uint64, lastSlot uint64) {
+	if !services.AnnotationsEnabled() {
+		return
+	}
+	pageData.Annotations = buildAnnotationModels(services.GetEpochAnnotations(pageData.Epoch, pageData.Epoch)[pageData.Epoch])
+	if len(pageData.Slots) == 0 {
+		return
+	}
+
+	slotAnnotations := services.GetSlotAnnotations(firstSlot, lastSlot)
+	seenSlots := map[uint64]bool{}
+	for _, slotData := range pageData.Slots {
+		firstRow := !seenSlots[slotData.Slot]
+		seenSlots[slotData.Slot] = true
+		for _, annotation := range slotAnnotations[slotData.Slot] {
+			if annotation.Type == dbtypes.AnnotationTypeReorg {
+				if !slotData.Status.IsOrphaned() || !bytes.Equal(annotation.Root, slotData.BlockRoot) {
+					continue
+				}
+			} else if !firstRow {
+				continue
+			}
+			slotData.Annotations = append(slotData.Annotations, buildAnnotationModels([]*dbtypes.Annotation{annotation})...)
+		}
+	}
+}
+
+func getEpochPageBlockDetails(firstSlot uint64, lastSlot uint64, dbSlots []*dbtypes.Block) (map[string]uint64, map[string]*dbtypes.BlockArrival) {
+	blobCounts := map[string]uint64{}
+	firstArrivals := map[string]*dbtypes.BlockArrival{}
+	loadFromDb := false
+	for _, dbSlot := range dbSlots {
+		if dbSlot == nil {
+			continue
+		}
+		cachedBlock := services.GlobalBeaconService.GetIndexer().GetCachedBlock(dbSlot.Root)
+		if cachedBlock == nil {
+			loadFromDb = true
+			continue
+		}
+		if blockBody := cachedBlock.GetBlockBody(); blockBody != nil {
+			blobKzgCommitments, _ := blockBody.BlobKzgCommitments()
+			blobCounts[string(dbSlot.Root)] = uint64(len(blobKzgCommitments))
+		}
+		slotTime := utils.SlotToTime(dbSlot.Slot)
+		for _, arrival := range cachedBlock.GetArrivals() {
+			delay := arrival.Time.Sub(slotTime).Milliseconds()
+			if firstArrival := firstArrivals[string(dbSlot.Root)]; firstArrival == nil || delay < firstArrival.Delay {
+				firstArrivals[string(dbSlot.Root)] = &dbtypes.BlockArrival{
+					Client: arrival.ClientName,
+					Delay:  delay,
+				}
+			}
+		}
+	}
+
+	if loadFromDb {
+		for _, blobCount := range db.GetBlobCountsForSlots(firstSlot, lastSlot) {
+			if _, found := blobCounts[string(blobCount.Root)]; !found {
+				blobCounts[string(blobCount.Root)] = blobCount.Count
+			}
+		}
+		for _, arrival := range db.GetBlockArrivalsForSlots(firstSlot, lastSlot) {
+			if firstArrivals[string(arrival.Root)] == nil {
+				firstArrivals[string(arrival.Root)] = arrival
+			}
+		}
+	}
+	return blobCounts, firstArrivals
+}
+
+// buildEpochPageArrivalChart builds the bar chart of the first arrival per canonical block, with the deadlines as lines.
+// The chart is drawn in ms, delays beyond the end of the slot are cut off at the chart top.
+func buildEpochPageArrivalChart(firstSlot uint64, slots []*models.EpochPageDataSlot) *models.EpochPageArrivals {
+	chart := &models.EpochPageArrivals{
+		SlotCount:           utils.Config.Chain.Config.SlotsPerEpoch,
+		SlotDuration:        int64(utils.Config.Chain.Config.SecondsPerSlot) * 1000,
+		AttestationDeadline: utils.SlotAttestationDeadline().Milliseconds(),
+		AggregateDeadline:   utils.SlotAggregateDeadline().Milliseconds(),
+	}
+	chart.AttestationLine = chart.SlotDuration - chart.AttestationDeadline
+	chart.AggregateLine = chart.SlotDuration - chart.AggregateDeadline
+
+	delaySum := int64(0)
+	for _, slot := range slots {
+		if !slot.HasArrival || !slot.Status.IsFound() {
+			continue
+		}
+		bar := &models.EpochPageArrivalBar{
+			X:      slot.Slot - firstSlot,
+			Slot:   slot.Slot,
+			Delay:  slot.ArrivalDelay,
+			Height: slot.ArrivalDelay,
+			Late:   slot.ArrivalDelay > chart.AttestationDeadline,
+		}
+		if bar.Height < 0 {
+			bar.Height = 0
+		} else if bar.Height > chart.SlotDuration {
+			bar.Height = chart.SlotDuration
+		}
+		bar.Y = chart.SlotDuration - bar.Height
+		chart.Bars = append(chart.Bars, bar)
+
+		chart.BlockCount++
+		delaySum += bar.Delay
+		if bar.Late {
+			chart.LateCount++
+		}
+		if bar.Delay > chart.MaxDelay {
+			chart.MaxDelay = bar.Delay
+		}
+	}
+	if chart.BlockCount == 0 {
+		return nil
+	}
+	chart.AvgDelay = delaySum / int64(chart.BlockCount)
+	return chart
+}
+
+// buildEpochPageCommitteeChart builds the participation heatmap with one column per slot and one row per committee index.
+// The hue goes from red (no votes included) to green (all votes included).
+func buildEpochPageCommitteeChart(firstSlot uint64, participation []*dbtypes.SlotCommitteeParticipation) *models.EpochPageCommittees {
+	if len(participation) == 0 {
+		return nil
+	}
+	chart := &models.EpochPageCommittees{
+		SlotCount:        utils.Config.Chain.Config.SlotsPerEpoch,
+		MinParticipation: 101,
+	}
+	participationSum := uint64(0)
+	for _, slotParticipation := range participation {
+		if uint64(len(slotParticipation.Participation)) > chart.CommitteeCount {
+			chart.CommitteeCount = uint64(len(slotParticipation.Participation))
+		}
+		for committeeIndex, value := range slotParticipation.Participation {
+			cell := &models.EpochPageCommitteeCell{
+				X:             slotParticipation.Slot - firstSlot,
+				Y:             uint64(committeeIndex),
+				Slot:          slotParticipation.Slot,
+				Committee:     uint64(committeeIndex),
+				Participation: uint64(value),
+				Hue:           uint64(value) * 120 / 100,
+			}
+			chart.Cells = append(chart.Cells, cell)
+			participationSum += cell.Participation
+			if cell.Participation < 50 {
+				chart.LowCount++
+			}
+			if cell.Participation < chart.MinParticipation {
+				chart.MinParticipation = cell.Participation
+				chart.MinSlot = cell.Slot
+				chart.MinCommittee = cell.Committee
+			}
+		}
+	}
+	if len(chart.Cells) == 0 {
+		return nil
+	}
+	chart.AvgParticipation = float64(participationSum) / float64(len(chart.Cells))
+	return chart
+}
+
+func buildAggregationStatsModel(aggregateCount uint64, committeeCount uint64, redundantCount uint64, voteCount uint64, duplicateVoteCount uint64) *models.AggregationStats {
+	stats := &models.AggregationStats{
+		AggregateCount:     aggregateCount,
+		CommitteeCount:     committeeCount,
+		RedundantCount:     redundantCount,
+		VoteCount:          voteCount,
+		DuplicateVoteCount: duplicateVoteCount,
+	}
+	if voteCount > 0 {
+		stats.DuplicateRatio = float64(duplicateVoteCount) * 100.0 / float64(voteCount)
+	}
+	return stats
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/utils"
+)
+
+// attestation exports load the blocks of two epochs, so only one export is built at a time
+var epochAttestationExportMutex sync.Mutex
+
+var epochAttestationExportHeader = []string{
+	"validator_index", "attestation_slot", "committee_index", "committee_position",
+	"inclusion_slot", "inclusion_delay", "correct_target", "correct_head",
+}
+
+type epochAttestationExportRow struct {
+	validatorIndex    uint64
+	slot              uint64
+	committeeIndex    uint64
+	committeePosition int
+	inclusionSlot     uint64
+	correctTarget     bool
+	correctHead       bool
+}
+
+// EpochAttestationExport returns the attestation duties of an epoch with their first inclusion as gzip compressed csv.
+// Duties without an included vote are exported with an empty inclusion slot.
+func EpochAttestationExport(w http.ResponseWriter, r *http.Request) {
+	epoch, err := strconv.ParseUint(mux.Vars(r)["epoch"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid epoch", http.StatusBadRequest)
+		return
+	}
+	currentEpoch := utils.EpochOfSlot(utils.TimeToSlot(uint64(time.Now().Unix())))
+	if epoch+1 >= currentEpoch {
+		// votes can be included until the end of the next epoch
+		http.Error(w, "epoch votes not complete yet", http.StatusBadRequest)
+		return
+	}
+
+	epochAttestationExportMutex.Lock()
+	rows, err := buildEpochAttestationExport(epoch)
+	epochAttestationExportMutex.Unlock()
+	if err != nil {
+		logrus.WithError(err).WithField("request_id", getRequestId(r)).Warnf("error building attestation export for epoch %v", epoch)
+		http.Error(w, err.Error(), getPageErrorKind(err).Status)
+		return
+	}
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	csvWriter := csv.NewWriter(gzipWriter)
+	csvWriter.Write(epochAttestationExportHeader)
+	for _, row := range rows {
+		record := []string{
+			strconv.FormatUint(row.validatorIndex, 10),
+			strconv.FormatUint(row.slot, 10),
+			strconv.FormatUint(row.committeeIndex, 10),
+			strconv.Itoa(row.committeePosition),
+			"", "",
+			strconv.FormatBool(row.correctTarget),
+			strconv.FormatBool(row.correctHead),
+		}
+		if row.inclusionSlot > 0 {
+			record[4] = strconv.FormatUint(row.inclusionSlot, 10)
+			record[5] = strconv.FormatUint(row.inclusionSlot-row.slot, 10)
+		}
+		csvWriter.Write(record)
+	}
+	csvWriter.Flush()
+	if err := gzipWriter.Close(); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"epoch-%v-attestations.csv.gz\"", epoch))
+	w.Write(buf.Bytes())
+}
+
+// buildEpochAttestationExport matches the attestation duties of the epoch with the votes included in the canonical
+// blocks of the epoch and the following one. Only the first inclusion of a vote is exported.
+func buildEpochAttestationExport(epoch uint64) ([]*epochAttestationExportRow, error) {
+	assignments, err := services.GlobalBeaconService.GetEpochAssignments(epoch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: epoch duties not available: %v", ErrUpstreamUnavailable, err)
+	}
+	if assignments == nil {
+		return nil, fmt.Errorf("%w: epoch duties not available", ErrDataPruned)
+	}
+
+	rowMap := map[string][]*epochAttestationExportRow{}
+	rows := []*epochAttestationExportRow{}
+	for committeeKey, validators := range assignments.AttestorAssignments {
+		var slot, committeeIndex uint64
+		if _, err := fmt.Sscanf(committeeKey, "%d-%d", &slot, &committeeIndex); err != nil {
+			continue
+		}
+		committeeRows := make([]*epochAttestationExportRow, len(validators))
+		for position, validatorIndex := range validators {
+			committeeRows[position] = &epochAttestationExportRow{
+				validatorIndex:    validatorIndex,
+				slot:              slot,
+				committeeIndex:    committeeIndex,
+				committeePosition: position,
+			}
+		}
+		rowMap[committeeKey] = committeeRows
+		rows = append(rows, committeeRows...)
+	}
+
+	// load the canonical block roots of the epoch & the blocks of the inclusion range
+	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := firstSlot + 2*utils.Config.Chain.Config.SlotsPerEpoch - 1
+	blocks := make(map[uint64]*services.CombinedBlockResponse)
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block, err := services.GlobalBeaconService.GetSlotDetailsBySlot(slot)
+		if err != nil {
+			return nil, fmt.Errorf("%w: error loading block %v: %v", ErrUpstreamUnavailable, slot, err)
+		}
+		if block != nil && !block.Orphaned && block.Block != nil {
+			blocks[slot] = block
+		}
+	}
+
+	// the target root is the epoch boundary block, or the last block before it if the boundary slot was missed
+	var targetRoot []byte
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		if block := blocks[slot]; block != nil {
+			if slot == firstSlot {
+				targetRoot = block.Root
+			} else {
+				targetRoot = block.Header.Message.ParentRoot[:]
+			}
+			break
+		}
+	}
+
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block := blocks[slot]
+		if block == nil {
+			continue
+		}
+		attestations, err := block.Block.Attestations()
+		if err != nil {
+			continue
+		}
+		for _, att := range attestations {
+			attSlot := uint64(att.Data.Slot)
+			if utils.EpochOfSlot(attSlot) != epoch {
+				continue
+			}
+			committeeRows := rowMap[fmt.Sprintf("%v-%v", attSlot, uint64(att.Data.Index))]
+			if committeeRows == nil {
+				continue
+			}
+
+			// the correct head vote is the parent of the next canonical block after the attestation slot
+			var headRoot []byte
+			for headSlot := attSlot + 1; headSlot <= slot; headSlot++ {
+				if headBlock := blocks[headSlot]; headBlock != nil {
+					headRoot = headBlock.Header.Message.ParentRoot[:]
+					break
+				}
+			}
+			correctTarget := bytes.Equal(att.Data.Target.Root[:], targetRoot)
+			correctHead := correctTarget && bytes.Equal(att.Data.BeaconBlockRoot[:], headRoot)
+
+			for position, row := range committeeRows {
+				if row.inclusionSlot > 0 || !utils.BitAtVector(att.AggregationBits, position) {
+					continue
+				}
+				row.inclusionSlot = slot
+				row.correctTarget = correctTarget
+				row.correctHead = correctHead
+			}
+		}
+	}
+
+	sort.Slice(rows, func(a, b int) bool {
+		if rows[a].slot != rows[b].slot {
+			return rows[a].slot < rows[b].slot
+		}
+		if rows[a].committeeIndex != rows[b].committeeIndex {
+			return rows[a].committeeIndex < rows[b].committeeIndex
+		}
+		return rows[a].committeePosition < rows[b].committeePosition
+	})
+	return rows, nil
+}
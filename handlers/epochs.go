@@ -5,8 +5,10 @@ import (
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/pk910/dora/dbtypes"
 	"github.com/pk910/dora/services"
 	"github.com/pk910/dora/templates"
 	"github.com/pk910/dora/types/models"
@@ -67,6 +69,10 @@ func getEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPageDa
 func buildEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPageData, time.Duration) {
 	logrus.Debugf("epochs page called: %v:%v", firstEpoch, pageSize)
 	pageData := &models.EpochsPageData{}
+	if utils.Config.Indexer.DisableIndexWriter {
+		pageData.LightMode = true
+		pageData.LowestLiveEpoch = uint64(services.GlobalBeaconService.GetIndexer().GetLowestLiveEpoch())
+	}
 
 	now := time.Now()
 	currentEpoch := utils.TimeToEpoch(now)
@@ -110,6 +116,11 @@ func buildEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPage
 	dbIdx := 0
 	dbCnt := len(dbEpochs)
 	epochCount := uint64(0)
+	lastEpoch := int64(firstEpoch) - int64(epochLimit) + 1
+	if lastEpoch < 0 {
+		lastEpoch = 0
+	}
+	targetVotes := services.GlobalBeaconService.GetEpochTargetVotes(uint64(lastEpoch), firstEpoch)
 	allFinalized := true
 	allSynchronized := true
 	for epochIdx := int64(firstEpoch); epochIdx >= 0 && epochCount < epochLimit; epochIdx-- {
@@ -125,26 +136,8 @@ func buildEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPage
 			Justified: justifiedEpoch >= epochIdx,
 		}
 		if dbIdx < dbCnt && dbEpochs[dbIdx] != nil && dbEpochs[dbIdx].Epoch == epoch {
-			dbEpoch := dbEpochs[dbIdx]
+			setEpochsPageEpochData(epochData, dbEpochs[dbIdx], targetVotes[epoch])
 			dbIdx++
-			epochData.Synchronized = true
-			epochData.CanonicalBlockCount = uint64(dbEpoch.BlockCount)
-			epochData.OrphanedBlockCount = uint64(dbEpoch.OrphanedCount)
-			epochData.AttestationCount = dbEpoch.AttestationCount
-			epochData.DepositCount = dbEpoch.DepositCount
-			epochData.ExitCount = dbEpoch.ExitCount
-			epochData.ProposerSlashingCount = dbEpoch.ProposerSlashingCount
-			epochData.AttesterSlashingCount = dbEpoch.AttesterSlashingCount
-			epochData.EligibleEther = dbEpoch.Eligible
-			epochData.TargetVoted = dbEpoch.VotedTarget
-			epochData.HeadVoted = dbEpoch.VotedHead
-			epochData.TotalVoted = dbEpoch.VotedTotal
-			if dbEpoch.Eligible > 0 {
-				epochData.TargetVoteParticipation = float64(dbEpoch.VotedTarget) * 100.0 / float64(dbEpoch.Eligible)
-				epochData.HeadVoteParticipation = float64(dbEpoch.VotedHead) * 100.0 / float64(dbEpoch.Eligible)
-				epochData.TotalVoteParticipation = float64(dbEpoch.VotedTotal) * 100.0 / float64(dbEpoch.Eligible)
-			}
-			epochData.EthTransactionCount = dbEpoch.EthTransactionCount
 		} else {
 			allSynchronized = false
 		}
@@ -154,6 +147,8 @@ func buildEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPage
 	pageData.EpochCount = uint64(epochCount)
 	pageData.FirstEpoch = firstEpoch
 	pageData.LastEpoch = firstEpoch - pageData.EpochCount + 1
+	setEpochsPageAnnotations(pageData)
+	pageData.Charts = buildEpochsPageCharts(pageData.Epochs)
 
 	var cacheTimeout time.Duration
 	if !allSynchronized {
@@ -167,3 +162,134 @@ func buildEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPage
 	}
 	return pageData, cacheTimeout
 }
+
+// setEpochsPageEpochData fills the synchronized epoch stats of an epochs page row
+func setEpochsPageEpochData(epochData *models.EpochsPageDataEpoch, dbEpoch *dbtypes.Epoch, targetVotes []*dbtypes.EpochTargetVote) {
+	epochData.Synchronized = true
+	epochData.CanonicalBlockCount = uint64(dbEpoch.BlockCount)
+	epochData.OrphanedBlockCount = uint64(dbEpoch.OrphanedCount)
+	epochData.AttestationCount = dbEpoch.AttestationCount
+	epochData.DepositCount = dbEpoch.DepositCount
+	epochData.ExitCount = dbEpoch.ExitCount
+	epochData.ProposerSlashingCount = dbEpoch.ProposerSlashingCount
+	epochData.AttesterSlashingCount = dbEpoch.AttesterSlashingCount
+	epochData.EligibleEther = dbEpoch.Eligible
+	epochData.TargetVoted = dbEpoch.VotedTarget
+	epochData.HeadVoted = dbEpoch.VotedHead
+	epochData.TotalVoted = dbEpoch.VotedTotal
+	if dbEpoch.Eligible > 0 {
+		epochData.TargetVoteParticipation = float64(dbEpoch.VotedTarget) * 100.0 / float64(dbEpoch.Eligible)
+		epochData.HeadVoteParticipation = float64(dbEpoch.VotedHead) * 100.0 / float64(dbEpoch.Eligible)
+		epochData.TotalVoteParticipation = float64(dbEpoch.VotedTotal) * 100.0 / float64(dbEpoch.Eligible)
+	}
+	epochData.EthTransactionCount = dbEpoch.EthTransactionCount
+	epochData.BlobCount = dbEpoch.BlobCount
+	epochData.TargetSplit = len(targetVotes) > 1
+	epochData.Alerts = services.GetEpochAlerts(dbEpoch)
+}
+
+// setEpochsPageAnnotations adds the operator notes of each epoch and its slots
+func setEpochsPageAnnotations(pageData *models.EpochsPageData) {
+	if !services.AnnotationsEnabled() || pageData.EpochCount == 0 {
+		return
+	}
+	slotsPerEpoch := utils.Config.Chain.Config.SlotsPerEpoch
+	epochAnnotations := services.GetEpochAnnotations(pageData.LastEpoch, pageData.FirstEpoch)
+	slotAnnotations := services.GetSlotAnnotations(pageData.LastEpoch*slotsPerEpoch, (pageData.FirstEpoch+1)*slotsPerEpoch-1)
+	for _, epochData := range pageData.Epochs {
+		for _, annotation := range epochAnnotations[epochData.Epoch] {
+			epochData.Annotations = append(epochData.Annotations, annotation.Note)
+		}
+		for slot := epochData.Epoch * slotsPerEpoch; slot < (epochData.Epoch+1)*slotsPerEpoch; slot++ {
+			for _, annotation := range slotAnnotations[slot] {
+				epochData.Annotations = append(epochData.Annotations, fmt.Sprintf("slot %v: %v", slot, annotation.Note))
+			}
+		}
+	}
+}
+
+const (
+	epochsSparklineWidth  = 240
+	epochsSparklineHeight = 40
+)
+
+// buildEpochsPageCharts builds the participation, blob & transaction sparklines for all synchronized epochs on the page
+func buildEpochsPageCharts(epochs []*models.EpochsPageDataEpoch) []*models.EpochsPageSparkline {
+	participation := make([]float64, 0, len(epochs))
+	blobs := make([]float64, 0, len(epochs))
+	transactions := make([]float64, 0, len(epochs))
+	markers := map[int]*models.EpochsPageSparklineMarker{}
+	for idx := len(epochs) - 1; idx >= 0; idx-- {
+		epoch := epochs[idx]
+		if !epoch.Synchronized {
+			continue
+		}
+		if len(epoch.Annotations) > 0 {
+			markers[len(participation)] = &models.EpochsPageSparklineMarker{
+				Epoch: epoch.Epoch,
+				Title: fmt.Sprintf("epoch %v: %v", epoch.Epoch, strings.Join(epoch.Annotations, ", ")),
+			}
+		}
+		participation = append(participation, epoch.TargetVoteParticipation)
+		blobs = append(blobs, float64(epoch.BlobCount))
+		transactions = append(transactions, float64(epoch.EthTransactionCount))
+	}
+	if len(participation) < 2 {
+		return nil
+	}
+
+	return []*models.EpochsPageSparkline{
+		buildEpochsSparkline("Target Participation", "%", participation, markers),
+		buildEpochsSparkline("Blobs", "", blobs, markers),
+		buildEpochsSparkline("Transactions", "", transactions, markers),
+	}
+}
+
+func buildEpochsSparkline(title string, unit string, values []float64, markers map[int]*models.EpochsPageSparklineMarker) *models.EpochsPageSparkline {
+	sparkline := &models.EpochsPageSparkline{
+		Title:  title,
+		Unit:   unit,
+		Values: values,
+		Width:  epochsSparklineWidth,
+		Height: epochsSparklineHeight,
+		Last:   values[len(values)-1],
+		Min:    values[0],
+		Max:    values[0],
+	}
+	total := float64(0)
+	for _, value := range values {
+		if value < sparkline.Min {
+			sparkline.Min = value
+		}
+		if value > sparkline.Max {
+			sparkline.Max = value
+		}
+		total += value
+	}
+	sparkline.Average = total / float64(len(values))
+
+	// keep 2px padding, so the line isn't cut at the top & bottom edge
+	drawHeight := float64(epochsSparklineHeight - 4)
+	stepWidth := float64(epochsSparklineWidth) / float64(len(values)-1)
+	var points strings.Builder
+	for idx, value := range values {
+		y := drawHeight / 2
+		if sparkline.Max > sparkline.Min {
+			y = drawHeight - (value-sparkline.Min)/(sparkline.Max-sparkline.Min)*drawHeight
+		}
+		fmt.Fprintf(&points, "%.1f,%.1f ", float64(idx)*stepWidth, y+2)
+	}
+	sparkline.Points = strings.TrimSpace(points.String())
+
+	for idx := range values {
+		if marker := markers[idx]; marker != nil {
+			sparkline.Markers = append(sparkline.Markers, &models.EpochsPageSparklineMarker{
+				Epoch: marker.Epoch,
+				X:     float64(idx) * stepWidth,
+				Title: marker.Title,
+			})
+		}
+	}
+
+	return sparkline
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// EpochsFiltered will return the filtered "epochs" page using a go template
+func EpochsFiltered(w http.ResponseWriter, r *http.Request) {
+	var epochsTemplateFiles = append(layoutTemplateFiles,
+		"epochs_filtered/epochs_filtered.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(epochsTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/epochs/filtered", "Filtered Epochs", epochsTemplateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 0
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+	}
+
+	filterArgs := parseEpochsFilterArgs(urlArgs)
+	var pageError error
+	data.Data, pageError = getFilteredEpochsPageData(pageIdx, pageSize, filterArgs)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "epochs_filtered.go", "EpochsFiltered", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// epochsFilterArgs holds the epoch filter arguments of the filtered epochs page
+type epochsFilterArgs struct {
+	from         string
+	to           string
+	minEpoch     string
+	maxEpoch     string
+	finalized    uint8
+	minPart      string
+	maxPart      string
+	withOrphaned uint8
+}
+
+func parseEpochsFilterArgs(urlArgs url.Values) *epochsFilterArgs {
+	filterArgs := &epochsFilterArgs{}
+	if urlArgs.Has("f") {
+		filterArgs.from = urlArgs.Get("f.from")
+		filterArgs.to = urlArgs.Get("f.to")
+		filterArgs.minEpoch = urlArgs.Get("f.minepoch")
+		filterArgs.maxEpoch = urlArgs.Get("f.maxepoch")
+		filterArgs.minPart = urlArgs.Get("f.minpart")
+		filterArgs.maxPart = urlArgs.Get("f.maxpart")
+		if urlArgs.Has("f.finalized") {
+			finalized, _ := strconv.ParseUint(urlArgs.Get("f.finalized"), 10, 8)
+			filterArgs.finalized = uint8(finalized)
+		}
+		if urlArgs.Has("f.orphaned") {
+			withOrphaned, _ := strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 8)
+			filterArgs.withOrphaned = uint8(withOrphaned)
+		}
+	} else {
+		filterArgs.withOrphaned = 1
+	}
+	return filterArgs
+}
+
+func (fa *epochsFilterArgs) cacheKey() string {
+	return fmt.Sprintf("%v:%v:%v:%v:%v:%v:%v:%v", fa.from, fa.to, fa.minEpoch, fa.maxEpoch, fa.finalized, fa.minPart, fa.maxPart, fa.withOrphaned)
+}
+
+func (fa *epochsFilterArgs) urlValues() url.Values {
+	filterArgs := url.Values{}
+	if fa.from != "" {
+		filterArgs.Add("f.from", fa.from)
+	}
+	if fa.to != "" {
+		filterArgs.Add("f.to", fa.to)
+	}
+	if fa.minEpoch != "" {
+		filterArgs.Add("f.minepoch", fa.minEpoch)
+	}
+	if fa.maxEpoch != "" {
+		filterArgs.Add("f.maxepoch", fa.maxEpoch)
+	}
+	if fa.finalized != 0 {
+		filterArgs.Add("f.finalized", fmt.Sprintf("%v", fa.finalized))
+	}
+	if fa.minPart != "" {
+		filterArgs.Add("f.minpart", fa.minPart)
+	}
+	if fa.maxPart != "" {
+		filterArgs.Add("f.maxpart", fa.maxPart)
+	}
+	if fa.withOrphaned != 0 {
+		filterArgs.Add("f.orphaned", fmt.Sprintf("%v", fa.withOrphaned))
+	}
+	return filterArgs
+}
+
+// parseEpochsFilterTime parses the time range inputs, a date without time is the whole day (UTC)
+func parseEpochsFilterTime(value string, endOfDay bool) (time.Time, error) {
+	if ts, err := time.Parse("2006-01-02T15:04", value); err == nil {
+		return ts, nil
+	}
+	ts, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return ts, fmt.Errorf("invalid date %v", value)
+	}
+	if endOfDay {
+		ts = ts.Add(24*time.Hour - time.Second)
+	}
+	return ts, nil
+}
+
+// epochFilter converts the filter arguments to the db filter, the time range is narrowed down to the epoch range
+func (fa *epochsFilterArgs) epochFilter() (*dbtypes.EpochFilter, error) {
+	epochFilter := &dbtypes.EpochFilter{
+		Finalized:    fa.finalized,
+		WithOrphaned: fa.withOrphaned,
+	}
+	setMinEpoch := func(epoch uint64) {
+		if epochFilter.MinEpoch == nil || epoch > *epochFilter.MinEpoch {
+			epochFilter.MinEpoch = &epoch
+		}
+	}
+	setMaxEpoch := func(epoch uint64) {
+		if epochFilter.MaxEpoch == nil || epoch < *epochFilter.MaxEpoch {
+			epochFilter.MaxEpoch = &epoch
+		}
+	}
+
+	if minEpoch, err := strconv.ParseUint(fa.minEpoch, 10, 64); err == nil {
+		setMinEpoch(minEpoch)
+	}
+	if maxEpoch, err := strconv.ParseUint(fa.maxEpoch, 10, 64); err == nil {
+		setMaxEpoch(maxEpoch)
+	}
+	if fa.from != "" {
+		fromTime, err := parseEpochsFilterTime(fa.from, false)
+		if err != nil {
+			return nil, err
+		}
+		setMinEpoch(uint64(utils.TimeToEpoch(fromTime)))
+	}
+	if fa.to != "" {
+		toTime, err := parseEpochsFilterTime(fa.to, true)
+		if err != nil {
+			return nil, err
+		}
+		if toTime.Unix() < int64(utils.Config.Chain.GenesisTimestamp) {
+			return nil, fmt.Errorf("time range ends before genesis")
+		}
+		setMaxEpoch(uint64(utils.TimeToEpoch(toTime)))
+	}
+
+	if fa.minPart != "" {
+		minPart, err := strconv.ParseFloat(fa.minPart, 64)
+		if err != nil || minPart < 0 || minPart > 100 {
+			return nil, fmt.Errorf("invalid minimum participation %v", fa.minPart)
+		}
+		epochFilter.MinParticipation = &minPart
+	}
+	if fa.maxPart != "" {
+		maxPart, err := strconv.ParseFloat(fa.maxPart, 64)
+		if err != nil || maxPart < 0 || maxPart > 100 {
+			return nil, fmt.Errorf("invalid maximum participation %v", fa.maxPart)
+		}
+		epochFilter.MaxParticipation = &maxPart
+	}
+	return epochFilter, nil
+}
+
+func getFilteredEpochsPageData(pageIdx uint64, pageSize uint64, filterArgs *epochsFilterArgs) (*models.EpochsFilteredPageData, error) {
+	pageData := &models.EpochsFilteredPageData{}
+	pageCacheKey := fmt.Sprintf("epochs_filtered:%v:%v:%v", pageIdx, pageSize, filterArgs.cacheKey())
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildFilteredEpochsPageData(pageIdx, pageSize, filterArgs)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.EpochsFilteredPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildFilteredEpochsPageData(pageIdx uint64, pageSize uint64, filterArgs *epochsFilterArgs) *models.EpochsFilteredPageData {
+	pageData := &models.EpochsFilteredPageData{
+		FilterFrom:             filterArgs.from,
+		FilterTo:               filterArgs.to,
+		FilterMinEpoch:         filterArgs.minEpoch,
+		FilterMaxEpoch:         filterArgs.maxEpoch,
+		FilterFinalized:        filterArgs.finalized,
+		FilterMinParticipation: filterArgs.minPart,
+		FilterMaxParticipation: filterArgs.maxPart,
+		FilterWithOrphaned:     filterArgs.withOrphaned,
+	}
+	logrus.Debugf("epochs_filtered page called: %v:%v [%v]", pageIdx, pageSize, filterArgs.cacheKey())
+	if pageIdx == 0 {
+		pageData.IsDefaultPage = true
+	}
+
+	if pageSize == 0 || pageSize > 100 {
+		pageSize = 100
+	}
+	pageData.PageSize = pageSize
+	pageData.TotalPages = pageIdx + 1
+	pageData.CurrentPageIndex = pageIdx + 1
+	if pageIdx >= 1 {
+		pageData.PrevPageIndex = pageIdx
+	}
+
+	pageData.Epochs = make([]*models.EpochsPageDataEpoch, 0)
+	epochFilter, err := filterArgs.epochFilter()
+	if err != nil {
+		pageData.FilterError = err.Error()
+		return pageData
+	}
+
+	finalizedEpoch, _, justifiedEpoch, _ := services.GlobalBeaconService.GetIndexer().GetFinalizationCheckpoints()
+	dbEpochs := services.GlobalBeaconService.GetDbEpochsByFilter(epochFilter, pageIdx, uint32(pageSize))
+	haveMore := uint64(len(dbEpochs)) > pageSize
+	if haveMore {
+		dbEpochs = dbEpochs[:pageSize]
+	}
+	var targetVotes map[uint64][]*dbtypes.EpochTargetVote
+	if len(dbEpochs) > 0 {
+		targetVotes = services.GlobalBeaconService.GetEpochTargetVotes(dbEpochs[len(dbEpochs)-1].Epoch, dbEpochs[0].Epoch)
+	}
+	for _, dbEpoch := range dbEpochs {
+		epochData := &models.EpochsPageDataEpoch{
+			Epoch:     dbEpoch.Epoch,
+			Ts:        utils.EpochToTime(dbEpoch.Epoch),
+			Finalized: finalizedEpoch >= int64(dbEpoch.Epoch),
+			Justified: justifiedEpoch >= int64(dbEpoch.Epoch),
+		}
+		setEpochsPageEpochData(epochData, dbEpoch, targetVotes[dbEpoch.Epoch])
+		pageData.Epochs = append(pageData.Epochs, epochData)
+	}
+	pageData.EpochCount = uint64(len(pageData.Epochs))
+	if pageData.EpochCount > 0 {
+		pageData.FirstEpoch = pageData.Epochs[0].Epoch
+		pageData.LastEpoch = pageData.Epochs[pageData.EpochCount-1].Epoch
+	}
+	if haveMore {
+		pageData.NextPageIndex = pageIdx + 2
+		pageData.TotalPages++
+	}
+
+	filterQuery := filterArgs.urlValues().Encode()
+	pageData.FirstPageLink = fmt.Sprintf("/epochs/filtered?f&%v&c=%v", filterQuery, pageData.PageSize)
+	if pageIdx >= 1 {
+		pageData.PrevPageLink = fmt.Sprintf("/epochs/filtered?f&%v&c=%v&p=%v", filterQuery, pageData.PageSize, pageIdx-1)
+	}
+	pageData.NextPageLink = fmt.Sprintf("/epochs/filtered?f&%v&c=%v&p=%v", filterQuery, pageData.PageSize, pageIdx+1)
+
+	return pageData
+}
@@ -17,6 +17,44 @@ import (
 
 var InvalidPageModelError = errors.New("invalid page model")
 
+// page builders wrap these errors (fmt.Errorf("%w: ...")), so the error page & status code tell the cause apart
+var (
+	ErrPageNotFound        = errors.New("not found")
+	ErrUpstreamUnavailable = errors.New("upstream rpc failure")
+	ErrDataPruned          = errors.New("data pruned")
+)
+
+// pageErrorKind describes a class of page errors with its status code & the text shown on the error page
+type pageErrorKind struct {
+	Code    string
+	Status  int
+	Title   string
+	Message string
+}
+
+var (
+	pageErrorInternal = &pageErrorKind{"internal_error", http.StatusInternalServerError, "Page Error", "Sorry, there was an unexpected error when processing the page you requested."}
+	pageErrorNotFound = &pageErrorKind{"not_found", http.StatusNotFound, "Not Found", "Sorry, but we could not find the data you are looking for."}
+	pageErrorUpstream = &pageErrorKind{"upstream_unavailable", http.StatusBadGateway, "Node Unavailable", "The beacon nodes backing this explorer failed to provide the requested data. Please try again in a few moments."}
+	pageErrorPruned   = &pageErrorKind{"data_pruned", http.StatusGone, "Data Pruned", "The requested data is no longer available, it has been pruned by the beacon nodes or the explorer database."}
+	pageErrorTimeout  = &pageErrorKind{"page_timeout", http.StatusGatewayTimeout, "Page Timeout", "Building the page you requested took too long. Please try again in a few moments."}
+)
+
+func getPageErrorKind(pageError error) *pageErrorKind {
+	switch {
+	case errors.Is(pageError, ErrPageNotFound):
+		return pageErrorNotFound
+	case errors.Is(pageError, ErrDataPruned):
+		return pageErrorPruned
+	case errors.Is(pageError, ErrUpstreamUnavailable):
+		return pageErrorUpstream
+	}
+	if fcError, isOk := pageError.(*services.FrontendCachePageError); isOk && fcError.Name() == "page timeout" {
+		return pageErrorTimeout
+	}
+	return pageErrorInternal
+}
+
 type customFileServer struct {
 	handler         http.Handler
 	root            http.FileSystem
@@ -82,22 +120,41 @@ func NotFound(w http.ResponseWriter, r *http.Request) {
 }
 
 func handlePageError(w http.ResponseWriter, r *http.Request, pageError error) {
+	errKind := getPageErrorKind(pageError)
+	requestId := getRequestId(r)
+	log := logrus.WithFields(logrus.Fields{
+		"request_id": requestId,
+		"code":       errKind.Code,
+		"route":      r.URL.String(),
+	}).WithError(pageError)
+	if errKind.Status >= 500 {
+		log.Error("page error")
+	} else {
+		log.Debug("page error")
+	}
+
 	templateFiles := append(layoutTemplateFiles, "_layout/500.html")
-	notFoundTemplate := templates.GetTemplate(templateFiles...)
+	errorTemplate := templates.GetTemplate(templateFiles...)
 	w.Header().Set("Content-Type", "text/html")
-	w.WriteHeader(http.StatusInternalServerError)
-	data := InitPageData(w, r, "blockchain", r.URL.Path, "Internal Error", templateFiles)
+	w.WriteHeader(errKind.Status)
+	data := InitPageData(w, r, "blockchain", r.URL.Path, errKind.Title, templateFiles)
 	errData := &models.ErrorPageData{
-		CallTime: time.Now(),
-		CallUrl:  r.URL.String(),
-		ErrorMsg: pageError.Error(),
-		Version:  utils.GetExplorerVersion(),
+		CallTime:   time.Now(),
+		CallUrl:    r.URL.String(),
+		ErrorMsg:   pageError.Error(),
+		Version:    utils.GetExplorerVersion(),
+		Code:       errKind.Code,
+		StatusCode: errKind.Status,
+		Title:      errKind.Title,
+		Message:    errKind.Message,
+		RequestId:  requestId,
+		Internal:   errKind == pageErrorInternal || errKind == pageErrorTimeout,
 	}
 	if fcError, isOk := pageError.(*services.FrontendCachePageError); isOk {
 		errData.StackTrace = fcError.Stack()
 	}
 	data.Data = errData
-	err := notFoundTemplate.ExecuteTemplate(w, "layout", data)
+	err := errorTemplate.ExecuteTemplate(w, "layout", data)
 	if err != nil {
 		logrus.Errorf("error executing page error template for %v route: %v", r.URL.String(), err)
 		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
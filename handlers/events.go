@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/services"
+)
+
+var eventStreamTopics = []string{indexer.EventTapBlock, indexer.EventTapHead, indexer.EventTapFinalized}
+
+// EventStream re-broadcasts the block, head & finalized_checkpoint events of the beacon nodes as server-sent events.
+// The stream follows the format of the beacon api (/eth/v1/events), topics are selected with the `topics` query parameter.
+func EventStream(w http.ResponseWriter, r *http.Request) {
+	topics := eventStreamTopics
+	if topicsArg := r.URL.Query().Get("topics"); topicsArg != "" {
+		topics = strings.Split(topicsArg, ",")
+		for _, topic := range topics {
+			valid := false
+			for _, knownTopic := range eventStreamTopics {
+				if topic == knownTopic {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				http.Error(w, fmt.Sprintf("unknown topic: %v", topic), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	// the stream outlives the read & write timeouts of the http server, which are lifted for this request.
+	// this needs the response writer of the http server, so the route is not served through negroni.
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(time.Time{}); err != nil {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	subscription := services.GlobalBeaconService.GetIndexer().SubscribeEvents(topics)
+	defer subscription.Unsubscribe()
+
+	write := func(format string, args ...interface{}) error {
+		rc.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	err := rc.Flush()
+	keepaliveTicker := time.NewTicker(30 * time.Second)
+	defer keepaliveTicker.Stop()
+
+	for err == nil {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepaliveTicker.C:
+			err = write(": keepalive\n\n")
+		case event := <-subscription.Events:
+			eventData, jsonErr := json.Marshal(event.Data)
+			if jsonErr != nil {
+				logrus.WithError(jsonErr).Errorf("error encoding %v event", event.Topic)
+				continue
+			}
+			err = write("event: %v\ndata: %s\n\n", event.Topic, eventData)
+		}
+	}
+	logrus.Debugf("event stream closed: %v", err)
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// FeeRecipients will return the "fee recipient compliance" page using a go template
+func FeeRecipients(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"fee_recipients/fee_recipients.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/fee_recipients", "Fee Recipient Compliance", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getFeeRecipientsPageData(parseFeeRecipientsEpochs(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "fee_recipients.go", "FeeRecipients", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// FeeRecipientsData will return the fee recipient compliance report as json
+func FeeRecipientsData(w http.ResponseWriter, r *http.Request) {
+	pageData, pageError := getFeeRecipientsPageData(parseFeeRecipientsEpochs(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding fee recipient compliance data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func parseFeeRecipientsEpochs(r *http.Request) uint64 {
+	urlArgs := r.URL.Query()
+	var epochCount uint64 = 32
+	if urlArgs.Has("epochs") {
+		epochCount, _ = strconv.ParseUint(urlArgs.Get("epochs"), 10, 64)
+	}
+	if epochCount == 0 {
+		epochCount = 32
+	} else if epochCount > 225 {
+		epochCount = 225
+	}
+	return epochCount
+}
+
+type feeRecipientRule struct {
+	name    string
+	prefix  int
+	address []byte
+}
+
+// getFeeRecipientRule returns the configured fee recipient rule for a validator name.
+// exact name matches take precedence over prefix matches, the longest prefix wins.
+func getFeeRecipientRule(name string) *feeRecipientRule {
+	if name == "" {
+		return nil
+	}
+	var bestRule *feeRecipientRule
+	for _, ruleCfg := range utils.Config.Frontend.FeeRecipients {
+		if !common.IsHexAddress(ruleCfg.Address) {
+			continue
+		}
+		if ruleCfg.Name == name {
+			return &feeRecipientRule{
+				name:    ruleCfg.Name,
+				prefix:  len(name),
+				address: common.HexToAddress(ruleCfg.Address).Bytes(),
+			}
+		}
+		prefix, isPrefix := strings.CutSuffix(ruleCfg.Name, "*")
+		if !isPrefix || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if bestRule == nil || len(prefix) > bestRule.prefix {
+			bestRule = &feeRecipientRule{
+				name:    ruleCfg.Name,
+				prefix:  len(prefix),
+				address: common.HexToAddress(ruleCfg.Address).Bytes(),
+			}
+		}
+	}
+	return bestRule
+}
+
+// getExpectedFeeRecipient returns the expected fee recipient for a validator name or nil if there is no rule for it
+func getExpectedFeeRecipient(name string) []byte {
+	rule := getFeeRecipientRule(name)
+	if rule == nil {
+		return nil
+	}
+	return rule.address
+}
+
+func getFeeRecipientsPageData(epochCount uint64) (*models.FeeRecipientsPageData, error) {
+	pageData := &models.FeeRecipientsPageData{}
+	pageCacheKey := fmt.Sprintf("fee_recipients:%v", epochCount)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildFeeRecipientsPageData(epochCount)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.FeeRecipientsPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildFeeRecipientsPageData(epochCount uint64) (*models.FeeRecipientsPageData, time.Duration) {
+	logrus.Debugf("fee recipients page called: %v", epochCount)
+	pageData := &models.FeeRecipientsPageData{
+		EpochCount: epochCount,
+	}
+
+	slotCount := epochCount * utils.Config.Chain.Config.SlotsPerEpoch
+	pageData.LastSlot = utils.TimeToSlot(uint64(time.Now().Unix()))
+	if pageData.LastSlot+1 > slotCount {
+		pageData.FirstSlot = pageData.LastSlot + 1 - slotCount
+	}
+
+	ruleMap := map[string]*models.FeeRecipientsPageRule{}
+	pageData.Rules = make([]*models.FeeRecipientsPageRule, 0, len(utils.Config.Frontend.FeeRecipients))
+	for _, ruleCfg := range utils.Config.Frontend.FeeRecipients {
+		if !common.IsHexAddress(ruleCfg.Address) {
+			logrus.Warnf("invalid fee recipient address for %v: %v", ruleCfg.Name, ruleCfg.Address)
+			continue
+		}
+		if ruleMap[ruleCfg.Name] != nil {
+			continue
+		}
+		rule := &models.FeeRecipientsPageRule{
+			Name:    ruleCfg.Name,
+			Address: common.HexToAddress(ruleCfg.Address).Bytes(),
+		}
+		ruleMap[ruleCfg.Name] = rule
+		pageData.Rules = append(pageData.Rules, rule)
+	}
+
+	pageData.Deviations = make([]*models.FeeRecipientsPageBlock, 0)
+	blocks := services.GlobalBeaconService.GetDbBlocksForSlots(pageData.LastSlot, uint32(slotCount), false)
+	for _, block := range blocks {
+		if block.EthFeeRecipient == nil {
+			continue
+		}
+		pageData.TotalBlocks++
+
+		proposerName := services.GlobalBeaconService.GetValidatorName(block.Proposer)
+		blockRule := getFeeRecipientRule(proposerName)
+		if blockRule == nil {
+			continue
+		}
+		rule := ruleMap[blockRule.name]
+		if rule == nil {
+			continue
+		}
+		pageData.CheckedBlocks++
+		rule.Blocks++
+
+		if bytes.Equal(rule.Address, block.EthFeeRecipient) {
+			rule.Compliant++
+			continue
+		}
+		rule.Deviating++
+		pageData.DeviatingBlocks++
+		pageData.Deviations = append(pageData.Deviations, &models.FeeRecipientsPageBlock{
+			Slot:         block.Slot,
+			BlockRoot:    block.Root,
+			Proposer:     block.Proposer,
+			ProposerName: proposerName,
+			Rule:         rule.Name,
+			Expected:     rule.Address,
+			FeeRecipient: block.EthFeeRecipient,
+		})
+	}
+
+	for _, rule := range pageData.Rules {
+		if rule.Blocks > 0 {
+			rule.Rate = float64(rule.Compliant) * 100.0 / float64(rule.Blocks)
+		}
+	}
+
+	return pageData, 1 * time.Minute
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+const finalityEpochCount = 100
+
+// Finality will return the "finality history" page using a go template
+func Finality(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"finality/finality.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/epochs/finality", "Finality", pageTemplateFiles)
+
+	urlArgs := r.URL.Query()
+	var epochCount uint64 = finalityEpochCount
+	if urlArgs.Has("epochs") {
+		epochCount, _ = strconv.ParseUint(urlArgs.Get("epochs"), 10, 64)
+	}
+	if epochCount == 0 {
+		epochCount = finalityEpochCount
+	} else if epochCount > 1000 {
+		epochCount = 1000
+	}
+
+	var pageError error
+	data.Data, pageError = getFinalityPageData(epochCount)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "finality.go", "Finality", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getFinalityPageData(epochCount uint64) (*models.FinalityPageData, error) {
+	pageData := &models.FinalityPageData{}
+	pageCacheKey := fmt.Sprintf("finality:%v", epochCount)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildFinalityPageData(epochCount)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.FinalityPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildFinalityPageData(epochCount uint64) (*models.FinalityPageData, time.Duration) {
+	logrus.Debugf("finality page called: %v", epochCount)
+	pageData := &models.FinalityPageData{
+		EpochCount: epochCount,
+	}
+
+	// the indexer keeps the last fully finalized / justified epoch, the checkpoints are the following epochs
+	finalizedEpoch, _, justifiedEpoch, _ := services.GlobalBeaconService.GetIndexer().GetFinalizationCheckpoints()
+	pageData.FinalizedEpoch = uint64(finalizedEpoch + 1)
+	pageData.JustifiedEpoch = uint64(justifiedEpoch + 1)
+
+	currentEpoch := utils.TimeToEpoch(time.Now())
+	if currentEpoch < 0 {
+		currentEpoch = 0
+	}
+	lastEpoch := uint64(currentEpoch)
+	firstEpoch := uint64(0)
+	if lastEpoch >= epochCount {
+		firstEpoch = lastEpoch - epochCount + 1
+	}
+
+	// a transition from checkpoint PrevEpoch to Epoch justifies / finalizes all checkpoints after PrevEpoch up to Epoch
+	epochs := make([]*models.FinalityPageEpoch, lastEpoch-firstEpoch+1)
+	for idx := range epochs {
+		epoch := firstEpoch + uint64(idx)
+		epochs[idx] = &models.FinalityPageEpoch{
+			Epoch:   epoch,
+			Ts:      utils.EpochToTime(epoch),
+			Pending: epoch > pageData.FinalizedEpoch,
+		}
+	}
+	for _, checkpoint := range db.GetFinalityCheckpoints(firstEpoch, lastEpoch) {
+		seenTime := time.Unix(int64(checkpoint.SeenTime), 0)
+		for epoch := checkpoint.PrevEpoch + 1; epoch <= checkpoint.Epoch; epoch++ {
+			if epoch < firstEpoch || epoch > lastEpoch {
+				continue
+			}
+			epochData := epochs[epoch-firstEpoch]
+			switch checkpoint.CheckpointType {
+			case dbtypes.FinalityCheckpointJustified:
+				if epoch == checkpoint.Epoch {
+					epochData.Justified = true
+					epochData.JustifiedTime = seenTime
+					epochData.TimeToJustification = seenTime.Sub(epochData.Ts).Round(time.Second)
+				} else {
+					epochData.JustificationGap = true
+				}
+			case dbtypes.FinalityCheckpointFinalized:
+				epochData.Finalized = true
+				epochData.FinalizedTime = seenTime
+				epochData.TimeToFinality = seenTime.Sub(epochData.Ts).Round(time.Second)
+				epochData.FinalizedRange = checkpoint.Epoch - checkpoint.PrevEpoch
+			}
+		}
+	}
+
+	var totalTimeToFinality time.Duration
+	timeToFinality := make([]float64, 0, len(epochs))
+	gapMarkers := map[int]*models.EpochsPageSparklineMarker{}
+	for _, epochData := range epochs {
+		if epochData.JustificationGap {
+			pageData.GapCount++
+			pageData.LastGapEpoch = epochData.Epoch
+		}
+		if !epochData.Finalized {
+			continue
+		}
+		if epochData.JustificationGap {
+			gapMarkers[len(timeToFinality)] = &models.EpochsPageSparklineMarker{
+				Epoch: epochData.Epoch,
+				Title: fmt.Sprintf("epoch %v was not justified", epochData.Epoch),
+			}
+		}
+		pageData.FinalizedCount++
+		totalTimeToFinality += epochData.TimeToFinality
+		if epochData.TimeToFinality > pageData.MaxTimeToFinality {
+			pageData.MaxTimeToFinality = epochData.TimeToFinality
+			pageData.MaxFinalityEpoch = epochData.Epoch
+		}
+		timeToFinality = append(timeToFinality, epochData.TimeToFinality.Minutes())
+	}
+	if pageData.FinalizedCount > 0 {
+		pageData.AvgTimeToFinality = (totalTimeToFinality / time.Duration(pageData.FinalizedCount)).Round(time.Second)
+	}
+	if len(timeToFinality) >= 2 {
+		pageData.TimeToFinalityChart = buildEpochsSparkline("Time to Finality", " min", timeToFinality, gapMarkers)
+	}
+
+	// show newest epochs first in the table
+	pageData.Epochs = make([]*models.FinalityPageEpoch, len(epochs))
+	for idx, epochData := range epochs {
+		pageData.Epochs[len(epochs)-idx-1] = epochData
+	}
+
+	return pageData, 1 * time.Minute
+}
@@ -29,6 +29,13 @@ func Index(w http.ResponseWriter, r *http.Request) {
 		"index/recentBlocks.html",
 		"index/recentEpochs.html",
 		"index/recentSlots.html",
+		"index/participation.html",
+		"index/blobUsage.html",
+		"index/clientDiversity.html",
+		"index/elClientDiversity.html",
+		"index/clientPairs.html",
+		"index/watchedValidators.html",
+		"index/widgets.html",
 		"_svg/timeline.html",
 	)
 
@@ -76,16 +83,19 @@ func getIndexPageData() (*models.IndexPageData, error) {
 		}
 		pageData = resData
 	}
-	return pageData, pageErr
+	if pageErr != nil {
+		return nil, pageErr
+	}
+
+	// the widgets are cached separately, so copy the cached network overview before adding them
+	indexData := *pageData
+	pageErr = addIndexPageWidgets(&indexData)
+	return &indexData, pageErr
 }
 
 func buildIndexPageData() (*models.IndexPageData, time.Duration) {
 	logrus.Debugf("index page called")
 
-	recentEpochCount := 7
-	recentBlockCount := 7
-	recentSlotsCount := 16
-
 	// network overview
 	now := time.Now()
 	currentEpoch := utils.TimeToEpoch(now)
@@ -191,15 +201,6 @@ func buildIndexPageData() (*models.IndexPageData, time.Duration) {
 		})
 	}
 
-	// load recent epochs
-	buildIndexPageRecentEpochsData(pageData, uint64(currentEpoch), finalizedEpoch, justifiedEpoch, recentEpochCount)
-
-	// load recent blocks
-	buildIndexPageRecentBlocksData(pageData, currentSlot, recentBlockCount)
-
-	// load recent slots
-	buildIndexPageRecentSlotsData(pageData, currentSlot, recentSlotsCount)
-
 	return pageData, 12 * time.Second
 }
 
@@ -236,17 +237,13 @@ func buildIndexPageRecentBlocksData(pageData *models.IndexPageData, currentSlot
 		if blockData == nil {
 			continue
 		}
-		blockStatus := 1
-		if blockData.Orphaned == 1 {
-			blockStatus = 2
-		}
 		blockModel := &models.IndexPageDataBlocks{
 			Epoch:        utils.EpochOfSlot(blockData.Slot),
 			Slot:         blockData.Slot,
 			Ts:           utils.SlotToTime(blockData.Slot),
 			Proposer:     blockData.Proposer,
 			ProposerName: services.GlobalBeaconService.GetValidatorName(blockData.Proposer),
-			Status:       uint64(blockStatus),
+			Status:       models.NewSlotStatus(blockData.Orphaned),
 			BlockRoot:    blockData.Root,
 		}
 		if blockData.EthBlockNumber != nil {
@@ -288,16 +285,11 @@ func buildIndexPageRecentSlotsData(pageData *models.IndexPageData, firstSlot uin
 		for dbIdx < dbCnt && dbSlots[dbIdx] != nil && dbSlots[dbIdx].Slot == slot {
 			dbSlot := dbSlots[dbIdx]
 			dbIdx++
-			blockStatus := uint64(1)
-			if dbSlot.Orphaned == 1 {
-				blockStatus = 2
-			}
-
 			slotData := &models.IndexPageDataSlots{
 				Slot:         slot,
 				Epoch:        utils.EpochOfSlot(slot),
 				Ts:           utils.SlotToTime(slot),
-				Status:       blockStatus,
+				Status:       models.NewSlotStatus(dbSlot.Orphaned),
 				Proposer:     dbSlot.Proposer,
 				ProposerName: services.GlobalBeaconService.GetValidatorName(dbSlot.Proposer),
 				BlockRoot:    dbSlot.Root,
@@ -316,7 +308,7 @@ func buildIndexPageRecentSlotsData(pageData *models.IndexPageData, firstSlot uin
 				Slot:         slot,
 				Epoch:        epoch,
 				Ts:           utils.SlotToTime(slot),
-				Status:       0,
+				Status:       models.SlotStatusMissed,
 				Proposer:     slotAssignments[slot],
 				ProposerName: services.GlobalBeaconService.GetValidatorName(slotAssignments[slot]),
 				ForkGraph:    make([]*models.IndexPageDataForkGraph, 0),
@@ -389,7 +381,7 @@ func buildIndexPageSlotGraph(pageData *models.IndexPageData, slotData *models.In
 			}
 		}
 	}
-	if forkGraphIdx == -1 && slotData.Status > 0 {
+	if forkGraphIdx == -1 && !slotData.Status.IsMissed() {
 		// fork head
 		if freeForkIdx == -1 {
 			freeForkIdx = *maxOpenFork
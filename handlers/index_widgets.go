@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/types"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// max number of blobs per block (MAX_BLOB_GAS_PER_BLOCK / GAS_PER_BLOB)
+const indexMaxBlobsPerBlock = 6
+
+type indexWidget struct {
+	defaultCount   uint64
+	defaultTimeout time.Duration
+	enabled        func() bool
+	// build loads the widget data into an empty page model, which is cached with the widget timeout
+	build func(widgetData *models.IndexPageData, count uint64)
+	// apply copies the widget data into the page model
+	apply func(pageData *models.IndexPageData, widgetData *models.IndexPageData)
+}
+
+var indexWidgets = map[string]*indexWidget{
+	"recent_epochs": {
+		defaultCount:   7,
+		defaultTimeout: 12 * time.Second,
+		build:          buildIndexWidgetRecentEpochs,
+		apply: func(pageData *models.IndexPageData, widgetData *models.IndexPageData) {
+			pageData.RecentEpochs = widgetData.RecentEpochs
+			pageData.RecentEpochCount = widgetData.RecentEpochCount
+		},
+	},
+	"recent_blocks": {
+		defaultCount:   7,
+		defaultTimeout: 12 * time.Second,
+		build: func(widgetData *models.IndexPageData, count uint64) {
+			buildIndexPageRecentBlocksData(widgetData, utils.TimeToSlot(uint64(time.Now().Unix())), int(count))
+		},
+		apply: func(pageData *models.IndexPageData, widgetData *models.IndexPageData) {
+			pageData.RecentBlocks = widgetData.RecentBlocks
+			pageData.RecentBlockCount = widgetData.RecentBlockCount
+		},
+	},
+	"recent_slots": {
+		defaultCount:   16,
+		defaultTimeout: 12 * time.Second,
+		build: func(widgetData *models.IndexPageData, count uint64) {
+			buildIndexPageRecentSlotsData(widgetData, utils.TimeToSlot(uint64(time.Now().Unix())), int(count))
+		},
+		apply: func(pageData *models.IndexPageData, widgetData *models.IndexPageData) {
+			pageData.RecentSlots = widgetData.RecentSlots
+			pageData.RecentSlotCount = widgetData.RecentSlotCount
+			pageData.ForkTreeWidth = widgetData.ForkTreeWidth
+		},
+	},
+	"participation": {
+		defaultCount:   1,
+		defaultTimeout: 1 * time.Minute,
+		build:          buildIndexWidgetParticipation,
+		apply: func(pageData *models.IndexPageData, widgetData *models.IndexPageData) {
+			pageData.Participation = widgetData.Participation
+		},
+	},
+	"blob_usage": {
+		defaultCount:   32,
+		defaultTimeout: 1 * time.Minute,
+		build:          buildIndexWidgetBlobUsage,
+		apply: func(pageData *models.IndexPageData, widgetData *models.IndexPageData) {
+			pageData.BlobUsage = widgetData.BlobUsage
+		},
+	},
+	"client_diversity": {
+		defaultCount:   320,
+		defaultTimeout: 5 * time.Minute,
+		build:          buildIndexWidgetClientDiversity,
+		apply: func(pageData *models.IndexPageData, widgetData *models.IndexPageData) {
+			pageData.ClientDiversity = widgetData.ClientDiversity
+		},
+	},
+	"el_client_diversity": {
+		defaultCount:   320,
+		defaultTimeout: 5 * time.Minute,
+		build:          buildIndexWidgetElClientDiversity,
+		apply: func(pageData *models.IndexPageData, widgetData *models.IndexPageData) {
+			pageData.ElClientDiversity = widgetData.ElClientDiversity
+		},
+	},
+	"client_pairs": {
+		defaultCount:   320,
+		defaultTimeout: 5 * time.Minute,
+		build:          buildIndexWidgetClientPairs,
+		apply: func(pageData *models.IndexPageData, widgetData *models.IndexPageData) {
+			pageData.ClientPairs = widgetData.ClientPairs
+		},
+	},
+	"watched_validators": {
+		defaultTimeout: 1 * time.Minute,
+		enabled: func() bool {
+			return len(utils.Config.Frontend.ValidatorClients) > 0
+		},
+		build: buildIndexWidgetWatchedValidators,
+		apply: func(pageData *models.IndexPageData, widgetData *models.IndexPageData) {
+			pageData.WatchedValidators = widgetData.WatchedValidators
+		},
+	},
+}
+
+var defaultIndexWidgets = []types.IndexWidgetConfig{
+	{Name: "recent_epochs", Column: "left"},
+	{Name: "recent_blocks", Column: "left"},
+	{Name: "recent_slots", Column: "right"},
+}
+
+// addIndexPageWidgets loads the data of all configured front page widgets and sorts them into the layout columns
+func addIndexPageWidgets(pageData *models.IndexPageData) error {
+	widgetConfigs := utils.Config.Frontend.IndexWidgets
+	if len(widgetConfigs) == 0 {
+		widgetConfigs = defaultIndexWidgets
+	}
+
+	pageData.LeftWidgets = []*models.IndexPageDataWidget{}
+	pageData.RightWidgets = []*models.IndexPageDataWidget{}
+	pageData.FullWidgets = []*models.IndexPageDataWidget{}
+	for idx := range widgetConfigs {
+		widgetConfig := &widgetConfigs[idx]
+		widget := indexWidgets[widgetConfig.Name]
+		if widget == nil {
+			logrus.Warnf("unknown index widget: %v", widgetConfig.Name)
+			continue
+		}
+		if widget.enabled != nil && !widget.enabled() {
+			continue
+		}
+
+		widgetData, err := getIndexWidgetData(widgetConfig, widget)
+		if err != nil {
+			return err
+		}
+		widget.apply(pageData, widgetData)
+
+		widgetModel := &models.IndexPageDataWidget{
+			Name: widgetConfig.Name,
+			Page: pageData,
+		}
+		switch widgetConfig.Column {
+		case "right":
+			pageData.RightWidgets = append(pageData.RightWidgets, widgetModel)
+		case "full":
+			pageData.FullWidgets = append(pageData.FullWidgets, widgetModel)
+		default:
+			pageData.LeftWidgets = append(pageData.LeftWidgets, widgetModel)
+		}
+	}
+	return nil
+}
+
+func getIndexWidgetData(widgetConfig *types.IndexWidgetConfig, widget *indexWidget) (*models.IndexPageData, error) {
+	count := widgetConfig.Count
+	if count == 0 {
+		count = widget.defaultCount
+	}
+	cacheTimeout := widgetConfig.CacheTimeout
+	if cacheTimeout == 0 {
+		cacheTimeout = widget.defaultTimeout
+	}
+
+	widgetData := &models.IndexPageData{}
+	pageCacheKey := fmt.Sprintf("index_widget:%v:%v", widgetConfig.Name, count)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, widgetData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		widgetData := &models.IndexPageData{}
+		widget.build(widgetData, count)
+		pageCall.CacheTimeout = cacheTimeout
+		return widgetData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.IndexPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		widgetData = resData
+	}
+	return widgetData, pageErr
+}
+
+func buildIndexWidgetRecentEpochs(widgetData *models.IndexPageData, count uint64) {
+	currentEpoch := utils.TimeToEpoch(time.Now())
+	if currentEpoch < 0 {
+		currentEpoch = 0
+	}
+	finalizedEpoch, _, justifiedEpoch, _ := services.GlobalBeaconService.GetIndexer().GetFinalizationCheckpoints()
+	buildIndexPageRecentEpochsData(widgetData, uint64(currentEpoch), finalizedEpoch, justifiedEpoch, int(count))
+}
+
+// buildIndexWidgetParticipation shows the participation of the most recent epoch with complete votes
+func buildIndexWidgetParticipation(widgetData *models.IndexPageData, count uint64) {
+	currentEpoch := utils.TimeToEpoch(time.Now())
+	if currentEpoch < 1 {
+		return
+	}
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	for _, epochData := range services.GlobalBeaconService.GetDbEpochs(uint64(currentEpoch-1), 4) {
+		if epochData == nil || epochData.Eligible == 0 {
+			continue
+		}
+		widgetData.Participation = &models.IndexPageDataParticipation{
+			Epoch:         epochData.Epoch,
+			Finalized:     finalizedEpoch >= int64(epochData.Epoch),
+			EligibleEther: epochData.Eligible,
+			TargetPercent: float64(epochData.VotedTarget) * 100.0 / float64(epochData.Eligible),
+			HeadPercent:   float64(epochData.VotedHead) * 100.0 / float64(epochData.Eligible),
+			TotalPercent:  float64(epochData.VotedTotal) * 100.0 / float64(epochData.Eligible),
+		}
+		break
+	}
+}
+
+func buildIndexWidgetBlobUsage(widgetData *models.IndexPageData, count uint64) {
+	currentEpoch := utils.TimeToEpoch(time.Now())
+	if currentEpoch < 0 || uint64(currentEpoch) < utils.Config.Chain.Config.DenebForkEpoch {
+		return
+	}
+	blobUsage := &models.IndexPageDataBlobUsage{}
+	for _, epochData := range services.GlobalBeaconService.GetDbEpochs(uint64(currentEpoch), uint32(count)) {
+		if epochData == nil || epochData.Epoch < utils.Config.Chain.Config.DenebForkEpoch {
+			continue
+		}
+		if blobUsage.BlockCount == 0 || epochData.Epoch < blobUsage.FirstEpoch {
+			blobUsage.FirstEpoch = epochData.Epoch
+		}
+		if epochData.Epoch > blobUsage.LastEpoch {
+			blobUsage.LastEpoch = epochData.Epoch
+		}
+		blobUsage.BlockCount += uint64(epochData.BlockCount)
+		blobUsage.BlobCount += epochData.BlobCount
+	}
+	if blobUsage.BlockCount > 0 {
+		blobUsage.BlobsPerBlock = float64(blobUsage.BlobCount) / float64(blobUsage.BlockCount)
+		blobUsage.Usage = blobUsage.BlobsPerBlock * 100.0 / indexMaxBlobsPerBlock
+	}
+	widgetData.BlobUsage = blobUsage
+}
+
+// buildIndexWidgetClientDiversity estimates the consensus client distribution of the latest blocks by their graffiti
+func buildIndexWidgetClientDiversity(widgetData *models.IndexPageData, count uint64) {
+	widgetData.ClientDiversity = buildIndexClientShares(count, func(block *dbtypes.Block) string {
+		clClient, _ := getIndexBlockClients(block)
+		return clClient
+	})
+}
+
+// buildIndexWidgetElClientDiversity estimates the execution client distribution of the latest blocks by their payload extra data
+func buildIndexWidgetElClientDiversity(widgetData *models.IndexPageData, count uint64) {
+	widgetData.ElClientDiversity = buildIndexClientShares(count, func(block *dbtypes.Block) string {
+		_, elClient := getIndexBlockClients(block)
+		return elClient
+	})
+}
+
+// buildIndexWidgetClientPairs counts the consensus & execution client combinations of the latest blocks
+func buildIndexWidgetClientPairs(widgetData *models.IndexPageData, count uint64) {
+	shares := buildIndexClientShares(count, func(block *dbtypes.Block) string {
+		clClient, elClient := getIndexBlockClients(block)
+		return clClient + "/" + elClient
+	})
+	widgetData.ClientPairs = make([]*models.IndexPageDataClientPair, len(shares))
+	for idx, share := range shares {
+		clients := strings.SplitN(share.Client, "/", 2)
+		widgetData.ClientPairs[idx] = &models.IndexPageDataClientPair{
+			ClClient: clients[0],
+			ElClient: clients[1],
+			Blocks:   share.Blocks,
+			Percent:  share.Percent,
+		}
+	}
+}
+
+// getIndexBlockClients returns the consensus & execution client of a block.
+// blocks indexed before the clients were stored are matched by their graffiti & extra data again.
+func getIndexBlockClients(block *dbtypes.Block) (string, string) {
+	clClient := block.ClClient
+	if clClient == "" {
+		clClient = utils.GetGraffitiClient(block.GraffitiText)
+	}
+	elClient := block.ElClient
+	if elClient == "" {
+		elClient = utils.GetExtraDataClient(block.EthExtraData)
+	}
+	return clClient, elClient
+}
+
+// buildIndexClientShares groups the latest blocks by the client returned for each block, biggest share first
+func buildIndexClientShares(count uint64, getClient func(block *dbtypes.Block) string) []*models.IndexPageDataClientShare {
+	currentSlot := utils.TimeToSlot(uint64(time.Now().Unix()))
+	blockCounts := map[string]uint64{}
+	blockCount := uint64(0)
+	for _, block := range services.GlobalBeaconService.GetDbBlocks(currentSlot, int32(count), false) {
+		if block == nil {
+			continue
+		}
+		blockCounts[getClient(block)]++
+		blockCount++
+	}
+
+	shares := []*models.IndexPageDataClientShare{}
+	for client, clientBlocks := range blockCounts {
+		shares = append(shares, &models.IndexPageDataClientShare{
+			Client:  client,
+			Blocks:  clientBlocks,
+			Percent: float64(clientBlocks) * 100.0 / float64(blockCount),
+		})
+	}
+	sort.Slice(shares, func(a, b int) bool {
+		if shares[a].Blocks != shares[b].Blocks {
+			return shares[a].Blocks > shares[b].Blocks
+		}
+		return shares[a].Client < shares[b].Client
+	})
+	return shares
+}
+
+// buildIndexWidgetWatchedValidators summarizes the "my validators" dashboard
+func buildIndexWidgetWatchedValidators(widgetData *models.IndexPageData, count uint64) {
+	operatorData, err := getValidatorsOperatorPageData()
+	if err != nil {
+		logrus.WithError(err).Warnf("error loading validator clients for index widget")
+		return
+	}
+	widgetData.WatchedValidators = []*models.IndexPageDataValidatorClient{}
+	for _, client := range operatorData.Clients {
+		widgetData.WatchedValidators = append(widgetData.WatchedValidators, &models.IndexPageDataValidatorClient{
+			Name:          client.Name,
+			Error:         client.Error,
+			ActiveCount:   client.ActiveCount,
+			OnlineCount:   client.OnlineCount,
+			OfflineCount:  client.OfflineCount,
+			Participation: client.Participation,
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+type nameClaimRequest struct {
+	Validator uint64 `json:"validator"`
+	Name      string `json:"name"`
+	Signature string `json:"signature"`
+}
+
+type nameClaimResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// NameClaims will return the validator name claim page using a go template.
+// Operators get the message to sign for the validator & name and submit the signature with the same form.
+func NameClaims(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"name_claims/name_claims.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/name_claims", "Claim Validator Name", pageTemplateFiles)
+
+	pageData := &models.NameClaimsPageData{
+		Enabled: utils.Config.Frontend.NameClaimsEnabled,
+		Name:    strings.TrimSpace(r.FormValue("name")),
+	}
+	if validatorIndex, err := strconv.ParseUint(r.FormValue("validator"), 10, 64); err == nil {
+		pageData.ValidatorIndex = validatorIndex
+		pageData.HasValidator = true
+		pageData.CurrentName = services.GlobalBeaconService.GetValidatorName(validatorIndex)
+	}
+	if pageData.HasValidator && pageData.Name != "" {
+		if genesis, _ := services.GlobalBeaconService.GetGenesis(); genesis != nil {
+			pageData.Message = services.GetNameClaimMessage(pageData.ValidatorIndex, pageData.Name, genesis.GenesisValidatorsRoot[:])
+		} else {
+			pageData.Error = "genesis not loaded yet, please try again later"
+		}
+	}
+
+	if r.Method == http.MethodPost && pageData.Message != "" {
+		pageData.Signature = strings.TrimSpace(r.FormValue("signature"))
+		err := submitNameClaim(pageData.ValidatorIndex, pageData.Name, pageData.Signature)
+		if err != nil {
+			pageData.Error = err.Error()
+		} else {
+			pageData.Submitted = true
+		}
+	}
+	data.Data = pageData
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "name_claims.go", "NameClaims", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// NameClaimsSubmit queues a signed name claim, expects a json encoded {"validator", "name", "signature"} body
+func NameClaimsSubmit(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	request := &nameClaimRequest{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(request)
+	if err == nil {
+		err = submitNameClaim(request.Validator, strings.TrimSpace(request.Name), request.Signature)
+	}
+
+	response := &nameClaimResponse{
+		Status: "pending",
+	}
+	if err != nil {
+		response.Status = "error"
+		response.Error = err.Error()
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding name claim response")
+	}
+}
+
+// submitNameClaim returns the errors that can be shown to the user, internal errors are logged and replaced
+func submitNameClaim(validatorIndex uint64, name string, signatureHex string) error {
+	signature, err := hex.DecodeString(strings.TrimPrefix(signatureHex, "0x"))
+	if err != nil || len(signature) != 96 {
+		return errors.New("signature must be 96 hex encoded bytes")
+	}
+	err = services.GlobalBeaconService.SubmitValidatorNameClaim(validatorIndex, name, signature)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, services.ErrNameClaimsDisabled),
+		errors.Is(err, services.ErrNameClaimInvalidName),
+		errors.Is(err, services.ErrNameClaimUnknown),
+		errors.Is(err, services.ErrNameClaimBadSignature),
+		errors.Is(err, services.ErrNameClaimAlreadyActive):
+		return err
+	default:
+		logrus.WithError(err).Errorf("error submitting name claim for validator %v", validatorIndex)
+		return errors.New("internal error, please try again later")
+	}
+}
+
+// NameClaimsAdmin will return the name claim moderation queue using a go template.
+// Claims are approved or rejected by posting the form back to this page.
+func NameClaimsAdmin(w http.ResponseWriter, r *http.Request) {
+	if !checkPageToken(w, r, "name_claims_token", utils.Config.Frontend.NameClaimsAdminTokens) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		validatorIndex, err := strconv.ParseUint(r.FormValue("validator"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid validator index", http.StatusBadRequest)
+			return
+		}
+		err = services.GlobalBeaconService.ReviewValidatorNameClaim(validatorIndex, r.FormValue("name"), r.FormValue("action") == "approve")
+		if err != nil {
+			logrus.WithError(err).Errorf("error reviewing name claim for validator %v", validatorIndex)
+			http.Error(w, fmt.Sprintf("Error reviewing name claim: %v", err), http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, "/validators/name_claims/admin", http.StatusSeeOther)
+		return
+	}
+
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"name_claims/admin.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/name_claims/admin", "Name Claim Moderation", pageTemplateFiles)
+
+	pageData := &models.NameClaimsAdminPageData{
+		Claims: []*models.NameClaimsAdminPageClaim{},
+	}
+	for _, claim := range db.GetValidatorNameClaims(dbtypes.NameClaimStatusPending, 100) {
+		pageData.Claims = append(pageData.Claims, &models.NameClaimsAdminPageClaim{
+			ValidatorIndex: claim.ValidatorIndex,
+			CurrentName:    services.GlobalBeaconService.GetValidatorName(claim.ValidatorIndex),
+			Name:           claim.Name,
+			Signature:      claim.Signature,
+			Created:        time.Unix(int64(claim.Created), 0),
+		})
+	}
+	data.Data = pageData
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "name_claims.go", "NameClaimsAdmin", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
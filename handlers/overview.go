@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// OverviewResponse is a compact summary of the network state for status bots
+type OverviewResponse struct {
+	Data *OverviewResponseData `json:"data"`
+}
+
+type OverviewResponseData struct {
+	Network        string `json:"network"`
+	CurrentSlot    uint64 `json:"current_slot,string"`
+	CurrentEpoch   uint64 `json:"current_epoch,string"`
+	HeadSlot       uint64 `json:"head_slot,string"`
+	HeadRoot       string `json:"head_root"`
+	FinalizedEpoch int64  `json:"finalized_epoch,string"`
+	JustifiedEpoch int64  `json:"justified_epoch,string"`
+	// epochs since the last finalized epoch, the network isn't finalizing if this grows beyond 2
+	FinalityDelay uint64 `json:"finality_delay,string"`
+
+	Participation *OverviewResponseParticipation `json:"participation"`
+	Validators    *OverviewResponseValidators    `json:"validators"`
+	Slots         *OverviewResponseSlots         `json:"slots"`
+	Blobs         *OverviewResponseBlobs         `json:"blobs,omitempty"`
+}
+
+type OverviewResponseParticipation struct {
+	Epoch         uint64  `json:"epoch,string"`
+	TargetPercent float64 `json:"target_percent"`
+	HeadPercent   float64 `json:"head_percent"`
+	TotalPercent  float64 `json:"total_percent"`
+}
+
+type OverviewResponseValidators struct {
+	Active   uint64 `json:"active,string"`
+	Pending  uint64 `json:"pending,string"`
+	Exiting  uint64 `json:"exiting,string"`
+	Slashed  uint64 `json:"slashed,string"`
+	Total    uint64 `json:"total,string"`
+	Eligible uint64 `json:"eligible_gwei,string"`
+}
+
+// OverviewResponseSlots are the proposal stats of the slots in the configured window (excluding the current slot)
+type OverviewResponseSlots struct {
+	Window   string `json:"window"`
+	Slots    uint64 `json:"slots,string"`
+	Proposed uint64 `json:"proposed,string"`
+	Missed   uint64 `json:"missed,string"`
+	Orphaned uint64 `json:"orphaned,string"`
+}
+
+// OverviewResponseBlobs is the blob usage of the epochs covering the configured window
+type OverviewResponseBlobs struct {
+	FirstEpoch    uint64  `json:"first_epoch,string"`
+	LastEpoch     uint64  `json:"last_epoch,string"`
+	BlobCount     uint64  `json:"blob_count,string"`
+	BlobsPerBlock float64 `json:"blobs_per_block"`
+	UsagePercent  float64 `json:"usage_percent"`
+}
+
+// Overview returns a compact summary of the network (/api/v1/overview), made to be polled by chat status bots.
+// The response is cached for a slot, so frequent polling doesn't add load.
+func Overview(w http.ResponseWriter, r *http.Request) {
+	pageData := &OverviewResponse{}
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage("api_overview", true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageCall.CacheTimeout = time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
+		return buildOverviewResponse()
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*OverviewResponse)
+		if !resOk {
+			pageErr = InvalidPageModelError
+		}
+		pageData = resData
+	}
+	if pageErr != nil {
+		logrus.WithError(pageErr).Error("error building network overview")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%v", utils.Config.Chain.Config.SecondsPerSlot))
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding network overview")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func buildOverviewResponse() *OverviewResponse {
+	now := time.Now()
+	currentEpoch := utils.TimeToEpoch(now)
+	if currentEpoch < 0 {
+		currentEpoch = 0
+	}
+	currentSlot := utils.TimeToSlot(uint64(now.Unix()))
+	beaconIndexer := services.GlobalBeaconService.GetIndexer()
+	finalizedEpoch, _, justifiedEpoch, _ := beaconIndexer.GetFinalizationCheckpoints()
+	headSlot, headRoot := beaconIndexer.GetCanonicalHead()
+
+	overview := &OverviewResponseData{
+		Network:        utils.Config.Chain.Name,
+		CurrentSlot:    currentSlot,
+		CurrentEpoch:   uint64(currentEpoch),
+		HeadSlot:       headSlot,
+		HeadRoot:       fmt.Sprintf("0x%x", headRoot),
+		FinalizedEpoch: finalizedEpoch,
+		JustifiedEpoch: justifiedEpoch,
+	}
+	if utils.Config.Chain.DisplayName != "" {
+		overview.Network = utils.Config.Chain.DisplayName
+	}
+	if finalizedEpoch < currentEpoch {
+		overview.FinalityDelay = uint64(currentEpoch - finalizedEpoch)
+	}
+
+	// participation & blob usage are built like the homepage widgets
+	widgetData := &models.IndexPageData{}
+	buildIndexWidgetParticipation(widgetData, 1)
+	if participation := widgetData.Participation; participation != nil {
+		overview.Participation = &OverviewResponseParticipation{
+			Epoch:         participation.Epoch,
+			TargetPercent: participation.TargetPercent,
+			HeadPercent:   participation.HeadPercent,
+			TotalPercent:  participation.TotalPercent,
+		}
+	}
+
+	window := utils.Config.Frontend.OverviewApiWindow
+	if window <= 0 {
+		window = 1 * time.Hour
+	}
+	windowSlots := uint64(window.Seconds()) / utils.Config.Chain.Config.SecondsPerSlot
+	if windowSlots == 0 {
+		windowSlots = 1
+	}
+	windowEpochs := (windowSlots + utils.Config.Chain.Config.SlotsPerEpoch - 1) / utils.Config.Chain.Config.SlotsPerEpoch
+	buildIndexWidgetBlobUsage(widgetData, windowEpochs)
+	if blobUsage := widgetData.BlobUsage; blobUsage != nil {
+		overview.Blobs = &OverviewResponseBlobs{
+			FirstEpoch:    blobUsage.FirstEpoch,
+			LastEpoch:     blobUsage.LastEpoch,
+			BlobCount:     blobUsage.BlobCount,
+			BlobsPerBlock: blobUsage.BlobsPerBlock,
+			UsagePercent:  blobUsage.Usage,
+		}
+	}
+
+	overview.Validators = &OverviewResponseValidators{}
+	for _, validator := range services.GlobalBeaconService.GetCachedValidatorSet() {
+		overview.Validators.Total++
+		switch {
+		case strings.HasPrefix(validator.Status.String(), "pending"):
+			overview.Validators.Pending++
+		case strings.HasPrefix(validator.Status.String(), "active"):
+			overview.Validators.Active++
+			overview.Validators.Eligible += uint64(validator.Validator.EffectiveBalance)
+			if validator.Status == v1.ValidatorStateActiveExiting {
+				overview.Validators.Exiting++
+			}
+		}
+		if validator.Validator.Slashed {
+			overview.Validators.Slashed++
+		}
+	}
+
+	// the current slot may not be proposed yet, so the window ends with the previous slot
+	overview.Slots = &OverviewResponseSlots{
+		Window: window.String(),
+	}
+	if currentSlot > 0 {
+		lastSlot := currentSlot - 1
+		if windowSlots > currentSlot {
+			windowSlots = currentSlot
+		}
+		overview.Slots.Slots = windowSlots
+		proposedSlots := map[uint64]bool{}
+		for _, block := range services.GlobalBeaconService.GetDbBlocksForSlots(lastSlot, uint32(windowSlots), true) {
+			if block.Slot > lastSlot || block.Slot+windowSlots <= lastSlot {
+				continue
+			}
+			if block.Orphaned == 1 {
+				overview.Slots.Orphaned++
+			} else {
+				proposedSlots[block.Slot] = true
+			}
+		}
+		overview.Slots.Proposed = uint64(len(proposedSlots))
+		overview.Slots.Missed = windowSlots - overview.Slots.Proposed
+	}
+
+	return &OverviewResponse{
+		Data: overview,
+	}
+}
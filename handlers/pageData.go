@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"syscall"
 	"time"
@@ -18,6 +19,8 @@ var layoutTemplateFiles = []string{
 	"_layout/layout.html",
 	"_layout/header.html",
 	"_layout/footer.html",
+	"_layout/badges.html",
+	"_layout/partial.html",
 }
 
 func InitPageData(w http.ResponseWriter, r *http.Request, active, path, title string, mainTemplates []string) *types.PageData {
@@ -35,6 +38,7 @@ func InitPageData(w http.ResponseWriter, r *http.Request, active, path, title st
 			Description: "beaconchain makes Ethereum accessible to non-technical end users",
 			Domain:      r.Host,
 			Path:        path,
+			ShareUrl:    buildShareUrl(r),
 			Templates:   strings.Join(mainTemplates, ","),
 		},
 		Active:                active,
@@ -72,39 +76,149 @@ func InitPageData(w http.ResponseWriter, r *http.Request, active, path, title st
 	return data
 }
 
+// buildShareUrl returns the absolute link to the current view. all page filters, pagination & chart ranges
+// are parsed from the query, so the link reproduces the view; empty filter fields are dropped to keep it short.
+func buildShareUrl(r *http.Request) string {
+	shareArgs := url.Values{}
+	for key, values := range r.URL.Query() {
+		for _, value := range values {
+			if value != "" || key == "f" {
+				shareArgs.Add(key, value)
+			}
+		}
+	}
+
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	shareUrl := url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     r.URL.Path,
+		RawQuery: shareArgs.Encode(),
+	}
+	return shareUrl.String()
+}
+
 func createMenuItems(active string, isMain bool) []types.MainMenuItem {
 	hiddenFor := []string{"confirmation", "login", "register"}
 
 	if utils.SliceContains(hiddenFor, active) {
 		return []types.MainMenuItem{}
 	}
+
+	validatorLinks := []types.NavigationLink{
+		{
+			Label: "Validators",
+			Path:  "/validators",
+			Icon:  "fa-table",
+		},
+		{
+			Label: "Withdrawal Credentials",
+			Path:  "/validators/credentials",
+			Icon:  "fa-key",
+		},
+		{
+			Label: "Validator Uptime",
+			Path:  "/validators/uptime",
+			Icon:  "fa-heartbeat",
+		},
+		{
+			Label: "Validator Lifecycle",
+			Path:  "/validators/lifecycle",
+			Icon:  "fa-stream",
+		},
+		{
+			Label: "Set Projection",
+			Path:  "/validators/projection",
+			Icon:  "fa-chart-area",
+		},
+		{
+			Label: "Fee Recipients",
+			Path:  "/validators/fee_recipients",
+			Icon:  "fa-hand-holding-usd",
+		},
+		{
+			Label: "Consolidations",
+			Path:  "/validators/consolidations",
+			Icon:  "fa-compress-arrows-alt",
+		},
+	}
+	if len(utils.Config.Frontend.ValidatorClients) > 0 {
+		validatorLinks = append(validatorLinks, types.NavigationLink{
+			Label: "My Validators",
+			Path:  "/validators/operator",
+			Icon:  "fa-user-shield",
+		})
+	}
+	if utils.Config.Frontend.NameClaimsEnabled {
+		validatorLinks = append(validatorLinks, types.NavigationLink{
+			Label: "Claim Name",
+			Path:  "/validators/name_claims",
+			Icon:  "fa-signature",
+		})
+	}
+	chainLinks := []types.NavigationLink{
+		{
+			Label: "Epochs",
+			Path:  "/epochs",
+			Icon:  "fa-history",
+		},
+		{
+			Label: "Slots",
+			Path:  "/slots",
+			Icon:  "fa-cube",
+		},
+		{
+			Label: "Daily Stats",
+			Path:  "/epochs/daily",
+			Icon:  "fa-calendar-alt",
+		},
+		{
+			Label: "Finality",
+			Path:  "/epochs/finality",
+			Icon:  "fa-flag-checkered",
+		},
+		{
+			Label: "Blob Gas",
+			Path:  "/blobs/gas",
+			Icon:  "fa-chart-line",
+		},
+		{
+			Label: "State Roots",
+			Path:  "/roots",
+			Icon:  "fa-sitemap",
+		},
+	}
+	if len(utils.Config.Chain.CustomForks) > 0 {
+		validatorLinks = append(validatorLinks, types.NavigationLink{
+			Label: "Deposit Flow",
+			Path:  "/validators/deposit_receipts",
+			Icon:  "fa-file-import",
+		})
+		validatorLinks = append(validatorLinks, types.NavigationLink{
+			Label: "Consolidation Requests",
+			Path:  "/validators/consolidation_requests",
+			Icon:  "fa-compress-arrows-alt",
+		})
+		chainLinks = append(chainLinks, types.NavigationLink{
+			Label: "Execution Witnesses",
+			Path:  "/slots/witnesses",
+			Icon:  "fa-tree",
+		})
+	}
+
 	return []types.MainMenuItem{
 		{
 			Label:    "Blockchain",
 			IsActive: active == "blockchain",
 			Groups: []types.NavigationGroup{
 				{
-					Links: []types.NavigationLink{
-						{
-							Label: "Epochs",
-							Path:  "/epochs",
-							Icon:  "fa-history",
-						},
-						{
-							Label: "Slots",
-							Path:  "/slots",
-							Icon:  "fa-cube",
-						},
-					},
+					Links: chainLinks,
 				},
 				{
-					Links: []types.NavigationLink{
-						{
-							Label: "Validators",
-							Path:  "/validators",
-							Icon:  "fa-table",
-						},
-					},
+					Links: validatorLinks,
 				},
 				{
 					Links: []types.NavigationLink{
@@ -135,6 +249,7 @@ func handleTemplateError(w http.ResponseWriter, r *http.Request, fileIdentifier
 			"info":       infoIdentifier,
 			"error type": fmt.Sprintf("%T", err),
 			"route":      r.URL.String(),
+			"request_id": getRequestId(r),
 		}).WithError(err).Error("error executing template")
 		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
 	}
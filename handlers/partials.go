@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/utils"
+)
+
+// getPagePartial returns the page fragment requested by the "partial" url argument.
+// Heavy pages render their shell with placeholders first, the placeholders load the expensive fragments
+// asynchronously from the same url with the partial argument set (see loadPartials in explorer.js).
+// Only the given fragment names are accepted, so the request can't execute arbitrary templates of the page.
+func getPagePartial(r *http.Request, partials ...string) string {
+	partial := r.URL.Query().Get("partial")
+	if partial == "" || !utils.SliceContains(partials, partial) {
+		return ""
+	}
+	return partial
+}
+
+// buildPagePartialLink returns the url of a fragment of the requested page
+func buildPagePartialLink(r *http.Request, partial string) string {
+	query := r.URL.Query()
+	query.Set("partial", partial)
+	return r.URL.Path + "?" + query.Encode()
+}
+
+// handlePagePartial renders a single fragment of a page without the layout
+func handlePagePartial(w http.ResponseWriter, r *http.Request, fileIdentifier string, functionIdentifier string, pageTemplate *template.Template, partial string, data interface{}) error {
+	w.Header().Set("Content-Type", "text/html")
+	return handleTemplateError(w, r, fileIdentifier, functionIdentifier, partial, pageTemplate.ExecuteTemplate(w, partial, data))
+}
+
+// handlePartialError returns a short error message instead of the error page, as the fragment is embedded into the page shell
+func handlePartialError(w http.ResponseWriter, r *http.Request, pageError error) {
+	errKind := getPageErrorKind(pageError)
+	logrus.WithError(pageError).WithField("request_id", getRequestId(r)).Warnf("error loading page fragment %v", r.URL.String())
+	http.Error(w, "Failed to load page data: "+pageError.Error(), errKind.Status)
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/utils"
+)
+
+// maximum number of fields that can be proven in a single request
+const blockProofMaxPaths = 16
+
+// BlockProofResponse holds the merkle proofs of the requested fields of a block
+type BlockProofResponse struct {
+	Data *BlockProofResponseData `json:"data"`
+}
+
+type BlockProofResponseData struct {
+	BlockRoot string                     `json:"block_root"`
+	Slot      uint64                     `json:"slot,string"`
+	Version   string                     `json:"version"`
+	Proofs    []*BlockProofResponseProof `json:"proofs"`
+}
+
+type BlockProofResponseProof struct {
+	Path   string   `json:"path"`
+	GIndex uint64   `json:"gindex,string"`
+	Depth  uint64   `json:"depth,string"`
+	Leaf   string   `json:"leaf"`
+	Branch []string `json:"branch"`
+}
+
+// BlockProof returns merkle proofs of block fields against the block root, so contracts reading the beacon roots
+// of EIP-4788 can verify block fields (/api/v1/proof/block/{root}?path=body.execution_payload.block_hash).
+// The path argument can be given multiple times, the path segments are the snake case ssz field names.
+func BlockProof(w http.ResponseWriter, r *http.Request) {
+	blockRoot, err := hex.DecodeString(strings.Replace(mux.Vars(r)["root"], "0x", "", -1))
+	if err != nil || len(blockRoot) != 32 {
+		http.Error(w, "invalid block root", http.StatusBadRequest)
+		return
+	}
+	paths := r.URL.Query()["path"]
+	if len(paths) == 0 {
+		http.Error(w, "missing field path", http.StatusBadRequest)
+		return
+	}
+	if len(paths) > blockProofMaxPaths {
+		http.Error(w, fmt.Sprintf("too many field paths (max %v)", blockProofMaxPaths), http.StatusBadRequest)
+		return
+	}
+
+	blockData := getBlockProofBlock(w, blockRoot)
+	if blockData == nil {
+		return
+	}
+
+	response := &BlockProofResponse{
+		Data: &BlockProofResponseData{
+			BlockRoot: fmt.Sprintf("0x%x", blockData.Root),
+			Slot:      uint64(blockData.Header.Message.Slot),
+			Version:   blockData.Block.Version.String(),
+			Proofs:    make([]*BlockProofResponseProof, 0, len(paths)),
+		},
+	}
+	for _, path := range paths {
+		proof, err := indexer.BuildBlockFieldProof(blockData.Block, strings.TrimSpace(path))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("cannot prove %v: %v", path, err), http.StatusBadRequest)
+			return
+		}
+		response.Data.Proofs = append(response.Data.Proofs, buildBlockProofResponseProof(proof))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding block proof")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+// OperationProofResponse holds the merkle proof of a block operation against the block body root
+type OperationProofResponse struct {
+	Data *OperationProofResponseData `json:"data"`
+}
+
+type OperationProofResponseData struct {
+	BlockRoot string                   `json:"block_root"`
+	BodyRoot  string                   `json:"body_root"`
+	Slot      uint64                   `json:"slot,string"`
+	Version   string                   `json:"version"`
+	Operation string                   `json:"operation"`
+	Index     uint64                   `json:"index,string"`
+	Proof     *BlockProofResponseProof `json:"proof"`      // operation against the body root
+	BodyProof *BlockProofResponseProof `json:"body_proof"` // body root against the block root
+}
+
+// OperationProof returns the merkle proof of a deposit or withdrawal of a block against the block body root
+// (/api/v1/proof/block/{root}/deposit/{index}), with the proof of the body root against the block root to chain them.
+// The proof is served as file download if the download argument is set.
+func OperationProof(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	blockRoot, err := hex.DecodeString(strings.Replace(vars["root"], "0x", "", -1))
+	if err != nil || len(blockRoot) != 32 {
+		http.Error(w, "invalid block root", http.StatusBadRequest)
+		return
+	}
+	operationIndex, err := strconv.ParseUint(vars["index"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid operation index", http.StatusBadRequest)
+		return
+	}
+
+	blockData := getBlockProofBlock(w, blockRoot)
+	if blockData == nil {
+		return
+	}
+
+	var operationPath string
+	var operationCount int
+	switch vars["operation"] {
+	case "deposit":
+		deposits, _ := blockData.Block.Deposits()
+		operationPath = "deposits"
+		operationCount = len(deposits)
+	case "withdrawal":
+		withdrawals, err := blockData.Block.Withdrawals()
+		if err != nil {
+			http.Error(w, "block has no withdrawals", http.StatusBadRequest)
+			return
+		}
+		operationPath = "execution_payload.withdrawals"
+		operationCount = len(withdrawals)
+	default:
+		http.Error(w, "unknown operation type", http.StatusBadRequest)
+		return
+	}
+	if operationIndex >= uint64(operationCount) {
+		http.Error(w, fmt.Sprintf("%v %v not found", vars["operation"], operationIndex), http.StatusNotFound)
+		return
+	}
+
+	proof, err := indexer.BuildBlockBodyFieldProof(blockData.Block, fmt.Sprintf("%v.%v", operationPath, operationIndex))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot prove %v %v: %v", vars["operation"], operationIndex, err), http.StatusInternalServerError)
+		return
+	}
+	bodyProof, err := indexer.BuildBlockFieldProof(blockData.Block, "body")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot prove block body: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := &OperationProofResponse{
+		Data: &OperationProofResponseData{
+			BlockRoot: fmt.Sprintf("0x%x", blockData.Root),
+			BodyRoot:  fmt.Sprintf("0x%x", proof.Root),
+			Slot:      uint64(blockData.Header.Message.Slot),
+			Version:   blockData.Block.Version.String(),
+			Operation: vars["operation"],
+			Index:     operationIndex,
+			Proof:     buildBlockProofResponseProof(proof),
+			BodyProof: buildBlockProofResponseProof(bodyProof),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Query().Has("download") {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v-slot%v-%v%v-proof.json\"", utils.Config.Chain.Config.ConfigName, blockData.Header.Message.Slot, vars["operation"], operationIndex))
+	}
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding operation proof")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+// getBlockProofBlock loads a canonical or orphaned block by root, the error response is written if it can't be found
+func getBlockProofBlock(w http.ResponseWriter, blockRoot []byte) *services.CombinedBlockResponse {
+	blockData, err := services.GlobalBeaconService.GetSlotDetailsByBlockroot(blockRoot)
+	if err == nil && blockData == nil {
+		blockData = services.GlobalBeaconService.GetOrphanedBlock(blockRoot)
+	}
+	if err != nil || blockData == nil || blockData.Block == nil {
+		if err != nil {
+			logrus.WithError(err).Error("error loading block for proof")
+		}
+		http.Error(w, "block not found", http.StatusNotFound)
+		return nil
+	}
+	return blockData
+}
+
+func buildBlockProofResponseProof(proof *indexer.BlockFieldProof) *BlockProofResponseProof {
+	proofRsp := &BlockProofResponseProof{
+		Path:   proof.Path,
+		GIndex: proof.GIndex,
+		Depth:  proof.Depth,
+		Leaf:   fmt.Sprintf("0x%x", proof.Leaf),
+		Branch: make([]string, len(proof.Branch)),
+	}
+	for idx, hash := range proof.Branch {
+		proofRsp.Branch[idx] = fmt.Sprintf("0x%x", hash)
+	}
+	return proofRsp
+}
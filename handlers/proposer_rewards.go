@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// ProposerRewards will return the "proposer rewards" leaderboard page using a go template
+func ProposerRewards(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"proposer_rewards/proposer_rewards.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/proposer_rewards", "Proposer Rewards", pageTemplateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+	}
+
+	var pageError error
+	data.Data, pageError = getProposerRewardsPageData(pageIdx, pageSize)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "proposer_rewards.go", "ProposerRewards", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getProposerRewardsPageData(pageIdx uint64, pageSize uint64) (*models.ProposerRewardsPageData, error) {
+	pageData := &models.ProposerRewardsPageData{}
+	pageCacheKey := fmt.Sprintf("proposer_rewards:%v:%v", pageIdx, pageSize)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildProposerRewardsPageData(pageIdx, pageSize)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ProposerRewardsPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildProposerRewardsPageData(pageIdx uint64, pageSize uint64) (*models.ProposerRewardsPageData, time.Duration) {
+	logrus.Debugf("proposer rewards page called: %v:%v", pageIdx, pageSize)
+	if pageSize == 0 || pageSize > 100 {
+		pageSize = 100
+	}
+	if pageIdx < 1 {
+		pageIdx = 1
+	}
+
+	pageData := &models.ProposerRewardsPageData{
+		Proposers:   []*models.ProposerRewardsPageDataProposer{},
+		TrackingOff: !utils.Config.Indexer.TrackProposerRewards,
+		PageSize:    pageSize,
+	}
+
+	totalCount := db.GetProposerRewardStatsCount()
+	pageData.TotalPages = totalCount / pageSize
+	if totalCount%pageSize > 0 {
+		pageData.TotalPages++
+	}
+	if pageData.TotalPages > 0 && pageIdx > pageData.TotalPages {
+		pageIdx = pageData.TotalPages
+	}
+	pageData.CurrentPageIndex = pageIdx
+	pageData.PrevPageIndex = pageIdx - 1
+	if pageIdx < pageData.TotalPages {
+		pageData.NextPageIndex = pageIdx + 1
+	}
+	pageData.FirstRank = (pageIdx-1)*pageSize + 1
+
+	for idx, stats := range db.GetProposerRewardStats((pageIdx-1)*pageSize, uint32(pageSize)) {
+		proposerData := &models.ProposerRewardsPageDataProposer{
+			Rank:           pageData.FirstRank + uint64(idx),
+			Index:          stats.Proposer,
+			Name:           services.GlobalBeaconService.GetValidatorName(stats.Proposer),
+			SlotCount:      stats.SlotCount,
+			ProposedCount:  stats.ProposedCount,
+			ExpectedReward: stats.ExpectedReward,
+			ActualReward:   stats.ActualReward,
+		}
+		if stats.ExpectedReward > 0 {
+			proposerData.Efficiency = float64(stats.ActualReward) * 100 / float64(stats.ExpectedReward)
+		}
+		pageData.Proposers = append(pageData.Proposers, proposerData)
+	}
+
+	return pageData, 5 * time.Minute
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/utils"
+)
+
+// RecoveryMiddleware recovers panics of the request handlers, logs them with the request id & stack trace and
+// shows the error page. It has to run after the RequestIdMiddleware.
+func RecoveryMiddleware(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	defer func() {
+		if panicVal := recover(); panicVal != nil {
+			if panicVal == http.ErrAbortHandler {
+				panic(panicVal) // aborted responses are handled by the http server
+			}
+			panicErr, isErr := panicVal.(error)
+			if !isErr {
+				panicErr = fmt.Errorf("%v", panicVal)
+			}
+			logrus.WithFields(logrus.Fields{
+				"request_id": getRequestId(r),
+				"route":      r.URL.String(),
+			}).WithError(panicErr).Errorf("uncaught panic in request handler, stack: %v", string(debug.Stack()))
+			utils.CountSubroutineCrash("handler", panicErr)
+			handlePageError(w, r, fmt.Errorf("handler panic: %w", panicErr))
+		}
+	}()
+	next(w, r)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+)
+
+type requestIdContextKey struct{}
+
+// request ids passed by a reverse proxy are kept, if they're reasonably short & don't contain special characters
+var requestIdPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+
+// RequestIdMiddleware tags each request with an id, that is returned in the X-Request-Id header, shown on error pages
+// and added to the error logs, so reported errors can be correlated with the log entries.
+func RequestIdMiddleware(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	requestId := r.Header.Get("X-Request-Id")
+	if !requestIdPattern.MatchString(requestId) {
+		idBytes := make([]byte, 8)
+		rand.Read(idBytes)
+		requestId = hex.EncodeToString(idBytes)
+	}
+	w.Header().Set("X-Request-Id", requestId)
+	next(w, r.WithContext(context.WithValue(r.Context(), requestIdContextKey{}, requestId)))
+}
+
+func getRequestId(r *http.Request) string {
+	requestId, _ := r.Context().Value(requestIdContextKey{}).(string)
+	return requestId
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// Roots will return the "state roots" page using a go template
+func Roots(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"roots/roots.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/roots", "State Roots", pageTemplateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 64
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var firstSlot uint64 = math.MaxUint64
+	if urlArgs.Has("s") {
+		firstSlot, _ = strconv.ParseUint(urlArgs.Get("s"), 10, 64)
+	}
+
+	var pageError error
+	data.Data, pageError = getRootsPageData(firstSlot, pageSize)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "roots.go", "Roots", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getRootsPageData(firstSlot uint64, pageSize uint64) (*models.RootsPageData, error) {
+	pageData := &models.RootsPageData{}
+	pageCacheKey := fmt.Sprintf("roots:%v:%v", firstSlot, pageSize)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildRootsPageData(firstSlot, pageSize)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.RootsPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildRootsPageData(firstSlot uint64, pageSize uint64) (*models.RootsPageData, time.Duration) {
+	logrus.Debugf("roots page called: %v:%v", firstSlot, pageSize)
+	pageData := &models.RootsPageData{
+		SlotsPerHistoricalRoot: utils.Config.Chain.Config.SlotsPerHistoricalRoot,
+	}
+
+	headSlot := services.GlobalBeaconService.GetIndexer().GetHighestSlot()
+	if firstSlot > headSlot {
+		firstSlot = headSlot
+		pageData.IsDefaultPage = true
+	}
+	if pageSize == 0 || pageSize > 256 {
+		pageSize = 256
+	}
+	lastSlot := uint64(0)
+	if firstSlot >= pageSize {
+		lastSlot = firstSlot - pageSize + 1
+	}
+	pageData.PageSize = pageSize
+	pageData.HeadSlot = headSlot
+	pageData.FirstSlot = firstSlot
+	pageData.LastSlot = lastSlot
+	if firstSlot < headSlot {
+		pageData.HasPrevPage = true
+		pageData.PrevPageSlot = firstSlot + pageSize
+	}
+	if lastSlot > 0 {
+		pageData.HasNextPage = true
+		pageData.NextPageSlot = lastSlot - 1
+	}
+
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	dbRoots := map[uint64]*dbtypes.SlotRoot{}
+	for _, slotRoot := range db.GetSlotRoots(lastSlot, firstSlot) {
+		dbRoots[slotRoot.Slot] = slotRoot
+	}
+
+	// unfinalized slots are resolved from the canonical chain in cache, walking from the newest to the oldest slot
+	var cachedBlockRoot []byte
+	stateRootClient := services.GlobalBeaconService.GetIndexer().GetReadyClient(false, nil, nil)
+	pageData.Roots = make([]*models.RootsPageDataSlot, 0, pageSize)
+	for slotIdx := int64(firstSlot); slotIdx >= int64(lastSlot); slotIdx-- {
+		slot := uint64(slotIdx)
+		rootData := &models.RootsPageDataSlot{
+			Slot:             slot,
+			Epoch:            utils.EpochOfSlot(slot),
+			Ts:               utils.SlotToTime(slot),
+			Finalized:        finalizedEpoch >= int64(utils.EpochOfSlot(slot)),
+			HistoricalPeriod: slot / pageData.SlotsPerHistoricalRoot,
+			HistoricalIndex:  slot % pageData.SlotsPerHistoricalRoot,
+		}
+
+		if slotRoot := dbRoots[slot]; slotRoot != nil {
+			rootData.BlockRoot = slotRoot.BlockRoot
+			rootData.StateRoot = slotRoot.StateRoot
+			rootData.Missed = slotRoot.Missed == 1
+		} else if !rootData.Finalized {
+			for _, block := range services.GlobalBeaconService.GetIndexer().GetCachedBlocks(slot) {
+				if header := block.GetHeader(); header != nil && block.IsCanonical(services.GlobalBeaconService.GetIndexer(), nil) {
+					rootData.BlockRoot = block.Root
+					rootData.StateRoot = header.Message.StateRoot[:]
+					cachedBlockRoot = header.Message.ParentRoot[:]
+					break
+				}
+			}
+			if rootData.BlockRoot == nil {
+				rootData.Missed = true
+			}
+		} else {
+			continue
+		}
+		pageData.Roots = append(pageData.Roots, rootData)
+	}
+
+	// fill in the roots of missed slots
+	for idx := len(pageData.Roots) - 1; idx >= 0; idx-- {
+		rootData := pageData.Roots[idx]
+		if !rootData.Missed {
+			cachedBlockRoot = rootData.BlockRoot
+			continue
+		}
+		if rootData.BlockRoot == nil {
+			rootData.BlockRoot = cachedBlockRoot
+		}
+		if rootData.StateRoot == nil && stateRootClient != nil {
+			// the state root of empty slots is not part of any block, so it needs to be requested from the node
+			stateRoot, err := stateRootClient.GetRpcClient().GetStateRoot(fmt.Sprintf("%v", rootData.Slot))
+			if err == nil {
+				rootData.StateRoot = stateRoot
+			}
+		}
+	}
+	pageData.RootCount = uint64(len(pageData.Roots))
+
+	if int64(utils.EpochOfSlot(firstSlot)) <= finalizedEpoch {
+		return pageData, 30 * time.Minute
+	}
+	return pageData, 12 * time.Second
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -52,20 +54,15 @@ func Search(w http.ResponseWriter, r *http.Request) {
 
 	hashQuery := strings.Replace(searchQuery, "0x", "", -1)
 	if len(hashQuery) == 64 {
-		blockHash, err := hex.DecodeString(hashQuery)
+		rootHash, err := hex.DecodeString(hashQuery)
 		if err == nil {
-			blockResult := &dbtypes.SearchBlockResult{}
-			err = db.ReaderDb.Get(blockResult, `
-			SELECT slot, root, orphaned 
-			FROM blocks 
-			WHERE root = $1 OR
-				state_root = $1
-			LIMIT 1`, blockHash)
-			if err == nil {
-				if blockResult.Orphaned {
-					http.Redirect(w, r, fmt.Sprintf("/slot/0x%x", blockResult.Root), http.StatusMovedPermanently)
+			// block, state, body or execution block hash
+			resolved := services.GlobalBeaconService.ResolveRoot(rootHash)
+			if resolved != nil {
+				if resolved.Orphaned {
+					http.Redirect(w, r, fmt.Sprintf("/slot/0x%x", resolved.BlockRoot), http.StatusMovedPermanently)
 				} else {
-					http.Redirect(w, r, fmt.Sprintf("/slot/%v", blockResult.Slot), http.StatusMovedPermanently)
+					http.Redirect(w, r, fmt.Sprintf("/slot/%v", resolved.Slot), http.StatusMovedPermanently)
 				}
 				return
 			}
@@ -90,6 +87,16 @@ func Search(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// graffiti prefix matches can use the prefix index, the substring match needs a full scan on sqlite
+	if blocks := db.GetFilteredBlocks(&dbtypes.BlockFilter{
+		Graffiti:       searchQuery,
+		GraffitiPrefix: true,
+		WithOrphaned:   1,
+	}, math.MaxInt64, 0, 1); len(blocks) > 0 {
+		http.Redirect(w, r, "/slots/graffiti/"+url.PathEscape(searchQuery), http.StatusMovedPermanently)
+		return
+	}
+
 	graffiti := &dbtypes.SearchGraffitiResult{}
 	err = db.ReaderDb.Get(graffiti, db.EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
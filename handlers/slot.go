@@ -8,6 +8,7 @@ import (
 	"math"
 	"math/big"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/pk910/dora/db"
 	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/indexer"
 	"github.com/pk910/dora/rpc"
 	"github.com/pk910/dora/services"
 	"github.com/pk910/dora/templates"
@@ -38,6 +40,8 @@ func Slot(w http.ResponseWriter, r *http.Request) {
 		"slot/voluntary_exits.html",
 		"slot/slashings.html",
 		"slot/blobs.html",
+		"slot/data_columns.html",
+		"slot/raw.html",
 	)
 	var notfoundTemplateFiles = append(layoutTemplateFiles,
 		"slot/notfound.html",
@@ -117,7 +121,117 @@ func Slot(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SlotPermalink resolves "/slot/{slot}/{rootPrefix}" permalinks to the matching block.
+// the root prefix disambiguates between canonical and orphaned blocks at the same slot, so links stay valid across reorgs.
+func SlotPermalink(w http.ResponseWriter, r *http.Request) {
+	var notfoundTemplateFiles = append(layoutTemplateFiles,
+		"slot/notfound.html",
+	)
+
+	vars := mux.Vars(r)
+	blockSlot, err := strconv.ParseUint(vars["slot"], 10, 64)
+	rootPrefix := strings.ToLower(strings.Replace(vars["rootPrefix"], "0x", "", 1))
+	var blockRoot []byte
+	if err == nil && rootPrefix != "" {
+		for _, block := range services.GlobalBeaconService.GetDbBlocksForSlots(blockSlot, 1, true) {
+			if block.Slot != blockSlot || !strings.HasPrefix(fmt.Sprintf("%x", block.Root), rootPrefix) {
+				continue
+			}
+			if blockRoot != nil {
+				// ambiguous prefix
+				blockRoot = nil
+				break
+			}
+			blockRoot = block.Root
+		}
+	}
+
+	if blockRoot == nil {
+		data := InitPageData(w, r, "blockchain", "/slots", fmt.Sprintf("Slot %v", vars["slot"]), notfoundTemplateFiles)
+		data.Data = "slot"
+		w.Header().Set("Content-Type", "text/html")
+		if handleTemplateError(w, r, "slot.go", "SlotPermalink", "notFound", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+			return // an error has occurred and was processed
+		}
+		return
+	}
+
+	redirectUrl := fmt.Sprintf("/slot/0x%x", blockRoot)
+	if r.URL.RawQuery != "" {
+		redirectUrl += "?" + r.URL.RawQuery
+	}
+	http.Redirect(w, r, redirectUrl, http.StatusFound)
+}
+
 // SlotBlob handles responses for the block blobs tab
+// setSlotPageCustomForkFields adds the block fields of custom devnet forks, that are unknown to the base fork layout
+func setSlotPageCustomForkFields(pageData *models.SlotPageBlockData) map[string]json.RawMessage {
+	client := services.GlobalBeaconService.GetIndexer().GetReadyClient(false, nil, nil)
+	if client == nil {
+		return nil
+	}
+	forkName, customFields, err := client.GetRpcClient().GetBlockCustomFields(pageData.BlockRoot)
+	if err != nil {
+		logrus.WithError(err).Warnf("error loading custom fork fields for block 0x%x", pageData.BlockRoot)
+		return nil
+	}
+	if forkName == "" {
+		return nil
+	}
+
+	pageData.CustomFork = forkName
+	pageData.CustomFields = make([]*models.SlotPageCustomField, 0, len(customFields))
+	for name, value := range customFields {
+		var fieldValue bytes.Buffer
+		if json.Indent(&fieldValue, value, "", "  ") != nil {
+			fieldValue.Reset()
+			fieldValue.Write(value)
+		}
+		pageData.CustomFields = append(pageData.CustomFields, &models.SlotPageCustomField{
+			Name:  name,
+			Value: fieldValue.String(),
+		})
+	}
+	sort.Slice(pageData.CustomFields, func(a, b int) bool {
+		return pageData.CustomFields[a].Name < pageData.CustomFields[b].Name
+	})
+	return customFields
+}
+
+// setSlotPageWitness adds the execution witness summary of blocks on stateless devnets
+func setSlotPageWitness(pageData *models.SlotPageBlockData, customFields map[string]json.RawMessage) {
+	var witness *rpc.ExecutionWitnessStats
+	if witnessData := customFields[rpc.ExecutionWitnessField]; witnessData != nil {
+		var err error
+		witness, err = rpc.ParseExecutionWitness(witnessData)
+		if err != nil {
+			logrus.WithError(err).Warnf("error parsing execution witness of block 0x%x", pageData.BlockRoot)
+		}
+	} else if cachedBlock := services.GlobalBeaconService.GetIndexer().GetCachedBlock(pageData.BlockRoot); cachedBlock != nil {
+		witness = cachedBlock.GetWitnessStats()
+	} else if dbWitness := db.GetBlockWitness(pageData.BlockRoot); dbWitness != nil {
+		witness = &rpc.ExecutionWitnessStats{
+			StemCount:   dbWitness.StemCount,
+			KeyCount:    dbWitness.KeyCount,
+			WriteCount:  dbWitness.WriteCount,
+			ProofSize:   dbWitness.ProofSize,
+			WitnessSize: dbWitness.WitnessSize,
+		}
+	}
+	if witness == nil {
+		return
+	}
+
+	pageData.Witness = &models.SlotPageWitness{
+		StemCount:   witness.StemCount,
+		KeyCount:    witness.KeyCount,
+		ReadCount:   witness.KeyCount - witness.WriteCount,
+		WriteCount:  witness.WriteCount,
+		ProofSize:   witness.ProofSize,
+		WitnessSize: witness.WitnessSize,
+	}
+}
+
 func SlotBlob(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -141,13 +255,22 @@ func SlotBlob(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
 		return
 	}
+	versionedHash := utils.KzgCommitmentToVersionedHash(blobData.Commitment)
 	result := &models.SlotPageBlobDetails{
 		KzgCommitment: fmt.Sprintf("%x", blobData.Commitment),
 		KzgProof:      fmt.Sprintf("%x", blobData.Proof),
+		VersionedHash: fmt.Sprintf("%x", versionedHash),
 	}
 	if blobData.Blob != nil {
 		result.Blob = fmt.Sprintf("%x", *blobData.Blob)
 	}
+	if blockData, err := services.GlobalBeaconService.GetSlotDetailsByBlockroot(blockRoot); err == nil && blockData != nil {
+		if blobTx := getBlobTransactions(blockData.Block)[string(versionedHash)]; blobTx != nil {
+			result.TxHash = fmt.Sprintf("%x", blobTx.Hash)
+			result.TxFrom = fmt.Sprintf("%x", blobTx.From)
+			result.TxTo = fmt.Sprintf("%x", blobTx.To)
+		}
+	}
 	err = json.NewEncoder(w).Encode(result)
 	if err != nil {
 		logrus.WithError(err).Error("error encoding blob sidecar")
@@ -155,11 +278,63 @@ func SlotBlob(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SlotRaw handles responses for the block raw view tab
+func SlotRaw(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+	blockRoot, err := hex.DecodeString(strings.Replace(vars["root"], "0x", "", -1))
+	if err != nil || len(blockRoot) != 32 {
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+		return
+	}
+
+	blockData, err := services.GlobalBeaconService.GetSlotDetailsByBlockroot(blockRoot)
+	if err == nil && blockData == nil {
+		blockData = services.GlobalBeaconService.GetOrphanedBlock(blockRoot)
+	}
+	if err != nil || blockData == nil || blockData.Block == nil {
+		if err != nil {
+			logrus.WithError(err).Error("error loading block for raw view")
+		}
+		http.Error(w, "Block not found", http.StatusNotFound)
+		return
+	}
+
+	_, blockSsz, err := indexer.MarshalVersionedSignedBeaconBlockSSZ(blockData.Block)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding block ssz")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+		return
+	}
+	_, blockJson, err := indexer.MarshalVersionedSignedBeaconBlockJson(blockData.Block)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding block json")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+		return
+	}
+
+	result := &models.SlotPageRawBlock{
+		Root:    fmt.Sprintf("0x%x", blockData.Root),
+		Version: blockData.Block.Version.String(),
+		Ssz:     fmt.Sprintf("0x%x", blockSsz),
+		Data:    blockJson,
+	}
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding raw block")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
 func getSlotPageData(blockSlot int64, blockRoot []byte, loadDuties bool) (*models.SlotPageData, error) {
 	pageData := &models.SlotPageData{}
 	pageCacheKey := fmt.Sprintf("slot:%v:%x:%v", blockSlot, blockRoot, loadDuties)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildSlotPageData(blockSlot, blockRoot, loadDuties)
+		pageData, cacheTimeout, err := buildSlotPageData(blockSlot, blockRoot, loadDuties)
+		if err != nil {
+			return err
+		}
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -173,7 +348,7 @@ func getSlotPageData(blockSlot int64, blockRoot []byte, loadDuties bool) (*model
 	return pageData, pageErr
 }
 
-func buildSlotPageData(blockSlot int64, blockRoot []byte, loadDuties bool) (*models.SlotPageData, time.Duration) {
+func buildSlotPageData(blockSlot int64, blockRoot []byte, loadDuties bool) (*models.SlotPageData, time.Duration, error) {
 	currentSlot := utils.TimeToSlot(uint64(time.Now().Unix()))
 	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
 	var blockData *services.CombinedBlockResponse
@@ -202,6 +377,9 @@ func buildSlotPageData(blockSlot int64, blockRoot []byte, loadDuties bool) (*mod
 			// check orphaned status
 			blockData.Orphaned = services.GlobalBeaconService.CheckBlockOrphanedStatus(blockData.Root)
 		}
+	} else {
+		// don't show the slot as missed, if the nodes failed to provide the block
+		return nil, 0, fmt.Errorf("%w: %v", ErrUpstreamUnavailable, err)
 	}
 
 	var slot uint64
@@ -210,7 +388,7 @@ func buildSlotPageData(blockSlot int64, blockRoot []byte, loadDuties bool) (*mod
 	} else if blockSlot > -1 {
 		slot = uint64(blockSlot)
 	} else {
-		return nil, -1
+		return nil, -1, nil
 	}
 	logrus.Debugf("slot page called: %v", slot)
 
@@ -252,7 +430,7 @@ func buildSlotPageData(blockSlot int64, blockRoot []byte, loadDuties bool) (*mod
 	}
 
 	if blockData == nil {
-		pageData.Status = uint16(models.SlotStatusMissed)
+		pageData.Status = models.SlotStatusMissed
 		pageData.Proposer = math.MaxInt64
 		if assignments != nil {
 			pageData.Proposer = assignments.ProposerAssignments[slot]
@@ -269,16 +447,185 @@ func buildSlotPageData(blockSlot int64, blockRoot []byte, loadDuties bool) (*mod
 		pageData.ProposerName = services.GlobalBeaconService.GetValidatorName(pageData.Proposer)
 	} else {
 		if blockData.Orphaned {
-			pageData.Status = uint16(models.SlotStatusOrphaned)
+			pageData.Status = models.SlotStatusOrphaned
 		} else {
-			pageData.Status = uint16(models.SlotStatusFound)
+			pageData.Status = models.SlotStatusFound
 		}
 		pageData.Proposer = uint64(blockData.Header.Message.ProposerIndex)
 		pageData.ProposerName = services.GlobalBeaconService.GetValidatorName(pageData.Proposer)
 		pageData.Block = getSlotPageBlockData(blockData, assignments, loadDuties)
+		setSlotPageBlockArrivals(pageData.Block, slot)
+		if pageData.Block.BlobsCount > 0 && utils.IsPeerDASEpoch(pageData.Epoch) {
+			setSlotPageDataColumns(pageData.Block)
+		}
+		pageData.Block.ProposerDependentRoot = getSlotPageDependentRoot(pageData.Block.BlockRoot)
+		if rpc.GetCustomForkForEpoch(pageData.Epoch) != nil {
+			customFields := setSlotPageCustomForkFields(pageData.Block)
+			setSlotPageWitness(pageData.Block, customFields)
+		}
+	}
+
+	if pageData.Block != nil && slot > 0 {
+		if blockReward := services.GlobalBeaconService.GetBlockReward(slot, pageData.Block.BlockRoot); blockReward != nil {
+			pageData.BlockRewards = &models.SlotPageBlockRewards{
+				Total:             blockReward.Total,
+				Attestations:      blockReward.Attestations,
+				SyncAggregate:     blockReward.SyncAggregate,
+				ProposerSlashings: blockReward.ProposerSlashings,
+				AttesterSlashings: blockReward.AttesterSlashings,
+				SyncRewards:       blockReward.SyncRewards,
+				SyncPenalties:     blockReward.SyncPenalties,
+				SyncMissed:        blockReward.SyncMissed,
+			}
+		}
+	}
+
+	// reorg annotations belong to the orphaned block, not to the canonical block of the same slot
+	slotAnnotations := []*dbtypes.Annotation{}
+	for _, annotation := range services.GetSlotAnnotations(slot, slot)[slot] {
+		if annotation.Type == dbtypes.AnnotationTypeReorg && (pageData.Block == nil || !bytes.Equal(annotation.Root, pageData.Block.BlockRoot)) {
+			continue
+		}
+		slotAnnotations = append(slotAnnotations, annotation)
+	}
+	pageData.Annotations = buildAnnotationModels(slotAnnotations)
+
+	if pageData.EpochFinalized {
+		if slotReward := db.GetSlotReward(slot); slotReward != nil {
+			pageData.Rewards = &models.SlotPageRewards{
+				ClReward:       slotReward.ClReward,
+				MevBid:         slotReward.MevBid,
+				MevPayment:     slotReward.MevPayment,
+				MevRelay:       slotReward.MevRelay,
+				ExpectedReward: slotReward.ExpectedReward,
+				ActualReward:   slotReward.ActualReward,
+			}
+			if slotReward.ExpectedReward > 0 {
+				pageData.Rewards.Efficiency = float64(slotReward.ActualReward) * 100 / float64(slotReward.ExpectedReward)
+			}
+		}
 	}
 
-	return pageData, cacheTimeout
+	return pageData, cacheTimeout, nil
+}
+
+// getSlotPageDependentRoot returns the dependent root of the proposer duties the block has been proposed with (from cache or db)
+func getSlotPageDependentRoot(blockRoot []byte) []byte {
+	beaconIndexer := services.GlobalBeaconService.GetIndexer()
+	if cachedBlock := beaconIndexer.GetCachedBlock(blockRoot); cachedBlock != nil {
+		if dbBlock := beaconIndexer.BuildLiveBlock(cachedBlock); dbBlock != nil {
+			return dbBlock.ProposerDependentRoot
+		}
+		return nil
+	}
+	if dbBlock := db.GetBlockByRoot(blockRoot); dbBlock != nil {
+		return dbBlock.ProposerDependentRoot
+	}
+	return nil
+}
+
+// setSlotPageBlockArrivals adds the per client arrival times of the block (from cache or db)
+func setSlotPageBlockArrivals(pageData *models.SlotPageBlockData, slot uint64) {
+	arrivals := []*dbtypes.BlockArrival{}
+	if cachedBlock := services.GlobalBeaconService.GetIndexer().GetCachedBlock(pageData.BlockRoot); cachedBlock != nil {
+		slotTime := utils.SlotToTime(slot)
+		for _, arrival := range cachedBlock.GetArrivals() {
+			arrivals = append(arrivals, &dbtypes.BlockArrival{
+				Client: arrival.ClientName,
+				Delay:  arrival.Time.Sub(slotTime).Milliseconds(),
+			})
+		}
+	} else {
+		arrivals = db.GetBlockArrivals(pageData.BlockRoot)
+	}
+	if len(arrivals) == 0 {
+		return
+	}
+
+	firstDelay := arrivals[0].Delay
+	pageData.Arrivals = make([]*models.SlotPageBlockArrival, len(arrivals))
+	for idx, arrival := range arrivals {
+		pageData.Arrivals[idx] = &models.SlotPageBlockArrival{
+			Client: arrival.Client,
+			Delay:  arrival.Delay,
+			Behind: arrival.Delay - firstDelay,
+		}
+	}
+	pageData.ArrivalSpread = arrivals[len(arrivals)-1].Delay - firstDelay
+	pageData.ArrivalTimeline = buildSlotPageTimeline(arrivals)
+}
+
+// buildSlotPageTimeline places the arrivals (sorted by delay) between the slot start and the end of the slot,
+// arrivals outside of the slot are pinned to the timeline edges.
+func buildSlotPageTimeline(arrivals []*dbtypes.BlockArrival) *models.SlotPageTimeline {
+	slotDuration := int64(utils.Config.Chain.Config.SecondsPerSlot) * 1000
+	if slotDuration == 0 {
+		return nil
+	}
+	timelinePercent := func(offset int64) float64 {
+		if offset < 0 {
+			offset = 0
+		} else if offset > slotDuration {
+			offset = slotDuration
+		}
+		return float64(offset) * 100 / float64(slotDuration)
+	}
+
+	timeline := &models.SlotPageTimeline{
+		SlotDuration:        slotDuration,
+		AttestationDeadline: utils.SlotAttestationDeadline().Milliseconds(),
+		AggregateDeadline:   utils.SlotAggregateDeadline().Milliseconds(),
+		FirstArrival:        arrivals[0].Delay,
+		Points:              make([]*models.SlotPageTimelinePoint, len(arrivals)),
+	}
+	timeline.AttestationPercent = timelinePercent(timeline.AttestationDeadline)
+	timeline.AggregatePercent = timelinePercent(timeline.AggregateDeadline)
+	timeline.AttestationMargin = timeline.AttestationDeadline - timeline.FirstArrival
+	for idx, arrival := range arrivals {
+		timeline.Points[idx] = &models.SlotPageTimelinePoint{
+			Client:  arrival.Client,
+			Delay:   arrival.Delay,
+			Percent: timelinePercent(arrival.Delay),
+			Late:    arrival.Delay > timeline.AttestationDeadline,
+		}
+	}
+	return timeline
+}
+
+// setSlotPageDataColumns adds the PeerDAS column availability of the block, a column is available if any client serves it
+func setSlotPageDataColumns(pageData *models.SlotPageBlockData) {
+	dataColumns := services.GlobalBeaconService.GetIndexer().GetBlockDataColumns(pageData.BlockRoot)
+	if len(dataColumns) == 0 {
+		return
+	}
+
+	columnCount := utils.NumberOfDataColumns()
+	pageData.DataColumns = &models.SlotPageDataColumns{
+		ColumnCount: columnCount,
+		Clients:     make([]string, len(dataColumns)),
+		Columns:     make([]*models.SlotPageDataColumn, columnCount),
+	}
+	for idx, clientColumns := range dataColumns {
+		pageData.DataColumns.Clients[idx] = clientColumns.Client
+	}
+	for column := uint64(0); column < columnCount; column++ {
+		columnData := &models.SlotPageDataColumn{
+			Index:   column,
+			Custody: []string{},
+		}
+		for _, clientColumns := range dataColumns {
+			if indexer.DataColumnBitfieldHas(clientColumns.Columns, column) {
+				columnData.Custody = append(columnData.Custody, clientColumns.Client)
+			}
+		}
+		if len(columnData.Custody) > 0 {
+			columnData.Available = true
+			pageData.DataColumns.AvailableCount++
+		}
+		pageData.DataColumns.Columns[column] = columnData
+	}
+	// the blobs can be recovered from any half of the extended columns
+	pageData.DataColumns.Reconstructable = pageData.DataColumns.AvailableCount*2 >= columnCount
 }
 
 func getSlotPageBlockData(blockData *services.CombinedBlockResponse, assignments *rpc.EpochAssignments, loadDuties bool) *models.SlotPageBlockData {
@@ -312,8 +659,12 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, assignments
 		VoluntaryExitsCount:    uint64(len(voluntaryExits)),
 		SlashingsCount:         uint64(len(proposerSlashings)) + uint64(len(attesterSlashings)),
 		DutiesLoaded:           loadDuties,
+		OperationProofs:        utils.Config.Frontend.ProofApiEnabled,
 	}
 
+	blockAggregation := indexer.GetBlockAggregationStats(attestations)
+	pageData.Aggregation = buildAggregationStatsModel(blockAggregation.AggregateCount, blockAggregation.CommitteeCount, blockAggregation.RedundantCount, blockAggregation.VoteCount, blockAggregation.DuplicateVoteCount)
+
 	epoch := utils.EpochOfSlot(uint64(blockData.Header.Message.Slot))
 	assignmentsMap := make(map[uint64]*rpc.EpochAssignments)
 	assignmentsLoaded := make(map[uint64]bool)
@@ -399,6 +750,10 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, assignments
 			Attestation2TargetRoot:      slashing.Attestation2.Data.Target.Root[:],
 			SlashedValidators:           make([]types.NamedValidator, 0),
 		}
+		evidence := indexer.VerifyAttesterSlashing(slashing)
+		slashingData.SlashingType = string(evidence.Type)
+		slashingData.EvidenceValid = evidence.Valid
+		slashingData.EvidenceReason = evidence.Reason
 		pageData.AttesterSlashings[i] = slashingData
 		for j := range slashing.Attestation1.AttestingIndices {
 			slashingData.Attestation1Indices[j] = uint64(slashing.Attestation1.AttestingIndices[j])
@@ -418,6 +773,7 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, assignments
 
 	pageData.ProposerSlashings = make([]*models.SlotPageProposerSlashing, pageData.ProposerSlashingsCount)
 	for i, slashing := range proposerSlashings {
+		evidence := indexer.VerifyProposerSlashing(slashing)
 		pageData.ProposerSlashings[i] = &models.SlotPageProposerSlashing{
 			ProposerIndex:     uint64(slashing.SignedHeader1.Message.ProposerIndex),
 			ProposerName:      services.GlobalBeaconService.GetValidatorName(uint64(slashing.SignedHeader1.Message.ProposerIndex)),
@@ -431,6 +787,9 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, assignments
 			Header2StateRoot:  slashing.SignedHeader2.Message.StateRoot[:],
 			Header2BodyRoot:   slashing.SignedHeader2.Message.BodyRoot[:],
 			Header2Signature:  slashing.SignedHeader2.Signature[:],
+			SlashingType:      string(evidence.Type),
+			EvidenceValid:     evidence.Valid,
+			EvidenceReason:    evidence.Reason,
 		}
 	}
 
@@ -450,11 +809,22 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, assignments
 
 		if len(syncAssignments) != 0 {
 			pageData.SyncAggCommittee = make([]types.NamedValidator, len(syncAssignments))
+			pageData.SyncAggMembers = make([]*models.SlotPageSyncMember, len(syncAssignments))
+			pageData.SyncAggMissed = []types.NamedValidator{}
 			for idx, vidx := range syncAssignments {
 				pageData.SyncAggCommittee[idx] = types.NamedValidator{
 					Index: vidx,
 					Name:  services.GlobalBeaconService.GetValidatorName(vidx),
 				}
+				participated := utils.BitAtVector(pageData.SyncAggregateBits, idx)
+				pageData.SyncAggMembers[idx] = &models.SlotPageSyncMember{
+					Index:        vidx,
+					Name:         pageData.SyncAggCommittee[idx].Name,
+					Participated: participated,
+				}
+				if !participated {
+					pageData.SyncAggMissed = append(pageData.SyncAggMissed, pageData.SyncAggCommittee[idx])
+				}
 			}
 		} else {
 			pageData.SyncAggCommittee = []types.NamedValidator{}
@@ -543,6 +913,28 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, assignments
 		}
 	}
 
+	if pageData.ExecutionData != nil {
+		pageData.ExecutionData.ElClient = utils.GetExtraDataClient(pageData.ExecutionData.ExtraData)
+		transactions, _ := blockData.Block.ExecutionTransactions()
+		txTypes := utils.TransactionTypeCounts{}
+		for _, tx := range transactions {
+			txTypes.AddTransaction(tx)
+		}
+		pageData.ExecutionData.TxTypes = &models.TransactionTypes{
+			Legacy:     txTypes.Legacy,
+			AccessList: txTypes.AccessList,
+			DynamicFee: txTypes.DynamicFee,
+			Blob:       txTypes.Blob,
+			SetCode:    txTypes.SetCode,
+		}
+
+		proposerName := services.GlobalBeaconService.GetValidatorName(uint64(blockData.Header.Message.ProposerIndex))
+		if expectedFeeRecipient := getExpectedFeeRecipient(proposerName); expectedFeeRecipient != nil {
+			pageData.ExecutionData.ExpectedFeeRecipient = expectedFeeRecipient
+			pageData.ExecutionData.FeeRecipientDeviates = !bytes.Equal(expectedFeeRecipient, pageData.ExecutionData.FeeRecipient)
+		}
+	}
+
 	if epoch >= utils.Config.Chain.Config.CappellaForkEpoch {
 		pageData.BLSChangesCount = uint64(len(blsToExecChanges))
 		pageData.BLSChanges = make([]*models.SlotPageBLSChange, pageData.BLSChangesCount)
@@ -572,10 +964,14 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, assignments
 	if epoch >= utils.Config.Chain.Config.DenebForkEpoch {
 		pageData.BlobsCount = uint64(len(blobKzgCommitments))
 		pageData.Blobs = make([]*models.SlotPageBlob, pageData.BlobsCount)
+		blobTransactions := getBlobTransactions(blockData.Block)
 		for i, _ := range blobKzgCommitments {
+			versionedHash := utils.KzgCommitmentToVersionedHash(blobKzgCommitments[i][:])
 			blobData := &models.SlotPageBlob{
 				Index:         uint64(i),
 				KzgCommitment: blobKzgCommitments[i][:],
+				VersionedHash: versionedHash,
+				Transaction:   blobTransactions[string(versionedHash)],
 			}
 			pageData.Blobs[i] = blobData
 		}
@@ -583,3 +979,30 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, assignments
 
 	return pageData
 }
+
+// getBlobTransactions maps the versioned hashes referenced by the blob transactions in a block to the carrying transaction
+func getBlobTransactions(block *spec.VersionedSignedBeaconBlock) map[string]*models.SlotPageBlobTransaction {
+	blobTransactions := map[string]*models.SlotPageBlobTransaction{}
+	transactions, _ := block.ExecutionTransactions()
+	for txIdx, txBytes := range transactions {
+		blobTx, err := utils.DecodeBlobTransaction(txBytes)
+		if err != nil {
+			logrus.Warnf("error decoding blob transaction %v: %v", txIdx, err)
+			continue
+		}
+		if blobTx == nil {
+			continue
+		}
+		for blobIdx, blobHash := range blobTx.BlobHashes {
+			blobTransactions[string(blobHash)] = &models.SlotPageBlobTransaction{
+				Index:     uint64(txIdx),
+				Hash:      blobTx.Hash,
+				From:      blobTx.From,
+				To:        blobTx.To,
+				BlobIndex: uint64(blobIdx),
+				BlobCount: uint64(len(blobTx.BlobHashes)),
+			}
+		}
+	}
+	return blobTransactions
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// SlotTransition re-executes the state transition of a block with the configured state transition tool
+// and compares the resulting state root with the one in the block.
+// `?download=block` or `?download=prestate` returns the ssz encoded inputs for local debugging instead.
+func SlotTransition(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	blockRoot, err := hex.DecodeString(strings.Replace(vars["root"], "0x", "", -1))
+	if err != nil || len(blockRoot) != 32 {
+		http.Error(w, "Invalid block root", http.StatusBadRequest)
+		return
+	}
+
+	blockData, err := services.GlobalBeaconService.GetSlotDetailsByBlockroot(blockRoot)
+	if err == nil && blockData == nil {
+		blockData = services.GlobalBeaconService.GetOrphanedBlock(blockRoot)
+	}
+	if err != nil || blockData == nil || blockData.Block == nil || blockData.Header == nil {
+		http.Error(w, "Block not found", http.StatusNotFound)
+		return
+	}
+	parentRoot := blockData.Header.Message.ParentRoot[:]
+	parentData, err := services.GlobalBeaconService.GetSlotDetailsByBlockroot(parentRoot)
+	if err == nil && parentData == nil {
+		parentData = services.GlobalBeaconService.GetOrphanedBlock(parentRoot)
+	}
+	if err != nil || parentData == nil || parentData.Header == nil {
+		http.Error(w, "Parent block not found", http.StatusNotFound)
+		return
+	}
+
+	_, blockSsz, err := indexer.MarshalVersionedSignedBeaconBlockSSZ(blockData.Block)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding block ssz")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+		return
+	}
+
+	slot := uint64(blockData.Header.Message.Slot)
+	if r.URL.Query().Get("download") == "block" {
+		serveSszDownload(w, fmt.Sprintf("block-%v-0x%x.ssz", slot, blockRoot), blockSsz)
+		return
+	}
+
+	result := &models.SlotPageTransition{
+		Slot:              slot,
+		BlockRoot:         fmt.Sprintf("0x%x", blockRoot),
+		ParentRoot:        fmt.Sprintf("0x%x", parentRoot),
+		Version:           blockData.Block.Version.String(),
+		PreStateRoot:      fmt.Sprintf("0x%x", parentData.Header.Message.StateRoot[:]),
+		BlockSize:         uint64(len(blockSsz)),
+		ExpectedStateRoot: fmt.Sprintf("0x%x", blockData.Header.Message.StateRoot[:]),
+	}
+
+	rpcClient := services.GlobalBeaconService.GetIndexer().GetRpcClient(true, parentRoot)
+	preState, _, err := rpcClient.GetStateSSZ(result.PreStateRoot)
+	if err != nil {
+		result.Error = fmt.Sprintf("could not load pre-state: %v", err)
+	} else if preState == nil {
+		result.Error = "could not load pre-state: state not found"
+	} else if r.URL.Query().Get("download") == "prestate" {
+		serveSszDownload(w, fmt.Sprintf("prestate-%v-%v.ssz", slot, result.PreStateRoot), preState)
+		return
+	} else {
+		result.PreStateSize = uint64(len(preState))
+		if utils.Config.Frontend.StateTransitionEndpoint == "" {
+			result.Error = "no state transition tool configured"
+		} else {
+			toolStateRoot, err := executeStateTransition(result.Version, preState, blockSsz)
+			if err != nil {
+				result.Error = fmt.Sprintf("state transition failed: %v", err)
+			} else {
+				result.Executed = true
+				result.ToolStateRoot = toolStateRoot
+				result.Match = strings.EqualFold(toolStateRoot, result.ExpectedStateRoot)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(result)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding state transition result")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func serveSszDownload(w http.ResponseWriter, filename string, data []byte) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v\"", filename))
+	w.Write(data)
+}
+
+// executeStateTransition submits the pre-state & block as multipart form (fields "fork", "pre" & "block") to the
+// state transition tool endpoint, which responds with {"state_root": "0x..."} for the resulting post-state.
+func executeStateTransition(fork string, preState []byte, block []byte) (string, error) {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	if err := form.WriteField("fork", fork); err != nil {
+		return "", err
+	}
+	for field, data := range map[string][]byte{"pre": preState, "block": block} {
+		part, err := form.CreateFormFile(field, field+".ssz")
+		if err != nil {
+			return "", err
+		}
+		if _, err := part.Write(data); err != nil {
+			return "", err
+		}
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	client := &http.Client{Timeout: 120 * time.Second}
+	resp, err := client.Post(utils.Config.Frontend.StateTransitionEndpoint, form.FormDataContentType(), body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("tool responded with status %v: %s", resp.StatusCode, data)
+	}
+
+	toolRsp := struct {
+		StateRoot string `json:"state_root"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&toolRsp); err != nil {
+		return "", fmt.Errorf("error parsing tool response: %v", err)
+	}
+	return toolRsp.StateRoot, nil
+}
@@ -68,6 +68,10 @@ func getSlotsPageData(firstSlot uint64, pageSize uint64) (*models.SlotsPageData,
 func buildSlotsPageData(firstSlot uint64, pageSize uint64) (*models.SlotsPageData, time.Duration) {
 	logrus.Debugf("slots page called: %v:%v", firstSlot, pageSize)
 	pageData := &models.SlotsPageData{}
+	if utils.Config.Indexer.DisableIndexWriter {
+		pageData.LightMode = true
+		pageData.LowestLiveEpoch = uint64(services.GlobalBeaconService.GetIndexer().GetLowestLiveEpoch())
+	}
 
 	now := time.Now()
 	currentSlot := utils.TimeToSlot(uint64(now.Unix()))
@@ -139,10 +143,7 @@ func buildSlotsPageData(firstSlot uint64, pageSize uint64) (*models.SlotsPageDat
 		for dbIdx < dbCnt && dbSlots[dbIdx] != nil && dbSlots[dbIdx].Slot == slot {
 			dbSlot := dbSlots[dbIdx]
 			dbIdx++
-			blockStatus := uint8(1)
-			if dbSlot.Orphaned == 1 {
-				blockStatus = 2
-			}
+			blockStatus := models.NewSlotStatus(dbSlot.Orphaned)
 
 			slotData := &models.SlotsPageDataSlot{
 				Slot:                  slot,
@@ -183,7 +184,7 @@ func buildSlotsPageData(firstSlot uint64, pageSize uint64) (*models.SlotsPageDat
 				Ts:           utils.SlotToTime(slot),
 				Finalized:    finalized,
 				Scheduled:    epoch >= currentEpoch,
-				Status:       0,
+				Status:       models.SlotStatusMissed,
 				Synchronized: syncedEpochs[epoch],
 				Proposer:     slotAssignments[slot],
 				ProposerName: services.GlobalBeaconService.GetValidatorName(slotAssignments[slot]),
@@ -200,6 +201,9 @@ func buildSlotsPageData(firstSlot uint64, pageSize uint64) (*models.SlotsPageDat
 	pageData.FirstSlot = firstSlot
 	pageData.LastSlot = lastSlot
 	pageData.ForkTreeWidth = (maxOpenFork * 20) + 20
+	if pageData.IsDefaultPage {
+		pageData.Pending = buildSlotsPendingData(currentSlot, now)
+	}
 
 	var cacheTimeout time.Duration
 
@@ -274,7 +278,7 @@ func buildSlotsPageSlotGraph(pageData *models.SlotsPageData, slotData *models.Sl
 			}
 		}
 	}
-	if forkGraphIdx == -1 && slotData.Status > 0 {
+	if forkGraphIdx == -1 && !slotData.Status.IsMissed() {
 		// fork head
 		hasHead := false
 		hasForks := false
@@ -1,12 +1,15 @@
 package handlers
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/pk910/dora/dbtypes"
 	"github.com/pk910/dora/services"
 	"github.com/pk910/dora/templates"
@@ -35,49 +38,158 @@ func SlotsFiltered(w http.ResponseWriter, r *http.Request) {
 		pageIdx, _ = strconv.ParseUint(urlArgs.Get("s"), 10, 64)
 	}
 
-	var graffiti string
-	var proposer string
-	var pname string
-	var withOrphaned uint64
-	var withMissing uint64
+	filterArgs := parseSlotsFilterArgs(urlArgs)
+	var pageError error
+	data.Data, pageError = getFilteredSlotsPageData(pageIdx, pageSize, filterArgs)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "slots_filtered.go", "SlotsFiltered", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
 
-	if urlArgs.Has("f") {
-		if urlArgs.Has("f.graffiti") {
-			graffiti = urlArgs.Get("f.graffiti")
-		}
-		if urlArgs.Has("f.proposer") {
-			proposer = urlArgs.Get("f.proposer")
-		}
-		if urlArgs.Has("f.pname") {
-			pname = urlArgs.Get("f.pname")
-		}
-		if urlArgs.Has("f.orphaned") {
-			withOrphaned, _ = strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 64)
-		}
-		if urlArgs.Has("f.missing") {
-			withMissing, _ = strconv.ParseUint(urlArgs.Get("f.missing"), 10, 64)
-		}
-	} else {
-		withOrphaned = 1
-		withMissing = 1
+// SlotsGraffiti will return the slots with a graffiti starting with the query, using the filtered "slots" page template
+func SlotsGraffiti(w http.ResponseWriter, r *http.Request) {
+	var slotsTemplateFiles = append(layoutTemplateFiles,
+		"slots_filtered/slots_filtered.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(slotsTemplateFiles...)
+	query := mux.Vars(r)["query"]
+	data := InitPageData(w, r, "blockchain", "/slots/filtered", fmt.Sprintf("Graffiti: %v", query), slotsTemplateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 0
+	if urlArgs.Has("s") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("s"), 10, 64)
+	}
+
+	filterArgs := &slotsFilterArgs{
+		graffiti:     query,
+		graffitiPfx:  true,
+		withOrphaned: 1,
 	}
 	var pageError error
-	data.Data, pageError = getFilteredSlotsPageData(pageIdx, pageSize, graffiti, proposer, pname, uint8(withOrphaned), uint8(withMissing))
+	data.Data, pageError = getFilteredSlotsPageData(pageIdx, pageSize, filterArgs)
 	if pageError != nil {
 		handlePageError(w, r, pageError)
 		return
 	}
 	w.Header().Set("Content-Type", "text/html")
-	if handleTemplateError(w, r, "slots_filtered.go", "SlotsFiltered", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+	if handleTemplateError(w, r, "slots_filtered.go", "SlotsGraffiti", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
 		return // an error has occurred and was processed
 	}
 }
 
-func getFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string, proposer string, pname string, withOrphaned uint8, withMissing uint8) (*models.SlotsFilteredPageData, error) {
+// slotsFilterArgs holds the slot filter arguments shared by the filtered slots page & the slots api
+type slotsFilterArgs struct {
+	graffiti     string
+	graffitiRe   bool
+	graffitiPfx  bool
+	proposer     string
+	pname        string
+	withOrphaned uint8
+	withMissing  uint8
+	minSlot      string
+	maxSlot      string
+}
+
+func parseSlotsFilterArgs(urlArgs url.Values) *slotsFilterArgs {
+	filterArgs := &slotsFilterArgs{}
+	if urlArgs.Has("f") {
+		filterArgs.graffiti = urlArgs.Get("f.graffiti")
+		filterArgs.graffitiRe = urlArgs.Get("f.gregex") == "1"
+		filterArgs.graffitiPfx = urlArgs.Get("f.gprefix") == "1"
+		filterArgs.proposer = urlArgs.Get("f.proposer")
+		filterArgs.pname = urlArgs.Get("f.pname")
+		filterArgs.minSlot = urlArgs.Get("f.minslot")
+		filterArgs.maxSlot = urlArgs.Get("f.maxslot")
+		if urlArgs.Has("f.orphaned") {
+			withOrphaned, _ := strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 8)
+			filterArgs.withOrphaned = uint8(withOrphaned)
+		}
+		if urlArgs.Has("f.missing") {
+			withMissing, _ := strconv.ParseUint(urlArgs.Get("f.missing"), 10, 8)
+			filterArgs.withMissing = uint8(withMissing)
+		}
+	} else {
+		filterArgs.withOrphaned = 1
+		filterArgs.withMissing = 1
+	}
+	return filterArgs
+}
+
+func (fa *slotsFilterArgs) cacheKey() string {
+	return fmt.Sprintf("%v:%v:%v:%v:%v:%v:%v:%v:%v", fa.graffiti, fa.graffitiRe, fa.graffitiPfx, fa.proposer, fa.pname, fa.withOrphaned, fa.withMissing, fa.minSlot, fa.maxSlot)
+}
+
+func (fa *slotsFilterArgs) urlValues() url.Values {
+	filterArgs := url.Values{}
+	if fa.graffiti != "" {
+		filterArgs.Add("f.graffiti", fa.graffiti)
+	}
+	if fa.graffitiRe {
+		filterArgs.Add("f.gregex", "1")
+	}
+	if fa.graffitiPfx {
+		filterArgs.Add("f.gprefix", "1")
+	}
+	if fa.proposer != "" {
+		filterArgs.Add("f.proposer", fa.proposer)
+	}
+	if fa.pname != "" {
+		filterArgs.Add("f.pname", fa.pname)
+	}
+	if fa.withOrphaned != 0 {
+		filterArgs.Add("f.orphaned", fmt.Sprintf("%v", fa.withOrphaned))
+	}
+	if fa.withMissing != 0 {
+		filterArgs.Add("f.missing", fmt.Sprintf("%v", fa.withMissing))
+	}
+	if fa.minSlot != "" {
+		filterArgs.Add("f.minslot", fa.minSlot)
+	}
+	if fa.maxSlot != "" {
+		filterArgs.Add("f.maxslot", fa.maxSlot)
+	}
+	return filterArgs
+}
+
+func (fa *slotsFilterArgs) blockFilter() *dbtypes.BlockFilter {
+	blockFilter := &dbtypes.BlockFilter{
+		Graffiti:       fa.graffiti,
+		GraffitiRegex:  fa.graffitiRe,
+		GraffitiPrefix: fa.graffitiPfx && !fa.graffitiRe,
+		ProposerName:   fa.pname,
+		WithOrphaned:   fa.withOrphaned,
+		WithMissing:    fa.withMissing,
+	}
+	if fa.proposer != "" {
+		pidx, _ := strconv.ParseUint(fa.proposer, 10, 64)
+		blockFilter.ProposerIndex = &pidx
+	}
+	if minSlot, err := strconv.ParseUint(fa.minSlot, 10, 64); err == nil {
+		blockFilter.MinSlot = &minSlot
+	}
+	if maxSlot, err := strconv.ParseUint(fa.maxSlot, 10, 64); err == nil {
+		blockFilter.MaxSlot = &maxSlot
+	}
+	return blockFilter
+}
+
+func getFilteredSlotsPageData(pageIdx uint64, pageSize uint64, filterArgs *slotsFilterArgs) (*models.SlotsFilteredPageData, error) {
 	pageData := &models.SlotsFilteredPageData{}
-	pageCacheKey := fmt.Sprintf("slots_filtered:%v:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, graffiti, proposer, pname, withOrphaned, withMissing)
+	pageCacheKey := fmt.Sprintf("slots_filtered:%v:%v:%v", pageIdx, pageSize, filterArgs.cacheKey())
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
-		return buildFilteredSlotsPageData(pageIdx, pageSize, graffiti, proposer, pname, withOrphaned, withMissing)
+		return buildFilteredSlotsPageData(pageIdx, pageSize, filterArgs)
 	})
 	if pageErr == nil && pageRes != nil {
 		resData, resOk := pageRes.(*models.SlotsFilteredPageData)
@@ -89,35 +201,27 @@ func getFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string,
 	return pageData, pageErr
 }
 
-func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string, proposer string, pname string, withOrphaned uint8, withMissing uint8) *models.SlotsFilteredPageData {
-	filterArgs := url.Values{}
-	if graffiti != "" {
-		filterArgs.Add("f.graffiti", graffiti)
-	}
-	if proposer != "" {
-		filterArgs.Add("f.proposer", proposer)
-	}
-	if pname != "" {
-		filterArgs.Add("f.pname", pname)
-	}
-	if withOrphaned != 0 {
-		filterArgs.Add("f.orphaned", fmt.Sprintf("%v", withOrphaned))
-	}
-	if withMissing != 0 {
-		filterArgs.Add("f.missing", fmt.Sprintf("%v", withMissing))
-	}
-
+func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, filterArgs *slotsFilterArgs) *models.SlotsFilteredPageData {
 	pageData := &models.SlotsFilteredPageData{
-		FilterGraffiti:     graffiti,
-		FilterProposer:     proposer,
-		FilterProposerName: pname,
-		FilterWithOrphaned: withOrphaned,
-		FilterWithMissing:  withMissing,
+		FilterGraffiti:     filterArgs.graffiti,
+		FilterGraffitiRe:   filterArgs.graffitiRe,
+		FilterGraffitiPfx:  filterArgs.graffitiPfx,
+		FilterProposer:     filterArgs.proposer,
+		FilterProposerName: filterArgs.pname,
+		FilterWithOrphaned: filterArgs.withOrphaned,
+		FilterWithMissing:  filterArgs.withMissing,
+		FilterMinSlot:      filterArgs.minSlot,
+		FilterMaxSlot:      filterArgs.maxSlot,
 	}
-	logrus.Debugf("slots_filtered page called: %v:%v [%v]", pageIdx, pageSize, graffiti)
+	logrus.Debugf("slots_filtered page called: %v:%v [%v]", pageIdx, pageSize, filterArgs.graffiti)
 	if pageIdx == 0 {
 		pageData.IsDefaultPage = true
 	}
+	if filterArgs.graffitiRe {
+		if _, err := regexp.Compile(filterArgs.graffiti); err != nil {
+			pageData.FilterGraffitiError = fmt.Sprintf("invalid graffiti regex: %v", err)
+		}
+	}
 
 	if pageSize > 100 {
 		pageSize = 100
@@ -137,57 +241,14 @@ func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string
 
 	// load slots
 	pageData.Slots = make([]*models.SlotsFilteredPageDataSlot, 0)
-	blockFilter := &dbtypes.BlockFilter{
-		Graffiti:     graffiti,
-		ProposerName: pname,
-		WithOrphaned: withOrphaned,
-		WithMissing:  withMissing,
-	}
-	if proposer != "" {
-		pidx, _ := strconv.ParseUint(proposer, 10, 64)
-		blockFilter.ProposerIndex = &pidx
-	}
-
-	dbBlocks := services.GlobalBeaconService.GetDbBlocksByFilter(blockFilter, pageIdx, uint32(pageSize))
+	dbBlocks := services.GlobalBeaconService.GetDbBlocksByFilter(filterArgs.blockFilter(), pageIdx, uint32(pageSize))
 	haveMore := false
 	for idx, dbBlock := range dbBlocks {
 		if idx >= int(pageSize) {
 			haveMore = true
 			break
 		}
-		slot := dbBlock.Slot
-
-		slotData := &models.SlotsFilteredPageDataSlot{
-			Slot:         slot,
-			Epoch:        utils.EpochOfSlot(slot),
-			Ts:           utils.SlotToTime(slot),
-			Finalized:    finalizedEpoch >= int64(utils.EpochOfSlot(slot)),
-			Synchronized: true,
-			Scheduled:    slot >= currentSlot,
-			Proposer:     dbBlock.Proposer,
-			ProposerName: services.GlobalBeaconService.GetValidatorName(dbBlock.Proposer),
-		}
-
-		if dbBlock.Block != nil {
-			slotData.Status = 1
-			if dbBlock.Block.Orphaned == 1 {
-				slotData.Status = 2
-			}
-			slotData.AttestationCount = dbBlock.Block.AttestationCount
-			slotData.DepositCount = dbBlock.Block.DepositCount
-			slotData.ExitCount = dbBlock.Block.ExitCount
-			slotData.ProposerSlashingCount = dbBlock.Block.ProposerSlashingCount
-			slotData.AttesterSlashingCount = dbBlock.Block.AttesterSlashingCount
-			slotData.SyncParticipation = float64(dbBlock.Block.SyncParticipation) * 100
-			slotData.EthTransactionCount = dbBlock.Block.EthTransactionCount
-			slotData.Graffiti = dbBlock.Block.Graffiti
-			slotData.BlockRoot = dbBlock.Block.Root
-			if dbBlock.Block.EthBlockNumber != nil {
-				slotData.WithEthBlock = true
-				slotData.EthBlockNumber = *dbBlock.Block.EthBlockNumber
-			}
-		}
-		pageData.Slots = append(pageData.Slots, slotData)
+		pageData.Slots = append(pageData.Slots, buildFilteredSlotData(dbBlock, finalizedEpoch, currentSlot))
 	}
 	pageData.SlotCount = uint64(len(pageData.Slots))
 	if pageData.SlotCount > 0 {
@@ -200,10 +261,160 @@ func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string
 		pageData.TotalPages++
 	}
 
-	pageData.FirstPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v", filterArgs.Encode(), pageData.PageSize)
-	pageData.PrevPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v&s=%v", filterArgs.Encode(), pageData.PageSize, pageData.PrevPageSlot)
-	pageData.NextPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v&s=%v", filterArgs.Encode(), pageData.PageSize, pageData.NextPageSlot)
-	pageData.LastPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v&s=%v", filterArgs.Encode(), pageData.PageSize, pageData.LastPageSlot)
+	filterQuery := filterArgs.urlValues().Encode()
+	pageData.FirstPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v", filterQuery, pageData.PageSize)
+	pageData.PrevPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v&s=%v", filterQuery, pageData.PageSize, pageData.PrevPageSlot)
+	pageData.NextPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v&s=%v", filterQuery, pageData.PageSize, pageData.NextPageSlot)
+	pageData.LastPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v&s=%v", filterQuery, pageData.PageSize, pageData.LastPageSlot)
 
 	return pageData
 }
+
+func buildFilteredSlotData(dbBlock *dbtypes.AssignedBlock, finalizedEpoch int64, currentSlot uint64) *models.SlotsFilteredPageDataSlot {
+	slot := dbBlock.Slot
+
+	slotData := &models.SlotsFilteredPageDataSlot{
+		Slot:         slot,
+		Epoch:        utils.EpochOfSlot(slot),
+		Ts:           utils.SlotToTime(slot),
+		Finalized:    finalizedEpoch >= int64(utils.EpochOfSlot(slot)),
+		Synchronized: true,
+		Scheduled:    slot >= currentSlot,
+		Proposer:     dbBlock.Proposer,
+		ProposerName: services.GlobalBeaconService.GetValidatorName(dbBlock.Proposer),
+	}
+
+	if dbBlock.Block != nil {
+		slotData.Status = models.NewSlotStatus(dbBlock.Block.Orphaned)
+		slotData.AttestationCount = dbBlock.Block.AttestationCount
+		slotData.DepositCount = dbBlock.Block.DepositCount
+		slotData.ExitCount = dbBlock.Block.ExitCount
+		slotData.ProposerSlashingCount = dbBlock.Block.ProposerSlashingCount
+		slotData.AttesterSlashingCount = dbBlock.Block.AttesterSlashingCount
+		slotData.SyncParticipation = float64(dbBlock.Block.SyncParticipation) * 100
+		slotData.EthTransactionCount = dbBlock.Block.EthTransactionCount
+		slotData.Graffiti = dbBlock.Block.Graffiti
+		slotData.BlockRoot = dbBlock.Block.Root
+		if dbBlock.Block.EthBlockNumber != nil {
+			slotData.WithEthBlock = true
+			slotData.EthBlockNumber = *dbBlock.Block.EthBlockNumber
+		}
+	}
+	return slotData
+}
+
+// SlotsFilteredData serves the filtered slots as json api, using the same filters as the filtered slots page:
+//
+//	f.graffiti, f.proposer, f.pname  - graffiti / proposer index / proposer name filter
+//	f.gregex                         - 1: match f.graffiti as case insensitive regular expression
+//	f.gprefix                        - 1: match f.graffiti as case insensitive prefix (indexed, fast on large dbs)
+//	f.orphaned, f.missing            - 0: hide (default), 1: include, 2: only orphaned / missing slots
+//	f.minslot, f.maxslot             - slot range (inclusive)
+//	limit                            - max number of slots to return (default 50, max 100)
+//	cursor                           - next_cursor of the previous response
+//
+// Slots are returned in descending order. The cursor is the last returned slot, so pages stay stable while
+// new blocks are added. Blocks of the same slot are never split across pages, so a page may be shorter than limit,
+// or longer if a single slot has more blocks than limit.
+func SlotsFilteredData(w http.ResponseWriter, r *http.Request) {
+	urlArgs := r.URL.Query()
+	urlArgs.Set("f", "")
+	filterArgs := parseSlotsFilterArgs(urlArgs)
+
+	var limit uint64 = 50
+	if urlArgs.Has("limit") {
+		limit, _ = strconv.ParseUint(urlArgs.Get("limit"), 10, 64)
+	}
+	if limit == 0 || limit > 100 {
+		limit = 100
+	}
+	var cursor uint64
+	if urlArgs.Has("cursor") {
+		var err error
+		cursor, err = strconv.ParseUint(urlArgs.Get("cursor"), 10, 64)
+		if err != nil || cursor == 0 {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+	}
+
+	pageData := &models.SlotsFilteredApiData{}
+	pageCacheKey := fmt.Sprintf("slots_filtered_api:%v:%v:%v", limit, cursor, filterArgs.cacheKey())
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildFilteredSlotsApiData(limit, cursor, filterArgs)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.SlotsFilteredApiData)
+		if !resOk {
+			pageErr = InvalidPageModelError
+		}
+		pageData = resData
+	}
+	if pageErr != nil {
+		handlePageError(w, r, pageErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding filtered slots data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+// slotsFilteredMaxSlotBlocks limits the blocks of a single slot returned by the filtered slots api
+const slotsFilteredMaxSlotBlocks = 1000
+
+// getFilteredSlotsPageLength returns the number of blocks of the page, so the blocks of the slot at the limit are
+// moved to the next page. Returns 0 if all blocks belong to the same slot.
+func getFilteredSlotsPageLength(dbBlocks []*dbtypes.AssignedBlock, limit uint64) int {
+	if uint64(len(dbBlocks)) <= limit {
+		return len(dbBlocks)
+	}
+	splitSlot := dbBlocks[limit].Slot
+	resLen := int(limit)
+	for resLen > 0 && dbBlocks[resLen-1].Slot == splitSlot {
+		resLen--
+	}
+	return resLen
+}
+
+func buildFilteredSlotsApiData(limit uint64, cursor uint64, filterArgs *slotsFilterArgs) *models.SlotsFilteredApiData {
+	blockFilter := filterArgs.blockFilter()
+	if cursor > 0 {
+		maxSlot := cursor - 1
+		if blockFilter.MaxSlot == nil || *blockFilter.MaxSlot > maxSlot {
+			blockFilter.MaxSlot = &maxSlot
+		}
+	}
+
+	dbBlocks := services.GlobalBeaconService.GetDbBlocksByFilter(blockFilter, 0, uint32(limit))
+	haveMore := uint64(len(dbBlocks)) > limit
+	if haveMore {
+		if resLen := getFilteredSlotsPageLength(dbBlocks, limit); resLen > 0 {
+			dbBlocks = dbBlocks[:resLen]
+		} else {
+			// all blocks belong to the same slot, the page holds the whole slot as the cursor can't point into a slot
+			slot := dbBlocks[0].Slot
+			slotFilter := *blockFilter
+			slotFilter.MinSlot = &slot
+			slotFilter.MaxSlot = &slot
+			dbBlocks = services.GlobalBeaconService.GetDbBlocksByFilter(&slotFilter, 0, slotsFilteredMaxSlotBlocks)
+		}
+	}
+
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	currentSlot := utils.TimeToSlot(uint64(time.Now().Unix()))
+	apiData := &models.SlotsFilteredApiData{
+		Slots: make([]*models.SlotsFilteredPageDataSlot, 0, len(dbBlocks)),
+	}
+	for _, dbBlock := range dbBlocks {
+		apiData.Slots = append(apiData.Slots, buildFilteredSlotData(dbBlock, finalizedEpoch, currentSlot))
+	}
+	apiData.Count = uint64(len(apiData.Slots))
+	if haveMore && apiData.Count > 0 {
+		apiData.NextCursor = fmt.Sprintf("%v", apiData.Slots[apiData.Count-1].Slot)
+	}
+	return apiData
+}
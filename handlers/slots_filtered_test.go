@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/pk910/dora/dbtypes"
+)
+
+func TestGetFilteredSlotsPageLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		slots    []uint64
+		limit    uint64
+		expected int
+	}{
+		{
+			name:     "less blocks than limit",
+			slots:    []uint64{10, 9},
+			limit:    3,
+			expected: 2,
+		},
+		{
+			name:     "distinct slots",
+			slots:    []uint64{10, 9, 8, 7},
+			limit:    3,
+			expected: 3,
+		},
+		{
+			name:     "slot at the limit moved to next page",
+			slots:    []uint64{10, 9, 8, 8},
+			limit:    3,
+			expected: 2,
+		},
+		{
+			name:     "multiple blocks of the slot at the limit",
+			slots:    []uint64{10, 9, 9, 9},
+			limit:    3,
+			expected: 1,
+		},
+		{
+			name:     "all blocks of the same slot",
+			slots:    []uint64{10, 10},
+			limit:    1,
+			expected: 0,
+		},
+	}
+	for _, test := range tests {
+		dbBlocks := make([]*dbtypes.AssignedBlock, len(test.slots))
+		for idx, slot := range test.slots {
+			dbBlocks[idx] = &dbtypes.AssignedBlock{Slot: slot}
+		}
+		resLen := getFilteredSlotsPageLength(dbBlocks, test.limit)
+		if resLen != test.expected {
+			t.Errorf("%v: expected page length %v, got %v", test.name, test.expected, resLen)
+			continue
+		}
+		// the next page starts below the last slot of this page, so no block of the page slots may be left over
+		for _, dbBlock := range dbBlocks[resLen:] {
+			if resLen > 0 && dbBlock.Slot >= dbBlocks[resLen-1].Slot {
+				t.Errorf("%v: block of slot %v skipped by the cursor", test.name, dbBlock.Slot)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// SlotsPendingData will return the live state of the current in-progress slot as json.
+// The slots page polls it to update the pending slot row.
+func SlotsPendingData(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	currentSlot := utils.TimeToSlot(uint64(now.Unix()))
+
+	pageData := &models.SlotsPendingData{}
+	pageCacheKey := fmt.Sprintf("slots_pending:%v", currentSlot)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageCall.CacheTimeout = 1 * time.Second
+		return buildSlotsPendingData(currentSlot, now)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.SlotsPendingData)
+		if !resOk {
+			pageErr = InvalidPageModelError
+		} else {
+			pageData = resData
+		}
+	}
+	if pageErr != nil {
+		handlePageError(w, r, pageErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding pending slot data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+// buildSlotsPendingData returns the proposer & block state of the in-progress slot.
+// A slot without block is "awaiting" until it is over, the page marks it as missed when the countdown ends.
+func buildSlotsPendingData(slot uint64, now time.Time) *models.SlotsPendingData {
+	slotTime := utils.SlotToTime(slot)
+	pendingData := &models.SlotsPendingData{
+		Slot:   slot,
+		Epoch:  utils.EpochOfSlot(slot),
+		Ts:     slotTime,
+		EndTs:  slotTime.Add(time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second),
+		Status: "awaiting",
+	}
+	if !now.Before(pendingData.EndTs) {
+		pendingData.Status = "missed"
+	}
+
+	proposers, _ := services.GlobalBeaconService.GetProposerAssignments(pendingData.Epoch, pendingData.Epoch)
+	if proposer, found := proposers[slot]; found && proposer != math.MaxInt64 {
+		pendingData.HasProposer = true
+		pendingData.Proposer = proposer
+		pendingData.ProposerName = services.GlobalBeaconService.GetValidatorName(proposer)
+	}
+
+	for _, block := range services.GlobalBeaconService.GetIndexer().GetCachedBlocks(slot) {
+		pendingData.Status = "seen"
+		pendingData.BlockRoot = fmt.Sprintf("0x%x", block.Root)
+		if arrivals := block.GetArrivals(); len(arrivals) > 0 {
+			pendingData.SeenDelay = arrivals[0].Time.Sub(slotTime).Seconds()
+		}
+		break
+	}
+	return pendingData
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
+	"github.com/pk910/dora/db"
 	"github.com/pk910/dora/dbtypes"
 	"github.com/pk910/dora/services"
 	"github.com/pk910/dora/templates"
@@ -26,6 +28,7 @@ func Validator(w http.ResponseWriter, r *http.Request) {
 	var validatorTemplateFiles = append(layoutTemplateFiles,
 		"validator/validator.html",
 		"validator/recentBlocks.html",
+		"validator/lifecycle.html",
 		"_svg/timeline.html",
 	)
 	var notfoundTemplateFiles = append(layoutTemplateFiles,
@@ -113,6 +116,7 @@ func buildValidatorPageData(validatorIndex uint64) (*models.ValidatorPageData, t
 		BeaconState:         validator.Status.String(),
 		WithdrawCredentials: validator.Validator.WithdrawalCredentials,
 	}
+	pageData.NameSource, _ = services.GlobalBeaconService.GetValidatorNameSource(validatorIndex)
 	if strings.HasPrefix(validator.Status.String(), "pending") {
 		pageData.State = "Pending"
 	} else if validator.Status == v1.ValidatorStateActiveOngoing {
@@ -160,6 +164,92 @@ func buildValidatorPageData(validatorIndex uint64) (*models.ValidatorPageData, t
 		pageData.WithdrawAddress = validator.Validator.WithdrawalCredentials[12:]
 	}
 
+	// metadata from enrichment sources
+	validatorMetadata := services.GlobalBeaconService.GetValidatorMetadata(validatorIndex)
+	pageData.Metadata = make([]*models.ValidatorPageDataMetadata, 0, len(validatorMetadata))
+	for key, value := range validatorMetadata {
+		pageData.Metadata = append(pageData.Metadata, &models.ValidatorPageDataMetadata{
+			Key:   key,
+			Value: value,
+		})
+	}
+	sort.Slice(pageData.Metadata, func(a, b int) bool {
+		return pageData.Metadata[a].Key < pageData.Metadata[b].Key
+	})
+
+	// vote correctness of all finalized epochs
+	if voteStats := db.GetValidatorVoteStats(validatorIndex); voteStats != nil && voteStats.Duties > 0 {
+		pageData.ShowVoteStats = true
+		pageData.VoteDuties = voteStats.Duties
+		pageData.VoteAttested = voteStats.Attested
+		pageData.VoteFirstEpoch = voteStats.FirstEpoch
+		pageData.VoteLastEpoch = voteStats.LastEpoch
+		pageData.VoteTargetRate = float64(voteStats.CorrectTarget) * 100.0 / float64(voteStats.Duties)
+		pageData.VoteHeadRate = float64(voteStats.CorrectHead) * 100.0 / float64(voteStats.Duties)
+		pageData.VoteWrongRate = float64(voteStats.Attested-voteStats.CorrectTarget) * 100.0 / float64(voteStats.Duties)
+	}
+
+	// proposal, attestation & withdrawal totals from the precomputed summary
+	if summary := db.GetValidatorSummary(validatorIndex); summary != nil {
+		pageData.ShowSummary = true
+		pageData.SummaryEpoch = summary.LastEpoch
+		pageData.ProposedBlocks = summary.ProposedBlocks
+		pageData.MissedBlocks = summary.MissedBlocks
+		if summary.LastAttestationEpoch != nil {
+			pageData.ShowLastAttestation = true
+			pageData.LastAttestationEpoch = *summary.LastAttestationEpoch
+		}
+		pageData.WithdrawalCount = summary.WithdrawalCount
+		pageData.WithdrawnAmount = summary.WithdrawnAmount
+		pageData.ProposerIncome = summary.ProposerIncome
+	}
+
+	// more than one deposit for the key or conflicting votes point to key management mistakes (doppelgangers)
+	pageData.Deposits = make([]*models.ValidatorPageDataDeposit, 0)
+	for _, deposit := range db.GetDepositsByPubkey(pageData.PublicKey) {
+		if len(pageData.Deposits) > 0 && !bytes.Equal(deposit.WithdrawalCredentials, pageData.Deposits[0].WithdrawalCredentials) {
+			pageData.ConflictingDeposits = true
+		}
+		pageData.Deposits = append(pageData.Deposits, &models.ValidatorPageDataDeposit{
+			Slot:                  deposit.Slot,
+			BlockRoot:             deposit.BlockRoot,
+			WithdrawalCredentials: deposit.WithdrawalCredentials,
+			Amount:                deposit.Amount,
+		})
+	}
+	for _, receipt := range db.GetDepositReceiptsByPubkey(pageData.PublicKey) {
+		if len(pageData.Deposits) > 0 && !bytes.Equal(receipt.WithdrawalCredentials, pageData.Deposits[0].WithdrawalCredentials) {
+			pageData.ConflictingDeposits = true
+		}
+		pageData.Deposits = append(pageData.Deposits, &models.ValidatorPageDataDeposit{
+			Slot:                  receipt.Slot,
+			BlockRoot:             receipt.BlockRoot,
+			WithdrawalCredentials: receipt.WithdrawalCredentials,
+			Amount:                receipt.Amount,
+			Receipt:               true,
+		})
+	}
+	pageData.Doppelgangers = make([]*models.ValidatorPageDataDoppelganger, 0)
+	for _, doppelganger := range db.GetValidatorDoppelgangers(validatorIndex, 5) {
+		pageData.Doppelgangers = append(pageData.Doppelgangers, &models.ValidatorPageDataDoppelganger{
+			Epoch:             doppelganger.Epoch,
+			Slot:              doppelganger.Slot,
+			BlockRoot:         doppelganger.BlockRoot,
+			ConflictSlot:      doppelganger.ConflictSlot,
+			ConflictBlockRoot: doppelganger.ConflictBlockRoot,
+		})
+	}
+	// validators sharing the withdrawal address or deposit credentials are likely run by the same operator
+	depositCredentials := make([][]byte, 0, len(pageData.Deposits))
+	for _, deposit := range pageData.Deposits {
+		depositCredentials = append(depositCredentials, deposit.WithdrawalCredentials)
+	}
+	pageData.RelatedCount = getValidatorClusterSize(validator, depositCredentials)
+
+	// lifecycle timeline from the recorded status changes
+	validatorIndexes := []uint64{validatorIndex}
+	pageData.Lifecycle = buildValidatorLifecycleTimeline(validatorIndexes, db.GetValidatorStatusChanges(validatorIndexes), pageData.CurrentEpoch)
+
 	// load latest blocks
 	pageData.RecentBlocks = make([]*models.ValidatorPageDataBlocks, 0)
 	blocksData := services.GlobalBeaconService.GetDbBlocksByFilter(&dbtypes.BlockFilter{
@@ -168,19 +258,14 @@ func buildValidatorPageData(validatorIndex uint64) (*models.ValidatorPageData, t
 		WithMissing:   1,
 	}, 0, 10)
 	for _, blockData := range blocksData {
-		blockStatus := 1
-		if blockData.Block == nil {
-			blockStatus = 0
-		} else if blockData.Block.Orphaned == 1 {
-			blockStatus = 2
-		}
 		blockEntry := models.ValidatorPageDataBlocks{
 			Epoch:  utils.EpochOfSlot(blockData.Slot),
 			Slot:   blockData.Slot,
 			Ts:     utils.SlotToTime(blockData.Slot),
-			Status: uint64(blockStatus),
+			Status: models.SlotStatusMissed,
 		}
 		if blockData.Block != nil {
+			blockEntry.Status = models.NewSlotStatus(blockData.Block.Orphaned)
 			blockEntry.Graffiti = blockData.Block.Graffiti
 			blockEntry.BlockRoot = fmt.Sprintf("0x%x", blockData.Block.Root)
 			if blockData.Block.EthBlockNumber != nil {
@@ -192,5 +277,28 @@ func buildValidatorPageData(validatorIndex uint64) (*models.ValidatorPageData, t
 	}
 	pageData.RecentBlockCount = uint64(len(pageData.RecentBlocks))
 
+	// load consolidation requests with the validator as source or target
+	pageData.Consolidations = make([]*models.ValidatorPageDataConsolidation, 0)
+	for _, request := range db.GetConsolidationRequestsByValidator(validatorIndex, 10) {
+		consolidation := &models.ValidatorPageDataConsolidation{
+			Slot:          request.Slot,
+			BlockRoot:     request.BlockRoot,
+			IsSource:      request.SourceIndex != nil && *request.SourceIndex == validatorIndex,
+			Amount:        request.SourceBalance,
+			TargetBalance: request.TargetBalance,
+		}
+		otherIndex, otherPubkey := request.TargetIndex, request.TargetPubkey
+		if !consolidation.IsSource {
+			otherIndex, otherPubkey = request.SourceIndex, request.SourcePubkey
+		}
+		consolidation.OtherPubkey = otherPubkey
+		if otherIndex != nil {
+			consolidation.HasOther = true
+			consolidation.OtherIndex = *otherIndex
+			consolidation.OtherName = services.GlobalBeaconService.GetValidatorName(*otherIndex)
+		}
+		pageData.Consolidations = append(pageData.Consolidations, consolidation)
+	}
+
 	return pageData, 10 * time.Minute
 }
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/utils"
+)
+
+type validatorActivityRequest struct {
+	Action     string   `json:"action"`
+	Validators []uint64 `json:"validators"`
+}
+
+type validatorActivityResponse struct {
+	Type       string                            `json:"type"`
+	Validators int                               `json:"validators,omitempty"`
+	Error      string                            `json:"error,omitempty"`
+	Events     []*indexer.ValidatorActivityEvent `json:"events,omitempty"`
+}
+
+// ValidatorActivityWs serves the validator activity websocket api.
+// Clients send {"action": "subscribe", "validators": [...]} messages and receive the activity events of
+// the subscribed validators for each finalized epoch the indexer processes.
+func ValidatorActivityWs(w http.ResponseWriter, r *http.Request) {
+	if !checkApiToken(r, utils.Config.Frontend.ActivityApiTokens) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	server := websocket.Server{
+		Handler: serveValidatorActivity,
+	}
+	server.ServeHTTP(w, r)
+}
+
+func serveValidatorActivity(ws *websocket.Conn) {
+	defer ws.Close()
+
+	// the connection outlives the http server timeouts
+	ws.SetDeadline(time.Time{})
+
+	subscription := services.GlobalBeaconService.GetIndexer().SubscribeActivity()
+	defer subscription.Unsubscribe()
+
+	sendMutex := sync.Mutex{}
+	send := func(response *validatorActivityResponse) error {
+		sendMutex.Lock()
+		defer sendMutex.Unlock()
+		ws.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		return websocket.JSON.Send(ws, response)
+	}
+
+	closeChan := make(chan bool)
+	go func() {
+		defer utils.HandleSubroutinePanic("serveValidatorActivity")
+		defer close(closeChan)
+		for {
+			request := validatorActivityRequest{}
+			if err := websocket.JSON.Receive(ws, &request); err != nil {
+				return
+			}
+
+			var response *validatorActivityResponse
+			switch request.Action {
+			case "subscribe", "unsubscribe":
+				subscribe := request.Action == "subscribe"
+				updated := subscription.SetValidators(request.Validators, subscribe, int(utils.Config.Frontend.ActivityApiMaxValidators))
+				response = &validatorActivityResponse{
+					Type:       "subscribed",
+					Validators: subscription.GetValidatorCount(),
+				}
+				if !updated {
+					response.Error = "validator limit exceeded"
+				}
+			default:
+				response = &validatorActivityResponse{
+					Type:  "error",
+					Error: "unknown action",
+				}
+			}
+			if err := send(response); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closeChan:
+			return
+		case events := <-subscription.Events:
+			err := send(&validatorActivityResponse{
+				Type:   "events",
+				Events: events,
+			})
+			if err != nil {
+				logrus.Debugf("validator activity websocket closed: %v", err)
+				return
+			}
+		}
+	}
+}
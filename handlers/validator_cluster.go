@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+)
+
+// maximum number of deposit pubkeys loaded per cluster, big staking pools share a withdrawal address for a lot of validators
+const validatorClusterMaxDeposits = 100000
+
+// validatorClusterMember is a validator that is related to the clustered validator
+type validatorClusterMember struct {
+	validator         *v1.Validator
+	sharedCredentials bool
+	sharedDeposit     bool
+}
+
+// ValidatorCluster will return the validators related to a validator by withdrawal address or deposit credentials using a go template
+func ValidatorCluster(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"validator_cluster/validator_cluster.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	vars := mux.Vars(r)
+	validatorIndex, _ := strconv.ParseUint(vars["index"], 10, 64)
+	data := InitPageData(w, r, "validators", fmt.Sprintf("/validator/%v/cluster", validatorIndex), "Related Validators", pageTemplateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+	}
+
+	var pageError error
+	data.Data, pageError = getValidatorClusterPageData(validatorIndex, pageIdx, pageSize)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validator_cluster.go", "ValidatorCluster", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getValidatorClusterPageData(validatorIndex uint64, pageIdx uint64, pageSize uint64) (*models.ValidatorClusterPageData, error) {
+	pageData := &models.ValidatorClusterPageData{}
+	pageCacheKey := fmt.Sprintf("validator_cluster:%v:%v:%v", validatorIndex, pageIdx, pageSize)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout, err := buildValidatorClusterPageData(validatorIndex, pageIdx, pageSize)
+		if err != nil {
+			return err
+		}
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorClusterPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorClusterPageData(validatorIndex uint64, pageIdx uint64, pageSize uint64) (*models.ValidatorClusterPageData, time.Duration, error) {
+	logrus.Debugf("validator cluster page called: %v:%v:%v", validatorIndex, pageIdx, pageSize)
+	validatorSet := services.GlobalBeaconService.GetCachedValidatorSet()
+	validator := validatorSet[phase0.ValidatorIndex(validatorIndex)]
+	if validator == nil {
+		return nil, 0, fmt.Errorf("%w: validator %v", ErrPageNotFound, validatorIndex)
+	}
+
+	if pageSize == 0 {
+		pageSize = 50
+	} else if pageSize > 500 {
+		pageSize = 500
+	}
+	if pageIdx == 0 {
+		pageIdx = 1
+	}
+	pageData := &models.ValidatorClusterPageData{
+		Index:            validatorIndex,
+		Name:             services.GlobalBeaconService.GetValidatorName(validatorIndex),
+		PageSize:         pageSize,
+		CurrentPageIndex: pageIdx,
+	}
+
+	var members []*validatorClusterMember
+	pageData.Keys, members = getValidatorCluster(validator, validatorSet, getValidatorDepositCredentials(validator.Validator.PublicKey[:]))
+	pageData.ValidatorCount = uint64(len(members))
+	for _, member := range members {
+		pageData.TotalBalance += uint64(member.validator.Balance)
+		if strings.HasPrefix(member.validator.Status.String(), "active") {
+			pageData.ActiveCount++
+		}
+	}
+
+	pageData.TotalPages = (pageData.ValidatorCount + pageSize - 1) / pageSize
+	if pageIdx > 1 {
+		pageData.PrevPageIndex = pageIdx - 1
+	}
+	if pageIdx < pageData.TotalPages {
+		pageData.NextPageIndex = pageIdx + 1
+	}
+
+	pageData.Validators = make([]*models.ValidatorClusterPageDataItem, 0, pageSize)
+	for i := (pageIdx - 1) * pageSize; i < pageData.ValidatorCount && i < pageIdx*pageSize; i++ {
+		member := members[i]
+		item := &models.ValidatorClusterPageDataItem{
+			Index:             uint64(member.validator.Index),
+			Name:              services.GlobalBeaconService.GetValidatorName(uint64(member.validator.Index)),
+			PublicKey:         member.validator.Validator.PublicKey[:],
+			Balance:           uint64(member.validator.Balance),
+			EffectiveBalance:  uint64(member.validator.Validator.EffectiveBalance),
+			SharedCredentials: member.sharedCredentials,
+			SharedDeposit:     member.sharedDeposit,
+		}
+		if strings.HasPrefix(member.validator.Status.String(), "pending") {
+			item.State = "Pending"
+		} else if member.validator.Status == v1.ValidatorStateActiveOngoing {
+			item.State = "Active"
+		} else if member.validator.Status == v1.ValidatorStateActiveExiting {
+			item.State = "Exiting"
+		} else if member.validator.Status == v1.ValidatorStateActiveSlashed || member.validator.Status == v1.ValidatorStateExitedSlashed {
+			item.State = "Slashed"
+		} else if member.validator.Status == v1.ValidatorStateExitedUnslashed {
+			item.State = "Exited"
+		} else {
+			item.State = member.validator.Status.String()
+		}
+		pageData.Validators = append(pageData.Validators, item)
+	}
+
+	return pageData, 10 * time.Minute, nil
+}
+
+// getValidatorDepositCredentials returns the distinct withdrawal credentials of the indexed deposits for a pubkey
+func getValidatorDepositCredentials(pubkey []byte) [][]byte {
+	credentials := [][]byte{}
+	addCredentials := func(creds []byte) {
+		for _, known := range credentials {
+			if bytes.Equal(known, creds) {
+				return
+			}
+		}
+		credentials = append(credentials, creds)
+	}
+	for _, deposit := range db.GetDepositsByPubkey(pubkey) {
+		addCredentials(deposit.WithdrawalCredentials)
+	}
+	for _, receipt := range db.GetDepositReceiptsByPubkey(pubkey) {
+		addCredentials(receipt.WithdrawalCredentials)
+	}
+	return credentials
+}
+
+// getValidatorClusterKey returns the key validators are grouped by: the withdrawal address for execution credentials,
+// so 0x01 & 0x02 credentials of the same address match, or the full credentials otherwise.
+func getValidatorClusterKey(credentials []byte) string {
+	if len(credentials) == 32 && (credentials[0] == 0x01 || credentials[0] == 0x02) {
+		return fmt.Sprintf("addr:%x", credentials[12:])
+	}
+	return fmt.Sprintf("creds:%x", credentials)
+}
+
+// getValidatorCluster returns the validators that share the current withdrawal address of the validator or were deposited
+// with the same withdrawal credentials as the validator. The deposit sender is not indexed, so the deposit credentials
+// are used as deposit origin, which also links validators that changed their BLS credentials to different addresses.
+func getValidatorCluster(validator *v1.Validator, validatorSet map[phase0.ValidatorIndex]*v1.Validator, depositCredentials [][]byte) ([]*models.ValidatorClusterPageDataKey, []*validatorClusterMember) {
+	currentCreds := validator.Validator.WithdrawalCredentials
+	currentKey := getValidatorClusterKey(currentCreds)
+	keys := []*models.ValidatorClusterPageDataKey{
+		{
+			Credentials: currentCreds,
+		},
+	}
+	if strings.HasPrefix(currentKey, "addr:") {
+		keys[0].Address = currentCreds[12:]
+	}
+
+	// deposits made with the current or deposit credentials, execution credentials are matched with both prefixes
+	queryCreds := [][]byte{}
+	addQueryCreds := func(creds []byte) {
+		if len(creds) == 32 && (creds[0] == 0x01 || creds[0] == 0x02) {
+			for _, prefix := range []byte{0x01, 0x02} {
+				prefixedCreds := make([]byte, 32)
+				copy(prefixedCreds, creds)
+				prefixedCreds[0] = prefix
+				queryCreds = append(queryCreds, prefixedCreds)
+			}
+		} else {
+			queryCreds = append(queryCreds, creds)
+		}
+	}
+	addQueryCreds(currentCreds)
+	seenKeys := map[string]bool{currentKey: true}
+	for _, creds := range depositCredentials {
+		if seenKeys[getValidatorClusterKey(creds)] {
+			continue
+		}
+		seenKeys[getValidatorClusterKey(creds)] = true
+		key := &models.ValidatorClusterPageDataKey{
+			Credentials: creds,
+			Deposit:     true,
+		}
+		if creds[0] == 0x01 || creds[0] == 0x02 {
+			key.Address = creds[12:]
+		}
+		keys = append(keys, key)
+		addQueryCreds(creds)
+	}
+	depositPubkeys := map[string]bool{}
+	for _, pubkey := range db.GetDepositPubkeysByWithdrawalCredentials(queryCreds, validatorClusterMaxDeposits) {
+		depositPubkeys[string(pubkey)] = true
+	}
+
+	members := []*validatorClusterMember{}
+	for _, val := range validatorSet {
+		if val.Index == validator.Index {
+			continue
+		}
+		member := &validatorClusterMember{
+			validator:         val,
+			sharedCredentials: getValidatorClusterKey(val.Validator.WithdrawalCredentials) == currentKey,
+			sharedDeposit:     depositPubkeys[string(val.Validator.PublicKey[:])],
+		}
+		if member.sharedCredentials || member.sharedDeposit {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(a, b int) bool {
+		return members[a].validator.Index < members[b].validator.Index
+	})
+	return keys, members
+}
+
+// getValidatorClusterSize returns the number of validators related to the validator, for the link on the validator page
+func getValidatorClusterSize(validator *v1.Validator, depositCredentials [][]byte) uint64 {
+	_, members := getValidatorCluster(validator, services.GlobalBeaconService.GetCachedValidatorSet(), depositCredentials)
+	return uint64(len(members))
+}
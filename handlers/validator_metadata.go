@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/services"
+)
+
+type validatorMetadataGroupsResponse struct {
+	Key    string                             `json:"key"`
+	Groups []*services.ValidatorMetadataGroup `json:"groups"`
+}
+
+// ValidatorMetadataGroups returns all validators grouped by their value for a metadata key (eg. entity or region).
+// `?validators=1` includes the validator indexes of each group.
+func ValidatorMetadataGroups(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	withValidators := r.URL.Query().Get("validators") == "1"
+	response := &validatorMetadataGroupsResponse{
+		Key:    vars["key"],
+		Groups: services.GlobalBeaconService.GetValidatorMetadataGroups(vars["key"], withValidators),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding validator metadata groups")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// ValidatorNames will return the name history of a validator using a go template
+func ValidatorNames(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"validator_names/validator_names.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	vars := mux.Vars(r)
+	validatorIndex, _ := strconv.ParseUint(vars["index"], 10, 64)
+	data := InitPageData(w, r, "validators", fmt.Sprintf("/validator/%v/names", validatorIndex), "Validator Name History", pageTemplateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+	}
+
+	var pageError error
+	data.Data, pageError = getValidatorNamesPageData(validatorIndex, pageIdx, pageSize)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validator_names.go", "ValidatorNames", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getValidatorNamesPageData(validatorIndex uint64, pageIdx uint64, pageSize uint64) (*models.ValidatorNamesPageData, error) {
+	pageData := &models.ValidatorNamesPageData{}
+	pageCacheKey := fmt.Sprintf("validator_names:%v:%v:%v", validatorIndex, pageIdx, pageSize)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData := buildValidatorNamesPageData(validatorIndex, pageIdx, pageSize)
+		pageCall.CacheTimeout = 5 * time.Minute
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorNamesPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorNamesPageData(validatorIndex uint64, pageIdx uint64, pageSize uint64) *models.ValidatorNamesPageData {
+	logrus.Debugf("validator names page called: %v:%v:%v", validatorIndex, pageIdx, pageSize)
+	if pageSize == 0 {
+		pageSize = 50
+	} else if pageSize > 100 {
+		pageSize = 100
+	}
+	if pageIdx == 0 {
+		pageIdx = 1
+	}
+	pageData := &models.ValidatorNamesPageData{
+		Index:            validatorIndex,
+		Name:             services.GlobalBeaconService.GetValidatorName(validatorIndex),
+		LightMode:        utils.Config.Indexer.DisableIndexWriter,
+		PageSize:         pageSize,
+		CurrentPageIndex: pageIdx,
+	}
+	pageData.NameSource, pageData.NameSourceName = services.GlobalBeaconService.GetValidatorNameSource(validatorIndex)
+
+	// load one more entry to get the previous name of the oldest change on the page
+	history, historyCount := db.GetValidatorNameHistory(validatorIndex, (pageIdx-1)*pageSize, uint32(pageSize+1))
+	pageData.HistoryCount = historyCount
+	pageData.TotalPages = (historyCount + pageSize - 1) / pageSize
+	if pageIdx > 1 {
+		pageData.PrevPageIndex = pageIdx - 1
+	}
+	if pageIdx < pageData.TotalPages {
+		pageData.NextPageIndex = pageIdx + 1
+	}
+
+	pageData.History = make([]*models.ValidatorNamesPageDataItem, 0, pageSize)
+	for idx, entry := range history {
+		if uint64(idx) >= pageSize {
+			break
+		}
+		item := &models.ValidatorNamesPageDataItem{
+			Changed:    time.Unix(int64(entry.Changed), 0),
+			Name:       entry.Name,
+			Source:     entry.Source,
+			SourceName: entry.SourceName,
+		}
+		if idx+1 < len(history) {
+			item.PreviousName = history[idx+1].Name
+		}
+		pageData.History = append(pageData.History, item)
+	}
+
+	return pageData
+}
@@ -29,7 +29,6 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 	)
 
 	var pageTemplate = templates.GetTemplate(validatorsTemplateFiles...)
-	data := InitPageData(w, r, "validators", "/validators", "Validators", validatorsTemplateFiles)
 
 	urlArgs := r.URL.Query()
 	var firstIdx uint64 = 0
@@ -63,12 +62,19 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 		sortOrder = urlArgs.Get("o")
 	}
 
-	var pageError error
-	data.Data, pageError = getValidatorsPageData(firstIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
-	if pageError != nil {
-		handlePageError(w, r, pageError)
+	if partial := getPagePartial(r, "validators_table"); partial != "" {
+		pageData, pageError := getValidatorsPageData(firstIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+		if pageError != nil {
+			handlePartialError(w, r, pageError)
+			return
+		}
+		handlePagePartial(w, r, "validators.go", "Validators", pageTemplate, partial, pageData)
 		return
 	}
+
+	// the page shell only contains the filter form, the validator table is loaded asynchronously
+	data := InitPageData(w, r, "validators", "/validators", "Validators", validatorsTemplateFiles)
+	data.Data = buildValidatorsShellData(pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus, buildPagePartialLink(r, "validators_table"))
 	w.Header().Set("Content-Type", "text/html")
 	if handleTemplateError(w, r, "validators.go", "Validators", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
 		return // an error has occurred and was processed
@@ -93,6 +99,52 @@ func getValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder string
 	return pageData, pageErr
 }
 
+// buildValidatorsShellData returns the page data for the filter form of the validators page.
+// The validator set is only counted per status here, filtering & sorting is done when the table fragment is loaded.
+func buildValidatorsShellData(pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string, tablePartial string) *models.ValidatorsPageData {
+	pageData := &models.ValidatorsPageData{
+		FilterPubKey: filterPubKey,
+		FilterIndex:  filterIndex,
+		FilterName:   filterName,
+		FilterStatus: filterStatus,
+		Sorting:      sortOrder,
+		PageSize:     pageSize,
+		TablePartial: tablePartial,
+	}
+	if sortOrder == "" || sortOrder == "index" {
+		pageData.Sorting = "index"
+		pageData.IsDefaultSorting = true
+	}
+	if pageSize > 100 {
+		pageData.PageSize = 100
+	}
+
+	validatorSet := []*v1.Validator{}
+	if validatorSetRsp := services.GlobalBeaconService.GetCachedValidatorSet(); validatorSetRsp != nil {
+		validatorSet = maps.Values(validatorSetRsp)
+	}
+	pageData.FilterStatusOpts = buildValidatorsStatusOptions(validatorSet)
+	return pageData
+}
+
+func buildValidatorsStatusOptions(validatorSet []*v1.Validator) []models.ValidatorsPageDataStatusOption {
+	statusMap := map[v1.ValidatorState]uint64{}
+	for _, val := range validatorSet {
+		statusMap[val.Status]++
+	}
+	statusOpts := make([]models.ValidatorsPageDataStatusOption, 0)
+	for status, count := range statusMap {
+		statusOpts = append(statusOpts, models.ValidatorsPageDataStatusOption{
+			Status: status.String(),
+			Count:  count,
+		})
+	}
+	sort.Slice(statusOpts, func(a, b int) bool {
+		return strings.Compare(statusOpts[a].Status, statusOpts[b].Status) < 0
+	})
+	return statusOpts
+}
+
 func buildValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string) (*models.ValidatorsPageData, time.Duration) {
 	logrus.Debugf("validators page called: %v:%v:%v:%v:%v:%v:%v", firstValIdx, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
 	pageData := &models.ValidatorsPageData{}
@@ -108,21 +160,7 @@ func buildValidatorsPageData(firstValIdx uint64, pageSize uint64, sortOrder stri
 		validatorSet = maps.Values(validatorSetRsp)
 	}
 
-	// get status options
-	statusMap := map[v1.ValidatorState]uint64{}
-	for _, val := range validatorSet {
-		statusMap[val.Status]++
-	}
-	pageData.FilterStatusOpts = make([]models.ValidatorsPageDataStatusOption, 0)
-	for status, count := range statusMap {
-		pageData.FilterStatusOpts = append(pageData.FilterStatusOpts, models.ValidatorsPageDataStatusOption{
-			Status: status.String(),
-			Count:  count,
-		})
-	}
-	sort.Slice(pageData.FilterStatusOpts, func(a, b int) bool {
-		return strings.Compare(pageData.FilterStatusOpts[a].Status, pageData.FilterStatusOpts[b].Status) < 0
-	})
+	pageData.FilterStatusOpts = buildValidatorsStatusOptions(validatorSet)
 
 	filterArgs := url.Values{}
 	if filterPubKey != "" || filterIndex != "" || filterName != "" || filterStatus != "" {
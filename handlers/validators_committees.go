@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// maximum number of single committees listed in the report
+const validatorsCommitteesListLimit = 50
+
+// ValidatorsCommittees will return the "problem committees & operators" report page using a go template
+func ValidatorsCommittees(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"validators_committees/validators_committees.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/committees", "Problem Committees", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getValidatorsCommitteesPageData(parseValidatorsCommitteesEpochs(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_committees.go", "ValidatorsCommittees", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// ValidatorsCommitteesData will return the problem committees & operators report as json
+func ValidatorsCommitteesData(w http.ResponseWriter, r *http.Request) {
+	pageData, pageError := getValidatorsCommitteesPageData(parseValidatorsCommitteesEpochs(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding problem committees data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func parseValidatorsCommitteesEpochs(r *http.Request) uint64 {
+	urlArgs := r.URL.Query()
+	var epochCount uint64 = 100
+	if urlArgs.Has("epochs") {
+		epochCount, _ = strconv.ParseUint(urlArgs.Get("epochs"), 10, 64)
+	}
+	if epochCount == 0 {
+		epochCount = 100
+	} else if epochCount > 1000 {
+		epochCount = 1000
+	}
+	return epochCount
+}
+
+func getValidatorsCommitteesPageData(epochCount uint64) (*models.ValidatorsCommitteesPageData, error) {
+	pageData := &models.ValidatorsCommitteesPageData{}
+	pageCacheKey := fmt.Sprintf("validators_committees:%v", epochCount)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsCommitteesPageData(epochCount)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsCommitteesPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorsCommitteesPageData(epochCount uint64) (*models.ValidatorsCommitteesPageData, time.Duration) {
+	logrus.Debugf("validators committees page called: %v", epochCount)
+	pageData := &models.ValidatorsCommitteesPageData{}
+
+	// only finalized epochs are persisted with committee stats
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	lastEpoch := uint64(0)
+	if finalizedEpoch > 0 {
+		lastEpoch = uint64(finalizedEpoch - 1)
+	}
+	firstEpoch := uint64(0)
+	if lastEpoch+1 > epochCount {
+		firstEpoch = lastEpoch + 1 - epochCount
+	}
+	pageData.FirstEpoch = firstEpoch
+	pageData.LastEpoch = lastEpoch
+	pageData.EpochCount = lastEpoch - firstEpoch + 1
+
+	// aggregate the committees with the same index, a committee index that has problems in most epochs
+	// points to a systematic issue rather than a single offline validator
+	committeeStats := db.GetEpochCommitteeStats(firstEpoch, lastEpoch)
+	indexMap := map[uint64]*models.ValidatorsCommitteesPageDataIndex{}
+	indexNameCounts := map[uint64]map[string]uint64{}
+	operatorTopCommittees := map[string]uint64{}
+	for _, stats := range committeeStats {
+		indexData := indexMap[stats.CommitteeIndex]
+		if indexData == nil {
+			indexData = &models.ValidatorsCommitteesPageDataIndex{
+				CommitteeIndex: stats.CommitteeIndex,
+			}
+			indexMap[stats.CommitteeIndex] = indexData
+			indexNameCounts[stats.CommitteeIndex] = map[string]uint64{}
+		}
+		indexData.ProblemEpochs++
+		indexData.Missed += stats.Missed
+		indexData.Late += stats.Late
+		if stats.TopName != nil {
+			indexNameCounts[stats.CommitteeIndex][*stats.TopName] += stats.TopNameCount
+			operatorTopCommittees[*stats.TopName]++
+		}
+	}
+
+	committeeDuties := db.GetEpochCommitteeDuties(firstEpoch, lastEpoch)
+	pageData.CommitteeIndexes = make([]*models.ValidatorsCommitteesPageDataIndex, 0, len(indexMap))
+	for committeeIndex, indexData := range indexMap {
+		indexData.Duties = committeeDuties[committeeIndex]
+		if indexData.Duties > 0 {
+			indexData.MissedRate = float64(indexData.Missed) * 100.0 / float64(indexData.Duties)
+			indexData.LateRate = float64(indexData.Late) * 100.0 / float64(indexData.Duties)
+		}
+		for name, count := range indexNameCounts[committeeIndex] {
+			if count > indexData.TopNameCount || (count == indexData.TopNameCount && name < indexData.TopName) {
+				indexData.TopName = name
+				indexData.TopNameCount = count
+			}
+		}
+		pageData.CommitteeIndexes = append(pageData.CommitteeIndexes, indexData)
+	}
+	sort.Slice(pageData.CommitteeIndexes, func(a, b int) bool {
+		rateA := pageData.CommitteeIndexes[a].MissedRate + pageData.CommitteeIndexes[a].LateRate
+		rateB := pageData.CommitteeIndexes[b].MissedRate + pageData.CommitteeIndexes[b].LateRate
+		if rateA != rateB {
+			return rateA > rateB
+		}
+		return pageData.CommitteeIndexes[a].CommitteeIndex < pageData.CommitteeIndexes[b].CommitteeIndex
+	})
+
+	// operators with missing or late votes
+	pageData.Operators = make([]*models.ValidatorsCommitteesPageDataOperator, 0)
+	for _, delays := range db.GetOperatorVoteDelays(firstEpoch, lastEpoch) {
+		if delays.Duties == 0 || (delays.Attested == delays.Duties && delays.Late == 0) {
+			continue
+		}
+		operator := &models.ValidatorsCommitteesPageDataOperator{
+			Name:          delays.Name,
+			Duties:        delays.Duties,
+			Missed:        delays.Duties - delays.Attested,
+			Late:          delays.Late,
+			TopCommittees: operatorTopCommittees[delays.Name],
+		}
+		operator.MissedRate = float64(operator.Missed) * 100.0 / float64(operator.Duties)
+		operator.LateRate = float64(operator.Late) * 100.0 / float64(operator.Duties)
+		pageData.Operators = append(pageData.Operators, operator)
+	}
+	sort.Slice(pageData.Operators, func(a, b int) bool {
+		rateA := pageData.Operators[a].MissedRate + pageData.Operators[a].LateRate
+		rateB := pageData.Operators[b].MissedRate + pageData.Operators[b].LateRate
+		if rateA != rateB {
+			return rateA > rateB
+		}
+		return pageData.Operators[a].Name < pageData.Operators[b].Name
+	})
+
+	// single committees with the most missing or late votes
+	sort.SliceStable(committeeStats, func(a, b int) bool {
+		return committeeStats[a].Missed+committeeStats[a].Late > committeeStats[b].Missed+committeeStats[b].Late
+	})
+	if len(committeeStats) > validatorsCommitteesListLimit {
+		committeeStats = committeeStats[:validatorsCommitteesListLimit]
+	}
+	pageData.Committees = make([]*models.ValidatorsCommitteesPageDataCommittee, len(committeeStats))
+	for idx, stats := range committeeStats {
+		committee := &models.ValidatorsCommitteesPageDataCommittee{
+			Epoch:          stats.Epoch,
+			Slot:           stats.Slot,
+			CommitteeIndex: stats.CommitteeIndex,
+			Duties:         stats.Duties,
+			Missed:         stats.Missed,
+			Late:           stats.Late,
+			TopNameCount:   stats.TopNameCount,
+		}
+		if stats.TopName != nil {
+			committee.TopName = *stats.TopName
+		}
+		pageData.Committees[idx] = committee
+	}
+
+	return pageData, 10 * time.Minute
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// default MAX_EFFECTIVE_BALANCE_ELECTRA (2048 ETH) for chain configs without electra preset
+const defaultMaxEffectiveBalanceElectra uint64 = 2048000000000
+
+// ValidatorsConsolidations will return the consolidation planning page for a validator name group using a go template
+func ValidatorsConsolidations(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"validators_consolidations/validators_consolidations.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/consolidations", "Consolidations", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getValidatorsConsolidationsPageData(strings.TrimSpace(r.URL.Query().Get("name")))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_consolidations.go", "ValidatorsConsolidations", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// ValidatorsConsolidationsData will return the consolidation plan of a validator name group as json
+func ValidatorsConsolidationsData(w http.ResponseWriter, r *http.Request) {
+	pageData, pageError := getValidatorsConsolidationsPageData(strings.TrimSpace(r.URL.Query().Get("name")))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding consolidations data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func getValidatorsConsolidationsPageData(name string) (*models.ValidatorsConsolidationsPageData, error) {
+	pageData := &models.ValidatorsConsolidationsPageData{}
+	pageCacheKey := fmt.Sprintf("validators_consolidations:%v", strings.ToLower(name))
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsConsolidationsPageData(name)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsConsolidationsPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+// buildValidatorsConsolidationsPageData suggests consolidation plans for the validators of a name group.
+// Validators are grouped by withdrawal address and packed in index order into targets up to the electra max
+// effective balance, preferring validators that already have compounding (0x02) credentials as targets.
+// There is no record of submitted consolidation requests, so the progress is derived from the validator set:
+// exiting / exited validators of an address are attributed to its first compounding target.
+func buildValidatorsConsolidationsPageData(name string) (*models.ValidatorsConsolidationsPageData, time.Duration) {
+	logrus.Debugf("validators consolidations page called: %v", name)
+	maxEffectiveBalance := utils.Config.Chain.Config.MaxEffectiveBalanceElectra
+	if maxEffectiveBalance == 0 {
+		maxEffectiveBalance = defaultMaxEffectiveBalanceElectra
+	}
+	pageData := &models.ValidatorsConsolidationsPageData{
+		Name:                name,
+		MaxEffectiveBalance: maxEffectiveBalance,
+		Plans:               []*models.ValidatorsConsolidationsPageDataPlan{},
+	}
+	if name == "" {
+		return pageData, 1 * time.Minute
+	}
+
+	validatorSet := services.GlobalBeaconService.GetCachedValidatorSet()
+	currentEpoch := utils.EpochOfSlot(utils.TimeToSlot(uint64(time.Now().Unix())))
+
+	addressValidators := map[string][]*v1.Validator{}
+	addressList := []string{}
+	for _, index := range services.GlobalBeaconService.GetValidatorIndicesByName(name) {
+		validator := validatorSet[phase0.ValidatorIndex(index)]
+		if validator == nil {
+			continue
+		}
+		pageData.ValidatorCount++
+
+		credentials := validator.Validator.WithdrawalCredentials
+		if (credentials[0] != 0x01 && credentials[0] != 0x02) || validator.Validator.Slashed || strings.HasPrefix(validator.Status.String(), "pending") {
+			// bls credentials, slashed & pending validators can't be consolidated
+			pageData.SkippedCount++
+			continue
+		}
+		address := string(credentials[12:])
+		if addressValidators[address] == nil {
+			addressList = append(addressList, address)
+		}
+		addressValidators[address] = append(addressValidators[address], validator)
+	}
+
+	for _, address := range addressList {
+		validators := addressValidators[address]
+		sort.SliceStable(validators, func(a, b int) bool {
+			aCompounding := validators[a].Validator.WithdrawalCredentials[0] == 0x02
+			bCompounding := validators[b].Validator.WithdrawalCredentials[0] == 0x02
+			if aCompounding != bCompounding {
+				return aCompounding
+			}
+			return validators[a].Index < validators[b].Index
+		})
+
+		addressPlans := []*models.ValidatorsConsolidationsPageDataPlan{}
+		exitedValidators := []*v1.Validator{}
+		var currentPlan *models.ValidatorsConsolidationsPageDataPlan
+		for _, validator := range validators {
+			if validator.Status != v1.ValidatorStateActiveOngoing {
+				exitedValidators = append(exitedValidators, validator)
+				continue
+			}
+
+			balance := uint64(validator.Balance)
+			if currentPlan != nil && currentPlan.ExpectedBalance+balance <= maxEffectiveBalance {
+				source := buildConsolidationValidator(validator)
+				switch {
+				case !currentPlan.Compounding:
+					source.State = "Awaiting target"
+				case uint64(validator.Validator.ActivationEpoch)+utils.Config.Chain.Config.ShardCommitteePeriod > currentEpoch:
+					source.State = "Not eligible yet"
+				default:
+					source.State = "Ready"
+				}
+				currentPlan.Sources = append(currentPlan.Sources, source)
+				currentPlan.ExpectedBalance += balance
+				continue
+			}
+
+			currentPlan = &models.ValidatorsConsolidationsPageDataPlan{
+				WithdrawAddress: []byte(address),
+				Target:          buildConsolidationValidator(validator),
+				Sources:         []*models.ValidatorsConsolidationsPageDataValidator{},
+				ExpectedBalance: balance,
+				Compounding:     validator.Validator.WithdrawalCredentials[0] == 0x02,
+			}
+			if currentPlan.Compounding {
+				currentPlan.Target.State = "Compounding"
+			} else {
+				currentPlan.Target.State = "Switch required"
+			}
+			addressPlans = append(addressPlans, currentPlan)
+		}
+
+		for _, validator := range exitedValidators {
+			var targetPlan *models.ValidatorsConsolidationsPageDataPlan
+			for _, plan := range addressPlans {
+				if plan.Compounding {
+					targetPlan = plan
+					break
+				}
+			}
+			if targetPlan == nil {
+				pageData.SkippedCount++
+				continue
+			}
+			source := buildConsolidationValidator(validator)
+			if validator.Status == v1.ValidatorStateActiveExiting {
+				source.State = "Consolidating"
+				targetPlan.ExpectedBalance += uint64(validator.Balance)
+			} else {
+				source.State = "Consolidated"
+				pageData.ConsolidatedCount++
+			}
+			targetPlan.Sources = append(targetPlan.Sources, source)
+		}
+
+		for _, plan := range addressPlans {
+			if len(plan.Sources) == 0 {
+				continue
+			}
+			plan.Completed = plan.Compounding
+			for _, source := range plan.Sources {
+				if source.State != "Consolidated" {
+					plan.Completed = false
+				}
+			}
+			pageData.TargetCount++
+			pageData.SourceCount += uint64(len(plan.Sources))
+			pageData.Plans = append(pageData.Plans, plan)
+		}
+	}
+
+	return pageData, 1 * time.Minute
+}
+
+func buildConsolidationValidator(validator *v1.Validator) *models.ValidatorsConsolidationsPageDataValidator {
+	return &models.ValidatorsConsolidationsPageDataValidator{
+		Index:   uint64(validator.Index),
+		Name:    services.GlobalBeaconService.GetValidatorName(uint64(validator.Index)),
+		Pubkey:  validator.Validator.PublicKey[:],
+		Balance: uint64(validator.Balance),
+	}
+}
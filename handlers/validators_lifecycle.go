@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// names of the lifecycle states, indexed by dbtypes.ValidatorStatus*
+var validatorLifecycleStatusNames = []string{"deposited", "pending", "active", "exiting", "exited", "withdrawable", "withdrawn"}
+
+const (
+	validatorsLifecycleMaxRows   = 100
+	validatorsLifecycleBatchSize = 1000
+)
+
+// ValidatorsLifecycle will return the lifecycle timeline of all validators with a name using a go template
+func ValidatorsLifecycle(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"validators_lifecycle/validators_lifecycle.html",
+		"validator/lifecycle.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/lifecycle", "Validator Lifecycle", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getValidatorsLifecyclePageData(strings.TrimSpace(r.URL.Query().Get("name")))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_lifecycle.go", "ValidatorsLifecycle", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// ValidatorsLifecycleData will return the lifecycle timeline of a validator name group as json
+func ValidatorsLifecycleData(w http.ResponseWriter, r *http.Request) {
+	pageData, pageError := getValidatorsLifecyclePageData(strings.TrimSpace(r.URL.Query().Get("name")))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding validator lifecycle data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func getValidatorsLifecyclePageData(name string) (*models.ValidatorsLifecyclePageData, error) {
+	pageData := &models.ValidatorsLifecyclePageData{}
+	pageCacheKey := fmt.Sprintf("validators_lifecycle:%v", name)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsLifecyclePageData(name)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsLifecyclePageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorsLifecyclePageData(name string) (*models.ValidatorsLifecyclePageData, time.Duration) {
+	logrus.Debugf("validators lifecycle page called: %v", name)
+	pageData := &models.ValidatorsLifecyclePageData{
+		Name:         name,
+		StatusCounts: []*models.ValidatorsLifecyclePageDataCount{},
+	}
+	if name == "" {
+		return pageData, 10 * time.Minute
+	}
+
+	validatorIndexes := services.GlobalBeaconService.GetValidatorIndicesByName(name)
+	pageData.ValidatorCount = uint64(len(validatorIndexes))
+
+	// load the status changes in batches, the placeholder count per query is limited
+	statusChanges := []*dbtypes.ValidatorStatusChange{}
+	for start := 0; start < len(validatorIndexes); start += validatorsLifecycleBatchSize {
+		end := start + validatorsLifecycleBatchSize
+		if end > len(validatorIndexes) {
+			end = len(validatorIndexes)
+		}
+		statusChanges = append(statusChanges, db.GetValidatorStatusChanges(validatorIndexes[start:end])...)
+	}
+
+	currentEpoch := uint64(utils.TimeToEpoch(time.Now()))
+	timeline := buildValidatorLifecycleTimeline(validatorIndexes, statusChanges, currentEpoch)
+
+	// the aggregate row spans from the first to the last validator entering each state
+	aggregateStages := make([]*models.ValidatorLifecycleStage, len(validatorLifecycleStatusNames))
+	statusCounts := make([]uint64, len(validatorLifecycleStatusNames))
+	for _, row := range timeline.Rows {
+		if len(row.Stages) > 0 {
+			statusCounts[indexOfLifecycleStatus(row.Status)]++
+		}
+		for _, stage := range row.Stages {
+			status := indexOfLifecycleStatus(stage.Status)
+			aggregateStage := aggregateStages[status]
+			if aggregateStage == nil {
+				aggregateStage = &models.ValidatorLifecycleStage{
+					Status:     stage.Status,
+					StartEpoch: stage.StartEpoch,
+					EndEpoch:   stage.StartEpoch,
+				}
+				aggregateStages[status] = aggregateStage
+			}
+			if stage.StartEpoch < aggregateStage.StartEpoch {
+				aggregateStage.StartEpoch = stage.StartEpoch
+			}
+			if stage.StartEpoch > aggregateStage.EndEpoch {
+				aggregateStage.EndEpoch = stage.StartEpoch
+			}
+			aggregateStage.Count++
+		}
+	}
+	for status, count := range statusCounts {
+		if count > 0 {
+			pageData.StatusCounts = append(pageData.StatusCounts, &models.ValidatorsLifecyclePageDataCount{
+				Status: validatorLifecycleStatusNames[status],
+				Count:  count,
+			})
+		}
+	}
+	aggregateRow := &models.ValidatorLifecycleRow{
+		Aggregate: true,
+		Name:      name,
+		Stages:    []*models.ValidatorLifecycleStage{},
+	}
+	pageData.Aggregate = &models.ValidatorLifecycleTimeline{
+		FirstEpoch: timeline.FirstEpoch,
+		LastEpoch:  timeline.LastEpoch,
+		Markers:    timeline.Markers,
+		Rows:       []*models.ValidatorLifecycleRow{aggregateRow},
+	}
+	for _, stage := range aggregateStages {
+		if stage == nil {
+			continue
+		}
+		stage.EndEpoch++
+		setLifecycleStagePosition(pageData.Aggregate, stage)
+		aggregateRow.Stages = append(aggregateRow.Stages, stage)
+	}
+
+	if len(timeline.Rows) > validatorsLifecycleMaxRows {
+		timeline.Rows = timeline.Rows[:validatorsLifecycleMaxRows]
+	}
+	pageData.ShownCount = uint64(len(timeline.Rows))
+	pageData.Timeline = timeline
+
+	return pageData, 10 * time.Minute
+}
+
+// buildValidatorLifecycleTimeline builds the gantt rows of the validators from their persisted status changes.
+// Each state lasts until the next state was reached, the latest state lasts until the current epoch.
+func buildValidatorLifecycleTimeline(validatorIndexes []uint64, statusChanges []*dbtypes.ValidatorStatusChange, currentEpoch uint64) *models.ValidatorLifecycleTimeline {
+	timeline := &models.ValidatorLifecycleTimeline{
+		FirstEpoch: currentEpoch,
+		LastEpoch:  currentEpoch,
+		Markers:    []*models.ValidatorLifecycleMarker{},
+		Rows:       make([]*models.ValidatorLifecycleRow, 0, len(validatorIndexes)),
+	}
+
+	changesMap := map[uint64][]*dbtypes.ValidatorStatusChange{}
+	for _, change := range statusChanges {
+		changesMap[change.ValidatorIndex] = append(changesMap[change.ValidatorIndex], change)
+		if change.Epoch < timeline.FirstEpoch {
+			timeline.FirstEpoch = change.Epoch
+		}
+	}
+	if timeline.LastEpoch <= timeline.FirstEpoch {
+		timeline.LastEpoch = timeline.FirstEpoch + 1
+	}
+
+	for _, validatorIndex := range validatorIndexes {
+		row := &models.ValidatorLifecycleRow{
+			Index:  validatorIndex,
+			Name:   services.GlobalBeaconService.GetValidatorName(validatorIndex),
+			Stages: []*models.ValidatorLifecycleStage{},
+		}
+		changes := changesMap[validatorIndex]
+		for idx, change := range changes {
+			if int(change.Status) >= len(validatorLifecycleStatusNames) {
+				continue
+			}
+			stage := &models.ValidatorLifecycleStage{
+				Status:     validatorLifecycleStatusNames[change.Status],
+				StartEpoch: change.Epoch,
+				EndEpoch:   timeline.LastEpoch,
+			}
+			if idx+1 < len(changes) {
+				stage.EndEpoch = changes[idx+1].Epoch
+			}
+			setLifecycleStagePosition(timeline, stage)
+			row.Stages = append(row.Stages, stage)
+			row.Status = stage.Status
+		}
+		timeline.Rows = append(timeline.Rows, row)
+	}
+
+	markerCount := uint64(4)
+	epochSpan := timeline.LastEpoch - timeline.FirstEpoch
+	if epochSpan < markerCount {
+		markerCount = epochSpan
+	}
+	for i := uint64(0); i <= markerCount; i++ {
+		epoch := timeline.FirstEpoch + epochSpan*i/markerCount
+		timeline.Markers = append(timeline.Markers, &models.ValidatorLifecycleMarker{
+			Epoch:  epoch,
+			Offset: float64(epoch-timeline.FirstEpoch) * 100.0 / float64(epochSpan),
+		})
+	}
+	return timeline
+}
+
+func setLifecycleStagePosition(timeline *models.ValidatorLifecycleTimeline, stage *models.ValidatorLifecycleStage) {
+	epochSpan := float64(timeline.LastEpoch - timeline.FirstEpoch)
+	if stage.EndEpoch > timeline.LastEpoch {
+		stage.EndEpoch = timeline.LastEpoch
+	}
+	stage.Offset = float64(stage.StartEpoch-timeline.FirstEpoch) * 100.0 / epochSpan
+	if stage.EndEpoch > stage.StartEpoch {
+		stage.Width = float64(stage.EndEpoch-stage.StartEpoch) * 100.0 / epochSpan
+	}
+}
+
+func indexOfLifecycleStatus(status string) int {
+	for idx, name := range validatorLifecycleStatusNames {
+		if name == status {
+			return idx
+		}
+	}
+	return 0
+}
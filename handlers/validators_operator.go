@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// ValidatorsOperator will return the "my validators" operator dashboard using a go template
+func ValidatorsOperator(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"validators_operator/validators_operator.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/operator", "My Validators", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getValidatorsOperatorPageData()
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_operator.go", "ValidatorsOperator", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// ValidatorsOperatorData will return the operator dashboard as json
+func ValidatorsOperatorData(w http.ResponseWriter, r *http.Request) {
+	pageData, pageError := getValidatorsOperatorPageData()
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding operator dashboard data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func getValidatorsOperatorPageData() (*models.ValidatorsOperatorPageData, error) {
+	pageData := &models.ValidatorsOperatorPageData{}
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage("validators_operator", true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsOperatorPageData()
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsOperatorPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorsOperatorPageData() (*models.ValidatorsOperatorPageData, time.Duration) {
+	logrus.Debugf("validators operator page called")
+	pageData := &models.ValidatorsOperatorPageData{
+		Clients: []*models.ValidatorsOperatorPageDataClient{},
+	}
+
+	validatorSet := services.GlobalBeaconService.GetCachedValidatorSet()
+	activityMap, maxActivity := services.GlobalBeaconService.GetValidatorActivity()
+	pageData.ActivityEpochs = maxActivity
+
+	for _, status := range services.GlobalBeaconService.GetValidatorClientStatus() {
+		clientData := &models.ValidatorsOperatorPageDataClient{
+			Name:           status.Name,
+			HasRefresh:     !status.LastRefresh.IsZero(),
+			LastRefresh:    status.LastRefresh,
+			Error:          status.Error,
+			PubkeyCount:    uint64(status.Pubkeys),
+			ValidatorCount: uint64(len(status.Validators)),
+			Metrics:        []*models.ValidatorsOperatorPageDataMetric{},
+			Validators:     []*models.ValidatorsOperatorPageDataValidator{},
+		}
+
+		for name, value := range status.Metrics {
+			clientData.Metrics = append(clientData.Metrics, &models.ValidatorsOperatorPageDataMetric{
+				Name:  name,
+				Value: value,
+			})
+		}
+		sort.Slice(clientData.Metrics, func(a, b int) bool {
+			return clientData.Metrics[a].Name < clientData.Metrics[b].Name
+		})
+
+		activitySum := uint64(0)
+		for _, index := range status.Validators {
+			validator := validatorSet[phase0.ValidatorIndex(index)]
+			if validator == nil {
+				continue
+			}
+			validatorData := &models.ValidatorsOperatorPageDataValidator{
+				Index:   index,
+				Name:    services.GlobalBeaconService.GetValidatorName(index),
+				Balance: uint64(validator.Balance),
+			}
+			clientData.Balance += uint64(validator.Balance)
+
+			switch {
+			case strings.HasPrefix(validator.Status.String(), "pending"):
+				validatorData.State = "Pending"
+				clientData.PendingCount++
+			case validator.Status == v1.ValidatorStateActiveOngoing:
+				validatorData.State = "Active"
+				validatorData.ShowUpcheck = true
+			case validator.Status == v1.ValidatorStateActiveExiting:
+				validatorData.State = "Exiting"
+				validatorData.ShowUpcheck = true
+			case validator.Status == v1.ValidatorStateActiveSlashed:
+				validatorData.State = "Slashed"
+				validatorData.ShowUpcheck = true
+				clientData.SlashedCount++
+			case validator.Status == v1.ValidatorStateExitedSlashed:
+				validatorData.State = "Slashed"
+				clientData.SlashedCount++
+			case strings.HasPrefix(validator.Status.String(), "exited"), strings.HasPrefix(validator.Status.String(), "withdrawal"):
+				validatorData.State = "Exited"
+				clientData.ExitedCount++
+			default:
+				validatorData.State = validator.Status.String()
+			}
+
+			if validatorData.ShowUpcheck {
+				clientData.ActiveCount++
+				validatorData.UpcheckActivity = activityMap[index]
+				validatorData.UpcheckMaximum = uint8(maxActivity)
+				activitySum += uint64(validatorData.UpcheckActivity)
+				if validatorData.UpcheckActivity == 0 && maxActivity > 0 {
+					clientData.OfflineCount++
+				} else if uint64(validatorData.UpcheckActivity) == maxActivity {
+					clientData.OnlineCount++
+				}
+			}
+			clientData.Validators = append(clientData.Validators, validatorData)
+		}
+		if clientData.ActiveCount > 0 && maxActivity > 0 {
+			clientData.Participation = float64(activitySum) * 100.0 / float64(clientData.ActiveCount*maxActivity)
+		}
+		sort.Slice(clientData.Validators, func(a, b int) bool {
+			return clientData.Validators[a].Index < clientData.Validators[b].Index
+		})
+
+		pageData.Clients = append(pageData.Clients, clientData)
+	}
+
+	return pageData, 1 * time.Minute
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+const (
+	validatorsProjectionDayCount    = 30
+	validatorsProjectionMaxDays     = 365
+	validatorsProjectionMaxPerDay   = 1000000
+	validatorsProjectionMaxBalance  = 2048
+	validatorsProjectionSecondsADay = 24 * 3600
+)
+
+// validatorsProjectionArgs are the assumptions of the projection, the current queues are taken from the latest validator set
+type validatorsProjectionArgs struct {
+	dayCount       uint64
+	depositsPerDay uint64
+	exitsPerDay    uint64
+	depositBalance uint64 // ETH
+}
+
+// ValidatorsProjection will return the projected validator set growth using a go template
+func ValidatorsProjection(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"validators_projection/validators_projection.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/projection", "Validator Set Projection", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getValidatorsProjectionPageData(parseValidatorsProjectionArgs(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_projection.go", "ValidatorsProjection", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// ValidatorsProjectionData will return the projected validator set growth as json
+func ValidatorsProjectionData(w http.ResponseWriter, r *http.Request) {
+	pageData, pageError := getValidatorsProjectionPageData(parseValidatorsProjectionArgs(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding validator projection data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func parseValidatorsProjectionArgs(r *http.Request) *validatorsProjectionArgs {
+	urlArgs := r.URL.Query()
+	args := &validatorsProjectionArgs{
+		dayCount:       validatorsProjectionDayCount,
+		depositBalance: utils.Config.Chain.Config.MaxEffectiveBalance / 1000000000,
+	}
+	if urlArgs.Has("days") {
+		args.dayCount, _ = strconv.ParseUint(urlArgs.Get("days"), 10, 64)
+	}
+	if args.dayCount == 0 {
+		args.dayCount = validatorsProjectionDayCount
+	} else if args.dayCount > validatorsProjectionMaxDays {
+		args.dayCount = validatorsProjectionMaxDays
+	}
+	if urlArgs.Has("deposits") {
+		args.depositsPerDay, _ = strconv.ParseUint(urlArgs.Get("deposits"), 10, 64)
+		if args.depositsPerDay > validatorsProjectionMaxPerDay {
+			args.depositsPerDay = validatorsProjectionMaxPerDay
+		}
+	}
+	if urlArgs.Has("exits") {
+		args.exitsPerDay, _ = strconv.ParseUint(urlArgs.Get("exits"), 10, 64)
+		if args.exitsPerDay > validatorsProjectionMaxPerDay {
+			args.exitsPerDay = validatorsProjectionMaxPerDay
+		}
+	}
+	if urlArgs.Has("balance") {
+		balance, _ := strconv.ParseUint(urlArgs.Get("balance"), 10, 64)
+		if balance > validatorsProjectionMaxBalance {
+			balance = validatorsProjectionMaxBalance
+		}
+		if balance > 0 {
+			args.depositBalance = balance
+		}
+	}
+	return args
+}
+
+func getValidatorsProjectionPageData(args *validatorsProjectionArgs) (*models.ValidatorsProjectionPageData, error) {
+	pageData := &models.ValidatorsProjectionPageData{}
+	pageCacheKey := fmt.Sprintf("validators_projection:%v:%v:%v:%v", args.dayCount, args.depositsPerDay, args.exitsPerDay, args.depositBalance)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsProjectionPageData(args)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsProjectionPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorsProjectionPageData(args *validatorsProjectionArgs) (*models.ValidatorsProjectionPageData, time.Duration) {
+	logrus.Debugf("validators projection page called: %v:%v:%v:%v", args.dayCount, args.depositsPerDay, args.exitsPerDay, args.depositBalance)
+	pageData := &models.ValidatorsProjectionPageData{
+		DayCount:       args.dayCount,
+		DepositsPerDay: args.depositsPerDay,
+		ExitsPerDay:    args.exitsPerDay,
+		DepositBalance: args.depositBalance,
+		Days:           []*models.ValidatorsProjectionPageDay{},
+	}
+
+	currentEpoch := utils.TimeToEpoch(time.Now())
+	if currentEpoch < 0 {
+		currentEpoch = 0
+	}
+	pageData.Epoch = uint64(currentEpoch)
+
+	// current queues, the pending validators that are not queued yet are waiting for the finalization of their deposit
+	var entryStake, exitStake uint64
+	for _, validator := range services.GlobalBeaconService.GetCachedValidatorSet() {
+		if strings.HasPrefix(validator.Status.String(), "active") {
+			pageData.ActiveCount++
+			pageData.EligibleEther += uint64(validator.Validator.EffectiveBalance)
+		}
+		switch validator.Status {
+		case v1.ValidatorStatePendingInitialized, v1.ValidatorStatePendingQueued:
+			pageData.EntryQueueCount++
+			entryStake += uint64(validator.Validator.EffectiveBalance)
+		case v1.ValidatorStateActiveExiting:
+			pageData.ExitQueueCount++
+			exitStake += uint64(validator.Validator.EffectiveBalance)
+		}
+	}
+	if pageData.ActiveCount == 0 {
+		return pageData, 1 * time.Minute
+	}
+
+	epochsPerDay := validatorsProjectionSecondsADay / (utils.Config.Chain.Config.SecondsPerSlot * utils.Config.Chain.Config.SlotsPerEpoch)
+	if epochsPerDay == 0 {
+		epochsPerDay = 1
+	}
+
+	// the queues are simulated epoch by epoch with fractional validators, so small daily rates aren't rounded away
+	activeCount := float64(pageData.ActiveCount)
+	activeStake := float64(pageData.EligibleEther)
+	entryQueue := float64(pageData.EntryQueueCount)
+	entryQueueStake := float64(entryStake)
+	exitQueue := float64(pageData.ExitQueueCount)
+	exitQueueStake := float64(exitStake)
+	depositsPerEpoch := float64(args.depositsPerDay) / float64(epochsPerDay)
+	exitsPerEpoch := float64(args.exitsPerDay) / float64(epochsPerDay)
+
+	epoch := uint64(currentEpoch)
+	for day := uint64(1); day <= args.dayCount; day++ {
+		var activationChurn, exitChurn uint64
+		for i := uint64(0); i < epochsPerDay; i++ {
+			epoch++
+			entryQueue += depositsPerEpoch
+			entryQueueStake += depositsPerEpoch * float64(args.depositBalance) * 1e9
+			if exitQueue+exitsPerEpoch <= activeCount {
+				exitQueue += exitsPerEpoch
+				exitQueueStake += exitsPerEpoch * activeStake / activeCount
+			}
+
+			exitChurn = utils.GetValidatorChurnLimit(uint64(activeCount))
+			activationChurn = utils.GetValidatorActivationChurnLimit(uint64(activeCount), epoch)
+
+			if activations := math.Min(entryQueue, float64(activationChurn)); activations > 0 {
+				stake := entryQueueStake * activations / entryQueue
+				activeCount += activations
+				activeStake += stake
+				entryQueue -= activations
+				entryQueueStake -= stake
+			}
+			if exits := math.Min(exitQueue, float64(exitChurn)); exits > 0 {
+				stake := exitQueueStake * exits / exitQueue
+				activeCount -= exits
+				activeStake -= stake
+				exitQueue -= exits
+				exitQueueStake -= stake
+			}
+		}
+
+		projectedDay := &models.ValidatorsProjectionPageDay{
+			Day:                  day,
+			Epoch:                epoch,
+			Ts:                   utils.EpochToTime(epoch),
+			ActiveCount:          uint64(math.Round(activeCount)),
+			EligibleEther:        uint64(activeStake),
+			EntryQueueCount:      uint64(math.Round(entryQueue)),
+			ExitQueueCount:       uint64(math.Round(exitQueue)),
+			ActivationChurnLimit: activationChurn,
+			ExitChurnLimit:       exitChurn,
+		}
+		if activationChurn > 0 {
+			projectedDay.EntryQueueDays = entryQueue / float64(activationChurn*epochsPerDay)
+		}
+		if exitChurn > 0 {
+			projectedDay.ExitQueueDays = exitQueue / float64(exitChurn*epochsPerDay)
+		}
+		pageData.Days = append(pageData.Days, projectedDay)
+	}
+
+	if len(pageData.Days) >= 2 {
+		activeCounts := make([]float64, len(pageData.Days))
+		eligibleEther := make([]float64, len(pageData.Days))
+		entryQueues := make([]float64, len(pageData.Days))
+		exitQueues := make([]float64, len(pageData.Days))
+		for idx, day := range pageData.Days {
+			activeCounts[idx] = float64(day.ActiveCount)
+			eligibleEther[idx] = float64(day.EligibleEther) / 1e9
+			entryQueues[idx] = float64(day.EntryQueueCount)
+			exitQueues[idx] = float64(day.ExitQueueCount)
+		}
+		pageData.Charts = []*models.EpochsPageSparkline{
+			buildEpochsSparkline("Active Validators", "", activeCounts, nil),
+			buildEpochsSparkline("Eligible Stake", " ETH", eligibleEther, nil),
+			buildEpochsSparkline("Entry Queue", "", entryQueues, nil),
+			buildEpochsSparkline("Exit Queue", "", exitQueues, nil),
+		}
+	}
+
+	return pageData, 5 * time.Minute
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ValidatorsUptime will return the "validator uptime" report page using a go template
+func ValidatorsUptime(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"validators_uptime/validators_uptime.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/uptime", "Validator Uptime", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getValidatorsUptimePageData(parseValidatorsUptimeDays(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_uptime.go", "ValidatorsUptime", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// ValidatorsUptimeData will return the validator uptime report as json
+func ValidatorsUptimeData(w http.ResponseWriter, r *http.Request) {
+	pageData, pageError := getValidatorsUptimePageData(parseValidatorsUptimeDays(r))
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding validator uptime data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func parseValidatorsUptimeDays(r *http.Request) uint64 {
+	urlArgs := r.URL.Query()
+	var dayCount uint64 = 7
+	if urlArgs.Has("days") {
+		dayCount, _ = strconv.ParseUint(urlArgs.Get("days"), 10, 64)
+	}
+	if dayCount == 0 {
+		dayCount = 7
+	} else if dayCount > 90 {
+		dayCount = 90
+	}
+	return dayCount
+}
+
+func getValidatorsUptimePageData(dayCount uint64) (*models.ValidatorsUptimePageData, error) {
+	pageData := &models.ValidatorsUptimePageData{}
+	pageCacheKey := fmt.Sprintf("validators_uptime:%v", dayCount)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsUptimePageData(dayCount)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsUptimePageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorsUptimePageData(dayCount uint64) (*models.ValidatorsUptimePageData, time.Duration) {
+	logrus.Debugf("validators uptime page called: %v", dayCount)
+	pageData := &models.ValidatorsUptimePageData{}
+
+	lastDay := utils.TimeToDay(uint64(time.Now().Unix()))
+	firstDay := uint64(0)
+	if lastDay+1 > dayCount {
+		firstDay = lastDay + 1 - dayCount
+	}
+	pageData.FirstDay = firstDay
+	pageData.LastDay = lastDay
+	pageData.DayCount = lastDay - firstDay + 1
+
+	pageData.Days = make([]*models.ValidatorsUptimePageDataDay, 0, pageData.DayCount)
+	dayIndexes := map[uint64]int{}
+	for day := int64(lastDay); day >= int64(firstDay); day-- {
+		dayIndexes[uint64(day)] = len(pageData.Days)
+		pageData.Days = append(pageData.Days, &models.ValidatorsUptimePageDataDay{
+			Day:   uint64(day),
+			Start: utils.DayToTime(day),
+		})
+	}
+
+	operatorMap := map[string]*models.ValidatorsUptimePageDataOperator{}
+	pageData.Operators = make([]*models.ValidatorsUptimePageDataOperator, 0)
+	for _, uptime := range db.GetValidatorUptimeDays(firstDay, lastDay) {
+		operator := operatorMap[uptime.Name]
+		if operator == nil {
+			operator = &models.ValidatorsUptimePageDataOperator{
+				Name: uptime.Name,
+				Days: make([]*models.ValidatorsUptimePageDataUptime, len(pageData.Days)),
+			}
+			for idx, day := range pageData.Days {
+				operator.Days[idx] = &models.ValidatorsUptimePageDataUptime{
+					Day: day.Day,
+				}
+			}
+			operatorMap[uptime.Name] = operator
+			pageData.Operators = append(pageData.Operators, operator)
+		}
+
+		dayIdx, found := dayIndexes[uptime.Day]
+		if !found {
+			continue
+		}
+		dayData := operator.Days[dayIdx]
+		dayData.HasData = true
+		dayData.Duties = uptime.Duties
+		dayData.Attested = uptime.Attested
+		if uptime.Duties > 0 {
+			dayData.Uptime = float64(uptime.Attested) * 100.0 / float64(uptime.Duties)
+		}
+		operator.Duties += uptime.Duties
+		operator.Attested += uptime.Attested
+		operator.CorrectTarget += uptime.CorrectTarget
+		operator.CorrectHead += uptime.CorrectHead
+	}
+
+	for _, operator := range pageData.Operators {
+		if operator.Duties > 0 {
+			operator.Uptime = float64(operator.Attested) * 100.0 / float64(operator.Duties)
+			operator.TargetRate = float64(operator.CorrectTarget) * 100.0 / float64(operator.Duties)
+			operator.HeadRate = float64(operator.CorrectHead) * 100.0 / float64(operator.Duties)
+		}
+	}
+	sort.Slice(pageData.Operators, func(a, b int) bool {
+		return pageData.Operators[a].Name < pageData.Operators[b].Name
+	})
+
+	return pageData, 10 * time.Minute
+}
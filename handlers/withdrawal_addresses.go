@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/rpc"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+// WithdrawalAddresses will return the "withdrawal address reconciliation" page using a go template
+func WithdrawalAddresses(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"withdrawal_addresses/withdrawal_addresses.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/withdrawal_addresses", "Withdrawal Addresses", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getWithdrawalAddressesPageData()
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "withdrawal_addresses.go", "WithdrawalAddresses", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// WithdrawalAddressesData will return the withdrawal address reconciliation as json
+func WithdrawalAddressesData(w http.ResponseWriter, r *http.Request) {
+	pageData, pageError := getWithdrawalAddressesPageData()
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding withdrawal address data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func getWithdrawalAddressesPageData() (*models.WithdrawalAddressesPageData, error) {
+	pageData := &models.WithdrawalAddressesPageData{}
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage("withdrawal_addresses", true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildWithdrawalAddressesPageData()
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.WithdrawalAddressesPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildWithdrawalAddressesPageData() (*models.WithdrawalAddressesPageData, time.Duration) {
+	logrus.Debugf("withdrawal addresses page called")
+	pageData := &models.WithdrawalAddressesPageData{
+		Addresses:        []*models.WithdrawalAddressesPageDataAddress{},
+		HaveExecutionApi: utils.Config.ExecutionApi.Endpoint != "",
+	}
+
+	var executionClient *rpc.ExecutionClient
+	if pageData.HaveExecutionApi {
+		executionClient = rpc.NewExecutionClient(utils.Config.ExecutionApi.Endpoint)
+		finalizedBlock, err := executionClient.GetBlockNumber("finalized")
+		if err != nil {
+			logrus.Warnf("error loading finalized EL block: %v", err)
+		} else {
+			pageData.FinalizedBlock = finalizedBlock
+		}
+	}
+
+	withdrawalStats := db.GetWatchedWithdrawalStats()
+	gweiFactor := big.NewInt(1000000000)
+	for _, watchedAddress := range utils.Config.Indexer.WatchedWithdrawalAddresses {
+		address := common.HexToAddress(watchedAddress.Address)
+		addressData := &models.WithdrawalAddressesPageDataAddress{
+			Name:    watchedAddress.Name,
+			Address: address[:],
+		}
+		for _, stats := range withdrawalStats {
+			if bytes.Equal(stats.Address, address[:]) {
+				addressData.Tracked = true
+				addressData.FirstEpoch = stats.FirstEpoch
+				addressData.LastEpoch = stats.LastEpoch
+				addressData.WithdrawCount = stats.Count
+				addressData.WithdrawAmount = stats.Amount
+				break
+			}
+		}
+
+		if executionClient != nil {
+			// compare with the finalized balance, as only withdrawals of finalized epochs are tracked
+			balance, err := executionClient.GetBalance(address[:], "finalized")
+			if err != nil {
+				addressData.BalanceError = err.Error()
+			} else {
+				addressData.HaveBalance = true
+				addressData.Balance = new(big.Int).Div(balance, gweiFactor).Uint64()
+				if addressData.Balance >= addressData.WithdrawAmount {
+					addressData.Difference = addressData.Balance - addressData.WithdrawAmount
+				} else {
+					addressData.Difference = addressData.WithdrawAmount - addressData.Balance
+					addressData.DifferenceNeg = true
+				}
+				addressData.Discrepancy = addressData.Tracked && addressData.DifferenceNeg
+			}
+		}
+
+		pageData.Addresses = append(pageData.Addresses, addressData)
+	}
+
+	return pageData, time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
+}
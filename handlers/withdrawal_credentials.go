@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// WithdrawalCredentials will return the "withdrawal credentials" analytics page using a go template
+func WithdrawalCredentials(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"withdrawal_credentials/withdrawal_credentials.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/credentials", "Withdrawal Credentials", pageTemplateFiles)
+
+	urlArgs := r.URL.Query()
+	var firstEpoch uint64 = math.MaxUint64
+	if urlArgs.Has("epoch") {
+		firstEpoch, _ = strconv.ParseUint(urlArgs.Get("epoch"), 10, 64)
+	}
+	var pageSize uint64 = 50
+	if urlArgs.Has("count") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("count"), 10, 64)
+	}
+
+	var pageError error
+	data.Data, pageError = getWithdrawalCredentialsPageData(firstEpoch, pageSize)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "withdrawal_credentials.go", "WithdrawalCredentials", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getWithdrawalCredentialsPageData(firstEpoch uint64, pageSize uint64) (*models.WithdrawalCredentialsPageData, error) {
+	pageData := &models.WithdrawalCredentialsPageData{}
+	pageCacheKey := fmt.Sprintf("withdrawal_credentials:%v:%v", firstEpoch, pageSize)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildWithdrawalCredentialsPageData(firstEpoch, pageSize)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.WithdrawalCredentialsPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildWithdrawalCredentialsPageData(firstEpoch uint64, pageSize uint64) (*models.WithdrawalCredentialsPageData, time.Duration) {
+	logrus.Debugf("withdrawal credentials page called: %v:%v", firstEpoch, pageSize)
+	pageData := &models.WithdrawalCredentialsPageData{}
+
+	if pageSize == 0 {
+		pageSize = 50
+	} else if pageSize > 500 {
+		pageSize = 500
+	}
+	pageData.PageSize = pageSize
+
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	isDefaultPage := false
+	if finalizedEpoch < 0 || firstEpoch > uint64(finalizedEpoch) {
+		isDefaultPage = true
+		if finalizedEpoch < 0 {
+			firstEpoch = 0
+		} else {
+			firstEpoch = uint64(finalizedEpoch)
+		}
+	}
+
+	dbStats := db.GetEpochCredentialStats(firstEpoch, uint32(pageSize))
+	pageData.Epochs = make([]*models.WithdrawalCredentialsPageDataEpoch, 0, len(dbStats))
+	for _, stats := range dbStats {
+		epochData := &models.WithdrawalCredentialsPageDataEpoch{
+			Epoch:            stats.Epoch,
+			Ts:               utils.EpochToTime(stats.Epoch),
+			ValidatorCount:   stats.BlsCount + stats.ExecutionCount + stats.CompoundingCount + stats.OtherCount,
+			BlsCount:         stats.BlsCount,
+			ExecutionCount:   stats.ExecutionCount,
+			CompoundingCount: stats.CompoundingCount,
+			OtherCount:       stats.OtherCount,
+		}
+		if epochData.ValidatorCount > 0 {
+			epochData.BlsPercentage = float64(stats.BlsCount) * 100.0 / float64(epochData.ValidatorCount)
+			epochData.ExecutionPercentage = float64(stats.ExecutionCount) * 100.0 / float64(epochData.ValidatorCount)
+			epochData.CompoundingPercentage = float64(stats.CompoundingCount) * 100.0 / float64(epochData.ValidatorCount)
+			epochData.OtherPercentage = float64(stats.OtherCount) * 100.0 / float64(epochData.ValidatorCount)
+		}
+		pageData.Epochs = append(pageData.Epochs, epochData)
+	}
+	pageData.EpochCount = uint64(len(pageData.Epochs))
+
+	if pageData.EpochCount > 0 {
+		pageData.Latest = pageData.Epochs[0]
+		pageData.FirstEpoch = pageData.Epochs[0].Epoch
+		pageData.LastEpoch = pageData.Epochs[pageData.EpochCount-1].Epoch
+
+		if !isDefaultPage {
+			prevPageEpoch := pageData.FirstEpoch + pageSize
+			pageData.PrevPageEpoch = &prevPageEpoch
+		}
+		if pageData.LastEpoch > 0 && pageData.EpochCount == pageSize {
+			nextPageEpoch := pageData.LastEpoch - 1
+			pageData.NextPageEpoch = &nextPageEpoch
+		}
+	}
+
+	var cacheTimeout time.Duration
+	if isDefaultPage {
+		cacheTimeout = 1 * time.Minute
+	} else {
+		cacheTimeout = 30 * time.Minute
+	}
+	return pageData, cacheTimeout
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/services"
+	"github.com/pk910/dora/templates"
+	"github.com/pk910/dora/types/models"
+	"github.com/pk910/dora/utils"
+)
+
+const (
+	witnessesEpochCount = 100
+	witnessesDayCount   = 30
+)
+
+// Witnesses will return the "execution witnesses" page of stateless devnets using a go template
+func Witnesses(w http.ResponseWriter, r *http.Request) {
+	var pageTemplateFiles = append(layoutTemplateFiles,
+		"witnesses/witnesses.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(pageTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/slots/witnesses", "Execution Witnesses", pageTemplateFiles)
+
+	var pageError error
+	data.Data, pageError = getWitnessesPageData()
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "witnesses.go", "Witnesses", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getWitnessesPageData() (*models.WitnessesPageData, error) {
+	pageData := &models.WitnessesPageData{}
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage("witnesses", true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildWitnessesPageData()
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.WitnessesPageData)
+		if !resOk {
+			return nil, InvalidPageModelError
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildWitnessesPageData() (*models.WitnessesPageData, time.Duration) {
+	logrus.Debugf("witnesses page called")
+	pageData := &models.WitnessesPageData{}
+
+	currentEpoch := utils.TimeToEpoch(time.Now())
+	if currentEpoch < 0 {
+		currentEpoch = 0
+	}
+	slotsPerEpoch := utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := uint64(currentEpoch+1)*slotsPerEpoch - 1
+
+	firstEpoch := uint64(0)
+	if uint64(currentEpoch) >= witnessesEpochCount {
+		firstEpoch = uint64(currentEpoch) - witnessesEpochCount + 1
+	}
+	epochs := buildWitnessesPeriods(firstEpoch*slotsPerEpoch, lastSlot, slotsPerEpoch, func(period uint64) time.Time {
+		return utils.EpochToTime(period)
+	})
+
+	slotsPerDay := 24 * 3600 / utils.Config.Chain.Config.SecondsPerSlot
+	currentDay := lastSlot / slotsPerDay
+	firstDay := uint64(0)
+	if currentDay >= witnessesDayCount {
+		firstDay = currentDay - witnessesDayCount + 1
+	}
+	days := buildWitnessesPeriods(firstDay*slotsPerDay, lastSlot, slotsPerDay, func(period uint64) time.Time {
+		return utils.DayToTime(int64(period))
+	})
+
+	pageData.Sections = []*models.WitnessesPageSection{
+		buildWitnessesSection("Per Epoch", "Epoch", "/epoch/", epochs),
+		buildWitnessesSection("Per Day", "Day", "", days),
+	}
+
+	return pageData, 5 * time.Minute
+}
+
+func buildWitnessesPeriods(firstSlot uint64, lastSlot uint64, periodSlots uint64, periodTime func(period uint64) time.Time) []*models.WitnessesPagePeriod {
+	dbStats := db.GetBlockWitnessStats(firstSlot, lastSlot, periodSlots)
+	periods := make([]*models.WitnessesPagePeriod, 0, len(dbStats))
+	for _, stats := range dbStats {
+		periods = append(periods, &models.WitnessesPagePeriod{
+			Period:      stats.Period,
+			Ts:          periodTime(stats.Period),
+			BlockCount:  stats.BlockCount,
+			StemCount:   stats.StemCount,
+			KeyCount:    stats.KeyCount,
+			ProofSize:   stats.ProofSize,
+			WitnessSize: stats.WitnessSize,
+			MaxSize:     stats.MaxSize,
+		})
+	}
+	return periods
+}
+
+// buildWitnessesSection builds the witness size sparklines for the periods (sorted oldest first)
+func buildWitnessesSection(title string, periodLabel string, periodLink string, periods []*models.WitnessesPagePeriod) *models.WitnessesPageSection {
+	section := &models.WitnessesPageSection{
+		Title:       title,
+		PeriodLabel: periodLabel,
+		PeriodLink:  periodLink,
+		Periods:     make([]*models.WitnessesPagePeriod, len(periods)),
+	}
+	for idx, period := range periods {
+		section.Periods[len(periods)-idx-1] = period
+	}
+
+	if len(periods) < 2 {
+		return section
+	}
+	witnessSize := make([]float64, len(periods))
+	proofSize := make([]float64, len(periods))
+	keyCount := make([]float64, len(periods))
+	for idx, period := range periods {
+		witnessSize[idx] = period.WitnessSize / 1024
+		proofSize[idx] = period.ProofSize / 1024
+		keyCount[idx] = period.KeyCount
+	}
+	section.Charts = []*models.EpochsPageSparkline{
+		buildEpochsSparkline("Witness Size", " KiB", witnessSize, nil),
+		buildEpochsSparkline("Proof Size", " KiB", proofSize, nil),
+		buildEpochsSparkline("Accessed Keys", "", keyCount, nil),
+	}
+	return section
+}
@@ -0,0 +1,225 @@
+package indexer
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	ActivityAttestationIncluded = "attestation_included"
+	ActivityAttestationMissed   = "attestation_missed"
+	ActivityProposal            = "proposal"
+	ActivityProposalMissed      = "proposal_missed"
+	ActivityBalanceChange       = "balance_change"
+	ActivityStatusChange        = "status_change"
+)
+
+// ValidatorActivityEvent is a single validator related event that is emitted while processing finalized epochs
+type ValidatorActivityEvent struct {
+	Type            string `json:"type"`
+	Epoch           uint64 `json:"epoch"`
+	Slot            uint64 `json:"slot,omitempty"`
+	Validator       uint64 `json:"validator"`
+	BlockRoot       []byte `json:"block_root,omitempty"`
+	Balance         uint64 `json:"balance,omitempty"`
+	PreviousBalance uint64 `json:"previous_balance,omitempty"`
+	Status          string `json:"status,omitempty"`
+	PreviousStatus  string `json:"previous_status,omitempty"`
+}
+
+// ActivitySubscription receives the activity events of a set of validators.
+// Events are sent per processed epoch, slow receivers lose events instead of blocking the indexer.
+type ActivitySubscription struct {
+	indexer    *Indexer
+	mutex      sync.RWMutex
+	validators map[uint64]*activityValidatorState
+	Events     chan []*ValidatorActivityEvent
+}
+
+type activityValidatorState struct {
+	known   bool
+	balance uint64
+	status  string
+}
+
+type activityDispatcher struct {
+	mutex         sync.RWMutex
+	subscriptions map[*ActivitySubscription]bool
+}
+
+// SubscribeActivity creates a new subscription for validator activity events
+func (indexer *Indexer) SubscribeActivity() *ActivitySubscription {
+	subscription := &ActivitySubscription{
+		indexer:    indexer,
+		validators: map[uint64]*activityValidatorState{},
+		Events:     make(chan []*ValidatorActivityEvent, 16),
+	}
+	indexer.activity.mutex.Lock()
+	defer indexer.activity.mutex.Unlock()
+	indexer.activity.subscriptions[subscription] = true
+	return subscription
+}
+
+// Unsubscribe stops event delivery for this subscription
+func (subscription *ActivitySubscription) Unsubscribe() {
+	subscription.indexer.activity.mutex.Lock()
+	defer subscription.indexer.activity.mutex.Unlock()
+	delete(subscription.indexer.activity.subscriptions, subscription)
+}
+
+// SetValidators adds or removes validators from the subscription.
+// Adding validators that would grow the subscription beyond maxValidators (0 = unlimited) is rejected as a whole.
+func (subscription *ActivitySubscription) SetValidators(validators []uint64, subscribe bool, maxValidators int) bool {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+	if subscribe && maxValidators > 0 {
+		newValidators := map[uint64]bool{}
+		for _, validator := range validators {
+			if subscription.validators[validator] == nil {
+				newValidators[validator] = true
+			}
+		}
+		if len(subscription.validators)+len(newValidators) > maxValidators {
+			return false
+		}
+	}
+	for _, validator := range validators {
+		if !subscribe {
+			delete(subscription.validators, validator)
+		} else if subscription.validators[validator] == nil {
+			subscription.validators[validator] = &activityValidatorState{}
+		}
+	}
+	return true
+}
+
+// GetValidatorCount returns the number of subscribed validators
+func (subscription *ActivitySubscription) GetValidatorCount() int {
+	subscription.mutex.RLock()
+	defer subscription.mutex.RUnlock()
+	return len(subscription.validators)
+}
+
+func (dispatcher *activityDispatcher) hasSubscriptions() bool {
+	dispatcher.mutex.RLock()
+	defer dispatcher.mutex.RUnlock()
+	return len(dispatcher.subscriptions) > 0
+}
+
+// dispatchEpochActivity sends the duty results of a processed epoch and the balance/status changes
+// in the latest loaded validator set to all subscriptions.
+func (cache *indexerCache) dispatchEpochActivity(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *EpochStats, epochVotes *EpochVotes) {
+	dispatcher := &cache.indexer.activity
+	if !dispatcher.hasSubscriptions() {
+		return
+	}
+
+	cache.cacheMutex.RLock()
+	validatorSet := cache.lastValidatorsResp
+	validatorSetEpoch := cache.lastValidatorsEpoch
+	cache.cacheMutex.RUnlock()
+
+	dispatcher.mutex.RLock()
+	subscriptions := make([]*ActivitySubscription, 0, len(dispatcher.subscriptions))
+	for subscription := range dispatcher.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	dispatcher.mutex.RUnlock()
+
+	for _, subscription := range subscriptions {
+		events := subscription.buildEvents(epoch, blockMap, epochStats, epochVotes, validatorSet, uint64(validatorSetEpoch))
+		if len(events) == 0 {
+			continue
+		}
+		select {
+		case subscription.Events <- events:
+		default:
+			logger.Warnf("activity subscription queue full, dropped %v events for epoch %v", len(events), epoch)
+		}
+	}
+}
+
+func (subscription *ActivitySubscription) buildEvents(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *EpochStats, epochVotes *EpochVotes, validatorSet map[phase0.ValidatorIndex]*v1.Validator, validatorSetEpoch uint64) []*ValidatorActivityEvent {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+	events := []*ValidatorActivityEvent{}
+	if len(subscription.validators) == 0 {
+		return events
+	}
+
+	if epochStats != nil {
+		for slot, proposer := range epochStats.GetProposerAssignments() {
+			if subscription.validators[proposer] == nil {
+				continue
+			}
+			event := &ValidatorActivityEvent{
+				Type:      ActivityProposalMissed,
+				Epoch:     epoch,
+				Slot:      slot,
+				Validator: proposer,
+			}
+			if block := blockMap[slot]; block != nil {
+				event.Type = ActivityProposal
+				event.BlockRoot = block.Root
+			}
+			events = append(events, event)
+		}
+
+		if epochVotes != nil {
+			for attKey, validators := range epochStats.GetAttestorAssignments() {
+				var attSlot uint64
+				fmt.Sscanf(attKey, "%d-", &attSlot)
+				for _, validator := range validators {
+					if subscription.validators[validator] == nil {
+						continue
+					}
+					event := &ValidatorActivityEvent{
+						Type:      ActivityAttestationMissed,
+						Epoch:     epoch,
+						Slot:      attSlot,
+						Validator: validator,
+					}
+					if epochVotes.ActivityMap[validator] {
+						event.Type = ActivityAttestationIncluded
+					}
+					events = append(events, event)
+				}
+			}
+		}
+	}
+
+	for index, state := range subscription.validators {
+		validator := validatorSet[phase0.ValidatorIndex(index)]
+		if validator == nil {
+			continue
+		}
+		balance := uint64(validator.Balance)
+		status := validator.Status.String()
+		if state.known && state.balance != balance {
+			events = append(events, &ValidatorActivityEvent{
+				Type:            ActivityBalanceChange,
+				Epoch:           validatorSetEpoch,
+				Validator:       index,
+				Balance:         balance,
+				PreviousBalance: state.balance,
+			})
+		}
+		if state.known && state.status != status {
+			events = append(events, &ValidatorActivityEvent{
+				Type:           ActivityStatusChange,
+				Epoch:          validatorSetEpoch,
+				Validator:      index,
+				Status:         status,
+				PreviousStatus: state.status,
+			})
+		}
+		state.known = true
+		state.balance = balance
+		state.status = status
+	}
+
+	return events
+}
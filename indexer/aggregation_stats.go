@@ -0,0 +1,73 @@
+package indexer
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// AggregationStats counts the included attestation aggregates that don't add new votes for their committee.
+// Votes of a committee are only counted once, so redundant aggregates waste block space without any reward.
+type AggregationStats struct {
+	AggregateCount     uint64 // included aggregates
+	CommitteeCount     uint64 // distinct committees (slot & committee index) covered by the aggregates
+	RedundantCount     uint64 // aggregates with only votes that have already been included by other aggregates
+	VoteCount          uint64 // set aggregation bits of all aggregates
+	DuplicateVoteCount uint64 // set aggregation bits that have already been included by other aggregates
+}
+
+type aggregationTracker struct {
+	stats         AggregationStats
+	committeeBits map[string][]bool
+}
+
+func newAggregationTracker() *aggregationTracker {
+	return &aggregationTracker{
+		committeeBits: map[string][]bool{},
+	}
+}
+
+// addAttestation compares the aggregation bits with the bits of all previously added aggregates of the same committee
+func (tracker *aggregationTracker) addAttestation(att *phase0.Attestation) {
+	if att == nil || att.Data == nil {
+		return
+	}
+	committeeKey := fmt.Sprintf("%v-%v", uint64(att.Data.Slot), uint64(att.Data.Index))
+	bitCount := int(att.AggregationBits.Len())
+	seenBits := tracker.committeeBits[committeeKey]
+	if seenBits == nil {
+		tracker.stats.CommitteeCount++
+	}
+	if len(seenBits) < bitCount {
+		seenBits = append(seenBits, make([]bool, bitCount-len(seenBits))...)
+	}
+
+	newVotes := uint64(0)
+	for bitIdx := 0; bitIdx < bitCount; bitIdx++ {
+		if !att.AggregationBits.BitAt(uint64(bitIdx)) {
+			continue
+		}
+		tracker.stats.VoteCount++
+		if seenBits[bitIdx] {
+			tracker.stats.DuplicateVoteCount++
+		} else {
+			seenBits[bitIdx] = true
+			newVotes++
+		}
+	}
+	tracker.committeeBits[committeeKey] = seenBits
+
+	tracker.stats.AggregateCount++
+	if newVotes == 0 {
+		tracker.stats.RedundantCount++
+	}
+}
+
+// GetBlockAggregationStats returns the aggregation stats of the attestations included in a single block
+func GetBlockAggregationStats(attestations []*phase0.Attestation) *AggregationStats {
+	tracker := newAggregationTracker()
+	for _, att := range attestations {
+		tracker.addAttestation(att)
+	}
+	return &tracker.stats
+}
@@ -0,0 +1,464 @@
+package indexer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/rpc"
+	"github.com/pk910/dora/utils"
+)
+
+const archiveVersion = 1
+
+const archiveImportBatchSize = 1000
+
+// archiveManifest is the first entry of an archive and describes its content
+type archiveManifest struct {
+	Version     uint64    `json:"version"`
+	ChainName   string    `json:"chain_name"`
+	GenesisTime uint64    `json:"genesis_time"`
+	FirstEpoch  uint64    `json:"first_epoch"`
+	LastEpoch   uint64    `json:"last_epoch"`
+	Created     time.Time `json:"created"`
+	BlockCount  uint64    `json:"block_count"`
+	BlobCount   uint64    `json:"blob_count"`
+}
+
+// archiveBlob holds the blob metadata, the blob data itself is stored in a separate entry
+type archiveBlob struct {
+	Commitment  []byte                    `json:"commitment"`
+	Proof       []byte                    `json:"proof"`
+	Size        uint32                    `json:"size"`
+	Assignments []*dbtypes.BlobAssignment `json:"assignments"`
+}
+
+// ExportArchive writes all finalized data of the given epoch range into a tar.gz archive:
+// the db rows (epochs, blocks, slot assignments, blobs) as json, the epoch aggregates as csv and
+// the full ssz encoded blocks & blob data, so the chain history can be restored with ImportArchive
+// after the network has been shut down. Blocks & blobs that are not in the db are loaded from the clients.
+func ExportArchive(fileName string, firstEpoch uint64, lastEpoch uint64, clients []*rpc.BeaconClient) error {
+	slotsPerEpoch := utils.Config.Chain.Config.SlotsPerEpoch
+	firstSlot := firstEpoch * slotsPerEpoch
+	lastSlot := (lastEpoch+1)*slotsPerEpoch - 1
+
+	epochs := []*dbtypes.Epoch{}
+	for _, dbEpoch := range db.GetEpochs(lastEpoch, uint32(lastEpoch-firstEpoch+1)) {
+		if dbEpoch.Epoch >= firstEpoch {
+			epochs = append(epochs, dbEpoch)
+		}
+	}
+	blocks := db.GetBlocksForSlots(lastSlot, firstSlot, true)
+	slotAssignments := db.GetSlotAssignmentsForSlots(lastSlot, firstSlot)
+
+	blobs := []*archiveBlob{}
+	blobMap := map[string]*archiveBlob{}
+	for _, assignment := range db.GetBlobAssignmentsForSlots(firstSlot, lastSlot) {
+		blob := blobMap[string(assignment.Commitment)]
+		if blob == nil {
+			dbBlob := db.GetBlob(assignment.Commitment, false)
+			if dbBlob == nil {
+				continue
+			}
+			blob = &archiveBlob{
+				Commitment: dbBlob.Commitment,
+				Proof:      dbBlob.Proof,
+				Size:       dbBlob.Size,
+			}
+			blobMap[string(assignment.Commitment)] = blob
+			blobs = append(blobs, blob)
+		}
+		blob.Assignments = append(blob.Assignments, assignment)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("error creating archive file: %v", err)
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifest := &archiveManifest{
+		Version:     archiveVersion,
+		ChainName:   utils.Config.Chain.Name,
+		GenesisTime: utils.Config.Chain.GenesisTimestamp,
+		FirstEpoch:  firstEpoch,
+		LastEpoch:   lastEpoch,
+		Created:     time.Now(),
+		BlockCount:  uint64(len(blocks)),
+		BlobCount:   uint64(len(blobs)),
+	}
+	if err := writeArchiveJson(tarWriter, "manifest.json", manifest); err != nil {
+		return err
+	}
+	if err := writeArchiveJson(tarWriter, "epochs.json", epochs); err != nil {
+		return err
+	}
+	if err := writeArchiveJson(tarWriter, "blocks.json", blocks); err != nil {
+		return err
+	}
+	if err := writeArchiveJson(tarWriter, "slot_assignments.json", slotAssignments); err != nil {
+		return err
+	}
+	if err := writeArchiveJson(tarWriter, "blobs.json", blobs); err != nil {
+		return err
+	}
+	if err := writeArchiveFile(tarWriter, "epochs.csv", buildEpochsCsv(epochs)); err != nil {
+		return err
+	}
+
+	missingBlocks := 0
+	for _, block := range blocks {
+		archivedBlock, err := loadArchiveBlock(block, clients)
+		if err != nil {
+			logger.Warnf("archive export: could not load block 0x%x (slot %v): %v", block.Root, block.Slot, err)
+			missingBlocks++
+			continue
+		}
+		if err := writeArchiveJson(tarWriter, fmt.Sprintf("blocks/%v-0x%x.json", block.Slot, block.Root), archivedBlock); err != nil {
+			return err
+		}
+	}
+
+	missingBlobs := 0
+	blobStore := newBlobStore()
+	for _, blob := range blobs {
+		blobData := loadArchiveBlobData(blobStore, blob, clients)
+		if blobData == nil {
+			missingBlobs++
+			continue
+		}
+		if err := writeArchiveFile(tarWriter, fmt.Sprintf("blobs/0x%x.bin", blob.Commitment), blobData); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("error closing archive: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("error closing archive: %v", err)
+	}
+	logger.Infof("archive export: wrote %v epochs, %v blocks (%v missing), %v blobs (%v without data)", len(epochs), len(blocks)-missingBlocks, missingBlocks, len(blobs), missingBlobs)
+	return nil
+}
+
+func writeArchiveJson(tarWriter *tar.Writer, name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error encoding %v: %v", name, err)
+	}
+	return writeArchiveFile(tarWriter, name, data)
+}
+
+func writeArchiveFile(tarWriter *tar.Writer, name string, data []byte) error {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing %v: %v", name, err)
+	}
+	if _, err := tarWriter.Write(data); err != nil {
+		return fmt.Errorf("error writing %v: %v", name, err)
+	}
+	return nil
+}
+
+func buildEpochsCsv(epochs []*dbtypes.Epoch) []byte {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	writer.Write([]string{
+		"epoch", "validator_count", "validator_balance", "eligible", "voted_target", "voted_head", "voted_total",
+		"block_count", "orphaned_count", "attestation_count", "deposit_count", "exit_count", "withdraw_count",
+		"withdraw_amount", "attester_slashing_count", "proposer_slashing_count", "bls_change_count",
+		"eth_transaction_count", "blob_count", "sync_participation",
+	})
+	for i := len(epochs) - 1; i >= 0; i-- {
+		epoch := epochs[i]
+		writer.Write([]string{
+			fmt.Sprint(epoch.Epoch), fmt.Sprint(epoch.ValidatorCount), fmt.Sprint(epoch.ValidatorBalance), fmt.Sprint(epoch.Eligible),
+			fmt.Sprint(epoch.VotedTarget), fmt.Sprint(epoch.VotedHead), fmt.Sprint(epoch.VotedTotal),
+			fmt.Sprint(epoch.BlockCount), fmt.Sprint(epoch.OrphanedCount), fmt.Sprint(epoch.AttestationCount),
+			fmt.Sprint(epoch.DepositCount), fmt.Sprint(epoch.ExitCount), fmt.Sprint(epoch.WithdrawCount),
+			fmt.Sprint(epoch.WithdrawAmount), fmt.Sprint(epoch.AttesterSlashingCount), fmt.Sprint(epoch.ProposerSlashingCount),
+			fmt.Sprint(epoch.BLSChangeCount), fmt.Sprint(epoch.EthTransactionCount), fmt.Sprint(epoch.BlobCount),
+			fmt.Sprintf("%.4f", epoch.SyncParticipation),
+		})
+	}
+	writer.Flush()
+	return buf.Bytes()
+}
+
+// loadArchiveBlock returns the ssz encoded header & body of a block. orphaned & previously imported blocks
+// are taken from the db, canonical blocks are requested from the clients.
+func loadArchiveBlock(block *dbtypes.Block, clients []*rpc.BeaconClient) (*dbtypes.ArchivedBlock, error) {
+	if archivedBlock := db.GetArchivedBlock(block.Root); archivedBlock != nil {
+		return archivedBlock, nil
+	}
+	if block.Orphaned == 1 {
+		if orphanedBlock := db.GetOrphanedBlock(block.Root); orphanedBlock != nil {
+			return &dbtypes.ArchivedBlock{
+				Root:      orphanedBlock.Root,
+				Slot:      block.Slot,
+				HeaderVer: orphanedBlock.HeaderVer,
+				HeaderSSZ: orphanedBlock.HeaderSSZ,
+				BlockVer:  orphanedBlock.BlockVer,
+				BlockSSZ:  orphanedBlock.BlockSSZ,
+			}, nil
+		}
+	}
+
+	var lastErr error = fmt.Errorf("not found on any client")
+	for _, client := range clients {
+		header, err := client.GetBlockHeaderByBlockroot(block.Root)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if header == nil {
+			continue
+		}
+		body, err := client.GetBlockBodyByBlockroot(block.Root)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if body == nil {
+			continue
+		}
+
+		headerSSZ, err := header.Header.MarshalSSZ()
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling header: %v", err)
+		}
+		blockVer, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(body)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling block: %v", err)
+		}
+		return &dbtypes.ArchivedBlock{
+			Root:      block.Root,
+			Slot:      block.Slot,
+			HeaderVer: 1,
+			HeaderSSZ: headerSSZ,
+			BlockVer:  blockVer,
+			BlockSSZ:  blockSSZ,
+		}, nil
+	}
+	return nil, lastErr
+}
+
+func loadArchiveBlobData(blobStore *BlobStore, blob *archiveBlob, clients []*rpc.BeaconClient) []byte {
+	dbBlob, err := blobStore.LoadBlob(blob.Commitment, nil, nil)
+	if err == nil && dbBlob != nil && dbBlob.Blob != nil {
+		return *dbBlob.Blob
+	}
+
+	for _, assignment := range blob.Assignments {
+		for _, client := range clients {
+			sidecars, err := client.GetBlobSidecarsByBlockroot(assignment.Root)
+			if err != nil {
+				continue
+			}
+			for _, sidecar := range sidecars {
+				if bytes.Equal(sidecar.KzgCommitment[:], blob.Commitment) {
+					return sidecar.Blob[:]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ImportArchive restores the content of an archive created by ExportArchive into the db.
+// Canonical blocks are stored in the archived blocks table, so they can be shown without a node that knows them.
+func ImportArchive(fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return fmt.Errorf("error opening archive file: %v", err)
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("error opening archive: %v", err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var manifest *archiveManifest
+	orphanedBlocks := map[string]bool{}
+	pendingBlobs := map[string]*archiveBlob{}
+	blockCount := 0
+	blobCount := 0
+	blobStore := newBlobStore()
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error reading archive: %v", err)
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return fmt.Errorf("error reading %v: %v", header.Name, err)
+		}
+
+		if manifest == nil {
+			if header.Name != "manifest.json" {
+				return fmt.Errorf("invalid archive: missing manifest")
+			}
+			manifest = &archiveManifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return fmt.Errorf("error parsing manifest: %v", err)
+			}
+			if manifest.Version != archiveVersion {
+				return fmt.Errorf("unsupported archive version %v", manifest.Version)
+			}
+			if utils.Config.Chain.Name != "" && manifest.ChainName != utils.Config.Chain.Name {
+				return fmt.Errorf("archive belongs to chain '%v', but explorer is configured for '%v'", manifest.ChainName, utils.Config.Chain.Name)
+			}
+			logger.Infof("archive import: importing epochs %v-%v of %v (created %v)", manifest.FirstEpoch, manifest.LastEpoch, manifest.ChainName, manifest.Created)
+			continue
+		}
+
+		switch {
+		case header.Name == "epochs.json":
+			epochs := []*dbtypes.Epoch{}
+			if err := json.Unmarshal(data, &epochs); err != nil {
+				return fmt.Errorf("error parsing %v: %v", header.Name, err)
+			}
+			for _, epoch := range epochs {
+				if err := db.InsertEpoch(epoch, tx); err != nil {
+					return fmt.Errorf("error inserting epoch %v: %v", epoch.Epoch, err)
+				}
+			}
+		case header.Name == "blocks.json":
+			blocks := []*dbtypes.Block{}
+			if err := json.Unmarshal(data, &blocks); err != nil {
+				return fmt.Errorf("error parsing %v: %v", header.Name, err)
+			}
+			for _, block := range blocks {
+				if err := db.InsertBlock(block, tx); err != nil {
+					return fmt.Errorf("error inserting block 0x%x: %v", block.Root, err)
+				}
+				if block.Orphaned == 1 {
+					orphanedBlocks[string(block.Root)] = true
+				}
+			}
+		case header.Name == "slot_assignments.json":
+			slotAssignments := []*dbtypes.SlotAssignment{}
+			if err := json.Unmarshal(data, &slotAssignments); err != nil {
+				return fmt.Errorf("error parsing %v: %v", header.Name, err)
+			}
+			for start := 0; start < len(slotAssignments); start += archiveImportBatchSize {
+				end := start + archiveImportBatchSize
+				if end > len(slotAssignments) {
+					end = len(slotAssignments)
+				}
+				if err := db.InsertSlotAssignments(slotAssignments[start:end], tx); err != nil {
+					return fmt.Errorf("error inserting slot assignments: %v", err)
+				}
+			}
+		case header.Name == "blobs.json":
+			blobs := []*archiveBlob{}
+			if err := json.Unmarshal(data, &blobs); err != nil {
+				return fmt.Errorf("error parsing %v: %v", header.Name, err)
+			}
+			for _, blob := range blobs {
+				pendingBlobs[string(blob.Commitment)] = blob
+			}
+		case strings.HasPrefix(header.Name, "blocks/"):
+			block := &dbtypes.ArchivedBlock{}
+			if err := json.Unmarshal(data, block); err != nil {
+				return fmt.Errorf("error parsing %v: %v", header.Name, err)
+			}
+			if orphanedBlocks[string(block.Root)] {
+				err = db.InsertOrphanedBlock(&dbtypes.OrphanedBlock{
+					Root:      block.Root,
+					HeaderVer: block.HeaderVer,
+					HeaderSSZ: block.HeaderSSZ,
+					BlockVer:  block.BlockVer,
+					BlockSSZ:  block.BlockSSZ,
+				}, tx)
+			} else {
+				err = db.InsertArchivedBlock(block, tx)
+			}
+			if err != nil {
+				return fmt.Errorf("error inserting block 0x%x: %v", block.Root, err)
+			}
+			blockCount++
+		case strings.HasPrefix(header.Name, "blobs/"):
+			commitment, err := hex.DecodeString(strings.TrimSuffix(strings.TrimPrefix(header.Name, "blobs/0x"), ".bin"))
+			if err != nil {
+				return fmt.Errorf("invalid blob entry %v", header.Name)
+			}
+			blob := pendingBlobs[string(commitment)]
+			if blob == nil {
+				logger.Warnf("archive import: skipping blob 0x%x without metadata", commitment)
+				continue
+			}
+			if err := importArchiveBlob(blobStore, blob, data, tx); err != nil {
+				return err
+			}
+			delete(pendingBlobs, string(commitment))
+			blobCount++
+		}
+	}
+	if manifest == nil {
+		return fmt.Errorf("invalid archive: missing manifest")
+	}
+
+	// blobs whose data was not available on export
+	for _, blob := range pendingBlobs {
+		if err := importArchiveBlob(blobStore, blob, nil, tx); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+	logger.Infof("archive import: imported %v blocks and %v blobs (%v without data)", blockCount, blobCount, len(pendingBlobs))
+	return nil
+}
+
+func importArchiveBlob(blobStore *BlobStore, blob *archiveBlob, blobData []byte, tx *sqlx.Tx) error {
+	if len(blob.Assignments) == 0 {
+		return nil
+	}
+	dbBlob := &dbtypes.Blob{
+		Commitment: blob.Commitment,
+		Proof:      blob.Proof,
+		Size:       blob.Size,
+	}
+	if err := blobStore.storeBlob(dbBlob, blob.Assignments[0], blobData, tx); err != nil {
+		return fmt.Errorf("error importing blob 0x%x: %v", blob.Commitment, err)
+	}
+	for _, assignment := range blob.Assignments[1:] {
+		if err := db.InsertBlobAssignment(assignment, tx); err != nil {
+			return fmt.Errorf("error importing blob assignment 0x%x: %v", blob.Commitment, err)
+		}
+	}
+	return nil
+}
@@ -80,20 +80,26 @@ func (store *BlobStore) saveBlob(blob *deneb.BlobSidecar, tx *sqlx.Tx) error {
 		Commitment: blob.KzgCommitment[:],
 		Slot:       uint64(blob.Slot),
 	}
+	return store.storeBlob(dbBlob, dbBlobAssignment, blob.Blob[:], tx)
+}
+
+// storeBlob persists the blob data according to the persistence mode and adds the blob & assignment to the db.
+// blobData may be nil if the data isn't available, in which case only the db entries are written.
+func (store *BlobStore) storeBlob(dbBlob *dbtypes.Blob, dbBlobAssignment *dbtypes.BlobAssignment, blobData []byte, tx *sqlx.Tx) error {
 	blobName := store.getBlobName(dbBlob)
 
-	switch store.mode {
-	case blobPersistenceModeDb:
-		blobData := blob.Blob[:]
+	switch {
+	case blobData == nil:
+	case store.mode == blobPersistenceModeDb:
 		dbBlob.Blob = &blobData
-	case blobPersistenceModeFs:
+	case store.mode == blobPersistenceModeFs:
 		blobFile := path.Join(utils.Config.BlobStore.Fs.Path, blobName)
-		err := os.WriteFile(blobFile, blob.Blob[:], 0644)
+		err := os.WriteFile(blobFile, blobData, 0644)
 		if err != nil {
 			return fmt.Errorf("could not save blob to file '%v': %w", blobFile, err)
 		}
-	case blobPersistenceModeAws:
-		err := store.s3Store.Upload(blobName, blob.Blob[:])
+	case store.mode == blobPersistenceModeAws:
+		err := store.s3Store.Upload(blobName, blobData)
 		if err != nil {
 			return fmt.Errorf("could not upload blob to s3 '%v': %w", blobName, err)
 		}
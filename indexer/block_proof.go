@@ -0,0 +1,174 @@
+package indexer
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// BlockFieldProof is a merkle proof of a beacon block field against the block root.
+// The branch is ordered from the leaf up to the root, as expected by is_valid_merkle_branch.
+type BlockFieldProof struct {
+	Path   string
+	GIndex uint64
+	Depth  uint64
+	Leaf   []byte
+	Branch [][]byte
+	Root   []byte
+}
+
+// BuildBlockFieldProof builds the merkle proof of a field of the block message (eg. "body.execution_payload.block_hash").
+// Path segments are the snake case ssz field names or element indexes of lists (eg. "body.deposits.0").
+func BuildBlockFieldProof(block *spec.VersionedSignedBeaconBlock, path string) (*BlockFieldProof, error) {
+	var message ssz.HashRoot
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		message = block.Phase0.Message
+	case spec.DataVersionAltair:
+		message = block.Altair.Message
+	case spec.DataVersionBellatrix:
+		message = block.Bellatrix.Message
+	case spec.DataVersionCapella:
+		message = block.Capella.Message
+	case spec.DataVersionDeneb:
+		message = block.Deneb.Message
+	default:
+		return nil, fmt.Errorf("unknown block version")
+	}
+	return buildSszFieldProof(message, path)
+}
+
+// BuildBlockBodyFieldProof builds the merkle proof of a field of the block body against the body root (eg. "deposits.0"),
+// which is what operations are usually proven against.
+func BuildBlockBodyFieldProof(block *spec.VersionedSignedBeaconBlock, path string) (*BlockFieldProof, error) {
+	var body ssz.HashRoot
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		body = block.Phase0.Message.Body
+	case spec.DataVersionAltair:
+		body = block.Altair.Message.Body
+	case spec.DataVersionBellatrix:
+		body = block.Bellatrix.Message.Body
+	case spec.DataVersionCapella:
+		body = block.Capella.Message.Body
+	case spec.DataVersionDeneb:
+		body = block.Deneb.Message.Body
+	default:
+		return nil, fmt.Errorf("unknown block version")
+	}
+	return buildSszFieldProof(body, path)
+}
+
+func buildSszFieldProof(object ssz.HashRoot, path string) (*BlockFieldProof, error) {
+	gindex, depth, err := getSszFieldGIndex(reflect.TypeOf(object), path)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := object.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("error hashing block: %v", err)
+	}
+	tree, err := object.GetTree()
+	if err != nil {
+		return nil, fmt.Errorf("error building block tree: %v", err)
+	}
+	// the proof tree is built by a separate hasher, so don't hand out proofs if it doesn't match the block root
+	if !bytes.Equal(tree.Hash(), root[:]) {
+		return nil, fmt.Errorf("block tree root mismatch")
+	}
+	proof, err := tree.Prove(int(gindex))
+	if err != nil {
+		return nil, fmt.Errorf("error building proof: %v", err)
+	}
+	if valid, err := ssz.VerifyProof(root[:], proof); err != nil || !valid {
+		return nil, fmt.Errorf("proof verification failed: %v", err)
+	}
+
+	return &BlockFieldProof{
+		Path:   path,
+		GIndex: gindex,
+		Depth:  depth,
+		Leaf:   proof.Leaf,
+		Branch: proof.Hashes,
+		Root:   root[:],
+	}, nil
+}
+
+// getSszFieldGIndex returns the generalized index & depth of a nested container field.
+// Each container field is a leaf of a tree padded to the next power of two of the field count.
+// List elements are leafs of a tree padded to the list limit, which is the left child of the length mix-in.
+func getSszFieldGIndex(containerType reflect.Type, path string) (uint64, uint64, error) {
+	gindex := uint64(1)
+	depth := uint64(0)
+	var fieldTag reflect.StructTag
+	for _, segment := range strings.Split(path, ".") {
+		for containerType.Kind() == reflect.Ptr {
+			containerType = containerType.Elem()
+		}
+		if containerType.Kind() == reflect.Slice {
+			elementIndex, err := strconv.ParseUint(segment, 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid list index %v", segment)
+			}
+			elementType := containerType.Elem()
+			if elementType.Kind() == reflect.Ptr {
+				elementType = elementType.Elem()
+			}
+			if elementType.Kind() != reflect.Struct {
+				return 0, 0, fmt.Errorf("only elements of container lists can be proven")
+			}
+			limitTag := fieldTag.Get("ssz-max")
+			if limitTag == "" {
+				return 0, 0, fmt.Errorf("list %v has no limit", segment)
+			}
+			limit, err := strconv.ParseUint(strings.Split(limitTag, ",")[0], 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid list limit %v", limitTag)
+			}
+			if elementIndex >= limit {
+				return 0, 0, fmt.Errorf("list index %v exceeds the list limit %v", elementIndex, limit)
+			}
+
+			listDepth := uint64(0)
+			for (1 << listDepth) < limit {
+				listDepth++
+			}
+			gindex = (gindex<<1)<<listDepth | elementIndex
+			depth += 1 + listDepth
+			containerType = elementType
+			fieldTag = ""
+			continue
+		}
+		if containerType.Kind() != reflect.Struct {
+			return 0, 0, fmt.Errorf("field %v is not in a container", segment)
+		}
+
+		fieldName := strings.ReplaceAll(segment, "_", "")
+		fieldIndex := -1
+		for i := 0; i < containerType.NumField(); i++ {
+			if strings.EqualFold(containerType.Field(i).Name, fieldName) {
+				fieldIndex = i
+				break
+			}
+		}
+		if fieldIndex < 0 {
+			return 0, 0, fmt.Errorf("unknown field %v in %v", segment, containerType.Name())
+		}
+
+		fieldDepth := uint64(0)
+		for (1 << fieldDepth) < containerType.NumField() {
+			fieldDepth++
+		}
+		gindex = gindex<<fieldDepth | uint64(fieldIndex)
+		depth += fieldDepth
+		fieldTag = containerType.Field(fieldIndex).Tag
+		containerType = containerType.Field(fieldIndex).Type
+	}
+	return gindex, depth, nil
+}
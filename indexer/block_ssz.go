@@ -1,6 +1,7 @@
 package indexer
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/attestantio/go-eth2-client/spec"
@@ -69,3 +70,26 @@ func UnmarshalVersionedSignedBeaconBlockSSZ(version uint64, ssz []byte) (*spec.V
 	}
 	return block, nil
 }
+
+func MarshalVersionedSignedBeaconBlockJson(block *spec.VersionedSignedBeaconBlock) (version uint64, jsonRes []byte, err error) {
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		version = uint64(block.Version)
+		jsonRes, err = json.Marshal(block.Phase0)
+	case spec.DataVersionAltair:
+		version = uint64(block.Version)
+		jsonRes, err = json.Marshal(block.Altair)
+	case spec.DataVersionBellatrix:
+		version = uint64(block.Version)
+		jsonRes, err = json.Marshal(block.Bellatrix)
+	case spec.DataVersionCapella:
+		version = uint64(block.Version)
+		jsonRes, err = json.Marshal(block.Capella)
+	case spec.DataVersionDeneb:
+		version = uint64(block.Version)
+		jsonRes, err = json.Marshal(block.Deneb)
+	default:
+		err = fmt.Errorf("unknown block version")
+	}
+	return
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
 	"github.com/pk910/dora/utils"
 )
 
@@ -23,8 +24,12 @@ type indexerCache struct {
 	justifiedEpoch          int64
 	justifiedRoot           []byte
 	prefillEpoch            int64
+	processingMutex         sync.Mutex
 	processedEpoch          int64
 	processingRetry         uint64
+	epochQueueMutex         sync.Mutex
+	epochQueueEnd           int64
+	epochQueueRunning       bool
 	persistEpoch            int64
 	cleanupBlockEpoch       int64
 	cleanupStatsEpoch       int64
@@ -34,7 +39,15 @@ type indexerCache struct {
 	epochStatsMap           map[uint64][]*EpochStats
 	lastValidatorsEpoch     int64
 	lastValidatorsResp      map[phase0.ValidatorIndex]*v1.Validator
+	validatorBalancesMutex  sync.Mutex
+	lastValidatorBalances   *ValidatorBalances
+	statusTracker           validatorStatusTracker
+	pubkeyIndexMutex        sync.Mutex
+	pubkeyIndexEpoch        int64
+	pubkeyIndex             map[string]uint64
 	genesisResp             *v1.Genesis
+	chainGenesisMutex       sync.Mutex
+	chainGenesis            *dbtypes.IndexerGenesisState
 	validatorLoadingLimiter chan int
 }
 
@@ -52,6 +65,7 @@ func newIndexerCache(indexer *Indexer) *indexerCache {
 		justifiedEpoch:          -1,
 		prefillEpoch:            -1,
 		processedEpoch:          -2,
+		epochQueueEnd:           -1,
 		persistEpoch:            -1,
 		cleanupBlockEpoch:       -1,
 		cleanupStatsEpoch:       -1,
@@ -59,10 +73,12 @@ func newIndexerCache(indexer *Indexer) *indexerCache {
 		rootMap:                 make(map[string]*CacheBlock),
 		epochStatsMap:           make(map[uint64][]*EpochStats),
 		lastValidatorsEpoch:     -1,
+		pubkeyIndexEpoch:        -1,
 		validatorLoadingLimiter: make(chan int, valsetConcurrencyLimit),
 	}
 	cache.loadStoredUnfinalizedCache()
-	go cache.runCacheLoop()
+	go utils.RunWithRestart("runCacheLoop", cache.runCacheLoop)
+	go utils.RunWithRestart("runEpochRepairLoop", cache.runEpochRepairLoop)
 	return cache
 }
 
@@ -95,10 +111,12 @@ func (cache *indexerCache) setFinalizedHead(finalizedEpoch int64, finalizedRoot
 	defer cache.cacheMutex.Unlock()
 
 	if justifiedEpoch > cache.justifiedEpoch {
+		cache.recordFinalityTransition(dbtypes.FinalityCheckpointJustified, cache.justifiedEpoch, justifiedEpoch, justifiedRoot)
 		cache.justifiedEpoch = justifiedEpoch
 		cache.justifiedRoot = justifiedRoot
 	}
 	if finalizedEpoch > cache.finalizedEpoch {
+		cache.recordFinalityTransition(dbtypes.FinalityCheckpointFinalized, cache.finalizedEpoch, finalizedEpoch, finalizedRoot)
 		cache.finalizedEpoch = finalizedEpoch
 		cache.finalizedRoot = finalizedRoot
 
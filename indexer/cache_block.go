@@ -1,12 +1,16 @@
 package indexer
 
 import (
+	"encoding/json"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pk910/dora/db"
 	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/rpc"
 )
 
 type CacheBlock struct {
@@ -24,6 +28,24 @@ type CacheBlock struct {
 
 	dbBlockMutex sync.Mutex
 	dbBlockCache *dbtypes.Block
+
+	arrivalMutex sync.Mutex
+	arrivals     []*BlockArrival
+
+	dataColumnsMutex sync.Mutex
+	dataColumns      []*BlockDataColumns
+	dataColumnsTime  time.Time
+
+	witness               *rpc.ExecutionWitnessStats
+	depositReceipts       []*rpc.DepositReceipt
+	consolidationRequests []*rpc.ConsolidationRequest
+}
+
+// BlockArrival is the time a client announced the block via its event stream
+type BlockArrival struct {
+	ClientIndex uint8
+	ClientName  string
+	Time        time.Time
 }
 
 func (cache *indexerCache) getCachedBlock(root []byte) *CacheBlock {
@@ -137,6 +159,54 @@ func (block *CacheBlock) GetHeader() *phase0.SignedBeaconBlockHeader {
 	return block.header
 }
 
+// setCustomFields processes the custom fork fields of the block. The fields are not kept in the cache,
+// only the execution witness stats, deposit receipts & consolidation requests are needed for the charts & request pages.
+func (block *CacheBlock) setCustomFields(customFields map[string]json.RawMessage) {
+	if witnessData := customFields[rpc.ExecutionWitnessField]; witnessData != nil {
+		witness, err := rpc.ParseExecutionWitness(witnessData)
+		if err != nil {
+			logger.Warnf("error parsing execution witness of block %v [0x%x]: %v", block.Slot, block.Root, err)
+		} else {
+			block.witness = witness
+		}
+	}
+
+	depositReceipts, err := rpc.ParseDepositReceipts(customFields)
+	if err != nil {
+		logger.Warnf("error parsing deposit receipts of block %v [0x%x]: %v", block.Slot, block.Root, err)
+	} else {
+		block.depositReceipts = depositReceipts
+	}
+
+	consolidationRequests, err := rpc.ParseConsolidationRequests(customFields)
+	if err != nil {
+		logger.Warnf("error parsing consolidation requests of block %v [0x%x]: %v", block.Slot, block.Root, err)
+	} else {
+		block.consolidationRequests = consolidationRequests
+	}
+}
+
+// GetWitnessStats returns the execution witness stats of blocks on stateless devnets
+func (block *CacheBlock) GetWitnessStats() *rpc.ExecutionWitnessStats {
+	block.mutex.RLock()
+	defer block.mutex.RUnlock()
+	return block.witness
+}
+
+// GetDepositReceipts returns the EIP-6110 deposit receipts of the execution payload on electra devnets
+func (block *CacheBlock) GetDepositReceipts() []*rpc.DepositReceipt {
+	block.mutex.RLock()
+	defer block.mutex.RUnlock()
+	return block.depositReceipts
+}
+
+// GetConsolidationRequests returns the EIP-7251 consolidation requests of the block on electra devnets
+func (block *CacheBlock) GetConsolidationRequests() []*rpc.ConsolidationRequest {
+	block.mutex.RLock()
+	defer block.mutex.RUnlock()
+	return block.consolidationRequests
+}
+
 func (block *CacheBlock) GetBlockBody() *spec.VersionedSignedBeaconBlock {
 	block.mutex.RLock()
 	defer block.mutex.RUnlock()
@@ -171,3 +241,32 @@ func (block *CacheBlock) IsCanonical(indexer *Indexer, head []byte) bool {
 func (block *CacheBlock) IsReady() bool {
 	return block.header != nil && (block.block != nil || block.isInDb)
 }
+
+// recordArrival stores the first time the client announced the block
+func (block *CacheBlock) recordArrival(client *IndexerClient, arrival time.Time) {
+	block.arrivalMutex.Lock()
+	defer block.arrivalMutex.Unlock()
+	for _, blockArrival := range block.arrivals {
+		if blockArrival.ClientIndex == client.clientIdx {
+			return
+		}
+	}
+	block.arrivals = append(block.arrivals, &BlockArrival{
+		ClientIndex: client.clientIdx,
+		ClientName:  client.clientName,
+		Time:        arrival,
+	})
+}
+
+// GetArrivals returns the per client arrival times of the block, earliest first
+func (block *CacheBlock) GetArrivals() []*BlockArrival {
+	block.arrivalMutex.Lock()
+	arrivals := make([]*BlockArrival, len(block.arrivals))
+	copy(arrivals, block.arrivals)
+	block.arrivalMutex.Unlock()
+
+	sort.Slice(arrivals, func(a, b int) bool {
+		return arrivals[a].Time.Before(arrivals[b].Time)
+	})
+	return arrivals
+}
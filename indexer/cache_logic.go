@@ -8,12 +8,11 @@ import (
 
 	"github.com/pk910/dora/db"
 	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/rpc"
 	"github.com/pk910/dora/utils"
 )
 
 func (cache *indexerCache) runCacheLoop() {
-	defer utils.HandleSubroutinePanic("runCacheLoop")
-
 	for {
 		select {
 		case <-cache.triggerChan:
@@ -33,6 +32,10 @@ func (cache *indexerCache) runCacheLogic() error {
 		return nil
 	}
 
+	// the epoch queue worker holds the processing lock while processing an epoch
+	cache.processingMutex.Lock()
+	defer cache.processingMutex.Unlock()
+
 	var processingEpoch int64
 	headEpoch := int64(utils.EpochOfSlot(uint64(cache.highestSlot)))
 	if cache.indexer.writeDb {
@@ -58,7 +61,7 @@ func (cache *indexerCache) runCacheLogic() error {
 		}
 
 		logger.Debugf("check finalized processing %v < %v", cache.processedEpoch, cache.finalizedEpoch)
-		if cache.processedEpoch < cache.finalizedEpoch {
+		if cache.processedEpoch < cache.finalizedEpoch && !cache.queueFinalizedEpochs() {
 			// process finalized epochs
 			err := cache.processFinalizedEpochs()
 			if err != nil {
@@ -75,7 +78,11 @@ func (cache *indexerCache) runCacheLogic() error {
 		}
 		processingEpoch = cache.processedEpoch
 	} else {
+		// nothing is written to the db, so the finalized epochs are kept in memory until they leave the in-memory window
 		processingEpoch = cache.finalizedEpoch
+		if pruneEpoch := headEpoch - int64(cache.indexer.inMemoryEpochs); pruneEpoch < processingEpoch {
+			processingEpoch = pruneEpoch
+		}
 	}
 
 	if cache.persistEpoch < headEpoch {
@@ -105,24 +112,34 @@ func (cache *indexerCache) processFinalizedEpochs() error {
 		return nil
 	}
 	for cache.processedEpoch < cache.finalizedEpoch {
-		processEpoch := uint64(cache.processedEpoch + 1)
-		err := cache.processFinalizedEpoch(processEpoch)
+		err := cache.processNextFinalizedEpoch()
 		if err != nil {
-			cache.processingRetry++
-			if cache.processingRetry < 6 {
-				return err
-			} else {
-				logger.Warnf("epoch %v processing error: %v", processEpoch, err)
-				logger.Warnf("epoch %v processing failed repeatedly. skipping processing & starting synchronizer", processEpoch)
-				cache.startSynchronizer(processEpoch)
-			}
+			return err
 		}
-		cache.processedEpoch = int64(processEpoch)
-		cache.processingRetry = 0
 	}
 	return nil
 }
 
+// processNextFinalizedEpoch processes the epoch after the last processed one.
+// Epochs that fail repeatedly are skipped and left to the synchronizer.
+func (cache *indexerCache) processNextFinalizedEpoch() error {
+	processEpoch := uint64(cache.processedEpoch + 1)
+	err := cache.processFinalizedEpoch(processEpoch)
+	if err != nil {
+		cache.processingRetry++
+		if cache.processingRetry < 6 {
+			return err
+		} else {
+			logger.Warnf("epoch %v processing error: %v", processEpoch, err)
+			logger.Warnf("epoch %v processing failed repeatedly. skipping processing & starting synchronizer", processEpoch)
+			cache.startSynchronizer(processEpoch)
+		}
+	}
+	cache.processedEpoch = int64(processEpoch)
+	cache.processingRetry = 0
+	return nil
+}
+
 func (cache *indexerCache) processFinalizedEpoch(epoch uint64) error {
 	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
 	firstBlock := cache.getFirstCanonicalBlock(epoch, nil)
@@ -165,10 +182,19 @@ func (cache *indexerCache) processFinalizedEpoch(epoch uint64) error {
 
 			blobRsp, err := client.rpcClient.GetBlobSidecarsByBlockroot(block.Root)
 			if err != nil {
+				if errClass := rpc.ClassifyError(err); errClass == rpc.ErrorClassNotFound || errClass == rpc.ErrorClassUnsupported {
+					logger.Warnf("skipping blobs for block 0x%x: %v", block.Root, err)
+					continue
+				}
 				return fmt.Errorf("cannot load blobs for block 0x%x: %v", block.Root, err)
 			}
 			blobs = append(blobs, blobRsp...)
 		}
+
+		// record the data column availability before the block leaves the cache
+		if block.hasDataColumns() {
+			block.loadDataColumns(cache.indexer, true)
+		}
 	}
 	if len(blobs) > 0 {
 		logger.Infof("epoch %v blobs: %v blob sidecars in %v blocks", epoch, len(blobs), slotsWithBlobs)
@@ -179,6 +205,15 @@ func (cache *indexerCache) processFinalizedEpoch(epoch uint64) error {
 		canonicalMap[slot] = block
 	}
 
+	// the lazy validator loading might have given up, try once more before the epoch gets persisted as partial
+	if epochStats != nil && client != nil && !client.skipValidators {
+		if stateRef := epochStats.GetDependentStateRef(); stateRef != "" {
+			if err := epochStats.loadValidatorStats(client, stateRef); err != nil {
+				logger.Warnf("could not load validators for epoch %v, persisting as partial epoch: %v", epoch, err)
+			}
+		}
+	}
+
 	// store canonical blocks to db and remove from cache
 	tx, err := db.WriterDb.Beginx()
 	if err != nil {
@@ -197,9 +232,10 @@ func (cache *indexerCache) processFinalizedEpoch(epoch uint64) error {
 		}
 	}
 
+	var epochVotes *EpochVotes
 	if epochStats != nil {
 		// calculate votes
-		epochVotes := aggregateEpochVotes(canonicalMap, epoch, epochStats, epochTarget, false, true)
+		epochVotes = cache.indexer.epochPipeline.aggregateEpochVotes(canonicalMap, epoch, epochStats, epochTarget, false, true)
 
 		if epochStats.validatorStats != nil {
 			logger.Infof("epoch %v stats: %v validators (%v)", epoch, epochStats.validatorStats.ValidatorCount, epochStats.validatorStats.EligibleAmount)
@@ -208,12 +244,26 @@ func (cache *indexerCache) processFinalizedEpoch(epoch uint64) error {
 		logger.Infof("epoch %v votes: head %v + %v = %v", epoch, epochVotes.currentEpoch.headVoteAmount, epochVotes.nextEpoch.headVoteAmount, epochVotes.currentEpoch.headVoteAmount+epochVotes.nextEpoch.headVoteAmount)
 		logger.Infof("epoch %v votes: total %v + %v = %v", epoch, epochVotes.currentEpoch.totalVoteAmount, epochVotes.nextEpoch.totalVoteAmount, epochVotes.currentEpoch.totalVoteAmount+epochVotes.nextEpoch.totalVoteAmount)
 
-		err = persistEpochData(epoch, canonicalMap, epochStats, epochVotes, tx)
+		err = cache.indexer.epochPipeline.persistEpoch(epoch, canonicalMap, epochStats, epochVotes, tx)
 		if err != nil {
 			logger.Errorf("error persisting epoch data to db: %v", err)
 			return err
 		}
 
+		// check all forks for conflicting votes (doppelgangers)
+		err = cache.persistDoppelgangerVotes(epoch, epochStats, tx)
+		if err != nil {
+			logger.Errorf("error persisting doppelganger votes to db: %v", err)
+			return err
+		}
+
+		// record the validator lifecycle transitions
+		err = cache.persistValidatorStatusChanges(epoch, tx)
+		if err != nil {
+			logger.Errorf("error persisting validator status changes to db: %v", err)
+			return err
+		}
+
 		if len(epochStats.syncAssignments) > 0 {
 			err = persistSyncAssignments(epoch, epochStats, tx)
 			if err != nil {
@@ -245,12 +295,17 @@ func (cache *indexerCache) processFinalizedEpoch(epoch uint64) error {
 		return err
 	}
 
+	cache.dispatchEpochActivity(epoch, canonicalMap, epochStats, epochVotes)
+
 	// remove canonical blocks from cache
+	finalizedBlocks := []*CacheBlock{}
 	for slot, block := range canonicalMap {
 		if utils.EpochOfSlot(slot) == epoch {
+			finalizedBlocks = append(finalizedBlocks, block)
 			cache.removeCachedBlock(block)
 		}
 	}
+	cache.dispatchFinalizedBlocks(epoch, finalizedBlocks)
 
 	return nil
 }
@@ -299,7 +354,17 @@ func (cache *indexerCache) processOrphanedBlocks(processedEpoch int64) error {
 		if !block.IsReady() {
 			continue
 		}
-		dbBlock := buildDbBlock(block, cache.getEpochStats(utils.EpochOfSlot(block.Slot), nil))
+		// the duties of another fork are good enough for the sync participation, but the stored dependent root
+		// needs to be the one the proposer of the orphaned block has been assigned with
+		epochStats := cache.getEpochStatsForBlock(utils.EpochOfSlot(block.Slot), block.Root)
+		forkEpochStats := epochStats == nil
+		if forkEpochStats {
+			epochStats = cache.getEpochStats(utils.EpochOfSlot(block.Slot), nil)
+		}
+		dbBlock := buildDbBlock(block, epochStats)
+		if forkEpochStats && dbBlock != nil {
+			dbBlock.ProposerDependentRoot = nil
+		}
 		if block.IsCanonical(cache.indexer, cache.justifiedRoot) {
 			logger.Warnf("canonical block in orphaned block processing: %v [0x%x]", block.Slot, block.Root)
 		} else {
@@ -315,10 +380,13 @@ func (cache *indexerCache) processOrphanedBlocks(processedEpoch int64) error {
 	}
 
 	// remove blocks from cache
+	finalizedBlocks := make([]*CacheBlock, 0, len(cachedBlocks))
 	for _, block := range cachedBlocks {
+		finalizedBlocks = append(finalizedBlocks, block)
 		cache.removeCachedBlock(block)
 	}
 	cache.resetLowestSlot()
+	cache.dispatchFinalizedBlocks(uint64(processedEpoch), finalizedBlocks)
 
 	return nil
 }
@@ -474,7 +542,8 @@ func (cache *indexerCache) processCacheCleanup(processedEpoch int64, headEpoch i
 	cache.cacheMutex.RUnlock()
 	cache.epochStatsMutex.RLock()
 	for epoch, stats := range cache.epochStatsMap {
-		if int64(epoch) <= processedEpoch || int64(epoch) <= headEpoch-int64(cache.indexer.inMemoryEpochs) {
+		// finalized epochs that are still queued for processing need their stats, so only unfinalized epochs are dropped by age
+		if int64(epoch) <= processedEpoch || (int64(epoch) <= headEpoch-int64(cache.indexer.inMemoryEpochs) && int64(epoch) > cache.finalizedEpoch) {
 			clearStats = append(clearStats, stats...)
 		}
 	}
@@ -0,0 +1,169 @@
+package indexer
+
+import (
+	"sync"
+
+	"github.com/pk910/dora/utils"
+)
+
+const (
+	ChainChangeReorg     = "reorg"
+	ChainChangeFinalized = "finalized"
+	ChainChangeReset     = "reset"
+)
+
+// ChainChangeEvent reports blocks that changed their canonical or finalized state.
+// Reorg events contain the blocks of the previous and the new canonical branch above the common ancestor,
+// finalized events contain the blocks of a finalized epoch that have been moved from the cache to the db.
+// Reset events have no blocks, they're sent after all data of the previous chain has been cleared.
+type ChainChangeEvent struct {
+	Type   string
+	Epoch  uint64
+	Blocks []*ChainChangeBlock
+}
+
+type ChainChangeBlock struct {
+	Slot     uint64
+	Root     []byte
+	Proposer uint64
+}
+
+// ChainChangeSubscription receives the reorg, finalization & reset events of the indexer.
+// Slow receivers lose events instead of blocking the indexer.
+type ChainChangeSubscription struct {
+	indexer *Indexer
+	Events  chan *ChainChangeEvent
+}
+
+type chainChangeDispatcher struct {
+	mutex         sync.RWMutex
+	subscriptions map[*ChainChangeSubscription]bool
+	headMutex     sync.Mutex
+	lastHeadRoot  []byte
+}
+
+// SubscribeChainChanges creates a new subscription for reorg, finalization & reset events
+func (indexer *Indexer) SubscribeChainChanges() *ChainChangeSubscription {
+	subscription := &ChainChangeSubscription{
+		indexer: indexer,
+		Events:  make(chan *ChainChangeEvent, 32),
+	}
+	indexer.chainChanges.mutex.Lock()
+	defer indexer.chainChanges.mutex.Unlock()
+	indexer.chainChanges.subscriptions[subscription] = true
+	return subscription
+}
+
+// Unsubscribe stops event delivery for this subscription
+func (subscription *ChainChangeSubscription) Unsubscribe() {
+	subscription.indexer.chainChanges.mutex.Lock()
+	defer subscription.indexer.chainChanges.mutex.Unlock()
+	delete(subscription.indexer.chainChanges.subscriptions, subscription)
+}
+
+func (dispatcher *chainChangeDispatcher) hasSubscriptions() bool {
+	dispatcher.mutex.RLock()
+	defer dispatcher.mutex.RUnlock()
+	return len(dispatcher.subscriptions) > 0
+}
+
+func (dispatcher *chainChangeDispatcher) publish(event *ChainChangeEvent) {
+	dispatcher.mutex.RLock()
+	defer dispatcher.mutex.RUnlock()
+	for subscription := range dispatcher.subscriptions {
+		select {
+		case subscription.Events <- event:
+		default:
+			logger.Debugf("chain change subscription queue full, dropped %v event", event.Type)
+		}
+	}
+}
+
+// checkCanonicalReorg compares the canonical head with the previous one and publishes a reorg event
+// if the new head doesn't descend from the previous head
+func (indexer *Indexer) checkCanonicalReorg() {
+	dispatcher := &indexer.chainChanges
+	if !dispatcher.hasSubscriptions() {
+		return
+	}
+	_, headRoot := indexer.GetCanonicalHead()
+	if headRoot == nil {
+		return
+	}
+
+	dispatcher.headMutex.Lock()
+	lastHeadRoot := dispatcher.lastHeadRoot
+	dispatcher.lastHeadRoot = headRoot
+	dispatcher.headMutex.Unlock()
+
+	cache := indexer.indexerCache
+	if lastHeadRoot == nil || cache.isCanonicalBlock(lastHeadRoot, headRoot) {
+		return
+	}
+	lastHead := cache.getCachedBlock(lastHeadRoot)
+	if lastHead == nil {
+		return
+	}
+
+	// blocks of the previous canonical branch down to the common ancestor
+	blocks := []*ChainChangeBlock{}
+	ancestorSlot := uint64(0)
+	for block := lastHead; block != nil; {
+		if cache.isCanonicalBlock(block.Root, headRoot) {
+			ancestorSlot = block.Slot
+			break
+		}
+		blocks = append(blocks, block.buildChainChangeBlock())
+		parentRoot := block.GetParentRoot()
+		if parentRoot == nil {
+			break
+		}
+		block = cache.getCachedBlock(parentRoot)
+	}
+	orphanedCount := len(blocks)
+
+	// blocks of the new canonical branch
+	for block := cache.getCachedBlock(headRoot); block != nil && block.Slot > ancestorSlot; {
+		blocks = append(blocks, block.buildChainChangeBlock())
+		parentRoot := block.GetParentRoot()
+		if parentRoot == nil {
+			break
+		}
+		block = cache.getCachedBlock(parentRoot)
+	}
+
+	logger.Infof("canonical head reorg: %v blocks orphaned, %v blocks canonical (common ancestor slot %v)", orphanedCount, len(blocks)-orphanedCount, ancestorSlot)
+	dispatcher.publish(&ChainChangeEvent{
+		Type:   ChainChangeReorg,
+		Epoch:  utils.EpochOfSlot(ancestorSlot),
+		Blocks: blocks,
+	})
+}
+
+// dispatchFinalizedBlocks publishes the blocks that have been persisted while processing a finalized epoch
+func (cache *indexerCache) dispatchFinalizedBlocks(epoch uint64, blocks []*CacheBlock) {
+	dispatcher := &cache.indexer.chainChanges
+	if !dispatcher.hasSubscriptions() {
+		return
+	}
+	event := &ChainChangeEvent{
+		Type:   ChainChangeFinalized,
+		Epoch:  epoch,
+		Blocks: make([]*ChainChangeBlock, 0, len(blocks)),
+	}
+	for _, block := range blocks {
+		event.Blocks = append(event.Blocks, block.buildChainChangeBlock())
+	}
+	dispatcher.publish(event)
+}
+
+func (block *CacheBlock) buildChainChangeBlock() *ChainChangeBlock {
+	changeBlock := &ChainChangeBlock{
+		Slot: block.Slot,
+		Root: block.Root,
+	}
+	if header := block.GetHeader(); header != nil {
+		changeBlock.Proposer = uint64(header.Message.ProposerIndex)
+	}
+	return changeBlock
+}
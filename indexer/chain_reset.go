@@ -0,0 +1,149 @@
+package indexer
+
+import (
+	"bytes"
+	"fmt"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+// checkChainGenesis compares the genesis of a client with the genesis of the indexed chain.
+// a different genesis validators root means the chain has been relaunched (ephemeral devnets), which
+// either wipes all indexed data (indexer.resetDbOnChainChange) or rejects the client.
+func (cache *indexerCache) checkChainGenesis(genesis *v1.Genesis) error {
+	cache.chainGenesisMutex.Lock()
+	defer cache.chainGenesisMutex.Unlock()
+
+	if cache.chainGenesis == nil {
+		genesisState := &dbtypes.IndexerGenesisState{}
+		if _, err := db.GetExplorerState("indexer.genesis", genesisState); err == nil {
+			cache.chainGenesis = genesisState
+		}
+	}
+
+	if cache.chainGenesis == nil {
+		return cache.setChainGenesis(genesis)
+	}
+	if bytes.Equal(cache.chainGenesis.GenesisValidatorsRoot, genesis.GenesisValidatorsRoot[:]) {
+		return nil
+	}
+
+	if !utils.Config.Indexer.ResetDbOnChainChange || !cache.indexer.writeDb {
+		return fmt.Errorf("genesis validators root 0x%x does not match the indexed chain (0x%x), chain has been reset", genesis.GenesisValidatorsRoot[:], cache.chainGenesis.GenesisValidatorsRoot)
+	}
+
+	logger.Warnf("chain reset detected: genesis validators root changed from 0x%x to 0x%x, clearing indexed data", cache.chainGenesis.GenesisValidatorsRoot, genesis.GenesisValidatorsRoot[:])
+	if err := cache.resetChainData(); err != nil {
+		return fmt.Errorf("error resetting indexed chain data: %v", err)
+	}
+
+	genesisTime := uint64(genesis.GenesisTime.Unix())
+	if utils.Config.Chain.GenesisTimestamp != genesisTime {
+		logger.Infof("chain reset: shifting genesis time to %v", genesisTime)
+		utils.Config.Chain.GenesisTimestamp = genesisTime
+	}
+	return cache.setChainGenesis(genesis)
+}
+
+func (cache *indexerCache) setChainGenesis(genesis *v1.Genesis) error {
+	genesisState := &dbtypes.IndexerGenesisState{
+		GenesisTime:           uint64(genesis.GenesisTime.Unix()),
+		GenesisValidatorsRoot: genesis.GenesisValidatorsRoot[:],
+	}
+	if cache.indexer.writeDb {
+		tx, err := db.WriterDb.Beginx()
+		if err != nil {
+			return fmt.Errorf("error starting db transaction: %v", err)
+		}
+		defer tx.Rollback()
+
+		if err := db.SetExplorerState("indexer.genesis", genesisState, tx); err != nil {
+			return fmt.Errorf("error storing chain genesis: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("error committing db transaction: %v", err)
+		}
+	}
+	cache.chainGenesis = genesisState
+	return nil
+}
+
+// resetChainData stops the synchronizer, clears all chain data from the db and drops everything cached
+// for the previous chain, so the indexer starts over with the relaunched chain.
+// The subscribers get a reset event to drop their state of the previous chain too.
+func (cache *indexerCache) resetChainData() error {
+	cache.cacheMutex.Lock()
+	synchronizer := cache.synchronizer
+	cache.synchronizer = nil
+	cache.cacheMutex.Unlock()
+	if synchronizer != nil {
+		synchronizer.stopSync()
+	}
+
+	// wait for the epoch queue worker to finish its current epoch, it stops with the reset processing state
+	cache.processingMutex.Lock()
+	defer cache.processingMutex.Unlock()
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := db.ClearChainData(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+
+	cache.cacheMutex.Lock()
+	cache.highestSlot = -1
+	cache.lowestSlot = -1
+	cache.finalizedEpoch = -1
+	cache.finalizedRoot = nil
+	cache.justifiedEpoch = -1
+	cache.justifiedRoot = nil
+	cache.prefillEpoch = -1
+	cache.processedEpoch = -2
+	cache.processingRetry = 0
+	cache.epochQueueMutex.Lock()
+	cache.epochQueueEnd = -1
+	cache.epochQueueMutex.Unlock()
+	cache.persistEpoch = -1
+	cache.cleanupBlockEpoch = -1
+	cache.cleanupStatsEpoch = -1
+	cache.slotMap = make(map[uint64][]*CacheBlock)
+	cache.rootMap = make(map[string]*CacheBlock)
+	cache.lastValidatorsEpoch = -1
+	cache.lastValidatorsResp = nil
+	cache.genesisResp = nil
+	cache.cacheMutex.Unlock()
+
+	cache.epochStatsMutex.Lock()
+	cache.epochStatsMap = make(map[uint64][]*EpochStats)
+	cache.epochStatsMutex.Unlock()
+
+	cache.validatorBalancesMutex.Lock()
+	cache.lastValidatorBalances = nil
+	cache.validatorBalancesMutex.Unlock()
+
+	cache.pubkeyIndexMutex.Lock()
+	cache.pubkeyIndex = nil
+	cache.pubkeyIndexEpoch = -1
+	cache.pubkeyIndexMutex.Unlock()
+
+	dispatcher := &cache.indexer.chainChanges
+	dispatcher.headMutex.Lock()
+	dispatcher.lastHeadRoot = nil
+	dispatcher.headMutex.Unlock()
+	dispatcher.publish(&ChainChangeEvent{
+		Type: ChainChangeReset,
+	})
+
+	return nil
+}
@@ -2,6 +2,7 @@ package indexer
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -31,11 +32,14 @@ type IndexerClient struct {
 	retryCounter       uint64
 	lastHeadSlot       int64
 	lastHeadRoot       []byte
+	reorgCount         uint64
 	lastEpochStats     int64
 	lastFinalizedEpoch int64
 	lastFinalizedRoot  []byte
 	lastJustifiedEpoch int64
 	lastJustifiedRoot  []byte
+	pacer              clientPacer
+	resyncChan         chan bool
 }
 
 func newIndexerClient(clientIdx uint8, clientName string, rpcClient *rpc.BeaconClient, indexerCache *indexerCache, archive bool, priority int, skipValidators bool) *IndexerClient {
@@ -51,6 +55,7 @@ func newIndexerClient(clientIdx uint8, clientName string, rpcClient *rpc.BeaconC
 		lastEpochStats:     -1,
 		lastFinalizedEpoch: -1,
 		lastJustifiedEpoch: -1,
+		resyncChan:         make(chan bool, 1),
 	}
 	go client.runIndexerClientLoop()
 	return &client
@@ -104,7 +109,9 @@ func (client *IndexerClient) runIndexerClientLoop() {
 				// preload genesis validator set
 				if !client.skipValidators {
 					epochStats, _ := client.indexerCache.createOrGetEpochStats(0, nil)
-					epochStats.loadValidatorStats(client, "genesis")
+					if err := epochStats.loadValidatorStats(client, "genesis"); err != nil {
+						logger.WithField("client", client.clientName).Warnf("error fetching genesis validators: %v", err)
+					}
 				}
 
 				waitTime = int(time.Since(genesisTime).Abs().Seconds()) + 1
@@ -117,17 +124,21 @@ func (client *IndexerClient) runIndexerClientLoop() {
 				continue
 			}
 
-			err = client.runIndexerClient()
+			err = utils.CatchPanic("runIndexerClient", client.runIndexerClient)
 		}
 		if err == nil {
 			return
 		}
+		if errors.Is(err, errClientResync) {
+			logger.WithField("client", client.clientName).Infof("resyncing unfinalized chain from finalized checkpoint")
+			continue
+		}
 
 		client.retryCounter++
 		waitTime := 10
 		skipLog := false
 		if client.isOptimistic || client.isSynchronizing {
-			waitTime = 30
+			waitTime = int(client.getRetryInterval().Seconds())
 			skipLog = true
 		} else if client.retryCounter > 10 {
 			waitTime = 300
@@ -172,6 +183,10 @@ func (client *IndexerClient) checkIndexerClient() error {
 	if genesis == nil {
 		return fmt.Errorf("no genesis block found")
 	}
+	err = client.indexerCache.checkChainGenesis(genesis)
+	if err != nil {
+		return err
+	}
 	genesisTime := uint64(genesis.GenesisTime.Unix())
 	if genesisTime != utils.Config.Chain.GenesisTimestamp {
 		return fmt.Errorf("genesis time from RPC does not match the genesis time from explorer configuration")
@@ -229,7 +244,7 @@ func (client *IndexerClient) runIndexerClient() error {
 	client.retryCounter = 0
 
 	// start event stream
-	blockStream := client.rpcClient.NewBlockStream(rpc.StreamBlockEvent | rpc.StreamFinalizedEvent)
+	blockStream := client.rpcClient.NewBlockStream(rpc.StreamBlockEvent | rpc.StreamHeadEvent | rpc.StreamFinalizedEvent)
 	defer blockStream.Close()
 
 	// prefill cache
@@ -245,11 +260,12 @@ func (client *IndexerClient) runIndexerClient() error {
 	// process events
 	client.lastStreamEvent = time.Now()
 	for {
+		pollInterval := client.getPollInterval()
 		var eventTimeout time.Duration = time.Since(client.lastStreamEvent)
-		if eventTimeout > 30*time.Second {
+		if eventTimeout > pollInterval {
 			eventTimeout = 0
 		} else {
-			eventTimeout = 30*time.Second - eventTimeout
+			eventTimeout = pollInterval - eventTimeout
 		}
 		select {
 		case evt := <-blockStream.EventChan:
@@ -257,6 +273,9 @@ func (client *IndexerClient) runIndexerClient() error {
 			switch evt.Event {
 			case rpc.StreamBlockEvent:
 				client.processBlockEvent(evt.Data.(*v1.BlockEvent))
+			case rpc.StreamHeadEvent:
+				// head events are only re-broadcasted, the head is tracked via the block events
+				client.indexerCache.indexer.eventTap.publishHead(evt.Data.(*v1.HeadEvent))
 			case rpc.StreamFinalizedEvent:
 				client.processFinalizedEvent(evt.Data.(*v1.FinalizedCheckpointEvent))
 			}
@@ -271,8 +290,10 @@ func (client *IndexerClient) runIndexerClient() error {
 					logger.WithField("client", client.clientName).Debug("RPC event stream disconnected")
 				}
 			}
+		case <-client.resyncChan:
+			return errClientResync
 		case <-time.After(eventTimeout):
-			logger.WithField("client", client.clientName).Debug("no head event since 30 secs, polling chain head")
+			logger.WithField("client", client.clientName).Debugf("no head event since %v, polling chain head", pollInterval)
 			err := client.pollLatestBlocks()
 			if err != nil {
 				client.isConnected = false
@@ -339,7 +360,9 @@ func (client *IndexerClient) prefillCache(finalizedSlot uint64, latestHeader *v1
 			parentBlock.mutex.RUnlock()
 		}
 		if parentHead == nil {
+			t0 := time.Now()
 			headerRsp, err := client.rpcClient.GetBlockHeaderByBlockroot(parentRoot)
+			client.pacer.trackRequest(t0)
 			if err != nil {
 				return 0, fmt.Errorf("could not load parent header: %v", err)
 			}
@@ -374,6 +397,9 @@ func (client *IndexerClient) prefillCache(finalizedSlot uint64, latestHeader *v1
 			break
 		}
 		parentRoot = parentHead.Message.ParentRoot[:]
+		if delay := client.getBackfillDelay(); delay > 0 {
+			time.Sleep(delay)
+		}
 	}
 
 	// ensure epoch stats for loaded slots
@@ -395,7 +421,9 @@ func (client *IndexerClient) ensureBlock(block *CacheBlock, header *phase0.Signe
 	defer block.mutex.Unlock()
 	if block.header == nil {
 		if header == nil {
+			t0 := time.Now()
 			headerRsp, err := client.rpcClient.GetBlockHeaderByBlockroot(block.Root)
+			client.pacer.trackRequest(t0)
 			if err != nil {
 				logger.WithField("client", client.clientName).Warnf("ensure block %v [0x%x] failed (header): %v", block.Slot, block.Root, err)
 				return err
@@ -405,12 +433,15 @@ func (client *IndexerClient) ensureBlock(block *CacheBlock, header *phase0.Signe
 		block.header = header
 	}
 	if block.block == nil && !block.isInDb {
-		blockRsp, err := client.rpcClient.GetBlockBodyByBlockroot(block.Root)
+		t0 := time.Now()
+		blockRsp, customFields, err := client.rpcClient.GetBlockBodyWithCustomFields(block.Root)
+		client.pacer.trackRequest(t0)
 		if err != nil {
 			logger.WithField("client", client.clientName).Warnf("ensure block %v [0x%x] failed (block): %v", block.Slot, block.Root, err)
 			return err
 		}
 		block.block = blockRsp
+		block.setCustomFields(customFields)
 	}
 	// set seen flag
 	clientFlag := uint64(1) << client.clientIdx
@@ -420,7 +451,9 @@ func (client *IndexerClient) ensureBlock(block *CacheBlock, header *phase0.Signe
 
 func (client *IndexerClient) pollLatestBlocks() error {
 	// get latest header
+	t0 := time.Now()
 	latestHeader, err := client.rpcClient.GetLatestBlockHead()
+	client.pacer.trackRequest(t0)
 	if err != nil {
 		return fmt.Errorf("could not get latest header: %v", err)
 	}
@@ -468,7 +501,9 @@ func (client *IndexerClient) ensureParentBlocks(currentBlock *CacheBlock) error
 			}
 		}
 		if parentHead == nil {
+			t0 := time.Now()
 			headerRsp, err := client.rpcClient.GetBlockHeaderByBlockroot(parentRoot)
+			client.pacer.trackRequest(t0)
 			if err != nil {
 				return fmt.Errorf("could not load parent header [0x%x]: %v", parentRoot, err)
 			}
@@ -502,6 +537,9 @@ func (client *IndexerClient) ensureParentBlocks(currentBlock *CacheBlock) error
 			break
 		}
 		parentRoot = parentHead.Message.ParentRoot[:]
+		if delay := client.getBackfillDelay(); delay > 0 {
+			time.Sleep(delay)
+		}
 	}
 	return nil
 }
@@ -512,17 +550,27 @@ func (client *IndexerClient) setHeadBlock(root []byte, slot uint64) error {
 		client.cacheMutex.Unlock()
 		return nil
 	}
+	// the new head doesn't descend from the previous head, count as reorg if the previous head is still cached
+	if client.lastHeadRoot != nil && client.indexerCache.getCachedBlock(client.lastHeadRoot) != nil && !client.indexerCache.isCanonicalBlock(client.lastHeadRoot, root) {
+		client.reorgCount++
+		logger.WithField("client", client.clientName).Infof("head reorg from slot %v [0x%x] to slot %v [0x%x]", client.lastHeadSlot, client.lastHeadRoot, slot, root)
+	}
 	client.lastHeadSlot = int64(slot)
 	client.lastHeadRoot = root
 	client.cacheMutex.Unlock()
 
+	client.indexerCache.indexer.checkHeadDisagreement()
+	client.indexerCache.indexer.checkCanonicalReorg()
+	client.indexerCache.indexer.checkDeepReorg()
 	return nil
 }
 
 func (client *IndexerClient) processBlockEvent(evt *v1.BlockEvent) error {
 	currentBlock, isNewBlock := client.indexerCache.createOrGetCachedBlock(evt.Block[:], uint64(evt.Slot))
+	currentBlock.recordArrival(client, time.Now())
 	if isNewBlock {
 		logger.WithField("client", client.clientName).Infof("received block %v:%v [0x%x] stream", utils.EpochOfSlot(currentBlock.Slot), currentBlock.Slot, currentBlock.Root)
+		client.indexerCache.indexer.eventTap.publishBlock(evt)
 	} else {
 		logger.WithField("client", client.clientName).Debugf("received known block %v:%v [0x%x] stream", utils.EpochOfSlot(currentBlock.Slot), currentBlock.Slot, currentBlock.Root)
 	}
@@ -543,6 +591,7 @@ func (client *IndexerClient) processBlockEvent(evt *v1.BlockEvent) error {
 }
 
 func (client *IndexerClient) processFinalizedEvent(evt *v1.FinalizedCheckpointEvent) error {
+	client.indexerCache.indexer.eventTap.publishFinalized(evt)
 	time.Sleep(100 * time.Millisecond)
 	client.refreshFinalityCheckpoints()
 	logger.WithField("client", client.clientName).Debugf("received finalization_checkpoint event: finalized %v [0x%x], justified %v [0x%x]", client.lastFinalizedEpoch, client.lastFinalizedRoot, client.lastJustifiedEpoch, client.lastJustifiedRoot)
@@ -0,0 +1,113 @@
+package indexer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+// BlockDataColumns is the set of PeerDAS data columns a client was able to serve for a block
+type BlockDataColumns struct {
+	ClientIndex uint8
+	ClientName  string
+	Columns     []uint64
+	Error       string // set if the client could not be queried
+}
+
+// hasDataColumns returns true if the blobs of the block are distributed as data column sidecars
+func (block *CacheBlock) hasDataColumns() bool {
+	if !utils.IsPeerDASEpoch(utils.EpochOfSlot(block.Slot)) {
+		return false
+	}
+	blockBody := block.GetBlockBody()
+	if blockBody == nil {
+		return false
+	}
+	blobCommitments, _ := blockBody.BlobKzgCommitments()
+	return len(blobCommitments) > 0
+}
+
+// loadDataColumns queries the data columns of the block from all ready clients.
+// Each client only serves the columns it custodies (or has reconstructed), so the union of all clients shows which
+// columns can be retrieved from the network. The result is reused for a slot, unless a refresh is forced.
+func (block *CacheBlock) loadDataColumns(indexer *Indexer, forceRefresh bool) []*BlockDataColumns {
+	block.dataColumnsMutex.Lock()
+	defer block.dataColumnsMutex.Unlock()
+
+	slotDuration := time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
+	if block.dataColumns != nil && !forceRefresh && time.Since(block.dataColumnsTime) < slotDuration {
+		return block.dataColumns
+	}
+
+	dataColumns := []*BlockDataColumns{}
+	for _, client := range indexer.GetReadyClients(false, block.Root) {
+		clientColumns := &BlockDataColumns{
+			ClientIndex: client.clientIdx,
+			ClientName:  client.clientName,
+		}
+		sidecars, err := client.rpcClient.GetDataColumnSidecarsByBlockroot(block.Root)
+		if err != nil {
+			logger.Debugf("could not load data columns for block 0x%x from %v: %v", block.Root, client.clientName, err)
+			clientColumns.Error = err.Error()
+		}
+		for _, sidecar := range sidecars {
+			clientColumns.Columns = append(clientColumns.Columns, sidecar.Index)
+		}
+		sort.Slice(clientColumns.Columns, func(a, b int) bool {
+			return clientColumns.Columns[a] < clientColumns.Columns[b]
+		})
+		dataColumns = append(dataColumns, clientColumns)
+	}
+
+	block.dataColumns = dataColumns
+	block.dataColumnsTime = time.Now()
+	return dataColumns
+}
+
+// GetBlockDataColumns returns the data columns each client served for the block, live from the clients for cached
+// blocks or as recorded when the block was finalized.
+func (indexer *Indexer) GetBlockDataColumns(root []byte) []*dbtypes.BlockDataColumns {
+	cachedBlock := indexer.GetCachedBlock(root)
+	if cachedBlock == nil {
+		return db.GetBlockDataColumns(root)
+	}
+	if !cachedBlock.hasDataColumns() {
+		return nil
+	}
+	return buildDbBlockDataColumns(cachedBlock, cachedBlock.loadDataColumns(indexer, false))
+}
+
+func buildDbBlockDataColumns(block *CacheBlock, dataColumns []*BlockDataColumns) []*dbtypes.BlockDataColumns {
+	dbDataColumns := make([]*dbtypes.BlockDataColumns, 0, len(dataColumns))
+	for _, clientColumns := range dataColumns {
+		if clientColumns.Error != "" {
+			continue
+		}
+		dbDataColumns = append(dbDataColumns, &dbtypes.BlockDataColumns{
+			Root:    block.Root,
+			Slot:    block.Slot,
+			Client:  clientColumns.ClientName,
+			Columns: EncodeDataColumnBitfield(clientColumns.Columns),
+		})
+	}
+	return dbDataColumns
+}
+
+// EncodeDataColumnBitfield encodes the column indexes as bitfield (bit i%8 of byte i/8 is set for column i)
+func EncodeDataColumnBitfield(columns []uint64) []byte {
+	bitfield := make([]byte, (utils.NumberOfDataColumns()+7)/8)
+	for _, column := range columns {
+		if column/8 < uint64(len(bitfield)) {
+			bitfield[column/8] |= 1 << (column % 8)
+		}
+	}
+	return bitfield
+}
+
+// DataColumnBitfieldHas returns true if the column is set in the bitfield
+func DataColumnBitfieldHas(bitfield []byte, column uint64) bool {
+	return column/8 < uint64(len(bitfield)) && bitfield[column/8]&(1<<(column%8)) != 0
+}
@@ -0,0 +1,121 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+type doppelgangerVote struct {
+	voteRoot  phase0.Root
+	slot      uint64
+	blockRoot []byte
+}
+
+// findDoppelgangerVotes looks for validators that signed two different votes for the same epoch, which
+// honest validators never do and usually means the key is used by more than one validator client.
+// All cached blocks are checked, as the conflicting vote often only gets included in a fork seen by another endpoint.
+// Blocks that are not based on the dependent root of the epoch are skipped, as their committees differ.
+func (cache *indexerCache) findDoppelgangerVotes(epoch uint64, epochStats *EpochStats) []*dbtypes.ValidatorDoppelganger {
+	epochStats.dutiesMutex.RLock()
+	defer epochStats.dutiesMutex.RUnlock()
+	if epochStats.attestorAssignments == nil {
+		return nil
+	}
+
+	// attestations for the epoch can be included until the end of the next epoch
+	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := firstSlot + 2*utils.Config.Chain.Config.SlotsPerEpoch - 1
+	blocks := []*CacheBlock{}
+	cache.cacheMutex.RLock()
+	for slot, slotBlocks := range cache.slotMap {
+		if slot >= firstSlot && slot <= lastSlot {
+			blocks = append(blocks, slotBlocks...)
+		}
+	}
+	cache.cacheMutex.RUnlock()
+	sort.Slice(blocks, func(a, b int) bool {
+		return blocks[a].Slot < blocks[b].Slot
+	})
+
+	votes := map[uint64]*doppelgangerVote{}
+	conflicts := map[uint64]*dbtypes.ValidatorDoppelganger{}
+	for _, block := range blocks {
+		if len(epochStats.DependentRoot) > 0 && !cache.isCanonicalBlock(epochStats.DependentRoot, block.Root) {
+			continue
+		}
+		blockBody := block.GetBlockBody()
+		if blockBody == nil {
+			continue
+		}
+		attestations, err := blockBody.Attestations()
+		if err != nil {
+			continue
+		}
+
+		for _, att := range attestations {
+			if utils.EpochOfSlot(uint64(att.Data.Slot)) != epoch {
+				continue
+			}
+			voteRoot, err := att.Data.HashTreeRoot()
+			if err != nil {
+				continue
+			}
+
+			attKey := fmt.Sprintf("%v-%v", uint64(att.Data.Slot), uint64(att.Data.Index))
+			for bitIdx, validatorIdx := range epochStats.attestorAssignments[attKey] {
+				if !utils.BitAtVector(att.AggregationBits, bitIdx) {
+					continue
+				}
+				vote := votes[validatorIdx]
+				if vote == nil {
+					votes[validatorIdx] = &doppelgangerVote{
+						voteRoot:  voteRoot,
+						slot:      block.Slot,
+						blockRoot: block.Root,
+					}
+					continue
+				}
+				if vote.voteRoot == voteRoot || conflicts[validatorIdx] != nil {
+					continue
+				}
+				conflicts[validatorIdx] = &dbtypes.ValidatorDoppelganger{
+					ValidatorIndex:    validatorIdx,
+					Epoch:             epoch,
+					Slot:              vote.slot,
+					BlockRoot:         vote.blockRoot,
+					VoteRoot:          vote.voteRoot[:],
+					ConflictSlot:      block.Slot,
+					ConflictBlockRoot: block.Root,
+					ConflictVoteRoot:  voteRoot[:],
+				}
+			}
+		}
+	}
+
+	doppelgangers := make([]*dbtypes.ValidatorDoppelganger, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		doppelgangers = append(doppelgangers, conflict)
+	}
+	sort.Slice(doppelgangers, func(a, b int) bool {
+		return doppelgangers[a].ValidatorIndex < doppelgangers[b].ValidatorIndex
+	})
+	return doppelgangers
+}
+
+func (cache *indexerCache) persistDoppelgangerVotes(epoch uint64, epochStats *EpochStats, tx *sqlx.Tx) error {
+	doppelgangers := cache.findDoppelgangerVotes(epoch, epochStats)
+	if len(doppelgangers) == 0 {
+		return nil
+	}
+	for _, doppelganger := range doppelgangers {
+		logger.Warnf("validator %v signed conflicting votes for epoch %v (slot %v: 0x%x, slot %v: 0x%x), possible doppelganger", doppelganger.ValidatorIndex, epoch, doppelganger.Slot, doppelganger.BlockRoot, doppelganger.ConflictSlot, doppelganger.ConflictBlockRoot)
+	}
+	return db.InsertValidatorDoppelgangers(doppelgangers, tx)
+}
@@ -0,0 +1,187 @@
+package indexer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+// epochPipeline holds the dependencies of the epoch processing steps (vote aggregation, epoch
+// summary & persistence), so these steps can run against in-memory blocks, a fixed clock and a
+// mocked writer instead of a live node and database.
+type epochPipeline struct {
+	now              func() time.Time
+	validatorIndexes func(pubkeys [][]byte) map[string]uint64
+	newWriter        func(tx *sqlx.Tx) epochDataWriter
+}
+
+// newEpochPipeline creates the pipeline of an indexer. validatorIndexes resolves pubkeys to validator indexes
+// and newWriter returns the writer used to persist an epoch within the given transaction.
+func newEpochPipeline(now func() time.Time, validatorIndexes func(pubkeys [][]byte) map[string]uint64, newWriter func(tx *sqlx.Tx) epochDataWriter) *epochPipeline {
+	return &epochPipeline{
+		now:              now,
+		validatorIndexes: validatorIndexes,
+		newWriter:        newWriter,
+	}
+}
+
+// epochDataWriter is the subset of db operations needed to persist a finalized epoch
+type epochDataWriter interface {
+	InsertBlock(block *dbtypes.Block) error
+	InsertSlotAssignments(slotAssignments []*dbtypes.SlotAssignment) error
+	InsertEpoch(epoch *dbtypes.Epoch) error
+	UpdateDailyStats(day uint64, firstEpoch uint64, lastEpoch uint64) error
+	InsertEpochCredentialStats(stats *dbtypes.EpochCredentialStats) error
+	GetValidatorNames(minIdx uint64, maxIdx uint64) []*dbtypes.ValidatorName
+	InsertValidatorUptime(uptimes []*dbtypes.ValidatorUptime) error
+	InsertValidatorVoteStats(voteStats []*dbtypes.ValidatorVoteStats) error
+	InsertEpochCommitteeStats(committeeStats []*dbtypes.EpochCommitteeStats) error
+	InsertSlotCommitteeParticipation(participation []*dbtypes.SlotCommitteeParticipation) error
+	InsertValidatorSummaries(summaries []*dbtypes.ValidatorSummary) error
+	InsertEpochTargetVotes(targetVotes []*dbtypes.EpochTargetVote) error
+	InsertEpochAggregationStats(stats *dbtypes.EpochAggregationStats) error
+	InsertWatchedWithdrawals(withdrawals []*dbtypes.WatchedWithdrawal) error
+	InsertBlobGas(blobGas []*dbtypes.BlobGas) error
+	InsertBlockWitnesses(witnesses []*dbtypes.BlockWitness) error
+	InsertBlockArrivals(arrivals []*dbtypes.BlockArrival) error
+	InsertBlockDataColumns(dataColumns []*dbtypes.BlockDataColumns) error
+	InsertSlotRoots(slotRoots []*dbtypes.SlotRoot) error
+	InsertDeposits(deposits []*dbtypes.Deposit) error
+	InsertDepositReceipts(receipts []*dbtypes.DepositReceipt) error
+	GetLastSlotRoot(beforeSlot uint64) *dbtypes.SlotRoot
+	InsertConsolidationRequests(requests []*dbtypes.ConsolidationRequest) error
+}
+
+// dbEpochDataWriter writes the epoch data to the database within the given transaction
+type dbEpochDataWriter struct {
+	tx *sqlx.Tx
+}
+
+func newDbEpochDataWriter(tx *sqlx.Tx) epochDataWriter {
+	return &dbEpochDataWriter{tx: tx}
+}
+
+func (writer *dbEpochDataWriter) InsertBlock(block *dbtypes.Block) error {
+	return db.InsertBlock(block, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertSlotAssignments(slotAssignments []*dbtypes.SlotAssignment) error {
+	return db.InsertSlotAssignments(slotAssignments, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertEpoch(epoch *dbtypes.Epoch) error {
+	return db.InsertEpoch(epoch, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) UpdateDailyStats(day uint64, firstEpoch uint64, lastEpoch uint64) error {
+	return db.UpdateDailyStats(day, firstEpoch, lastEpoch, utils.Config.Chain.Config.SlotsPerEpoch, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertEpochCredentialStats(stats *dbtypes.EpochCredentialStats) error {
+	return db.InsertEpochCredentialStats(stats, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) GetValidatorNames(minIdx uint64, maxIdx uint64) []*dbtypes.ValidatorName {
+	return db.GetValidatorNames(minIdx, maxIdx, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertValidatorUptime(uptimes []*dbtypes.ValidatorUptime) error {
+	return db.InsertValidatorUptime(uptimes, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertValidatorVoteStats(voteStats []*dbtypes.ValidatorVoteStats) error {
+	return db.InsertValidatorVoteStats(voteStats, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertEpochCommitteeStats(committeeStats []*dbtypes.EpochCommitteeStats) error {
+	return db.InsertEpochCommitteeStats(committeeStats, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertSlotCommitteeParticipation(participation []*dbtypes.SlotCommitteeParticipation) error {
+	return db.InsertSlotCommitteeParticipation(participation, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertValidatorSummaries(summaries []*dbtypes.ValidatorSummary) error {
+	return db.InsertValidatorSummaries(summaries, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertEpochTargetVotes(targetVotes []*dbtypes.EpochTargetVote) error {
+	return db.InsertEpochTargetVotes(targetVotes, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertEpochAggregationStats(stats *dbtypes.EpochAggregationStats) error {
+	return db.InsertEpochAggregationStats(stats, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertWatchedWithdrawals(withdrawals []*dbtypes.WatchedWithdrawal) error {
+	return db.InsertWatchedWithdrawals(withdrawals, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertBlobGas(blobGas []*dbtypes.BlobGas) error {
+	return db.InsertBlobGas(blobGas, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertBlockWitnesses(witnesses []*dbtypes.BlockWitness) error {
+	return db.InsertBlockWitnesses(witnesses, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertBlockArrivals(arrivals []*dbtypes.BlockArrival) error {
+	return db.InsertBlockArrivals(arrivals, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertBlockDataColumns(dataColumns []*dbtypes.BlockDataColumns) error {
+	return db.InsertBlockDataColumns(dataColumns, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertSlotRoots(slotRoots []*dbtypes.SlotRoot) error {
+	return db.InsertSlotRoots(slotRoots, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertDeposits(deposits []*dbtypes.Deposit) error {
+	return db.InsertDeposits(deposits, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertDepositReceipts(receipts []*dbtypes.DepositReceipt) error {
+	return db.InsertDepositReceipts(receipts, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) GetLastSlotRoot(beforeSlot uint64) *dbtypes.SlotRoot {
+	return db.GetLastSlotRoot(beforeSlot, writer.tx)
+}
+
+func (writer *dbEpochDataWriter) InsertConsolidationRequests(requests []*dbtypes.ConsolidationRequest) error {
+	return db.InsertConsolidationRequests(requests, writer.tx)
+}
+
+// persistEpoch writes the epoch to the db, a new transaction is started if tx is nil
+func (pipeline *epochPipeline) persistEpoch(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *EpochStats, epochVotes *EpochVotes, tx *sqlx.Tx) error {
+	commitTx := false
+	if tx == nil {
+		var err error
+		tx, err = db.WriterDb.Beginx()
+		if err != nil {
+			logger.Errorf("error starting db transactions: %v", err)
+			return err
+		}
+		defer tx.Rollback()
+		commitTx = true
+	}
+
+	err := pipeline.persistEpochData(epoch, blockMap, epochStats, epochVotes, pipeline.newWriter(tx))
+	if err != nil {
+		return err
+	}
+
+	if commitTx {
+		logger.Infof("commit transaction")
+		if err := tx.Commit(); err != nil {
+			logger.Errorf("error committing db transaction: %v", err)
+			return fmt.Errorf("error committing db transaction: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,598 @@
+package indexer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/rpc"
+	"github.com/pk910/dora/types"
+	"github.com/pk910/dora/utils"
+)
+
+const testEffectiveBalance = 32000000000
+
+// memoryEpochDataWriter keeps everything the epoch pipeline writes in memory.
+// failOn makes the named writer method return an error.
+type memoryEpochDataWriter struct {
+	failOn         string
+	calls          []string
+	validatorNames map[uint64]string
+
+	blocks          []*dbtypes.Block
+	slotAssignments []*dbtypes.SlotAssignment
+	epochs          []*dbtypes.Epoch
+	uptimes         []*dbtypes.ValidatorUptime
+	summaries       []*dbtypes.ValidatorSummary
+	targetVotes     []*dbtypes.EpochTargetVote
+	aggregation     []*dbtypes.EpochAggregationStats
+	slotRoots       []*dbtypes.SlotRoot
+	consolidations  []*dbtypes.ConsolidationRequest
+}
+
+func (writer *memoryEpochDataWriter) call(name string) error {
+	writer.calls = append(writer.calls, name)
+	if writer.failOn == name {
+		return errors.New("injected failure")
+	}
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) InsertBlock(block *dbtypes.Block) error {
+	if err := writer.call("InsertBlock"); err != nil {
+		return err
+	}
+	writer.blocks = append(writer.blocks, block)
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) InsertSlotAssignments(slotAssignments []*dbtypes.SlotAssignment) error {
+	if err := writer.call("InsertSlotAssignments"); err != nil {
+		return err
+	}
+	writer.slotAssignments = append(writer.slotAssignments, slotAssignments...)
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) InsertEpoch(epoch *dbtypes.Epoch) error {
+	if err := writer.call("InsertEpoch"); err != nil {
+		return err
+	}
+	writer.epochs = append(writer.epochs, epoch)
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) UpdateDailyStats(day uint64, firstEpoch uint64, lastEpoch uint64) error {
+	return writer.call("UpdateDailyStats")
+}
+
+func (writer *memoryEpochDataWriter) InsertEpochCredentialStats(stats *dbtypes.EpochCredentialStats) error {
+	return writer.call("InsertEpochCredentialStats")
+}
+
+func (writer *memoryEpochDataWriter) GetValidatorNames(minIdx uint64, maxIdx uint64) []*dbtypes.ValidatorName {
+	names := []*dbtypes.ValidatorName{}
+	for index, name := range writer.validatorNames {
+		if index >= minIdx && index <= maxIdx {
+			names = append(names, &dbtypes.ValidatorName{Index: index, Name: name})
+		}
+	}
+	return names
+}
+
+func (writer *memoryEpochDataWriter) InsertValidatorUptime(uptimes []*dbtypes.ValidatorUptime) error {
+	if err := writer.call("InsertValidatorUptime"); err != nil {
+		return err
+	}
+	writer.uptimes = append(writer.uptimes, uptimes...)
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) InsertValidatorVoteStats(voteStats []*dbtypes.ValidatorVoteStats) error {
+	return writer.call("InsertValidatorVoteStats")
+}
+
+func (writer *memoryEpochDataWriter) InsertEpochCommitteeStats(committeeStats []*dbtypes.EpochCommitteeStats) error {
+	return writer.call("InsertEpochCommitteeStats")
+}
+
+func (writer *memoryEpochDataWriter) InsertSlotCommitteeParticipation(participation []*dbtypes.SlotCommitteeParticipation) error {
+	return writer.call("InsertSlotCommitteeParticipation")
+}
+
+func (writer *memoryEpochDataWriter) InsertValidatorSummaries(summaries []*dbtypes.ValidatorSummary) error {
+	if err := writer.call("InsertValidatorSummaries"); err != nil {
+		return err
+	}
+	writer.summaries = append(writer.summaries, summaries...)
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) InsertEpochTargetVotes(targetVotes []*dbtypes.EpochTargetVote) error {
+	if err := writer.call("InsertEpochTargetVotes"); err != nil {
+		return err
+	}
+	writer.targetVotes = append(writer.targetVotes, targetVotes...)
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) InsertEpochAggregationStats(stats *dbtypes.EpochAggregationStats) error {
+	if err := writer.call("InsertEpochAggregationStats"); err != nil {
+		return err
+	}
+	writer.aggregation = append(writer.aggregation, stats)
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) InsertWatchedWithdrawals(withdrawals []*dbtypes.WatchedWithdrawal) error {
+	return writer.call("InsertWatchedWithdrawals")
+}
+
+func (writer *memoryEpochDataWriter) InsertBlobGas(blobGas []*dbtypes.BlobGas) error {
+	return writer.call("InsertBlobGas")
+}
+
+func (writer *memoryEpochDataWriter) InsertBlockWitnesses(witnesses []*dbtypes.BlockWitness) error {
+	return writer.call("InsertBlockWitnesses")
+}
+
+func (writer *memoryEpochDataWriter) InsertBlockArrivals(arrivals []*dbtypes.BlockArrival) error {
+	return writer.call("InsertBlockArrivals")
+}
+
+func (writer *memoryEpochDataWriter) InsertBlockDataColumns(dataColumns []*dbtypes.BlockDataColumns) error {
+	return writer.call("InsertBlockDataColumns")
+}
+
+func (writer *memoryEpochDataWriter) InsertSlotRoots(slotRoots []*dbtypes.SlotRoot) error {
+	if err := writer.call("InsertSlotRoots"); err != nil {
+		return err
+	}
+	writer.slotRoots = append(writer.slotRoots, slotRoots...)
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) InsertDeposits(deposits []*dbtypes.Deposit) error {
+	return writer.call("InsertDeposits")
+}
+
+func (writer *memoryEpochDataWriter) InsertDepositReceipts(receipts []*dbtypes.DepositReceipt) error {
+	return writer.call("InsertDepositReceipts")
+}
+
+func (writer *memoryEpochDataWriter) InsertConsolidationRequests(requests []*dbtypes.ConsolidationRequest) error {
+	if err := writer.call("InsertConsolidationRequests"); err != nil {
+		return err
+	}
+	writer.consolidations = append(writer.consolidations, requests...)
+	return nil
+}
+
+func (writer *memoryEpochDataWriter) GetLastSlotRoot(beforeSlot uint64) *dbtypes.SlotRoot {
+	return nil
+}
+
+// setupTestChain configures a small chain with 4 slots per epoch and restores the previous config after the test
+func setupTestChain(t testing.TB) {
+	prevConfig := utils.Config
+	utils.Config = &types.Config{}
+	utils.Config.Chain.GenesisTimestamp = 1600000000
+	utils.Config.Chain.Config.SlotsPerEpoch = 4
+	utils.Config.Chain.Config.SecondsPerSlot = 12
+	utils.Config.Chain.Config.EffectiveBalanceIncrement = 1000000000
+	utils.Config.Chain.Config.DenebForkEpoch = 1000
+	t.Cleanup(func() {
+		utils.Config = prevConfig
+	})
+}
+
+// newTestPipeline returns a pipeline with a fixed clock and the test validator set, the epochs are written to a mocked writer
+func newTestPipeline() *epochPipeline {
+	return newEpochPipeline(func() time.Time {
+		return time.Unix(1600000000, 0)
+	}, testValidatorIndexes, nil)
+}
+
+// testValidatorIndexes resolves the pubkeys of the test validator set, which consists of the pubkeys 0x01-0x08 for the indexes 0-7
+func testValidatorIndexes(pubkeys [][]byte) map[string]uint64 {
+	indexes := map[string]uint64{}
+	for _, pubkey := range pubkeys {
+		if len(pubkey) == 1 && pubkey[0] >= 1 && pubkey[0] <= 8 {
+			indexes[string(pubkey)] = uint64(pubkey[0] - 1)
+		}
+	}
+	return indexes
+}
+
+func newTestWriter(failOn string) *memoryEpochDataWriter {
+	return &memoryEpochDataWriter{
+		failOn: failOn,
+		validatorNames: map[uint64]string{
+			0: "operator-a", 1: "operator-a", 2: "operator-b", 3: "operator-b",
+		},
+	}
+}
+
+func testRoot(id byte) phase0.Root {
+	return phase0.Root{id}
+}
+
+func newTestBlock(slot uint64, parent byte, attestations ...*phase0.Attestation) *CacheBlock {
+	root := testRoot(byte(slot))
+	return &CacheBlock{
+		Root: root[:],
+		Slot: slot,
+		header: &phase0.SignedBeaconBlockHeader{
+			Message: &phase0.BeaconBlockHeader{
+				Slot:          phase0.Slot(slot),
+				ProposerIndex: phase0.ValidatorIndex(slot % 8),
+				ParentRoot:    testRoot(parent),
+				StateRoot:     testRoot(byte(slot) + 100),
+			},
+		},
+		block: &spec.VersionedSignedBeaconBlock{
+			Version: spec.DataVersionPhase0,
+			Phase0: &phase0.SignedBeaconBlock{
+				Message: &phase0.BeaconBlock{
+					Slot:          phase0.Slot(slot),
+					ProposerIndex: phase0.ValidatorIndex(slot % 8),
+					ParentRoot:    testRoot(parent),
+					Body: &phase0.BeaconBlockBody{
+						ETH1Data:     &phase0.ETH1Data{},
+						Attestations: attestations,
+					},
+				},
+			},
+		},
+	}
+}
+
+func newTestAttestation(slot uint64, bits []byte, head byte, target byte) *phase0.Attestation {
+	return &phase0.Attestation{
+		AggregationBits: bits,
+		Data: &phase0.AttestationData{
+			Slot:            phase0.Slot(slot),
+			BeaconBlockRoot: testRoot(head),
+			Source:          &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{
+				Epoch: phase0.Epoch(slot / 4),
+				Root:  testRoot(target),
+			},
+		},
+	}
+}
+
+// newTestEpochStats returns the duties of epoch 1 (slots 4-7): 8 validators, one committee of 2 per slot
+func newTestEpochStats() *EpochStats {
+	balances := newValidatorBalances(8)
+	for idx := uint64(0); idx < 8; idx++ {
+		balances.set(idx, testEffectiveBalance)
+	}
+	return &EpochStats{
+		Epoch: 1,
+		proposerAssignments: map[uint64]uint64{
+			4: 4, 5: 5, 6: 6, 7: 7,
+		},
+		attestorAssignments: map[string][]uint64{
+			"4-0": {0, 1},
+			"5-0": {2, 3},
+			"6-0": {4, 5},
+			"7-0": {6, 7},
+		},
+		validatorStats: &EpochValidatorStats{
+			ValidatorCount:    8,
+			ValidatorBalance:  8 * testEffectiveBalance,
+			EligibleAmount:    8 * testEffectiveBalance,
+			ValidatorBalances: balances,
+		},
+	}
+}
+
+// newTestBlockMap returns the canonical blocks of epoch 1 and the first block of epoch 2.
+// slot 6 is missed, the target root of epoch 1 is the block at slot 4.
+func newTestBlockMap() map[uint64]*CacheBlock {
+	blockMap := map[uint64]*CacheBlock{
+		4: newTestBlock(4, 3),
+		5: newTestBlock(5, 4,
+			newTestAttestation(4, []byte{0x07}, 4, 4), // validators 0 & 1, timely & correct
+		),
+		7: newTestBlock(7, 5,
+			newTestAttestation(5, []byte{0x05}, 5, 4), // validator 2, timely & correct
+			newTestAttestation(4, []byte{0x07}, 4, 4), // duplicate of the aggregate in slot 5
+		),
+		8: newTestBlock(8, 7,
+			newTestAttestation(6, []byte{0x07}, 5, 4),       // validators 4 & 5, correct but late
+			newTestAttestation(7, []byte{0x05}, 0x99, 0x99), // validator 6, wrong target
+		),
+	}
+	blockMap[7].consolidationRequests = []*rpc.ConsolidationRequest{
+		{SourceAddress: []byte{0xaa}, SourcePubkey: []byte{0x02}, TargetPubkey: []byte{0x09}},
+	}
+	return blockMap
+}
+
+func TestAggregateEpochVotes(t *testing.T) {
+	setupTestChain(t)
+	pipeline := newTestPipeline()
+	targetRoot := testRoot(4)
+
+	votes := pipeline.aggregateEpochVotes(newTestBlockMap(), 1, newTestEpochStats(), targetRoot[:], false, true)
+
+	if votes.currentEpoch.targetVoteAmount != 3*testEffectiveBalance {
+		t.Errorf("unexpected current target votes: %v", votes.currentEpoch.targetVoteAmount)
+	}
+	if votes.currentEpoch.headVoteAmount != 3*testEffectiveBalance {
+		t.Errorf("unexpected current head votes: %v", votes.currentEpoch.headVoteAmount)
+	}
+	if votes.currentEpoch.totalVoteAmount != 3*testEffectiveBalance {
+		t.Errorf("unexpected current total votes: %v", votes.currentEpoch.totalVoteAmount)
+	}
+	if votes.nextEpoch.targetVoteAmount != 2*testEffectiveBalance {
+		t.Errorf("unexpected next target votes: %v", votes.nextEpoch.targetVoteAmount)
+	}
+	if votes.nextEpoch.headVoteAmount != 0 {
+		t.Errorf("unexpected next head votes: %v", votes.nextEpoch.headVoteAmount)
+	}
+	if votes.nextEpoch.totalVoteAmount != 3*testEffectiveBalance {
+		t.Errorf("unexpected next total votes: %v", votes.nextEpoch.totalVoteAmount)
+	}
+
+	expectedFlags := map[uint64]uint8{
+		0: VoteFlagCorrectTarget | VoteFlagCorrectHead,
+		1: VoteFlagCorrectTarget | VoteFlagCorrectHead,
+		2: VoteFlagCorrectTarget | VoteFlagCorrectHead,
+		4: VoteFlagCorrectTarget | VoteFlagCorrectHead | VoteFlagLateInclusion,
+		5: VoteFlagCorrectTarget | VoteFlagCorrectHead | VoteFlagLateInclusion,
+		6: 0,
+	}
+	if len(votes.ActivityMap) != len(expectedFlags) {
+		t.Errorf("unexpected number of active validators: %v", len(votes.ActivityMap))
+	}
+	for validatorIdx, flags := range expectedFlags {
+		if !votes.ActivityMap[validatorIdx] {
+			t.Errorf("validator %v not marked active", validatorIdx)
+		}
+		if votes.VoteFlags[validatorIdx] != flags {
+			t.Errorf("unexpected vote flags for validator %v: %v (expected %v)", validatorIdx, votes.VoteFlags[validatorIdx], flags)
+		}
+	}
+
+	if !votes.HasTargetSplit() {
+		t.Errorf("expected split target votes")
+	}
+	targetVotes := votes.GetTargetVotes()
+	if len(targetVotes) != 2 || targetVotes[0].Amount != 5*testEffectiveBalance || targetVotes[1].Amount != testEffectiveBalance {
+		t.Errorf("unexpected target votes: %v", targetVotes)
+	}
+
+	stats := votes.AggregationStats
+	if stats.AggregateCount != 5 || stats.CommitteeCount != 4 || stats.VoteCount != 8 || stats.DuplicateVoteCount != 2 {
+		t.Errorf("unexpected aggregation stats: %+v", stats)
+	}
+}
+
+func TestAggregateEpochVotesCurrentOnly(t *testing.T) {
+	setupTestChain(t)
+	pipeline := newTestPipeline()
+	targetRoot := testRoot(4)
+
+	votes := pipeline.aggregateEpochVotes(newTestBlockMap(), 1, newTestEpochStats(), targetRoot[:], true, false)
+
+	if votes.currentEpoch.totalVoteAmount != 3*testEffectiveBalance {
+		t.Errorf("unexpected current total votes: %v", votes.currentEpoch.totalVoteAmount)
+	}
+	if votes.nextEpoch.totalVoteAmount != 0 {
+		t.Errorf("votes of the next epoch aggregated: %v", votes.nextEpoch.totalVoteAmount)
+	}
+	if votes.ActivityMap[4] || votes.ActivityMap[6] {
+		t.Errorf("validators voting in the next epoch marked active")
+	}
+}
+
+func TestAggregateEpochVotesWithoutValidatorStats(t *testing.T) {
+	setupTestChain(t)
+	pipeline := newTestPipeline()
+	targetRoot := testRoot(4)
+	epochStats := newTestEpochStats()
+	epochStats.validatorStats = nil
+
+	votes := pipeline.aggregateEpochVotes(newTestBlockMap(), 1, epochStats, targetRoot[:], false, false)
+
+	// without balances the votes are counted instead of weighted
+	if !votes.VoteCounts {
+		t.Errorf("expected vote counts")
+	}
+	if total := votes.currentEpoch.totalVoteAmount + votes.nextEpoch.totalVoteAmount; total != 6 {
+		t.Errorf("unexpected vote count: %v", total)
+	}
+}
+
+func TestBuildDbEpoch(t *testing.T) {
+	setupTestChain(t)
+	pipeline := newTestPipeline()
+	targetRoot := testRoot(4)
+	blockMap := newTestBlockMap()
+	epochStats := newTestEpochStats()
+	votes := pipeline.aggregateEpochVotes(blockMap, 1, epochStats, targetRoot[:], false, false)
+
+	seenSlots := []uint64{}
+	dbEpoch := pipeline.buildDbEpoch(1, blockMap, epochStats, votes, func(block *CacheBlock) {
+		seenSlots = append(seenSlots, block.Slot)
+	})
+
+	if len(seenSlots) != 3 || seenSlots[0] != 4 || seenSlots[1] != 5 || seenSlots[2] != 7 {
+		t.Errorf("unexpected blocks passed to the block callback: %v", seenSlots)
+	}
+	if dbEpoch.Epoch != 1 || dbEpoch.BlockCount != 3 || dbEpoch.AttestationCount != 3 {
+		t.Errorf("unexpected epoch counts: %+v", dbEpoch)
+	}
+	if dbEpoch.VotedTarget != 5*testEffectiveBalance || dbEpoch.VotedHead != 3*testEffectiveBalance || dbEpoch.VotedTotal != 6*testEffectiveBalance {
+		t.Errorf("unexpected epoch votes: target %v, head %v, total %v", dbEpoch.VotedTarget, dbEpoch.VotedHead, dbEpoch.VotedTotal)
+	}
+	if dbEpoch.ValidatorCount != 8 || dbEpoch.Eligible != 8*testEffectiveBalance {
+		t.Errorf("unexpected validator stats: %v validators, %v eligible", dbEpoch.ValidatorCount, dbEpoch.Eligible)
+	}
+	if dbEpoch.Partial != 0 {
+		t.Errorf("unexpected partial flags: %v", dbEpoch.Partial)
+	}
+
+	// epochs without duties & validator set are flagged for the partial epoch repair
+	dbEpoch = pipeline.buildDbEpoch(1, blockMap, nil, nil, nil)
+	if dbEpoch.Partial != dbtypes.EpochPartialValidators|dbtypes.EpochPartialDuties {
+		t.Errorf("unexpected partial flags without epoch stats: %v", dbEpoch.Partial)
+	}
+	if dbEpoch.BlockCount != 3 || dbEpoch.VotedTotal != 0 {
+		t.Errorf("unexpected epoch without epoch stats: %+v", dbEpoch)
+	}
+}
+
+func TestPersistEpochData(t *testing.T) {
+	setupTestChain(t)
+	pipeline := newTestPipeline()
+	targetRoot := testRoot(4)
+	blockMap := newTestBlockMap()
+	epochStats := newTestEpochStats()
+	votes := pipeline.aggregateEpochVotes(blockMap, 1, epochStats, targetRoot[:], false, false)
+
+	writer := newTestWriter("")
+	if err := pipeline.persistEpochData(1, blockMap, epochStats, votes, writer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(writer.blocks) != 3 {
+		t.Errorf("unexpected number of blocks: %v", len(writer.blocks))
+	}
+	if len(writer.slotAssignments) != 4 || writer.slotAssignments[2].Proposer != 6 {
+		t.Errorf("unexpected slot assignments: %v", writer.slotAssignments)
+	}
+	if len(writer.epochs) != 1 || writer.epochs[0].VotedTotal != 6*testEffectiveBalance {
+		t.Errorf("unexpected epochs: %v", writer.epochs)
+	}
+	if len(writer.targetVotes) != 2 {
+		t.Errorf("unexpected target votes: %v", writer.targetVotes)
+	}
+	if len(writer.aggregation) != 1 || writer.aggregation[0].Epoch != 1 {
+		t.Errorf("unexpected aggregation stats: %v", writer.aggregation)
+	}
+
+	// the missed slot 6 refers to the block at slot 5
+	if len(writer.slotRoots) != 4 {
+		t.Fatalf("unexpected number of slot roots: %v", len(writer.slotRoots))
+	}
+	missedRoot := writer.slotRoots[2]
+	if missedRoot.Slot != 6 || missedRoot.Missed != 1 || missedRoot.BlockRoot[0] != 5 {
+		t.Errorf("unexpected slot root for the missed slot: %+v", missedRoot)
+	}
+
+	// validators 0-3 have a name, validator 3 missed its vote
+	if len(writer.uptimes) != 2 {
+		t.Fatalf("unexpected number of uptimes: %v", len(writer.uptimes))
+	}
+	for _, uptime := range writer.uptimes {
+		if uptime.Duties != 2 || (uptime.Name == "operator-a" && uptime.Attested != 2) || (uptime.Name == "operator-b" && uptime.Attested != 1) {
+			t.Errorf("unexpected uptime: %+v", uptime)
+		}
+	}
+
+	// the target of the consolidation is not in the validator set
+	if len(writer.consolidations) != 1 {
+		t.Fatalf("unexpected number of consolidation requests: %v", len(writer.consolidations))
+	}
+	consolidation := writer.consolidations[0]
+	if consolidation.Slot != 7 || consolidation.SourceIndex == nil || *consolidation.SourceIndex != 1 || consolidation.SourceBalance != testEffectiveBalance || consolidation.TargetIndex != nil {
+		t.Errorf("unexpected consolidation request: %+v", consolidation)
+	}
+
+	var missedBlocks uint64
+	for _, summary := range writer.summaries {
+		if summary.ValidatorIndex == 6 {
+			missedBlocks = summary.MissedBlocks
+		}
+	}
+	if missedBlocks != 1 {
+		t.Errorf("missed block of proposer 6 not recorded")
+	}
+}
+
+func TestPersistEpochDataErrors(t *testing.T) {
+	setupTestChain(t)
+	pipeline := newTestPipeline()
+	targetRoot := testRoot(4)
+
+	for _, failOn := range []string{"InsertBlock", "InsertSlotAssignments", "InsertEpoch", "InsertValidatorUptime", "InsertEpochTargetVotes", "InsertSlotRoots", "InsertConsolidationRequests"} {
+		blockMap := newTestBlockMap()
+		epochStats := newTestEpochStats()
+		votes := pipeline.aggregateEpochVotes(blockMap, 1, epochStats, targetRoot[:], false, false)
+
+		writer := newTestWriter(failOn)
+		err := pipeline.persistEpochData(1, blockMap, epochStats, votes, writer)
+		if err == nil {
+			t.Errorf("%v: error not returned", failOn)
+			continue
+		}
+		if !strings.Contains(err.Error(), "injected failure") {
+			t.Errorf("%v: unexpected error: %v", failOn, err)
+		}
+
+		// nothing is written after the failed insert, the caller rolls back the transaction
+		if lastCall := writer.calls[len(writer.calls)-1]; lastCall != failOn {
+			t.Errorf("%v: writer called after the failure: %v", failOn, lastCall)
+		}
+	}
+}
+
+func FuzzAggregateEpochVotes(f *testing.F) {
+	f.Add(uint64(4), uint64(0), []byte{0x07}, true)
+	f.Add(uint64(5), uint64(0), []byte{0x01}, false)
+	f.Add(uint64(6), uint64(1), []byte{0xff, 0xff}, true)
+	f.Add(uint64(7), uint64(0), []byte{}, true)
+	f.Add(uint64(8), uint64(0), []byte{0x03}, true)
+
+	f.Fuzz(func(t *testing.T, attSlot uint64, committeeIndex uint64, bits []byte, correctTarget bool) {
+		setupTestChain(t)
+		pipeline := newTestPipeline()
+		targetRoot := testRoot(4)
+
+		target := byte(0x99)
+		if correctTarget {
+			target = 4
+		}
+		att := newTestAttestation(attSlot, bits, byte(attSlot), target)
+		att.Data.Index = phase0.CommitteeIndex(committeeIndex)
+
+		blockMap := newTestBlockMap()
+		blockMap[9] = newTestBlock(9, 8, att)
+		epochStats := newTestEpochStats()
+
+		votes := pipeline.aggregateEpochVotes(blockMap, 1, epochStats, targetRoot[:], false, false)
+
+		// every vote is weighted with the balance of exactly one active validator
+		var activeBalance uint64
+		for validatorIdx := range votes.ActivityMap {
+			activeBalance += epochStats.validatorStats.ValidatorBalances.Get(validatorIdx)
+		}
+		totalVotes := votes.currentEpoch.totalVoteAmount + votes.nextEpoch.totalVoteAmount
+		if totalVotes != activeBalance {
+			t.Errorf("total votes %v do not match the balance of the active validators %v", totalVotes, activeBalance)
+		}
+		if len(votes.VoteFlags) != len(votes.ActivityMap) {
+			t.Errorf("vote flags of %v validators for %v active validators", len(votes.VoteFlags), len(votes.ActivityMap))
+		}
+		if votes.currentEpoch.targetVoteAmount > votes.currentEpoch.totalVoteAmount || votes.nextEpoch.targetVoteAmount > votes.nextEpoch.totalVoteAmount {
+			t.Errorf("target votes exceed the total votes")
+		}
+		if totalVotes > epochStats.validatorStats.EligibleAmount {
+			t.Errorf("total votes %v exceed the eligible amount", totalVotes)
+		}
+
+		dbEpoch := pipeline.buildDbEpoch(1, blockMap, epochStats, votes, nil)
+		if dbEpoch.VotedTotal != totalVotes {
+			t.Errorf("epoch votes %v do not match the aggregated votes %v", dbEpoch.VotedTotal, totalVotes)
+		}
+	})
+}
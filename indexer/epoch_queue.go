@@ -0,0 +1,74 @@
+package indexer
+
+import (
+	"time"
+
+	"github.com/pk910/dora/utils"
+)
+
+// queueFinalizedEpochs hands the finalized epoch processing over to the epoch queue worker when the processing
+// lags more than the configured threshold behind the finalized checkpoint. Processing such a backlog inline
+// would block the cache loop (cache persistence & cleanup) until all epochs are written to the (slow) db.
+// Returns true if the finalized epochs are processed by the worker.
+// The caller must hold the processing lock.
+func (cache *indexerCache) queueFinalizedEpochs() bool {
+	cache.epochQueueMutex.Lock()
+	defer cache.epochQueueMutex.Unlock()
+
+	backlog := cache.finalizedEpoch - cache.processedEpoch
+	if !cache.epochQueueRunning && backlog <= int64(cache.indexer.epochQueueThreshold) {
+		return false
+	}
+	cache.epochQueueEnd = cache.finalizedEpoch
+	if !cache.epochQueueRunning {
+		logger.Warnf("finalized epoch processing is %v epochs behind, processing epochs %v - %v in background", backlog, cache.processedEpoch+1, cache.epochQueueEnd)
+		cache.epochQueueRunning = true
+		go cache.runEpochQueue()
+	}
+	return true
+}
+
+// runEpochQueue processes the queued finalized epochs one by one. The processing lock is released after each
+// epoch, so the cache loop can keep up with the head while the backlog is processed.
+func (cache *indexerCache) runEpochQueue() {
+	defer utils.HandleSubroutinePanic("runEpochQueue")
+	defer func() {
+		cache.epochQueueMutex.Lock()
+		cache.epochQueueRunning = false
+		cache.epochQueueMutex.Unlock()
+	}()
+
+	for {
+		done, err := cache.processQueuedEpoch()
+		if done {
+			break
+		}
+		if err != nil {
+			logger.Errorf("epoch queue error: %v, retrying in 10 sec...", err)
+			time.Sleep(10 * time.Second)
+		}
+	}
+
+	// trigger the cache loop to clean up the processed epochs
+	select {
+	case cache.triggerChan <- true:
+	default:
+	}
+}
+
+func (cache *indexerCache) processQueuedEpoch() (bool, error) {
+	cache.processingMutex.Lock()
+	defer cache.processingMutex.Unlock()
+
+	cache.epochQueueMutex.Lock()
+	if cache.processedEpoch < -1 || cache.processedEpoch >= cache.epochQueueEnd {
+		// queue is done or the processing state has been reset
+		cache.epochQueueRunning = false
+		cache.epochQueueMutex.Unlock()
+		logger.Infof("epoch queue processed up to epoch %v", cache.processedEpoch)
+		return true, nil
+	}
+	cache.epochQueueMutex.Unlock()
+
+	return false, cache.processNextFinalizedEpoch()
+}
@@ -0,0 +1,142 @@
+package indexer
+
+import (
+	"fmt"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+const (
+	epochRebuildBatchSize = 100
+	blobGasPerBlob        = 131072
+)
+
+// RebuildEpochAggregates recalculates the block aggregates of finalized epochs from the stored block rows,
+// without loading anything from the beacon node. Vote & validator stats can't be derived from the block rows,
+// so they're kept from the existing epoch rows. The blob count is derived from the stored blob gas of the blocks.
+// The transaction types aren't stored per block, they're kept if the transaction count didn't change and the
+// rebuild fails otherwise.
+func RebuildEpochAggregates(firstEpoch uint64, lastEpoch uint64) error {
+	for batchStart := firstEpoch; batchStart <= lastEpoch; batchStart += epochRebuildBatchSize {
+		batchEnd := batchStart + epochRebuildBatchSize - 1
+		if batchEnd > lastEpoch {
+			batchEnd = lastEpoch
+		}
+		count, err := rebuildEpochAggregateBatch(batchStart, batchEnd)
+		if err != nil {
+			return fmt.Errorf("error rebuilding epochs %v-%v: %v", batchStart, batchEnd, err)
+		}
+		logger.Infof("rebuilt aggregates for %v epochs (%v-%v)", count, batchStart, batchEnd)
+	}
+	return nil
+}
+
+func rebuildEpochAggregateBatch(firstEpoch uint64, lastEpoch uint64) (int, error) {
+	slotsPerEpoch := utils.Config.Chain.Config.SlotsPerEpoch
+	firstSlot := firstEpoch * slotsPerEpoch
+	lastSlot := (lastEpoch+1)*slotsPerEpoch - 1
+
+	epochMap := map[uint64]*dbtypes.Epoch{}
+	for _, dbEpoch := range db.GetEpochs(lastEpoch, uint32(lastEpoch-firstEpoch+1)) {
+		if dbEpoch.Epoch >= firstEpoch {
+			epochMap[dbEpoch.Epoch] = dbEpoch
+		}
+	}
+
+	blobGasUsed := map[string]uint64{}
+	for _, blobGas := range db.GetBlobGasForSlots(firstSlot, lastSlot) {
+		blobGasUsed[string(blobGas.Root)] = blobGas.BlobGasUsed
+	}
+
+	rebuiltEpochs := map[uint64]*dbtypes.Epoch{}
+	syncParticipation := map[uint64]float32{}
+	for _, block := range db.GetBlocksForSlots(lastSlot, firstSlot, true) {
+		epoch := utils.EpochOfSlot(block.Slot)
+		dbEpoch := rebuiltEpochs[epoch]
+		if dbEpoch == nil {
+			dbEpoch = &dbtypes.Epoch{
+				Epoch: epoch,
+			}
+			if oldEpoch := epochMap[epoch]; oldEpoch != nil {
+				dbEpoch.ValidatorCount = oldEpoch.ValidatorCount
+				dbEpoch.ValidatorBalance = oldEpoch.ValidatorBalance
+				dbEpoch.Eligible = oldEpoch.Eligible
+				dbEpoch.VotedTarget = oldEpoch.VotedTarget
+				dbEpoch.VotedHead = oldEpoch.VotedHead
+				dbEpoch.VotedTotal = oldEpoch.VotedTotal
+				dbEpoch.Partial = oldEpoch.Partial
+			}
+			rebuiltEpochs[epoch] = dbEpoch
+		}
+
+		if block.Orphaned == 1 {
+			dbEpoch.OrphanedCount++
+			continue
+		}
+		dbEpoch.BlockCount++
+		dbEpoch.AttestationCount += block.AttestationCount
+		dbEpoch.DepositCount += block.DepositCount
+		dbEpoch.ExitCount += block.ExitCount
+		dbEpoch.WithdrawCount += block.WithdrawCount
+		dbEpoch.WithdrawAmount += block.WithdrawAmount
+		dbEpoch.AttesterSlashingCount += block.AttesterSlashingCount
+		dbEpoch.ProposerSlashingCount += block.ProposerSlashingCount
+		dbEpoch.BLSChangeCount += block.BLSChangeCount
+		dbEpoch.EthTransactionCount += block.EthTransactionCount
+		syncParticipation[epoch] += block.SyncParticipation
+		if epoch >= utils.Config.Chain.Config.DenebForkEpoch {
+			gasUsed, found := blobGasUsed[string(block.Root)]
+			if !found {
+				return 0, fmt.Errorf("blob gas of block %v (0x%x) not stored, can't rebuild the blob count of epoch %v", block.Slot, block.Root, epoch)
+			}
+			dbEpoch.BlobCount += gasUsed / blobGasPerBlob
+		}
+	}
+
+	for epoch, dbEpoch := range rebuiltEpochs {
+		oldEpoch := epochMap[epoch]
+		if oldEpoch != nil && oldEpoch.EthTransactionCount == dbEpoch.EthTransactionCount {
+			dbEpoch.TxLegacyCount = oldEpoch.TxLegacyCount
+			dbEpoch.TxAccessListCount = oldEpoch.TxAccessListCount
+			dbEpoch.TxDynamicFeeCount = oldEpoch.TxDynamicFeeCount
+			dbEpoch.TxBlobCount = oldEpoch.TxBlobCount
+			dbEpoch.TxSetCodeCount = oldEpoch.TxSetCodeCount
+		} else if dbEpoch.EthTransactionCount > 0 {
+			return 0, fmt.Errorf("transaction types of epoch %v can't be rebuilt from the block rows, the transaction count changed to %v", epoch, dbEpoch.EthTransactionCount)
+		}
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for epoch, dbEpoch := range rebuiltEpochs {
+		if dbEpoch.BlockCount > 0 && epoch >= utils.Config.Chain.Config.AltairForkEpoch {
+			dbEpoch.SyncParticipation = syncParticipation[epoch] / float32(dbEpoch.BlockCount)
+		}
+		if err := db.InsertEpoch(dbEpoch, tx); err != nil {
+			return 0, err
+		}
+	}
+
+	// refresh the daily rollups of the rebuilt epochs, which also backfills them for epochs indexed before the rollups existed
+	rebuiltDays := map[uint64]bool{}
+	for epoch := range rebuiltEpochs {
+		rebuiltDays[utils.DayOfSlot(epoch*slotsPerEpoch)] = true
+	}
+	for day := range rebuiltDays {
+		dayFirstEpoch, dayLastEpoch := utils.DayToEpochRange(day)
+		if err := db.UpdateDailyStats(day, dayFirstEpoch, dayLastEpoch, slotsPerEpoch, tx); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(rebuiltEpochs), nil
+}
@@ -0,0 +1,218 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/rpc"
+	"github.com/pk910/dora/utils"
+)
+
+const (
+	epochRepairInterval   = 2 * time.Minute
+	epochRepairBatchSize  = 10
+	epochRepairMaxRetries = 8
+)
+
+// epochRepairState tracks the failed repair attempts of a partial epoch
+type epochRepairState struct {
+	retries int
+	nextTry time.Time
+}
+
+// runEpochRepairLoop reloads the validator set & duties of epochs that have been persisted as partial (see
+// EpochStats.getPartialFlags) and re-aggregates their validator & vote stats. Failed repairs are retried with
+// an increasing delay and given up after a few attempts.
+func (cache *indexerCache) runEpochRepairLoop() {
+	if !cache.indexer.writeDb {
+		return
+	}
+
+	repairStates := map[uint64]*epochRepairState{}
+	for {
+		time.Sleep(epochRepairInterval)
+
+		cache.processingMutex.Lock()
+		processedEpoch := cache.processedEpoch
+		cache.processingMutex.Unlock()
+		if processedEpoch < 1 {
+			continue
+		}
+
+		// the votes of an epoch are included in the next epoch, so only repair epochs followed by a processed epoch
+		now := time.Now()
+		for _, dbEpoch := range db.GetPartialEpochs(uint64(processedEpoch), epochRepairBatchSize) {
+			repairState := repairStates[dbEpoch.Epoch]
+			if repairState == nil {
+				repairState = &epochRepairState{}
+				repairStates[dbEpoch.Epoch] = repairState
+			}
+			if repairState.retries >= epochRepairMaxRetries || now.Before(repairState.nextTry) {
+				continue
+			}
+
+			err := cache.repairPartialEpoch(dbEpoch)
+			if err == nil {
+				logger.Infof("repaired partial epoch %v: %v validators, target votes %v", dbEpoch.Epoch, dbEpoch.ValidatorCount, dbEpoch.VotedTarget)
+				delete(repairStates, dbEpoch.Epoch)
+				continue
+			}
+
+			repairState.retries++
+			if repairState.retries >= epochRepairMaxRetries {
+				logger.Warnf("could not repair partial epoch %v: %v, giving up", dbEpoch.Epoch, err)
+			} else {
+				repairState.nextTry = now.Add(epochRepairInterval << repairState.retries)
+				logger.Warnf("could not repair partial epoch %v: %v, retrying later", dbEpoch.Epoch, err)
+			}
+		}
+	}
+}
+
+// getEpochRepairClient returns a ready archive client that is allowed to load the validator set
+func (cache *indexerCache) getEpochRepairClient() *IndexerClient {
+	for _, client := range cache.indexer.GetReadyClients(true, nil) {
+		if !client.skipValidators {
+			return client
+		}
+	}
+	return nil
+}
+
+func (cache *indexerCache) repairPartialEpoch(dbEpoch *dbtypes.Epoch) error {
+	client := cache.getEpochRepairClient()
+	if client == nil {
+		return fmt.Errorf("no ready client")
+	}
+
+	// reload the canonical blocks of this & next epoch
+	epoch := dbEpoch.Epoch
+	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := firstSlot + (utils.Config.Chain.Config.SlotsPerEpoch * 2) - 1
+	blockMap := map[uint64]*CacheBlock{}
+	var firstBlock *CacheBlock
+	for _, block := range db.GetBlocksForSlots(lastSlot, firstSlot, false) {
+		headerRsp, err := client.rpcClient.GetBlockHeaderByBlockroot(block.Root)
+		if err != nil {
+			return fmt.Errorf("error fetching slot %v header: %v", block.Slot, err)
+		}
+		if headerRsp == nil {
+			return fmt.Errorf("error fetching slot %v header: not found", block.Slot)
+		}
+		blockRsp, err := client.rpcClient.GetBlockBodyByBlockroot(block.Root)
+		if err != nil {
+			return fmt.Errorf("error fetching slot %v block: %v", block.Slot, err)
+		}
+		cacheBlock := &CacheBlock{
+			Root:   block.Root,
+			Slot:   block.Slot,
+			header: headerRsp.Header,
+			block:  blockRsp,
+		}
+		blockMap[block.Slot] = cacheBlock
+		if block.Slot < firstSlot+utils.Config.Chain.Config.SlotsPerEpoch && (firstBlock == nil || block.Slot < firstBlock.Slot) {
+			firstBlock = cacheBlock
+		}
+	}
+
+	// reload duties & validator set
+	var dependentRoot []byte
+	if firstBlock != nil {
+		dependentRoot = firstBlock.GetParentRoot()
+	} else {
+		dependentRoot = db.GetHighestRootBeforeSlot(firstSlot, false)
+	}
+	dutiesCtx, cancelDuties := context.WithTimeout(context.Background(), 2*rpc.GetCallTimeout(rpc.CallTypeDuties))
+	epochAssignments, err := client.rpcClient.GetEpochAssignments(dutiesCtx, epoch, dependentRoot)
+	cancelDuties()
+	if err != nil || epochAssignments == nil {
+		return fmt.Errorf("error fetching duties: %v", err)
+	}
+	if len(epochAssignments.AttestorAssignments) == 0 {
+		return fmt.Errorf("error fetching duties: attestor assignments empty")
+	}
+	epochStats := &EpochStats{
+		Epoch:               epoch,
+		DependentRoot:       epochAssignments.DependendRoot[:],
+		proposerAssignments: epochAssignments.ProposerAssignments,
+		attestorAssignments: epochAssignments.AttestorAssignments,
+		syncAssignments:     epochAssignments.SyncAssignments,
+	}
+	if err := epochStats.loadValidatorStats(client, epochAssignments.DependendStateRef); err != nil {
+		return fmt.Errorf("error fetching validators: %v", err)
+	}
+
+	// re-aggregate the votes
+	var targetRoot []byte
+	if firstBlock != nil {
+		if firstBlock.Slot == firstSlot {
+			targetRoot = firstBlock.Root
+		} else {
+			targetRoot = firstBlock.GetParentRoot()
+		}
+	}
+	epochVotes := cache.indexer.epochPipeline.aggregateEpochVotes(blockMap, epoch, epochStats, targetRoot, false, true)
+
+	missingDuties := dbEpoch.Partial&dbtypes.EpochPartialDuties != 0
+	dbEpoch.ValidatorCount = epochStats.validatorStats.ValidatorCount
+	dbEpoch.ValidatorBalance = epochStats.validatorStats.ValidatorBalance
+	dbEpoch.Eligible = epochStats.validatorStats.EligibleAmount
+	dbEpoch.VotedTarget = epochVotes.currentEpoch.targetVoteAmount + epochVotes.nextEpoch.targetVoteAmount
+	dbEpoch.VotedHead = epochVotes.currentEpoch.headVoteAmount + epochVotes.nextEpoch.headVoteAmount
+	dbEpoch.VotedTotal = epochVotes.currentEpoch.totalVoteAmount + epochVotes.nextEpoch.totalVoteAmount
+	dbEpoch.Partial = 0
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	writer := cache.indexer.epochPipeline.newWriter(tx)
+	if err := writer.InsertEpoch(dbEpoch); err != nil {
+		return fmt.Errorf("error updating epoch: %v", err)
+	}
+	if err := persistDailyStats(epoch, writer); err != nil {
+		return fmt.Errorf("error updating daily stats: %v", err)
+	}
+	credentialCounts := epochStats.validatorStats.CredentialCounts
+	err = writer.InsertEpochCredentialStats(&dbtypes.EpochCredentialStats{
+		Epoch:            epoch,
+		BlsCount:         credentialCounts.BlsCount,
+		ExecutionCount:   credentialCounts.ExecutionCount,
+		CompoundingCount: credentialCounts.CompoundingCount,
+		OtherCount:       credentialCounts.OtherCount,
+	})
+	if err != nil {
+		return fmt.Errorf("error inserting credential stats: %v", err)
+	}
+
+	// the per validator vote stats have only been skipped if the duties were missing, they'd be counted twice otherwise
+	if missingDuties {
+		if err := persistValidatorUptime(epoch, epochStats, epochVotes, writer); err != nil {
+			return fmt.Errorf("error inserting validator uptime: %v", err)
+		}
+		if err := persistValidatorVoteStats(epoch, epochStats, epochVotes, writer); err != nil {
+			return fmt.Errorf("error inserting validator vote stats: %v", err)
+		}
+		if err := persistEpochCommitteeStats(epoch, epochStats, epochVotes, writer); err != nil {
+			return fmt.Errorf("error inserting committee stats: %v", err)
+		}
+		if err := persistSlotCommitteeParticipation(epoch, epochStats, epochVotes, writer); err != nil {
+			return fmt.Errorf("error inserting committee participation: %v", err)
+		}
+		if epochVotes.HasTargetSplit() {
+			if err := persistEpochTargetVotes(epoch, epochVotes, writer); err != nil {
+				return fmt.Errorf("error inserting target votes: %v", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+	return nil
+}
@@ -2,9 +2,11 @@ package indexer
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math"
 	"sync"
+	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -15,6 +17,12 @@ import (
 	"github.com/pk910/dora/utils"
 )
 
+const (
+	epochStatsMaxRetries    = 5
+	epochStatsRetryDelay    = 5 * time.Second
+	epochStatsMaxRetryDelay = 3 * time.Minute
+)
+
 type EpochStats struct {
 	Epoch               uint64
 	DependentRoot       []byte
@@ -35,7 +43,16 @@ type EpochValidatorStats struct {
 	ValidatorCount    uint64
 	ValidatorBalance  uint64
 	EligibleAmount    uint64
-	ValidatorBalances map[uint64]uint64
+	ValidatorBalances *ValidatorBalances
+	CredentialCounts  EpochCredentialCounts
+}
+
+// EpochCredentialCounts holds the number of active validators per withdrawal credentials type
+type EpochCredentialCounts struct {
+	BlsCount         uint64
+	ExecutionCount   uint64
+	CompoundingCount uint64
+	OtherCount       uint64
 }
 
 func (cache *indexerCache) getEpochStats(epoch uint64, dependendRoot []byte) *EpochStats {
@@ -51,6 +68,22 @@ func (cache *indexerCache) getEpochStats(epoch uint64, dependendRoot []byte) *Ep
 	return nil
 }
 
+// getEpochStatsForBlock returns the epoch stats with a dependent root in the chain of the block
+func (cache *indexerCache) getEpochStatsForBlock(epoch uint64, blockRoot []byte) *EpochStats {
+	cache.epochStatsMutex.RLock()
+	defer cache.epochStatsMutex.RUnlock()
+	epochStatsList := cache.epochStatsMap[epoch]
+	if len(epochStatsList) == 1 {
+		return epochStatsList[0]
+	}
+	for _, epochStats := range epochStatsList {
+		if cache.isCanonicalBlock(epochStats.DependentRoot, blockRoot) {
+			return epochStats
+		}
+	}
+	return nil
+}
+
 func (cache *indexerCache) createOrGetEpochStats(epoch uint64, dependendRoot []byte) (*EpochStats, bool) {
 	cache.epochStatsMutex.Lock()
 	defer cache.epochStatsMutex.Unlock()
@@ -200,9 +233,20 @@ func (client *IndexerClient) ensureEpochStats(epoch uint64, head []byte) error {
 	}
 	go func() {
 		defer utils.HandleSubroutinePanic("ensureEpochStatsLazy")
-		err := epochStats.ensureEpochStatsLazy(client, proposerRsp)
-		if err != nil {
-			logger.WithField("client", client.clientName).WithError(err).Warnf("error while loading epoch stats for epoch %v", epoch)
+		for retry := 0; ; retry++ {
+			err := utils.CatchPanic("ensureEpochStatsLazy", func() error {
+				return epochStats.ensureEpochStatsLazy(client, proposerRsp)
+			})
+			if err == nil {
+				break
+			}
+			if retry >= epochStatsMaxRetries {
+				logger.WithField("client", client.clientName).WithError(err).Warnf("error while loading epoch stats for epoch %v, giving up", epoch)
+				break
+			}
+			retryDelay := getEpochStatsRetryDelay(retry)
+			logger.WithField("client", client.clientName).WithError(err).Warnf("error while loading epoch stats for epoch %v, retrying in %v", epoch, retryDelay)
+			time.Sleep(retryDelay)
 		}
 	}()
 	if int64(epoch) > client.lastEpochStats {
@@ -286,9 +330,13 @@ func (epochStats *EpochStats) ensureEpochStatsLazy(client *IndexerClient, propos
 		go epochStats.ensureValidatorStatsLazy(client, epochStats.dependentStateRef)
 	}
 
+	// the duties mutex blocks the epoch processing, so bound the time spent on the duty requests in total
+	dutiesCtx, cancelDuties := context.WithTimeout(context.Background(), 2*rpc.GetCallTimeout(rpc.CallTypeDuties))
+	defer cancelDuties()
+
 	// get committee duties
 	if epochStats.attestorAssignments == nil {
-		parsedCommittees, err := client.rpcClient.GetCommitteeDuties(epochStats.dependentStateRef, epochStats.Epoch)
+		parsedCommittees, err := client.rpcClient.GetCommitteeDuties(dutiesCtx, epochStats.dependentStateRef, epochStats.Epoch)
 		if err != nil {
 			return fmt.Errorf("error retrieving committees data: %v", err)
 		}
@@ -313,7 +361,7 @@ func (epochStats *EpochStats) ensureEpochStatsLazy(client *IndexerClient, propos
 		if epochStats.Epoch > 0 && epochStats.Epoch == utils.Config.Chain.Config.AltairForkEpoch {
 			syncCommitteeState = fmt.Sprintf("%d", utils.Config.Chain.Config.AltairForkEpoch*utils.Config.Chain.Config.SlotsPerEpoch)
 		}
-		parsedSyncCommittees, err := client.rpcClient.GetSyncCommitteeDuties(syncCommitteeState, epochStats.Epoch)
+		parsedSyncCommittees, err := client.rpcClient.GetSyncCommitteeDuties(dutiesCtx, syncCommitteeState, epochStats.Epoch)
 		if err != nil {
 			return fmt.Errorf("error retrieving sync_committees for epoch %v (state: %v): %v", epochStats.Epoch, syncCommitteeState, err)
 		}
@@ -337,14 +385,28 @@ func (epochStats *EpochStats) ensureValidatorStatsLazy(client *IndexerClient, st
 	if client.skipValidators {
 		return
 	}
-	epochStats.loadValidatorStats(client, stateRef)
+	for retry := 0; ; retry++ {
+		err := utils.CatchPanic("loadValidatorStats", func() error {
+			return epochStats.loadValidatorStats(client, stateRef)
+		})
+		if err == nil {
+			break
+		}
+		if retry >= epochStatsMaxRetries {
+			logger.WithField("client", client.clientName).Warnf("error fetching epoch %v validators: %v, giving up", epochStats.Epoch, err)
+			break
+		}
+		retryDelay := getEpochStatsRetryDelay(retry)
+		logger.WithField("client", client.clientName).Warnf("error fetching epoch %v validators: %v, retrying in %v", epochStats.Epoch, err, retryDelay)
+		time.Sleep(retryDelay)
+	}
 }
 
-func (epochStats *EpochStats) loadValidatorStats(client *IndexerClient, stateRef string) {
+func (epochStats *EpochStats) loadValidatorStats(client *IndexerClient, stateRef string) error {
 	epochStats.validatorsMutex.Lock()
 	defer epochStats.validatorsMutex.Unlock()
 	if epochStats.validatorStats != nil {
-		return
+		return nil
 	}
 
 	// `lock` concurrency limit (limit concurrent get validators calls)
@@ -353,29 +415,73 @@ func (epochStats *EpochStats) loadValidatorStats(client *IndexerClient, stateRef
 	var epochValidators map[phase0.ValidatorIndex]*v1.Validator
 	var err error
 	if epochStats.Epoch == 0 {
-		epochValidators, err = client.rpcClient.GetStateValidators("genesis")
+		epochValidators, err = client.rpcClient.GetStateValidators(context.Background(), "genesis")
 	} else {
-		epochValidators, err = client.rpcClient.GetStateValidators(stateRef)
+		epochValidators, err = client.rpcClient.GetStateValidators(context.Background(), stateRef)
 	}
 
 	// `unlock` concurrency limit
 	<-client.indexerCache.validatorLoadingLimiter
 
 	if err != nil {
-		logger.Warnf("error fetching epoch %v validators: %v", epochStats.Epoch, err)
-		return
+		return err
 	}
 	client.indexerCache.setLastValidators(epochStats.Epoch, epochValidators)
-	validatorStats := &EpochValidatorStats{
-		ValidatorBalances: make(map[uint64]uint64),
-	}
+	validatorBalances := newValidatorBalances(uint64(len(epochValidators)))
+	validatorStats := &EpochValidatorStats{}
 	for _, validator := range epochValidators {
-		validatorStats.ValidatorBalances[uint64(validator.Index)] = uint64(validator.Validator.EffectiveBalance)
+		validatorBalances.set(uint64(validator.Index), uint64(validator.Validator.EffectiveBalance))
 		if uint64(validator.Validator.ActivationEpoch) <= epochStats.Epoch && epochStats.Epoch < uint64(validator.Validator.ExitEpoch) {
 			validatorStats.ValidatorCount++
 			validatorStats.ValidatorBalance += uint64(validator.Balance)
 			validatorStats.EligibleAmount += uint64(validator.Validator.EffectiveBalance)
+			validatorStats.CredentialCounts.addCredentials(validator.Validator.WithdrawalCredentials)
 		}
 	}
+	validatorStats.ValidatorBalances = client.indexerCache.shareValidatorBalances(validatorBalances)
 	epochStats.validatorStats = validatorStats
+	return nil
+}
+
+// getEpochStatsRetryDelay returns the delay before the next attempt to load the duties or validators of an epoch,
+// doubling with each failed attempt up to a few minutes
+func getEpochStatsRetryDelay(retry int) time.Duration {
+	retryDelay := epochStatsRetryDelay << retry
+	if retryDelay > epochStatsMaxRetryDelay {
+		retryDelay = epochStatsMaxRetryDelay
+	}
+	return retryDelay
+}
+
+// getPartialFlags returns the dbtypes.EpochPartial* flags for the data that is missing in the epoch stats.
+// Epochs persisted with these flags are reloaded & re-aggregated by the partial epoch repair.
+func (epochStats *EpochStats) getPartialFlags() uint8 {
+	if epochStats == nil {
+		return dbtypes.EpochPartialValidators | dbtypes.EpochPartialDuties
+	}
+	var flags uint8
+	if epochStats.validatorStats == nil {
+		flags |= dbtypes.EpochPartialValidators
+	}
+	if epochStats.attestorAssignments == nil {
+		flags |= dbtypes.EpochPartialDuties
+	}
+	return flags
+}
+
+func (counts *EpochCredentialCounts) addCredentials(withdrawalCredentials []byte) {
+	if len(withdrawalCredentials) == 0 {
+		counts.OtherCount++
+		return
+	}
+	switch withdrawalCredentials[0] {
+	case 0x00:
+		counts.BlsCount++
+	case 0x01:
+		counts.ExecutionCount++
+	case 0x02:
+		counts.CompoundingCount++
+	default:
+		counts.OtherCount++
+	}
 }
@@ -0,0 +1,122 @@
+package indexer
+
+import (
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+)
+
+const (
+	EventTapBlock     = "block"
+	EventTapHead      = "head"
+	EventTapFinalized = "finalized_checkpoint"
+)
+
+// number of slots a published head root is remembered to drop the same head event from other clients
+const eventTapHeadHistory = 64
+
+// EventTapEvent is a beacon node event, deduplicated over all clients before it's re-broadcasted.
+// Data is the parsed node event (*v1.BlockEvent, *v1.HeadEvent or *v1.FinalizedCheckpointEvent).
+type EventTapEvent struct {
+	Topic string
+	Data  interface{}
+}
+
+// EventTapSubscription receives the node events of the subscribed topics.
+// Slow receivers lose events instead of blocking the indexer clients.
+type EventTapSubscription struct {
+	indexer *Indexer
+	topics  map[string]bool
+	Events  chan *EventTapEvent
+}
+
+type eventTapDispatcher struct {
+	mutex         sync.RWMutex
+	subscriptions map[*EventTapSubscription]bool
+	seenMutex     sync.Mutex
+	seenHeads     map[string]uint64
+	lastFinalized int64
+}
+
+// SubscribeEvents creates a new subscription for the given event topics
+func (indexer *Indexer) SubscribeEvents(topics []string) *EventTapSubscription {
+	subscription := &EventTapSubscription{
+		indexer: indexer,
+		topics:  map[string]bool{},
+		Events:  make(chan *EventTapEvent, 32),
+	}
+	for _, topic := range topics {
+		subscription.topics[topic] = true
+	}
+	indexer.eventTap.mutex.Lock()
+	defer indexer.eventTap.mutex.Unlock()
+	indexer.eventTap.subscriptions[subscription] = true
+	return subscription
+}
+
+// Unsubscribe stops event delivery for this subscription
+func (subscription *EventTapSubscription) Unsubscribe() {
+	subscription.indexer.eventTap.mutex.Lock()
+	defer subscription.indexer.eventTap.mutex.Unlock()
+	delete(subscription.indexer.eventTap.subscriptions, subscription)
+}
+
+func (dispatcher *eventTapDispatcher) publish(topic string, data interface{}) {
+	dispatcher.mutex.RLock()
+	defer dispatcher.mutex.RUnlock()
+	if len(dispatcher.subscriptions) == 0 {
+		return
+	}
+
+	event := &EventTapEvent{
+		Topic: topic,
+		Data:  data,
+	}
+	for subscription := range dispatcher.subscriptions {
+		if !subscription.topics[topic] {
+			continue
+		}
+		select {
+		case subscription.Events <- event:
+		default:
+			logger.Debugf("event tap subscription queue full, dropped %v event", topic)
+		}
+	}
+}
+
+// publishBlock broadcasts a block event, the caller only passes blocks that weren't seen before
+func (dispatcher *eventTapDispatcher) publishBlock(evt *v1.BlockEvent) {
+	dispatcher.publish(EventTapBlock, evt)
+}
+
+// publishHead broadcasts a head event, unless the same head has already been announced by another client
+func (dispatcher *eventTapDispatcher) publishHead(evt *v1.HeadEvent) {
+	dispatcher.seenMutex.Lock()
+	rootKey := string(evt.Block[:])
+	if _, seen := dispatcher.seenHeads[rootKey]; seen {
+		dispatcher.seenMutex.Unlock()
+		return
+	}
+	dispatcher.seenHeads[rootKey] = uint64(evt.Slot)
+	for root, slot := range dispatcher.seenHeads {
+		if slot+eventTapHeadHistory < uint64(evt.Slot) {
+			delete(dispatcher.seenHeads, root)
+		}
+	}
+	dispatcher.seenMutex.Unlock()
+
+	dispatcher.publish(EventTapHead, evt)
+}
+
+// publishFinalized broadcasts a finalized checkpoint event for each newly finalized epoch
+func (dispatcher *eventTapDispatcher) publishFinalized(evt *v1.FinalizedCheckpointEvent) {
+	dispatcher.seenMutex.Lock()
+	if int64(evt.Epoch) <= dispatcher.lastFinalized {
+		dispatcher.seenMutex.Unlock()
+		return
+	}
+	dispatcher.lastFinalized = int64(evt.Epoch)
+	dispatcher.seenMutex.Unlock()
+
+	dispatcher.publish(EventTapFinalized, evt)
+}
@@ -0,0 +1,49 @@
+package indexer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+// recordFinalityTransition persists a move of the justified or finalized checkpoint in the background.
+// The epochs are the last fully justified / finalized epochs of the cache, the checkpoint epoch is the following one.
+// The checkpoints loaded on startup are no observed transition, so they are skipped to keep the timings meaningful.
+func (cache *indexerCache) recordFinalityTransition(checkpointType uint8, prevEpoch int64, epoch int64, root []byte) {
+	if prevEpoch < 0 || !cache.indexer.writeDb {
+		return
+	}
+
+	checkpoint := &dbtypes.FinalityCheckpoint{
+		CheckpointType: checkpointType,
+		Epoch:          uint64(epoch + 1),
+		Root:           root,
+		PrevEpoch:      uint64(prevEpoch + 1),
+		SeenTime:       uint64(time.Now().Unix()),
+	}
+	go func() {
+		defer utils.HandleSubroutinePanic("recordFinalityTransition")
+		if err := persistFinalityCheckpoint(checkpoint); err != nil {
+			logger.Errorf("error persisting finality checkpoint %v (type %v): %v", checkpoint.Epoch, checkpoint.CheckpointType, err)
+		}
+	}()
+}
+
+func persistFinalityCheckpoint(checkpoint *dbtypes.FinalityCheckpoint) error {
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := db.InsertFinalityCheckpoint(checkpoint, tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+	return nil
+}
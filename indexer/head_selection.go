@@ -0,0 +1,141 @@
+package indexer
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pk910/dora/utils"
+)
+
+const (
+	HeadSelectionClients  = "clients"  // head fork with the most ready clients
+	HeadSelectionPriority = "priority" // head fork with the highest priority ready client, client count as tie breaker
+)
+
+// HeadDisagreement is a snapshot of the competing head forks while the clients disagree on the head
+type HeadDisagreement struct {
+	Time  time.Time               `json:"time"`
+	Forks []*HeadDisagreementFork `json:"forks"`
+}
+
+type HeadDisagreementFork struct {
+	Slot              uint64   `json:"slot"`
+	Root              []byte   `json:"root"`
+	Clients           []string `json:"clients"`
+	FinalityConflict  bool     `json:"finality_conflict"`
+	SelectedCanonical bool     `json:"selected_canonical"`
+}
+
+type headDisagreementTracker struct {
+	mutex        sync.Mutex
+	disagreeing  bool
+	count        uint64
+	current      *HeadDisagreement
+	lastResolved *HeadDisagreement
+}
+
+// sortHeadForks orders the head forks by the configured head selection, the first fork is the canonical head.
+// forks that don't descend from the finalized checkpoint are always sorted last, regardless of their client weight.
+func (indexer *Indexer) sortHeadForks(headForks []*HeadFork) {
+	finalizedEpoch, finalizedRoot, _, _ := indexer.indexerCache.getFinalizationCheckpoints()
+	for _, fork := range headForks {
+		fork.FinalityConflict = indexer.isFinalityConflict(fork.Root, finalizedEpoch, finalizedRoot)
+		for _, client := range fork.ReadyClients {
+			if fork.Priority == nil || client.priority > *fork.Priority {
+				priority := client.priority
+				fork.Priority = &priority
+			}
+		}
+	}
+
+	byPriority := utils.Config.Indexer.HeadSelection == HeadSelectionPriority
+	sort.SliceStable(headForks, func(a, b int) bool {
+		forkA := headForks[a]
+		forkB := headForks[b]
+		if forkA.FinalityConflict != forkB.FinalityConflict {
+			return !forkA.FinalityConflict
+		}
+		if byPriority && forkA.Priority != nil && forkB.Priority != nil && *forkA.Priority != *forkB.Priority {
+			return *forkA.Priority > *forkB.Priority
+		}
+		return len(forkA.ReadyClients) > len(forkB.ReadyClients)
+	})
+}
+
+// isFinalityConflict checks if the head descends from a different block than the finalized checkpoint.
+// heads that can't be traced back to the finalized slot with the cached blocks are assumed to agree.
+func (indexer *Indexer) isFinalityConflict(head []byte, finalizedEpoch int64, finalizedRoot []byte) bool {
+	if finalizedEpoch < 0 || finalizedRoot == nil {
+		return false
+	}
+	finalizedSlot := uint64(finalizedEpoch) * utils.Config.Chain.Config.SlotsPerEpoch
+	block := indexer.indexerCache.getCachedBlock(head)
+	for block != nil {
+		if bytes.Equal(block.Root, finalizedRoot) {
+			return false
+		}
+		if block.Slot <= finalizedSlot {
+			return true
+		}
+		parentRoot := block.GetParentRoot()
+		if parentRoot == nil {
+			break
+		}
+		block = indexer.indexerCache.getCachedBlock(parentRoot)
+	}
+	return false
+}
+
+// checkHeadDisagreement records a disagreement while the ready clients follow different head forks
+func (indexer *Indexer) checkHeadDisagreement() {
+	headForks := indexer.GetHeadForks(true)
+	tracker := &indexer.headDisagreements
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if len(headForks) < 2 {
+		if tracker.disagreeing {
+			tracker.disagreeing = false
+			tracker.lastResolved = tracker.current
+			tracker.current = nil
+			if len(headForks) > 0 {
+				logger.Infof("clients agree on head again: slot %v [0x%x]", headForks[0].Slot, headForks[0].Root)
+			}
+		}
+		return
+	}
+
+	disagreement := &HeadDisagreement{
+		Time:  time.Now(),
+		Forks: make([]*HeadDisagreementFork, len(headForks)),
+	}
+	for idx, fork := range headForks {
+		clientNames := make([]string, len(fork.AllClients))
+		for clientIdx, client := range fork.AllClients {
+			clientNames[clientIdx] = client.clientName
+		}
+		disagreement.Forks[idx] = &HeadDisagreementFork{
+			Slot:              fork.Slot,
+			Root:              fork.Root,
+			Clients:           clientNames,
+			FinalityConflict:  fork.FinalityConflict,
+			SelectedCanonical: idx == 0,
+		}
+	}
+	if !tracker.disagreeing {
+		tracker.disagreeing = true
+		tracker.count++
+		logger.Warnf("clients disagree on head: %v forks, selected slot %v [0x%x] (%v clients)", len(headForks), headForks[0].Slot, headForks[0].Root, len(headForks[0].ReadyClients))
+	}
+	tracker.current = disagreement
+}
+
+// GetHeadDisagreements returns the number of head disagreements since startup, the ongoing and the last resolved disagreement
+func (indexer *Indexer) GetHeadDisagreements() (uint64, *HeadDisagreement, *HeadDisagreement) {
+	tracker := &indexer.headDisagreements
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	return tracker.count, tracker.current, tracker.lastResolved
+}
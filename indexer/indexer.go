@@ -5,6 +5,7 @@ import (
 	"math"
 	"math/rand"
 	"sort"
+	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -21,11 +22,18 @@ var logger = logrus.StandardLogger().WithField("module", "indexer")
 type Indexer struct {
 	BlobStore             *BlobStore
 	indexerCache          *indexerCache
+	epochPipeline         *epochPipeline
 	indexerClients        []*IndexerClient
 	writeDb               bool
 	disableSync           bool
 	inMemoryEpochs        uint16
 	cachePersistenceDelay uint16
+	epochQueueThreshold   uint16
+	activity              activityDispatcher
+	eventTap              eventTapDispatcher
+	chainChanges          chainChangeDispatcher
+	headDisagreements     headDisagreementTracker
+	reorgResync           reorgResyncState
 }
 
 func NewIndexer() (*Indexer, error) {
@@ -37,6 +45,10 @@ func NewIndexer() (*Indexer, error) {
 	if cachePersistenceDelay < 2 {
 		cachePersistenceDelay = 2
 	}
+	epochQueueThreshold := utils.Config.Indexer.EpochQueueThreshold
+	if epochQueueThreshold < 1 {
+		epochQueueThreshold = 4
+	}
 
 	indexer := &Indexer{
 		BlobStore:             newBlobStore(),
@@ -45,24 +57,49 @@ func NewIndexer() (*Indexer, error) {
 		disableSync:           utils.Config.Indexer.DisableSynchronizer,
 		inMemoryEpochs:        inMemoryEpochs,
 		cachePersistenceDelay: cachePersistenceDelay,
+		epochQueueThreshold:   epochQueueThreshold,
+		activity: activityDispatcher{
+			subscriptions: map[*ActivitySubscription]bool{},
+		},
+		eventTap: eventTapDispatcher{
+			subscriptions: map[*EventTapSubscription]bool{},
+			seenHeads:     map[string]uint64{},
+			lastFinalized: -1,
+		},
+		chainChanges: chainChangeDispatcher{
+			subscriptions: map[*ChainChangeSubscription]bool{},
+		},
 	}
 	indexer.indexerCache = newIndexerCache(indexer)
+	indexer.epochPipeline = newEpochPipeline(time.Now, indexer.getValidatorIndexes, newDbEpochDataWriter)
 
 	return indexer, nil
 }
 
 func (indexer *Indexer) AddClient(index uint8, endpoint *types.EndpointConfig) *IndexerClient {
 
-	rpcClient, err := rpc.NewBeaconClient(endpoint.Url, endpoint.Name, endpoint.Headers, endpoint.Ssh)
+	rpcClient, err := rpc.NewBeaconClient(endpoint.Url, endpoint.Name, endpoint.Headers, endpoint.Ssh, endpoint.Fixture)
 	if err != nil {
 		logger.Errorf("error while adding client %v to indexer: %v", endpoint.Name, err)
 		return nil
 	}
+	if genesisTime, ok := rpcClient.GetReplayGenesisTime(); ok && utils.Config.Chain.GenesisTimestamp != genesisTime {
+		// align slot clock with the replayed chain
+		logger.Infof("fixture replay: shifting genesis time to %v", genesisTime)
+		utils.Config.Chain.GenesisTimestamp = genesisTime
+	}
 	client := newIndexerClient(index, endpoint.Name, rpcClient, indexer.indexerCache, endpoint.Archive, endpoint.Priority, endpoint.SkipValidators)
 	indexer.indexerClients = append(indexer.indexerClients, client)
 	return client
 }
 
+// Close releases the resources of the rpc clients (fixture recorders / replayers & ssh tunnels)
+func (indexer *Indexer) Close() {
+	for _, client := range indexer.indexerClients {
+		client.rpcClient.Close()
+	}
+}
+
 func (indexer *Indexer) GetClients() []*IndexerClient {
 	return indexer.indexerClients
 }
@@ -77,6 +114,7 @@ func (indexer *Indexer) GetReadyClient(archive bool, head []byte, skip []*Indexe
 				clientCandidates = append(clientCandidates, client)
 			}
 		}
+		clientCandidates = filterHealthyClients(clientCandidates)
 		candidateCount = len(clientCandidates)
 	}
 	allCandidates := make([]*IndexerClient, candidateCount)
@@ -116,7 +154,7 @@ func (indexer *Indexer) GetReadyClient(archive bool, head []byte, skip []*Indexe
 func (indexer *Indexer) GetReadyClients(archive bool, head []byte) []*IndexerClient {
 	headCandidates := indexer.GetHeadForks(true)
 	if len(headCandidates) == 0 {
-		return indexer.indexerClients
+		return filterHealthyClients(indexer.indexerClients)
 	}
 
 	var headFork *HeadFork
@@ -139,7 +177,21 @@ func (indexer *Indexer) GetReadyClients(archive bool, head []byte) []*IndexerCli
 	if len(clientCandidates) == 0 && archive {
 		clientCandidates = indexer.getReadyClientCandidates(headFork, false)
 	}
-	return clientCandidates
+	return filterHealthyClients(clientCandidates)
+}
+
+// filterHealthyClients demotes clients with an open circuit breaker, unless there are no other clients left
+func filterHealthyClients(clients []*IndexerClient) []*IndexerClient {
+	healthyClients := make([]*IndexerClient, 0, len(clients))
+	for _, client := range clients {
+		if !client.rpcClient.GetCircuitBreaker().IsOpen() {
+			healthyClients = append(healthyClients, client)
+		}
+	}
+	if len(healthyClients) == 0 {
+		return clients
+	}
+	return healthyClients
 }
 
 func (indexer *Indexer) getReadyClientCandidates(headFork *HeadFork, archive bool) []*IndexerClient {
@@ -182,6 +234,28 @@ func (indexer *Indexer) GetHighestSlot() uint64 {
 	return uint64(indexer.indexerCache.highestSlot)
 }
 
+// GetLowestLiveEpoch returns the first epoch that is served from the cache, older epochs are loaded from the db.
+// Without index writer (light mode) the finalized epochs of the in-memory window are kept in the cache as well.
+func (indexer *Indexer) GetLowestLiveEpoch() int64 {
+	finalizedEpoch, _, _, _ := indexer.indexerCache.getFinalizationCheckpoints()
+	if indexer.writeDb {
+		return finalizedEpoch + 1
+	}
+
+	indexer.indexerCache.cacheMutex.RLock()
+	lowestSlot := indexer.indexerCache.lowestSlot
+	indexer.indexerCache.cacheMutex.RUnlock()
+
+	lowestEpoch := indexer.indexerCache.cleanupBlockEpoch + 1
+	if lowestSlot >= 0 && int64(utils.EpochOfSlot(uint64(lowestSlot))) > lowestEpoch {
+		lowestEpoch = int64(utils.EpochOfSlot(uint64(lowestSlot)))
+	}
+	if lowestEpoch > finalizedEpoch+1 {
+		lowestEpoch = finalizedEpoch + 1
+	}
+	return lowestEpoch
+}
+
 func (indexer *Indexer) GetHeadForks(readyOnly bool) []*HeadFork {
 	headForks := []*HeadFork{}
 	for _, client := range indexer.indexerClients {
@@ -232,12 +306,8 @@ func (indexer *Indexer) GetHeadForks(readyOnly bool) []*HeadFork {
 		}
 	}
 
-	// sort by relevance (client count)
-	sort.Slice(headForks, func(a, b int) bool {
-		countA := len(headForks[a].ReadyClients)
-		countB := len(headForks[b].ReadyClients)
-		return countA > countB
-	})
+	// sort by relevance (finality agreement, client count or priority)
+	indexer.sortHeadForks(headForks)
 
 	return headForks
 }
@@ -302,6 +372,29 @@ func (indexer *Indexer) GetCachedBlockByStateroot(stateroot []byte) *CacheBlock
 	return nil
 }
 
+// GetCachedBlockByBodyroot returns the unfinalized block with the given body root
+func (indexer *Indexer) GetCachedBlockByBodyroot(bodyroot []byte) *CacheBlock {
+	indexer.indexerCache.cacheMutex.RLock()
+	defer indexer.indexerCache.cacheMutex.RUnlock()
+
+	var lowestSlotIdx int64
+	if indexer.indexerCache.finalizedEpoch >= 0 {
+		lowestSlotIdx = (indexer.indexerCache.finalizedEpoch + 1) * int64(utils.Config.Chain.Config.SlotsPerEpoch)
+	} else {
+		lowestSlotIdx = 0
+	}
+	for slotIdx := int64(indexer.indexerCache.highestSlot); slotIdx >= lowestSlotIdx; slotIdx-- {
+		slot := uint64(slotIdx)
+		blocks := indexer.indexerCache.slotMap[slot]
+		for _, block := range blocks {
+			if block.IsReady() && bytes.Equal(block.header.Message.BodyRoot[:], bodyroot) {
+				return block
+			}
+		}
+	}
+	return nil
+}
+
 func (indexer *Indexer) GetCachedBlocksByExecutionBlockHash(hash []byte) []*CacheBlock {
 	indexer.indexerCache.cacheMutex.RLock()
 	defer indexer.indexerCache.cacheMutex.RUnlock()
@@ -390,6 +483,49 @@ func (indexer *Indexer) GetFirstCachedCanonicalBlock(epoch uint64, head []byte)
 	return block
 }
 
+// ClientArrivalStats summarizes how far a client is behind the first client announcing the cached blocks
+type ClientArrivalStats struct {
+	BlockCount uint64
+	AvgBehind  time.Duration
+	MaxBehind  time.Duration
+}
+
+// GetClientArrivalStats aggregates the block arrival times of all cached blocks seen by more than one client
+func (indexer *Indexer) GetClientArrivalStats() map[uint8]*ClientArrivalStats {
+	indexer.indexerCache.cacheMutex.RLock()
+	blocks := make([]*CacheBlock, 0, len(indexer.indexerCache.rootMap))
+	for _, block := range indexer.indexerCache.rootMap {
+		blocks = append(blocks, block)
+	}
+	indexer.indexerCache.cacheMutex.RUnlock()
+
+	stats := map[uint8]*ClientArrivalStats{}
+	behindSums := map[uint8]time.Duration{}
+	for _, block := range blocks {
+		arrivals := block.GetArrivals()
+		if len(arrivals) < 2 {
+			continue
+		}
+		for _, arrival := range arrivals {
+			clientStats := stats[arrival.ClientIndex]
+			if clientStats == nil {
+				clientStats = &ClientArrivalStats{}
+				stats[arrival.ClientIndex] = clientStats
+			}
+			behind := arrival.Time.Sub(arrivals[0].Time)
+			clientStats.BlockCount++
+			behindSums[arrival.ClientIndex] += behind
+			if behind > clientStats.MaxBehind {
+				clientStats.MaxBehind = behind
+			}
+		}
+	}
+	for clientIdx, clientStats := range stats {
+		clientStats.AvgBehind = behindSums[clientIdx] / time.Duration(clientStats.BlockCount)
+	}
+	return stats
+}
+
 func (indexer *Indexer) GetCachedEpochStats(epoch uint64) *EpochStats {
 	_, headRoot := indexer.GetCanonicalHead()
 	return indexer.getCachedEpochStats(epoch, headRoot)
@@ -428,10 +564,82 @@ func (indexer *Indexer) getCachedEpochStats(epoch uint64, headRoot []byte) *Epoc
 	return epochStats
 }
 
+// GetCachedEpochStatsForBlock returns the epoch stats of the chain the block is part of.
+// Other than GetCachedEpochStats there is no fallback to the stats of another fork, as the committees of
+// the forks may differ. nil is returned if the duties of the blocks chain are not known.
+func (indexer *Indexer) GetCachedEpochStatsForBlock(epoch uint64, blockRoot []byte) *EpochStats {
+	return indexer.indexerCache.getEpochStatsForBlock(epoch, blockRoot)
+}
+
 func (indexer *Indexer) GetCachedValidatorSet() map[phase0.ValidatorIndex]*v1.Validator {
 	return indexer.indexerCache.lastValidatorsResp
 }
 
+// getValidatorIndexes resolves the indexes of the pubkeys from the cached validator set.
+// The pubkey index of the set is built on first use and rebuilt after a newer set has been loaded.
+func (indexer *Indexer) getValidatorIndexes(pubkeys [][]byte) map[string]uint64 {
+	cache := indexer.indexerCache
+	cache.cacheMutex.RLock()
+	validatorsEpoch, validators := cache.lastValidatorsEpoch, cache.lastValidatorsResp
+	cache.cacheMutex.RUnlock()
+
+	cache.pubkeyIndexMutex.Lock()
+	if cache.pubkeyIndexEpoch != validatorsEpoch {
+		pubkeyIndex := make(map[string]uint64, len(validators))
+		for index, validator := range validators {
+			pubkeyIndex[string(validator.Validator.PublicKey[:])] = uint64(index)
+		}
+		cache.pubkeyIndex = pubkeyIndex
+		cache.pubkeyIndexEpoch = validatorsEpoch
+	}
+	pubkeyIndex := cache.pubkeyIndex
+	cache.pubkeyIndexMutex.Unlock()
+
+	indexes := make(map[string]uint64, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		if index, found := pubkeyIndex[string(pubkey)]; found {
+			indexes[string(pubkey)] = index
+		}
+	}
+	return indexes
+}
+
+// GetCachedValidatorSetEpoch returns the epoch of the cached validator set (-1 if no set has been loaded yet)
+func (indexer *Indexer) GetCachedValidatorSetEpoch() int64 {
+	indexer.indexerCache.cacheMutex.RLock()
+	defer indexer.indexerCache.cacheMutex.RUnlock()
+	return indexer.indexerCache.lastValidatorsEpoch
+}
+
+// IndexerCacheStats holds the number of objects in the indexer cache, used to debug memory growth
+type IndexerCacheStats struct {
+	CachedSlots      uint64 `json:"cached_slots"`
+	CachedBlocks     uint64 `json:"cached_blocks"`
+	CachedEpochStats uint64 `json:"cached_epoch_stats"`
+	LowestSlot       int64  `json:"lowest_slot"`
+	HighestSlot      int64  `json:"highest_slot"`
+}
+
+func (indexer *Indexer) GetCacheStats() *IndexerCacheStats {
+	cache := indexer.indexerCache
+	stats := &IndexerCacheStats{}
+
+	cache.cacheMutex.RLock()
+	stats.CachedSlots = uint64(len(cache.slotMap))
+	stats.CachedBlocks = uint64(len(cache.rootMap))
+	stats.LowestSlot = cache.lowestSlot
+	stats.HighestSlot = cache.highestSlot
+	cache.cacheMutex.RUnlock()
+
+	cache.epochStatsMutex.RLock()
+	for _, epochStats := range cache.epochStatsMap {
+		stats.CachedEpochStats += uint64(len(epochStats))
+	}
+	cache.epochStatsMutex.RUnlock()
+
+	return stats
+}
+
 func (indexer *Indexer) GetEpochVotes(epoch uint64) (*EpochStats, *EpochVotes) {
 	epochStats := indexer.GetCachedEpochStats(epoch)
 	if epochStats == nil {
@@ -440,6 +648,15 @@ func (indexer *Indexer) GetEpochVotes(epoch uint64) (*EpochStats, *EpochVotes) {
 	return epochStats, indexer.getEpochVotes(epoch, epochStats)
 }
 
+// GetSlotCommitteeParticipation returns the per committee participation of the slots in a cached epoch
+func (indexer *Indexer) GetSlotCommitteeParticipation(epoch uint64) []*dbtypes.SlotCommitteeParticipation {
+	epochStats, epochVotes := indexer.GetEpochVotes(epoch)
+	if epochStats == nil || epochVotes == nil {
+		return nil
+	}
+	return buildSlotCommitteeParticipation(epoch, epochStats, epochVotes)
+}
+
 func (indexer *Indexer) getEpochVotes(epoch uint64, epochStats *EpochStats) *EpochVotes {
 	_, headRoot := indexer.GetCanonicalHead()
 
@@ -465,7 +682,7 @@ func (indexer *Indexer) getEpochVotes(epoch uint64, epochStats *EpochStats) *Epo
 	}
 
 	// calculate votes
-	return aggregateEpochVotes(canonicalMap, epoch, epochStats, epochTarget, false, false)
+	return indexer.epochPipeline.aggregateEpochVotes(canonicalMap, epoch, epochStats, epochTarget, false, false)
 }
 
 func (indexer *Indexer) BuildLiveEpoch(epoch uint64) *dbtypes.Epoch {
@@ -494,7 +711,7 @@ func (indexer *Indexer) buildLiveEpoch(epoch uint64, epochStats *EpochStats) (*d
 	logger.Tracef("build live epoch data %v", epoch)
 	canonicalMap := indexer.indexerCache.getCanonicalBlockMap(epoch, headRoot)
 	epochVotes := indexer.getEpochVotes(epoch, epochStats)
-	dbEpoch := buildDbEpoch(epoch, canonicalMap, epochStats, epochVotes, nil)
+	dbEpoch := indexer.epochPipeline.buildDbEpoch(epoch, canonicalMap, epochStats, epochVotes, nil)
 	if headEpoch > epoch && headEpoch-epoch > 2 {
 		epochStats.dbEpochCache = dbEpoch
 	}
@@ -510,8 +727,15 @@ func (indexer *Indexer) BuildLiveBlock(block *CacheBlock) *dbtypes.Block {
 		logger.Tracef("build live block data 0x%x", block.Root)
 		header := block.GetHeader()
 		epoch := utils.EpochOfSlot(uint64(header.Message.Slot))
-		epochStats := indexer.GetCachedEpochStats(epoch)
+		epochStats := indexer.indexerCache.getEpochStatsForBlock(epoch, block.Root)
+		forkEpochStats := epochStats == nil
+		if forkEpochStats {
+			epochStats = indexer.GetCachedEpochStats(epoch)
+		}
 		dbBlock = buildDbBlock(block, epochStats)
+		if forkEpochStats && dbBlock != nil {
+			dbBlock.ProposerDependentRoot = nil
+		}
 		if epochStats != nil {
 			block.dbBlockCache = dbBlock
 		}
@@ -0,0 +1,122 @@
+package indexer
+
+import "time"
+
+// IndexerState is a snapshot of the indexer internals, exposed for debugging
+type IndexerState struct {
+	*IndexerCacheStats
+	HeadSlot             uint64                `json:"head_slot"`
+	HeadRoot             []byte                `json:"head_root"`
+	FinalizedEpoch       int64                 `json:"finalized_epoch"`
+	FinalizedRoot        []byte                `json:"finalized_root"`
+	JustifiedEpoch       int64                 `json:"justified_epoch"`
+	JustifiedRoot        []byte                `json:"justified_root"`
+	PrefillEpoch         int64                 `json:"prefill_epoch"`
+	ValidatorSetEpoch    int64                 `json:"validator_set_epoch"`
+	ProcessingBusy       bool                  `json:"processing_busy"`
+	ProcessedEpoch       int64                 `json:"processed_epoch"`
+	ProcessingRetry      uint64                `json:"processing_retry"`
+	PersistEpoch         int64                 `json:"persist_epoch"`
+	CleanupBlockEpoch    int64                 `json:"cleanup_block_epoch"`
+	CleanupStatsEpoch    int64                 `json:"cleanup_stats_epoch"`
+	EpochQueueEnd        int64                 `json:"epoch_queue_end"`
+	EpochQueueRunning    bool                  `json:"epoch_queue_running"`
+	Synchronizer         *IndexerStateSync     `json:"synchronizer"`
+	Clients              []*IndexerStateClient `json:"clients"`
+	ReorgCount           uint64                `json:"reorg_count"` // highest reorg count seen by a single client
+	ReorgResyncs         uint64                `json:"reorg_resyncs"`
+	LastReorgResync      time.Time             `json:"last_reorg_resync"`
+	ValidatorLoadsQueue  int                   `json:"validator_loads_queue"`
+	HeadDisagreements    uint64                `json:"head_disagreements"`
+	HeadDisagreement     *HeadDisagreement     `json:"head_disagreement,omitempty"`
+	LastHeadDisagreement *HeadDisagreement     `json:"last_head_disagreement,omitempty"`
+}
+
+type IndexerStateSync struct {
+	Running      bool   `json:"running"`
+	CurrentEpoch uint64 `json:"current_epoch"`
+	CachedSlot   uint64 `json:"cached_slot"`
+}
+
+type IndexerStateClient struct {
+	Index          uint8  `json:"index"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	HeadSlot       int64  `json:"head_slot"`
+	HeadRoot       []byte `json:"head_root"`
+	FinalizedEpoch int64  `json:"finalized_epoch"`
+	SyncDistance   uint64 `json:"sync_distance"`
+	RetryCounter   uint64 `json:"retry_counter"`
+	ReorgCount     uint64 `json:"reorg_count"`
+}
+
+// GetIndexerState collects the current indexer state. The processing fields are only filled while the
+// epoch processing is idle, as they're guarded by the processing lock.
+func (indexer *Indexer) GetIndexerState() *IndexerState {
+	cache := indexer.indexerCache
+	state := &IndexerState{
+		IndexerCacheStats: indexer.GetCacheStats(),
+		Clients:           []*IndexerStateClient{},
+	}
+	state.HeadSlot, state.HeadRoot = indexer.GetCanonicalHead()
+
+	cache.cacheMutex.RLock()
+	state.FinalizedEpoch = cache.finalizedEpoch
+	state.FinalizedRoot = cache.finalizedRoot
+	state.JustifiedEpoch = cache.justifiedEpoch
+	state.JustifiedRoot = cache.justifiedRoot
+	state.PrefillEpoch = cache.prefillEpoch
+	state.ValidatorSetEpoch = cache.lastValidatorsEpoch
+	synchronizer := cache.synchronizer
+	cache.cacheMutex.RUnlock()
+
+	if cache.processingMutex.TryLock() {
+		state.ProcessedEpoch = cache.processedEpoch
+		state.ProcessingRetry = cache.processingRetry
+		state.PersistEpoch = cache.persistEpoch
+		state.CleanupBlockEpoch = cache.cleanupBlockEpoch
+		state.CleanupStatsEpoch = cache.cleanupStatsEpoch
+		cache.processingMutex.Unlock()
+	} else {
+		state.ProcessingBusy = true
+	}
+
+	cache.epochQueueMutex.Lock()
+	state.EpochQueueEnd = cache.epochQueueEnd
+	state.EpochQueueRunning = cache.epochQueueRunning
+	cache.epochQueueMutex.Unlock()
+
+	state.Synchronizer = &IndexerStateSync{}
+	if synchronizer != nil {
+		synchronizer.stateMutex.Lock()
+		state.Synchronizer.Running = synchronizer.running
+		state.Synchronizer.CurrentEpoch = synchronizer.currentEpoch
+		state.Synchronizer.CachedSlot = synchronizer.cachedSlot
+		synchronizer.stateMutex.Unlock()
+	}
+	state.ValidatorLoadsQueue = len(cache.validatorLoadingLimiter)
+	state.HeadDisagreements, state.HeadDisagreement, state.LastHeadDisagreement = indexer.GetHeadDisagreements()
+	state.ReorgResyncs, state.LastReorgResync = indexer.GetReorgResyncStats()
+
+	for _, client := range indexer.GetClients() {
+		client.cacheMutex.RLock()
+		clientState := &IndexerStateClient{
+			Index:          client.clientIdx,
+			Name:           client.clientName,
+			HeadSlot:       client.lastHeadSlot,
+			HeadRoot:       client.lastHeadRoot,
+			FinalizedEpoch: client.lastFinalizedEpoch,
+			SyncDistance:   client.syncDistance,
+			RetryCounter:   client.retryCounter,
+			ReorgCount:     client.reorgCount,
+		}
+		client.cacheMutex.RUnlock()
+		clientState.Status = client.GetStatus()
+		if clientState.ReorgCount > state.ReorgCount {
+			state.ReorgCount = clientState.ReorgCount
+		}
+		state.Clients = append(state.Clients, clientState)
+	}
+
+	return state
+}
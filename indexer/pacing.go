@@ -0,0 +1,105 @@
+package indexer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pk910/dora/utils"
+)
+
+const (
+	pacingIdleLatency      = 250 * time.Millisecond
+	pacingOverloadLatency  = 2 * time.Second
+	pacingMaxBackfillDelay = 5 * time.Second
+	pacingSyncingDelay     = 2 * time.Second
+	pacingLatencyWeight    = 0.2
+)
+
+// clientPacer keeps a moving average of the response latency of a beacon node and derives
+// polling intervals and backfill delays from it, so busy or syncing nodes get less load.
+type clientPacer struct {
+	mutex      sync.Mutex
+	avgLatency time.Duration
+	samples    uint64
+}
+
+func (pacer *clientPacer) trackRequest(t0 time.Time) {
+	latency := time.Since(t0)
+	pacer.mutex.Lock()
+	defer pacer.mutex.Unlock()
+	if pacer.samples == 0 {
+		pacer.avgLatency = latency
+	} else {
+		pacer.avgLatency = time.Duration(float64(pacer.avgLatency)*(1-pacingLatencyWeight) + float64(latency)*pacingLatencyWeight)
+	}
+	pacer.samples++
+}
+
+func (pacer *clientPacer) getLatency() time.Duration {
+	pacer.mutex.Lock()
+	defer pacer.mutex.Unlock()
+	return pacer.avgLatency
+}
+
+// GetAvgLatency returns the moving average response latency of the client
+func (client *IndexerClient) GetAvgLatency() time.Duration {
+	return client.pacer.getLatency()
+}
+
+// getBackfillDelay returns the delay between two requests of a backfill loop.
+// idle nodes are backfilled at full speed, overloaded or syncing nodes are throttled.
+func (client *IndexerClient) getBackfillDelay() time.Duration {
+	if utils.Config.Indexer.DisableAdaptivePolling {
+		return 0
+	}
+	if client.isSynchronizing {
+		return pacingSyncingDelay
+	}
+	latency := client.pacer.getLatency()
+	switch {
+	case latency <= pacingIdleLatency:
+		return 0
+	case latency >= pacingOverloadLatency:
+		// spend at most half of the time waiting for the node
+		if latency > pacingMaxBackfillDelay {
+			return pacingMaxBackfillDelay
+		}
+		return latency
+	default:
+		return latency / 4
+	}
+}
+
+// getPollInterval returns the time without head events after which the chain head is polled
+func (client *IndexerClient) getPollInterval() time.Duration {
+	if utils.Config.Indexer.DisableAdaptivePolling {
+		return 30 * time.Second
+	}
+	latency := client.pacer.getLatency()
+	switch {
+	case latency >= pacingOverloadLatency:
+		return 60 * time.Second
+	case latency <= pacingIdleLatency:
+		// poll every slot, so missed events are caught up quickly
+		return time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
+	default:
+		return 30 * time.Second
+	}
+}
+
+// getRetryInterval returns the wait time before reconnecting to a node that is not ready
+func (client *IndexerClient) getRetryInterval() time.Duration {
+	if !client.isSynchronizing || utils.Config.Indexer.DisableAdaptivePolling {
+		return 30 * time.Second
+	}
+	// check nodes that are far behind less often, they won't be ready soon
+	distanceEpochs := client.syncDistance / utils.Config.Chain.Config.SlotsPerEpoch
+	switch {
+	case distanceEpochs > 1000:
+		return 5 * time.Minute
+	case distanceEpochs > 100:
+		return 2 * time.Minute
+	default:
+		return 30 * time.Second
+	}
+}
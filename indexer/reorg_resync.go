@@ -0,0 +1,214 @@
+package indexer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/utils"
+)
+
+// deepReorgEpochs is the reorg depth (in epochs below the previous head) that triggers a resync of the unfinalized chain.
+// shallow reorgs are handled incrementally, but the epoch stats, vote aggregations & persisted unfinalized epochs of a
+// deep reorg are built on the previous fork, so they are dropped & reloaded from the clients instead.
+const deepReorgEpochs = 2
+
+// errClientResync stops the event loop of a client, so it prefills the cache again from the finalized checkpoint
+var errClientResync = errors.New("unfinalized chain resync requested")
+
+type reorgResyncState struct {
+	mutex        sync.Mutex
+	lastHeadRoot []byte
+	lastHeadSlot uint64
+	running      bool
+	resyncCount  uint64
+	lastResync   time.Time
+}
+
+// checkDeepReorg compares the canonical head with the previous one and starts a resync of the unfinalized chain
+// if the common ancestor of both heads is more than deepReorgEpochs below the previous head.
+func (indexer *Indexer) checkDeepReorg() {
+	headSlot, headRoot := indexer.GetCanonicalHead()
+	if headRoot == nil {
+		return
+	}
+
+	state := &indexer.reorgResync
+	state.mutex.Lock()
+	if state.running {
+		state.mutex.Unlock()
+		return
+	}
+	lastHeadRoot := state.lastHeadRoot
+	lastHeadSlot := state.lastHeadSlot
+	state.lastHeadRoot = headRoot
+	state.lastHeadSlot = headSlot
+	state.mutex.Unlock()
+
+	cache := indexer.indexerCache
+	if lastHeadRoot == nil || bytes.Equal(lastHeadRoot, headRoot) || cache.isCanonicalBlock(lastHeadRoot, headRoot) {
+		return
+	}
+
+	// walk the previous canonical branch down to the common ancestor, or to the lowest cached block of the branch
+	ancestorSlot := lastHeadSlot
+	for block := cache.getCachedBlock(lastHeadRoot); block != nil; {
+		ancestorSlot = block.Slot
+		if cache.isCanonicalBlock(block.Root, headRoot) {
+			break
+		}
+		parentRoot := block.GetParentRoot()
+		if parentRoot == nil {
+			break
+		}
+		block = cache.getCachedBlock(parentRoot)
+	}
+	reorgDepth := utils.EpochOfSlot(lastHeadSlot) - utils.EpochOfSlot(ancestorSlot)
+	if reorgDepth <= deepReorgEpochs {
+		return
+	}
+
+	logger.Warnf("deep chain reorg detected: head moved from slot %v [0x%x] to slot %v [0x%x], common ancestor at slot %v (%v epochs), resyncing unfinalized chain", lastHeadSlot, lastHeadRoot, headSlot, headRoot, ancestorSlot, reorgDepth)
+	go indexer.resyncUnfinalizedChain()
+}
+
+// resyncUnfinalizedChain drops all unfinalized blocks & epoch stats from the cache and the db and lets all clients
+// reload the chain from the finalized checkpoint. the canonical & orphaned state of the reloaded blocks is derived
+// from the new head, so no block of the previous fork is shown as canonical afterwards.
+func (indexer *Indexer) resyncUnfinalizedChain() {
+	defer utils.HandleSubroutinePanic("resyncUnfinalizedChain")
+
+	state := &indexer.reorgResync
+	state.mutex.Lock()
+	if state.running {
+		state.mutex.Unlock()
+		return
+	}
+	state.running = true
+	state.mutex.Unlock()
+
+	droppedBlocks, err := indexer.indexerCache.dropUnfinalizedChain()
+	if err != nil {
+		logger.Errorf("error while dropping unfinalized chain: %v", err)
+	}
+
+	// the clients reset their head, the next canonical head is the base for further reorg checks
+	for _, client := range indexer.GetClients() {
+		client.requestResync()
+	}
+
+	state.mutex.Lock()
+	state.running = false
+	state.resyncCount++
+	state.lastResync = time.Now()
+	state.lastHeadRoot = nil
+	state.mutex.Unlock()
+
+	if len(droppedBlocks) > 0 {
+		// the dropped blocks are reported as reorged, so the cached pages of the previous fork get evicted
+		event := &ChainChangeEvent{
+			Type:   ChainChangeReorg,
+			Epoch:  utils.EpochOfSlot(droppedBlocks[0].Slot),
+			Blocks: make([]*ChainChangeBlock, 0, len(droppedBlocks)),
+		}
+		for _, block := range droppedBlocks {
+			if epoch := utils.EpochOfSlot(block.Slot); epoch < event.Epoch {
+				event.Epoch = epoch
+			}
+			event.Blocks = append(event.Blocks, block.buildChainChangeBlock())
+		}
+		indexer.chainChanges.publish(event)
+	}
+}
+
+// dropUnfinalizedChain removes the blocks & epoch stats above the finalized checkpoint from the cache & the db.
+// the epoch processing is paused meanwhile, finalized epochs are not affected by the reorg.
+func (cache *indexerCache) dropUnfinalizedChain() ([]*CacheBlock, error) {
+	cache.processingMutex.Lock()
+	defer cache.processingMutex.Unlock()
+
+	cache.cacheMutex.RLock()
+	finalizedEpoch := cache.finalizedEpoch
+	firstSlot := uint64(finalizedEpoch+1) * utils.Config.Chain.Config.SlotsPerEpoch
+	droppedBlocks := []*CacheBlock{}
+	for slot, blocks := range cache.slotMap {
+		if slot >= firstSlot {
+			droppedBlocks = append(droppedBlocks, blocks...)
+		}
+	}
+	cache.cacheMutex.RUnlock()
+
+	droppedStats := []*EpochStats{}
+	cache.epochStatsMutex.RLock()
+	for epoch, epochStats := range cache.epochStatsMap {
+		if int64(epoch) > finalizedEpoch {
+			droppedStats = append(droppedStats, epochStats...)
+		}
+	}
+	cache.epochStatsMutex.RUnlock()
+
+	logger.Infof("dropping unfinalized chain from slot %v: %v blocks, %v epoch stats", firstSlot, len(droppedBlocks), len(droppedStats))
+	for _, block := range droppedBlocks {
+		cache.removeCachedBlock(block)
+	}
+	for _, epochStats := range droppedStats {
+		cache.removeEpochStats(epochStats)
+	}
+
+	cache.cacheMutex.Lock()
+	cache.highestSlot = -1
+	cache.lowestSlot = -1
+	for slot := range cache.slotMap {
+		if int64(slot) > cache.highestSlot {
+			cache.highestSlot = int64(slot)
+		}
+		if cache.lowestSlot < 0 || int64(slot) < cache.lowestSlot {
+			cache.lowestSlot = int64(slot)
+		}
+	}
+	if cache.persistEpoch > finalizedEpoch {
+		cache.persistEpoch = finalizedEpoch
+	}
+	cache.cacheMutex.Unlock()
+
+	if !cache.indexer.writeDb {
+		return droppedBlocks, nil
+	}
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return droppedBlocks, fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := db.DeleteUnfinalizedFrom(firstSlot, tx); err != nil {
+		return droppedBlocks, fmt.Errorf("error deleting unfinalized blocks: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return droppedBlocks, fmt.Errorf("error committing db transaction: %v", err)
+	}
+	return droppedBlocks, nil
+}
+
+// requestResync stops the event loop of the client, it reconnects & prefills the cache right away
+func (client *IndexerClient) requestResync() {
+	client.cacheMutex.Lock()
+	client.lastHeadSlot = -1
+	client.lastHeadRoot = nil
+	client.lastEpochStats = -1
+	client.cacheMutex.Unlock()
+
+	select {
+	case client.resyncChan <- true:
+	default:
+	}
+}
+
+// GetReorgResyncStats returns the number of deep reorg resyncs and the time of the last one
+func (indexer *Indexer) GetReorgResyncStats() (uint64, time.Time) {
+	indexer.reorgResync.mutex.Lock()
+	defer indexer.reorgResync.mutex.Unlock()
+	return indexer.reorgResync.resyncCount, indexer.reorgResync.lastResync
+}
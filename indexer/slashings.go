@@ -0,0 +1,118 @@
+package indexer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type SlashingType string
+
+const (
+	SlashingTypeInvalid        SlashingType = "invalid"
+	SlashingTypeDoubleVote     SlashingType = "double_vote"
+	SlashingTypeSurroundVote   SlashingType = "surround_vote"
+	SlashingTypeDoubleProposal SlashingType = "double_proposal"
+)
+
+// SlashingEvidence is the result of verifying the evidence of a slashing against the slashing conditions.
+// Signatures are not verified, the evidence is checked against the rules of the beacon chain spec only.
+type SlashingEvidence struct {
+	Type   SlashingType
+	Valid  bool
+	Reason string
+}
+
+// VerifyAttesterSlashing checks if the two attestations of an attester slashing violate one of the
+// casper ffg slashing conditions (is_slashable_attestation_data) and share at least one attester
+func VerifyAttesterSlashing(slashing *phase0.AttesterSlashing) *SlashingEvidence {
+	att1 := slashing.Attestation1
+	att2 := slashing.Attestation2
+	if att1 == nil || att2 == nil || att1.Data == nil || att2.Data == nil {
+		return &SlashingEvidence{Type: SlashingTypeInvalid, Reason: "incomplete attestation data"}
+	}
+
+	evidence := &SlashingEvidence{}
+	data1 := att1.Data
+	data2 := att2.Data
+	if isAttestationDataEqual(data1, data2) {
+		evidence.Type = SlashingTypeInvalid
+		evidence.Reason = "both attestations vote for the same data"
+		return evidence
+	} else if data1.Target.Epoch == data2.Target.Epoch {
+		evidence.Type = SlashingTypeDoubleVote
+		evidence.Reason = fmt.Sprintf("two different votes for target epoch %v", data1.Target.Epoch)
+	} else if data1.Source.Epoch < data2.Source.Epoch && data2.Target.Epoch < data1.Target.Epoch {
+		evidence.Type = SlashingTypeSurroundVote
+		evidence.Reason = fmt.Sprintf("vote %v->%v surrounds vote %v->%v", data1.Source.Epoch, data1.Target.Epoch, data2.Source.Epoch, data2.Target.Epoch)
+	} else {
+		evidence.Type = SlashingTypeInvalid
+		evidence.Reason = fmt.Sprintf("votes %v->%v and %v->%v are neither a double nor a surround vote", data1.Source.Epoch, data1.Target.Epoch, data2.Source.Epoch, data2.Target.Epoch)
+		return evidence
+	}
+
+	if !isSortedIndexList(att1.AttestingIndices) || !isSortedIndexList(att2.AttestingIndices) {
+		evidence.Type = SlashingTypeInvalid
+		evidence.Reason = "attesting indices are not sorted or contain duplicates"
+		return evidence
+	}
+	attesters := make(map[uint64]bool, len(att1.AttestingIndices))
+	for _, index := range att1.AttestingIndices {
+		attesters[index] = true
+	}
+	for _, index := range att2.AttestingIndices {
+		if attesters[index] {
+			evidence.Valid = true
+			return evidence
+		}
+	}
+	evidence.Type = SlashingTypeInvalid
+	evidence.Reason = "no validator attested both votes"
+	return evidence
+}
+
+// VerifyProposerSlashing checks if the two headers of a proposer slashing are different proposals
+// of the same proposer for the same slot
+func VerifyProposerSlashing(slashing *phase0.ProposerSlashing) *SlashingEvidence {
+	if slashing.SignedHeader1 == nil || slashing.SignedHeader2 == nil || slashing.SignedHeader1.Message == nil || slashing.SignedHeader2.Message == nil {
+		return &SlashingEvidence{Type: SlashingTypeInvalid, Reason: "incomplete block headers"}
+	}
+	header1 := slashing.SignedHeader1.Message
+	header2 := slashing.SignedHeader2.Message
+
+	evidence := &SlashingEvidence{
+		Type: SlashingTypeInvalid,
+	}
+	if header1.Slot != header2.Slot {
+		evidence.Reason = fmt.Sprintf("headers are for different slots (%v, %v)", header1.Slot, header2.Slot)
+	} else if header1.ProposerIndex != header2.ProposerIndex {
+		evidence.Reason = fmt.Sprintf("headers are from different proposers (%v, %v)", header1.ProposerIndex, header2.ProposerIndex)
+	} else if header1.ParentRoot == header2.ParentRoot && header1.StateRoot == header2.StateRoot && header1.BodyRoot == header2.BodyRoot {
+		evidence.Reason = "both headers are identical"
+	} else {
+		evidence.Type = SlashingTypeDoubleProposal
+		evidence.Valid = true
+		evidence.Reason = fmt.Sprintf("two different blocks proposed for slot %v", header1.Slot)
+	}
+	return evidence
+}
+
+func isAttestationDataEqual(data1 *phase0.AttestationData, data2 *phase0.AttestationData) bool {
+	return data1.Slot == data2.Slot &&
+		data1.Index == data2.Index &&
+		bytes.Equal(data1.BeaconBlockRoot[:], data2.BeaconBlockRoot[:]) &&
+		data1.Source.Epoch == data2.Source.Epoch &&
+		bytes.Equal(data1.Source.Root[:], data2.Source.Root[:]) &&
+		data1.Target.Epoch == data2.Target.Epoch &&
+		bytes.Equal(data1.Target.Root[:], data2.Target.Root[:])
+}
+
+func isSortedIndexList(indices []uint64) bool {
+	for i := 1; i < len(indices); i++ {
+		if indices[i] <= indices[i-1] {
+			return false
+		}
+	}
+	return true
+}
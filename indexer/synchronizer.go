@@ -1,6 +1,7 @@
 package indexer
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/pk910/dora/db"
 	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/rpc"
 	"github.com/pk910/dora/utils"
 	"github.com/sirupsen/logrus"
 )
@@ -66,6 +68,17 @@ func (sync *synchronizerState) startSync(startEpoch uint64) {
 	go sync.runSync()
 }
 
+func (sync *synchronizerState) stopSync() {
+	sync.stateMutex.Lock()
+	if sync.running {
+		sync.killChan <- true
+	}
+	sync.stateMutex.Unlock()
+	// wait for synchronizer to stop
+	sync.runMutex.Lock()
+	sync.runMutex.Unlock()
+}
+
 func (sync *synchronizerState) runSync() {
 	defer utils.HandleSubroutinePanic("runSync")
 
@@ -84,7 +97,13 @@ func (sync *synchronizerState) runSync() {
 		syncEpoch := sync.currentEpoch
 
 		lastRetry := retryCount >= 20
-		done, usedClient, err := sync.syncEpoch(syncEpoch, retryCount, lastRetry, skipClients)
+		var done bool
+		var usedClient *IndexerClient
+		// a panic while processing a malformed block fails the attempt, so the epoch is skipped after the last retry
+		err := utils.CatchPanic("syncEpoch", func() (err error) {
+			done, usedClient, err = sync.syncEpoch(syncEpoch, retryCount, lastRetry, skipClients)
+			return err
+		})
 		if done || lastRetry {
 			if err != nil {
 				synclogger.Warnf("synchronization of epoch %v failed: %v - skipping epoch", syncEpoch, err)
@@ -163,7 +182,12 @@ func (sync *synchronizerState) syncEpoch(syncEpoch uint64, retryCount int, lastT
 	var firstBlock *CacheBlock
 	for slot := firstSlot; slot <= lastSlot; slot++ {
 		if sync.cachedSlot < slot || sync.cachedBlocks[slot] == nil {
+			if delay := client.getBackfillDelay(); delay > 0 {
+				time.Sleep(delay)
+			}
+			t0 := time.Now()
 			headerRsp, err := client.rpcClient.GetBlockHeaderBySlot(slot)
+			client.pacer.trackRequest(t0)
 			if err != nil {
 				return false, client, fmt.Errorf("error fetching slot %v header: %v", slot, err)
 			}
@@ -173,7 +197,9 @@ func (sync *synchronizerState) syncEpoch(syncEpoch uint64, retryCount int, lastT
 			if sync.checkKillChan(0) {
 				return false, nil, nil
 			}
-			blockRsp, err := client.rpcClient.GetBlockBodyByBlockroot(headerRsp.Root[:])
+			t0 = time.Now()
+			blockRsp, customFields, err := client.rpcClient.GetBlockBodyWithCustomFields(headerRsp.Root[:])
+			client.pacer.trackRequest(t0)
 			if err != nil {
 				return false, client, fmt.Errorf("error fetching slot %v block: %v", slot, err)
 			}
@@ -183,6 +209,7 @@ func (sync *synchronizerState) syncEpoch(syncEpoch uint64, retryCount int, lastT
 				header: headerRsp.Header,
 				block:  blockRsp,
 			}
+			sync.cachedBlocks[slot].setCustomFields(customFields)
 		}
 		if firstBlock == nil && sync.cachedBlocks[slot] != nil {
 			firstBlock = sync.cachedBlocks[slot]
@@ -203,7 +230,10 @@ func (sync *synchronizerState) syncEpoch(syncEpoch uint64, retryCount int, lastT
 		dependentRoot = db.GetHighestRootBeforeSlot(firstSlot, false)
 	}
 
-	epochAssignments, err := client.rpcClient.GetEpochAssignments(syncEpoch, dependentRoot)
+	// bound all duty requests of the epoch, so a hung client can't stall the synchronization
+	dutiesCtx, cancelDuties := context.WithTimeout(context.Background(), 2*rpc.GetCallTimeout(rpc.CallTypeDuties))
+	epochAssignments, err := client.rpcClient.GetEpochAssignments(dutiesCtx, syncEpoch, dependentRoot)
+	cancelDuties()
 	if err != nil || epochAssignments == nil {
 		return false, client, fmt.Errorf("error fetching epoch %v duties: %v", syncEpoch, err)
 	}
@@ -226,9 +256,8 @@ func (sync *synchronizerState) syncEpoch(syncEpoch uint64, retryCount int, lastT
 		attestorAssignments: epochAssignments.AttestorAssignments,
 		syncAssignments:     epochAssignments.SyncAssignments,
 	}
-	epochStats.loadValidatorStats(client, epochAssignments.DependendStateRef)
-
-	if epochStats.validatorStats == nil && !lastTry {
+	err = epochStats.loadValidatorStats(client, epochAssignments.DependendStateRef)
+	if err != nil && !lastTry {
 		return false, client, fmt.Errorf("error fetching validator stats for epoch %v: %v", syncEpoch, err)
 	}
 	if sync.checkKillChan(0) {
@@ -244,7 +273,7 @@ func (sync *synchronizerState) syncEpoch(syncEpoch uint64, retryCount int, lastT
 			targetRoot = firstBlock.GetParentRoot()
 		}
 	}
-	epochVotes := aggregateEpochVotes(sync.cachedBlocks, syncEpoch, epochStats, targetRoot, false, true)
+	epochVotes := sync.indexer.epochPipeline.aggregateEpochVotes(sync.cachedBlocks, syncEpoch, epochStats, targetRoot, false, true)
 
 	// load blobs
 	lastSlot = firstSlot + utils.Config.Chain.Config.SlotsPerEpoch - 1
@@ -261,6 +290,10 @@ func (sync *synchronizerState) syncEpoch(syncEpoch uint64, retryCount int, lastT
 		}
 		blobRsp, err := client.rpcClient.GetBlobSidecarsByBlockroot(block.Root)
 		if err != nil {
+			if errClass := rpc.ClassifyError(err); errClass == rpc.ErrorClassNotFound || errClass == rpc.ErrorClassUnsupported {
+				synclogger.Warnf("skipping blobs for block 0x%x: %v", block.Root, err)
+				continue
+			}
 			return false, client, fmt.Errorf("cannot load blobs for block 0x%x: %v", block.Root, err)
 		}
 		blobs = append(blobs, blobRsp...)
@@ -273,7 +306,7 @@ func (sync *synchronizerState) syncEpoch(syncEpoch uint64, retryCount int, lastT
 	}
 	defer tx.Rollback()
 
-	err = persistEpochData(syncEpoch, sync.cachedBlocks, epochStats, epochVotes, tx)
+	err = sync.indexer.epochPipeline.persistEpoch(syncEpoch, sync.cachedBlocks, epochStats, epochVotes, tx)
 	if err != nil {
 		return false, client, fmt.Errorf("error persisting epoch data to db: %v", err)
 	}
@@ -1,8 +1,10 @@
 package indexer
 
 type HeadFork struct {
-	Slot         uint64
-	Root         []byte
-	ReadyClients []*IndexerClient
-	AllClients   []*IndexerClient
+	Slot             uint64
+	Root             []byte
+	ReadyClients     []*IndexerClient
+	AllClients       []*IndexerClient
+	Priority         *int // highest priority of the ready clients
+	FinalityConflict bool // head doesn't descend from the finalized checkpoint
 }
@@ -0,0 +1,70 @@
+package indexer
+
+import (
+	"github.com/pk910/dora/utils"
+)
+
+// ValidatorBalances holds the effective balances of a validator set in a slice indexed by validator index.
+// Balances are stored as multiples of EFFECTIVE_BALANCE_INCREMENT to keep large validator sets compact.
+// Instances are never modified once built, so identical balance sets are shared between epoch stats.
+type ValidatorBalances struct {
+	increment  uint64
+	increments []uint32
+}
+
+func newValidatorBalances(validatorCount uint64) *ValidatorBalances {
+	increment := utils.Config.Chain.Config.EffectiveBalanceIncrement
+	if increment == 0 {
+		increment = 1000000000
+	}
+	return &ValidatorBalances{
+		increment:  increment,
+		increments: make([]uint32, validatorCount),
+	}
+}
+
+func (balances *ValidatorBalances) set(index uint64, effectiveBalance uint64) {
+	if index >= uint64(len(balances.increments)) {
+		increments := make([]uint32, index+1)
+		copy(increments, balances.increments)
+		balances.increments = increments
+	}
+	balances.increments[index] = uint32(effectiveBalance / balances.increment)
+}
+
+// Get returns the effective balance of a validator in gwei
+func (balances *ValidatorBalances) Get(index uint64) uint64 {
+	if index >= uint64(len(balances.increments)) {
+		return 0
+	}
+	return uint64(balances.increments[index]) * balances.increment
+}
+
+// Len returns the number of validators in the set
+func (balances *ValidatorBalances) Len() int {
+	return len(balances.increments)
+}
+
+func (balances *ValidatorBalances) equals(other *ValidatorBalances) bool {
+	if other == nil || balances.increment != other.increment || len(balances.increments) != len(other.increments) {
+		return false
+	}
+	for idx, value := range balances.increments {
+		if other.increments[idx] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// shareValidatorBalances returns the previously loaded balance set if it's identical to the given one,
+// so epochs with an unchanged validator set reference the same slice instead of keeping a copy each.
+func (cache *indexerCache) shareValidatorBalances(balances *ValidatorBalances) *ValidatorBalances {
+	cache.validatorBalancesMutex.Lock()
+	defer cache.validatorBalancesMutex.Unlock()
+	if balances.equals(cache.lastValidatorBalances) {
+		return cache.lastValidatorBalances
+	}
+	cache.lastValidatorBalances = balances
+	return balances
+}
@@ -0,0 +1,151 @@
+package indexer
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+const farFutureEpoch = uint64(math.MaxUint64)
+
+// validatorStatusTracker remembers the latest persisted lifecycle status of each validator,
+// so only new status changes need to be written per finalized epoch.
+type validatorStatusTracker struct {
+	mutex    sync.Mutex
+	statuses map[uint64]uint8
+}
+
+// persistValidatorStatusChanges records the lifecycle status changes up to the finalized epoch.
+// Most transitions are taken from the epochs in the validator record, only the exit initiation and
+// the final withdrawal are not part of the state and get the epoch they were first seen in.
+func (cache *indexerCache) persistValidatorStatusChanges(epoch uint64, tx *sqlx.Tx) error {
+	cache.cacheMutex.RLock()
+	validatorSet := cache.lastValidatorsResp
+	validatorSetEpoch := cache.lastValidatorsEpoch
+	cache.cacheMutex.RUnlock()
+	if validatorSet == nil || validatorSetEpoch < 0 {
+		return nil
+	}
+	seenEpoch := epoch
+	if uint64(validatorSetEpoch) < seenEpoch {
+		seenEpoch = uint64(validatorSetEpoch)
+	}
+
+	tracker := &cache.statusTracker
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	if tracker.statuses == nil {
+		tracker.statuses = db.GetValidatorLatestStatuses()
+		if tracker.statuses == nil {
+			tracker.statuses = map[uint64]uint8{}
+		}
+	}
+
+	changes := []*dbtypes.ValidatorStatusChange{}
+	latestStatuses := map[uint64]uint8{}
+	for index, validator := range validatorSet {
+		validatorIndex := uint64(index)
+		lastStatus, isTracked := tracker.statuses[validatorIndex]
+		for _, change := range getValidatorStatusChanges(validator, epoch, seenEpoch, isTracked, lastStatus) {
+			if isTracked && change.Status <= lastStatus {
+				continue
+			}
+			change.ValidatorIndex = validatorIndex
+			changes = append(changes, change)
+			latestStatuses[validatorIndex] = change.Status
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	sort.Slice(changes, func(a, b int) bool {
+		if changes[a].ValidatorIndex != changes[b].ValidatorIndex {
+			return changes[a].ValidatorIndex < changes[b].ValidatorIndex
+		}
+		return changes[a].Status < changes[b].Status
+	})
+
+	batchSize := 10000
+	for start := 0; start < len(changes); start += batchSize {
+		end := start + batchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		if err := db.InsertValidatorStatusChanges(changes[start:end], tx); err != nil {
+			return err
+		}
+	}
+	for validatorIndex, status := range latestStatuses {
+		tracker.statuses[validatorIndex] = status
+	}
+	logger.Debugf("epoch %v: persisted %v validator status changes", epoch, len(changes))
+	return nil
+}
+
+// getValidatorStatusChanges returns all lifecycle states the validator reached up to the given epoch
+func getValidatorStatusChanges(validator *v1.Validator, epoch uint64, seenEpoch uint64, isTracked bool, lastStatus uint8) []*dbtypes.ValidatorStatusChange {
+	changes := []*dbtypes.ValidatorStatusChange{}
+	addChange := func(status uint8, statusEpoch uint64) {
+		if statusEpoch <= epoch {
+			changes = append(changes, &dbtypes.ValidatorStatusChange{
+				Status: status,
+				Epoch:  statusEpoch,
+			})
+		}
+	}
+	if validator.Validator == nil {
+		return changes
+	}
+
+	eligibilityEpoch := uint64(validator.Validator.ActivationEligibilityEpoch)
+	activationEpoch := uint64(validator.Validator.ActivationEpoch)
+	exitEpoch := uint64(validator.Validator.ExitEpoch)
+	withdrawableEpoch := uint64(validator.Validator.WithdrawableEpoch)
+
+	// the eligibility epoch is set with the epoch transition after the deposit got processed
+	depositEpoch := seenEpoch
+	if eligibilityEpoch != farFutureEpoch {
+		depositEpoch = eligibilityEpoch
+		if depositEpoch > 0 {
+			depositEpoch--
+		}
+		addChange(dbtypes.ValidatorStatusDeposited, depositEpoch)
+		addChange(dbtypes.ValidatorStatusPending, eligibilityEpoch)
+	} else {
+		addChange(dbtypes.ValidatorStatusDeposited, depositEpoch)
+	}
+	if activationEpoch != farFutureEpoch {
+		addChange(dbtypes.ValidatorStatusActive, activationEpoch)
+	}
+	if exitEpoch != farFutureEpoch {
+		// exits are initiated at least MAX_SEED_LOOKAHEAD+1 epochs before the exit epoch,
+		// which is the best guess for exits that happened before the validator was tracked
+		exitingEpoch := seenEpoch
+		if !isTracked || lastStatus < dbtypes.ValidatorStatusActive || exitingEpoch > exitEpoch {
+			exitingEpoch = activationEpoch
+			if minDelay := utils.Config.Chain.Config.MaxSeedLookahead + 1; exitEpoch >= activationEpoch+minDelay {
+				exitingEpoch = exitEpoch - minDelay
+			}
+		}
+		addChange(dbtypes.ValidatorStatusExiting, exitingEpoch)
+		addChange(dbtypes.ValidatorStatusExited, exitEpoch)
+	}
+	if withdrawableEpoch != farFutureEpoch {
+		addChange(dbtypes.ValidatorStatusWithdrawable, withdrawableEpoch)
+		if validator.Balance == 0 && withdrawableEpoch <= epoch {
+			withdrawnEpoch := seenEpoch
+			if !isTracked || lastStatus < dbtypes.ValidatorStatusWithdrawable || withdrawnEpoch < withdrawableEpoch {
+				withdrawnEpoch = withdrawableEpoch
+			}
+			addChange(dbtypes.ValidatorStatusWithdrawn, withdrawnEpoch)
+		}
+	}
+	return changes
+}
@@ -3,7 +3,7 @@ package indexer
 import (
 	"bytes"
 	"fmt"
-	"time"
+	"sort"
 
 	"github.com/pk910/dora/utils"
 )
@@ -19,12 +19,52 @@ type EpochVotes struct {
 		headVoteAmount   uint64
 		totalVoteAmount  uint64
 	}
-	VoteCounts  bool
-	ActivityMap map[uint64]bool
+	VoteCounts       bool
+	ActivityMap      map[uint64]bool
+	VoteFlags        map[uint64]uint8
+	AggregationStats *AggregationStats
+	targetVotes      map[string]uint64
 }
 
-func aggregateEpochVotes(blockMap map[uint64]*CacheBlock, epoch uint64, epochStats *EpochStats, targetRoot []byte, currentOnly bool, awaitDutiesLoaded bool) *EpochVotes {
-	t1 := time.Now()
+// vote correctness flags of the first included vote of a validator (see EpochVotes.VoteFlags)
+const (
+	VoteFlagCorrectTarget uint8 = 1 << iota
+	VoteFlagCorrectHead
+	VoteFlagLateInclusion // not included in the first canonical block after the attestation slot
+)
+
+// EpochTargetVote is the vote weight of one of the target roots voted for in an epoch
+type EpochTargetVote struct {
+	Root   []byte
+	Amount uint64
+}
+
+// GetTargetVotes returns the vote weights of all target roots that got votes, highest weight first.
+// more than one entry means the validators disagreed about the epoch boundary block.
+func (votes *EpochVotes) GetTargetVotes() []*EpochTargetVote {
+	targetVotes := make([]*EpochTargetVote, 0, len(votes.targetVotes))
+	for root, amount := range votes.targetVotes {
+		targetVotes = append(targetVotes, &EpochTargetVote{
+			Root:   []byte(root),
+			Amount: amount,
+		})
+	}
+	sort.Slice(targetVotes, func(a, b int) bool {
+		if targetVotes[a].Amount != targetVotes[b].Amount {
+			return targetVotes[a].Amount > targetVotes[b].Amount
+		}
+		return bytes.Compare(targetVotes[a].Root, targetVotes[b].Root) < 0
+	})
+	return targetVotes
+}
+
+// HasTargetSplit returns true if votes for more than one target root have been included
+func (votes *EpochVotes) HasTargetSplit() bool {
+	return len(votes.targetVotes) > 1
+}
+
+func (pipeline *epochPipeline) aggregateEpochVotes(blockMap map[uint64]*CacheBlock, epoch uint64, epochStats *EpochStats, targetRoot []byte, currentOnly bool, awaitDutiesLoaded bool) *EpochVotes {
+	t1 := pipeline.now()
 
 	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
 	lastSlot := firstSlot + utils.Config.Chain.Config.SlotsPerEpoch - 1
@@ -43,8 +83,11 @@ func aggregateEpochVotes(blockMap map[uint64]*CacheBlock, epoch uint64, epochSta
 
 	votes := EpochVotes{
 		ActivityMap: map[uint64]bool{},
+		VoteFlags:   map[uint64]uint8{},
 		VoteCounts:  epochStats.validatorStats == nil,
+		targetVotes: map[string]uint64{},
 	}
+	aggregations := newAggregationTracker()
 
 	for slot := firstSlot; slot <= lastSlot; slot++ {
 		block := blockMap[slot]
@@ -66,6 +109,29 @@ func aggregateEpochVotes(blockMap map[uint64]*CacheBlock, epoch uint64, epochSta
 			if utils.EpochOfSlot(uint64(att.Data.Slot)) != epoch {
 				continue
 			}
+			aggregations.addAttestation(att)
+
+			// the correct head vote is the latest canonical block at the attestation slot,
+			// which is the parent of the next canonical block after that slot
+			var headRoot []byte
+			var headSlot uint64
+			for headSlot = uint64(att.Data.Slot) + 1; headSlot <= slot; headSlot++ {
+				if headBlock := blockMap[headSlot]; headBlock != nil {
+					headRoot = headBlock.GetParentRoot()
+					break
+				}
+			}
+
+			voteFlags := uint8(0)
+			if headSlot < slot {
+				voteFlags |= VoteFlagLateInclusion
+			}
+			if bytes.Equal(att.Data.Target.Root[:], targetRoot) {
+				voteFlags |= VoteFlagCorrectTarget
+				if bytes.Equal(att.Data.BeaconBlockRoot[:], headRoot) {
+					voteFlags |= VoteFlagCorrectHead
+				}
+			}
 
 			attKey := fmt.Sprintf("%v-%v", uint64(att.Data.Slot), uint64(att.Data.Index))
 			voteAmount := uint64(0)
@@ -73,29 +139,32 @@ func aggregateEpochVotes(blockMap map[uint64]*CacheBlock, epoch uint64, epochSta
 			if epochStats.attestorAssignments != nil {
 				voteValidators := epochStats.attestorAssignments[attKey]
 				for bitIdx, validatorIdx := range voteValidators {
-					if utils.BitAtVector(voteBitset, bitIdx) {
+					// skip the bits of malformed aggregates that are shorter than the committee
+					if bitIdx < int(voteBitset.Len()) && utils.BitAtVector(voteBitset, bitIdx) {
 						if votes.ActivityMap[validatorIdx] {
 							continue
 						}
 						if epochStats.validatorStats != nil {
-							voteAmount += uint64(epochStats.validatorStats.ValidatorBalances[validatorIdx])
+							voteAmount += epochStats.validatorStats.ValidatorBalances.Get(validatorIdx)
 						} else {
 							voteAmount += 1
 						}
 						votes.ActivityMap[validatorIdx] = true
+						votes.VoteFlags[validatorIdx] = voteFlags
 					}
 				}
 			}
 
-			if bytes.Equal(att.Data.Target.Root[:], targetRoot) {
+			if voteFlags&VoteFlagCorrectTarget != 0 {
 				if isNextEpoch {
 					votes.nextEpoch.targetVoteAmount += voteAmount
 				} else {
 					votes.currentEpoch.targetVoteAmount += voteAmount
 				}
-			} /*else {
-				logger.Infof("vote target missmatch %v != 0x%x", att.Data.Target.Root, targetRoot)
-			}*/
+			}
+			if voteAmount > 0 {
+				votes.targetVotes[string(att.Data.Target.Root[:])] += voteAmount
+			}
 			if bytes.Equal(att.Data.BeaconBlockRoot[:], block.GetParentRoot()) {
 				if isNextEpoch {
 					votes.nextEpoch.headVoteAmount += voteAmount
@@ -111,6 +180,8 @@ func aggregateEpochVotes(blockMap map[uint64]*CacheBlock, epoch uint64, epochSta
 		}
 	}
 
-	logger.Debugf("aggregated epoch %v votes in %v", epoch, time.Since(t1))
+	votes.AggregationStats = &aggregations.stats
+
+	logger.Debugf("aggregated epoch %v votes in %v", epoch, pipeline.now().Sub(t1))
 	return &votes
 }
@@ -2,31 +2,31 @@ package indexer
 
 import (
 	"fmt"
+	"math"
 
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
 	"github.com/pk910/dora/db"
 	"github.com/pk910/dora/dbtypes"
 	"github.com/pk910/dora/utils"
 )
 
-func persistEpochData(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *EpochStats, epochVotes *EpochVotes, tx *sqlx.Tx) error {
-	commitTx := false
-	if tx == nil {
-		var err error
-		tx, err = db.WriterDb.Beginx()
-		if err != nil {
-			logger.Errorf("error starting db transactions: %v", err)
-			return err
-		}
-		defer tx.Rollback()
-		commitTx = true
-	}
-
-	dbEpoch := buildDbEpoch(epoch, blockMap, epochStats, epochVotes, func(block *CacheBlock) {
+func (pipeline *epochPipeline) persistEpochData(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *EpochStats, epochVotes *EpochVotes, writer epochDataWriter) error {
+	var blockErr error
+	dbEpoch := pipeline.buildDbEpoch(epoch, blockMap, epochStats, epochVotes, func(block *CacheBlock) {
 		// insert block
+		if blockErr != nil {
+			return
+		}
 		dbBlock := buildDbBlock(block, epochStats)
-		db.InsertBlock(dbBlock, tx)
+		if err := writer.InsertBlock(dbBlock); err != nil {
+			blockErr = fmt.Errorf("error inserting block %v [0x%x]: %v", block.Slot, block.Root, err)
+		}
 	})
+	if blockErr != nil {
+		return blockErr
+	}
 
 	// insert slot assignments
 	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
@@ -39,19 +39,114 @@ func persistEpochData(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats
 				Proposer: epochStats.proposerAssignments[slot],
 			}
 		}
-		db.InsertSlotAssignments(slotAssignments, tx)
+		if err := writer.InsertSlotAssignments(slotAssignments); err != nil {
+			return fmt.Errorf("error inserting slot assignments: %v", err)
+		}
 	}
 
-	// insert epoch
-	db.InsertEpoch(dbEpoch, tx)
+	// insert epoch & update the rollup of its day
+	if err := writer.InsertEpoch(dbEpoch); err != nil {
+		return fmt.Errorf("error inserting epoch: %v", err)
+	}
+	if err := persistDailyStats(epoch, writer); err != nil {
+		return fmt.Errorf("error updating daily stats: %v", err)
+	}
 
-	if commitTx {
-		logger.Infof("commit transaction")
-		if err := tx.Commit(); err != nil {
-			logger.Errorf("error committing db transaction: %v", err)
-			return fmt.Errorf("error committing db transaction: %w", err)
+	// insert withdrawal credential stats
+	if epochStats.validatorStats != nil {
+		credentialCounts := epochStats.validatorStats.CredentialCounts
+		err := writer.InsertEpochCredentialStats(&dbtypes.EpochCredentialStats{
+			Epoch:            epoch,
+			BlsCount:         credentialCounts.BlsCount,
+			ExecutionCount:   credentialCounts.ExecutionCount,
+			CompoundingCount: credentialCounts.CompoundingCount,
+			OtherCount:       credentialCounts.OtherCount,
+		})
+		if err != nil {
+			return fmt.Errorf("error inserting credential stats: %v", err)
 		}
 	}
+
+	// insert validator uptime
+	if epochVotes != nil {
+		if err := persistValidatorUptime(epoch, epochStats, epochVotes, writer); err != nil {
+			return fmt.Errorf("error inserting validator uptime: %v", err)
+		}
+		if err := persistValidatorVoteStats(epoch, epochStats, epochVotes, writer); err != nil {
+			return fmt.Errorf("error inserting validator vote stats: %v", err)
+		}
+		if err := persistEpochCommitteeStats(epoch, epochStats, epochVotes, writer); err != nil {
+			return fmt.Errorf("error inserting committee stats: %v", err)
+		}
+		if err := persistSlotCommitteeParticipation(epoch, epochStats, epochVotes, writer); err != nil {
+			return fmt.Errorf("error inserting slot committee participation: %v", err)
+		}
+	}
+
+	// update the per validator summary shown on the validator page
+	if err := persistValidatorSummaries(epoch, blockMap, epochStats, epochVotes, writer); err != nil {
+		return fmt.Errorf("error updating validator summaries: %v", err)
+	}
+
+	// insert competing vote targets
+	if epochVotes != nil && epochVotes.HasTargetSplit() {
+		if err := persistEpochTargetVotes(epoch, epochVotes, writer); err != nil {
+			return fmt.Errorf("error inserting target votes: %v", err)
+		}
+	}
+
+	// insert aggregation redundancy stats
+	if epochVotes != nil && epochVotes.AggregationStats != nil {
+		if err := persistEpochAggregationStats(epoch, epochVotes.AggregationStats, writer); err != nil {
+			return fmt.Errorf("error inserting aggregation stats: %v", err)
+		}
+	}
+
+	// insert withdrawals to watched addresses
+	if err := persistWatchedWithdrawals(epoch, blockMap, writer); err != nil {
+		return fmt.Errorf("error inserting watched withdrawals: %v", err)
+	}
+
+	// insert blob gas of the execution payloads
+	if err := persistBlobGas(epoch, blockMap, writer); err != nil {
+		return fmt.Errorf("error inserting blob gas: %v", err)
+	}
+
+	// insert execution witness sizes on stateless devnets
+	if err := persistBlockWitnesses(blockMap, writer); err != nil {
+		return fmt.Errorf("error inserting block witnesses: %v", err)
+	}
+
+	// insert block arrival times of all clients
+	if err := persistBlockArrivals(blockMap, writer); err != nil {
+		return fmt.Errorf("error inserting block arrivals: %v", err)
+	}
+
+	// insert the PeerDAS data columns each client served
+	if err := persistBlockDataColumns(blockMap, writer); err != nil {
+		return fmt.Errorf("error inserting block data columns: %v", err)
+	}
+
+	// insert block & state roots of all slots
+	if err := persistSlotRoots(epoch, blockMap, writer); err != nil {
+		return fmt.Errorf("error inserting slot roots: %v", err)
+	}
+
+	// insert deposits (used to detect duplicate deposits for the same key)
+	if err := persistDeposits(epoch, blockMap, writer); err != nil {
+		return fmt.Errorf("error inserting deposits: %v", err)
+	}
+
+	// insert EIP-6110 deposit receipts from the execution payloads
+	if err := persistDepositReceipts(blockMap, writer); err != nil {
+		return fmt.Errorf("error inserting deposit receipts: %v", err)
+	}
+
+	// insert EIP-7251 consolidation requests
+	if err := persistConsolidationRequests(epochStats, blockMap, pipeline.validatorIndexes, writer); err != nil {
+		return fmt.Errorf("error inserting consolidation requests: %v", err)
+	}
+
 	return nil
 }
 
@@ -78,6 +173,481 @@ func persistSyncAssignments(epoch uint64, epochStats *EpochStats, tx *sqlx.Tx) e
 	return db.InsertSyncAssignments(syncAssignments, tx)
 }
 
+func persistDailyStats(epoch uint64, writer epochDataWriter) error {
+	day := utils.DayOfSlot(epoch * utils.Config.Chain.Config.SlotsPerEpoch)
+	firstEpoch, lastEpoch := utils.DayToEpochRange(day)
+	return writer.UpdateDailyStats(day, firstEpoch, lastEpoch)
+}
+
+// getAttestorNames returns the names of all validators with an attestation duty in the epoch
+func getAttestorNames(epochStats *EpochStats, writer epochDataWriter) map[uint64]string {
+	var maxIndex uint64
+	for _, validators := range epochStats.attestorAssignments {
+		for _, validatorIdx := range validators {
+			if validatorIdx > maxIndex {
+				maxIndex = validatorIdx
+			}
+		}
+	}
+	validatorNames := writer.GetValidatorNames(0, maxIndex)
+	nameMap := make(map[uint64]string, len(validatorNames))
+	for _, validatorName := range validatorNames {
+		nameMap[validatorName.Index] = validatorName.Name
+	}
+	return nameMap
+}
+
+func persistValidatorUptime(epoch uint64, epochStats *EpochStats, epochVotes *EpochVotes, writer epochDataWriter) error {
+	if epochStats.attestorAssignments == nil {
+		return nil
+	}
+	nameMap := getAttestorNames(epochStats, writer)
+	if len(nameMap) == 0 {
+		return nil
+	}
+
+	// aggregate attestation duties & included votes per validator name
+	day := utils.TimeToDay(uint64(utils.EpochToTime(epoch).Unix()))
+	uptimeMap := map[string]*dbtypes.ValidatorUptime{}
+	for _, validators := range epochStats.attestorAssignments {
+		for _, validatorIdx := range validators {
+			name := nameMap[validatorIdx]
+			if name == "" {
+				continue
+			}
+			uptime := uptimeMap[name]
+			if uptime == nil {
+				uptime = &dbtypes.ValidatorUptime{
+					Epoch: epoch,
+					Name:  name,
+					Day:   day,
+				}
+				uptimeMap[name] = uptime
+			}
+			uptime.Duties++
+			if epochVotes.ActivityMap[validatorIdx] {
+				uptime.Attested++
+			}
+			voteFlags := epochVotes.VoteFlags[validatorIdx]
+			if voteFlags&VoteFlagCorrectTarget != 0 {
+				uptime.CorrectTarget++
+			}
+			if voteFlags&VoteFlagCorrectHead != 0 {
+				uptime.CorrectHead++
+			}
+			if voteFlags&VoteFlagLateInclusion != 0 {
+				uptime.Late++
+			}
+		}
+	}
+
+	uptimes := make([]*dbtypes.ValidatorUptime, 0, len(uptimeMap))
+	for _, uptime := range uptimeMap {
+		uptimes = append(uptimes, uptime)
+	}
+	return writer.InsertValidatorUptime(uptimes)
+}
+
+// persistEpochCommitteeStats counts the missing & late votes per attestation committee, with the validator name
+// that is responsible for most of them, so committees with systematic vote problems can be traced to an operator.
+func persistEpochCommitteeStats(epoch uint64, epochStats *EpochStats, epochVotes *EpochVotes, writer epochDataWriter) error {
+	if epochStats.attestorAssignments == nil {
+		return nil
+	}
+	nameMap := getAttestorNames(epochStats, writer)
+
+	committeeStats := make([]*dbtypes.EpochCommitteeStats, 0, len(epochStats.attestorAssignments))
+	for committeeKey, validators := range epochStats.attestorAssignments {
+		stats := &dbtypes.EpochCommitteeStats{
+			Epoch:  epoch,
+			Duties: uint64(len(validators)),
+		}
+		if _, err := fmt.Sscanf(committeeKey, "%d-%d", &stats.Slot, &stats.CommitteeIndex); err != nil {
+			continue
+		}
+
+		nameCounts := map[string]uint64{}
+		for _, validatorIdx := range validators {
+			if !epochVotes.ActivityMap[validatorIdx] {
+				stats.Missed++
+			} else if epochVotes.VoteFlags[validatorIdx]&VoteFlagLateInclusion != 0 {
+				stats.Late++
+			} else {
+				continue
+			}
+			if name := nameMap[validatorIdx]; name != "" {
+				nameCounts[name]++
+			}
+		}
+		for name, count := range nameCounts {
+			if count > stats.TopNameCount || (count == stats.TopNameCount && name < *stats.TopName) {
+				topName := name
+				stats.TopName = &topName
+				stats.TopNameCount = count
+			}
+		}
+		committeeStats = append(committeeStats, stats)
+	}
+
+	// split into batches to stay below the query argument limit of sqlite (8 arguments per row)
+	for start := 0; start < len(committeeStats); start += 4000 {
+		end := start + 4000
+		if end > len(committeeStats) {
+			end = len(committeeStats)
+		}
+		if err := writer.InsertEpochCommitteeStats(committeeStats[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildSlotCommitteeParticipation returns the share of included votes per committee index for each slot of the epoch.
+// Committees of a slot with poor participation while the others are fine point to propagation problems on their subnet.
+func buildSlotCommitteeParticipation(epoch uint64, epochStats *EpochStats, epochVotes *EpochVotes) []*dbtypes.SlotCommitteeParticipation {
+	attestorAssignments := epochStats.GetAttestorAssignments()
+	if attestorAssignments == nil {
+		return nil
+	}
+
+	slotCommittees := map[uint64][][]uint64{}
+	for committeeKey, validators := range attestorAssignments {
+		var slot, committeeIndex uint64
+		if _, err := fmt.Sscanf(committeeKey, "%d-%d", &slot, &committeeIndex); err != nil {
+			continue
+		}
+		committees := slotCommittees[slot]
+		for uint64(len(committees)) <= committeeIndex {
+			committees = append(committees, nil)
+		}
+		committees[committeeIndex] = validators
+		slotCommittees[slot] = committees
+	}
+
+	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+	participation := make([]*dbtypes.SlotCommitteeParticipation, 0, len(slotCommittees))
+	for slot := firstSlot; slot < firstSlot+utils.Config.Chain.Config.SlotsPerEpoch; slot++ {
+		committees := slotCommittees[slot]
+		if len(committees) == 0 {
+			continue
+		}
+		slotParticipation := &dbtypes.SlotCommitteeParticipation{
+			Slot:          slot,
+			Epoch:         epoch,
+			Participation: make([]byte, len(committees)),
+		}
+		for committeeIndex, validators := range committees {
+			if len(validators) == 0 {
+				continue
+			}
+			voted := 0
+			for _, validatorIdx := range validators {
+				if epochVotes.ActivityMap[validatorIdx] {
+					voted++
+				}
+			}
+			slotParticipation.Participation[committeeIndex] = byte(voted * 100 / len(validators))
+		}
+		participation = append(participation, slotParticipation)
+	}
+	return participation
+}
+
+// persistSlotCommitteeParticipation stores the per committee participation of the slots in the epoch
+func persistSlotCommitteeParticipation(epoch uint64, epochStats *EpochStats, epochVotes *EpochVotes, writer epochDataWriter) error {
+	return writer.InsertSlotCommitteeParticipation(buildSlotCommitteeParticipation(epoch, epochStats, epochVotes))
+}
+
+// persistValidatorVoteStats adds the vote correctness of all validators with an attestation duty in the epoch to their totals
+func persistValidatorVoteStats(epoch uint64, epochStats *EpochStats, epochVotes *EpochVotes, writer epochDataWriter) error {
+	if epochStats.attestorAssignments == nil {
+		return nil
+	}
+
+	voteStats := []*dbtypes.ValidatorVoteStats{}
+	for _, validators := range epochStats.attestorAssignments {
+		for _, validatorIdx := range validators {
+			stats := &dbtypes.ValidatorVoteStats{
+				ValidatorIndex: validatorIdx,
+				FirstEpoch:     epoch,
+				LastEpoch:      epoch,
+				Duties:         1,
+			}
+			if epochVotes.ActivityMap[validatorIdx] {
+				stats.Attested = 1
+			}
+			voteFlags := epochVotes.VoteFlags[validatorIdx]
+			if voteFlags&VoteFlagCorrectTarget != 0 {
+				stats.CorrectTarget = 1
+			}
+			if voteFlags&VoteFlagCorrectHead != 0 {
+				stats.CorrectHead = 1
+			}
+			voteStats = append(voteStats, stats)
+		}
+	}
+
+	// split into batches to stay below the query argument limit of sqlite (7 arguments per row)
+	for start := 0; start < len(voteStats); start += 4000 {
+		end := start + 4000
+		if end > len(voteStats) {
+			end = len(voteStats)
+		}
+		if err := writer.InsertValidatorVoteStats(voteStats[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// persistValidatorSummaries adds the proposed & missed blocks, included attestations and withdrawals of the epoch to the validator summaries
+func persistValidatorSummaries(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *EpochStats, epochVotes *EpochVotes, writer epochDataWriter) error {
+	summaryMap := map[uint64]*dbtypes.ValidatorSummary{}
+	getSummary := func(validatorIdx uint64) *dbtypes.ValidatorSummary {
+		summary := summaryMap[validatorIdx]
+		if summary == nil {
+			summary = &dbtypes.ValidatorSummary{
+				ValidatorIndex: validatorIdx,
+				LastEpoch:      epoch,
+			}
+			summaryMap[validatorIdx] = summary
+		}
+		return summary
+	}
+
+	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := firstSlot + utils.Config.Chain.Config.SlotsPerEpoch - 1
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block := blockMap[slot]
+		if block == nil {
+			if proposer, found := epochStats.proposerAssignments[slot]; found && proposer != math.MaxInt64 && slot > 0 {
+				getSummary(proposer).MissedBlocks++
+			}
+			continue
+		}
+		if header := block.GetHeader(); header != nil {
+			getSummary(uint64(header.Message.ProposerIndex)).ProposedBlocks++
+		}
+		if blockBody := block.GetBlockBody(); blockBody != nil {
+			executionWithdrawals, _ := blockBody.Withdrawals()
+			for _, withdrawal := range executionWithdrawals {
+				summary := getSummary(uint64(withdrawal.ValidatorIndex))
+				summary.WithdrawalCount++
+				summary.WithdrawnAmount += uint64(withdrawal.Amount)
+			}
+		}
+	}
+
+	if epochVotes != nil {
+		attestationEpoch := epoch
+		for validatorIdx, attested := range epochVotes.ActivityMap {
+			if attested {
+				getSummary(validatorIdx).LastAttestationEpoch = &attestationEpoch
+			}
+		}
+	}
+
+	summaries := make([]*dbtypes.ValidatorSummary, 0, len(summaryMap))
+	for _, summary := range summaryMap {
+		summaries = append(summaries, summary)
+	}
+
+	// split into batches to stay below the query argument limit of sqlite (7 arguments per row)
+	for start := 0; start < len(summaries); start += 4000 {
+		end := start + 4000
+		if end > len(summaries) {
+			end = len(summaries)
+		}
+		if err := writer.InsertValidatorSummaries(summaries[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// persistEpochTargetVotes stores the vote weights of all target roots, only called for epochs with split target votes
+func persistEpochTargetVotes(epoch uint64, epochVotes *EpochVotes, writer epochDataWriter) error {
+	targetVotes := epochVotes.GetTargetVotes()
+	dbTargetVotes := make([]*dbtypes.EpochTargetVote, len(targetVotes))
+	for idx, targetVote := range targetVotes {
+		dbTargetVotes[idx] = &dbtypes.EpochTargetVote{
+			Epoch:      epoch,
+			TargetRoot: targetVote.Root,
+			VoteAmount: targetVote.Amount,
+		}
+	}
+	return writer.InsertEpochTargetVotes(dbTargetVotes)
+}
+
+func persistEpochAggregationStats(epoch uint64, stats *AggregationStats, writer epochDataWriter) error {
+	return writer.InsertEpochAggregationStats(&dbtypes.EpochAggregationStats{
+		Epoch:              epoch,
+		AggregateCount:     stats.AggregateCount,
+		CommitteeCount:     stats.CommitteeCount,
+		RedundantCount:     stats.RedundantCount,
+		VoteCount:          stats.VoteCount,
+		DuplicateVoteCount: stats.DuplicateVoteCount,
+	})
+}
+
+func persistWatchedWithdrawals(epoch uint64, blockMap map[uint64]*CacheBlock, writer epochDataWriter) error {
+	watchedAddresses := utils.Config.Indexer.WatchedWithdrawalAddresses
+	if len(watchedAddresses) == 0 {
+		return nil
+	}
+	withdrawalMap := make(map[string]*dbtypes.WatchedWithdrawal, len(watchedAddresses))
+	for _, watchedAddress := range watchedAddresses {
+		address := common.HexToAddress(watchedAddress.Address)
+		withdrawalMap[string(address[:])] = &dbtypes.WatchedWithdrawal{
+			Address: address[:],
+			Epoch:   epoch,
+		}
+	}
+
+	// epochs without withdrawals are stored too, so the tracked epoch range is continuous
+	for _, block := range blockMap {
+		blockBody := block.GetBlockBody()
+		if blockBody == nil {
+			continue
+		}
+		executionWithdrawals, _ := blockBody.Withdrawals()
+		for _, withdrawal := range executionWithdrawals {
+			watchedWithdrawal := withdrawalMap[string(withdrawal.Address[:])]
+			if watchedWithdrawal == nil {
+				continue
+			}
+			watchedWithdrawal.Count++
+			watchedWithdrawal.Amount += uint64(withdrawal.Amount)
+		}
+	}
+
+	withdrawals := make([]*dbtypes.WatchedWithdrawal, 0, len(withdrawalMap))
+	for _, watchedWithdrawal := range withdrawalMap {
+		withdrawals = append(withdrawals, watchedWithdrawal)
+	}
+	return writer.InsertWatchedWithdrawals(withdrawals)
+}
+
+func persistBlobGas(epoch uint64, blockMap map[uint64]*CacheBlock, writer epochDataWriter) error {
+	if epoch < utils.Config.Chain.Config.DenebForkEpoch {
+		return nil
+	}
+	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := firstSlot + utils.Config.Chain.Config.SlotsPerEpoch - 1
+
+	blobGas := make([]*dbtypes.BlobGas, 0, utils.Config.Chain.Config.SlotsPerEpoch)
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block := blockMap[slot]
+		if block == nil {
+			continue
+		}
+		blockBody := block.GetBlockBody()
+		if blockBody == nil || blockBody.Version != spec.DataVersionDeneb || blockBody.Deneb == nil {
+			continue
+		}
+		executionPayload := blockBody.Deneb.Message.Body.ExecutionPayload
+		blobGas = append(blobGas, &dbtypes.BlobGas{
+			Slot:          slot,
+			Root:          block.Root,
+			BlobGasUsed:   executionPayload.BlobGasUsed,
+			ExcessBlobGas: executionPayload.ExcessBlobGas,
+		})
+	}
+	return writer.InsertBlobGas(blobGas)
+}
+
+func persistBlockWitnesses(blockMap map[uint64]*CacheBlock, writer epochDataWriter) error {
+	witnesses := []*dbtypes.BlockWitness{}
+	for slot, block := range blockMap {
+		witness := block.GetWitnessStats()
+		if witness == nil {
+			continue
+		}
+		witnesses = append(witnesses, &dbtypes.BlockWitness{
+			Slot:        slot,
+			Root:        block.Root,
+			StemCount:   witness.StemCount,
+			KeyCount:    witness.KeyCount,
+			WriteCount:  witness.WriteCount,
+			ProofSize:   witness.ProofSize,
+			WitnessSize: witness.WitnessSize,
+		})
+	}
+	return writer.InsertBlockWitnesses(witnesses)
+}
+
+func persistSlotRoots(epoch uint64, blockMap map[uint64]*CacheBlock, writer epochDataWriter) error {
+	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := firstSlot + utils.Config.Chain.Config.SlotsPerEpoch - 1
+
+	// missed slots carry the root of the latest block before them
+	var lastBlockRoot []byte
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		if block := blockMap[slot]; block != nil {
+			if header := block.GetHeader(); header != nil {
+				lastBlockRoot = header.Message.ParentRoot[:]
+			}
+			break
+		}
+	}
+	if lastBlockRoot == nil && firstSlot > 0 {
+		if lastSlotRoot := writer.GetLastSlotRoot(firstSlot); lastSlotRoot != nil {
+			lastBlockRoot = lastSlotRoot.BlockRoot
+		}
+	}
+
+	slotRoots := make([]*dbtypes.SlotRoot, 0, utils.Config.Chain.Config.SlotsPerEpoch)
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block := blockMap[slot]
+		if block != nil && block.GetHeader() != nil {
+			header := block.GetHeader()
+			lastBlockRoot = block.Root
+			slotRoots = append(slotRoots, &dbtypes.SlotRoot{
+				Slot:      slot,
+				BlockRoot: block.Root,
+				StateRoot: header.Message.StateRoot[:],
+			})
+		} else if lastBlockRoot != nil {
+			slotRoots = append(slotRoots, &dbtypes.SlotRoot{
+				Slot:      slot,
+				BlockRoot: lastBlockRoot,
+				Missed:    1,
+			})
+		}
+	}
+	return writer.InsertSlotRoots(slotRoots)
+}
+
+func persistBlockArrivals(blockMap map[uint64]*CacheBlock, writer epochDataWriter) error {
+	arrivals := []*dbtypes.BlockArrival{}
+	for _, block := range blockMap {
+		slotTime := utils.SlotToTime(block.Slot)
+		for _, arrival := range block.GetArrivals() {
+			arrivals = append(arrivals, &dbtypes.BlockArrival{
+				Root:   block.Root,
+				Slot:   block.Slot,
+				Client: arrival.ClientName,
+				Delay:  arrival.Time.Sub(slotTime).Milliseconds(),
+			})
+		}
+	}
+	return writer.InsertBlockArrivals(arrivals)
+}
+
+// persistBlockDataColumns writes the data columns that were loaded from the clients before the epoch got persisted
+func persistBlockDataColumns(blockMap map[uint64]*CacheBlock, writer epochDataWriter) error {
+	dataColumns := []*dbtypes.BlockDataColumns{}
+	for _, block := range blockMap {
+		block.dataColumnsMutex.Lock()
+		blockColumns := block.dataColumns
+		block.dataColumnsMutex.Unlock()
+		if blockColumns != nil {
+			dataColumns = append(dataColumns, buildDbBlockDataColumns(block, blockColumns)...)
+		}
+	}
+	return writer.InsertBlockDataColumns(dataColumns)
+}
+
 func buildDbBlock(block *CacheBlock, epochStats *EpochStats) *dbtypes.Block {
 	blockBody := block.GetBlockBody()
 	if blockBody == nil {
@@ -103,9 +673,11 @@ func buildDbBlock(block *CacheBlock, epochStats *EpochStats) *dbtypes.Block {
 		Slot:                  uint64(block.header.Message.Slot),
 		ParentRoot:            block.header.Message.ParentRoot[:],
 		StateRoot:             block.header.Message.StateRoot[:],
+		BodyRoot:              block.header.Message.BodyRoot[:],
 		Proposer:              uint64(block.header.Message.ProposerIndex),
 		Graffiti:              graffiti[:],
 		GraffitiText:          utils.GraffitiToString(graffiti[:]),
+		ClClient:              utils.GetGraffitiClient(string(graffiti[:])),
 		AttestationCount:      uint64(len(attestations)),
 		DepositCount:          uint64(len(deposits)),
 		ExitCount:             uint64(len(voluntaryExits)),
@@ -114,6 +686,12 @@ func buildDbBlock(block *CacheBlock, epochStats *EpochStats) *dbtypes.Block {
 		BLSChangeCount:        uint64(len(blsToExecChanges)),
 	}
 
+	if epochStats != nil {
+		// the proposer has been assigned with the duties of this dependent root, which allows
+		// auditing the assignment after the block or its dependent block got reorged
+		dbBlock.ProposerDependentRoot = epochStats.DependentRoot
+	}
+
 	if syncAggregate != nil {
 		var assignedCount int
 		if epochStats != nil && epochStats.syncAssignments != nil {
@@ -135,6 +713,9 @@ func buildDbBlock(block *CacheBlock, epochStats *EpochStats) *dbtypes.Block {
 		dbBlock.EthTransactionCount = uint64(len(executionTransactions))
 		dbBlock.EthBlockNumber = &executionBlockNumber
 		dbBlock.EthBlockHash = executionBlockHash[:]
+		dbBlock.EthFeeRecipient = getBlockFeeRecipient(blockBody)
+		dbBlock.EthExtraData = getBlockExtraData(blockBody)
+		dbBlock.ElClient = utils.GetExtraDataClient(dbBlock.EthExtraData)
 		dbBlock.WithdrawCount = uint64(len(executionWithdrawals))
 		for _, withdrawal := range executionWithdrawals {
 			dbBlock.WithdrawAmount += uint64(withdrawal.Amount)
@@ -144,12 +725,49 @@ func buildDbBlock(block *CacheBlock, epochStats *EpochStats) *dbtypes.Block {
 	return &dbBlock
 }
 
-func buildDbEpoch(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *EpochStats, epochVotes *EpochVotes, blockFn func(block *CacheBlock)) *dbtypes.Epoch {
+func getBlockFeeRecipient(blockBody *spec.VersionedSignedBeaconBlock) []byte {
+	switch blockBody.Version {
+	case spec.DataVersionBellatrix:
+		if blockBody.Bellatrix != nil {
+			return blockBody.Bellatrix.Message.Body.ExecutionPayload.FeeRecipient[:]
+		}
+	case spec.DataVersionCapella:
+		if blockBody.Capella != nil {
+			return blockBody.Capella.Message.Body.ExecutionPayload.FeeRecipient[:]
+		}
+	case spec.DataVersionDeneb:
+		if blockBody.Deneb != nil {
+			return blockBody.Deneb.Message.Body.ExecutionPayload.FeeRecipient[:]
+		}
+	}
+	return nil
+}
+
+func getBlockExtraData(blockBody *spec.VersionedSignedBeaconBlock) []byte {
+	switch blockBody.Version {
+	case spec.DataVersionBellatrix:
+		if blockBody.Bellatrix != nil {
+			return blockBody.Bellatrix.Message.Body.ExecutionPayload.ExtraData
+		}
+	case spec.DataVersionCapella:
+		if blockBody.Capella != nil {
+			return blockBody.Capella.Message.Body.ExecutionPayload.ExtraData
+		}
+	case spec.DataVersionDeneb:
+		if blockBody.Deneb != nil {
+			return blockBody.Deneb.Message.Body.ExecutionPayload.ExtraData
+		}
+	}
+	return nil
+}
+
+func (pipeline *epochPipeline) buildDbEpoch(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *EpochStats, epochVotes *EpochVotes, blockFn func(block *CacheBlock)) *dbtypes.Epoch {
 	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
 	lastSlot := firstSlot + (utils.Config.Chain.Config.SlotsPerEpoch) - 1
 
 	totalSyncAssigned := 0
 	totalSyncVoted := 0
+	txTypes := utils.TransactionTypeCounts{}
 	dbEpoch := dbtypes.Epoch{
 		Epoch: epoch,
 	}
@@ -163,6 +781,7 @@ func buildDbEpoch(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *Epo
 		dbEpoch.ValidatorBalance = epochStats.validatorStats.ValidatorBalance
 		dbEpoch.Eligible = epochStats.validatorStats.EligibleAmount
 	}
+	dbEpoch.Partial = epochStats.getPartialFlags()
 
 	// aggregate blocks
 	for slot := firstSlot; slot <= lastSlot; slot++ {
@@ -188,6 +807,7 @@ func buildDbEpoch(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *Epo
 			syncAggregate, _ := blockBody.SyncAggregate()
 			executionTransactions, _ := blockBody.ExecutionTransactions()
 			executionWithdrawals, _ := blockBody.Withdrawals()
+			blobKzgCommitments, _ := blockBody.BlobKzgCommitments()
 
 			dbEpoch.AttestationCount += uint64(len(attestations))
 			dbEpoch.DepositCount += uint64(len(deposits))
@@ -209,6 +829,10 @@ func buildDbEpoch(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *Epo
 			}
 
 			dbEpoch.EthTransactionCount += uint64(len(executionTransactions))
+			for _, tx := range executionTransactions {
+				txTypes.AddTransaction(tx)
+			}
+			dbEpoch.BlobCount += uint64(len(blobKzgCommitments))
 			dbEpoch.WithdrawCount += uint64(len(executionWithdrawals))
 			for _, withdrawal := range executionWithdrawals {
 				dbEpoch.WithdrawAmount += uint64(withdrawal.Amount)
@@ -219,6 +843,111 @@ func buildDbEpoch(epoch uint64, blockMap map[uint64]*CacheBlock, epochStats *Epo
 	if totalSyncAssigned > 0 {
 		dbEpoch.SyncParticipation = float32(totalSyncVoted) / float32(totalSyncAssigned)
 	}
+	dbEpoch.TxLegacyCount = txTypes.Legacy
+	dbEpoch.TxAccessListCount = txTypes.AccessList
+	dbEpoch.TxDynamicFeeCount = txTypes.DynamicFee
+	dbEpoch.TxBlobCount = txTypes.Blob
+	dbEpoch.TxSetCodeCount = txTypes.SetCode
 
 	return &dbEpoch
 }
+
+func persistDeposits(epoch uint64, blockMap map[uint64]*CacheBlock, writer epochDataWriter) error {
+	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := firstSlot + utils.Config.Chain.Config.SlotsPerEpoch - 1
+
+	deposits := []*dbtypes.Deposit{}
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block := blockMap[slot]
+		if block == nil {
+			continue
+		}
+		blockBody := block.GetBlockBody()
+		if blockBody == nil {
+			continue
+		}
+		blockDeposits, err := blockBody.Deposits()
+		if err != nil {
+			continue
+		}
+		for idx, deposit := range blockDeposits {
+			if deposit.Data == nil {
+				continue
+			}
+			deposits = append(deposits, &dbtypes.Deposit{
+				Slot:                  slot,
+				Index:                 uint64(idx),
+				BlockRoot:             block.Root,
+				Pubkey:                deposit.Data.PublicKey[:],
+				WithdrawalCredentials: deposit.Data.WithdrawalCredentials,
+				Amount:                uint64(deposit.Data.Amount),
+			})
+		}
+	}
+	return writer.InsertDeposits(deposits)
+}
+
+func persistDepositReceipts(blockMap map[uint64]*CacheBlock, writer epochDataWriter) error {
+	receipts := []*dbtypes.DepositReceipt{}
+	for slot, block := range blockMap {
+		for idx, receipt := range block.GetDepositReceipts() {
+			receipts = append(receipts, &dbtypes.DepositReceipt{
+				Slot:                  slot,
+				Index:                 uint64(idx),
+				BlockRoot:             block.Root,
+				BlockNumber:           block.Refs.ExecutionNumber,
+				DepositIndex:          receipt.Index,
+				Pubkey:                receipt.Pubkey,
+				WithdrawalCredentials: receipt.WithdrawalCredentials,
+				Amount:                receipt.Amount,
+			})
+		}
+	}
+	return writer.InsertDepositReceipts(receipts)
+}
+
+func persistConsolidationRequests(epochStats *EpochStats, blockMap map[uint64]*CacheBlock, validatorIndexes func(pubkeys [][]byte) map[string]uint64, writer epochDataWriter) error {
+	requests := []*dbtypes.ConsolidationRequest{}
+	pubkeys := [][]byte{}
+	for slot, block := range blockMap {
+		for idx, request := range block.GetConsolidationRequests() {
+			requests = append(requests, &dbtypes.ConsolidationRequest{
+				Slot:          slot,
+				Index:         uint64(idx),
+				BlockRoot:     block.Root,
+				BlockNumber:   block.Refs.ExecutionNumber,
+				SourceAddress: request.SourceAddress,
+				SourcePubkey:  request.SourcePubkey,
+				TargetPubkey:  request.TargetPubkey,
+			})
+			pubkeys = append(pubkeys, request.SourcePubkey, request.TargetPubkey)
+		}
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	// the effective balances of the epoch are the amount moved from the source & the target balance before the consolidation
+	if validatorIndexes != nil {
+		indexes := validatorIndexes(pubkeys)
+		var balances *ValidatorBalances
+		if epochStats.validatorStats != nil {
+			balances = epochStats.validatorStats.ValidatorBalances
+		}
+		for _, request := range requests {
+			if index, found := indexes[string(request.SourcePubkey)]; found {
+				request.SourceIndex = &index
+				if balances != nil {
+					request.SourceBalance = balances.Get(index)
+				}
+			}
+			if index, found := indexes[string(request.TargetPubkey)]; found {
+				request.TargetIndex = &index
+				if balances != nil {
+					request.TargetBalance = balances.Get(index)
+				}
+			}
+		}
+	}
+	return writer.InsertConsolidationRequests(requests)
+}
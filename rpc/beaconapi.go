@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,6 +10,7 @@ import (
 	nethttp "net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -21,6 +23,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh"
 
+	"github.com/pk910/dora/rpc/fixtures"
 	"github.com/pk910/dora/rpc/sshtunnel"
 	"github.com/pk910/dora/types"
 	"github.com/pk910/dora/utils"
@@ -34,14 +37,24 @@ type BeaconClient struct {
 	headers   map[string]string
 	clientSvc eth2client.Service
 	sshtunnel *sshtunnel.SSHTunnel
+	recorder  *fixtures.Recorder
+	replayer  *fixtures.Replayer
+	breaker   *CircuitBreaker
+	schema    *SchemaStats
+
+	capabilities *ClientCapabilities
 }
 
 // NewBeaconClient is used to create a new beacon client
-func NewBeaconClient(endpoint string, name string, headers map[string]string, sshcfg *types.EndpointSshConfig) (*BeaconClient, error) {
+func NewBeaconClient(endpoint string, name string, headers map[string]string, sshcfg *types.EndpointSshConfig, fixturecfg *types.EndpointFixtureConfig) (*BeaconClient, error) {
 	client := &BeaconClient{
 		name:     name,
 		endpoint: endpoint,
 		headers:  headers,
+		breaker:  newCircuitBreaker(),
+		schema:   newSchemaStats(endpoint),
+
+		capabilities: newClientCapabilities(),
 	}
 
 	if sshcfg != nil {
@@ -91,27 +104,109 @@ func NewBeaconClient(endpoint string, name string, headers map[string]string, ss
 		client.endpoint = endpointUrl.String()
 	}
 
+	if fixturecfg != nil {
+		switch fixturecfg.Mode {
+		case "record":
+			// proxy all requests through a local recorder
+			recorder, err := fixtures.NewRecorder(client.endpoint, fixturecfg.Path)
+			if err != nil {
+				return nil, fmt.Errorf("could not create fixture recorder: %w", err)
+			}
+			recorder.Log = logger.WithField("client", name)
+			if err := recorder.Start(); err != nil {
+				return nil, fmt.Errorf("could not start fixture recorder: %w", err)
+			}
+			client.recorder = recorder
+			client.endpoint = fmt.Sprintf("http://%v", recorder.Local)
+		case "replay":
+			// serve all requests from recorded fixtures, no live node needed
+			replayer, err := fixtures.NewReplayer(fixturecfg.Path)
+			if err != nil {
+				return nil, fmt.Errorf("could not load fixtures: %w", err)
+			}
+			replayer.Log = logger.WithField("client", name)
+			if err := replayer.Start(); err != nil {
+				return nil, fmt.Errorf("could not start fixture replayer: %w", err)
+			}
+			if _, err := replayer.GetGenesisTime(); err != nil {
+				replayer.Close()
+				return nil, fmt.Errorf("could not get genesis time from fixtures: %w", err)
+			}
+			client.replayer = replayer
+			client.endpoint = fmt.Sprintf("http://%v", replayer.Local)
+		default:
+			return nil, fmt.Errorf("unknown fixture mode: %v", fixturecfg.Mode)
+		}
+	}
+
 	return client, nil
 }
 
+// GetReplayGenesisTime returns the shifted genesis time of the replayed fixtures, the slot clock
+// needs to be aligned with it to replay the recorded chain. ok is false if the client is not replaying.
+func (bc *BeaconClient) GetReplayGenesisTime() (genesisTime uint64, ok bool) {
+	if bc.replayer == nil {
+		return 0, false
+	}
+	genesisTime, err := bc.replayer.GetGenesisTime()
+	return genesisTime, err == nil
+}
+
+// Close stops the fixture recorder / replayer and the ssh tunnel of the client
+func (bc *BeaconClient) Close() {
+	if bc.recorder != nil {
+		bc.recorder.Close()
+	}
+	if bc.replayer != nil {
+		bc.replayer.Close()
+	}
+	if bc.sshtunnel != nil {
+		bc.sshtunnel.Stop()
+	}
+}
+
 var errNotFound = errors.New("not found 404")
 
-func (bc *BeaconClient) getJson(requrl string, returnValue interface{}) error {
+func (bc *BeaconClient) getJson(ctx context.Context, callType CallType, requrl string, returnValue interface{}) error {
+	return bc.callJson(ctx, callType, "GET", requrl, nil, returnValue)
+}
+
+// postJson sends the json encoded postData to the endpoint, used by the few POST endpoints that only read data (eg. rewards)
+func (bc *BeaconClient) postJson(ctx context.Context, callType CallType, requrl string, postData interface{}, returnValue interface{}) error {
+	postBody, err := json.Marshal(postData)
+	if err != nil {
+		return fmt.Errorf("error encoding request body: %v", err)
+	}
+	return bc.callJson(ctx, callType, "POST", requrl, postBody, returnValue)
+}
+
+func (bc *BeaconClient) callJson(ctx context.Context, callType CallType, method string, requrl string, postBody []byte, returnValue interface{}) (err error) {
 	logurl := utils.GetRedactedUrl(requrl)
 	t0 := time.Now()
 	defer func() {
-		logger.WithField("client", bc.name).Debugf("RPC GET call (json): %v [%v ms]", logurl, time.Since(t0).Milliseconds())
+		logger.WithField("client", bc.name).Debugf("RPC %v call (json): %v [%v ms]", method, logurl, time.Since(t0).Milliseconds())
+		bc.breaker.reportResult(err)
 	}()
 
-	req, err := nethttp.NewRequest("GET", requrl, nil)
+	ctx, cancel := newCallContext(ctx, callType)
+	defer cancel()
+
+	var reqBody io.Reader
+	if postBody != nil {
+		reqBody = bytes.NewReader(postBody)
+	}
+	req, err := nethttp.NewRequestWithContext(ctx, method, requrl, reqBody)
 	if err != nil {
 		return err
 	}
 	for headerKey, headerVal := range bc.headers {
 		req.Header.Set(headerKey, headerVal)
 	}
+	if postBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
-	client := &nethttp.Client{Timeout: time.Second * 300}
+	client := &nethttp.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -120,16 +215,24 @@ func (bc *BeaconClient) getJson(requrl string, returnValue interface{}) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != nethttp.StatusOK {
-		if resp.StatusCode == nethttp.StatusNotFound {
+		data, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == nethttp.StatusNotFound && !isUnsupportedResponse(resp.StatusCode, strings.ToLower(string(data))) {
 			return errNotFound
 		}
-		data, _ := io.ReadAll(resp.Body)
 		logger.WithField("client", bc.name).Debugf("RPC Error %v: %v", resp.StatusCode, data)
-		return fmt.Errorf("url: %v, error-response: %s", logurl, data)
+		return &ApiError{
+			Url:        logurl,
+			StatusCode: resp.StatusCode,
+			Response:   data,
+		}
 	}
 
-	dec := json.NewDecoder(resp.Body)
-	err = dec.Decode(&returnValue)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading json response: %v", err)
+	}
+	err = json.Unmarshal(data, returnValue)
+	bc.schema.checkResponse(requrl, data, returnValue, err)
 	if err != nil {
 		return fmt.Errorf("error parsing json response: %v", err)
 	}
@@ -137,6 +240,11 @@ func (bc *BeaconClient) getJson(requrl string, returnValue interface{}) error {
 	return nil
 }
 
+// GetCircuitBreaker returns the circuit breaker that tracks the health of this endpoint
+func (bc *BeaconClient) GetCircuitBreaker() *CircuitBreaker {
+	return bc.breaker
+}
+
 func (bc *BeaconClient) Initialize() error {
 	if bc.clientSvc != nil {
 		return nil
@@ -144,7 +252,8 @@ func (bc *BeaconClient) Initialize() error {
 
 	cliParams := []http.Parameter{
 		http.WithAddress(bc.endpoint),
-		http.WithTimeout(10 * time.Minute),
+		// per call timeouts are applied via the request context, this is just the upper bound
+		http.WithTimeout(getMaxCallTimeout()),
 		// TODO (when upstream PR is merged)
 		//http.WithConnectionCheck(false),
 	}
@@ -174,13 +283,15 @@ func (bc *BeaconClient) Initialize() error {
 }
 
 func (bc *BeaconClient) GetGenesis() (*v1.Genesis, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := newCallContext(context.Background(), CallTypeHeader)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.GenesisProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get genesis not supported")
 	}
 	result, err := provider.Genesis(ctx)
+	err = bc.handleResponseError(FeatureCore, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
@@ -188,13 +299,15 @@ func (bc *BeaconClient) GetGenesis() (*v1.Genesis, error) {
 }
 
 func (bc *BeaconClient) GetNodeSyncing() (*v1.SyncState, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := newCallContext(context.Background(), CallTypeHeader)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.NodeSyncingProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get node syncing not supported")
 	}
 	result, err := provider.NodeSyncing(ctx)
+	err = bc.handleResponseError(FeatureCore, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
@@ -209,21 +322,24 @@ type apiNodeVersion struct {
 
 func (bc *BeaconClient) GetNodeVersion() (string, error) {
 	var nodeVersion apiNodeVersion
-	err := bc.getJson(fmt.Sprintf("%s/eth/v1/node/version", bc.endpoint), &nodeVersion)
+	err := bc.getJson(context.Background(), CallTypeHeader, fmt.Sprintf("%s/eth/v1/node/version", bc.endpoint), &nodeVersion)
 	if err != nil {
 		return "", fmt.Errorf("error retrieving node version: %v", err)
 	}
+	bc.capabilities.setVersion(nodeVersion.Data.Version)
 	return nodeVersion.Data.Version, nil
 }
 
 func (bc *BeaconClient) GetLatestBlockHead() (*v1.BeaconBlockHeader, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := newCallContext(context.Background(), CallTypeHeader)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.BeaconBlockHeadersProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get beacon block headers not supported")
 	}
 	result, err := provider.BeaconBlockHeader(ctx, "head")
+	err = bc.handleResponseError(FeatureCore, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
@@ -231,13 +347,15 @@ func (bc *BeaconClient) GetLatestBlockHead() (*v1.BeaconBlockHeader, error) {
 }
 
 func (bc *BeaconClient) GetFinalityCheckpoints() (*v1.Finality, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := newCallContext(context.Background(), CallTypeHeader)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.FinalityProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get finality not supported")
 	}
 	result, err := provider.Finality(ctx, "head")
+	err = bc.handleResponseError(FeatureCore, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
@@ -245,13 +363,15 @@ func (bc *BeaconClient) GetFinalityCheckpoints() (*v1.Finality, error) {
 }
 
 func (bc *BeaconClient) GetBlockHeaderByBlockroot(blockroot []byte) (*v1.BeaconBlockHeader, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := newCallContext(context.Background(), CallTypeHeader)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.BeaconBlockHeadersProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get beacon block headers not supported")
 	}
 	result, err := provider.BeaconBlockHeader(ctx, fmt.Sprintf("0x%x", blockroot))
+	err = bc.handleResponseError(FeatureCore, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
@@ -259,13 +379,15 @@ func (bc *BeaconClient) GetBlockHeaderByBlockroot(blockroot []byte) (*v1.BeaconB
 }
 
 func (bc *BeaconClient) GetBlockHeaderBySlot(slot uint64) (*v1.BeaconBlockHeader, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := newCallContext(context.Background(), CallTypeHeader)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.BeaconBlockHeadersProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get beacon block headers not supported")
 	}
 	result, err := provider.BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
+	err = bc.handleResponseError(FeatureCore, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
@@ -273,17 +395,42 @@ func (bc *BeaconClient) GetBlockHeaderBySlot(slot uint64) (*v1.BeaconBlockHeader
 }
 
 func (bc *BeaconClient) GetBlockBodyByBlockroot(blockroot []byte) (*spec.VersionedSignedBeaconBlock, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	block, _, err := bc.GetBlockBodyWithCustomFields(blockroot)
+	return block, err
+}
+
+// GetBlockBodyWithCustomFields returns the block and the fields specific to the custom fork of the block (if any)
+func (bc *BeaconClient) GetBlockBodyWithCustomFields(blockroot []byte) (*spec.VersionedSignedBeaconBlock, map[string]json.RawMessage, error) {
+	if len(utils.Config.Chain.CustomForks) > 0 {
+		// the block version is unknown before loading the block, so decode all blocks manually on custom fork devnets
+		block, customFields, err := bc.getCustomForkBlockBody(context.Background(), blockroot)
+		if errors.Is(err, errNotFound) {
+			return nil, nil, nil
+		}
+		return block, customFields, err
+	}
+
+	if bc.useSsz() {
+		block, err := bc.getSszBlockBody(context.Background(), blockroot)
+		if !errors.Is(err, errSszUnsupported) {
+			return block, nil, err
+		}
+		bc.handleSszFallback(err)
+	}
+
+	ctx, cancel := newCallContext(context.Background(), CallTypeBlock)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.SignedBeaconBlockProvider)
 	if !isProvider {
-		return nil, fmt.Errorf("get signed beacon block not supported")
+		return nil, nil, fmt.Errorf("get signed beacon block not supported")
 	}
 	result, err := provider.SignedBeaconBlock(ctx, fmt.Sprintf("0x%x", blockroot))
+	err = bc.handleResponseError(FeatureCore, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return result, nil
+	return result, nil, nil
 }
 
 type ProposerDuties struct {
@@ -297,54 +444,151 @@ func (bc *BeaconClient) GetProposerDuties(epoch uint64) (*ProposerDuties, error)
 		return nil, nil
 	}
 
+	if err := bc.checkFeature(FeatureProposerDuties); err != nil {
+		return nil, err
+	}
+
 	var proposerDuties ProposerDuties
-	err := bc.getJson(fmt.Sprintf("%s/eth/v1/validator/duties/proposer/%d", bc.endpoint, epoch), &proposerDuties)
+	err := bc.getJson(context.Background(), CallTypeDuties, fmt.Sprintf("%s/eth/v1/validator/duties/proposer/%d", bc.endpoint, epoch), &proposerDuties)
+	err = bc.handleResponseError(FeatureProposerDuties, err)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving proposer duties: %v", err)
+		return nil, fmt.Errorf("error retrieving proposer duties: %w", err)
+	}
+	if proposerDuties.Data == nil {
+		return nil, fmt.Errorf("error retrieving proposer duties: %w", errNotFound)
 	}
 
 	return &proposerDuties, nil
 }
 
-func (bc *BeaconClient) GetCommitteeDuties(stateRef string, epoch uint64) ([]*v1.BeaconCommittee, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+type BlockRewards struct {
+	Data struct {
+		ProposerIndex     uint64 `json:"proposer_index,string"`
+		Total             uint64 `json:"total,string"`
+		Attestations      uint64 `json:"attestations,string"`
+		SyncAggregate     uint64 `json:"sync_aggregate,string"`
+		ProposerSlashings uint64 `json:"proposer_slashings,string"`
+		AttesterSlashings uint64 `json:"attester_slashings,string"`
+	} `json:"data"`
+}
+
+// GetBlockRewards returns the consensus layer rewards (in gwei) the proposer received for the block
+func (bc *BeaconClient) GetBlockRewards(blockroot []byte) (*BlockRewards, error) {
+	var blockRewards BlockRewards
+	err := bc.getJson(context.Background(), CallTypeBlock, fmt.Sprintf("%s/eth/v1/beacon/rewards/blocks/0x%x", bc.endpoint, blockroot), &blockRewards)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving block rewards: %w", err)
+	}
+	return &blockRewards, nil
+}
+
+type SyncCommitteeRewards struct {
+	Data []struct {
+		ValidatorIndex uint64 `json:"validator_index,string"`
+		Reward         int64  `json:"reward,string"`
+	} `json:"data"`
+}
+
+// GetSyncCommitteeRewards returns the rewards (in gwei) of all sync committee members for the block.
+// Members that missed their sync committee signature receive a negative reward.
+func (bc *BeaconClient) GetSyncCommitteeRewards(blockroot []byte) (*SyncCommitteeRewards, error) {
+	var syncRewards SyncCommitteeRewards
+	err := bc.postJson(context.Background(), CallTypeBlock, fmt.Sprintf("%s/eth/v1/beacon/rewards/sync_committee/0x%x", bc.endpoint, blockroot), []string{}, &syncRewards)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving sync committee rewards: %w", err)
+	}
+	return &syncRewards, nil
+}
+
+type stateRootResponse struct {
+	Data struct {
+		Root phase0.Root `json:"root"`
+	} `json:"data"`
+}
+
+// GetStateRoot returns the state root of the given state (slot, block root or state id)
+func (bc *BeaconClient) GetStateRoot(stateRef string) ([]byte, error) {
+	var stateRoot stateRootResponse
+	err := bc.getJson(context.Background(), CallTypeHeader, fmt.Sprintf("%s/eth/v1/beacon/states/%v/root", bc.endpoint, stateRef), &stateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving state root: %w", err)
+	}
+	return stateRoot.Data.Root[:], nil
+}
+
+type stateForkResponse struct {
+	Data *phase0.Fork `json:"data"`
+}
+
+// GetStateFork returns the fork (previous & current fork version) of the given state
+func (bc *BeaconClient) GetStateFork(stateRef string) (*phase0.Fork, error) {
+	var stateFork stateForkResponse
+	err := bc.getJson(context.Background(), CallTypeHeader, fmt.Sprintf("%s/eth/v1/beacon/states/%v/fork", bc.endpoint, stateRef), &stateFork)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving state fork: %w", err)
+	}
+	if stateFork.Data == nil {
+		return nil, fmt.Errorf("empty state fork response")
+	}
+	return stateFork.Data, nil
+}
+
+func (bc *BeaconClient) GetCommitteeDuties(ctx context.Context, stateRef string, epoch uint64) ([]*v1.BeaconCommittee, error) {
+	ctx, cancel := newCallContext(ctx, CallTypeDuties)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.BeaconCommitteesProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get beacon committees not supported")
 	}
 	result, err := provider.BeaconCommitteesAtEpoch(ctx, stateRef, phase0.Epoch(epoch))
+	err = bc.handleResponseError(FeatureCore, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-func (bc *BeaconClient) GetSyncCommitteeDuties(stateRef string, epoch uint64) (*v1.SyncCommittee, error) {
+func (bc *BeaconClient) GetSyncCommitteeDuties(ctx context.Context, stateRef string, epoch uint64) (*v1.SyncCommittee, error) {
 	if epoch < utils.Config.Chain.Config.AltairForkEpoch {
 		return nil, fmt.Errorf("cannot get sync committee duties for epoch before altair: %v", epoch)
 	}
-	ctx, cancel := context.WithCancel(context.Background())
+	if err := bc.checkFeature(FeatureSyncCommittees); err != nil {
+		return nil, err
+	}
+	ctx, cancel := newCallContext(ctx, CallTypeDuties)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.SyncCommitteesProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get sync committees not supported")
 	}
 	result, err := provider.SyncCommitteeAtEpoch(ctx, stateRef, phase0.Epoch(epoch))
+	err = bc.handleResponseError(FeatureSyncCommittees, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-func (bc *BeaconClient) GetStateValidators(stateRef string) (map[phase0.ValidatorIndex]*v1.Validator, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+func (bc *BeaconClient) GetStateValidators(ctx context.Context, stateRef string) (map[phase0.ValidatorIndex]*v1.Validator, error) {
+	if bc.useSsz() {
+		validators, err := bc.getSszStateValidators(ctx, stateRef)
+		if !errors.Is(err, errSszUnsupported) {
+			return validators, err
+		}
+		bc.handleSszFallback(err)
+	}
+
+	ctx, cancel := newCallContext(ctx, CallTypeState)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.ValidatorsProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get validators not supported")
 	}
 	result, err := provider.Validators(ctx, stateRef, nil)
+	err = bc.handleResponseError(FeatureCore, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
@@ -352,15 +596,24 @@ func (bc *BeaconClient) GetStateValidators(stateRef string) (map[phase0.Validato
 }
 
 func (bc *BeaconClient) GetBlobSidecarsByBlockroot(blockroot []byte) ([]*deneb.BlobSidecar, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	if err := bc.checkFeature(FeatureBlobSidecars); err != nil {
+		return nil, err
+	}
+	ctx, cancel := newCallContext(context.Background(), CallTypeBlock)
 	defer cancel()
 	provider, isProvider := bc.clientSvc.(eth2client.BeaconBlockBlobsProvider)
 	if !isProvider {
 		return nil, fmt.Errorf("get beacon block blobs not supported")
 	}
 	result, err := provider.BeaconBlockBlobs(ctx, fmt.Sprintf("0x%x", blockroot))
+	err = bc.handleResponseError(FeatureBlobSidecars, err)
+	bc.breaker.reportResult(err)
 	if err != nil {
 		return nil, err
 	}
+	if result == nil {
+		// the client responded with 404, which is either an unknown block or an unsupported endpoint
+		return nil, fmt.Errorf("no blob sidecars found for block 0x%x: %w", blockroot, errNotFound)
+	}
 	return result, nil
 }
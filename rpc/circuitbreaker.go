@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	circuitBreakerThreshold   = 5
+	circuitBreakerMinCooldown = 30 * time.Second
+	circuitBreakerMaxCooldown = 5 * time.Minute
+)
+
+type CircuitState uint8
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (state CircuitState) String() string {
+	switch state {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker tracks consecutive endpoint failures and temporarily marks flaky endpoints as unhealthy
+type CircuitBreaker struct {
+	mutex         sync.Mutex
+	failures      uint64
+	cooldown      time.Duration
+	openUntil     time.Time
+	lastError     error
+	lastErrorTime time.Time
+	lastClass     ErrorClass
+	errorCounts   map[ErrorClass]uint64
+}
+
+type CircuitBreakerStatus struct {
+	State         CircuitState
+	Failures      uint64
+	OpenUntil     time.Time
+	LastError     string
+	LastErrorTime time.Time
+	LastClass     ErrorClass
+	ErrorCounts   map[ErrorClass]uint64
+}
+
+func newCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		cooldown:    circuitBreakerMinCooldown,
+		errorCounts: map[ErrorClass]uint64{},
+	}
+}
+
+func (cb *CircuitBreaker) reportResult(err error) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if err == nil {
+		cb.failures = 0
+		cb.cooldown = circuitBreakerMinCooldown
+		cb.openUntil = time.Time{}
+		return
+	}
+
+	class := ClassifyError(err)
+	cb.errorCounts[class]++
+	cb.lastError = err
+	cb.lastErrorTime = time.Now()
+	cb.lastClass = class
+	if !class.IsEndpointFailure() {
+		return
+	}
+
+	cb.failures++
+	if cb.failures >= circuitBreakerThreshold && !cb.openUntil.After(time.Now()) {
+		wasOpened := !cb.openUntil.IsZero()
+		cb.openUntil = time.Now().Add(cb.cooldown)
+		if wasOpened {
+			// failed again after cooldown, back off further
+			cb.cooldown *= 2
+			if cb.cooldown > circuitBreakerMaxCooldown {
+				cb.cooldown = circuitBreakerMaxCooldown
+			}
+		}
+	}
+}
+
+func (cb *CircuitBreaker) getState() CircuitState {
+	if cb.openUntil.IsZero() {
+		return CircuitClosed
+	}
+	if cb.openUntil.After(time.Now()) {
+		return CircuitOpen
+	}
+	return CircuitHalfOpen
+}
+
+// IsOpen returns true while the endpoint is considered unhealthy
+func (cb *CircuitBreaker) IsOpen() bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return cb.getState() == CircuitOpen
+}
+
+func (cb *CircuitBreaker) GetStatus() *CircuitBreakerStatus {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	status := &CircuitBreakerStatus{
+		State:         cb.getState(),
+		Failures:      cb.failures,
+		OpenUntil:     cb.openUntil,
+		LastErrorTime: cb.lastErrorTime,
+		LastClass:     cb.lastClass,
+		ErrorCounts:   make(map[ErrorClass]uint64, len(cb.errorCounts)),
+	}
+	if cb.lastError != nil {
+		status.LastError = cb.lastError.Error()
+	}
+	for class, count := range cb.errorCounts {
+		status.ErrorCounts[class] = count
+	}
+	return status
+}
@@ -0,0 +1,221 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/http"
+)
+
+// ClientType identifies the beacon node implementation behind an endpoint
+type ClientType uint8
+
+const (
+	UnknownClient ClientType = iota
+	LighthouseClient
+	LodestarClient
+	NimbusClient
+	PrysmClient
+	TekuClient
+	GrandineClient
+)
+
+var clientTypePrefixes = []struct {
+	prefix     string
+	clientType ClientType
+}{
+	{"lighthouse", LighthouseClient},
+	{"lodestar", LodestarClient},
+	{"nimbus", NimbusClient},
+	{"prysm", PrysmClient},
+	{"teku", TekuClient},
+	{"grandine", GrandineClient},
+}
+
+func (clientType ClientType) String() string {
+	switch clientType {
+	case LighthouseClient:
+		return "lighthouse"
+	case LodestarClient:
+		return "lodestar"
+	case NimbusClient:
+		return "nimbus"
+	case PrysmClient:
+		return "prysm"
+	case TekuClient:
+		return "teku"
+	case GrandineClient:
+		return "grandine"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseClientType detects the client implementation from a node version string (eg. "Lighthouse/v4.5.0-441fc16/x86_64-linux")
+func ParseClientType(version string) ClientType {
+	version = strings.ToLower(version)
+	for _, entry := range clientTypePrefixes {
+		if strings.HasPrefix(version, entry.prefix) {
+			return entry.clientType
+		}
+	}
+	return UnknownClient
+}
+
+// ClientFeature is an optional beacon api feature that might not be available on all clients or client versions
+type ClientFeature string
+
+const (
+	FeatureCore           ClientFeature = ""
+	FeatureBlobSidecars   ClientFeature = "blob_sidecars"
+	FeatureDataColumns    ClientFeature = "data_columns"
+	FeatureSyncCommittees ClientFeature = "sync_committees"
+	FeatureProposerDuties ClientFeature = "proposer_duties"
+	FeatureSsz            ClientFeature = "ssz"
+)
+
+// ErrUnsupported is returned when the client does not provide the requested endpoint
+var ErrUnsupported = errors.New("endpoint not supported by client")
+
+// unsupported features are checked again after this time, as the client might have been upgraded meanwhile
+const unsupportedFeatureRecheck = 30 * time.Minute
+
+// ClientCapabilities records the detected client implementation and the features it failed to provide
+type ClientCapabilities struct {
+	mutex       sync.RWMutex
+	clientType  ClientType
+	version     string
+	unsupported map[ClientFeature]time.Time
+}
+
+func newClientCapabilities() *ClientCapabilities {
+	return &ClientCapabilities{
+		unsupported: map[ClientFeature]time.Time{},
+	}
+}
+
+func (caps *ClientCapabilities) setVersion(version string) {
+	caps.mutex.Lock()
+	defer caps.mutex.Unlock()
+	if caps.version == version {
+		return
+	}
+	caps.version = version
+	caps.clientType = ParseClientType(version)
+	// client changed, recheck all features
+	caps.unsupported = map[ClientFeature]time.Time{}
+}
+
+// GetClientType returns the detected client implementation
+func (caps *ClientCapabilities) GetClientType() ClientType {
+	caps.mutex.RLock()
+	defer caps.mutex.RUnlock()
+	return caps.clientType
+}
+
+// IsSupported returns false if the client failed to provide the feature recently
+func (caps *ClientCapabilities) IsSupported(feature ClientFeature) bool {
+	caps.mutex.RLock()
+	defer caps.mutex.RUnlock()
+	markedAt, isMarked := caps.unsupported[feature]
+	return !isMarked || time.Since(markedAt) > unsupportedFeatureRecheck
+}
+
+// GetUnsupportedFeatures returns a sorted list of features the client does not provide
+func (caps *ClientCapabilities) GetUnsupportedFeatures() []string {
+	caps.mutex.RLock()
+	defer caps.mutex.RUnlock()
+	features := []string{}
+	for feature, markedAt := range caps.unsupported {
+		if time.Since(markedAt) <= unsupportedFeatureRecheck {
+			features = append(features, string(feature))
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+func (caps *ClientCapabilities) markUnsupported(feature ClientFeature) {
+	caps.mutex.Lock()
+	defer caps.mutex.Unlock()
+	caps.unsupported[feature] = time.Now()
+}
+
+// GetCapabilities returns the detected client implementation & capabilities of this endpoint
+func (bc *BeaconClient) GetCapabilities() *ClientCapabilities {
+	return bc.capabilities
+}
+
+// checkFeature returns ErrUnsupported if the feature is known to be unavailable on this client
+func (bc *BeaconClient) checkFeature(feature ClientFeature) error {
+	if feature != FeatureCore && !bc.capabilities.IsSupported(feature) {
+		return fmt.Errorf("%v: %w", feature, ErrUnsupported)
+	}
+	return nil
+}
+
+// handleResponseError applies client specific workarounds to errors returned by the beacon api.
+// errors that are known to mean "object not found" on the detected client are dropped to match the 404 semantics
+// of other clients, while errors caused by missing endpoints mark the feature as unsupported.
+func (bc *BeaconClient) handleResponseError(feature ClientFeature, err error) error {
+	if err == nil {
+		return nil
+	}
+	statusCode, errData := getResponseErrorDetails(err)
+	errMsg := strings.ToLower(errData)
+
+	if isUnsupportedResponse(statusCode, errMsg) {
+		if feature != FeatureCore {
+			bc.capabilities.markUnsupported(feature)
+			logger.WithField("client", bc.name).Warnf("client does not support %v: %v", feature, err)
+		}
+		return fmt.Errorf("%v: %w", err, ErrUnsupported)
+	}
+
+	switch bc.capabilities.GetClientType() {
+	case PrysmClient:
+		// prysm responds with 500 instead of 404 for unknown blocks / states on some endpoints
+		if statusCode == nethttp.StatusInternalServerError && (strings.Contains(errMsg, "not found") || strings.Contains(errMsg, "could not find")) {
+			return nil
+		}
+	case NimbusClient, LodestarClient:
+		// unknown block ids are rejected with 400 instead of 404
+		if statusCode == nethttp.StatusBadRequest && strings.Contains(errMsg, "block not found") {
+			return nil
+		}
+	}
+	return err
+}
+
+func getResponseErrorDetails(err error) (int, string) {
+	var httpErr http.Error
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode, string(httpErr.Data)
+	}
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode, string(apiErr.Response)
+	}
+	return 0, ""
+}
+
+// isUnsupportedResponse checks if a error response was caused by a missing route rather than a missing object.
+// the routers of most clients respond with a plain text or generic message for unknown paths.
+func isUnsupportedResponse(statusCode int, errMsg string) bool {
+	switch statusCode {
+	case nethttp.StatusMethodNotAllowed, nethttp.StatusNotImplemented:
+		return true
+	case nethttp.StatusNotFound, nethttp.StatusBadRequest:
+		return strings.Contains(errMsg, "404 page not found") ||
+			strings.Contains(errMsg, "not implemented") ||
+			strings.Contains(errMsg, "unsupported endpoint") ||
+			(strings.Contains(errMsg, "route") && strings.Contains(errMsg, "not found")) ||
+			strings.Contains(errMsg, "endpoint not found")
+	}
+	return false
+}
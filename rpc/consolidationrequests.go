@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ConsolidationRequestFields are the custom field paths of the EIP-7251 consolidation requests in the block.
+// The list was part of the execution payload on the first electra devnets and moved to the execution requests later.
+var ConsolidationRequestFields = []string{
+	"message.body.execution_payload.consolidation_requests",
+	"message.body.execution_requests.consolidations",
+}
+
+// ConsolidationRequest is a request from the withdrawal address of the source validator to move its balance to the target validator
+type ConsolidationRequest struct {
+	SourceAddress []byte
+	SourcePubkey  []byte
+	TargetPubkey  []byte
+}
+
+type consolidationRequestJson struct {
+	SourceAddress string `json:"source_address"`
+	SourcePubkey  string `json:"source_pubkey"`
+	TargetPubkey  string `json:"target_pubkey"`
+}
+
+// ParseConsolidationRequests returns the consolidation requests of the first consolidation request field found in the custom fields
+func ParseConsolidationRequests(customFields map[string]json.RawMessage) ([]*ConsolidationRequest, error) {
+	var data json.RawMessage
+	for _, field := range ConsolidationRequestFields {
+		if data = customFields[field]; data != nil {
+			break
+		}
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	requestsJson := []*consolidationRequestJson{}
+	if err := json.Unmarshal(data, &requestsJson); err != nil {
+		return nil, fmt.Errorf("error decoding consolidation requests: %v", err)
+	}
+	requests := make([]*ConsolidationRequest, len(requestsJson))
+	for idx, requestJson := range requestsJson {
+		request := &ConsolidationRequest{}
+		var err error
+		if request.SourceAddress, err = decodeDepositReceiptHex(requestJson.SourceAddress, 20); err != nil {
+			return nil, fmt.Errorf("invalid source address in consolidation request %v: %v", idx, err)
+		}
+		if request.SourcePubkey, err = decodeDepositReceiptHex(requestJson.SourcePubkey, 48); err != nil {
+			return nil, fmt.Errorf("invalid source pubkey in consolidation request %v: %v", idx, err)
+		}
+		if request.TargetPubkey, err = decodeDepositReceiptHex(requestJson.TargetPubkey, 48); err != nil {
+			return nil, fmt.Errorf("invalid target pubkey in consolidation request %v: %v", idx, err)
+		}
+		requests[idx] = request
+	}
+	return requests, nil
+}
@@ -0,0 +1,224 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/pk910/dora/types"
+	"github.com/pk910/dora/utils"
+)
+
+// CustomForkDecoder decodes the blocks of an experimental fork, that is not supported by the beacon api library.
+type CustomForkDecoder interface {
+	// DecodeBlock decodes the json encoded signed block. It returns the block in the layout of the base fork,
+	// so it can be processed like any other block, and the fork specific fields keyed by their json path.
+	DecodeBlock(fork *types.CustomForkConfig, data []byte) (*spec.VersionedSignedBeaconBlock, map[string]json.RawMessage, error)
+}
+
+var customForkDecoders = map[string]CustomForkDecoder{
+	"extra-fields": &extraFieldsDecoder{},
+}
+
+// RegisterCustomForkDecoder adds a block decoder plugin, that can be referenced by the custom fork configs
+func RegisterCustomForkDecoder(name string, decoder CustomForkDecoder) {
+	customForkDecoders[name] = decoder
+}
+
+// GetCustomFork returns the custom fork config for the consensus version name
+func GetCustomFork(name string) *types.CustomForkConfig {
+	for idx := range utils.Config.Chain.CustomForks {
+		fork := &utils.Config.Chain.CustomForks[idx]
+		if strings.EqualFold(fork.Name, name) {
+			return fork
+		}
+	}
+	return nil
+}
+
+// GetCustomForkForEpoch returns the latest custom fork activated at the epoch
+func GetCustomForkForEpoch(epoch uint64) *types.CustomForkConfig {
+	var activeFork *types.CustomForkConfig
+	for idx := range utils.Config.Chain.CustomForks {
+		fork := &utils.Config.Chain.CustomForks[idx]
+		if epoch >= fork.Epoch && (activeFork == nil || fork.Epoch >= activeFork.Epoch) {
+			activeFork = fork
+		}
+	}
+	return activeFork
+}
+
+func decodeCustomForkBlock(fork *types.CustomForkConfig, data []byte) (*spec.VersionedSignedBeaconBlock, map[string]json.RawMessage, error) {
+	decoderName := fork.Decoder
+	if decoderName == "" {
+		decoderName = "extra-fields"
+	}
+	decoder := customForkDecoders[decoderName]
+	if decoder == nil {
+		return nil, nil, fmt.Errorf("unknown block decoder %v for fork %v", decoderName, fork.Name)
+	}
+	return decoder.DecodeBlock(fork, data)
+}
+
+func parseDataVersion(name string) (spec.DataVersion, error) {
+	var version spec.DataVersion
+	err := version.UnmarshalJSON([]byte(fmt.Sprintf("%q", strings.ToLower(name))))
+	return version, err
+}
+
+func decodeSignedBlockJson(version spec.DataVersion, data []byte) (*spec.VersionedSignedBeaconBlock, error) {
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: version,
+	}
+	var err error
+	switch version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Phase0)
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Altair)
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Bellatrix)
+	case spec.DataVersionCapella:
+		block.Capella = &capella.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Capella)
+	case spec.DataVersionDeneb:
+		block.Deneb = &deneb.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Deneb)
+	default:
+		return nil, fmt.Errorf("unsupported block version %v", version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %v block: %v", version, err)
+	}
+	return block, nil
+}
+
+func encodeSignedBlockJson(block *spec.VersionedSignedBeaconBlock) ([]byte, error) {
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		return json.Marshal(block.Phase0)
+	case spec.DataVersionAltair:
+		return json.Marshal(block.Altair)
+	case spec.DataVersionBellatrix:
+		return json.Marshal(block.Bellatrix)
+	case spec.DataVersionCapella:
+		return json.Marshal(block.Capella)
+	case spec.DataVersionDeneb:
+		return json.Marshal(block.Deneb)
+	default:
+		return nil, fmt.Errorf("unsupported block version %v", block.Version)
+	}
+}
+
+// extraFieldsDecoder decodes the block with the base fork layout and returns all fields unknown to the base fork.
+// this covers forks that only add new fields to existing containers (eg. the execution witness on verkle devnets).
+type extraFieldsDecoder struct{}
+
+func (d *extraFieldsDecoder) DecodeBlock(fork *types.CustomForkConfig, data []byte) (*spec.VersionedSignedBeaconBlock, map[string]json.RawMessage, error) {
+	baseVersion, err := parseDataVersion(fork.BaseFork)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid base fork %v for fork %v", fork.BaseFork, fork.Name)
+	}
+	block, err := decodeSignedBlockJson(baseVersion, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseData, err := encodeSignedBlockJson(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	extraFields := map[string]json.RawMessage{}
+	if err := collectExtraFields("", data, baseData, extraFields); err != nil {
+		return nil, nil, err
+	}
+	return block, extraFields, nil
+}
+
+func collectExtraFields(path string, data []byte, baseData []byte, extraFields map[string]json.RawMessage) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("error decoding %v: %v", path, err)
+	}
+	baseFields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(baseData, &baseFields); err != nil {
+		return fmt.Errorf("error decoding base %v: %v", path, err)
+	}
+
+	for name, value := range fields {
+		baseValue, found := baseFields[name]
+		if !found {
+			extraFields[path+name] = value
+			continue
+		}
+		if isJsonObject(value) && isJsonObject(baseValue) {
+			if err := collectExtraFields(path+name+".", value, baseValue, extraFields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isJsonObject(data json.RawMessage) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{'
+}
+
+type customForkBlockResponse struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// getCustomForkBlockBody loads the json encoded block and decodes it with the custom fork decoder if it belongs to a custom fork
+func (bc *BeaconClient) getCustomForkBlockBody(ctx context.Context, blockroot []byte) (*spec.VersionedSignedBeaconBlock, map[string]json.RawMessage, error) {
+	var response customForkBlockResponse
+	err := bc.getJson(ctx, CallTypeBlock, fmt.Sprintf("%s/eth/v2/beacon/blocks/0x%x", bc.endpoint, blockroot), &response)
+	err = bc.handleResponseError(FeatureCore, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if fork := GetCustomFork(response.Version); fork != nil {
+		return decodeCustomForkBlock(fork, response.Data)
+	}
+	version, err := parseDataVersion(response.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unknown block version %v", response.Version)
+	}
+	block, err := decodeSignedBlockJson(version, response.Data)
+	return block, nil, err
+}
+
+// GetBlockCustomFields returns the name of the custom fork and the fork specific fields of the block.
+// The fork name is empty if the block does not belong to a custom fork.
+func (bc *BeaconClient) GetBlockCustomFields(blockroot []byte) (string, map[string]json.RawMessage, error) {
+	var response customForkBlockResponse
+	err := bc.getJson(context.Background(), CallTypeBlock, fmt.Sprintf("%s/eth/v2/beacon/blocks/0x%x", bc.endpoint, blockroot), &response)
+	if errors.Is(err, errNotFound) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	fork := GetCustomFork(response.Version)
+	if fork == nil {
+		return "", nil, nil
+	}
+	_, extraFields, err := decodeCustomForkBlock(fork, response.Data)
+	return fork.Name, extraFields, err
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// DataColumnSidecar is a PeerDAS (EIP-7594) data column of a block.
+// Only the column metadata is kept, the cells & proofs are dropped after decoding.
+type DataColumnSidecar struct {
+	Index         uint64
+	Slot          uint64
+	ProposerIndex uint64
+	CellCount     uint64 // number of cells in the column, one per blob of the block
+}
+
+type dataColumnSidecarJson struct {
+	Index             string            `json:"index"`
+	Column            []json.RawMessage `json:"column"`
+	SignedBlockHeader struct {
+		Message struct {
+			Slot          string `json:"slot"`
+			ProposerIndex string `json:"proposer_index"`
+		} `json:"message"`
+	} `json:"signed_block_header"`
+}
+
+type dataColumnSidecarsResponse struct {
+	Data []*dataColumnSidecarJson `json:"data"`
+}
+
+// GetDataColumnSidecarsByBlockroot returns the data columns of the block the client custodies or has sampled
+func (bc *BeaconClient) GetDataColumnSidecarsByBlockroot(blockroot []byte) ([]*DataColumnSidecar, error) {
+	if err := bc.checkFeature(FeatureDataColumns); err != nil {
+		return nil, err
+	}
+
+	var response dataColumnSidecarsResponse
+	err := bc.getJson(context.Background(), CallTypeBlock, fmt.Sprintf("%s/eth/v1/debug/beacon/data_column_sidecars/0x%x", bc.endpoint, blockroot), &response)
+	err = bc.handleResponseError(FeatureDataColumns, err)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving data column sidecars: %w", err)
+	}
+
+	sidecars := make([]*DataColumnSidecar, len(response.Data))
+	for idx, sidecarJson := range response.Data {
+		sidecar := &DataColumnSidecar{
+			CellCount: uint64(len(sidecarJson.Column)),
+		}
+		if sidecar.Index, err = strconv.ParseUint(sidecarJson.Index, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid index in data column sidecar %v: %v", idx, err)
+		}
+		if sidecar.Slot, err = strconv.ParseUint(sidecarJson.SignedBlockHeader.Message.Slot, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid slot in data column sidecar %v: %v", idx, err)
+		}
+		if sidecar.ProposerIndex, err = strconv.ParseUint(sidecarJson.SignedBlockHeader.Message.ProposerIndex, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid proposer index in data column sidecar %v: %v", idx, err)
+		}
+		sidecars[idx] = sidecar
+	}
+	return sidecars, nil
+}
@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DepositReceiptFields are the custom field paths of the EIP-6110 deposits in the block.
+// The list was called deposit_receipts on the first electra devnets and renamed to deposit_requests later.
+var DepositReceiptFields = []string{
+	"message.body.execution_payload.deposit_receipts",
+	"message.body.execution_payload.deposit_requests",
+	"message.body.execution_requests.deposits",
+}
+
+// DepositReceipt is a deposit that was processed by the execution layer and passed to the beacon chain via the execution payload
+type DepositReceipt struct {
+	Pubkey                []byte
+	WithdrawalCredentials []byte
+	Amount                uint64
+	Signature             []byte
+	Index                 uint64
+}
+
+type depositReceiptJson struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Signature             string `json:"signature"`
+	Index                 string `json:"index"`
+}
+
+// ParseDepositReceipts returns the deposit receipts of the first deposit receipt field found in the custom fields
+func ParseDepositReceipts(customFields map[string]json.RawMessage) ([]*DepositReceipt, error) {
+	var data json.RawMessage
+	for _, field := range DepositReceiptFields {
+		if data = customFields[field]; data != nil {
+			break
+		}
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	receiptsJson := []*depositReceiptJson{}
+	if err := json.Unmarshal(data, &receiptsJson); err != nil {
+		return nil, fmt.Errorf("error decoding deposit receipts: %v", err)
+	}
+	receipts := make([]*DepositReceipt, len(receiptsJson))
+	for idx, receiptJson := range receiptsJson {
+		receipt := &DepositReceipt{}
+		var err error
+		if receipt.Pubkey, err = decodeDepositReceiptHex(receiptJson.Pubkey, 48); err != nil {
+			return nil, fmt.Errorf("invalid pubkey in deposit receipt %v: %v", idx, err)
+		}
+		if receipt.WithdrawalCredentials, err = decodeDepositReceiptHex(receiptJson.WithdrawalCredentials, 32); err != nil {
+			return nil, fmt.Errorf("invalid withdrawal credentials in deposit receipt %v: %v", idx, err)
+		}
+		if receipt.Signature, err = decodeDepositReceiptHex(receiptJson.Signature, 96); err != nil {
+			return nil, fmt.Errorf("invalid signature in deposit receipt %v: %v", idx, err)
+		}
+		if receipt.Amount, err = strconv.ParseUint(receiptJson.Amount, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid amount in deposit receipt %v: %v", idx, err)
+		}
+		if receipt.Index, err = strconv.ParseUint(receiptJson.Index, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid index in deposit receipt %v: %v", idx, err)
+		}
+		receipts[idx] = receipt
+	}
+	return receipts, nil
+}
+
+func decodeDepositReceiptHex(value string, length int) ([]byte, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != length {
+		return nil, fmt.Errorf("expected %v bytes, got %v", length, len(data))
+	}
+	return data, nil
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -17,7 +18,7 @@ type EpochAssignments struct {
 }
 
 // GetEpochAssignments will get the epoch assignments from Lighthouse RPC api
-func (bc *BeaconClient) GetEpochAssignments(epoch uint64, dependendRoot []byte) (*EpochAssignments, error) {
+func (bc *BeaconClient) GetEpochAssignments(ctx context.Context, epoch uint64, dependendRoot []byte) (*EpochAssignments, error) {
 	parsedProposerResponse, err := bc.GetProposerDuties(epoch)
 	if err != nil {
 		return nil, err
@@ -62,7 +63,7 @@ func (bc *BeaconClient) GetEpochAssignments(epoch uint64, dependendRoot []byte)
 	}
 
 	// Now use the state root to make a consistent committee query
-	parsedCommittees, err := bc.GetCommitteeDuties(depStateRoot, epoch)
+	parsedCommittees, err := bc.GetCommitteeDuties(ctx, depStateRoot, epoch)
 	if err != nil {
 		logger.Errorf("error retrieving committees data: %v", err)
 	} else {
@@ -87,7 +88,7 @@ func (bc *BeaconClient) GetEpochAssignments(epoch uint64, dependendRoot []byte)
 		if epoch > 0 && epoch == utils.Config.Chain.Config.AltairForkEpoch {
 			syncCommitteeState = fmt.Sprintf("%d", utils.Config.Chain.Config.AltairForkEpoch*utils.Config.Chain.Config.SlotsPerEpoch)
 		}
-		parsedSyncCommittees, err := bc.GetSyncCommitteeDuties(syncCommitteeState, epoch)
+		parsedSyncCommittees, err := bc.GetSyncCommitteeDuties(ctx, syncCommitteeState, epoch)
 		if err != nil {
 			logger.Errorf("error retrieving sync_committees for epoch %v (state: %v): %v", epoch, syncCommitteeState, err)
 		} else {
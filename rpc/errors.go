@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ErrorClass uint8
+
+const (
+	ErrorClassNone ErrorClass = iota
+	ErrorClassTimeout
+	ErrorClassConnection
+	ErrorClassServerError
+	ErrorClassSyncDistance
+	ErrorClassPrunedData
+	ErrorClassNotFound
+	ErrorClassUnsupported
+	ErrorClassOther
+)
+
+func (class ErrorClass) String() string {
+	switch class {
+	case ErrorClassNone:
+		return "none"
+	case ErrorClassTimeout:
+		return "timeout"
+	case ErrorClassConnection:
+		return "connection"
+	case ErrorClassServerError:
+		return "server error"
+	case ErrorClassSyncDistance:
+		return "sync distance"
+	case ErrorClassPrunedData:
+		return "pruned data"
+	case ErrorClassNotFound:
+		return "not found"
+	case ErrorClassUnsupported:
+		return "unsupported"
+	default:
+		return "other"
+	}
+}
+
+// IsEndpointFailure returns true for error classes that indicate an unhealthy endpoint.
+// missing or pruned data is a property of the requested object and does not count as failure.
+// unsupported endpoints are tracked via the client capabilities instead.
+func (class ErrorClass) IsEndpointFailure() bool {
+	switch class {
+	case ErrorClassTimeout, ErrorClassConnection, ErrorClassServerError, ErrorClassSyncDistance:
+		return true
+	default:
+		return false
+	}
+}
+
+// ApiError is returned for non-200 responses of the beacon api
+type ApiError struct {
+	Url        string
+	StatusCode int
+	Response   []byte
+}
+
+func (err *ApiError) Error() string {
+	return fmt.Sprintf("url: %v, error-response: %s", err.Url, err.Response)
+}
+
+var statusCodePattern = regexp.MustCompile(`(?i)status(?: code)?:? ?([1-5][0-9]{2})`)
+
+// ClassifyError returns the error class for errors returned by the beacon api
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+	if errors.Is(err, errNotFound) {
+		return ErrorClassNotFound
+	}
+	if errors.Is(err, ErrUnsupported) {
+		return ErrorClassUnsupported
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorClassTimeout
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return ErrorClassConnection
+	}
+
+	if statusCode, errData := getResponseErrorDetails(err); isUnsupportedResponse(statusCode, strings.ToLower(errData)) {
+		return ErrorClassUnsupported
+	}
+
+	errMsg := strings.ToLower(err.Error())
+	if statusCode := getErrorStatusCode(err, errMsg); statusCode > 0 {
+		switch {
+		case statusCode == 404:
+			if isPrunedDataMessage(errMsg) {
+				return ErrorClassPrunedData
+			}
+			return ErrorClassNotFound
+		case statusCode == 503 && strings.Contains(errMsg, "sync"):
+			return ErrorClassSyncDistance
+		case statusCode >= 500:
+			if isPrunedDataMessage(errMsg) {
+				return ErrorClassPrunedData
+			}
+			return ErrorClassServerError
+		}
+	}
+
+	switch {
+	case strings.Contains(errMsg, "timeout") || strings.Contains(errMsg, "deadline exceeded"):
+		return ErrorClassTimeout
+	case strings.Contains(errMsg, "connection refused") || strings.Contains(errMsg, "connection reset") || strings.Contains(errMsg, "no such host") || strings.Contains(errMsg, "eof"):
+		return ErrorClassConnection
+	case strings.Contains(errMsg, "syncing") || strings.Contains(errMsg, "sync distance"):
+		return ErrorClassSyncDistance
+	case isPrunedDataMessage(errMsg):
+		return ErrorClassPrunedData
+	case strings.Contains(errMsg, "not found"):
+		return ErrorClassNotFound
+	}
+	return ErrorClassOther
+}
+
+func getErrorStatusCode(err error, errMsg string) int {
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	if match := statusCodePattern.FindStringSubmatch(errMsg); match != nil {
+		statusCode, _ := strconv.Atoi(match[1])
+		return statusCode
+	}
+	return 0
+}
+
+func isPrunedDataMessage(errMsg string) bool {
+	return strings.Contains(errMsg, "pruned") || strings.Contains(errMsg, "historical") || strings.Contains(errMsg, "not available") || strings.Contains(errMsg, "missing state")
+}
@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pk910/dora/utils"
+)
+
+// ExecutionClient is a minimal json-rpc client for the few execution layer calls the explorer needs
+type ExecutionClient struct {
+	endpoint string
+}
+
+type executionRpcRequest struct {
+	JsonRpc string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	Id      uint64        `json:"id"`
+}
+
+type executionRpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func NewExecutionClient(endpoint string) *ExecutionClient {
+	return &ExecutionClient{
+		endpoint: endpoint,
+	}
+}
+
+func (ec *ExecutionClient) call(method string, result interface{}, params ...interface{}) error {
+	reqBody, err := json.Marshal(&executionRpcRequest{
+		JsonRpc: "2.0",
+		Method:  method,
+		Params:  params,
+		Id:      1,
+	})
+	if err != nil {
+		return err
+	}
+
+	t0 := time.Now()
+	defer func() {
+		logger.Debugf("EL RPC call: %v [%v ms]", method, time.Since(t0).Milliseconds())
+	}()
+
+	client := &http.Client{Timeout: time.Second * 30}
+	resp, err := client.Post(ec.endpoint, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("error calling %v on %v: %v", method, utils.GetRedactedUrl(ec.endpoint), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(ec.endpoint), data)
+	}
+
+	rpcRsp := &executionRpcResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(rpcRsp); err != nil {
+		return fmt.Errorf("error parsing %v response: %v", method, err)
+	}
+	if rpcRsp.Error != nil {
+		return fmt.Errorf("%v failed: %v (code %v)", method, rpcRsp.Error.Message, rpcRsp.Error.Code)
+	}
+	return json.Unmarshal(rpcRsp.Result, result)
+}
+
+func parseHexBig(value string) (*big.Int, error) {
+	res, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex quantity: %v", value)
+	}
+	return res, nil
+}
+
+// GetBalance returns the balance (in wei) of an address at the given block tag ("latest", "finalized", ...)
+func (ec *ExecutionClient) GetBalance(address []byte, blockTag string) (*big.Int, error) {
+	var balance string
+	if err := ec.call("eth_getBalance", &balance, fmt.Sprintf("0x%x", address), blockTag); err != nil {
+		return nil, err
+	}
+	return parseHexBig(balance)
+}
+
+// GetBlockNumber returns the number of the block with the given tag ("latest", "finalized", ...)
+func (ec *ExecutionClient) GetBlockNumber(blockTag string) (uint64, error) {
+	block := struct {
+		Number string `json:"number"`
+	}{}
+	if err := ec.call("eth_getBlockByNumber", &block, blockTag, false); err != nil {
+		return 0, err
+	}
+	number, err := parseHexBig(block.Number)
+	if err != nil {
+		return 0, err
+	}
+	return number.Uint64(), nil
+}
+
+// CallContract executes a read-only call (eth_call) against a contract at the given block tag and returns the raw return data
+func (ec *ExecutionClient) CallContract(to []byte, data []byte, blockTag string) ([]byte, error) {
+	callArgs := map[string]string{
+		"to":   fmt.Sprintf("0x%x", to),
+		"data": fmt.Sprintf("0x%x", data),
+	}
+	var result string
+	if err := ec.call("eth_call", &result, callArgs, blockTag); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimPrefix(result, "0x"))
+}
@@ -0,0 +1,109 @@
+package fixtures
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+)
+
+const (
+	metaFile     = "meta.json"
+	requestsFile = "requests.jsonl"
+	eventsFile   = "events.jsonl"
+)
+
+var sessionStartOnce sync.Once
+var sessionStart time.Time
+
+// getSessionStart returns the shared reference time for all recorders & replayers of this process,
+// so timing offsets of multiple endpoints stay aligned
+func getSessionStart() time.Time {
+	sessionStartOnce.Do(func() {
+		sessionStart = time.Now()
+	})
+	return sessionStart
+}
+
+// FixtureMeta describes a recorded fixture set
+type FixtureMeta struct {
+	Endpoint   string `json:"endpoint"`
+	RecordedAt int64  `json:"recorded_at"`
+}
+
+// FixtureResponse is a single recorded API response
+type FixtureResponse struct {
+	Offset      int64  `json:"offset_ms"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Accept      string `json:"accept"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// FixtureEvent is a single recorded event stream message
+type FixtureEvent struct {
+	Offset int64  `json:"offset_ms"`
+	Event  string `json:"event"`
+	Data   string `json:"data"`
+}
+
+type fixtureWriter struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+func newFixtureWriter(dir string, name string) (*fixtureWriter, error) {
+	file, err := os.OpenFile(path.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not open fixture file %v: %w", name, err)
+	}
+	return &fixtureWriter{
+		file: file,
+	}, nil
+}
+
+func (writer *fixtureWriter) write(entry interface{}) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	_, err = writer.file.Write(append(data, '\n'))
+	return err
+}
+
+func (writer *fixtureWriter) close() error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	return writer.file.Close()
+}
+
+func readFixtureLines(dir string, name string, lineFn func(line []byte) error) error {
+	file, err := os.Open(path.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("could not open fixture file %v: %w", name, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 512*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if err := lineFn(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,195 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/donovanhide/eventsource"
+	"github.com/sirupsen/logrus"
+)
+
+// Recorder is a local proxy that forwards all requests to the target endpoint and
+// records the responses & event stream messages to a fixture directory
+type Recorder struct {
+	Local     string
+	Log       *logrus.Entry
+	target    *url.URL
+	dir       string
+	startTime time.Time
+	requests  *fixtureWriter
+	events    *fixtureWriter
+	server    *http.Server
+}
+
+func NewRecorder(target string, dir string) (*Recorder, error) {
+	targetUrl, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target url: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create fixture directory: %w", err)
+	}
+
+	recorder := &Recorder{
+		target:    targetUrl,
+		dir:       dir,
+		startTime: getSessionStart(),
+	}
+
+	metaJson, err := json.Marshal(&FixtureMeta{
+		Endpoint:   targetUrl.Redacted(),
+		RecordedAt: recorder.startTime.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path.Join(dir, metaFile), metaJson, 0644); err != nil {
+		return nil, fmt.Errorf("could not write fixture meta: %w", err)
+	}
+
+	recorder.requests, err = newFixtureWriter(dir, requestsFile)
+	if err != nil {
+		return nil, err
+	}
+	recorder.events, err = newFixtureWriter(dir, eventsFile)
+	if err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+func (recorder *Recorder) Start() error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	recorder.Local = listener.Addr().String()
+	recorder.server = &http.Server{
+		Handler: recorder,
+	}
+	go func() {
+		err := recorder.server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed && recorder.Log != nil {
+			recorder.Log.Errorf("fixture recorder stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (recorder *Recorder) Close() {
+	if recorder.server != nil {
+		recorder.server.Close()
+	}
+	recorder.requests.close()
+	recorder.events.close()
+}
+
+func (recorder *Recorder) offset() int64 {
+	return time.Since(recorder.startTime).Milliseconds()
+}
+
+func (recorder *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	targetUrl := *recorder.target
+	targetUrl.Path = strings.TrimRight(targetUrl.Path, "/") + r.URL.Path
+	targetUrl.RawQuery = r.URL.RawQuery
+
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, targetUrl.String(), r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	req.Header = r.Header.Clone()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+
+	if r.URL.Path == "/eth/v1/events" && resp.StatusCode == http.StatusOK {
+		recorder.proxyEventStream(w, resp.Body)
+		return
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if recorder.Log != nil {
+			recorder.Log.Warnf("fixture recorder failed reading response for %v: %v", r.URL.Path, err)
+		}
+		return
+	}
+	w.Write(body)
+
+	err = recorder.requests.write(&FixtureResponse{
+		Offset:      recorder.offset(),
+		Method:      r.Method,
+		Path:        r.URL.RequestURI(),
+		Accept:      r.Header.Get("Accept"),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        body,
+	})
+	if err != nil && recorder.Log != nil {
+		recorder.Log.Warnf("fixture recorder failed writing response: %v", err)
+	}
+}
+
+func (recorder *Recorder) proxyEventStream(w http.ResponseWriter, body io.Reader) {
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	decoder := eventsource.NewDecoder(body)
+	for {
+		evt, err := decoder.Decode()
+		if err != nil {
+			return
+		}
+
+		if err := writeEvent(w, evt.Event(), evt.Data()); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		err = recorder.events.write(&FixtureEvent{
+			Offset: recorder.offset(),
+			Event:  evt.Event(),
+			Data:   evt.Data(),
+		})
+		if err != nil && recorder.Log != nil {
+			recorder.Log.Warnf("fixture recorder failed writing event: %v", err)
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event string, data string) error {
+	var msg strings.Builder
+	if event != "" {
+		fmt.Fprintf(&msg, "event: %v\n", event)
+	}
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(&msg, "data: %v\n", line)
+	}
+	msg.WriteString("\n")
+	_, err := io.WriteString(w, msg.String())
+	return err
+}
@@ -0,0 +1,254 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Replayer is a local beacon api mock that serves recorded responses & event stream messages from
+// a fixture directory. Responses are replayed in the recorded request sequence, events relative to the
+// replayer start. The genesis time is shifted by the same amount, so the slot clock matches the recorded chain.
+type Replayer struct {
+	Local      string
+	Log        *logrus.Entry
+	meta       *FixtureMeta
+	startTime  time.Time
+	timeShift  int64
+	genesis    *FixtureResponse
+	responses  map[string][]*FixtureResponse
+	callMutex  sync.Mutex
+	callCounts map[string]int
+	events     []*FixtureEvent
+	server     *http.Server
+}
+
+func NewReplayer(dir string) (*Replayer, error) {
+	metaJson, err := os.ReadFile(path.Join(dir, metaFile))
+	if err != nil {
+		return nil, fmt.Errorf("could not read fixture meta: %w", err)
+	}
+	meta := &FixtureMeta{}
+	if err := json.Unmarshal(metaJson, meta); err != nil {
+		return nil, fmt.Errorf("could not parse fixture meta: %w", err)
+	}
+
+	replayer := &Replayer{
+		meta:       meta,
+		startTime:  getSessionStart(),
+		responses:  map[string][]*FixtureResponse{},
+		callCounts: map[string]int{},
+		events:     []*FixtureEvent{},
+	}
+	replayer.timeShift = replayer.startTime.Unix() - meta.RecordedAt
+
+	err = readFixtureLines(dir, requestsFile, func(line []byte) error {
+		response := &FixtureResponse{}
+		if err := json.Unmarshal(line, response); err != nil {
+			return fmt.Errorf("could not parse fixture response: %w", err)
+		}
+		// the requests file is written in recording order, which is kept as replay sequence
+		key := getResponseKey(response.Method, response.Path, response.Accept)
+		replayer.responses[key] = append(replayer.responses[key], response)
+		if replayer.genesis == nil && response.Method == "GET" && response.Path == "/eth/v1/beacon/genesis" && response.Status == http.StatusOK {
+			replayer.genesis = response
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readFixtureLines(dir, eventsFile, func(line []byte) error {
+		event := &FixtureEvent{}
+		if err := json.Unmarshal(line, event); err != nil {
+			return fmt.Errorf("could not parse fixture event: %w", err)
+		}
+		replayer.events = append(replayer.events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(replayer.events, func(a, b int) bool {
+		return replayer.events[a].Offset < replayer.events[b].Offset
+	})
+
+	return replayer, nil
+}
+
+func (replayer *Replayer) Start() error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	replayer.Local = listener.Addr().String()
+	replayer.server = &http.Server{
+		Handler: replayer,
+	}
+	go func() {
+		err := replayer.server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed && replayer.Log != nil {
+			replayer.Log.Errorf("fixture replayer stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (replayer *Replayer) Close() {
+	if replayer.server != nil {
+		replayer.server.Close()
+	}
+}
+
+// GetGenesisTime returns the shifted genesis time from the recorded genesis response
+func (replayer *Replayer) GetGenesisTime() (uint64, error) {
+	if replayer.genesis == nil {
+		return 0, fmt.Errorf("no genesis response recorded")
+	}
+	genesisTime, err := parseGenesisTime(replayer.genesis.Body)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(int64(genesisTime) + replayer.timeShift), nil
+}
+
+func (replayer *Replayer) offset() int64 {
+	return time.Since(replayer.startTime).Milliseconds()
+}
+
+func (replayer *Replayer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/eth/v1/events" {
+		replayer.serveEventStream(w, r)
+		return
+	}
+
+	response := replayer.getResponse(r.Method, r.URL.RequestURI(), r.Header.Get("Accept"))
+	if response == nil {
+		if replayer.Log != nil {
+			replayer.Log.Debugf("fixture replayer: no recorded response for %v %v", r.Method, r.URL.RequestURI())
+		}
+		http.Error(w, `{"code":404,"message":"not found in fixtures"}`, http.StatusNotFound)
+		return
+	}
+
+	body := response.Body
+	if r.URL.Path == "/eth/v1/beacon/genesis" && response.Status == http.StatusOK {
+		body = replayer.shiftGenesisResponse(body)
+	}
+
+	if response.ContentType != "" {
+		w.Header().Set("Content-Type", response.ContentType)
+	}
+	w.WriteHeader(response.Status)
+	w.Write(body)
+}
+
+func getResponseKey(method string, path string, accept string) string {
+	return fmt.Sprintf("%v %v %v", method, path, accept)
+}
+
+// getResponse returns the recorded responses of a request (method, path & accept header) in recording order:
+// the n-th call gets the n-th recorded response, calls beyond the recorded ones get the last response again.
+func (replayer *Replayer) getResponse(method string, path string, accept string) *FixtureResponse {
+	key := getResponseKey(method, path, accept)
+	responses := replayer.responses[key]
+	if len(responses) == 0 {
+		return nil
+	}
+
+	replayer.callMutex.Lock()
+	callIdx := replayer.callCounts[key]
+	replayer.callCounts[key]++
+	replayer.callMutex.Unlock()
+
+	if callIdx >= len(responses) {
+		callIdx = len(responses) - 1
+	}
+	return responses[callIdx]
+}
+
+func (replayer *Replayer) shiftGenesisResponse(body []byte) []byte {
+	genesisRsp := map[string]map[string]interface{}{}
+	if err := json.Unmarshal(body, &genesisRsp); err != nil || genesisRsp["data"] == nil {
+		return body
+	}
+	genesisTime, err := parseGenesisTime(body)
+	if err != nil {
+		return body
+	}
+	genesisRsp["data"]["genesis_time"] = fmt.Sprintf("%v", int64(genesisTime)+replayer.timeShift)
+	shifted, err := json.Marshal(genesisRsp)
+	if err != nil {
+		return body
+	}
+	return shifted
+}
+
+func (replayer *Replayer) serveEventStream(w http.ResponseWriter, r *http.Request) {
+	topics := map[string]bool{}
+	for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		topics[topic] = true
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	// skip events that have been emitted before the stream was opened
+	connectOffset := replayer.offset()
+	eventIdx := sort.Search(len(replayer.events), func(i int) bool {
+		return replayer.events[i].Offset >= connectOffset
+	})
+
+	for ; eventIdx < len(replayer.events); eventIdx++ {
+		event := replayer.events[eventIdx]
+		if !topics[event.Event] {
+			continue
+		}
+		waitTime := time.Duration(event.Offset-replayer.offset()) * time.Millisecond
+		if waitTime > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(waitTime):
+			}
+		}
+		if err := writeEvent(w, event.Event, event.Data); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	// all recorded events replayed, keep the stream open until the client disconnects
+	<-r.Context().Done()
+}
+
+func parseGenesisTime(body []byte) (uint64, error) {
+	genesisRsp := struct {
+		Data struct {
+			GenesisTime string `json:"genesis_time"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &genesisRsp); err != nil {
+		return 0, fmt.Errorf("could not parse genesis response: %w", err)
+	}
+	return strconv.ParseUint(genesisRsp.Data.GenesisTime, 10, 64)
+}
@@ -0,0 +1,217 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+const testGenesisTime = 1600000000
+
+// writeTestFixtures writes a fixture directory with the given responses in recording order
+func writeTestFixtures(t *testing.T, responses []*FixtureResponse) string {
+	dir := t.TempDir()
+	metaJson, err := json.Marshal(&FixtureMeta{
+		Endpoint:   "http://beacon:5052",
+		RecordedAt: getSessionStart().Unix() - 3600,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(dir, metaFile), metaJson, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	writer, err := newFixtureWriter(dir, requestsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, response := range responses {
+		if err := writer.write(response); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.close(); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func startTestReplayer(t *testing.T, dir string) *Replayer {
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("could not load fixtures: %v", err)
+	}
+	if err := replayer.Start(); err != nil {
+		t.Fatalf("could not start replayer: %v", err)
+	}
+	t.Cleanup(replayer.Close)
+	return replayer
+}
+
+func getTestResponse(t *testing.T, endpoint string, path string, accept string) (int, string) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("http://%v%v", endpoint, path), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request %v failed: %v", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func testGenesisResponse() *FixtureResponse {
+	return &FixtureResponse{
+		Method:      "GET",
+		Path:        "/eth/v1/beacon/genesis",
+		Accept:      "application/json",
+		Status:      http.StatusOK,
+		ContentType: "application/json",
+		Body:        []byte(fmt.Sprintf(`{"data":{"genesis_time":"%v"}}`, testGenesisTime)),
+	}
+}
+
+func TestReplayerSequence(t *testing.T) {
+	// the offsets are not in recording order, the replay must follow the request sequence anyway
+	dir := writeTestFixtures(t, []*FixtureResponse{
+		testGenesisResponse(),
+		{Offset: 5000, Method: "GET", Path: "/eth/v1/node/syncing", Accept: "application/json", Status: 200, Body: []byte(`{"head":1}`)},
+		{Offset: 100, Method: "GET", Path: "/eth/v1/node/syncing", Accept: "application/json", Status: 200, Body: []byte(`{"head":2}`)},
+		{Offset: 200, Method: "GET", Path: "/eth/v1/node/syncing", Accept: "application/json", Status: 503, Body: []byte(`{"head":3}`)},
+	})
+	replayer := startTestReplayer(t, dir)
+
+	expected := []struct {
+		status int
+		body   string
+	}{
+		{200, `{"head":1}`},
+		{200, `{"head":2}`},
+		{503, `{"head":3}`},
+		{503, `{"head":3}`}, // the last response is repeated
+	}
+	for idx, expect := range expected {
+		status, body := getTestResponse(t, replayer.Local, "/eth/v1/node/syncing", "application/json")
+		if status != expect.status || body != expect.body {
+			t.Errorf("call %v: unexpected response %v %v", idx, status, body)
+		}
+	}
+}
+
+func TestReplayerAccept(t *testing.T) {
+	dir := writeTestFixtures(t, []*FixtureResponse{
+		testGenesisResponse(),
+		{Method: "GET", Path: "/eth/v2/beacon/blocks/head", Accept: "application/json", Status: 200, Body: []byte(`json`)},
+		{Method: "GET", Path: "/eth/v2/beacon/blocks/head", Accept: "application/octet-stream", Status: 200, Body: []byte(`ssz`)},
+	})
+	replayer := startTestReplayer(t, dir)
+
+	if _, body := getTestResponse(t, replayer.Local, "/eth/v2/beacon/blocks/head", "application/octet-stream"); body != "ssz" {
+		t.Errorf("unexpected ssz response: %v", body)
+	}
+	if _, body := getTestResponse(t, replayer.Local, "/eth/v2/beacon/blocks/head", "application/json"); body != "json" {
+		t.Errorf("unexpected json response: %v", body)
+	}
+
+	// a response recorded for another accept header must not be served
+	if status, _ := getTestResponse(t, replayer.Local, "/eth/v2/beacon/blocks/head", "text/plain"); status != http.StatusNotFound {
+		t.Errorf("unexpected status for an unrecorded accept header: %v", status)
+	}
+}
+
+func TestReplayerGenesisShift(t *testing.T) {
+	dir := writeTestFixtures(t, []*FixtureResponse{
+		testGenesisResponse(),
+	})
+	replayer := startTestReplayer(t, dir)
+
+	genesisTime, err := replayer.GetGenesisTime()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if genesisTime != testGenesisTime+3600 {
+		t.Errorf("unexpected genesis time: %v", genesisTime)
+	}
+
+	_, body := getTestResponse(t, replayer.Local, "/eth/v1/beacon/genesis", "application/json")
+	if body != fmt.Sprintf(`{"data":{"genesis_time":"%v"}}`, genesisTime) {
+		t.Errorf("unexpected genesis response: %v", body)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	callCount := 0
+	callMutex := sync.Mutex{}
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callMutex.Lock()
+		callCount++
+		call := callCount
+		callMutex.Unlock()
+
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"data":{"genesis_time":"%v"}}`, testGenesisTime)
+		case "/eth/v1/beacon/headers/head":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"call":%v}`, call)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer target.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(target.URL, dir)
+	if err != nil {
+		t.Fatalf("could not create recorder: %v", err)
+	}
+	if err := recorder.Start(); err != nil {
+		t.Fatalf("could not start recorder: %v", err)
+	}
+
+	recorded := []string{}
+	getTestResponse(t, recorder.Local, "/eth/v1/beacon/genesis", "application/json")
+	for i := 0; i < 3; i++ {
+		_, body := getTestResponse(t, recorder.Local, "/eth/v1/beacon/headers/head", "application/json")
+		recorded = append(recorded, body)
+	}
+	recorder.Close()
+
+	replayer := startTestReplayer(t, dir)
+	for idx, expected := range recorded {
+		status, body := getTestResponse(t, replayer.Local, "/eth/v1/beacon/headers/head", "application/json")
+		if status != http.StatusOK || body != expected {
+			t.Errorf("call %v: replayed %v %v, recorded %v", idx, status, body, expected)
+		}
+	}
+
+	_, body := getTestResponse(t, replayer.Local, "/eth/v1/beacon/genesis", "application/json")
+	genesisRsp := struct {
+		Data struct {
+			GenesisTime string `json:"genesis_time"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &genesisRsp); err != nil {
+		t.Fatalf("could not parse replayed genesis: %v", err)
+	}
+	if genesisTime, _ := strconv.ParseUint(genesisRsp.Data.GenesisTime, 10, 64); genesisTime < testGenesisTime {
+		t.Errorf("unexpected replayed genesis time: %v", genesisTime)
+	}
+}
@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pk910/dora/utils"
+)
+
+// MevRelayClient loads builder bids & delivered payloads from the data api of a mev-boost relay
+type MevRelayClient struct {
+	name     string
+	endpoint string
+}
+
+// MevBidTrace is a builder bid as returned by the relay data api, the value is in wei
+type MevBidTrace struct {
+	Slot                 uint64 `json:"slot,string"`
+	ParentHash           string `json:"parent_hash"`
+	BlockHash            string `json:"block_hash"`
+	BuilderPubkey        string `json:"builder_pubkey"`
+	ProposerPubkey       string `json:"proposer_pubkey"`
+	ProposerFeeRecipient string `json:"proposer_fee_recipient"`
+	GasLimit             uint64 `json:"gas_limit,string"`
+	GasUsed              uint64 `json:"gas_used,string"`
+	Value                string `json:"value"`
+	BlockNumber          uint64 `json:"block_number,string"`
+	NumTx                uint64 `json:"num_tx,string"`
+}
+
+func NewMevRelayClient(name string, endpoint string) *MevRelayClient {
+	return &MevRelayClient{
+		name:     name,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+	}
+}
+
+func (rc *MevRelayClient) GetName() string {
+	return rc.name
+}
+
+func (rc *MevRelayClient) getBidTraces(path string, slot uint64) ([]*MevBidTrace, error) {
+	requrl := fmt.Sprintf("%s%s?slot=%d", rc.endpoint, path, slot)
+	t0 := time.Now()
+	defer func() {
+		logger.WithField("relay", rc.name).Debugf("relay GET call: %v [%v ms]", utils.GetRedactedUrl(requrl), time.Since(t0).Milliseconds())
+	}()
+
+	client := &http.Client{Timeout: time.Second * 30}
+	resp, err := client.Get(requrl)
+	if err != nil {
+		return nil, fmt.Errorf("error calling relay %v: %v", rc.name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(requrl), data)
+	}
+
+	bidTraces := []*MevBidTrace{}
+	if err := json.NewDecoder(resp.Body).Decode(&bidTraces); err != nil {
+		return nil, fmt.Errorf("error parsing relay response: %v", err)
+	}
+	return bidTraces, nil
+}
+
+// GetDeliveredPayload returns the payload the relay delivered to the proposer of the slot, or nil if there was none
+func (rc *MevRelayClient) GetDeliveredPayload(slot uint64) (*MevBidTrace, error) {
+	bidTraces, err := rc.getBidTraces("/relay/v1/data/bidtraces/proposer_payload_delivered", slot)
+	if err != nil {
+		return nil, err
+	}
+	for _, bidTrace := range bidTraces {
+		if bidTrace.Slot == slot {
+			return bidTrace, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetHighestBid returns the most valuable bid the relay received from builders for the slot, or nil if there was none
+func (rc *MevRelayClient) GetHighestBid(slot uint64) (*MevBidTrace, error) {
+	bidTraces, err := rc.getBidTraces("/relay/v1/data/bidtraces/builder_blocks_received", slot)
+	if err != nil {
+		return nil, err
+	}
+	var highestBid *MevBidTrace
+	var highestValue *big.Int
+	for _, bidTrace := range bidTraces {
+		if bidTrace.Slot != slot {
+			continue
+		}
+		value, ok := new(big.Int).SetString(bidTrace.Value, 10)
+		if !ok {
+			continue
+		}
+		if highestValue == nil || value.Cmp(highestValue) > 0 {
+			highestBid = bidTrace
+			highestValue = value
+		}
+	}
+	return highestBid, nil
+}
+
+// GetValueGwei returns the bid value converted to gwei
+func (bid *MevBidTrace) GetValueGwei() uint64 {
+	value, ok := new(big.Int).SetString(bid.Value, 10)
+	if !ok {
+		return 0
+	}
+	return value.Div(value, big.NewInt(1000000000)).Uint64()
+}
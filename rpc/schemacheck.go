@@ -0,0 +1,396 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pk910/dora/utils"
+)
+
+type SchemaDeviationKind string
+
+const (
+	SchemaDeviationParseError   SchemaDeviationKind = "parse_error"
+	SchemaDeviationTypeMismatch SchemaDeviationKind = "type_mismatch"
+	SchemaDeviationMissingField SchemaDeviationKind = "missing_field"
+	SchemaDeviationUnknownField SchemaDeviationKind = "unknown_field"
+)
+
+const (
+	schemaCaptureLimit      = 25        // malformed responses kept per client
+	schemaPayloadLimit      = 64 * 1024 // max captured payload size
+	schemaMaxDeviations     = 20        // max deviations reported per response
+	schemaEndpointPlacehold = "{id}"
+)
+
+// response envelope fields that are part of the beacon api spec, but not decoded by dora
+var schemaIgnoredEnvelopeFields = map[string]bool{
+	"execution_optimistic":      true,
+	"finalized":                 true,
+	"version":                   true,
+	"execution_payload_blinded": true,
+	"execution_payload_value":   true,
+	"consensus_block_value":     true,
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+type SchemaDeviation struct {
+	Kind   SchemaDeviationKind `json:"kind"`
+	Path   string              `json:"path"`
+	Detail string              `json:"detail"`
+}
+
+type SchemaEndpointStats struct {
+	Endpoint      string                         `json:"endpoint"`
+	Checked       uint64                         `json:"checked"`
+	Malformed     uint64                         `json:"malformed"`
+	Deviations    map[SchemaDeviationKind]uint64 `json:"deviations"`
+	LastMalformed time.Time                      `json:"last_malformed"`
+}
+
+type SchemaCapture struct {
+	Time       time.Time          `json:"time"`
+	Endpoint   string             `json:"endpoint"`
+	Url        string             `json:"url"`
+	Deviations []*SchemaDeviation `json:"deviations"`
+	Payload    string             `json:"payload"`
+	Truncated  bool               `json:"truncated"`
+}
+
+// SchemaStats collects the schema deviations of the json responses of a single endpoint
+type SchemaStats struct {
+	mutex     sync.Mutex
+	basePath  string
+	endpoints map[string]*SchemaEndpointStats
+	captures  []*SchemaCapture
+}
+
+func newSchemaStats(endpoint string) *SchemaStats {
+	stats := &SchemaStats{
+		endpoints: map[string]*SchemaEndpointStats{},
+	}
+	if endpointUrl, err := url.Parse(endpoint); err == nil {
+		stats.basePath = strings.TrimSuffix(endpointUrl.Path, "/")
+	}
+	return stats
+}
+
+// GetSchemaStats returns the schema validation stats of this endpoint
+func (bc *BeaconClient) GetSchemaStats() *SchemaStats {
+	return bc.schema
+}
+
+// GetEndpoints returns a copy of the per endpoint counters, sorted by endpoint path
+func (ss *SchemaStats) GetEndpoints() []*SchemaEndpointStats {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	endpoints := make([]*SchemaEndpointStats, 0, len(ss.endpoints))
+	for _, stats := range ss.endpoints {
+		statsCopy := *stats
+		statsCopy.Deviations = make(map[SchemaDeviationKind]uint64, len(stats.Deviations))
+		for kind, count := range stats.Deviations {
+			statsCopy.Deviations[kind] = count
+		}
+		endpoints = append(endpoints, &statsCopy)
+	}
+	sort.Slice(endpoints, func(a, b int) bool {
+		return endpoints[a].Endpoint < endpoints[b].Endpoint
+	})
+	return endpoints
+}
+
+// GetCaptures returns the captured malformed responses, newest first
+func (ss *SchemaStats) GetCaptures() []*SchemaCapture {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	captures := make([]*SchemaCapture, len(ss.captures))
+	for idx, capture := range ss.captures {
+		captures[len(ss.captures)-idx-1] = capture
+	}
+	return captures
+}
+
+// normalizeEndpoint strips the endpoint base path and replaces slot numbers & roots, so calls can be grouped by api
+func (ss *SchemaStats) normalizeEndpoint(requrl string) string {
+	reqUrl, err := url.Parse(requrl)
+	if err != nil {
+		return "unknown"
+	}
+	segments := strings.Split(strings.TrimPrefix(reqUrl.Path, ss.basePath), "/")
+	for idx, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "0x") || strings.Trim(segment, "0123456789") == "" {
+			segments[idx] = schemaEndpointPlacehold
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// checkResponse validates the response payload against the expected response type.
+// decodeErr is the error returned by the regular decoding, which is classified as parse error or type mismatch.
+func (ss *SchemaStats) checkResponse(requrl string, payload []byte, returnValue interface{}, decodeErr error) {
+	if !utils.Config.BeaconApi.SchemaValidation {
+		return
+	}
+
+	var deviations []*SchemaDeviation
+	var rawValue interface{}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	if err := dec.Decode(&rawValue); err != nil {
+		deviations = append(deviations, &SchemaDeviation{
+			Kind:   SchemaDeviationParseError,
+			Detail: err.Error(),
+		})
+	} else {
+		deviations = checkJsonSchema(rawValue, reflect.TypeOf(returnValue), "", true, deviations)
+
+		// type errors within custom unmarshalers aren't found by the schema walk
+		var typeErr *json.UnmarshalTypeError
+		if len(deviations) == 0 && errors.As(decodeErr, &typeErr) {
+			deviations = append(deviations, &SchemaDeviation{
+				Kind:   SchemaDeviationTypeMismatch,
+				Path:   typeErr.Field,
+				Detail: fmt.Sprintf("expected %v, got %v", typeErr.Type, typeErr.Value),
+			})
+		} else if len(deviations) == 0 && decodeErr != nil {
+			deviations = append(deviations, &SchemaDeviation{
+				Kind:   SchemaDeviationParseError,
+				Detail: decodeErr.Error(),
+			})
+		}
+	}
+
+	endpoint := ss.normalizeEndpoint(requrl)
+	now := time.Now()
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	stats := ss.endpoints[endpoint]
+	if stats == nil {
+		stats = &SchemaEndpointStats{
+			Endpoint:   endpoint,
+			Deviations: map[SchemaDeviationKind]uint64{},
+		}
+		ss.endpoints[endpoint] = stats
+	}
+	stats.Checked++
+	if len(deviations) == 0 {
+		return
+	}
+
+	stats.Malformed++
+	stats.LastMalformed = now
+	for _, deviation := range deviations {
+		stats.Deviations[deviation.Kind]++
+	}
+
+	capture := &SchemaCapture{
+		Time:       now,
+		Endpoint:   endpoint,
+		Url:        utils.GetRedactedUrl(requrl),
+		Deviations: deviations,
+	}
+	if len(payload) > schemaPayloadLimit {
+		capture.Payload = string(payload[:schemaPayloadLimit])
+		capture.Truncated = true
+	} else {
+		capture.Payload = string(payload)
+	}
+	if len(ss.captures) >= schemaCaptureLimit {
+		ss.captures = ss.captures[1:]
+	}
+	ss.captures = append(ss.captures, capture)
+}
+
+// checkJsonSchema compares the generic decoded json value with the go type it gets decoded into.
+// Types with custom json unmarshalers are checked by their unmarshaler only, so they're not inspected here.
+func checkJsonSchema(value interface{}, valueType reflect.Type, path string, envelope bool, deviations []*SchemaDeviation) []*SchemaDeviation {
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+	if value == nil || len(deviations) >= schemaMaxDeviations {
+		return deviations
+	}
+	if valueType == reflect.TypeOf(json.RawMessage{}) || reflect.PointerTo(valueType).Implements(jsonUnmarshalerType) {
+		return deviations
+	}
+
+	mismatch := func(expected string) []*SchemaDeviation {
+		return append(deviations, &SchemaDeviation{
+			Kind:   SchemaDeviationTypeMismatch,
+			Path:   path,
+			Detail: fmt.Sprintf("expected %v, got %v", expected, jsonTypeName(value)),
+		})
+	}
+
+	switch valueType.Kind() {
+	case reflect.Struct:
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return mismatch("object")
+		}
+		knownFields := map[string]bool{}
+		deviations = checkJsonStruct(object, valueType, path, knownFields, deviations)
+		unknownFields := []string{}
+		for key := range object {
+			if !knownFields[key] && !(envelope && schemaIgnoredEnvelopeFields[key]) {
+				unknownFields = append(unknownFields, key)
+			}
+		}
+		sort.Strings(unknownFields)
+		for _, key := range unknownFields {
+			deviations = append(deviations, &SchemaDeviation{
+				Kind: SchemaDeviationUnknownField,
+				Path: joinSchemaPath(path, key),
+			})
+		}
+	case reflect.Slice, reflect.Array:
+		if valueType.Elem().Kind() == reflect.Uint8 {
+			if _, isString := value.(string); !isString {
+				return mismatch("string")
+			}
+			break
+		}
+		array, isArray := value.([]interface{})
+		if !isArray {
+			return mismatch("array")
+		}
+		// report each deviation once per response, not once per array item
+		itemDeviations := []*SchemaDeviation{}
+		for _, item := range array {
+			itemDeviations = checkJsonSchema(item, valueType.Elem(), path+"[]", false, itemDeviations)
+		}
+		seenDeviations := map[string]bool{}
+		for _, deviation := range itemDeviations {
+			deviationKey := fmt.Sprintf("%v:%v", deviation.Kind, deviation.Path)
+			if !seenDeviations[deviationKey] {
+				seenDeviations[deviationKey] = true
+				deviations = append(deviations, deviation)
+			}
+		}
+	case reflect.Map:
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return mismatch("object")
+		}
+		for _, item := range object {
+			deviations = checkJsonSchema(item, valueType.Elem(), path+".*", false, deviations)
+		}
+	case reflect.String:
+		if _, isString := value.(string); !isString {
+			return mismatch("string")
+		}
+	case reflect.Bool:
+		if _, isBool := value.(bool); !isBool {
+			return mismatch("bool")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if _, isNumber := value.(json.Number); !isNumber {
+			return mismatch("number")
+		}
+	}
+	return deviations
+}
+
+// checkJsonStruct checks the fields of a struct type, fields of embedded structs are checked as part of the parent object
+func checkJsonStruct(object map[string]interface{}, structType reflect.Type, path string, knownFields map[string]bool, deviations []*SchemaDeviation) []*SchemaDeviation {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		tagParts := strings.Split(tag, ",")
+		if field.Anonymous && tagParts[0] == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				deviations = checkJsonStruct(object, fieldType, path, knownFields, deviations)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name := tagParts[0]
+		if name == "" {
+			name = field.Name
+		}
+		omitEmpty := false
+		asString := false
+		for _, option := range tagParts[1:] {
+			switch option {
+			case "omitempty":
+				omitEmpty = true
+			case "string":
+				asString = true
+			}
+		}
+		knownFields[name] = true
+
+		fieldPath := joinSchemaPath(path, name)
+		fieldValue, hasField := object[name]
+		switch {
+		case !hasField:
+			if !omitEmpty {
+				deviations = append(deviations, &SchemaDeviation{
+					Kind: SchemaDeviationMissingField,
+					Path: fieldPath,
+				})
+			}
+		case asString:
+			if _, isString := fieldValue.(string); !isString && fieldValue != nil {
+				deviations = append(deviations, &SchemaDeviation{
+					Kind:   SchemaDeviationTypeMismatch,
+					Path:   fieldPath,
+					Detail: fmt.Sprintf("expected quoted value, got %v", jsonTypeName(fieldValue)),
+				})
+			}
+		default:
+			deviations = checkJsonSchema(fieldValue, field.Type, fieldPath, false, deviations)
+		}
+	}
+	return deviations
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
+
+func joinSchemaPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
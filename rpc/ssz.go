@@ -0,0 +1,201 @@
+package rpc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	nethttp "net/http"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/pk910/dora/utils"
+)
+
+const sszContentType = "application/octet-stream"
+
+// validator container size in the beacon state (fixed size ssz container)
+const sszValidatorSize = 121
+
+// errSszUnsupported is returned when the client does not serve the requested object as ssz
+var errSszUnsupported = errors.New("ssz encoding not supported")
+
+// getSsz requests the object ssz encoded and returns the raw response with the consensus version of the object.
+// Unknown objects (404) are returned as nil data without error, like the json api calls do.
+func (bc *BeaconClient) getSsz(ctx context.Context, callType CallType, requrl string) (data []byte, version spec.DataVersion, err error) {
+	logurl := utils.GetRedactedUrl(requrl)
+	t0 := time.Now()
+	defer func() {
+		logger.WithField("client", bc.name).Debugf("RPC GET call (ssz): %v [%v ms]", logurl, time.Since(t0).Milliseconds())
+		if !errors.Is(err, errSszUnsupported) {
+			bc.breaker.reportResult(err)
+		}
+	}()
+
+	ctx, cancel := newCallContext(ctx, callType)
+	defer cancel()
+
+	req, err := nethttp.NewRequestWithContext(ctx, "GET", requrl, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	for headerKey, headerVal := range bc.headers {
+		req.Header.Set(headerKey, headerVal)
+	}
+	req.Header.Set("Accept", sszContentType)
+
+	client := &nethttp.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != nethttp.StatusOK {
+		switch resp.StatusCode {
+		case nethttp.StatusNotFound:
+			return nil, 0, nil
+		case nethttp.StatusNotAcceptable, nethttp.StatusUnsupportedMediaType:
+			return nil, 0, errSszUnsupported
+		}
+		data, _ := io.ReadAll(resp.Body)
+		logger.WithField("client", bc.name).Debugf("RPC Error %v: %v", resp.StatusCode, data)
+		return nil, 0, &ApiError{
+			Url:        logurl,
+			StatusCode: resp.StatusCode,
+			Response:   data,
+		}
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), sszContentType) {
+		// client ignored the accept header and responded with json
+		return nil, 0, errSszUnsupported
+	}
+
+	versionHeader := resp.Header.Get("Eth-Consensus-Version")
+	if versionHeader == "" {
+		// the version is needed to decode the response, so clients without the header are served via json
+		return nil, 0, fmt.Errorf("missing Eth-Consensus-Version header: %w", errSszUnsupported)
+	}
+	if err := version.UnmarshalJSON([]byte(fmt.Sprintf("%q", versionHeader))); err != nil {
+		return nil, 0, fmt.Errorf("error parsing consensus version: %v", err)
+	}
+
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading ssz response: %v", err)
+	}
+	return data, version, nil
+}
+
+// useSsz returns true if objects should be requested ssz encoded from this client
+func (bc *BeaconClient) useSsz() bool {
+	return utils.Config.BeaconApi.SszFetching && bc.capabilities.IsSupported(FeatureSsz)
+}
+
+// handleSszFallback records clients that do not serve ssz, so later requests go to the json api directly
+func (bc *BeaconClient) handleSszFallback(err error) {
+	if errors.Is(err, errSszUnsupported) {
+		bc.capabilities.markUnsupported(FeatureSsz)
+		logger.WithField("client", bc.name).Warnf("client does not support ssz encoded responses, falling back to json")
+	}
+}
+
+func (bc *BeaconClient) getSszBlockBody(ctx context.Context, blockroot []byte) (*spec.VersionedSignedBeaconBlock, error) {
+	data, version, err := bc.getSsz(ctx, CallTypeBlock, fmt.Sprintf("%s/eth/v2/beacon/blocks/0x%x", bc.endpoint, blockroot))
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: version,
+	}
+	switch version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{}
+		err = block.Phase0.UnmarshalSSZ(data)
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{}
+		err = block.Altair.UnmarshalSSZ(data)
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{}
+		err = block.Bellatrix.UnmarshalSSZ(data)
+	case spec.DataVersionCapella:
+		block.Capella = &capella.SignedBeaconBlock{}
+		err = block.Capella.UnmarshalSSZ(data)
+	case spec.DataVersionDeneb:
+		block.Deneb = &deneb.SignedBeaconBlock{}
+		err = block.Deneb.UnmarshalSSZ(data)
+	default:
+		return nil, errSszUnsupported
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %v block: %v", version, err)
+	}
+	return block, nil
+}
+
+// getSszStateValidators loads the beacon state ssz encoded and decodes the validator registry & balances only.
+// all fields in front of the balances list have the same layout in every fork, so the remaining state is skipped.
+func (bc *BeaconClient) getSszStateValidators(ctx context.Context, stateRef string) (map[phase0.ValidatorIndex]*v1.Validator, error) {
+	data, _, err := bc.getSsz(ctx, CallTypeState, fmt.Sprintf("%s/eth/v2/debug/beacon/states/%v", bc.endpoint, stateRef))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		// an unknown state must not be taken for an empty validator set
+		return nil, fmt.Errorf("state %v not found: %w", stateRef, errNotFound)
+	}
+
+	// genesis_time, genesis_validators_root, slot, fork, latest_block_header, block_roots, state_roots,
+	// historical_roots (offset), eth1_data, eth1_data_votes (offset), eth1_deposit_index
+	validatorsPos := 8 + 32 + 8 + 16 + 112 + 2*utils.Config.Chain.Config.SlotsPerHistoricalRoot*32 + 4 + 72 + 4 + 8
+	if uint64(len(data)) < validatorsPos+8 {
+		return nil, fmt.Errorf("invalid ssz state: too short")
+	}
+	slot := binary.LittleEndian.Uint64(data[40:48])
+	validatorsOffset := uint64(binary.LittleEndian.Uint32(data[validatorsPos : validatorsPos+4]))
+	balancesOffset := uint64(binary.LittleEndian.Uint32(data[validatorsPos+4 : validatorsPos+8]))
+	if balancesOffset < validatorsOffset || (balancesOffset-validatorsOffset)%sszValidatorSize != 0 {
+		return nil, fmt.Errorf("invalid ssz state: malformed validator list")
+	}
+	validatorCount := (balancesOffset - validatorsOffset) / sszValidatorSize
+	if uint64(len(data)) < balancesOffset+validatorCount*8 {
+		return nil, fmt.Errorf("invalid ssz state: malformed balance list")
+	}
+
+	epoch := phase0.Epoch(slot / utils.Config.Chain.Config.SlotsPerEpoch)
+	farFutureEpoch := phase0.Epoch(math.MaxUint64)
+	validators := make(map[phase0.ValidatorIndex]*v1.Validator, validatorCount)
+	for idx := uint64(0); idx < validatorCount; idx++ {
+		validator := &phase0.Validator{}
+		validatorPos := validatorsOffset + idx*sszValidatorSize
+		if err := validator.UnmarshalSSZ(data[validatorPos : validatorPos+sszValidatorSize]); err != nil {
+			return nil, fmt.Errorf("error decoding validator %v: %v", idx, err)
+		}
+		balancePos := balancesOffset + idx*8
+		balance := phase0.Gwei(binary.LittleEndian.Uint64(data[balancePos : balancePos+8]))
+		validators[phase0.ValidatorIndex(idx)] = &v1.Validator{
+			Index:     phase0.ValidatorIndex(idx),
+			Balance:   balance,
+			Status:    v1.ValidatorToState(validator, &balance, epoch, farFutureEpoch),
+			Validator: validator,
+		}
+	}
+	return validators, nil
+}
+
+// GetStateSSZ returns the ssz encoded beacon state, regardless of the ssz fetching setting (nil if the state is unknown)
+func (bc *BeaconClient) GetStateSSZ(stateRef string) ([]byte, spec.DataVersion, error) {
+	return bc.getSsz(context.Background(), CallTypeState, fmt.Sprintf("%s/eth/v2/debug/beacon/states/%v", bc.endpoint, stateRef))
+}
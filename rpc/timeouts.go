@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/pk910/dora/utils"
+)
+
+// CallType groups the beacon api calls by their expected response time, so each group can have its own timeout
+type CallType uint8
+
+const (
+	CallTypeDefault CallType = iota
+	CallTypeHeader           // block headers, node status & finality checkpoints
+	CallTypeBlock            // block bodies, blob sidecars & rewards
+	CallTypeDuties           // proposer, committee & sync committee duties
+	CallTypeState            // validator sets & full beacon states
+)
+
+// GetCallTimeout returns the configured timeout for the call type
+func GetCallTimeout(callType CallType) time.Duration {
+	timeouts := &utils.Config.BeaconApi.Timeouts
+	var timeout time.Duration
+	switch callType {
+	case CallTypeHeader:
+		timeout = timeouts.Header
+		if timeout == 0 {
+			timeout = 10 * time.Second
+		}
+	case CallTypeBlock:
+		timeout = timeouts.Block
+		if timeout == 0 {
+			timeout = 30 * time.Second
+		}
+	case CallTypeDuties:
+		timeout = timeouts.Duties
+		if timeout == 0 {
+			timeout = 60 * time.Second
+		}
+	case CallTypeState:
+		timeout = timeouts.State
+		if timeout == 0 {
+			timeout = 5 * time.Minute
+		}
+	}
+	if timeout == 0 {
+		timeout = timeouts.Default
+	}
+	if timeout == 0 {
+		timeout = 60 * time.Second
+	}
+	return timeout
+}
+
+// newCallContext derives the request context from the callers context.
+// the timeout of the call type applies on top of the callers deadline, whichever comes first.
+func newCallContext(ctx context.Context, callType CallType) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithTimeout(ctx, GetCallTimeout(callType))
+}
+
+// getMaxCallTimeout returns the highest timeout of all call types, which is used as fallback timeout of the api library
+func getMaxCallTimeout() time.Duration {
+	maxTimeout := time.Duration(0)
+	for _, callType := range []CallType{CallTypeDefault, CallTypeHeader, CallTypeBlock, CallTypeDuties, CallTypeState} {
+		if timeout := GetCallTimeout(callType); timeout > maxTimeout {
+			maxTimeout = timeout
+		}
+	}
+	return maxTimeout
+}
@@ -0,0 +1,157 @@
+package rpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/dora/utils"
+)
+
+// ValidatorClientApi is a client for the keymanager api & prometheus metrics of a validator client
+type ValidatorClientApi struct {
+	name            string
+	keymanagerUrl   string
+	keymanagerToken string
+	metricsUrl      string
+}
+
+type keymanagerKeystoresResponse struct {
+	Data []struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+	} `json:"data"`
+}
+
+type keymanagerRemoteKeysResponse struct {
+	Data []struct {
+		Pubkey string `json:"pubkey"`
+	} `json:"data"`
+}
+
+func NewValidatorClientApi(name string, keymanagerUrl string, keymanagerToken string, metricsUrl string) *ValidatorClientApi {
+	return &ValidatorClientApi{
+		name:            name,
+		keymanagerUrl:   strings.TrimSuffix(keymanagerUrl, "/"),
+		keymanagerToken: keymanagerToken,
+		metricsUrl:      metricsUrl,
+	}
+}
+
+func (vc *ValidatorClientApi) GetName() string {
+	return vc.name
+}
+
+func (vc *ValidatorClientApi) get(requrl string, withToken bool) (io.ReadCloser, error) {
+	logurl := utils.GetRedactedUrl(requrl)
+	t0 := time.Now()
+	defer func() {
+		logger.WithField("client", vc.name).Debugf("VC GET call: %v [%v ms]", logurl, time.Since(t0).Milliseconds())
+	}()
+
+	req, err := http.NewRequest("GET", requrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	if withToken && vc.keymanagerToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", vc.keymanagerToken))
+	}
+
+	client := &http.Client{Timeout: time.Second * 30}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting %v: %v", logurl, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("url: %v, status: %v, error-response: %s", logurl, resp.StatusCode, data)
+	}
+	return resp.Body, nil
+}
+
+// GetPubkeys returns the pubkeys of all local keystores & remote signer keys managed by the validator client
+func (vc *ValidatorClientApi) GetPubkeys() ([][]byte, error) {
+	if vc.keymanagerUrl == "" {
+		return nil, fmt.Errorf("no keymanager url configured")
+	}
+
+	pubkeys := [][]byte{}
+	body, err := vc.get(vc.keymanagerUrl+"/eth/v1/keystores", true)
+	if err != nil {
+		return nil, err
+	}
+	keystores := &keymanagerKeystoresResponse{}
+	err = json.NewDecoder(body).Decode(keystores)
+	body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing keystores response: %v", err)
+	}
+	for _, keystore := range keystores.Data {
+		pubkeys = append(pubkeys, common.FromHex(keystore.ValidatingPubkey))
+	}
+
+	// remote keys are optional, not all clients support web3signer
+	body, err = vc.get(vc.keymanagerUrl+"/eth/v1/remotekeys", true)
+	if err != nil {
+		logger.WithField("client", vc.name).Debugf("could not load remote keys: %v", err)
+		return pubkeys, nil
+	}
+	remoteKeys := &keymanagerRemoteKeysResponse{}
+	err = json.NewDecoder(body).Decode(remoteKeys)
+	body.Close()
+	if err == nil {
+		for _, remoteKey := range remoteKeys.Data {
+			pubkeys = append(pubkeys, common.FromHex(remoteKey.Pubkey))
+		}
+	}
+	return pubkeys, nil
+}
+
+// GetMetrics reads the given metrics from the prometheus endpoint. Values of all label sets of a metric are summed up.
+func (vc *ValidatorClientApi) GetMetrics(names []string) (map[string]float64, error) {
+	if vc.metricsUrl == "" || len(names) == 0 {
+		return nil, nil
+	}
+	body, err := vc.get(vc.metricsUrl, false)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+	metrics := map[string]float64{}
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		nameEnd := strings.IndexAny(line, "{ ")
+		if nameEnd == -1 || !wanted[line[:nameEnd]] {
+			continue
+		}
+		fields := strings.Fields(line[strings.LastIndex(line, "}")+1:])
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		metrics[line[:nameEnd]] += value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading metrics: %v", err)
+	}
+	return metrics, nil
+}
@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ExecutionWitnessField is the custom field path of the verkle execution witness in the block
+const ExecutionWitnessField = "message.body.execution_payload.execution_witness"
+
+// ExecutionWitness is the verkle execution witness included in the execution payload on stateless devnets
+type ExecutionWitness struct {
+	StateDiff []struct {
+		Stem        string `json:"stem"`
+		SuffixDiffs []struct {
+			Suffix       json.RawMessage `json:"suffix"`
+			CurrentValue *string         `json:"current_value"`
+			NewValue     *string         `json:"new_value"`
+		} `json:"suffix_diffs"`
+	} `json:"state_diff"`
+	VerkleProof json.RawMessage `json:"verkle_proof"`
+}
+
+// ExecutionWitnessStats summarizes the size of an execution witness
+type ExecutionWitnessStats struct {
+	StemCount   uint64
+	KeyCount    uint64 // accessed keys (suffix diffs of all stems)
+	WriteCount  uint64 // accessed keys with a new value
+	ProofSize   uint64 // bytes of the verkle proof
+	WitnessSize uint64 // bytes of the state diff & proof
+}
+
+// ParseExecutionWitness decodes the execution witness and returns its stats
+func ParseExecutionWitness(data json.RawMessage) (*ExecutionWitnessStats, error) {
+	witness := &ExecutionWitness{}
+	if err := json.Unmarshal(data, witness); err != nil {
+		return nil, fmt.Errorf("error decoding execution witness: %v", err)
+	}
+
+	stats := &ExecutionWitnessStats{
+		StemCount: uint64(len(witness.StateDiff)),
+	}
+	for _, stemDiff := range witness.StateDiff {
+		stats.KeyCount += uint64(len(stemDiff.SuffixDiffs))
+		for _, suffixDiff := range stemDiff.SuffixDiffs {
+			if suffixDiff.NewValue != nil {
+				stats.WriteCount++
+			}
+		}
+	}
+
+	// hex encoded fields are counted with their binary size, so the sizes do not depend on the json formatting
+	var proof interface{}
+	if len(witness.VerkleProof) > 0 {
+		if err := json.Unmarshal(witness.VerkleProof, &proof); err != nil {
+			return nil, fmt.Errorf("error decoding verkle proof: %v", err)
+		}
+		stats.ProofSize = getJsonHexSize(proof)
+	}
+	var witnessData interface{}
+	if err := json.Unmarshal(data, &witnessData); err == nil {
+		stats.WitnessSize = getJsonHexSize(witnessData)
+	}
+	return stats, nil
+}
+
+func getJsonHexSize(value interface{}) uint64 {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			return uint64(len(v)-2) / 2
+		}
+	case []interface{}:
+		size := uint64(0)
+		for _, entry := range v {
+			size += getJsonHexSize(entry)
+		}
+		return size
+	case map[string]interface{}:
+		size := uint64(0)
+		for _, entry := range v {
+			size += getJsonHexSize(entry)
+		}
+		return size
+	}
+	return 0
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+const annotationMaxNoteLength = 2000
+
+var (
+	ErrAnnotationInvalid   = errors.New("annotation note must be between 1 and 2000 characters")
+	ErrAnnotationBadTarget = errors.New("reorg annotations need the 32 byte root of the orphaned block")
+)
+
+// AddAnnotation stores an operator note for a slot, epoch or reorg
+func AddAnnotation(annotationType uint8, target uint64, root []byte, author string, note string) (*dbtypes.Annotation, error) {
+	note = strings.TrimSpace(note)
+	if note == "" || len(note) > annotationMaxNoteLength {
+		return nil, ErrAnnotationInvalid
+	}
+	switch annotationType {
+	case dbtypes.AnnotationTypeSlot, dbtypes.AnnotationTypeEpoch:
+		root = nil
+	case dbtypes.AnnotationTypeReorg:
+		if len(root) != 32 {
+			return nil, ErrAnnotationBadTarget
+		}
+	default:
+		return nil, fmt.Errorf("unknown annotation type %v", annotationType)
+	}
+	author = strings.TrimSpace(author)
+	if len(author) > 100 {
+		author = author[:100]
+	}
+
+	now := time.Now()
+	annotation := &dbtypes.Annotation{
+		Id:      uint64(now.UnixNano()),
+		Type:    annotationType,
+		Target:  target,
+		Root:    root,
+		Author:  author,
+		Note:    note,
+		Created: uint64(now.Unix()),
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := db.InsertAnnotation(annotation, tx); err != nil {
+		return nil, fmt.Errorf("error inserting annotation: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing db transaction: %v", err)
+	}
+	return annotation, nil
+}
+
+// DeleteAnnotation removes an operator note
+func DeleteAnnotation(id uint64) error {
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := db.DeleteAnnotation(id, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetSlotAnnotations returns the slot & reorg annotations of the slot range, grouped by slot
+func GetSlotAnnotations(firstSlot uint64, lastSlot uint64) map[uint64][]*dbtypes.Annotation {
+	annotations := map[uint64][]*dbtypes.Annotation{}
+	if !AnnotationsEnabled() {
+		return annotations
+	}
+	for _, annotation := range db.GetAnnotations(firstSlot, lastSlot, dbtypes.AnnotationTypeSlot, dbtypes.AnnotationTypeReorg) {
+		annotations[annotation.Target] = append(annotations[annotation.Target], annotation)
+	}
+	return annotations
+}
+
+// GetEpochAnnotations returns the epoch annotations of the epoch range, grouped by epoch
+func GetEpochAnnotations(firstEpoch uint64, lastEpoch uint64) map[uint64][]*dbtypes.Annotation {
+	annotations := map[uint64][]*dbtypes.Annotation{}
+	if !AnnotationsEnabled() {
+		return annotations
+	}
+	for _, annotation := range db.GetAnnotations(firstEpoch, lastEpoch, dbtypes.AnnotationTypeEpoch) {
+		annotations[annotation.Target] = append(annotations[annotation.Target], annotation)
+	}
+	return annotations
+}
+
+// AnnotationsEnabled returns true if operators are able to add annotations (at least one token configured)
+func AnnotationsEnabled() bool {
+	return len(utils.Config.Frontend.AnnotationTokens) > 0
+}
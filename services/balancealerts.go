@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/utils"
+)
+
+var logger_ba = logrus.StandardLogger().WithField("module", "balance_alerts")
+
+// balance changes above this are deposits or top-ups, not rewards
+const balanceAlertsMaxRewardGwei = 1000000000
+
+// BalanceAlerts compares the balance growth of the watched validators with the median balance growth of all
+// active validators for each epoch. Validators that attest but still earn less than their peers (late or wrong
+// votes, missed sync committee signatures) raise a notification.
+type BalanceAlerts struct {
+	beaconService *BeaconService
+	notifications *Notifications
+	lastEpoch     int64
+	lastBalances  map[phase0.ValidatorIndex]*v1.Validator
+}
+
+// BalanceAlertEvent is the notification payload of a balance deviation alert
+type BalanceAlertEvent struct {
+	Epoch          uint64                   `json:"epoch"`
+	ExpectedReward int64                    `json:"expected_reward"`
+	Validators     []*BalanceAlertValidator `json:"validators"`
+}
+
+// BalanceAlertValidator is a watched validator whose balance growth fell short of the expected reward
+type BalanceAlertValidator struct {
+	Index     uint64  `json:"index"`
+	Name      string  `json:"name"`
+	Reward    int64   `json:"reward"`
+	Deviation float64 `json:"deviation"`
+}
+
+// StartUpdater checks the balances of each newly loaded validator set in the background
+func (ba *BalanceAlerts) StartUpdater() {
+	if utils.Config.Alerts.MaxBalanceDeviation <= 0 {
+		return
+	}
+	ba.lastEpoch = -1
+
+	go func() {
+		defer utils.HandleSubroutinePanic("BalanceAlerts.StartUpdater")
+		slotDuration := time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
+		for {
+			ba.checkBalances()
+			time.Sleep(slotDuration)
+		}
+	}()
+}
+
+// getWatchedValidators returns the configured validators and the validators of all validator clients
+func (ba *BalanceAlerts) getWatchedValidators() map[uint64]bool {
+	watched := map[uint64]bool{}
+	for _, index := range utils.Config.Alerts.WatchedValidators {
+		watched[index] = true
+	}
+	for _, client := range ba.beaconService.GetValidatorClientStatus() {
+		for _, index := range client.Validators {
+			watched[index] = true
+		}
+	}
+	return watched
+}
+
+func (ba *BalanceAlerts) checkBalances() {
+	indexer := ba.beaconService.GetIndexer()
+	validatorSetEpoch := indexer.GetCachedValidatorSetEpoch()
+	if validatorSetEpoch <= ba.lastEpoch {
+		return
+	}
+	validatorSet := indexer.GetCachedValidatorSet()
+	if validatorSet == nil {
+		return
+	}
+
+	lastEpoch := ba.lastEpoch
+	lastBalances := ba.lastBalances
+	ba.lastEpoch = validatorSetEpoch
+	ba.lastBalances = validatorSet
+	if lastBalances == nil || validatorSetEpoch != lastEpoch+1 {
+		// the rewards can only be compared between consecutive epochs
+		return
+	}
+
+	expectedReward, hasExpected := getMedianBalanceReward(lastBalances, validatorSet)
+	if !hasExpected || expectedReward <= 0 {
+		return
+	}
+
+	maxDeviation := utils.Config.Alerts.MaxBalanceDeviation
+	alertValidators := []*BalanceAlertValidator{}
+	for index := range ba.getWatchedValidators() {
+		reward, isValid := getBalanceReward(lastBalances[phase0.ValidatorIndex(index)], validatorSet[phase0.ValidatorIndex(index)])
+		if !isValid {
+			continue
+		}
+		deviation := float64(expectedReward-reward) * 100.0 / float64(expectedReward)
+		if deviation <= maxDeviation {
+			continue
+		}
+		alertValidators = append(alertValidators, &BalanceAlertValidator{
+			Index:     index,
+			Name:      ba.beaconService.GetValidatorName(index),
+			Reward:    reward,
+			Deviation: deviation,
+		})
+	}
+	if len(alertValidators) == 0 {
+		return
+	}
+	sort.Slice(alertValidators, func(a, b int) bool {
+		return alertValidators[a].Index < alertValidators[b].Index
+	})
+
+	validatorStrs := make([]string, len(alertValidators))
+	for idx, validator := range alertValidators {
+		validatorStrs[idx] = fmt.Sprintf("%v (%v gwei, -%.2f%%)", validator.Index, validator.Reward, validator.Deviation)
+	}
+	logger_ba.Debugf("epoch %v: %v watched validators below the expected reward of %v gwei", validatorSetEpoch, len(alertValidators), expectedReward)
+	ba.notifications.Dispatch(&NotificationEvent{
+		Type:    "balance_alert",
+		Message: fmt.Sprintf("epoch %v: balance growth below the expected %v gwei: %v", validatorSetEpoch, expectedReward, strings.Join(validatorStrs, ", ")),
+		Data: &BalanceAlertEvent{
+			Epoch:          uint64(validatorSetEpoch),
+			ExpectedReward: expectedReward,
+			Validators:     alertValidators,
+		},
+	})
+}
+
+// getBalanceReward returns the balance change of an active validator between two epochs.
+// Changes caused by withdrawals, deposits or slashings are not comparable and reported as invalid.
+func getBalanceReward(oldState *v1.Validator, newState *v1.Validator) (int64, bool) {
+	if oldState == nil || newState == nil || oldState.Validator == nil || newState.Validator == nil {
+		return 0, false
+	}
+	if newState.Status != v1.ValidatorStateActiveOngoing || oldState.Status != v1.ValidatorStateActiveOngoing || newState.Validator.Slashed {
+		return 0, false
+	}
+	maxEffectiveBalance := phase0.Gwei(utils.Config.Chain.Config.MaxEffectiveBalance)
+	if oldState.Balance > maxEffectiveBalance && newState.Balance <= maxEffectiveBalance {
+		// withdrawal sweep
+		return 0, false
+	}
+	reward := int64(newState.Balance) - int64(oldState.Balance)
+	if reward > balanceAlertsMaxRewardGwei {
+		return 0, false
+	}
+	return reward, true
+}
+
+// getMedianBalanceReward returns the median balance change of all active validators, which is the reward of a
+// validator with perfect attestations (proposals & sync committee duties only affect a few validators)
+func getMedianBalanceReward(oldSet map[phase0.ValidatorIndex]*v1.Validator, newSet map[phase0.ValidatorIndex]*v1.Validator) (int64, bool) {
+	rewards := make([]int64, 0, len(newSet))
+	for index, newState := range newSet {
+		if reward, isValid := getBalanceReward(oldSet[index], newState); isValid {
+			rewards = append(rewards, reward)
+		}
+	}
+	if len(rewards) == 0 {
+		return 0, false
+	}
+	sort.Slice(rewards, func(a, b int) bool {
+		return rewards[a] < rewards[b]
+	})
+	return rewards[len(rewards)/2], true
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/rpc"
+	"github.com/pk910/dora/utils"
+)
+
+var logger_brc = logrus.StandardLogger().WithField("module", "beacon_root_checks")
+
+// address of the EIP-4788 beacon roots contract, which is deployed at the same address on all networks
+var beaconRootsContractAddress = []byte{0x00, 0x0F, 0x3d, 0xf6, 0xD7, 0x32, 0x80, 0x7E, 0xf1, 0x31, 0x9f, 0xB7, 0xB8, 0xbB, 0x85, 0x22, 0xd0, 0xBe, 0xac, 0x02}
+
+// number of mismatches to keep for the debug endpoint
+const beaconRootMismatchHistory = 100
+
+// BeaconRootChecks queries the EIP-4788 beacon roots contract for the timestamp of each new canonical block and
+// compares the parent beacon root the EL recorded with the parent root of the indexed block.
+// A mismatch means the EL built on a different beacon block than the one the CL chain references.
+type BeaconRootChecks struct {
+	beaconService   *BeaconService
+	notifications   *Notifications
+	executionClient *rpc.ExecutionClient
+	lastSlot        uint64
+	statsMutex      sync.Mutex
+	stats           BeaconRootCheckStats
+}
+
+// BeaconRootCheckStats holds the number of cross-checked blocks since startup & the recent mismatches
+type BeaconRootCheckStats struct {
+	CheckedBlocks uint64                `json:"checked_blocks"`
+	Mismatches    uint64                `json:"mismatches"`
+	Skipped       uint64                `json:"skipped"` // blocks the EL could not be queried for (eg. not synced yet)
+	LastError     string                `json:"last_error,omitempty"`
+	Recent        []*BeaconRootMismatch `json:"recent_mismatches"`
+}
+
+// BeaconRootMismatch is the notification payload of a block whose parent root differs from the EL record
+type BeaconRootMismatch struct {
+	Slot               uint64 `json:"slot"`
+	BlockRoot          []byte `json:"block_root"`
+	ExecutionNumber    uint64 `json:"execution_number"`
+	ExecutionTimestamp uint64 `json:"execution_timestamp"`
+	ParentRoot         []byte `json:"parent_root"`   // parent root of the beacon block
+	ContractRoot       []byte `json:"contract_root"` // parent beacon root returned by the beacon roots contract
+}
+
+// StartUpdater checks the new blocks in the background, if enabled and an execution endpoint is configured
+func (bc *BeaconRootChecks) StartUpdater() {
+	if !utils.Config.Alerts.BeaconRootCheck || utils.Config.ExecutionApi.Endpoint == "" {
+		return
+	}
+	bc.executionClient = rpc.NewExecutionClient(utils.Config.ExecutionApi.Endpoint)
+
+	go func() {
+		defer utils.HandleSubroutinePanic("BeaconRootChecks.StartUpdater")
+		slotDuration := time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
+		bc.lastSlot = bc.beaconService.GetIndexer().GetHighestSlot()
+		for {
+			time.Sleep(slotDuration)
+			bc.checkNewBlocks()
+		}
+	}()
+}
+
+// GetStats returns a copy of the current check counters
+func (bc *BeaconRootChecks) GetStats() *BeaconRootCheckStats {
+	bc.statsMutex.Lock()
+	defer bc.statsMutex.Unlock()
+	stats := bc.stats
+	stats.Recent = make([]*BeaconRootMismatch, len(bc.stats.Recent))
+	copy(stats.Recent, bc.stats.Recent)
+	return &stats
+}
+
+func (bc *BeaconRootChecks) checkNewBlocks() {
+	beaconIndexer := bc.beaconService.GetIndexer()
+	headSlot := beaconIndexer.GetHighestSlot()
+	if headSlot == 0 {
+		return
+	}
+	// the head block is checked a slot later, so the EL had time to import its payload
+	headSlot--
+	_, headRoot := beaconIndexer.GetCanonicalHead()
+
+	for slot := bc.lastSlot + 1; slot <= headSlot; slot++ {
+		for _, block := range beaconIndexer.GetCachedBlocks(slot) {
+			if !block.IsCanonical(beaconIndexer, headRoot) {
+				continue
+			}
+			bc.checkBlock(block)
+		}
+	}
+	if headSlot > bc.lastSlot {
+		bc.lastSlot = headSlot
+	}
+}
+
+func (bc *BeaconRootChecks) checkBlock(block *indexer.CacheBlock) {
+	blockBody := block.GetBlockBody()
+	if blockBody == nil || blockBody.Version < spec.DataVersionDeneb || blockBody.Deneb == nil {
+		// the beacon roots contract is active since deneb (cancun)
+		return
+	}
+	payload := blockBody.Deneb.Message.Body.ExecutionPayload
+	parentRoot := blockBody.Deneb.Message.ParentRoot
+
+	calldata := make([]byte, 32)
+	binary.BigEndian.PutUint64(calldata[24:], payload.Timestamp)
+	contractRoot, err := bc.executionClient.CallContract(beaconRootsContractAddress, calldata, fmt.Sprintf("0x%x", payload.BlockNumber))
+
+	bc.statsMutex.Lock()
+	if err != nil || len(contractRoot) != 32 {
+		if err == nil {
+			err = fmt.Errorf("unexpected return data length %v", len(contractRoot))
+		}
+		bc.stats.Skipped++
+		bc.stats.LastError = err.Error()
+		bc.statsMutex.Unlock()
+		logger_brc.Debugf("error querying beacon root for block %v [0x%x]: %v", block.Slot, block.Root, err)
+		return
+	}
+	bc.stats.CheckedBlocks++
+	if bytes.Equal(contractRoot, parentRoot[:]) {
+		bc.statsMutex.Unlock()
+		return
+	}
+
+	mismatch := &BeaconRootMismatch{
+		Slot:               block.Slot,
+		BlockRoot:          block.Root,
+		ExecutionNumber:    payload.BlockNumber,
+		ExecutionTimestamp: payload.Timestamp,
+		ParentRoot:         parentRoot[:],
+		ContractRoot:       contractRoot,
+	}
+	bc.stats.Mismatches++
+	bc.stats.Recent = append(bc.stats.Recent, mismatch)
+	if len(bc.stats.Recent) > beaconRootMismatchHistory {
+		bc.stats.Recent = bc.stats.Recent[len(bc.stats.Recent)-beaconRootMismatchHistory:]
+	}
+	bc.statsMutex.Unlock()
+
+	logger_brc.Warnf("beacon root mismatch in block %v [0x%x]: parent root 0x%x, EL recorded 0x%x", block.Slot, block.Root, parentRoot[:], contractRoot)
+	bc.notifications.Dispatch(&NotificationEvent{
+		Type:    "beacon_root_mismatch",
+		Message: fmt.Sprintf("parent beacon root recorded on the EL (block %v) differs from the parent of block %v [0x%x]", payload.BlockNumber, block.Slot, block.Root),
+		Data:    mismatch,
+	})
+}
@@ -1,7 +1,11 @@
 package services
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"math"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -22,8 +26,18 @@ import (
 )
 
 type BeaconService struct {
-	indexer        *indexer.Indexer
-	validatorNames *ValidatorNames
+	indexer           *indexer.Indexer
+	validatorNames    *ValidatorNames
+	validatorMetadata *ValidatorMetadata
+	proposerRewards   *ProposerRewards
+	validatorClients  *ValidatorClients
+	endpointStatus    *EndpointStatus
+	notifications     *Notifications
+	federation        *ArchiveFederation
+	blsSpotChecks     *BlsSpotChecks
+	beaconRootChecks  *BeaconRootChecks
+	dbMaintenance     *DbMaintenance
+	rootResolver      *rootResolver
 
 	validatorActivityMutex sync.Mutex
 	validatorActivityStats struct {
@@ -33,7 +47,7 @@ type BeaconService struct {
 	}
 
 	assignmentsCacheMux sync.Mutex
-	assignmentsCache    *lru.Cache[uint64, *rpc.EpochAssignments]
+	assignmentsCache    *lru.Cache[string, *rpc.EpochAssignments] // keyed by epoch & dependent root
 }
 
 var GlobalBeaconService *BeaconService
@@ -53,17 +67,119 @@ func StartBeaconService() error {
 		indexer.AddClient(uint8(idx), &endpoint)
 	}
 
-	validatorNames := &ValidatorNames{}
-	validatorNames.LoadValidatorNames()
+	validatorNames := &ValidatorNames{
+		indexer: indexer,
+	}
+	validatorNames.StartUpdater()
+
+	validatorMetadata := &ValidatorMetadata{
+		indexer: indexer,
+	}
+	validatorMetadata.StartUpdater()
+
+	proposerRewards := &ProposerRewards{
+		indexer: indexer,
+	}
+	proposerRewards.StartUpdater()
+
+	tableStats := &TableStats{}
+	tableStats.StartUpdater()
+
+	dbMaintenance := newDbMaintenance()
+	dbMaintenance.StartUpdater()
+
+	validatorClients := &ValidatorClients{
+		indexer: indexer,
+	}
+	validatorClients.StartUpdater()
+
+	endpointStatus := &EndpointStatus{
+		indexer: indexer,
+	}
+	endpointStatus.StartUpdater()
+
+	assignmentsCacheSize := utils.Config.BeaconApi.AssignmentsCacheSize
+	if assignmentsCacheSize <= 0 {
+		assignmentsCacheSize = 10
+	}
 
 	GlobalBeaconService = &BeaconService{
-		indexer:          indexer,
-		validatorNames:   validatorNames,
-		assignmentsCache: lru.NewCache[uint64, *rpc.EpochAssignments](10),
+		indexer:           indexer,
+		validatorNames:    validatorNames,
+		validatorMetadata: validatorMetadata,
+		proposerRewards:   proposerRewards,
+		validatorClients:  validatorClients,
+		endpointStatus:    endpointStatus,
+		assignmentsCache:  lru.NewCache[string, *rpc.EpochAssignments](assignmentsCacheSize),
+		notifications:     &Notifications{},
+		federation:        newArchiveFederation(),
+		dbMaintenance:     dbMaintenance,
+		rootResolver:      newRootResolver(),
 	}
+
+	epochAlerts := &EpochAlerts{
+		beaconService: GlobalBeaconService,
+		notifications: GlobalBeaconService.notifications,
+	}
+	epochAlerts.StartUpdater()
+
+	balanceAlerts := &BalanceAlerts{
+		beaconService: GlobalBeaconService,
+		notifications: GlobalBeaconService.notifications,
+	}
+	balanceAlerts.StartUpdater()
+
+	GlobalBeaconService.blsSpotChecks = &BlsSpotChecks{
+		beaconService: GlobalBeaconService,
+		notifications: GlobalBeaconService.notifications,
+	}
+	GlobalBeaconService.blsSpotChecks.StartUpdater()
+
+	GlobalBeaconService.beaconRootChecks = &BeaconRootChecks{
+		beaconService: GlobalBeaconService,
+		notifications: GlobalBeaconService.notifications,
+	}
+	GlobalBeaconService.beaconRootChecks.StartUpdater()
+
+	slashingRisks := &SlashingRisks{
+		beaconService: GlobalBeaconService,
+		notifications: GlobalBeaconService.notifications,
+	}
+	slashingRisks.StartUpdater()
+
+	GlobalBeaconService.startChainResetHandler()
 	return nil
 }
 
+// startChainResetHandler drops the service state of the previous chain when the indexer cleared the data of a relaunched chain
+func (bs *BeaconService) startChainResetHandler() {
+	subscription := bs.indexer.SubscribeChainChanges()
+	go func() {
+		defer utils.HandleSubroutinePanic("BeaconService.startChainResetHandler")
+		for event := range subscription.Events {
+			if event.Type != indexer.ChainChangeReset {
+				continue
+			}
+			logrus.Infof("chain reset: reloading validator names & metadata")
+			bs.validatorActivityMutex.Lock()
+			bs.validatorActivityStats.activity = nil
+			bs.validatorActivityMutex.Unlock()
+
+			bs.validatorNames.resetValidatorNames()
+			bs.validatorMetadata.resetValidatorMetadata()
+		}
+	}()
+}
+
+// StopBeaconService releases the resources of the global beaconchain service on shutdown
+func StopBeaconService() {
+	if GlobalBeaconService == nil {
+		return
+	}
+	GlobalBeaconService.validatorNames.StopUpdater()
+	GlobalBeaconService.indexer.Close()
+}
+
 func (bs *BeaconService) GetIndexer() *indexer.Indexer {
 	return bs.indexer
 }
@@ -80,6 +196,54 @@ func (bs *BeaconService) GetValidatorName(index uint64) string {
 	return bs.validatorNames.GetValidatorName(index)
 }
 
+func (bs *BeaconService) GetValidatorNameSource(index uint64) (string, string) {
+	return bs.validatorNames.GetValidatorNameSource(index)
+}
+
+func (bs *BeaconService) GetValidatorIndicesByName(name string) []uint64 {
+	return bs.validatorNames.GetValidatorIndicesByName(name)
+}
+
+func (bs *BeaconService) SubmitValidatorNameClaim(index uint64, name string, signature []byte) error {
+	return bs.validatorNames.SubmitNameClaim(index, name, signature)
+}
+
+func (bs *BeaconService) ReviewValidatorNameClaim(index uint64, name string, approve bool) error {
+	return bs.validatorNames.ReviewNameClaim(index, name, approve)
+}
+
+func (bs *BeaconService) GetValidatorMetadata(index uint64) map[string]string {
+	return bs.validatorMetadata.GetValidatorMetadata(index)
+}
+
+func (bs *BeaconService) GetValidatorMetadataGroups(key string, withValidators bool) []*ValidatorMetadataGroup {
+	return bs.validatorMetadata.GetMetadataGroups(key, withValidators)
+}
+
+func (bs *BeaconService) GetValidatorClientStatus() []*ValidatorClientStatus {
+	return bs.validatorClients.GetClientStatus()
+}
+
+func (bs *BeaconService) GetEndpointStatus(clientIndex uint8) *EndpointStatusEntry {
+	return bs.endpointStatus.GetStatus(clientIndex)
+}
+
+func (bs *BeaconService) GetNotifications() *Notifications {
+	return bs.notifications
+}
+
+func (bs *BeaconService) GetBlsSpotCheckStats() *BlsSpotCheckStats {
+	return bs.blsSpotChecks.GetStats()
+}
+
+func (bs *BeaconService) GetBeaconRootCheckStats() *BeaconRootCheckStats {
+	return bs.beaconRootChecks.GetStats()
+}
+
+func (bs *BeaconService) GetDbMaintenanceStatus() *DbMaintenanceStatus {
+	return bs.dbMaintenance.GetStatus()
+}
+
 func (bs *BeaconService) GetCachedValidatorSet() map[phase0.ValidatorIndex]*v1.Validator {
 	return bs.indexer.GetCachedValidatorSet()
 }
@@ -120,6 +284,10 @@ func (bs *BeaconService) GetSlotDetailsByBlockroot(blockroot []byte) (*CombinedB
 		var err error
 		for retry := 0; retry < 3; retry++ {
 			client := bs.indexer.GetReadyClient(false, blockroot, skipClients)
+			if client == nil {
+				// no node available, archived blocks may still be shown
+				break
+			}
 			header, err = client.GetRpcClient().GetBlockHeaderByBlockroot(blockroot)
 			if header != nil {
 				break
@@ -133,6 +301,9 @@ func (bs *BeaconService) GetSlotDetailsByBlockroot(blockroot []byte) (*CombinedB
 			}
 		}
 		if err != nil || header == nil {
+			if archivedBlock := bs.parseArchivedBlock(db.GetArchivedBlock(blockroot)); archivedBlock != nil {
+				return archivedBlock, nil
+			}
 			return nil, err
 		}
 
@@ -191,6 +362,10 @@ func (bs *BeaconService) GetSlotDetailsBySlot(slot uint64) (*CombinedBlockRespon
 		var err error
 		for retry := 0; retry < 3; retry++ {
 			client := bs.indexer.GetReadyClient(false, nil, skipClients)
+			if client == nil {
+				// no node available, archived blocks may still be shown
+				break
+			}
 			header, err = client.GetRpcClient().GetBlockHeaderBySlot(slot)
 			if header != nil {
 				break
@@ -204,6 +379,9 @@ func (bs *BeaconService) GetSlotDetailsBySlot(slot uint64) (*CombinedBlockRespon
 			}
 		}
 		if err != nil || header == nil {
+			if archivedBlock := bs.parseArchivedBlock(db.GetArchivedBlockBySlot(slot)); archivedBlock != nil {
+				return archivedBlock, nil
+			}
 			return nil, err
 		}
 
@@ -271,9 +449,40 @@ func (bs *BeaconService) GetOrphanedBlock(blockroot []byte) *CombinedBlockRespon
 	}
 }
 
+// parseArchivedBlock decodes a block imported from an archive, these blocks are kept after the network that produced them is gone
+func (bs *BeaconService) parseArchivedBlock(archivedBlock *dbtypes.ArchivedBlock) *CombinedBlockResponse {
+	if archivedBlock == nil {
+		return nil
+	}
+
+	header := &phase0.SignedBeaconBlockHeader{}
+	if archivedBlock.HeaderVer != 1 {
+		logrus.Warnf("failed unmarshal archived block header from db: unknown version")
+		return nil
+	}
+	err := header.UnmarshalSSZ(archivedBlock.HeaderSSZ)
+	if err != nil {
+		logrus.Warnf("failed unmarshal archived block header from db: %v", err)
+		return nil
+	}
+	body, err := indexer.UnmarshalVersionedSignedBeaconBlockSSZ(archivedBlock.BlockVer, archivedBlock.BlockSSZ)
+	if err != nil {
+		logrus.Warnf("Error parsing archived block body from db: %v", err)
+		return nil
+	}
+
+	return &CombinedBlockResponse{
+		Root:     archivedBlock.Root,
+		Header:   header,
+		Block:    body,
+		Orphaned: false,
+	}
+}
+
 func (bs *BeaconService) GetEpochAssignments(epoch uint64) (*rpc.EpochAssignments, error) {
 	finalizedEpoch, _ := bs.GetFinalizedEpoch()
 
+	var dependentRoot []byte
 	if int64(epoch) > finalizedEpoch {
 		epochStats := bs.indexer.GetCachedEpochStats(epoch)
 		if epochStats != nil {
@@ -285,37 +494,72 @@ func (bs *BeaconService) GetEpochAssignments(epoch uint64) (*rpc.EpochAssignment
 				SyncAssignments:     epochStats.GetSyncAssignments(),
 			}
 			return epochAssignments, nil
-		} else {
-			return nil, nil
 		}
-	}
 
-	if utils.Config.BeaconApi.SkipFinalAssignments {
-		return nil, nil
+		// the indexer loads the duties when the epoch starts, upcoming epochs within the lookahead are loaded on demand
+		currentEpoch := utils.TimeToEpoch(time.Now())
+		if currentEpoch < 0 || epoch > uint64(currentEpoch)+utils.Config.BeaconApi.DutiesLookahead {
+			return nil, nil
+		}
+		dependentRoot = bs.getCanonicalDependentRoot(epoch)
+	} else {
+		if utils.Config.BeaconApi.SkipFinalAssignments {
+			return nil, nil
+		}
+		firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+		dependentRoot = db.GetHighestRootBeforeSlot(firstSlot, false)
 	}
 
-	bs.assignmentsCacheMux.Lock()
-	epochAssignments, found := bs.assignmentsCache.Get(epoch)
-	bs.assignmentsCacheMux.Unlock()
-	if found {
-		return epochAssignments, nil
+	if dependentRoot != nil {
+		if epochAssignments := bs.getCachedEpochAssignments(epoch, dependentRoot); epochAssignments != nil {
+			return epochAssignments, nil
+		}
 	}
 
-	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
-	dependentRoot := db.GetHighestRootBeforeSlot(firstSlot, false)
-	var err error
-	epochAssignments, err = bs.indexer.GetRpcClient(true, nil).GetEpochAssignments(epoch, dependentRoot)
+	epochAssignments, err := bs.indexer.GetRpcClient(true, nil).GetEpochAssignments(context.Background(), epoch, dependentRoot)
 	if err != nil {
 		return nil, err
 	}
 
-	bs.assignmentsCacheMux.Lock()
-	bs.assignmentsCache.Add(epoch, epochAssignments)
-	bs.assignmentsCacheMux.Unlock()
+	bs.AddCachedEpochAssignments(epoch, epochAssignments.DependendRoot[:], epochAssignments)
+	if dependentRoot != nil && !bytes.Equal(dependentRoot, epochAssignments.DependendRoot[:]) {
+		// the node reported a different dependent root (eg. skipped slots not known locally), remember the duties for both
+		bs.AddCachedEpochAssignments(epoch, dependentRoot, epochAssignments)
+	}
 
 	return epochAssignments, nil
 }
 
+// AddCachedEpochAssignments caches the duties of the epoch for the given dependent root.
+// Duties only change when the dependent block changes, so a reorg of the dependent block results in a new cache entry.
+func (bs *BeaconService) AddCachedEpochAssignments(epoch uint64, dependentRoot []byte, epochAssignments *rpc.EpochAssignments) {
+	bs.assignmentsCacheMux.Lock()
+	defer bs.assignmentsCacheMux.Unlock()
+	bs.assignmentsCache.Add(fmt.Sprintf("%v-%x", epoch, dependentRoot), epochAssignments)
+}
+
+func (bs *BeaconService) getCachedEpochAssignments(epoch uint64, dependentRoot []byte) *rpc.EpochAssignments {
+	bs.assignmentsCacheMux.Lock()
+	defer bs.assignmentsCacheMux.Unlock()
+	epochAssignments, _ := bs.assignmentsCache.Get(fmt.Sprintf("%v-%x", epoch, dependentRoot))
+	return epochAssignments
+}
+
+// getCanonicalDependentRoot returns the root of the last canonical block before the epoch (nil if unknown)
+func (bs *BeaconService) getCanonicalDependentRoot(epoch uint64) []byte {
+	headSlot, headRoot := bs.indexer.GetCanonicalHead()
+	if headRoot == nil {
+		return nil
+	}
+	if headSlot < epoch*utils.Config.Chain.Config.SlotsPerEpoch {
+		return headRoot
+	}
+	if firstBlock := bs.indexer.GetFirstCachedCanonicalBlock(epoch, headRoot); firstBlock != nil {
+		return firstBlock.GetParentRoot()
+	}
+	return nil
+}
+
 func (bs *BeaconService) GetProposerAssignments(firstEpoch uint64, lastEpoch uint64) (proposerAssignments map[uint64]uint64, synchronizedEpochs map[uint64]bool) {
 	proposerAssignments = make(map[uint64]uint64)
 	synchronizedEpochs = make(map[uint64]bool)
@@ -328,8 +572,7 @@ func (bs *BeaconService) GetProposerAssignments(firstEpoch uint64, lastEpoch uin
 		}
 	}
 
-	finalizedEpoch, _ := bs.GetFinalizedEpoch()
-	idxMinEpoch := finalizedEpoch + 1
+	idxMinEpoch := bs.indexer.GetLowestLiveEpoch()
 	idxHeadEpoch := utils.EpochOfSlot(bs.indexer.GetHighestSlot())
 	if firstEpoch > idxHeadEpoch {
 		firstEpoch = idxHeadEpoch
@@ -375,9 +618,23 @@ func (bs *BeaconService) GetDbEpochs(firstEpoch uint64, limit uint32) []*dbtypes
 	dbIdx := 0
 	dbCnt := len(dbEpochs)
 
-	finalizedEpoch, _ := bs.GetFinalizedEpoch()
+	// epochs before the local db range are loaded from the archive instance
+	archivedEpochs := map[uint64]*dbtypes.Epoch{}
+	if bs.federation != nil {
+		boundaryEpoch := bs.federation.GetBoundaryEpoch()
+		if boundaryEpoch > 0 && int64(firstEpoch)-int64(limit) < int64(boundaryEpoch) {
+			archiveFirstEpoch := firstEpoch
+			if archiveFirstEpoch >= boundaryEpoch {
+				archiveFirstEpoch = boundaryEpoch - 1
+			}
+			for _, archivedEpoch := range bs.federation.GetEpochs(archiveFirstEpoch, limit) {
+				archivedEpochs[archivedEpoch.Epoch] = archivedEpoch
+			}
+		}
+	}
+
 	var idxMinEpoch, idxHeadEpoch uint64
-	idxMinEpoch = uint64(finalizedEpoch + 1)
+	idxMinEpoch = uint64(bs.indexer.GetLowestLiveEpoch())
 	idxHeadEpoch = utils.EpochOfSlot(bs.indexer.GetHighestSlot())
 
 	lastEpoch := int64(firstEpoch) - int64(limit)
@@ -390,6 +647,8 @@ func (bs *BeaconService) GetDbEpochs(firstEpoch uint64, limit uint32) []*dbtypes
 		if dbIdx < dbCnt && dbEpochs[dbIdx].Epoch == epoch {
 			resEpoch = dbEpochs[dbIdx]
 			dbIdx++
+		} else if archivedEpochs[epoch] != nil {
+			resEpoch = archivedEpochs[epoch]
 		}
 		if epoch >= idxMinEpoch && epoch <= idxHeadEpoch {
 			resEpoch = bs.indexer.BuildLiveEpoch(epoch)
@@ -406,12 +665,88 @@ func (bs *BeaconService) GetDbEpochs(firstEpoch uint64, limit uint32) []*dbtypes
 	return resEpochs
 }
 
+// GetDbEpochsByFilter returns the epochs matching the filter, newest first. The unfinalized epochs are built from the
+// indexer cache & filtered locally, older epochs are loaded from the db. Up to pageSize+1 epochs are returned, so the
+// caller can tell whether there is a next page.
+func (bs *BeaconService) GetDbEpochsByFilter(filter *dbtypes.EpochFilter, pageIdx uint64, pageSize uint32) []*dbtypes.Epoch {
+	finalizedEpoch, _ := bs.GetFinalizedEpoch()
+	idxMinEpoch := bs.indexer.GetLowestLiveEpoch()
+	idxHeadEpoch := int64(utils.EpochOfSlot(bs.indexer.GetHighestSlot()))
+	if filter.MaxEpoch != nil && int64(*filter.MaxEpoch) < idxHeadEpoch {
+		idxHeadEpoch = int64(*filter.MaxEpoch)
+	}
+	scanMinEpoch := idxMinEpoch
+	if filter.MinEpoch != nil && int64(*filter.MinEpoch) > scanMinEpoch {
+		scanMinEpoch = int64(*filter.MinEpoch)
+	}
+
+	cachedMatches := make([]*dbtypes.Epoch, 0)
+	for epochIdx := idxHeadEpoch; epochIdx >= scanMinEpoch; epochIdx-- {
+		epoch := uint64(epochIdx)
+		if filter.Finalized == 1 && epochIdx > finalizedEpoch {
+			continue
+		}
+		if filter.Finalized == 2 && epochIdx <= finalizedEpoch {
+			continue
+		}
+		dbEpoch := bs.indexer.BuildLiveEpoch(epoch)
+		if dbEpoch == nil {
+			dbEpoch = db.GetUnfinalizedEpoch(epoch)
+		}
+		if dbEpoch == nil {
+			continue
+		}
+		if filter.WithOrphaned == 0 && dbEpoch.OrphanedCount > 0 {
+			continue
+		}
+		if filter.WithOrphaned == 2 && dbEpoch.OrphanedCount == 0 {
+			continue
+		}
+		if filter.MinParticipation != nil || filter.MaxParticipation != nil {
+			participation := float64(0)
+			if dbEpoch.Eligible > 0 {
+				participation = float64(dbEpoch.VotedTarget) * 100.0 / float64(dbEpoch.Eligible)
+			}
+			if filter.MinParticipation != nil && participation < *filter.MinParticipation {
+				continue
+			}
+			if filter.MaxParticipation != nil && participation > *filter.MaxParticipation {
+				continue
+			}
+		}
+		cachedMatches = append(cachedMatches, dbEpoch)
+	}
+
+	resEpochs := make([]*dbtypes.Epoch, 0)
+	cachedStart := pageIdx * uint64(pageSize)
+	cachedLen := uint64(len(cachedMatches))
+	if cachedStart < cachedLen {
+		cachedEnd := cachedStart + uint64(pageSize) + 1
+		if cachedEnd > cachedLen {
+			cachedEnd = cachedLen
+		}
+		resEpochs = append(resEpochs, cachedMatches[cachedStart:cachedEnd]...)
+	}
+	if len(resEpochs) > int(pageSize) {
+		return resEpochs
+	}
+
+	// load from db
+	var dbOffset uint64
+	if cachedStart > cachedLen {
+		dbOffset = cachedStart - cachedLen
+	}
+	dbLimit := pageSize + 1 - uint32(len(resEpochs))
+	resEpochs = append(resEpochs, db.GetFilteredEpochs(filter, uint64(idxMinEpoch), dbOffset, dbLimit)...)
+
+	return resEpochs
+}
+
 func (bs *BeaconService) GetDbBlocks(firstSlot uint64, limit int32, withOrphaned bool) []*dbtypes.Block {
 	resBlocks := make([]*dbtypes.Block, limit)
 	resIdx := 0
 
-	finalizedEpoch, _ := bs.GetFinalizedEpoch()
-	idxMinSlot := (finalizedEpoch + 1) * int64(utils.Config.Chain.Config.SlotsPerEpoch)
+	idxMinSlot := bs.indexer.GetLowestLiveEpoch() * int64(utils.Config.Chain.Config.SlotsPerEpoch)
 	idxHeadSlot := bs.indexer.GetHighestSlot()
 	if firstSlot > idxHeadSlot {
 		firstSlot = idxHeadSlot
@@ -442,14 +777,36 @@ func (bs *BeaconService) GetDbBlocks(firstSlot uint64, limit int32, withOrphaned
 	}
 
 	if resIdx < int(limit) {
+		var boundarySlot uint64
+		if bs.federation != nil {
+			boundarySlot = bs.federation.GetBoundarySlot()
+		}
 		dbBlocks := db.GetBlocks(slot, uint32(limit-int32(resIdx)), withOrphaned)
 		for _, dbBlock := range dbBlocks {
+			if dbBlock.Slot < boundarySlot {
+				break
+			}
 			resBlocks[resIdx] = dbBlock
 			resIdx++
 			if resIdx >= int(limit) {
 				break
 			}
 		}
+
+		// blocks before the local db range are loaded from the archive instance
+		if resIdx < int(limit) && boundarySlot > 0 {
+			archiveSlot := boundarySlot - 1
+			if slot < archiveSlot {
+				archiveSlot = slot
+			}
+			for _, archivedBlock := range bs.federation.GetBlocks(archiveSlot, uint32(limit-int32(resIdx)), withOrphaned) {
+				resBlocks[resIdx] = archivedBlock
+				resIdx++
+				if resIdx >= int(limit) {
+					break
+				}
+			}
+		}
 	}
 
 	return resBlocks
@@ -458,8 +815,7 @@ func (bs *BeaconService) GetDbBlocks(firstSlot uint64, limit int32, withOrphaned
 func (bs *BeaconService) GetDbBlocksForSlots(firstSlot uint64, slotLimit uint32, withOrphaned bool) []*dbtypes.Block {
 	resBlocks := make([]*dbtypes.Block, 0)
 
-	finalizedEpoch, _ := bs.GetFinalizedEpoch()
-	idxMinSlot := (finalizedEpoch + 1) * int64(utils.Config.Chain.Config.SlotsPerEpoch)
+	idxMinSlot := bs.indexer.GetLowestLiveEpoch() * int64(utils.Config.Chain.Config.SlotsPerEpoch)
 	idxHeadSlot := bs.indexer.GetHighestSlot()
 	if firstSlot > idxHeadSlot {
 		firstSlot = idxHeadSlot
@@ -495,11 +851,30 @@ func (bs *BeaconService) GetDbBlocksForSlots(firstSlot uint64, slotLimit uint32,
 	}
 
 	if slot > lastSlot {
-		dbBlocks := db.GetBlocksForSlots(slot, lastSlot, withOrphaned)
-		if dbBlocks != nil {
-			for idx := 0; idx < len(dbBlocks); idx++ {
-				resBlocks = append(resBlocks, dbBlocks[idx])
+		dbLastSlot := lastSlot
+		var boundarySlot uint64
+		if bs.federation != nil {
+			boundarySlot = bs.federation.GetBoundarySlot()
+			if dbLastSlot < boundarySlot {
+				dbLastSlot = boundarySlot
+			}
+		}
+		if slot >= dbLastSlot {
+			dbBlocks := db.GetBlocksForSlots(slot, dbLastSlot, withOrphaned)
+			if dbBlocks != nil {
+				for idx := 0; idx < len(dbBlocks); idx++ {
+					resBlocks = append(resBlocks, dbBlocks[idx])
+				}
+			}
+		}
+
+		// slots before the local db range are loaded from the archive instance
+		if lastSlot < boundarySlot {
+			archiveSlot := boundarySlot - 1
+			if slot < archiveSlot {
+				archiveSlot = slot
 			}
+			resBlocks = append(resBlocks, bs.federation.GetBlocksForSlots(archiveSlot, lastSlot, withOrphaned)...)
 		}
 	}
 
@@ -513,12 +888,41 @@ type cachedDbBlock struct {
 }
 
 func (bs *BeaconService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx uint64, pageSize uint32) []*dbtypes.AssignedBlock {
+	var graffitiRegex *regexp.Regexp
+	if filter.Graffiti != "" && filter.GraffitiRegex {
+		var err error
+		graffitiRegex, err = regexp.Compile("(?i)" + filter.Graffiti)
+		if err != nil {
+			return []*dbtypes.AssignedBlock{}
+		}
+	}
+	var proposerSet map[uint64]bool
+	if filter.ProposerName != "" {
+		if filter.ProposerIndices == nil {
+			filter.ProposerIndices = bs.validatorNames.GetValidatorIndicesByName(filter.ProposerName)
+		}
+		if len(filter.ProposerIndices) == 0 {
+			return []*dbtypes.AssignedBlock{}
+		}
+		proposerSet = make(map[uint64]bool, len(filter.ProposerIndices))
+		for _, index := range filter.ProposerIndices {
+			proposerSet[index] = true
+		}
+	}
+
 	cachedMatches := make([]cachedDbBlock, 0)
-	finalizedEpoch, _ := bs.GetFinalizedEpoch()
-	idxMinSlot := (finalizedEpoch + 1) * int64(utils.Config.Chain.Config.SlotsPerEpoch)
+	idxMinSlot := bs.indexer.GetLowestLiveEpoch() * int64(utils.Config.Chain.Config.SlotsPerEpoch)
 	idxHeadSlot := bs.indexer.GetHighestSlot()
+	scanHeadSlot := idxHeadSlot
+	if filter.MaxSlot != nil && *filter.MaxSlot < scanHeadSlot {
+		scanHeadSlot = *filter.MaxSlot
+	}
+	scanMinSlot := idxMinSlot
+	if filter.MinSlot != nil && int64(*filter.MinSlot) > scanMinSlot {
+		scanMinSlot = int64(*filter.MinSlot)
+	}
 	proposedMap := map[uint64]bool{}
-	for slotIdx := int64(idxHeadSlot); slotIdx >= int64(idxMinSlot); slotIdx-- {
+	for slotIdx := int64(scanHeadSlot); slotIdx >= scanMinSlot; slotIdx-- {
 		slot := uint64(slotIdx)
 		blocks := bs.indexer.GetCachedBlocks(slot)
 		if blocks != nil {
@@ -540,8 +944,16 @@ func (bs *BeaconService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageId
 
 				if filter.Graffiti != "" {
 					graffitiBytes, _ := block.GetBlockBody().Graffiti()
-					blockGraffiti := string(graffitiBytes[:])
-					if !strings.Contains(blockGraffiti, filter.Graffiti) {
+					blockGraffiti := string(bytes.TrimRight(graffitiBytes[:], "\x00"))
+					if graffitiRegex != nil {
+						if !graffitiRegex.MatchString(blockGraffiti) {
+							continue
+						}
+					} else if filter.GraffitiPrefix {
+						if !strings.HasPrefix(strings.ToLower(blockGraffiti), strings.ToLower(filter.Graffiti)) {
+							continue
+						}
+					} else if !strings.Contains(strings.ToLower(blockGraffiti), strings.ToLower(filter.Graffiti)) {
 						continue
 					}
 				}
@@ -551,11 +963,8 @@ func (bs *BeaconService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageId
 						continue
 					}
 				}
-				if filter.ProposerName != "" {
-					proposerName := bs.validatorNames.GetValidatorName(proposer)
-					if !strings.Contains(proposerName, filter.ProposerName) {
-						continue
-					}
+				if proposerSet != nil && !proposerSet[proposer] {
+					continue
 				}
 
 				cachedMatches = append(cachedMatches, cachedDbBlock{
@@ -582,17 +991,17 @@ func (bs *BeaconService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageId
 				if proposedMap[slot] {
 					continue
 				}
+				if int64(slot) < scanMinSlot || slot > scanHeadSlot {
+					continue
+				}
 
 				if filter.ProposerIndex != nil {
 					if assigned != *filter.ProposerIndex {
 						continue
 					}
 				}
-				if filter.ProposerName != "" {
-					assignedName := bs.validatorNames.GetValidatorName(assigned)
-					if assignedName == "" || !strings.Contains(assignedName, filter.ProposerName) {
-						continue
-					}
+				if proposerSet != nil && !proposerSet[assigned] {
+					continue
 				}
 
 				cachedMatches = append(cachedMatches, cachedDbBlock{
@@ -742,3 +1151,78 @@ func (bs *BeaconService) GetValidatorActivity() (map[uint64]uint8, uint64) {
 	bs.validatorActivityStats.activity = activityMap
 	return activityMap, epochLimit
 }
+
+// GetEpochAggregationStats returns the attestation aggregation redundancy of the epoch, aggregated from the indexer
+// cache for unfinalized epochs and loaded from the db for finalized epochs. Returns nil if the epoch hasn't been indexed.
+func (bs *BeaconService) GetEpochAggregationStats(epoch uint64) *dbtypes.EpochAggregationStats {
+	finalizedEpoch, _ := bs.GetFinalizedEpoch()
+	if int64(epoch) <= finalizedEpoch {
+		return db.GetEpochAggregationStats(epoch)
+	}
+	if epoch > utils.EpochOfSlot(bs.indexer.GetHighestSlot()) {
+		return nil
+	}
+	_, epochVotes := bs.indexer.GetEpochVotes(epoch)
+	if epochVotes == nil || epochVotes.AggregationStats == nil {
+		return nil
+	}
+	return &dbtypes.EpochAggregationStats{
+		Epoch:              epoch,
+		AggregateCount:     epochVotes.AggregationStats.AggregateCount,
+		CommitteeCount:     epochVotes.AggregationStats.CommitteeCount,
+		RedundantCount:     epochVotes.AggregationStats.RedundantCount,
+		VoteCount:          epochVotes.AggregationStats.VoteCount,
+		DuplicateVoteCount: epochVotes.AggregationStats.DuplicateVoteCount,
+	}
+}
+
+// GetSlotCommitteeParticipation returns the per committee participation of the slots in the epoch
+func (bs *BeaconService) GetSlotCommitteeParticipation(epoch uint64) []*dbtypes.SlotCommitteeParticipation {
+	finalizedEpoch, _ := bs.GetFinalizedEpoch()
+	if int64(epoch) <= finalizedEpoch {
+		return db.GetSlotCommitteeParticipation(epoch)
+	}
+	if epoch > utils.EpochOfSlot(bs.indexer.GetHighestSlot()) {
+		return nil
+	}
+	return bs.indexer.GetSlotCommitteeParticipation(epoch)
+}
+
+// GetEpochTargetVotes returns the competing vote targets for all epochs in the range that had split target votes.
+// unfinalized epochs are aggregated from the indexer cache, finalized epochs are loaded from the db.
+func (bs *BeaconService) GetEpochTargetVotes(firstEpoch uint64, lastEpoch uint64) map[uint64][]*dbtypes.EpochTargetVote {
+	targetVotes := map[uint64][]*dbtypes.EpochTargetVote{}
+
+	idxMinEpoch := uint64(bs.indexer.GetLowestLiveEpoch())
+	idxHeadEpoch := utils.EpochOfSlot(bs.indexer.GetHighestSlot())
+
+	if firstEpoch < idxMinEpoch {
+		dbLastEpoch := lastEpoch
+		if dbLastEpoch >= idxMinEpoch {
+			dbLastEpoch = idxMinEpoch - 1
+		}
+		for _, targetVote := range db.GetEpochTargetVotes(firstEpoch, dbLastEpoch) {
+			targetVotes[targetVote.Epoch] = append(targetVotes[targetVote.Epoch], targetVote)
+		}
+	}
+
+	for epochIdx := int64(lastEpoch); epochIdx >= int64(firstEpoch) && epochIdx >= int64(idxMinEpoch); epochIdx-- {
+		epoch := uint64(epochIdx)
+		if epoch > idxHeadEpoch {
+			continue
+		}
+		_, epochVotes := bs.indexer.GetEpochVotes(epoch)
+		if epochVotes == nil || !epochVotes.HasTargetSplit() {
+			continue
+		}
+		for _, targetVote := range epochVotes.GetTargetVotes() {
+			targetVotes[epoch] = append(targetVotes[epoch], &dbtypes.EpochTargetVote{
+				Epoch:      epoch,
+				TargetRoot: targetVote.Root,
+				VoteAmount: targetVote.Amount,
+			})
+		}
+	}
+
+	return targetVotes
+}
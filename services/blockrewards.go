@@ -0,0 +1,65 @@
+package services
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/rpc"
+	"github.com/pk910/dora/utils"
+)
+
+var logger_br = logrus.StandardLogger().WithField("module", "block_rewards")
+
+// loadBlockReward combines the proposer reward and the sync committee rewards of the block.
+// The sync committee rewards are only requested if withSyncRewards is set, the totals come from the block rewards.
+func loadBlockReward(client *rpc.BeaconClient, slot uint64, root []byte, withSyncRewards bool) (*dbtypes.BlockReward, error) {
+	blockRewards, err := client.GetBlockRewards(root)
+	if err != nil {
+		return nil, err
+	}
+	reward := &dbtypes.BlockReward{
+		Slot:              slot,
+		Root:              root,
+		Proposer:          blockRewards.Data.ProposerIndex,
+		Total:             blockRewards.Data.Total,
+		Attestations:      blockRewards.Data.Attestations,
+		SyncAggregate:     blockRewards.Data.SyncAggregate,
+		ProposerSlashings: blockRewards.Data.ProposerSlashings,
+		AttesterSlashings: blockRewards.Data.AttesterSlashings,
+	}
+
+	if withSyncRewards && utils.EpochOfSlot(slot) >= utils.Config.Chain.Config.AltairForkEpoch {
+		syncRewards, err := client.GetSyncCommitteeRewards(root)
+		if err != nil {
+			return nil, err
+		}
+		for _, syncReward := range syncRewards.Data {
+			if syncReward.Reward < 0 {
+				reward.SyncPenalties += uint64(-syncReward.Reward)
+				reward.SyncMissed++
+			} else {
+				reward.SyncRewards += uint64(syncReward.Reward)
+			}
+		}
+	}
+	return reward, nil
+}
+
+// GetBlockReward returns the consensus reward breakdown of the block.
+// Blocks that haven't been processed yet are loaded from a ready client, which works as long as the node still has the parent state.
+func (bs *BeaconService) GetBlockReward(slot uint64, root []byte) *dbtypes.BlockReward {
+	if reward := db.GetBlockReward(root); reward != nil {
+		return reward
+	}
+	client := bs.indexer.GetReadyClient(false, nil, nil)
+	if client == nil {
+		return nil
+	}
+	reward, err := loadBlockReward(client.GetRpcClient(), slot, root, true)
+	if err != nil {
+		logger_br.Debugf("could not load block rewards for slot %v: %v", slot, err)
+		return nil
+	}
+	return reward
+}
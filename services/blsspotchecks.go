@@ -0,0 +1,230 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/utils"
+)
+
+var logger_bls = logrus.StandardLogger().WithField("module", "bls_spot_checks")
+
+// BlsSpotChecks re-verifies the proposer signature, one attestation aggregate and all voluntary exits of randomly
+// sampled new blocks. Beacon nodes should never accept invalid signatures, so a failure points to a consensus bug
+// in the clients that accepted the block, which is raised as notification.
+type BlsSpotChecks struct {
+	beaconService *BeaconService
+	notifications *Notifications
+	lastSlot      uint64
+	statsMutex    sync.Mutex
+	stats         BlsSpotCheckStats
+}
+
+// BlsSpotCheckStats holds the number of verified signatures since startup
+type BlsSpotCheckStats struct {
+	CheckedBlocks       uint64               `json:"checked_blocks"`
+	CheckedAttestations uint64               `json:"checked_attestations"`
+	CheckedExits        uint64               `json:"checked_exits"`
+	Failures            uint64               `json:"failures"`
+	Skipped             uint64               `json:"skipped"` // signatures that couldn't be checked (missing validator or duties)
+	LastFailure         *BlsSpotCheckFailure `json:"last_failure,omitempty"`
+}
+
+// BlsSpotCheckFailure is the notification payload of an invalid signature
+type BlsSpotCheckFailure struct {
+	Slot      uint64 `json:"slot"`
+	BlockRoot []byte `json:"block_root"`
+	Kind      string `json:"kind"`
+	Index     int    `json:"index"`
+	Error     string `json:"error,omitempty"`
+}
+
+// StartUpdater checks the new blocks in the background, if a sample rate is configured
+func (bc *BlsSpotChecks) StartUpdater() {
+	if utils.Config.Alerts.BlsSpotCheckRate <= 0 {
+		return
+	}
+
+	go func() {
+		defer utils.HandleSubroutinePanic("BlsSpotChecks.StartUpdater")
+		slotDuration := time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
+		bc.lastSlot = bc.beaconService.GetIndexer().GetHighestSlot()
+		for {
+			time.Sleep(slotDuration)
+			bc.checkNewBlocks()
+		}
+	}()
+}
+
+// GetStats returns a copy of the current verification counters
+func (bc *BlsSpotChecks) GetStats() *BlsSpotCheckStats {
+	bc.statsMutex.Lock()
+	defer bc.statsMutex.Unlock()
+	stats := bc.stats
+	return &stats
+}
+
+func (bc *BlsSpotChecks) checkNewBlocks() {
+	beaconIndexer := bc.beaconService.GetIndexer()
+	headSlot := beaconIndexer.GetHighestSlot()
+	genesis := beaconIndexer.GetCachedGenesis()
+	validatorSet := beaconIndexer.GetCachedValidatorSet()
+	if genesis == nil || validatorSet == nil {
+		return
+	}
+
+	for slot := bc.lastSlot + 1; slot <= headSlot; slot++ {
+		for _, block := range beaconIndexer.GetCachedBlocks(slot) {
+			if rand.Float64() >= utils.Config.Alerts.BlsSpotCheckRate {
+				continue
+			}
+			bc.checkBlock(block, genesis, validatorSet)
+		}
+	}
+	bc.lastSlot = headSlot
+}
+
+func (bc *BlsSpotChecks) checkBlock(block *indexer.CacheBlock, genesis *v1.Genesis, validatorSet map[phase0.ValidatorIndex]*v1.Validator) {
+	header := block.GetHeader()
+	blockBody := block.GetBlockBody()
+	if header == nil || blockBody == nil {
+		return
+	}
+	epoch := utils.EpochOfSlot(block.Slot)
+
+	// proposer signature
+	proposer := validatorSet[header.Message.ProposerIndex]
+	if proposer == nil {
+		bc.countCheck("skipped")
+	} else {
+		domain, err := utils.ComputeDomain(utils.DomainBeaconProposer, utils.GetForkVersionAtEpoch(epoch), genesis.GenesisValidatorsRoot)
+		valid, err := bc.verifySignature(err, domain, phase0.Root(block.Root), [][]byte{proposer.Validator.PublicKey[:]}, header.Signature[:])
+		bc.countCheck("block")
+		bc.processResult(block, "block", 0, valid, err)
+	}
+
+	// one random attestation aggregate
+	attestations, _ := blockBody.Attestations()
+	if len(attestations) > 0 {
+		attIndex := rand.Intn(len(attestations))
+		bc.checkAttestation(block, attIndex, attestations[attIndex], genesis, validatorSet)
+	}
+
+	// all voluntary exits, which are always signed with the capella fork version since deneb (EIP-7044)
+	exits, _ := blockBody.VoluntaryExits()
+	for exitIndex, exit := range exits {
+		validator := validatorSet[exit.Message.ValidatorIndex]
+		if validator == nil {
+			bc.countCheck("skipped")
+			continue
+		}
+		forkVersion := utils.GetForkVersionAtEpoch(uint64(exit.Message.Epoch))
+		if epoch >= utils.Config.Chain.Config.DenebForkEpoch {
+			forkVersion = utils.GetForkVersionAtEpoch(utils.Config.Chain.Config.CappellaForkEpoch)
+		}
+		domain, err := utils.ComputeDomain(utils.DomainVoluntaryExit, forkVersion, genesis.GenesisValidatorsRoot)
+		var objectRoot phase0.Root
+		if err == nil {
+			objectRoot, err = exit.Message.HashTreeRoot()
+		}
+		valid, err := bc.verifySignature(err, domain, objectRoot, [][]byte{validator.Validator.PublicKey[:]}, exit.Signature[:])
+		bc.countCheck("exit")
+		bc.processResult(block, "exit", exitIndex, valid, err)
+	}
+}
+
+func (bc *BlsSpotChecks) checkAttestation(block *indexer.CacheBlock, attIndex int, attestation *phase0.Attestation, genesis *v1.Genesis, validatorSet map[phase0.ValidatorIndex]*v1.Validator) {
+	attEpoch := utils.EpochOfSlot(uint64(attestation.Data.Slot))
+	assignments, err := bc.beaconService.GetEpochAssignments(attEpoch)
+	if err != nil || assignments == nil {
+		bc.countCheck("skipped")
+		return
+	}
+	committee := assignments.AttestorAssignments[fmt.Sprintf("%v-%v", uint64(attestation.Data.Slot), uint64(attestation.Data.Index))]
+	if committee == nil {
+		bc.countCheck("skipped")
+		return
+	}
+
+	pubkeys := [][]byte{}
+	for bitIdx, validatorIndex := range committee {
+		if uint64(bitIdx) >= attestation.AggregationBits.Len() || !attestation.AggregationBits.BitAt(uint64(bitIdx)) {
+			continue
+		}
+		validator := validatorSet[phase0.ValidatorIndex(validatorIndex)]
+		if validator == nil {
+			bc.countCheck("skipped")
+			return
+		}
+		pubkeys = append(pubkeys, validator.Validator.PublicKey[:])
+	}
+
+	domain, err := utils.ComputeDomain(utils.DomainBeaconAttester, utils.GetForkVersionAtEpoch(uint64(attestation.Data.Target.Epoch)), genesis.GenesisValidatorsRoot)
+	var objectRoot phase0.Root
+	if err == nil {
+		objectRoot, err = attestation.Data.HashTreeRoot()
+	}
+	valid, err := bc.verifySignature(err, domain, objectRoot, pubkeys, attestation.Signature[:])
+	bc.countCheck("attestation")
+	bc.processResult(block, "attestation", attIndex, valid, err)
+}
+
+func (bc *BlsSpotChecks) verifySignature(err error, domain phase0.Domain, objectRoot phase0.Root, pubkeys [][]byte, signature []byte) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+	signingRoot, err := utils.ComputeSigningRoot(objectRoot, domain)
+	if err != nil {
+		return false, err
+	}
+	return utils.VerifyBlsAggregateSignature(pubkeys, signingRoot[:], signature)
+}
+
+func (bc *BlsSpotChecks) countCheck(kind string) {
+	bc.statsMutex.Lock()
+	defer bc.statsMutex.Unlock()
+	switch kind {
+	case "block":
+		bc.stats.CheckedBlocks++
+	case "attestation":
+		bc.stats.CheckedAttestations++
+	case "exit":
+		bc.stats.CheckedExits++
+	case "skipped":
+		bc.stats.Skipped++
+	}
+}
+
+func (bc *BlsSpotChecks) processResult(block *indexer.CacheBlock, kind string, index int, valid bool, err error) {
+	if valid && err == nil {
+		return
+	}
+	failure := &BlsSpotCheckFailure{
+		Slot:      block.Slot,
+		BlockRoot: block.Root,
+		Kind:      kind,
+		Index:     index,
+	}
+	if err != nil {
+		failure.Error = err.Error()
+	}
+	logger_bls.Debugf("invalid %v signature in block %v [0x%x]: %v", kind, block.Slot, block.Root, failure.Error)
+
+	bc.statsMutex.Lock()
+	bc.stats.Failures++
+	bc.stats.LastFailure = failure
+	bc.statsMutex.Unlock()
+
+	bc.notifications.Dispatch(&NotificationEvent{
+		Type:    "bls_verification_failure",
+		Message: fmt.Sprintf("invalid %v signature (index %v) in block %v [0x%x]", kind, index, block.Slot, block.Root),
+		Data:    failure,
+	})
+}
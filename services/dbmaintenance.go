@@ -0,0 +1,239 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/utils"
+)
+
+var logger_dm = logrus.StandardLogger().WithField("module", "db_maintenance")
+
+// DbMaintenance runs the db maintenance tasks when they are due and the current time is within the off-peak window.
+// The last runs are kept in the explorer state, so a restart doesn't trigger the tasks again.
+type DbMaintenance struct {
+	enabled     bool
+	window      string
+	windowStart time.Duration // offset from midnight UTC
+	windowEnd   time.Duration
+	statusMutex sync.Mutex
+	tasks       []*DbMaintenanceTask
+}
+
+// DbMaintenanceTask is the schedule & the outcome of the last run of a maintenance task
+type DbMaintenanceTask struct {
+	Name         string
+	Interval     time.Duration
+	Running      bool
+	LastRun      time.Time
+	LastDuration time.Duration
+	LastError    string
+	run          func() error
+}
+
+// DbMaintenanceStatus is a snapshot of the maintenance schedule for the runtime diagnostics page
+type DbMaintenanceStatus struct {
+	Enabled  bool
+	Window   string
+	InWindow bool
+	Tasks    []DbMaintenanceTask
+}
+
+type dbMaintenanceState struct {
+	LastRuns map[string]int64 `json:"last_runs"`
+}
+
+func newDbMaintenance() *DbMaintenance {
+	config := utils.Config.Database.Maintenance
+	dm := &DbMaintenance{
+		enabled: config.Enabled && !utils.Config.Indexer.DisableIndexWriter && utils.Config.Database.Engine != "memory",
+		window:  config.Window,
+	}
+
+	vacuumInterval := config.VacuumInterval
+	if vacuumInterval == 0 {
+		vacuumInterval = 24 * time.Hour
+	}
+	dm.tasks = append(dm.tasks, &DbMaintenanceTask{
+		Name:     "vacuum",
+		Interval: vacuumInterval,
+		run:      db.VacuumDatabase,
+	})
+	if config.ReindexInterval > 0 {
+		dm.tasks = append(dm.tasks, &DbMaintenanceTask{
+			Name:     "reindex",
+			Interval: config.ReindexInterval,
+			run:      db.ReindexDatabase,
+		})
+	}
+
+	if dm.enabled && dm.window != "" {
+		var err error
+		dm.windowStart, dm.windowEnd, err = parseDbMaintenanceWindow(dm.window)
+		if err != nil {
+			logger_dm.WithError(err).Errorf("invalid maintenance window, db maintenance disabled")
+			dm.enabled = false
+		}
+	}
+	return dm
+}
+
+// parseDbMaintenanceWindow parses a "hh:mm-hh:mm" time range, the window may wrap around midnight
+func parseDbMaintenanceWindow(window string) (time.Duration, time.Duration, error) {
+	parseTime := func(value string) (time.Duration, error) {
+		parts := strings.Split(strings.TrimSpace(value), ":")
+		if len(parts) != 2 {
+			return 0, fmt.Errorf("invalid time %v", value)
+		}
+		hours, err := strconv.ParseUint(parts[0], 10, 8)
+		if err != nil || hours > 24 {
+			return 0, fmt.Errorf("invalid hour in %v", value)
+		}
+		minutes, err := strconv.ParseUint(parts[1], 10, 8)
+		if err != nil || minutes > 59 {
+			return 0, fmt.Errorf("invalid minute in %v", value)
+		}
+		return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+	}
+
+	bounds := strings.Split(window, "-")
+	if len(bounds) != 2 {
+		return 0, 0, fmt.Errorf("expected hh:mm-hh:mm, got %v", window)
+	}
+	start, err := parseTime(bounds[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseTime(bounds[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+func (dm *DbMaintenance) isInWindow(now time.Time) bool {
+	if dm.window == "" {
+		return true
+	}
+	now = now.UTC()
+	offset := now.Sub(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	if dm.windowStart <= dm.windowEnd {
+		return offset >= dm.windowStart && offset < dm.windowEnd
+	}
+	return offset >= dm.windowStart || offset < dm.windowEnd
+}
+
+// StartUpdater checks for due maintenance tasks once per minute
+func (dm *DbMaintenance) StartUpdater() {
+	if !dm.enabled {
+		return
+	}
+
+	state := dbMaintenanceState{}
+	if _, err := db.GetExplorerState("dbmaintenance.state", &state); err == nil {
+		for _, task := range dm.tasks {
+			if lastRun := state.LastRuns[task.Name]; lastRun > 0 {
+				task.LastRun = time.Unix(lastRun, 0)
+			}
+		}
+	}
+
+	go func() {
+		defer utils.HandleSubroutinePanic("DbMaintenance.StartUpdater")
+		for {
+			if dm.isInWindow(time.Now()) {
+				dm.runDueTasks()
+			}
+			time.Sleep(1 * time.Minute)
+		}
+	}()
+}
+
+// runDueTasks runs the tasks one after another, so the db isn't hit by a vacuum & an index rebuild at the same time
+func (dm *DbMaintenance) runDueTasks() {
+	for _, task := range dm.tasks {
+		dm.statusMutex.Lock()
+		due := time.Since(task.LastRun) >= task.Interval
+		if due {
+			task.Running = true
+		}
+		dm.statusMutex.Unlock()
+		if !due {
+			continue
+		}
+
+		logger_dm.Infof("running db maintenance task: %v", task.Name)
+		startTime := time.Now()
+		err := task.run()
+
+		dm.statusMutex.Lock()
+		task.Running = false
+		task.LastRun = startTime
+		task.LastDuration = time.Since(startTime)
+		if err != nil {
+			task.LastError = err.Error()
+		} else {
+			task.LastError = ""
+		}
+		dm.statusMutex.Unlock()
+
+		if err != nil {
+			logger_dm.WithError(err).Warnf("db maintenance task %v failed", task.Name)
+		} else {
+			logger_dm.Infof("db maintenance task %v completed (%v)", task.Name, task.LastDuration.Round(time.Second))
+		}
+		if err := dm.persistState(); err != nil {
+			logger_dm.WithError(err).Warnf("error while saving db maintenance state")
+		}
+	}
+}
+
+func (dm *DbMaintenance) persistState() error {
+	state := &dbMaintenanceState{
+		LastRuns: map[string]int64{},
+	}
+	dm.statusMutex.Lock()
+	for _, task := range dm.tasks {
+		if !task.LastRun.IsZero() {
+			state.LastRuns[task.Name] = task.LastRun.Unix()
+		}
+	}
+	dm.statusMutex.Unlock()
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := db.SetExplorerState("dbmaintenance.state", state, tx); err != nil {
+		return fmt.Errorf("error updating db maintenance state: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+	return nil
+}
+
+// GetStatus returns the schedule and the last run of the maintenance tasks
+func (dm *DbMaintenance) GetStatus() *DbMaintenanceStatus {
+	dm.statusMutex.Lock()
+	defer dm.statusMutex.Unlock()
+
+	status := &DbMaintenanceStatus{
+		Enabled:  dm.enabled,
+		Window:   dm.window,
+		InWindow: dm.isInWindow(time.Now()),
+		Tasks:    make([]DbMaintenanceTask, len(dm.tasks)),
+	}
+	for idx, task := range dm.tasks {
+		status.Tasks[idx] = *task
+	}
+	return status
+}
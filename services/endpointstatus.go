@@ -0,0 +1,95 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/utils"
+)
+
+var logger_es = logrus.StandardLogger().WithField("module", "endpoint_status")
+
+// EndpointStatus polls the node version & head fork of all beacon endpoints, so endpoints that
+// have been upgraded (or missed an upgrade) show up on the clients page without a reconnect.
+type EndpointStatus struct {
+	indexer     *indexer.Indexer
+	statusMutex sync.RWMutex
+	status      map[uint8]*EndpointStatusEntry
+}
+
+// EndpointStatusEntry is the last polled state of a beacon endpoint
+type EndpointStatusEntry struct {
+	LastRefresh time.Time
+	Error       string
+	Version     string
+	ForkVersion phase0.Version
+	ForkEpoch   uint64
+	ForkDigest  phase0.ForkDigest
+	HasFork     bool
+}
+
+// StartUpdater polls the endpoints in the configured refresh interval
+func (es *EndpointStatus) StartUpdater() {
+	es.status = map[uint8]*EndpointStatusEntry{}
+
+	refreshInterval := utils.Config.BeaconApi.EndpointStatusInterval
+	if refreshInterval <= 0 {
+		refreshInterval = 1 * time.Minute
+	}
+	go func() {
+		defer utils.HandleSubroutinePanic("EndpointStatus.StartUpdater")
+		for {
+			es.refreshEndpoints()
+			time.Sleep(refreshInterval)
+		}
+	}()
+}
+
+func (es *EndpointStatus) refreshEndpoints() {
+	genesis := es.indexer.GetCachedGenesis()
+	for _, client := range es.indexer.GetClients() {
+		status := &EndpointStatusEntry{
+			LastRefresh: time.Now(),
+		}
+
+		version, err := client.GetRpcClient().GetNodeVersion()
+		if err == nil {
+			status.Version = version
+			fork, forkErr := client.GetRpcClient().GetStateFork("head")
+			if forkErr == nil && genesis != nil {
+				forkData := &phase0.ForkData{
+					CurrentVersion:        fork.CurrentVersion,
+					GenesisValidatorsRoot: genesis.GenesisValidatorsRoot,
+				}
+				forkRoot, hashErr := forkData.HashTreeRoot()
+				if hashErr == nil {
+					status.ForkVersion = fork.CurrentVersion
+					status.ForkEpoch = uint64(fork.Epoch)
+					copy(status.ForkDigest[:], forkRoot[:4])
+					status.HasFork = true
+				}
+				forkErr = hashErr
+			}
+			err = forkErr
+		}
+		if err != nil {
+			logger_es.WithError(err).Debugf("error refreshing status of endpoint %v", client.GetName())
+			status.Error = err.Error()
+		}
+
+		es.statusMutex.Lock()
+		es.status[client.GetIndex()] = status
+		es.statusMutex.Unlock()
+	}
+}
+
+// GetStatus returns the last polled state of the endpoint or nil if it hasn't been polled yet
+func (es *EndpointStatus) GetStatus(clientIndex uint8) *EndpointStatusEntry {
+	es.statusMutex.RLock()
+	defer es.statusMutex.RUnlock()
+	return es.status[clientIndex]
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var logger_ea = logrus.StandardLogger().WithField("module", "epoch_alerts")
+
+// EpochAlerts checks each completed epoch against the configured alert thresholds and raises a notification
+// for epochs exceeding them.
+type EpochAlerts struct {
+	beaconService *BeaconService
+	notifications *Notifications
+	lastEpoch     int64
+}
+
+// EpochAlertEvent is the notification payload of an epoch alert
+type EpochAlertEvent struct {
+	Epoch  uint64   `json:"epoch"`
+	Alerts []string `json:"alerts"`
+}
+
+// GetEpochAlerts returns the threshold violations of the epoch
+func GetEpochAlerts(epoch *dbtypes.Epoch) []string {
+	alerts := []string{}
+	if epoch == nil {
+		return alerts
+	}
+
+	minParticipation := utils.Config.Alerts.MinParticipation
+	if minParticipation > 0 && epoch.Eligible > 0 {
+		participation := float64(epoch.VotedTarget) * 100.0 / float64(epoch.Eligible)
+		if participation < minParticipation {
+			alerts = append(alerts, fmt.Sprintf("participation %.2f%% below %.2f%%", participation, minParticipation))
+		}
+	}
+
+	if maxMissedSlots := utils.Config.Alerts.MaxMissedSlots; maxMissedSlots != nil {
+		expectedBlocks := utils.Config.Chain.Config.SlotsPerEpoch
+		if epoch.Epoch == 0 {
+			// no block proposal for the genesis slot
+			expectedBlocks--
+		}
+		missedSlots := uint64(0)
+		if uint64(epoch.BlockCount) < expectedBlocks {
+			missedSlots = expectedBlocks - uint64(epoch.BlockCount)
+		}
+		if missedSlots > *maxMissedSlots {
+			alerts = append(alerts, fmt.Sprintf("%v missed slots (max %v)", missedSlots, *maxMissedSlots))
+		}
+	}
+	return alerts
+}
+
+// StartUpdater checks newly completed epochs in the background
+func (ea *EpochAlerts) StartUpdater() {
+	if utils.Config.Alerts.MinParticipation <= 0 && utils.Config.Alerts.MaxMissedSlots == nil {
+		return
+	}
+	ea.lastEpoch = -1
+
+	go func() {
+		defer utils.HandleSubroutinePanic("EpochAlerts.StartUpdater")
+		slotDuration := time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
+		for {
+			ea.checkEpochs()
+			time.Sleep(slotDuration)
+		}
+	}()
+}
+
+func (ea *EpochAlerts) checkEpochs() {
+	// attestations for an epoch can be included until the end of the next epoch
+	headEpoch := int64(utils.EpochOfSlot(ea.beaconService.GetIndexer().GetHighestSlot()))
+	checkEpoch := headEpoch - 2
+	if checkEpoch < 0 {
+		return
+	}
+	if ea.lastEpoch < 0 || ea.lastEpoch < checkEpoch-10 {
+		// don't raise alerts for old epochs on startup
+		ea.lastEpoch = checkEpoch - 1
+	}
+
+	for epoch := ea.lastEpoch + 1; epoch <= checkEpoch; epoch++ {
+		dbEpochs := ea.beaconService.GetDbEpochs(uint64(epoch), 1)
+		if len(dbEpochs) == 0 || dbEpochs[0] == nil {
+			logger_ea.Debugf("epoch %v not available yet", epoch)
+			return
+		}
+		ea.lastEpoch = epoch
+
+		alerts := GetEpochAlerts(dbEpochs[0])
+		if len(alerts) == 0 {
+			continue
+		}
+		ea.notifications.Dispatch(&NotificationEvent{
+			Type:    "epoch_alert",
+			Message: fmt.Sprintf("epoch %v: %v", epoch, strings.Join(alerts, ", ")),
+			Data: &EpochAlertEvent{
+				Epoch:  uint64(epoch),
+				Alerts: alerts,
+			},
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+var logger_af = logrus.StandardLogger().WithField("module", "archive_federation")
+
+// ArchiveFederation loads the finalized epochs & blocks a lightweight instance does not have in its db
+// from a remote dora instance that holds the full history (/api/archive endpoints).
+type ArchiveFederation struct {
+	endpoint string
+
+	boundaryMutex sync.Mutex
+	boundaryEpoch uint64
+	boundaryTime  time.Time
+
+	// finalized data does not change, so the responses are cached by url
+	cacheMutex sync.Mutex
+	cache      *lru.Cache[string, []byte]
+}
+
+func newArchiveFederation() *ArchiveFederation {
+	if utils.Config.Federation.ArchiveUrl == "" {
+		return nil
+	}
+	return &ArchiveFederation{
+		endpoint: strings.TrimSuffix(utils.Config.Federation.ArchiveUrl, "/"),
+		cache:    lru.NewCache[string, []byte](100),
+	}
+}
+
+// GetBoundaryEpoch returns the first epoch that is served from the local db, all epochs before are loaded from the archive
+func (af *ArchiveFederation) GetBoundaryEpoch() uint64 {
+	if utils.Config.Federation.ArchiveBelowEpoch > 0 {
+		return utils.Config.Federation.ArchiveBelowEpoch
+	}
+
+	af.boundaryMutex.Lock()
+	defer af.boundaryMutex.Unlock()
+	if time.Since(af.boundaryTime) > 1*time.Minute {
+		// no local epochs yet, the lowest epoch is unknown
+		af.boundaryEpoch = 0
+		if lowestEpoch := db.GetLowestEpoch(); lowestEpoch > 0 {
+			af.boundaryEpoch = uint64(lowestEpoch)
+		}
+		af.boundaryTime = time.Now()
+	}
+	return af.boundaryEpoch
+}
+
+// GetBoundarySlot returns the first slot that is served from the local db
+func (af *ArchiveFederation) GetBoundarySlot() uint64 {
+	return af.GetBoundaryEpoch() * utils.Config.Chain.Config.SlotsPerEpoch
+}
+
+// GetEpochs returns the archived epochs, newest first (same as db.GetEpochs)
+func (af *ArchiveFederation) GetEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch {
+	epochs := []*dbtypes.Epoch{}
+	err := af.getJson(fmt.Sprintf("/api/archive/epochs?epoch=%v&limit=%v", firstEpoch, limit), &epochs)
+	if err != nil {
+		logger_af.Errorf("error loading epochs from archive: %v", err)
+		return nil
+	}
+	return epochs
+}
+
+// GetBlocks returns the archived blocks, newest first (same as db.GetBlocks)
+func (af *ArchiveFederation) GetBlocks(firstSlot uint64, limit uint32, withOrphaned bool) []*dbtypes.Block {
+	blocks := []*dbtypes.Block{}
+	err := af.getJson(fmt.Sprintf("/api/archive/blocks?slot=%v&limit=%v&orphaned=%v", firstSlot, limit, withOrphaned), &blocks)
+	if err != nil {
+		logger_af.Errorf("error loading blocks from archive: %v", err)
+		return nil
+	}
+	return blocks
+}
+
+// GetBlocksForSlots returns the archived blocks of the slot range, newest first (same as db.GetBlocksForSlots)
+func (af *ArchiveFederation) GetBlocksForSlots(firstSlot uint64, lastSlot uint64, withOrphaned bool) []*dbtypes.Block {
+	blocks := []*dbtypes.Block{}
+	err := af.getJson(fmt.Sprintf("/api/archive/blocks?slot=%v&last_slot=%v&orphaned=%v", firstSlot, lastSlot, withOrphaned), &blocks)
+	if err != nil {
+		logger_af.Errorf("error loading blocks from archive: %v", err)
+		return nil
+	}
+	return blocks
+}
+
+func (af *ArchiveFederation) getJson(path string, returnValue interface{}) error {
+	requrl := af.endpoint + path
+
+	af.cacheMutex.Lock()
+	data, cached := af.cache.Get(requrl)
+	af.cacheMutex.Unlock()
+
+	if !cached {
+		t0 := time.Now()
+		defer func() {
+			logger_af.Debugf("archive GET call: %v [%v ms]", utils.GetRedactedUrl(requrl), time.Since(t0).Milliseconds())
+		}()
+
+		client := &http.Client{Timeout: time.Second * 30}
+		resp, err := client.Get(requrl)
+		if err != nil {
+			return fmt.Errorf("error calling archive: %v", err)
+		}
+		defer resp.Body.Close()
+		data, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading archive response: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(requrl), data)
+		}
+
+		af.cacheMutex.Lock()
+		af.cache.Add(requrl, data)
+		af.cacheMutex.Unlock()
+	}
+
+	if err := json.Unmarshal(data, returnValue); err != nil {
+		return fmt.Errorf("error parsing archive response: %v", err)
+	}
+	return nil
+}
@@ -21,6 +21,10 @@ type FrontendCacheService struct {
 	tieredCache          *cache.TieredCache
 	processingMutex      sync.Mutex
 	processingDict       map[string]*FrontendCacheProcessingPage
+	revalidatingMutex    sync.Mutex
+	revalidatingDict     map[string]bool
+	pageKeysMutex        sync.Mutex
+	pageKeys             map[string]time.Time
 	callStackMutex       sync.RWMutex
 	callStackBuffer      []byte
 }
@@ -33,10 +37,14 @@ type FrontendCacheProcessingPage struct {
 	CacheTimeout time.Duration
 }
 
+// PageDataHandlerFn builds the page model, returning an error value fails the page call without caching it
 type PageDataHandlerFn = func(pageCall *FrontendCacheProcessingPage) interface{}
 
 var GlobalFrontendCache *FrontendCacheService
 
+// pages read from the remote cache have an unknown timeout, their keys are tracked for the longest page timeout
+const frontendCachePageKeyRetention = 1 * time.Hour
+
 type FrontendCachePageError struct {
 	err   error
 	name  string
@@ -52,6 +60,9 @@ func (e FrontendCachePageError) Name() string {
 func (e FrontendCachePageError) Stack() string {
 	return e.stack
 }
+func (e FrontendCachePageError) Unwrap() error {
+	return e.err
+}
 
 // StartFrontendCache is used to start the global frontend cache service
 func StartFrontendCache() error {
@@ -66,9 +77,14 @@ func StartFrontendCache() error {
 	}
 
 	GlobalFrontendCache = &FrontendCacheService{
-		tieredCache:     tieredCache,
-		processingDict:  make(map[string]*FrontendCacheProcessingPage),
-		callStackBuffer: make([]byte, 1024*1024*5),
+		tieredCache:      tieredCache,
+		processingDict:   make(map[string]*FrontendCacheProcessingPage),
+		revalidatingDict: make(map[string]bool),
+		pageKeys:         make(map[string]time.Time),
+		callStackBuffer:  make([]byte, 1024*1024*5),
+	}
+	if GlobalBeaconService != nil {
+		GlobalFrontendCache.startPageInvalidation(GlobalBeaconService.GetIndexer())
 	}
 	return nil
 }
@@ -116,21 +132,34 @@ func (fc *FrontendCacheService) processPageCall(pageKey string, caching bool, pa
 	go func(callIdx uint64) {
 		defer func() {
 			if err := recover(); err != nil {
+				panicErr, isErr := err.(error)
+				if !isErr {
+					panicErr = fmt.Errorf("%v", err)
+				}
+				utils.CountSubroutineCrash("page", panicErr)
 				errorChan <- &FrontendCachePageError{
 					name:  "page panic",
-					err:   fmt.Errorf("page call %v panic: %v", callIdx, err),
+					err:   fmt.Errorf("page call %v panic: %w", callIdx, panicErr),
 					stack: string(debug.Stack()),
 				}
 			}
 		}()
 
 		// check cache
-		if !utils.Config.Frontend.Debug && caching && fc.getFrontendCache(pageKey, pageData) == nil {
-			logrus.Debugf("page served from cache: %v", pageKey)
-			if !isTimedOut {
-				returnChan <- pageData
+		if !utils.Config.Frontend.Debug && caching {
+			if refreshTime, err := fc.getFrontendCache(pageKey, pageData); err == nil {
+				if !refreshTime.IsZero() && time.Now().After(refreshTime) {
+					// stale page, serve it and rebuild it in background
+					logrus.Debugf("page served stale from cache: %v", pageKey)
+					fc.revalidatePage(pageKey, buildFn)
+				} else {
+					logrus.Debugf("page served from cache: %v", pageKey)
+				}
+				if !isTimedOut {
+					returnChan <- pageData
+				}
+				return
 			}
-			return
 		}
 
 		// process page call
@@ -139,6 +168,11 @@ func (fc *FrontendCacheService) processPageCall(pageKey string, caching bool, pa
 		if isTimedOut {
 			return
 		}
+		if pageErr, isErr := pageData.(error); isErr {
+			// the page builder failed, don't cache the error
+			errorChan <- pageErr
+			return
+		}
 		if !utils.Config.Frontend.Debug && caching && pageCall.CacheTimeout >= 0 {
 			fc.setFrontendCache(pageKey, pageData, pageCall.CacheTimeout)
 		}
@@ -167,15 +201,106 @@ func (fc *FrontendCacheService) processPageCall(pageKey string, caching bool, pa
 	}
 }
 
-func (fc *FrontendCacheService) getFrontendCache(pageKey string, returnValue interface{}) error {
-	_, err := fc.tieredCache.Get(pageKey, returnValue)
-	return err
+// revalidatePage rebuilds a stale page in background, only one rebuild per page runs at a time
+func (fc *FrontendCacheService) revalidatePage(pageKey string, buildFn PageDataHandlerFn) {
+	fc.revalidatingMutex.Lock()
+	if fc.revalidatingDict[pageKey] {
+		fc.revalidatingMutex.Unlock()
+		return
+	}
+	fc.revalidatingDict[pageKey] = true
+	fc.revalidatingMutex.Unlock()
+
+	go func() {
+		defer utils.HandleSubroutinePanic("FrontendCacheService.revalidatePage")
+		defer func() {
+			fc.revalidatingMutex.Lock()
+			delete(fc.revalidatingDict, pageKey)
+			fc.revalidatingMutex.Unlock()
+		}()
+
+		pageCall := &FrontendCacheProcessingPage{
+			PageKey:      pageKey,
+			CacheTimeout: -1,
+		}
+		pageData := buildFn(pageCall)
+		if _, isErr := pageData.(error); isErr {
+			return
+		}
+		if pageCall.CacheTimeout >= 0 {
+			fc.setFrontendCache(pageKey, pageData, pageCall.CacheTimeout)
+		}
+	}()
+}
+
+func (fc *FrontendCacheService) getFrontendCache(pageKey string, returnValue interface{}) (time.Time, error) {
+	_, refreshTime, err := fc.tieredCache.GetWithRefresh(pageKey, returnValue)
+	if err == nil {
+		fc.pageKeysMutex.Lock()
+		if _, tracked := fc.pageKeys[pageKey]; !tracked {
+			fc.pageKeys[pageKey] = time.Now().Add(frontendCachePageKeyRetention)
+		}
+		fc.pageKeysMutex.Unlock()
+	}
+	return refreshTime, err
 }
 
 func (fc *FrontendCacheService) setFrontendCache(pageKey string, value interface{}, timeout time.Duration) error {
+	staleWindow := utils.Config.Frontend.StaleCacheWindow
+	fc.pageKeysMutex.Lock()
+	if timeout > 0 {
+		fc.pageKeys[pageKey] = time.Now().Add(timeout + staleWindow)
+	} else {
+		fc.pageKeys[pageKey] = time.Now().Add(frontendCachePageKeyRetention)
+	}
+	fc.pageKeysMutex.Unlock()
+
+	if staleWindow > 0 && timeout > 0 {
+		// keep the page for the stale window, so it can be served while it's being rebuilt
+		return fc.tieredCache.SetWithRefresh(pageKey, value, timeout, timeout+staleWindow)
+	}
 	return fc.tieredCache.Set(pageKey, value, timeout)
 }
 
+// InvalidatePages removes the given pages from the cache, so they're rebuilt on the next call
+func (fc *FrontendCacheService) InvalidatePages(pageKeys ...string) {
+	fc.pageKeysMutex.Lock()
+	for _, pageKey := range pageKeys {
+		delete(fc.pageKeys, pageKey)
+	}
+	fc.pageKeysMutex.Unlock()
+
+	for _, pageKey := range pageKeys {
+		if err := fc.tieredCache.Delete(pageKey); err != nil {
+			logrus.WithError(err).Warnf("error invalidating cached page %v", pageKey)
+		}
+	}
+}
+
+// InvalidatePagePrefix removes all pages with one of the given key prefixes.
+// Only the pages that have been cached or served by this instance are known, other instances sharing the
+// remote cache invalidate their own pages.
+func (fc *FrontendCacheService) InvalidatePagePrefix(prefixes ...string) {
+	now := time.Now()
+	pageKeys := []string{}
+	fc.pageKeysMutex.Lock()
+	for pageKey, timeout := range fc.pageKeys {
+		if now.After(timeout) {
+			delete(fc.pageKeys, pageKey)
+			continue
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(pageKey, prefix) {
+				pageKeys = append(pageKeys, pageKey)
+				break
+			}
+		}
+	}
+	fc.pageKeysMutex.Unlock()
+
+	fc.InvalidatePages(pageKeys...)
+}
+
 func (fc *FrontendCacheService) completePageLoad(pageKey string, processingPage *FrontendCacheProcessingPage) {
 	processingPage.modelMutex.Unlock()
 	fc.processingMutex.Lock()
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/utils"
+)
+
+// startPageInvalidation evicts the cached pages of the blocks that have been reorged or finalized, so the pages
+// show the new canonical & finalized state immediately instead of after the page timeout.
+// All pages are evicted when the chain has been reset.
+func (fc *FrontendCacheService) startPageInvalidation(beaconIndexer *indexer.Indexer) {
+	subscription := beaconIndexer.SubscribeChainChanges()
+	go func() {
+		defer utils.HandleSubroutinePanic("FrontendCacheService.startPageInvalidation")
+		for event := range subscription.Events {
+			fc.invalidateChainChange(event)
+		}
+	}()
+}
+
+func (fc *FrontendCacheService) invalidateChainChange(event *indexer.ChainChangeEvent) {
+	if event.Type == indexer.ChainChangeReset {
+		logrus.Infof("invalidating all cached pages after chain reset")
+		fc.InvalidatePagePrefix("")
+		return
+	}
+
+	pageKeys := []string{"index"}
+	pagePrefixes := []string{"slots:", "epochs:"}
+	epochs := map[uint64]bool{
+		event.Epoch: true,
+	}
+	proposers := map[uint64]bool{}
+	for _, block := range event.Blocks {
+		pagePrefixes = append(pagePrefixes, fmt.Sprintf("slot:%v:", block.Slot))
+		epochs[utils.EpochOfSlot(block.Slot)] = true
+		proposers[block.Proposer] = true
+	}
+	for epoch := range epochs {
+		pageKeys = append(pageKeys, fmt.Sprintf("epoch:%v:true", epoch), fmt.Sprintf("epoch:%v:false", epoch))
+	}
+	for proposer := range proposers {
+		pageKeys = append(pageKeys, fmt.Sprintf("validator:%v", proposer))
+		pagePrefixes = append(pagePrefixes, fmt.Sprintf("valslots:%v:", proposer))
+	}
+
+	logrus.Debugf("invalidating cached pages after %v (epoch %v, %v blocks)", event.Type, event.Epoch, len(event.Blocks))
+	fc.InvalidatePages(pageKeys...)
+	fc.InvalidatePagePrefix(pagePrefixes...)
+}
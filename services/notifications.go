@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pk910/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var logger_nf = logrus.StandardLogger().WithField("module", "notifications")
+
+const notificationHistorySize = 100
+
+// Notifications dispatches events to the log and all configured webhooks and keeps the latest events in memory
+type Notifications struct {
+	historyMutex sync.RWMutex
+	history      []*NotificationEvent
+}
+
+// NotificationEvent is a single event sent through the notification subsystem
+type NotificationEvent struct {
+	Type    string      `json:"type"`
+	Time    time.Time   `json:"time"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Dispatch records the event and sends it to all webhooks in the background
+func (nf *Notifications) Dispatch(event *NotificationEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	logger_nf.WithField("type", event.Type).Warnf("%v", event.Message)
+
+	nf.historyMutex.Lock()
+	nf.history = append(nf.history, event)
+	if len(nf.history) > notificationHistorySize {
+		nf.history = nf.history[len(nf.history)-notificationHistorySize:]
+	}
+	nf.historyMutex.Unlock()
+
+	for _, webhook := range utils.Config.Notifications.Webhooks {
+		go func(name string, url string, headers map[string]string) {
+			defer utils.HandleSubroutinePanic("Notifications.Dispatch")
+			if err := nf.sendWebhook(url, headers, event); err != nil {
+				logger_nf.WithError(err).Warnf("error sending notification to webhook %v", name)
+			}
+		}(webhook.Name, webhook.Url, webhook.Headers)
+	}
+}
+
+func (nf *Notifications) sendWebhook(url string, headers map[string]string, event *NotificationEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{Timeout: time.Second * 10}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %v", resp.Status)
+	}
+	return nil
+}
+
+// GetHistory returns the latest events, newest first
+func (nf *Notifications) GetHistory() []*NotificationEvent {
+	nf.historyMutex.RLock()
+	defer nf.historyMutex.RUnlock()
+	history := make([]*NotificationEvent, len(nf.history))
+	for idx, event := range nf.history {
+		history[len(nf.history)-idx-1] = event
+	}
+	return history
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/rpc"
+	"github.com/pk910/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var logger_pr = logrus.StandardLogger().WithField("module", "proposer_rewards")
+
+const proposerRewardsMaxEpochsPerRun = 10
+
+// ProposerRewards compares the expected & realized rewards of the proposer for each finalized slot.
+// the expected reward is the consensus reward of the block plus the best builder bid seen by the relays,
+// the realized reward is the consensus reward plus the payment of the payload that was actually delivered.
+// The consensus reward breakdown of the blocks is persisted from the same block rewards request, if enabled.
+type ProposerRewards struct {
+	indexer *indexer.Indexer
+	relays  []*rpc.MevRelayClient
+}
+
+type proposerRewardsState struct {
+	Epoch uint64 `json:"epoch"`
+}
+
+// StartUpdater processes newly finalized epochs in the background
+func (pr *ProposerRewards) StartUpdater() {
+	if (!utils.Config.Indexer.TrackProposerRewards && !utils.Config.Indexer.TrackBlockRewards) || utils.Config.Indexer.DisableIndexWriter {
+		return
+	}
+	if utils.Config.Indexer.TrackProposerRewards {
+		for _, relay := range utils.Config.Indexer.MevRelays {
+			pr.relays = append(pr.relays, rpc.NewMevRelayClient(relay.Name, relay.Url))
+		}
+	}
+
+	go func() {
+		defer utils.HandleSubroutinePanic("ProposerRewards.StartUpdater")
+		epochDuration := time.Duration(utils.Config.Chain.Config.SecondsPerSlot*utils.Config.Chain.Config.SlotsPerEpoch) * time.Second
+		for {
+			if err := pr.processFinalizedEpochs(); err != nil {
+				logger_pr.WithError(err).Warnf("error while processing proposer rewards")
+			}
+			time.Sleep(epochDuration)
+		}
+	}()
+}
+
+func (pr *ProposerRewards) processFinalizedEpochs() error {
+	finalizedEpoch, _, _, _ := pr.indexer.GetFinalizationCheckpoints()
+	if finalizedEpoch < 0 {
+		return nil
+	}
+
+	// the block rewards api needs the parent state, so only recently finalized epochs are processed on first start
+	state := proposerRewardsState{}
+	if _, err := db.GetExplorerState("proposerrewards.state", &state); err != nil {
+		state.Epoch = uint64(finalizedEpoch)
+	}
+
+	processed := 0
+	for epoch := state.Epoch; epoch < uint64(finalizedEpoch) && processed < proposerRewardsMaxEpochsPerRun; epoch++ {
+		if !db.IsEpochSynchronized(epoch) {
+			// blocks & duties of the epoch haven't been written yet
+			break
+		}
+		rewards, blockRewards, err := pr.buildEpochRewards(epoch)
+		if err != nil {
+			return fmt.Errorf("error processing epoch %v: %v", epoch, err)
+		}
+
+		if err := pr.persistEpochRewards(epoch, rewards, blockRewards); err != nil {
+			return err
+		}
+		logger_pr.Debugf("processed proposer rewards for epoch %v (%v slots, %v blocks)", epoch, len(rewards), len(blockRewards))
+		processed++
+	}
+	return nil
+}
+
+func (pr *ProposerRewards) persistEpochRewards(epoch uint64, rewards []*dbtypes.SlotReward, blockRewards []*dbtypes.BlockReward) error {
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if utils.Config.Indexer.TrackProposerRewards {
+		if err := db.InsertSlotRewards(rewards, tx); err != nil {
+			return fmt.Errorf("error inserting slot rewards: %v", err)
+		}
+	}
+	if utils.Config.Indexer.TrackBlockRewards {
+		if err := db.InsertBlockRewards(blockRewards, tx); err != nil {
+			return fmt.Errorf("error inserting block rewards: %v", err)
+		}
+		for _, reward := range blockRewards {
+			if err := db.AddValidatorSummaryIncome(reward.Proposer, reward.Total, tx); err != nil {
+				return fmt.Errorf("error updating validator summary: %v", err)
+			}
+		}
+	}
+	if err := db.SetExplorerState("proposerrewards.state", &proposerRewardsState{Epoch: epoch + 1}, tx); err != nil {
+		return fmt.Errorf("error updating proposer rewards state: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+	return nil
+}
+
+func (pr *ProposerRewards) buildEpochRewards(epoch uint64) ([]*dbtypes.SlotReward, []*dbtypes.BlockReward, error) {
+	client := pr.indexer.GetReadyClient(false, nil, nil)
+	if client == nil {
+		return nil, nil, fmt.Errorf("no ready client")
+	}
+
+	firstSlot := epoch * utils.Config.Chain.Config.SlotsPerEpoch
+	lastSlot := firstSlot + utils.Config.Chain.Config.SlotsPerEpoch - 1
+	if epoch == 0 {
+		// no proposer for the genesis block
+		firstSlot = 1
+	}
+
+	proposers := map[uint64]uint64{}
+	for _, assignment := range db.GetSlotAssignmentsForSlots(lastSlot, firstSlot) {
+		proposers[assignment.Slot] = assignment.Proposer
+	}
+	blocks := map[uint64]*dbtypes.Block{}
+	for _, block := range db.GetBlocksForSlots(lastSlot, firstSlot, false) {
+		blocks[block.Slot] = block
+	}
+
+	rewards := make([]*dbtypes.SlotReward, 0, utils.Config.Chain.Config.SlotsPerEpoch)
+	blockRewards := make([]*dbtypes.BlockReward, 0, len(blocks))
+	clRewardSum := uint64(0)
+	clRewardCount := uint64(0)
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		reward := &dbtypes.SlotReward{
+			Slot:     slot,
+			Proposer: proposers[slot],
+		}
+
+		block := blocks[slot]
+		if block != nil {
+			reward.Root = block.Root
+			reward.Proposer = block.Proposer
+			// the epoch is retried on the next run, a missing consensus reward would skew the expected rewards
+			blockReward, err := loadBlockReward(client.GetRpcClient(), slot, block.Root, utils.Config.Indexer.TrackBlockRewards)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error loading block rewards for slot %v: %v", slot, err)
+			}
+			reward.ClReward = blockReward.Total
+			clRewardSum += reward.ClReward
+			clRewardCount++
+			blockRewards = append(blockRewards, blockReward)
+		}
+
+		if utils.Config.Indexer.TrackProposerRewards {
+			pr.loadRelayData(reward, block)
+		}
+		rewards = append(rewards, reward)
+	}
+
+	// missed slots are expected to yield the average consensus reward of the blocks in the same epoch
+	clRewardAvg := uint64(0)
+	if clRewardCount > 0 {
+		clRewardAvg = clRewardSum / clRewardCount
+	}
+	for _, reward := range rewards {
+		if reward.Root == nil {
+			reward.ExpectedReward = clRewardAvg + reward.MevBid
+		} else {
+			reward.ExpectedReward = reward.ClReward + reward.MevBid
+			reward.ActualReward = reward.ClReward + reward.MevPayment
+		}
+	}
+	return rewards, blockRewards, nil
+}
+
+// loadRelayData sets the best bid of all relays and the payment of the delivered payload that ended up in the block
+func (pr *ProposerRewards) loadRelayData(reward *dbtypes.SlotReward, block *dbtypes.Block) {
+	relayNames := []string{}
+	for _, relay := range pr.relays {
+		highestBid, err := relay.GetHighestBid(reward.Slot)
+		if err != nil {
+			logger_pr.Debugf("could not load bids for slot %v from relay %v: %v", reward.Slot, relay.GetName(), err)
+		} else if highestBid != nil && highestBid.GetValueGwei() > reward.MevBid {
+			reward.MevBid = highestBid.GetValueGwei()
+		}
+
+		if block == nil || block.EthBlockHash == nil {
+			continue
+		}
+		delivered, err := relay.GetDeliveredPayload(reward.Slot)
+		if err != nil {
+			logger_pr.Debugf("could not load delivered payload for slot %v from relay %v: %v", reward.Slot, relay.GetName(), err)
+			continue
+		}
+		if delivered == nil || !bytes.Equal(common.FromHex(delivered.BlockHash), block.EthBlockHash) {
+			continue
+		}
+		reward.MevPayment = delivered.GetValueGwei()
+		relayNames = append(relayNames, relay.GetName())
+	}
+	reward.MevRelay = strings.Join(relayNames, ", ")
+
+	// the delivered payload has been one of the bids, even if the bid api didn't report it
+	if reward.MevPayment > reward.MevBid {
+		reward.MevBid = reward.MevPayment
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/indexer"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// the search resolves roots on each request, so the node lookups are limited to one per interval and
+	// unknown roots are not looked up again until the miss expired
+	rootLookupInterval      = 500 * time.Millisecond
+	rootLookupMissTimeout   = 5 * time.Minute
+	rootLookupMissCacheSize = 1000
+)
+
+type ResolvedRootType string
+
+const (
+	ResolvedRootBlock          ResolvedRootType = "block"
+	ResolvedRootState          ResolvedRootType = "state"
+	ResolvedRootBody           ResolvedRootType = "body"
+	ResolvedRootExecutionBlock ResolvedRootType = "execution_block"
+)
+
+// ResolvedRoot describes the object a 32 byte root refers to and the block it belongs to
+type ResolvedRoot struct {
+	Type      ResolvedRootType
+	Slot      uint64
+	BlockRoot []byte
+	Orphaned  bool
+}
+
+// rootResolver looks up block roots that are neither cached nor in the db on a ready client
+type rootResolver struct {
+	mutex      sync.Mutex
+	lastLookup time.Time
+	misses     *lru.Cache[string, time.Time]
+}
+
+func newRootResolver() *rootResolver {
+	return &rootResolver{
+		misses: lru.NewCache[string, time.Time](rootLookupMissCacheSize),
+	}
+}
+
+// ResolveRoot determines what a root (block, state, body or execution block hash) refers to.
+// The unfinalized cache is checked first, followed by the db indexes and finally a ready
+// client, so blocks that are only known on other forks can be resolved too.
+// Returns nil if the root is unknown.
+func (bs *BeaconService) ResolveRoot(root []byte) *ResolvedRoot {
+	if len(root) != 32 {
+		return nil
+	}
+
+	// unfinalized blocks
+	if block := bs.indexer.GetCachedBlock(root); block != nil {
+		return newResolvedRoot(ResolvedRootBlock, block.Root, uint64(block.GetHeader().Message.Slot), !block.IsCanonical(bs.indexer, nil))
+	}
+	if block := bs.indexer.GetCachedBlockByStateroot(root); block != nil {
+		return newResolvedRoot(ResolvedRootState, block.Root, uint64(block.GetHeader().Message.Slot), !block.IsCanonical(bs.indexer, nil))
+	}
+	if block := bs.indexer.GetCachedBlockByBodyroot(root); block != nil {
+		return newResolvedRoot(ResolvedRootBody, block.Root, uint64(block.GetHeader().Message.Slot), !block.IsCanonical(bs.indexer, nil))
+	}
+	if blocks := bs.indexer.GetCachedBlocksByExecutionBlockHash(root); len(blocks) > 0 {
+		block := blocks[0]
+		return newResolvedRoot(ResolvedRootExecutionBlock, block.Root, uint64(block.GetHeader().Message.Slot), !block.IsCanonical(bs.indexer, nil))
+	}
+
+	// finalized & orphaned blocks
+	if block := db.GetBlockByRoot(root); block != nil {
+		return newResolvedRoot(ResolvedRootBlock, block.Root, block.Slot, block.Orphaned == 1)
+	}
+	if block := db.GetBlockByStateRoot(root); block != nil {
+		return newResolvedRoot(ResolvedRootState, block.Root, block.Slot, block.Orphaned == 1)
+	}
+	if block := db.GetBlockByExecutionHash(root); block != nil {
+		return newResolvedRoot(ResolvedRootExecutionBlock, block.Root, block.Slot, block.Orphaned == 1)
+	}
+	if block := db.GetBlockByBodyRoot(root); block != nil {
+		return newResolvedRoot(ResolvedRootBody, block.Root, block.Slot, block.Orphaned == 1)
+	}
+
+	// the beacon api can only look up block roots, which is the last resort for blocks the indexer hasn't seen
+	return bs.rootResolver.lookupBlockRoot(bs.indexer, root)
+}
+
+// lookupBlockRoot asks one ready client for a block with the root. Returns nil if the lookup is rate limited,
+// the root has been unknown recently or the client doesn't know the block.
+func (rr *rootResolver) lookupBlockRoot(indexer *indexer.Indexer, root []byte) *ResolvedRoot {
+	now := time.Now()
+	rr.mutex.Lock()
+	if missTime, isMiss := rr.misses.Get(string(root)); isMiss && now.Sub(missTime) < rootLookupMissTimeout {
+		rr.mutex.Unlock()
+		return nil
+	}
+	if now.Sub(rr.lastLookup) < rootLookupInterval {
+		rr.mutex.Unlock()
+		return nil
+	}
+	rr.lastLookup = now
+	rr.mutex.Unlock()
+
+	client := indexer.GetReadyClient(false, nil, nil)
+	if client == nil {
+		return nil
+	}
+	header, err := client.GetRpcClient().GetBlockHeaderByBlockroot(root)
+	if err != nil {
+		logrus.WithError(err).WithField("client", client.GetName()).Debugf("error resolving root 0x%x", root)
+		return nil
+	}
+	if header == nil {
+		rr.mutex.Lock()
+		rr.misses.Add(string(root), now)
+		rr.mutex.Unlock()
+		return nil
+	}
+	return &ResolvedRoot{
+		Type:      ResolvedRootBlock,
+		Slot:      uint64(header.Header.Message.Slot),
+		BlockRoot: header.Root[:],
+		Orphaned:  !header.Canonical,
+	}
+}
+
+func newResolvedRoot(rootType ResolvedRootType, blockRoot []byte, slot uint64, orphaned bool) *ResolvedRoot {
+	return &ResolvedRoot{
+		Type:      rootType,
+		Slot:      slot,
+		BlockRoot: blockRoot,
+		Orphaned:  orphaned,
+	}
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/utils"
+)
+
+var logger_sr = logrus.StandardLogger().WithField("module", "slashing_risks")
+
+// SlashingRisks tracks the votes included in all unfinalized blocks, including the blocks of competing forks, and
+// raises a notification as soon as a validator signed two votes that violate a casper ffg slashing condition.
+// Such votes can be turned into an attester slashing by any proposer, so the operator gets a chance to react
+// before the slashing is included.
+type SlashingRisks struct {
+	beaconService   *BeaconService
+	notifications   *Notifications
+	processedBlocks map[string]uint64
+	votes           map[uint64][]*slashingRiskVote
+	reported        map[slashingRiskKey]bool
+}
+
+type slashingRiskVote struct {
+	dataRoot    phase0.Root
+	data        *phase0.AttestationData
+	blockSlot   uint64
+	blockRoot   []byte
+	sourceEpoch uint64
+	targetEpoch uint64
+}
+
+type slashingRiskKey struct {
+	validator   uint64
+	targetEpoch uint64
+}
+
+// SlashingRiskEvent is the notification payload of a pair of slashable votes
+type SlashingRiskEvent struct {
+	Type           indexer.SlashingType `json:"type"`
+	ValidatorIndex uint64               `json:"validator"`
+	ValidatorName  string               `json:"validator_name,omitempty"`
+	Votes          []*SlashingRiskVote  `json:"votes"`
+}
+
+// SlashingRiskVote is one of the conflicting votes and the block it has been included in
+type SlashingRiskVote struct {
+	Slot        uint64 `json:"slot"`
+	HeadRoot    []byte `json:"head_root"`
+	SourceEpoch uint64 `json:"source_epoch"`
+	TargetEpoch uint64 `json:"target_epoch"`
+	TargetRoot  []byte `json:"target_root"`
+	BlockSlot   uint64 `json:"block_slot"`
+	BlockRoot   []byte `json:"block_root"`
+}
+
+// StartUpdater checks the new blocks in the background, if the monitor is enabled
+func (sr *SlashingRisks) StartUpdater() {
+	if !utils.Config.Alerts.SlashingRiskMonitor {
+		return
+	}
+	sr.processedBlocks = map[string]uint64{}
+	sr.votes = map[uint64][]*slashingRiskVote{}
+	sr.reported = map[slashingRiskKey]bool{}
+
+	go func() {
+		defer utils.HandleSubroutinePanic("SlashingRisks.StartUpdater")
+		slotDuration := time.Duration(utils.Config.Chain.Config.SecondsPerSlot) * time.Second
+		for {
+			time.Sleep(slotDuration)
+			sr.checkBlocks()
+		}
+	}()
+}
+
+// checkBlocks processes all unfinalized blocks that have not been checked yet.
+// Blocks of a fork may show up late, so the whole unfinalized range is scanned and not only the new slots.
+func (sr *SlashingRisks) checkBlocks() {
+	beaconIndexer := sr.beaconService.GetIndexer()
+	finalizedEpoch, _ := sr.beaconService.GetFinalizedEpoch()
+	firstSlot := uint64(0)
+	if finalizedEpoch >= 0 {
+		firstSlot = uint64(finalizedEpoch+1) * utils.Config.Chain.Config.SlotsPerEpoch
+	}
+	sr.cleanup(firstSlot)
+
+	headSlot := beaconIndexer.GetHighestSlot()
+	for slot := firstSlot; slot <= headSlot; slot++ {
+		for _, block := range beaconIndexer.GetCachedBlocks(slot) {
+			if _, processed := sr.processedBlocks[string(block.Root)]; processed {
+				continue
+			}
+			if sr.checkBlock(beaconIndexer, block) {
+				sr.processedBlocks[string(block.Root)] = block.Slot
+			}
+		}
+	}
+}
+
+// checkBlock adds the votes of the block to the vote history of the attesters and returns false if the
+// committees are not known yet, so the block is checked again in the next round
+func (sr *SlashingRisks) checkBlock(beaconIndexer *indexer.Indexer, block *indexer.CacheBlock) bool {
+	blockBody := block.GetBlockBody()
+	if blockBody == nil {
+		return false
+	}
+	attestations, err := blockBody.Attestations()
+	if err != nil {
+		return true
+	}
+
+	committees := map[uint64]map[string][]uint64{}
+	for _, att := range attestations {
+		attEpoch := utils.EpochOfSlot(uint64(att.Data.Slot))
+		epochCommittees, loaded := committees[attEpoch]
+		if !loaded {
+			if epochStats := beaconIndexer.GetCachedEpochStatsForBlock(attEpoch, block.Root); epochStats != nil && epochStats.IsReady() {
+				epochCommittees = epochStats.GetAttestorAssignments()
+			}
+			if epochCommittees == nil {
+				return false
+			}
+			committees[attEpoch] = epochCommittees
+		}
+		dataRoot, err := att.Data.HashTreeRoot()
+		if err != nil {
+			continue
+		}
+		vote := &slashingRiskVote{
+			dataRoot:    dataRoot,
+			data:        att.Data,
+			blockSlot:   block.Slot,
+			blockRoot:   block.Root,
+			sourceEpoch: uint64(att.Data.Source.Epoch),
+			targetEpoch: uint64(att.Data.Target.Epoch),
+		}
+
+		committee := epochCommittees[fmt.Sprintf("%v-%v", uint64(att.Data.Slot), uint64(att.Data.Index))]
+		for bitIdx, validatorIdx := range committee {
+			if utils.BitAtVector(att.AggregationBits, bitIdx) {
+				sr.addVote(validatorIdx, vote)
+			}
+		}
+	}
+	return true
+}
+
+func (sr *SlashingRisks) addVote(validatorIdx uint64, vote *slashingRiskVote) {
+	for _, prevVote := range sr.votes[validatorIdx] {
+		if prevVote.dataRoot == vote.dataRoot {
+			// same vote included in another block
+			return
+		}
+	}
+	for _, prevVote := range sr.votes[validatorIdx] {
+		var riskType indexer.SlashingType
+		if prevVote.targetEpoch == vote.targetEpoch {
+			riskType = indexer.SlashingTypeDoubleVote
+		} else if (prevVote.sourceEpoch < vote.sourceEpoch && vote.targetEpoch < prevVote.targetEpoch) || (vote.sourceEpoch < prevVote.sourceEpoch && prevVote.targetEpoch < vote.targetEpoch) {
+			riskType = indexer.SlashingTypeSurroundVote
+		} else {
+			continue
+		}
+
+		riskKey := slashingRiskKey{validator: validatorIdx, targetEpoch: vote.targetEpoch}
+		if sr.reported[riskKey] {
+			break
+		}
+		sr.reported[riskKey] = true
+		sr.reportRisk(riskType, validatorIdx, prevVote, vote)
+		break
+	}
+	sr.votes[validatorIdx] = append(sr.votes[validatorIdx], vote)
+}
+
+func (sr *SlashingRisks) reportRisk(riskType indexer.SlashingType, validatorIdx uint64, vote1 *slashingRiskVote, vote2 *slashingRiskVote) {
+	riskName := strings.ReplaceAll(string(riskType), "_", " ")
+	validatorName := sr.beaconService.GetValidatorName(validatorIdx)
+	validatorLabel := fmt.Sprintf("%v", validatorIdx)
+	if validatorName != "" {
+		validatorLabel = fmt.Sprintf("%v (%v)", validatorIdx, validatorName)
+	}
+	logger_sr.Warnf("validator %v signed a %v: %v->%v in block 0x%x and %v->%v in block 0x%x", validatorLabel, riskName, vote1.sourceEpoch, vote1.targetEpoch, vote1.blockRoot, vote2.sourceEpoch, vote2.targetEpoch, vote2.blockRoot)
+
+	sr.notifications.Dispatch(&NotificationEvent{
+		Type:    "slashing_risk",
+		Message: fmt.Sprintf("validator %v signed a slashable %v (%v->%v at slot %v, %v->%v at slot %v)", validatorLabel, riskName, vote1.sourceEpoch, vote1.targetEpoch, vote1.blockSlot, vote2.sourceEpoch, vote2.targetEpoch, vote2.blockSlot),
+		Data: &SlashingRiskEvent{
+			Type:           riskType,
+			ValidatorIndex: validatorIdx,
+			ValidatorName:  validatorName,
+			Votes:          []*SlashingRiskVote{vote1.toEvent(), vote2.toEvent()},
+		},
+	})
+}
+
+func (vote *slashingRiskVote) toEvent() *SlashingRiskVote {
+	return &SlashingRiskVote{
+		Slot:        uint64(vote.data.Slot),
+		HeadRoot:    vote.data.BeaconBlockRoot[:],
+		SourceEpoch: vote.sourceEpoch,
+		TargetEpoch: vote.targetEpoch,
+		TargetRoot:  vote.data.Target.Root[:],
+		BlockSlot:   vote.blockSlot,
+		BlockRoot:   vote.blockRoot,
+	}
+}
+
+// cleanup drops the blocks & votes below the finalized checkpoint
+func (sr *SlashingRisks) cleanup(firstSlot uint64) {
+	for root, slot := range sr.processedBlocks {
+		if slot < firstSlot {
+			delete(sr.processedBlocks, root)
+		}
+	}
+	firstEpoch := utils.EpochOfSlot(firstSlot)
+	for validatorIdx, votes := range sr.votes {
+		keptVotes := votes[:0]
+		for _, vote := range votes {
+			if vote.targetEpoch+1 >= firstEpoch {
+				keptVotes = append(keptVotes, vote)
+			}
+		}
+		if len(keptVotes) == 0 {
+			delete(sr.votes, validatorIdx)
+		} else {
+			sr.votes[validatorIdx] = keptVotes
+		}
+	}
+	for riskKey := range sr.reported {
+		if riskKey.targetEpoch+1 < firstEpoch {
+			delete(sr.reported, riskKey)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/utils"
+)
+
+var logger_ts = logrus.StandardLogger().WithField("module", "table_stats")
+
+// TableStats samples the size of all db tables once per hour, keeping the latest sample of each day.
+// The daily samples are used to estimate the storage growth on the runtime diagnostics page.
+type TableStats struct{}
+
+// StartUpdater samples the table sizes in the background
+func (ts *TableStats) StartUpdater() {
+	if utils.Config.Indexer.DisableIndexWriter {
+		return
+	}
+
+	go func() {
+		defer utils.HandleSubroutinePanic("TableStats.StartUpdater")
+		for {
+			if err := ts.sampleTableSizes(); err != nil {
+				logger_ts.WithError(err).Warnf("error while sampling table sizes")
+			}
+			time.Sleep(1 * time.Hour)
+		}
+	}()
+}
+
+func (ts *TableStats) sampleTableSizes() error {
+	day := uint64(time.Now().Unix() / 86400)
+	tableSizes, err := db.MeasureTableSizes(day)
+	if err != nil {
+		return fmt.Errorf("error measuring table sizes: %v", err)
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := db.InsertTableSizeStats(tableSizes, tx); err != nil {
+		return fmt.Errorf("error inserting table size stats: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+	logger_ts.Debugf("sampled size of %v tables", len(tableSizes))
+	return nil
+}
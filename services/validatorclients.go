@@ -0,0 +1,124 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/rpc"
+	"github.com/pk910/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var logger_vc = logrus.StandardLogger().WithField("module", "validator_clients")
+
+// ValidatorClients polls the keymanager apis & metrics of the operators own validator clients,
+// so the locally managed validators can be matched with their on-chain performance.
+type ValidatorClients struct {
+	indexer     *indexer.Indexer
+	clients     []*rpc.ValidatorClientApi
+	statusMutex sync.RWMutex
+	status      map[string]*ValidatorClientStatus
+}
+
+// ValidatorClientStatus is the last polled state of a validator client
+type ValidatorClientStatus struct {
+	Name        string
+	LastRefresh time.Time
+	Error       string
+	Pubkeys     int
+	Validators  []uint64
+	Metrics     map[string]float64
+}
+
+// StartUpdater loads the validator client state and keeps it updated in the configured refresh interval
+func (vc *ValidatorClients) StartUpdater() {
+	if len(utils.Config.Frontend.ValidatorClients) == 0 {
+		return
+	}
+	for _, client := range utils.Config.Frontend.ValidatorClients {
+		vc.clients = append(vc.clients, rpc.NewValidatorClientApi(client.Name, client.KeymanagerUrl, client.KeymanagerToken, client.MetricsUrl))
+	}
+	vc.status = map[string]*ValidatorClientStatus{}
+
+	refreshInterval := utils.Config.Frontend.ValidatorClientsRefreshInterval
+	if refreshInterval <= 0 {
+		refreshInterval = 5 * time.Minute
+	}
+	go func() {
+		defer utils.HandleSubroutinePanic("ValidatorClients.StartUpdater")
+		for {
+			vc.refreshClients()
+			time.Sleep(refreshInterval)
+		}
+	}()
+}
+
+func (vc *ValidatorClients) refreshClients() {
+	var validatorSet map[string]uint64
+	if validators := vc.indexer.GetCachedValidatorSet(); validators != nil {
+		validatorSet = make(map[string]uint64, len(validators))
+		for index, validator := range validators {
+			validatorSet[string(validator.Validator.PublicKey[:])] = uint64(index)
+		}
+	}
+
+	for idx, client := range vc.clients {
+		status := &ValidatorClientStatus{
+			Name:        client.GetName(),
+			LastRefresh: time.Now(),
+			Validators:  []uint64{},
+		}
+
+		pubkeys, err := client.GetPubkeys()
+		if err != nil {
+			logger_vc.WithError(err).Warnf("error loading keys from validator client %v", client.GetName())
+			status.Error = err.Error()
+			// keep the previously loaded validators, the client might just be restarting
+			vc.statusMutex.RLock()
+			if oldStatus := vc.status[client.GetName()]; oldStatus != nil {
+				status.Pubkeys = oldStatus.Pubkeys
+				status.Validators = oldStatus.Validators
+			}
+			vc.statusMutex.RUnlock()
+		} else {
+			status.Pubkeys = len(pubkeys)
+			for _, pubkey := range pubkeys {
+				if index, found := validatorSet[string(pubkey)]; found {
+					status.Validators = append(status.Validators, index)
+				}
+			}
+		}
+
+		metrics, err := client.GetMetrics(utils.Config.Frontend.ValidatorClients[idx].Metrics)
+		if err != nil {
+			logger_vc.WithError(err).Debugf("error loading metrics from validator client %v", client.GetName())
+			if status.Error == "" {
+				status.Error = err.Error()
+			}
+		}
+		status.Metrics = metrics
+
+		vc.statusMutex.Lock()
+		vc.status[client.GetName()] = status
+		vc.statusMutex.Unlock()
+	}
+}
+
+// GetClientStatus returns the last polled state of all configured validator clients
+func (vc *ValidatorClients) GetClientStatus() []*ValidatorClientStatus {
+	vc.statusMutex.RLock()
+	defer vc.statusMutex.RUnlock()
+
+	result := make([]*ValidatorClientStatus, 0, len(vc.clients))
+	for _, client := range vc.clients {
+		status := vc.status[client.GetName()]
+		if status == nil {
+			status = &ValidatorClientStatus{
+				Name: client.GetName(),
+			}
+		}
+		result = append(result, status)
+	}
+	return result
+}
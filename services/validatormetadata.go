@@ -0,0 +1,357 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/types"
+	"github.com/pk910/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var logger_vm = logrus.StandardLogger().WithField("module", "validator_metadata")
+
+// validatorMetadataMaxIndex limits the indexes of the ranges as long as the validator set isn't loaded
+const validatorMetadataMaxIndex = 1<<22 - 1
+
+// ValidatorMetadata holds arbitrary key/value metadata (entity, region, client, ...) per validator,
+// loaded from the configured enrichment apis.
+type ValidatorMetadata struct {
+	indexer        *indexer.Indexer
+	loadingMutex   sync.Mutex
+	loading        bool
+	resetPending   bool
+	metadataMutex  sync.RWMutex
+	metadata       map[uint64]map[string]string
+	sourceMetadata map[string]map[uint64]map[string]string
+}
+
+// ValidatorMetadataGroup is a group of validators sharing the same value for a metadata key
+type ValidatorMetadataGroup struct {
+	Value      string   `json:"value"`
+	Count      uint64   `json:"count"`
+	Validators []uint64 `json:"validators,omitempty"`
+}
+
+func (vm *ValidatorMetadata) GetValidatorMetadata(index uint64) map[string]string {
+	vm.metadataMutex.RLock()
+	defer vm.metadataMutex.RUnlock()
+	if vm.metadata == nil {
+		return nil
+	}
+	return vm.metadata[index]
+}
+
+// GetMetadataGroups groups all validators by their value for the given metadata key
+func (vm *ValidatorMetadata) GetMetadataGroups(key string, withValidators bool) []*ValidatorMetadataGroup {
+	vm.metadataMutex.RLock()
+	groupMap := map[string]*ValidatorMetadataGroup{}
+	for index, metadata := range vm.metadata {
+		value, found := metadata[key]
+		if !found {
+			continue
+		}
+		group := groupMap[value]
+		if group == nil {
+			group = &ValidatorMetadataGroup{
+				Value: value,
+			}
+			groupMap[value] = group
+		}
+		group.Count++
+		if withValidators {
+			group.Validators = append(group.Validators, index)
+		}
+	}
+	vm.metadataMutex.RUnlock()
+
+	groups := make([]*ValidatorMetadataGroup, 0, len(groupMap))
+	for _, group := range groupMap {
+		sort.Slice(group.Validators, func(a, b int) bool {
+			return group.Validators[a] < group.Validators[b]
+		})
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(a, b int) bool {
+		if groups[a].Count != groups[b].Count {
+			return groups[a].Count > groups[b].Count
+		}
+		return groups[a].Value < groups[b].Value
+	})
+	return groups
+}
+
+// StartUpdater loads the validator metadata and keeps it updated in the configured refresh interval
+func (vm *ValidatorMetadata) StartUpdater() {
+	vm.loadFromDb()
+	if len(utils.Config.Frontend.ValidatorMetadataSources) == 0 {
+		return
+	}
+	vm.LoadValidatorMetadata()
+
+	refreshInterval := utils.Config.Frontend.ValidatorMetadataRefreshInterval
+	if refreshInterval <= 0 {
+		return
+	}
+	go func() {
+		defer utils.HandleSubroutinePanic("ValidatorMetadata.StartUpdater")
+		for {
+			time.Sleep(refreshInterval)
+			vm.LoadValidatorMetadata()
+		}
+	}()
+}
+
+func (vm *ValidatorMetadata) LoadValidatorMetadata() {
+	vm.loadingMutex.Lock()
+	defer vm.loadingMutex.Unlock()
+	if vm.loading {
+		return
+	}
+	vm.loading = true
+	resetSources := vm.resetPending
+	vm.resetPending = false
+
+	go func() {
+		defer utils.HandleSubroutinePanic("ValidatorMetadata.LoadValidatorMetadata")
+		defer func() {
+			vm.loadingMutex.Lock()
+			vm.loading = false
+			reload := vm.resetPending
+			vm.loadingMutex.Unlock()
+			if reload {
+				// the chain has been reset while loading, the metadata might belong to the previous chain
+				vm.LoadValidatorMetadata()
+			}
+		}()
+
+		if vm.sourceMetadata == nil || resetSources {
+			vm.sourceMetadata = map[string]map[uint64]map[string]string{}
+		}
+
+		// load metadata from all sources, keep the last loaded metadata of sources that fail to load
+		sources := make([]types.ValidatorMetadataSourceConfig, len(utils.Config.Frontend.ValidatorMetadataSources))
+		copy(sources, utils.Config.Frontend.ValidatorMetadataSources)
+		sort.SliceStable(sources, func(a, b int) bool {
+			return sources[a].Priority < sources[b].Priority
+		})
+		for idx, source := range sources {
+			sourceKey := fmt.Sprintf("%v:%v", idx, source.Name)
+			metadata, err := vm.loadFromSource(&source)
+			if err != nil {
+				logger_vm.WithError(err).Errorf("error while loading validator metadata from source %v", source.Name)
+				continue
+			}
+			vm.sourceMetadata[sourceKey] = metadata
+		}
+
+		// merge metadata per key, sources with higher priority override lower ones
+		metadata := make(map[uint64]map[string]string)
+		for idx, source := range sources {
+			for index, sourceEntries := range vm.sourceMetadata[fmt.Sprintf("%v:%v", idx, source.Name)] {
+				entries := metadata[index]
+				if entries == nil {
+					entries = make(map[string]string, len(sourceEntries))
+					metadata[index] = entries
+				}
+				for key, value := range sourceEntries {
+					entries[key] = value
+				}
+			}
+		}
+
+		vm.metadataMutex.Lock()
+		vm.metadata = metadata
+		vm.metadataMutex.Unlock()
+
+		// update db
+		if !utils.Config.Indexer.DisableIndexWriter {
+			err := vm.updateDb(metadata)
+			if err != nil {
+				logger_vm.WithError(err).Errorf("error while updating validator metadata in db")
+			}
+		}
+	}()
+}
+
+// resetValidatorMetadata drops the metadata of the previous chain after a chain reset and reloads it from the sources
+func (vm *ValidatorMetadata) resetValidatorMetadata() {
+	vm.metadataMutex.Lock()
+	vm.metadata = nil
+	vm.metadataMutex.Unlock()
+
+	if len(utils.Config.Frontend.ValidatorMetadataSources) == 0 {
+		return
+	}
+	vm.loadingMutex.Lock()
+	vm.resetPending = true
+	vm.loadingMutex.Unlock()
+	vm.LoadValidatorMetadata()
+}
+
+// loadFromDb loads the previously persisted metadata, so it's available before the sources have been loaded
+func (vm *ValidatorMetadata) loadFromDb() {
+	dbMetadata := db.GetValidatorMetadata(0, math.MaxInt64)
+	if len(dbMetadata) == 0 {
+		return
+	}
+	metadata := make(map[uint64]map[string]string)
+	for _, entry := range dbMetadata {
+		entries := metadata[entry.Index]
+		if entries == nil {
+			entries = map[string]string{}
+			metadata[entry.Index] = entries
+		}
+		entries[entry.Key] = entry.Value
+	}
+
+	vm.metadataMutex.Lock()
+	if vm.metadata == nil {
+		vm.metadata = metadata
+	}
+	vm.metadataMutex.Unlock()
+	logger_vm.Infof("loaded metadata for %v validators from db", len(metadata))
+}
+
+type validatorMetadataRangesResponse struct {
+	Ranges map[string]map[string]string `json:"ranges"`
+}
+
+func (vm *ValidatorMetadata) loadFromSource(source *types.ValidatorMetadataSourceConfig) (map[uint64]map[string]string, error) {
+	logger_vm.Debugf("Loading validator metadata from source: %v", utils.GetRedactedUrl(source.Url))
+
+	req, err := http.NewRequest("GET", source.Url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for headerKey, headerVal := range source.Headers {
+		req.Header.Set(headerKey, headerVal)
+	}
+
+	client := &http.Client{Timeout: time.Second * 120}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch validator metadata (%v): %v", utils.GetRedactedUrl(source.Url), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("could not fetch validator metadata (%v): not found", utils.GetRedactedUrl(source.Url))
+		}
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(source.Url), data)
+	}
+	rangesResponse := &validatorMetadataRangesResponse{}
+	dec := json.NewDecoder(resp.Body)
+	err = dec.Decode(&rangesResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing validator metadata response: %v", err)
+	}
+
+	maxIndex := uint64(validatorMetadataMaxIndex)
+	if validatorSet := vm.indexer.GetCachedValidatorSet(); len(validatorSet) > 0 {
+		maxIndex = uint64(len(validatorSet) - 1)
+	}
+	metadata := parseMetadataRanges(rangesResponse.Ranges, maxIndex)
+
+	logger_vm.Infof("loaded metadata for %v validators from source %v", len(metadata), source.Name)
+	return metadata, nil
+}
+
+// parseMetadataRanges expands the "min-max" or single index keys of the ranges to the metadata per validator.
+// Ranges ending before they start are skipped and the ranges are cut at maxIndex, so a bogus range can't
+// allocate metadata for more validators than there are.
+func parseMetadataRanges(ranges map[string]map[string]string, maxIndex uint64) map[uint64]map[string]string {
+	metadata := make(map[uint64]map[string]string)
+	for idxStr, entries := range ranges {
+		rangeParts := strings.Split(idxStr, "-")
+		if len(rangeParts) > 2 {
+			logger_vm.Warnf("skipping invalid validator metadata range %v", idxStr)
+			continue
+		}
+		minIdx, err := strconv.ParseUint(rangeParts[0], 10, 64)
+		if err != nil {
+			logger_vm.Warnf("skipping invalid validator metadata range %v: %v", idxStr, err)
+			continue
+		}
+		maxIdx := minIdx
+		if len(rangeParts) > 1 {
+			maxIdx, err = strconv.ParseUint(rangeParts[1], 10, 64)
+			if err != nil {
+				logger_vm.Warnf("skipping invalid validator metadata range %v: %v", idxStr, err)
+				continue
+			}
+		}
+		if maxIdx < minIdx {
+			logger_vm.Warnf("skipping invalid validator metadata range %v: range ends before it starts", idxStr)
+			continue
+		}
+		if minIdx > maxIndex {
+			continue
+		}
+		if maxIdx > maxIndex {
+			maxIdx = maxIndex
+		}
+		for idx := minIdx; ; idx++ {
+			metadata[idx] = entries
+			if idx == maxIdx {
+				break
+			}
+		}
+	}
+	return metadata
+}
+
+// updateDb replaces the persisted metadata with the merged metadata of all sources
+func (vm *ValidatorMetadata) updateDb(metadata map[uint64]map[string]string) error {
+	metadataRows := make([]*dbtypes.ValidatorMetadata, 0)
+	for index, entries := range metadata {
+		for key, value := range entries {
+			metadataRows = append(metadataRows, &dbtypes.ValidatorMetadata{
+				Index: index,
+				Key:   key,
+				Value: value,
+			})
+		}
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = db.DeleteValidatorMetadata(0, math.MaxInt64, tx)
+	if err != nil {
+		return fmt.Errorf("error while clearing validator metadata: %v", err)
+	}
+
+	batchSize := 10000
+	for rowIdx := 0; rowIdx < len(metadataRows); rowIdx += batchSize {
+		maxIdx := rowIdx + batchSize
+		if maxIdx > len(metadataRows) {
+			maxIdx = len(metadataRows)
+		}
+		err := db.InsertValidatorMetadata(metadataRows[rowIdx:maxIdx], tx)
+		if err != nil {
+			return fmt.Errorf("error while adding validator metadata to db: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+	logger_vm.Debugf("updated validator metadata in db: %v entries", len(metadataRows))
+	return nil
+}
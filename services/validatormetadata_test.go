@@ -0,0 +1,78 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseMetadataRanges(t *testing.T) {
+	entries := map[string]string{"entity": "test"}
+	tests := []struct {
+		name     string
+		ranges   []string
+		maxIndex uint64
+		expected []uint64
+	}{
+		{
+			name:     "single index",
+			ranges:   []string{"3"},
+			maxIndex: 10,
+			expected: []uint64{3},
+		},
+		{
+			name:     "range",
+			ranges:   []string{"2-4"},
+			maxIndex: 10,
+			expected: []uint64{2, 3, 4},
+		},
+		{
+			name:     "range ending before start",
+			ranges:   []string{"5-3"},
+			maxIndex: 10,
+		},
+		{
+			name:     "invalid ranges",
+			ranges:   []string{"abc", "1-x", "1-2-3", "-1"},
+			maxIndex: 10,
+		},
+		{
+			name:     "range cut at max index",
+			ranges:   []string{"8-12"},
+			maxIndex: 10,
+			expected: []uint64{8, 9, 10},
+		},
+		{
+			name:     "range above max index",
+			ranges:   []string{"11-12"},
+			maxIndex: 10,
+		},
+		{
+			name:     "range ending at max uint64",
+			ranges:   []string{"18446744073709551614-18446744073709551615"},
+			maxIndex: math.MaxUint64,
+			expected: []uint64{math.MaxUint64 - 1, math.MaxUint64},
+		},
+		{
+			name:     "range to max uint64 cut at max index",
+			ranges:   []string{"0-18446744073709551615"},
+			maxIndex: 2,
+			expected: []uint64{0, 1, 2},
+		},
+	}
+	for _, test := range tests {
+		ranges := map[string]map[string]string{}
+		for _, rangeStr := range test.ranges {
+			ranges[rangeStr] = entries
+		}
+		metadata := parseMetadataRanges(ranges, test.maxIndex)
+		if len(metadata) != len(test.expected) {
+			t.Errorf("%v: expected %v validators, got %v", test.name, len(test.expected), len(metadata))
+			continue
+		}
+		for _, index := range test.expected {
+			if metadata[index]["entity"] != "test" {
+				t.Errorf("%v: missing metadata for validator %v", test.name, index)
+			}
+		}
+	}
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"sort"
@@ -15,6 +17,8 @@ import (
 	"github.com/pk910/dora/config"
 	"github.com/pk910/dora/db"
 	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/indexer"
+	"github.com/pk910/dora/types"
 	"github.com/pk910/dora/utils"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
@@ -23,10 +27,24 @@ import (
 var logger_vn = logrus.StandardLogger().WithField("module", "validator_names")
 
 type ValidatorNames struct {
+	indexer      *indexer.Indexer
 	loadingMutex sync.Mutex
 	loading      bool
+	resetPending bool
+	stopChan     chan struct{}
 	namesMutex   sync.RWMutex
 	names        map[uint64]string
+	nameSources  map[uint64]*validatorNameSource
+	sourceNames  map[string]map[uint64]string
+
+	mnemonicMutex   sync.Mutex
+	mnemonicPubkeys map[string][]byte
+}
+
+// validatorNameSource is the provenance of a validator name
+type validatorNameSource struct {
+	source     string
+	sourceName string
 }
 
 func (vn *ValidatorNames) GetValidatorName(index uint64) string {
@@ -40,6 +58,69 @@ func (vn *ValidatorNames) GetValidatorName(index uint64) string {
 	return vn.names[index]
 }
 
+// GetValidatorNameSource returns the provenance and the configured source name of the validator name
+func (vn *ValidatorNames) GetValidatorNameSource(index uint64) (string, string) {
+	if !vn.namesMutex.TryRLock() {
+		return "", ""
+	}
+	defer vn.namesMutex.RUnlock()
+	if nameSource := vn.nameSources[index]; nameSource != nil {
+		return nameSource.source, nameSource.sourceName
+	}
+	return "", ""
+}
+
+// GetValidatorIndicesByName returns the sorted indices of all validators whose name contains the given string (case insensitive)
+func (vn *ValidatorNames) GetValidatorIndicesByName(name string) []uint64 {
+	vn.namesMutex.RLock()
+	defer vn.namesMutex.RUnlock()
+
+	name = strings.ToLower(name)
+	indices := []uint64{}
+	for index, validatorName := range vn.names {
+		if strings.Contains(strings.ToLower(validatorName), name) {
+			indices = append(indices, index)
+		}
+	}
+	sort.Slice(indices, func(a, b int) bool {
+		return indices[a] < indices[b]
+	})
+	return indices
+}
+
+// StartUpdater loads the validator names and keeps them updated in the configured refresh interval until StopUpdater is called
+func (vn *ValidatorNames) StartUpdater() {
+	vn.loadFromDb()
+	vn.LoadValidatorNames()
+
+	refreshInterval := utils.Config.Frontend.ValidatorNamesRefreshInterval
+	if refreshInterval <= 0 {
+		return
+	}
+	vn.stopChan = make(chan struct{})
+	go func(stopChan chan struct{}) {
+		defer utils.HandleSubroutinePanic("ValidatorNames.StartUpdater")
+		refreshTicker := time.NewTicker(refreshInterval)
+		defer refreshTicker.Stop()
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-refreshTicker.C:
+				vn.LoadValidatorNames()
+			}
+		}
+	}(vn.stopChan)
+}
+
+// StopUpdater stops the periodic refresh of the validator names
+func (vn *ValidatorNames) StopUpdater() {
+	if vn.stopChan != nil {
+		close(vn.stopChan)
+		vn.stopChan = nil
+	}
+}
+
 func (vn *ValidatorNames) LoadValidatorNames() {
 	vn.loadingMutex.Lock()
 	defer vn.loadingMutex.Unlock()
@@ -47,44 +128,161 @@ func (vn *ValidatorNames) LoadValidatorNames() {
 		return
 	}
 	vn.loading = true
+	resetSources := vn.resetPending
+	vn.resetPending = false
 
 	go func() {
-		vn.namesMutex.Lock()
-		vn.names = make(map[uint64]string)
-		vn.namesMutex.Unlock()
-
-		// load names
-		if strings.HasPrefix(utils.Config.Frontend.ValidatorNamesYaml, "~internal/") {
-			err := vn.loadFromInternalYaml(utils.Config.Frontend.ValidatorNamesYaml[10:])
-			if err != nil {
-				logger_vn.WithError(err).Errorf("error while loading validator names from internal yaml")
+		defer func() {
+			vn.loadingMutex.Lock()
+			vn.loading = false
+			reload := vn.resetPending
+			vn.loadingMutex.Unlock()
+			if reload {
+				// the chain has been reset while loading, the names might belong to the previous chain
+				vn.LoadValidatorNames()
 			}
-		} else if utils.Config.Frontend.ValidatorNamesYaml != "" {
-			err := vn.loadFromYaml(utils.Config.Frontend.ValidatorNamesYaml)
-			if err != nil {
-				logger_vn.WithError(err).Errorf("error while loading validator names from yaml")
+		}()
+
+		if vn.sourceNames == nil || resetSources {
+			vn.sourceNames = map[string]map[uint64]string{}
+		}
+
+		// load names from all sources, keep the last loaded names of sources that fail to load
+		sources := make([]types.ValidatorNamesSourceConfig, len(utils.Config.Frontend.ValidatorNamesSources))
+		copy(sources, utils.Config.Frontend.ValidatorNamesSources)
+		sort.SliceStable(sources, func(a, b int) bool {
+			return sources[a].Priority < sources[b].Priority
+		})
+		awaitValidatorSet := false
+		for idx, source := range sources {
+			sourceKey := fmt.Sprintf("%v:%v", idx, source.Name)
+			names, err := vn.loadFromSource(&source)
+			if errors.Is(err, errValidatorSetNotReady) {
+				awaitValidatorSet = true
+				continue
+			} else if err != nil {
+				logger_vn.WithError(err).Errorf("error while loading validator names from source %v", source.Name)
+				continue
 			}
+			vn.sourceNames[sourceKey] = names
 		}
-		if utils.Config.Frontend.ValidatorNamesInventory != "" {
-			err := vn.loadFromRangesApi(utils.Config.Frontend.ValidatorNamesInventory)
-			if err != nil {
-				logger_vn.WithError(err).Errorf("error while loading validator names inventory")
+		if awaitValidatorSet {
+			// deposit data sources can only be mapped to validator indexes once the validator set is available
+			logger_vn.Infof("validator set not loaded yet, reloading validator names in 1 minute")
+			time.AfterFunc(1*time.Minute, vn.LoadValidatorNames)
+		}
+
+		// merge names, sources with higher priority override lower ones
+		names := make(map[uint64]string)
+		nameSources := make(map[uint64]*validatorNameSource)
+		for idx, source := range sources {
+			nameSource := &validatorNameSource{
+				source:     getValidatorNameProvenance(source.Type),
+				sourceName: source.Name,
+			}
+			for index, name := range vn.sourceNames[fmt.Sprintf("%v:%v", idx, source.Name)] {
+				names[index] = name
+				nameSources[index] = nameSource
 			}
 		}
+		claimSource := &validatorNameSource{
+			source: dbtypes.ValidatorNameSourceClaim,
+		}
+		for index, name := range vn.loadClaimedNames() {
+			names[index] = name
+			nameSources[index] = claimSource
+		}
+
+		vn.namesMutex.Lock()
+		vn.names = names
+		vn.nameSources = nameSources
+		vn.namesMutex.Unlock()
 
 		// update db
 		if !utils.Config.Indexer.DisableIndexWriter {
-			vn.updateDb()
+			err := vn.updateDb()
+			if err != nil {
+				logger_vn.WithError(err).Errorf("error while updating validator names in db")
+			}
 		}
-
-		vn.loading = false
 	}()
 }
 
-func (vn *ValidatorNames) loadFromYaml(fileName string) error {
+// resetValidatorNames drops the names of the previous chain after a chain reset and reloads them.
+// The names of sources that fail to load are not kept, the validator indexes might refer to other validators now.
+func (vn *ValidatorNames) resetValidatorNames() {
+	vn.namesMutex.Lock()
+	vn.names = nil
+	vn.nameSources = nil
+	vn.namesMutex.Unlock()
+
+	vn.loadingMutex.Lock()
+	vn.resetPending = true
+	vn.loadingMutex.Unlock()
+	vn.LoadValidatorNames()
+}
+
+// loadFromDb loads the previously persisted names, so they're available before the sources have been loaded
+func (vn *ValidatorNames) loadFromDb() {
+	dbNames := db.GetValidatorNames(0, math.MaxInt64, nil)
+	if len(dbNames) == 0 {
+		return
+	}
+	names := make(map[uint64]string, len(dbNames))
+	nameSources := make(map[uint64]*validatorNameSource, len(dbNames))
+	for _, dbName := range dbNames {
+		names[dbName.Index] = dbName.Name
+		nameSources[dbName.Index] = &validatorNameSource{
+			source:     dbName.Source,
+			sourceName: dbName.SourceName,
+		}
+	}
+
+	vn.namesMutex.Lock()
+	if vn.names == nil {
+		vn.names = names
+		vn.nameSources = nameSources
+	}
+	vn.namesMutex.Unlock()
+	logger_vn.Infof("loaded %v validator names from db", len(names))
+}
+
+// getValidatorNameProvenance returns the provenance recorded for names loaded from a source type
+func getValidatorNameProvenance(sourceType string) string {
+	switch sourceType {
+	case "inventory":
+		return dbtypes.ValidatorNameSourceApi
+	case "deposit_data":
+		return dbtypes.ValidatorNameSourceDepositData
+	case "mnemonic":
+		return dbtypes.ValidatorNameSourceMnemonic
+	default:
+		return dbtypes.ValidatorNameSourceYaml
+	}
+}
+
+func (vn *ValidatorNames) loadFromSource(source *types.ValidatorNamesSourceConfig) (map[uint64]string, error) {
+	switch source.Type {
+	case "yaml", "":
+		if strings.HasPrefix(source.Source, "~internal/") {
+			return vn.loadFromInternalYaml(source.Source[10:])
+		}
+		return vn.loadFromYaml(source.Source)
+	case "inventory":
+		return vn.loadFromRangesApi(source.Source)
+	case "deposit_data":
+		return vn.loadFromDepositData(source.Name, source.Source)
+	case "mnemonic":
+		return vn.loadFromMnemonics(source.Source)
+	default:
+		return nil, fmt.Errorf("unknown validator names source type: %v", source.Type)
+	}
+}
+
+func (vn *ValidatorNames) loadFromYaml(fileName string) (map[uint64]string, error) {
 	f, err := os.Open(fileName)
 	if err != nil {
-		return fmt.Errorf("error opening validator names file %v: %v", fileName, err)
+		return nil, fmt.Errorf("error opening validator names file %v: %v", fileName, err)
 	}
 	defer f.Close()
 
@@ -92,45 +290,44 @@ func (vn *ValidatorNames) loadFromYaml(fileName string) error {
 	decoder := yaml.NewDecoder(f)
 	err = decoder.Decode(&namesYaml)
 	if err != nil {
-		return fmt.Errorf("error decoding validator names file %v: %v", fileName, err)
+		return nil, fmt.Errorf("error decoding validator names file %v: %v", fileName, err)
 	}
 
-	nameCount := vn.parseNamesMap(namesYaml)
-	logger_vn.Infof("loaded %v validator names from yaml (%v)", nameCount, fileName)
+	names := vn.parseNamesMap(namesYaml)
+	logger_vn.Infof("loaded %v validator names from yaml (%v)", len(names), fileName)
 
-	return nil
+	return names, nil
 }
 
-func (vn *ValidatorNames) loadFromInternalYaml(fileName string) error {
+func (vn *ValidatorNames) loadFromInternalYaml(fileName string) (map[uint64]string, error) {
 	f, err := config.ValidatorNamesYml.Open(fileName)
 	if err != nil {
-		return fmt.Errorf("could not find internal validator names file %v: %v", fileName, err)
+		return nil, fmt.Errorf("could not find internal validator names file %v: %v", fileName, err)
 	}
 
 	namesYaml := map[string]string{}
 	decoder := yaml.NewDecoder(f)
 	err = decoder.Decode(&namesYaml)
 	if err != nil {
-		return fmt.Errorf("could not find internal validator names file %v: %v", fileName, err)
+		return nil, fmt.Errorf("could not find internal validator names file %v: %v", fileName, err)
 	}
 
-	nameCount := vn.parseNamesMap(namesYaml)
-	logger_vn.Infof("loaded %v validator names from internal yaml (%v)", nameCount, fileName)
+	names := vn.parseNamesMap(namesYaml)
+	logger_vn.Infof("loaded %v validator names from internal yaml (%v)", len(names), fileName)
 
-	return nil
+	return names, nil
 }
 
-func (vn *ValidatorNames) parseNamesMap(names map[string]string) int {
-	vn.namesMutex.Lock()
-	defer vn.namesMutex.Unlock()
-	nameCount := 0
-	for idxStr, name := range names {
+// parseNamesMap parses a map of validator indexes or index ranges ("<min>-<max>") to names
+func (vn *ValidatorNames) parseNamesMap(namesMap map[string]string) map[uint64]string {
+	names := make(map[uint64]string)
+	for idxStr, name := range namesMap {
 		rangeParts := strings.Split(idxStr, "-")
 		minIdx, err := strconv.ParseUint(rangeParts[0], 10, 64)
 		if err != nil {
 			continue
 		}
-		maxIdx := minIdx + 1
+		maxIdx := minIdx
 		if len(rangeParts) > 1 {
 			maxIdx, err = strconv.ParseUint(rangeParts[1], 10, 64)
 			if err != nil {
@@ -138,74 +335,59 @@ func (vn *ValidatorNames) parseNamesMap(names map[string]string) int {
 			}
 		}
 		for idx := minIdx; idx <= maxIdx; idx++ {
-			vn.names[idx] = name
-			nameCount++
+			names[idx] = name
 		}
 	}
-	return nameCount
+	return names
 }
 
 type validatorNamesRangesResponse struct {
 	Ranges map[string]string `json:"ranges"`
 }
 
-func (vn *ValidatorNames) loadFromRangesApi(apiUrl string) error {
+func (vn *ValidatorNames) loadFromRangesApi(apiUrl string) (map[uint64]string, error) {
 	logger_vn.Debugf("Loading validator names from inventory: %v", apiUrl)
 
 	client := &http.Client{Timeout: time.Second * 120}
 	resp, err := client.Get(apiUrl)
 	if err != nil {
-		return fmt.Errorf("could not fetch inventory (%v): %v", utils.GetRedactedUrl(apiUrl), err)
+		return nil, fmt.Errorf("could not fetch inventory (%v): %v", utils.GetRedactedUrl(apiUrl), err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		if resp.StatusCode == http.StatusNotFound {
-			logger_vn.Errorf("could not fetch inventory (%v): not found", utils.GetRedactedUrl(apiUrl))
-			return nil
+			return nil, fmt.Errorf("could not fetch inventory (%v): not found", utils.GetRedactedUrl(apiUrl))
 		}
 		data, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(apiUrl), data)
+		return nil, fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(apiUrl), data)
 	}
 	rangesResponse := &validatorNamesRangesResponse{}
 	dec := json.NewDecoder(resp.Body)
 	err = dec.Decode(&rangesResponse)
 	if err != nil {
-		return fmt.Errorf("error parsing validator ranges response: %v", err)
+		return nil, fmt.Errorf("error parsing validator ranges response: %v", err)
 	}
 
-	vn.namesMutex.Lock()
-	defer vn.namesMutex.Unlock()
-	nameCount := 0
-	for rangeStr, name := range rangesResponse.Ranges {
-		rangeParts := strings.Split(rangeStr, "-")
-		minIdx, err := strconv.ParseUint(rangeParts[0], 10, 64)
-		if err != nil {
-			continue
-		}
-		maxIdx := minIdx + 1
-		if len(rangeParts) > 1 {
-			maxIdx, err = strconv.ParseUint(rangeParts[1], 10, 64)
-			if err != nil {
-				continue
-			}
-		}
-		for idx := minIdx; idx <= maxIdx; idx++ {
-			vn.names[idx] = name
-			nameCount++
-		}
-	}
-	logger_vn.Infof("loaded %v validator names from inventory api (%v)", nameCount, utils.GetRedactedUrl(apiUrl))
-	return nil
+	names := vn.parseNamesMap(rangesResponse.Ranges)
+	logger_vn.Infof("loaded %v validator names from inventory api (%v)", len(names), utils.GetRedactedUrl(apiUrl))
+	return names, nil
 }
 
 func (vn *ValidatorNames) updateDb() error {
+	now := uint64(time.Now().Unix())
 	vn.namesMutex.RLock()
 	nameRows := make([]*dbtypes.ValidatorName, 0)
 	for index, name := range vn.names {
-		nameRows = append(nameRows, &dbtypes.ValidatorName{
-			Index: index,
-			Name:  name,
-		})
+		nameRow := &dbtypes.ValidatorName{
+			Index:   index,
+			Name:    name,
+			Updated: now,
+		}
+		if nameSource := vn.nameSources[index]; nameSource != nil {
+			nameRow.Source = nameSource.source
+			nameRow.SourceName = nameSource.sourceName
+		}
+		nameRows = append(nameRows, nameRow)
 	}
 	vn.namesMutex.RUnlock()
 
@@ -219,7 +401,7 @@ func (vn *ValidatorNames) updateDb() error {
 	}
 	defer tx.Rollback()
 
-	batchSize := 10000
+	batchSize := 5000
 
 	lastIndex := uint64(0)
 	nameIdx := 0
@@ -234,25 +416,37 @@ func (vn *ValidatorNames) updateDb() error {
 		maxIndex := namesSlice[sliceLen-1].Index
 
 		// get existing db entries
-		dbNamesMap := map[uint64]string{}
+		dbNamesMap := map[uint64]*dbtypes.ValidatorName{}
 		for _, dbName := range db.GetValidatorNames(lastIndex, maxIndex, tx) {
-			dbNamesMap[dbName.Index] = dbName.Name
+			dbNamesMap[dbName.Index] = dbName
 		}
 
-		// get diffs
+		// get diffs, every change of the name or its source is recorded in the name history
 		updateNames := make([]*dbtypes.ValidatorName, 0)
+		nameHistory := make([]*dbtypes.ValidatorNameHistory, 0)
 		for _, nameRow := range namesSlice {
 			dbName := dbNamesMap[nameRow.Index]
 			delete(dbNamesMap, nameRow.Index)
-			if dbName == nameRow.Name {
+			if dbName != nil && dbName.Name == nameRow.Name && dbName.Source == nameRow.Source && dbName.SourceName == nameRow.SourceName {
 				continue // no update
 			}
 			updateNames = append(updateNames, nameRow)
+			nameHistory = append(nameHistory, &dbtypes.ValidatorNameHistory{
+				Index:      nameRow.Index,
+				Changed:    now,
+				Name:       nameRow.Name,
+				Source:     nameRow.Source,
+				SourceName: nameRow.SourceName,
+			})
 		}
 
 		removeIndexes := make([]uint64, 0)
 		for index := range dbNamesMap {
 			removeIndexes = append(removeIndexes, index)
+			nameHistory = append(nameHistory, &dbtypes.ValidatorNameHistory{
+				Index:   index,
+				Changed: now,
+			})
 		}
 
 		if len(updateNames) > 0 {
@@ -267,6 +461,17 @@ func (vn *ValidatorNames) updateDb() error {
 				logger_vn.WithError(err).Errorf("error while deleting validator names from db")
 			}
 		}
+		for len(nameHistory) > 0 {
+			historySlice := nameHistory
+			if len(historySlice) > batchSize {
+				historySlice = historySlice[:batchSize]
+			}
+			nameHistory = nameHistory[len(historySlice):]
+			err := db.InsertValidatorNameHistory(historySlice, tx)
+			if err != nil {
+				logger_vn.WithError(err).Errorf("error while adding validator name history to db")
+			}
+		}
 		logger_vn.Debugf("update validator names %v-%v: %v changed, %v removed", lastIndex, maxIdx, len(updateNames), len(removeIndexes))
 
 		lastIndex = maxIndex + 1
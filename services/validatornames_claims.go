@@ -0,0 +1,138 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/pk910/dora/db"
+	"github.com/pk910/dora/dbtypes"
+	"github.com/pk910/dora/utils"
+)
+
+const nameClaimMaxLength = 50
+
+var (
+	ErrNameClaimsDisabled     = errors.New("name claims are disabled")
+	ErrNameClaimInvalidName   = fmt.Errorf("name must be 1-%v printable characters", nameClaimMaxLength)
+	ErrNameClaimUnknown       = errors.New("unknown validator")
+	ErrNameClaimBadSignature  = errors.New("invalid signature")
+	ErrNameClaimAlreadyActive = errors.New("the validator already has this name")
+)
+
+// GetNameClaimMessage returns the message the validator key has to sign to claim the name.
+// The network name & genesis validators root are part of the message, so claims can't be replayed on other
+// networks or on a relaunch of the same network.
+func GetNameClaimMessage(validatorIndex uint64, name string, genesisValidatorsRoot []byte) string {
+	return fmt.Sprintf("I am the operator of validator %v on %v (genesis 0x%x) and want it to be named \"%v\"", validatorIndex, utils.Config.Chain.Config.ConfigName, genesisValidatorsRoot, name)
+}
+
+func checkNameClaimName(name string) error {
+	if name == "" || len([]rune(name)) > nameClaimMaxLength || strings.TrimSpace(name) != name {
+		return ErrNameClaimInvalidName
+	}
+	for _, char := range name {
+		if !unicode.IsPrint(char) {
+			return ErrNameClaimInvalidName
+		}
+	}
+	return nil
+}
+
+// SubmitNameClaim verifies the signature of a name claim with the validator pubkey and queues it for moderation
+func (vn *ValidatorNames) SubmitNameClaim(validatorIndex uint64, name string, signature []byte) error {
+	if !utils.Config.Frontend.NameClaimsEnabled {
+		return ErrNameClaimsDisabled
+	}
+	if err := checkNameClaimName(name); err != nil {
+		return err
+	}
+	validatorSet := vn.indexer.GetCachedValidatorSet()
+	genesis := vn.indexer.GetCachedGenesis()
+	if validatorSet == nil || genesis == nil {
+		return errValidatorSetNotReady
+	}
+	validator := validatorSet[phase0.ValidatorIndex(validatorIndex)]
+	if validator == nil || validator.Validator == nil {
+		return ErrNameClaimUnknown
+	}
+	if vn.GetValidatorName(validatorIndex) == name {
+		return ErrNameClaimAlreadyActive
+	}
+
+	pubkey := validator.Validator.PublicKey
+	valid, err := utils.VerifyBlsSignature(pubkey[:], []byte(GetNameClaimMessage(validatorIndex, name, genesis.GenesisValidatorsRoot[:])), signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNameClaimBadSignature, err)
+	}
+	if !valid {
+		return ErrNameClaimBadSignature
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = db.InsertValidatorNameClaim(&dbtypes.ValidatorNameClaim{
+		ValidatorIndex: validatorIndex,
+		Name:           name,
+		Signature:      signature,
+		Status:         dbtypes.NameClaimStatusPending,
+		Created:        uint64(time.Now().Unix()),
+	}, tx)
+	if err != nil {
+		return fmt.Errorf("error adding name claim to db: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+	logger_vn.Infof("validator %v claimed name \"%v\", awaiting moderation", validatorIndex, name)
+	return nil
+}
+
+// ReviewNameClaim approves or rejects a pending name claim. Approved names are applied with the next names reload.
+func (vn *ValidatorNames) ReviewNameClaim(validatorIndex uint64, name string, approve bool) error {
+	status := dbtypes.NameClaimStatusRejected
+	if approve {
+		status = dbtypes.NameClaimStatusApproved
+	}
+
+	tx, err := db.WriterDb.Beginx()
+	if err != nil {
+		return fmt.Errorf("error starting db transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	err = db.UpdateValidatorNameClaimStatus(validatorIndex, name, status, uint64(time.Now().Unix()), tx)
+	if err != nil {
+		return fmt.Errorf("error updating name claim: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing db transaction: %v", err)
+	}
+
+	if approve {
+		logger_vn.Infof("approved name \"%v\" for validator %v", name, validatorIndex)
+		vn.LoadValidatorNames()
+	}
+	return nil
+}
+
+// loadClaimedNames returns the approved name claims, which take precedence over all configured sources
+func (vn *ValidatorNames) loadClaimedNames() map[uint64]string {
+	names := map[uint64]string{}
+	if !utils.Config.Frontend.NameClaimsEnabled {
+		return names
+	}
+	for _, claim := range db.GetValidatorNameClaims(dbtypes.NameClaimStatusApproved, math.MaxInt32) {
+		names[claim.ValidatorIndex] = claim.Name
+	}
+	return names
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var errValidatorSetNotReady = errors.New("validator set not loaded yet")
+
+var depositPubkeyDirRE = regexp.MustCompile(`^0x[0-9a-fA-F]{96}$`)
+
+type depositDataEntry struct {
+	Pubkey string `json:"pubkey"`
+}
+
+// loadFromDepositData names validators by the pubkeys found in deposit data files (deposit_data-*.json),
+// keystores or key directories (kurtosis/genesis validator_keys layout).
+// For directories, the first sub directory is used as operator label (eg. "node-1-keystores"), files
+// placed directly in the source directory or a single file source are labeled with the source name.
+func (vn *ValidatorNames) loadFromDepositData(sourceName string, path string) (map[uint64]string, error) {
+	pubkeyLabels := map[string]string{}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening deposit data %v: %v", path, err)
+	}
+	if !stat.IsDir() {
+		label := sourceName
+		if label == "" {
+			label = strings.TrimSuffix(stat.Name(), filepath.Ext(stat.Name()))
+		}
+		pubkeys, err := vn.parseDepositDataFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, pubkey := range pubkeys {
+			pubkeyLabels[string(pubkey)] = label
+		}
+	} else {
+		err = filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			label := sourceName
+			if relPath, err := filepath.Rel(path, filePath); err == nil {
+				if parts := strings.Split(relPath, string(filepath.Separator)); len(parts) > 1 {
+					label = parts[0]
+				}
+			}
+
+			if entry.IsDir() {
+				// lighthouse/teku style key directories are named by the pubkey
+				if depositPubkeyDirRE.MatchString(entry.Name()) {
+					if pubkey, err := hex.DecodeString(entry.Name()[2:]); err == nil {
+						pubkeyLabels[string(pubkey)] = label
+					}
+				}
+				return nil
+			}
+			if filepath.Ext(entry.Name()) != ".json" {
+				return nil
+			}
+			pubkeys, err := vn.parseDepositDataFile(filePath)
+			if err != nil {
+				// not every json file in a key directory is a keystore
+				logger_vn.Debugf("skipping %v: %v", filePath, err)
+				return nil
+			}
+			for _, pubkey := range pubkeys {
+				pubkeyLabels[string(pubkey)] = label
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading deposit data directory %v: %v", path, err)
+		}
+	}
+
+	names, err := vn.mapPubkeyLabels(pubkeyLabels)
+	if err != nil {
+		return nil, err
+	}
+	logger_vn.Infof("loaded %v validator names from deposit data (%v pubkeys, %v)", len(names), len(pubkeyLabels), path)
+	return names, nil
+}
+
+// mapPubkeyLabels resolves the validator indexes of labeled pubkeys, pubkeys that are not in the validator set are skipped
+func (vn *ValidatorNames) mapPubkeyLabels(pubkeyLabels map[string]string) (map[uint64]string, error) {
+	var validatorSet map[string]uint64
+	if vn.indexer != nil {
+		if validators := vn.indexer.GetCachedValidatorSet(); validators != nil {
+			validatorSet = make(map[string]uint64, len(validators))
+			for index, validator := range validators {
+				validatorSet[string(validator.Validator.PublicKey[:])] = uint64(index)
+			}
+		}
+	}
+	if validatorSet == nil {
+		return nil, errValidatorSetNotReady
+	}
+
+	names := make(map[uint64]string)
+	for pubkey, label := range pubkeyLabels {
+		if index, found := validatorSet[pubkey]; found {
+			names[index] = label
+		}
+	}
+	return names, nil
+}
+
+// parseDepositDataFile returns the pubkeys of a deposit data file (list of deposits) or a keystore
+func (vn *ValidatorNames) parseDepositDataFile(fileName string) ([][]byte, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %v: %v", fileName, err)
+	}
+
+	entries := []*depositDataEntry{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		keystore := &depositDataEntry{}
+		if err := json.Unmarshal(data, keystore); err != nil {
+			return nil, fmt.Errorf("error parsing %v: %v", fileName, err)
+		}
+		entries = append(entries, keystore)
+	}
+
+	pubkeys := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		pubkey, err := hex.DecodeString(strings.TrimPrefix(entry.Pubkey, "0x"))
+		if err != nil || len(pubkey) != 48 {
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	if len(pubkeys) == 0 {
+		return nil, fmt.Errorf("no pubkeys found in %v", fileName)
+	}
+	return pubkeys, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/pk910/dora/utils"
+)
+
+// validatorNamesMnemonic is an entry of a mnemonic names file, the ranges map key indexes of the mnemonic
+// (EIP-2334 path m/12381/3600/<index>/0/0) or key index ranges ("<min>-<max>") to names
+type validatorNamesMnemonic struct {
+	Mnemonic   string            `yaml:"mnemonic"`
+	Passphrase string            `yaml:"passphrase"`
+	Ranges     map[string]string `yaml:"ranges"`
+}
+
+// loadFromMnemonics names validators by the pubkeys derived from the devnet mnemonics in a yaml file.
+// The file is a list of mnemonics with the names of their key index ranges (see validatorNamesSources in the default config).
+// The derived pubkeys are kept in memory, so only new key indexes are derived when the names are reloaded.
+func (vn *ValidatorNames) loadFromMnemonics(fileName string) (map[uint64]string, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("error opening mnemonics file %v: %v", fileName, err)
+	}
+	mnemonics := []*validatorNamesMnemonic{}
+	if err := yaml.Unmarshal(data, &mnemonics); err != nil {
+		return nil, fmt.Errorf("error decoding mnemonics file %v: %v", fileName, err)
+	}
+
+	pubkeyLabels := map[string]string{}
+	for mnemonicIdx, mnemonic := range mnemonics {
+		var seed []byte
+		seedHash := sha256.Sum256([]byte(mnemonic.Mnemonic + "\x00" + mnemonic.Passphrase))
+		for rangeStr, name := range mnemonic.Ranges {
+			rangeParts := strings.Split(rangeStr, "-")
+			minIdx, err := strconv.ParseUint(rangeParts[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key range %v of mnemonic %v: %v", rangeStr, mnemonicIdx, err)
+			}
+			maxIdx := minIdx
+			if len(rangeParts) > 1 {
+				maxIdx, err = strconv.ParseUint(rangeParts[1], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key range %v of mnemonic %v: %v", rangeStr, mnemonicIdx, err)
+				}
+			}
+
+			for keyIdx := minIdx; keyIdx <= maxIdx; keyIdx++ {
+				cacheKey := fmt.Sprintf("%x:%v", seedHash, keyIdx)
+				vn.mnemonicMutex.Lock()
+				pubkey := vn.mnemonicPubkeys[cacheKey]
+				vn.mnemonicMutex.Unlock()
+				if pubkey == nil {
+					if seed == nil {
+						seed = utils.MnemonicToSeed(mnemonic.Mnemonic, mnemonic.Passphrase)
+					}
+					pubkey, err = utils.DeriveValidatorPubkey(seed, keyIdx)
+					if err != nil {
+						return nil, fmt.Errorf("error deriving key %v of mnemonic %v: %v", keyIdx, mnemonicIdx, err)
+					}
+					vn.mnemonicMutex.Lock()
+					if vn.mnemonicPubkeys == nil {
+						vn.mnemonicPubkeys = map[string][]byte{}
+					}
+					vn.mnemonicPubkeys[cacheKey] = pubkey
+					vn.mnemonicMutex.Unlock()
+				}
+				pubkeyLabels[string(pubkey)] = name
+			}
+		}
+	}
+
+	names, err := vn.mapPubkeyLabels(pubkeyLabels)
+	if err != nil {
+		return nil, err
+	}
+	logger_vn.Infof("loaded %v validator names from mnemonics (%v pubkeys, %v)", len(names), len(pubkeyLabels), fileName)
+	return names, nil
+}
@@ -4,11 +4,13 @@
     initControls();
     window.setInterval(updateTimers, 1000);
     initHeaderSearch();
+    loadPartials();
   });
   var tooltipDict = {};
   var tooltipIdx = 1;
   window.explorer = {
     initControls: initControls,
+    loadPartials: loadPartials,
     renderRecentTime: renderRecentTime,
     tooltipDict: tooltipDict,
   };
@@ -23,6 +25,37 @@
     document.querySelectorAll("[data-clipboard-target]").forEach(initCopyBtn);
   }
 
+  function loadPartials() {
+    // replace the placeholders of the page shell with the server rendered page fragments
+    document.querySelectorAll("[data-partial-src]").forEach(function(el) {
+      var src = el.getAttribute("data-partial-src");
+      el.removeAttribute("data-partial-src");
+      fetch(src).then(function(rsp) {
+        return rsp.text().then(function(body) {
+          if(!rsp.ok)
+            throw new Error(body || rsp.statusText);
+          return body;
+        });
+      }).then(function(html) {
+        var fragment = document.createRange().createContextualFragment(html);
+        el.replaceWith(fragment);
+        initControls();
+        updateTimers();
+      }).catch(function(err) {
+        var loadingEl = el.querySelector(".partial-loading") || el;
+        loadingEl.textContent = "";
+        var errorEl = document.createElement("div");
+        errorEl.className = "text-danger";
+        errorEl.textContent = err.message;
+        var retryEl = document.createElement("a");
+        retryEl.href = window.location.href;
+        retryEl.textContent = "Reload page";
+        loadingEl.appendChild(errorEl);
+        loadingEl.appendChild(retryEl);
+      });
+    });
+  }
+
   function initTooltip(el) {
     if($(el).data("tooltip-init"))
       return;
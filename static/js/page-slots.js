@@ -0,0 +1,85 @@
+(function() {
+  window.addEventListener('DOMContentLoaded', function() {
+    pendingEl = document.getElementById("pending-slot");
+    if(!pendingEl)
+      return;
+    updateCountdown();
+    refresh();
+    window.setInterval(updateCountdown, 250);
+    window.setInterval(refresh, 2000);
+  });
+
+  var pendingEl = null;
+  var isRefreshing = false;
+
+  async function refresh() {
+    if(isRefreshing)
+      return;
+    isRefreshing = true;
+    try {
+      var pendingData = await $.get("/slots/pending/data");
+      updatePendingSlot(pendingData);
+    } finally {
+      isRefreshing = false;
+    }
+  }
+
+  function updatePendingSlot(data) {
+    pendingEl.setAttribute("data-end", Math.floor(new Date(data.end_ts).getTime() / 1000));
+    pendingEl.setAttribute("data-status", data.status);
+
+    var slotLink = pendingEl.querySelector(".pending-slot-link");
+    slotLink.innerText = data.slot.toLocaleString("en-US");
+    slotLink.setAttribute("href", data.block_root ? "/slot/" + data.block_root : "/slot/" + data.slot);
+    pendingEl.querySelector(".pending-slot-epoch").innerText = data.epoch.toLocaleString("en-US");
+    pendingEl.querySelector(".pending-slot-proposer").innerHTML = data.has_proposer ? formatValidator(data.proposer, data.proposer_name) : "unknown";
+    renderStatus(data.status, data.seen_delay);
+    window.explorer.initControls();
+  }
+
+  function updateCountdown() {
+    var endTime = parseInt(pendingEl.getAttribute("data-end"));
+    var timeLeft = endTime - new Date().getTime() / 1000;
+    var countdownEl = pendingEl.querySelector(".pending-slot-countdown");
+    if(timeLeft > 0) {
+      countdownEl.innerText = "Slot ends in " + Math.ceil(timeLeft) + "s";
+    } else {
+      countdownEl.innerText = "Slot ended";
+      if(pendingEl.getAttribute("data-status") == "awaiting") {
+        // no block seen until the end of the slot
+        pendingEl.setAttribute("data-status", "missed");
+        renderStatus("missed");
+      }
+    }
+  }
+
+  function renderStatus(status, seenDelay) {
+    var statusEl = pendingEl.querySelector(".pending-slot-status");
+    if(status == "seen") {
+      var seenStr = seenDelay ? " at +" + seenDelay.toFixed(2) + "s" : "";
+      statusEl.innerHTML = '<span class="badge rounded-pill text-bg-success">Block seen' + seenStr + '</span>';
+    } else if(status == "missed") {
+      statusEl.innerHTML = '<span class="badge rounded-pill text-bg-warning">Missed</span>';
+    } else {
+      statusEl.innerHTML = '<span class="badge rounded-pill text-bg-secondary">Awaiting block</span>';
+    }
+  }
+
+  function escapeHtml(unsafe) {
+    return unsafe
+      .replace(/&/g, "&amp;")
+      .replace(/</g, "&lt;")
+      .replace(/>/g, "&gt;")
+      .replace(/"/g, "&quot;")
+      .replace(/'/g, "&#039;");
+  }
+
+  function formatValidator(idx, name) {
+    var icon = "fa-male mr-2";
+    if(name != "") {
+      return `<span class="validator-label validator-name" data-bs-toggle="tooltip" data-bs-placement="top" data-bs-title="` + idx + `"><i class="fas ` + icon + `"></i> <a href="/validator/` + idx + `">` + escapeHtml(name) + `</a></span>`;
+    }
+    return `<span class="validator-label validator-index"><i class="fas ` + icon + `"></i> <a href="/validator/` + idx + `">` + idx + `</a></span>`
+  }
+
+})()
@@ -37,6 +37,7 @@ type ChainConfig struct {
 	EjectionBalance                  uint64 `yaml:"EJECTION_BALANCE"`
 	MinPerEpochChurnLimit            uint64 `yaml:"MIN_PER_EPOCH_CHURN_LIMIT"`
 	ChurnLimitQuotient               uint64 `yaml:"CHURN_LIMIT_QUOTIENT"`
+	MaxPerEpochActivationChurnLimit  uint64 `yaml:"MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT"`
 	ProposerScoreBoost               uint64 `yaml:"PROPOSER_SCORE_BOOST"`
 	DepositChainID                   uint64 `yaml:"DEPOSIT_CHAIN_ID"`
 	DepositNetworkID                 uint64 `yaml:"DEPOSIT_NETWORK_ID"`
@@ -102,4 +103,15 @@ type ChainConfig struct {
 	MaxWithdrawalsPerPayload        uint64 `yaml:"MAX_WITHDRAWALS_PER_PAYLOAD"`
 	MaxValidatorsPerWithdrawalSweep uint64 `yaml:"MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP"`
 	MaxBlsToExecutionChange         uint64 `yaml:"MAX_BLS_TO_EXECUTION_CHANGES"`
+
+	// electra
+	// https://github.com/ethereum/consensus-specs/blob/dev/presets/mainnet/electra.yaml
+	MaxEffectiveBalanceElectra uint64 `yaml:"MAX_EFFECTIVE_BALANCE_ELECTRA"`
+
+	// peerdas (eip7594)
+	// https://github.com/ethereum/consensus-specs/blob/dev/configs/mainnet.yaml
+	Eip7594ForkEpoch             *uint64 `yaml:"EIP7594_FORK_EPOCH"`
+	NumberOfColumns              uint64  `yaml:"NUMBER_OF_COLUMNS"`
+	DataColumnSidecarSubnetCount uint64  `yaml:"DATA_COLUMN_SIDECAR_SUBNET_COUNT"`
+	CustodyRequirement           uint64  `yaml:"CUSTODY_REQUIREMENT"`
 }
@@ -15,6 +15,16 @@ type Config struct {
 	Server struct {
 		Port string `yaml:"port" envconfig:"FRONTEND_SERVER_PORT"`
 		Host string `yaml:"host" envconfig:"FRONTEND_SERVER_HOST"`
+
+		Tls struct {
+			CertFile     string   `yaml:"certFile" envconfig:"FRONTEND_SERVER_TLS_CERT_FILE"`
+			KeyFile      string   `yaml:"keyFile" envconfig:"FRONTEND_SERVER_TLS_KEY_FILE"`
+			AcmeDomains  []string `yaml:"acmeDomains" envconfig:"FRONTEND_SERVER_TLS_ACME_DOMAINS"`
+			AcmeEmail    string   `yaml:"acmeEmail" envconfig:"FRONTEND_SERVER_TLS_ACME_EMAIL"`
+			AcmeCacheDir string   `yaml:"acmeCacheDir" envconfig:"FRONTEND_SERVER_TLS_ACME_CACHE_DIR"`
+		} `yaml:"tls"`
+
+		Listeners []ListenerConfig `yaml:"listeners"`
 	} `yaml:"server"`
 
 	Chain struct {
@@ -26,6 +36,9 @@ type Config struct {
 
 		// optional features
 		WhiskForkEpoch *uint64 `yaml:"whiskForkEpoch" envconfig:"WHISK_FORK_EPOCH"`
+
+		// experimental devnet forks, that are not supported by the beacon api library
+		CustomForks []CustomForkConfig `yaml:"customForks"`
 	} `yaml:"chain"`
 
 	Frontend struct {
@@ -42,7 +55,42 @@ type Config struct {
 		ValidatorNamesYaml      string `yaml:"validatorNamesYaml" envconfig:"FRONTEND_VALIDATOR_NAMES_YAML"`
 		ValidatorNamesInventory string `yaml:"validatorNamesInventory" envconfig:"FRONTEND_VALIDATOR_NAMES_INVENTORY"`
 
+		ValidatorNamesSources         []ValidatorNamesSourceConfig `yaml:"validatorNamesSources"`
+		ValidatorNamesRefreshInterval time.Duration                `yaml:"validatorNamesRefreshInterval" envconfig:"FRONTEND_VALIDATOR_NAMES_REFRESH_INTERVAL"`
+
+		ValidatorMetadataSources         []ValidatorMetadataSourceConfig `yaml:"validatorMetadataSources"`
+		ValidatorMetadataRefreshInterval time.Duration                   `yaml:"validatorMetadataRefreshInterval" envconfig:"FRONTEND_VALIDATOR_METADATA_REFRESH_INTERVAL"`
+
+		FeeRecipients []FeeRecipientConfig `yaml:"feeRecipients"`
+
+		ValidatorClients                []ValidatorClientConfig `yaml:"validatorClients"`
+		ValidatorClientsRefreshInterval time.Duration           `yaml:"validatorClientsRefreshInterval" envconfig:"FRONTEND_VALIDATOR_CLIENTS_REFRESH_INTERVAL"`
+
+		IndexWidgets []IndexWidgetConfig `yaml:"indexWidgets"`
+
+		StateTransitionEndpoint string `yaml:"stateTransitionEndpoint" envconfig:"FRONTEND_STATE_TRANSITION_ENDPOINT"`
+
+		ActivityApiTokens        []string `yaml:"activityApiTokens"`
+		ActivityApiMaxValidators uint     `yaml:"activityApiMaxValidators" envconfig:"FRONTEND_ACTIVITY_API_MAX_VALIDATORS"`
+
+		NameClaimsEnabled     bool     `yaml:"nameClaimsEnabled" envconfig:"FRONTEND_NAME_CLAIMS_ENABLED"`
+		NameClaimsAdminTokens []string `yaml:"nameClaimsAdminTokens"`
+
+		AnnotationTokens []string `yaml:"annotationTokens"`
+
+		IndexerStateTokens []string `yaml:"indexerStateTokens"`
+
+		EventStreamEnabled   bool `yaml:"eventStreamEnabled" envconfig:"FRONTEND_EVENT_STREAM_ENABLED"`
+		CheckpointApiEnabled bool `yaml:"checkpointApiEnabled" envconfig:"FRONTEND_CHECKPOINT_API_ENABLED"`
+		ProofApiEnabled      bool `yaml:"proofApiEnabled" envconfig:"FRONTEND_PROOF_API_ENABLED"`
+
+		OverviewApiEnabled bool          `yaml:"overviewApiEnabled" envconfig:"FRONTEND_OVERVIEW_API_ENABLED"`
+		OverviewApiWindow  time.Duration `yaml:"overviewApiWindow" envconfig:"FRONTEND_OVERVIEW_API_WINDOW"`
+
+		AttestationExportEnabled bool `yaml:"attestationExportEnabled" envconfig:"FRONTEND_ATTESTATION_EXPORT_ENABLED"`
+
 		PageCallTimeout  time.Duration `yaml:"pageCallTimeout" envconfig:"FRONTEND_PAGE_CALL_TIMEOUT"`
+		StaleCacheWindow time.Duration `yaml:"staleCacheWindow" envconfig:"FRONTEND_STALE_CACHE_WINDOW"`
 		HttpReadTimeout  time.Duration `yaml:"httpReadTimeout" envconfig:"FRONTEND_HTTP_READ_TIMEOUT"`
 		HttpWriteTimeout time.Duration `yaml:"httpWriteTimeout" envconfig:"FRONTEND_HTTP_WRITE_TIMEOUT"`
 		HttpIdleTimeout  time.Duration `yaml:"httpIdleTimeout" envconfig:"FRONTEND_HTTP_IDLE_TIMEOUT"`
@@ -52,22 +100,74 @@ type Config struct {
 		Endpoint  string           `yaml:"endpoint" envconfig:"BEACONAPI_ENDPOINT"`
 		Endpoints []EndpointConfig `yaml:"endpoints"`
 
+		SszFetching      bool `yaml:"sszFetching" envconfig:"BEACONAPI_SSZ_FETCHING"`
+		SchemaValidation bool `yaml:"schemaValidation" envconfig:"BEACONAPI_SCHEMA_VALIDATION"`
+
+		Timeouts struct {
+			Default time.Duration `yaml:"default" envconfig:"BEACONAPI_TIMEOUT_DEFAULT"`
+			Header  time.Duration `yaml:"header" envconfig:"BEACONAPI_TIMEOUT_HEADER"`
+			Block   time.Duration `yaml:"block" envconfig:"BEACONAPI_TIMEOUT_BLOCK"`
+			Duties  time.Duration `yaml:"duties" envconfig:"BEACONAPI_TIMEOUT_DUTIES"`
+			State   time.Duration `yaml:"state" envconfig:"BEACONAPI_TIMEOUT_STATE"`
+		} `yaml:"timeouts"`
+
 		LocalCacheSize       int    `yaml:"localCacheSize" envconfig:"BEACONAPI_LOCAL_CACHE_SIZE"`
 		SkipFinalAssignments bool   `yaml:"skipFinalAssignments" envconfig:"BEACONAPI_SKIP_FINAL_ASSIGNMENTS"`
 		AssignmentsCacheSize int    `yaml:"assignmentsCacheSize" envconfig:"BEACONAPI_ASSIGNMENTS_CACHE_SIZE"`
+		DutiesLookahead      uint64 `yaml:"dutiesLookahead" envconfig:"BEACONAPI_DUTIES_LOOKAHEAD"`
 		RedisCacheAddr       string `yaml:"redisCacheAddr" envconfig:"BEACONAPI_REDIS_CACHE_ADDR"`
 		RedisCachePrefix     string `yaml:"redisCachePrefix" envconfig:"BEACONAPI_REDIS_CACHE_PREFIX"`
+
+		EndpointStatusInterval time.Duration `yaml:"endpointStatusInterval" envconfig:"BEACONAPI_ENDPOINT_STATUS_INTERVAL"`
 	} `yaml:"beaconapi"`
 
+	ExecutionApi struct {
+		Endpoint string `yaml:"endpoint" envconfig:"EXECUTIONAPI_ENDPOINT"`
+	} `yaml:"executionapi"`
+
 	Indexer struct {
 		InMemoryEpochs                  uint16 `yaml:"inMemoryEpochs" envconfig:"INDEXER_IN_MEMORY_EPOCHS"`
 		CachePersistenceDelay           uint16 `yaml:"cachePersistenceDelay" envconfig:"INDEXER_CACHE_PERSISTENCE_DELAY"`
+		EpochQueueThreshold             uint16 `yaml:"epochQueueThreshold" envconfig:"INDEXER_EPOCH_QUEUE_THRESHOLD"`
 		DisableIndexWriter              bool   `yaml:"disableIndexWriter" envconfig:"INDEXER_DISABLE_INDEX_WRITER"`
 		DisableSynchronizer             bool   `yaml:"disableSynchronizer" envconfig:"INDEXER_DISABLE_SYNCHRONIZER"`
 		SyncEpochCooldown               uint   `yaml:"syncEpochCooldown" envconfig:"INDEXER_SYNC_EPOCH_COOLDOWN"`
 		MaxParallelValidatorSetRequests uint   `yaml:"maxParallelValidatorSetRequests" envconfig:"INDEXER_MAX_PARALLEL_VALIDATOR_SET_REQUESTS"`
+		DisableAdaptivePolling          bool   `yaml:"disableAdaptivePolling" envconfig:"INDEXER_DISABLE_ADAPTIVE_POLLING"`
+		ResetDbOnChainChange            bool   `yaml:"resetDbOnChainChange" envconfig:"INDEXER_RESET_DB_ON_CHAIN_CHANGE"`
+		HeadSelection                   string `yaml:"headSelection" envconfig:"INDEXER_HEAD_SELECTION"` // clients / priority
+
+		WatchedWithdrawalAddresses []WatchedAddressConfig `yaml:"watchedWithdrawalAddresses"`
+
+		TrackBlockRewards    bool             `yaml:"trackBlockRewards" envconfig:"INDEXER_TRACK_BLOCK_REWARDS"`
+		TrackProposerRewards bool             `yaml:"trackProposerRewards" envconfig:"INDEXER_TRACK_PROPOSER_REWARDS"`
+		MevRelays            []MevRelayConfig `yaml:"mevRelays"`
 	} `yaml:"indexer"`
 
+	Notifications struct {
+		Webhooks []NotificationWebhookConfig `yaml:"webhooks"`
+	} `yaml:"notifications"`
+
+	Alerts struct {
+		MinParticipation float64 `yaml:"minParticipation" envconfig:"ALERTS_MIN_PARTICIPATION"` // target vote participation in percent (0 = disabled)
+		MaxMissedSlots   *uint64 `yaml:"maxMissedSlots" envconfig:"ALERTS_MAX_MISSED_SLOTS"`    // missed slots per epoch (unset = disabled)
+
+		MaxBalanceDeviation float64  `yaml:"maxBalanceDeviation" envconfig:"ALERTS_MAX_BALANCE_DEVIATION"` // shortfall of a watched validators balance growth vs the network median in percent (0 = disabled)
+		WatchedValidators   []uint64 `yaml:"watchedValidators"`                                            // validators to check the balance growth for, in addition to the validators of the validator clients
+
+		BlsSpotCheckRate float64 `yaml:"blsSpotCheckRate" envconfig:"ALERTS_BLS_SPOT_CHECK_RATE"` // share of new blocks to re-verify the signatures for (0 = disabled)
+
+		SlashingRiskMonitor bool `yaml:"slashingRiskMonitor" envconfig:"ALERTS_SLASHING_RISK_MONITOR"` // check the votes in all unfinalized blocks for double & surround votes
+
+		BeaconRootCheck bool `yaml:"beaconRootCheck" envconfig:"ALERTS_BEACON_ROOT_CHECK"` // cross-check the EIP-4788 beacon roots contract via the executionapi endpoint
+	} `yaml:"alerts"`
+
+	Federation struct {
+		ArchiveUrl        string `yaml:"archiveUrl" envconfig:"FEDERATION_ARCHIVE_URL"`                // base url of the dora instance serving the older epochs
+		ArchiveBelowEpoch uint64 `yaml:"archiveBelowEpoch" envconfig:"FEDERATION_ARCHIVE_BELOW_EPOCH"` // epochs before this are loaded from the archive instance (0 = lowest epoch in the local db)
+		ServeArchiveApi   bool   `yaml:"serveArchiveApi" envconfig:"FEDERATION_SERVE_ARCHIVE_API"`     // serve the archive api for other instances
+	} `yaml:"federation"`
+
 	BlobStore struct {
 		PersistenceMode string `yaml:"persistenceMode" envconfig:"BLOBSTORE_PERSISTENCE_MODE"`
 		NameTemplate    string `yaml:"nameTemplate" envconfig:"BLOBSTORE_NAME_TEMPLATE"`
@@ -85,40 +185,57 @@ type Config struct {
 
 	Database struct {
 		Engine string `yaml:"engine" envconfig:"DATABASE_ENGINE"`
+
+		SlowQueryThreshold time.Duration `yaml:"slowQueryThreshold" envconfig:"DATABASE_SLOW_QUERY_THRESHOLD"`
+
+		Maintenance struct {
+			Enabled         bool          `yaml:"enabled" envconfig:"DATABASE_MAINTENANCE_ENABLED"`
+			Window          string        `yaml:"window" envconfig:"DATABASE_MAINTENANCE_WINDOW"`                    // off-peak window in UTC, e.g. "02:00-05:00" (empty = any time)
+			VacuumInterval  time.Duration `yaml:"vacuumInterval" envconfig:"DATABASE_MAINTENANCE_VACUUM_INTERVAL"`   // default 24h
+			ReindexInterval time.Duration `yaml:"reindexInterval" envconfig:"DATABASE_MAINTENANCE_REINDEX_INTERVAL"` // 0 = no index rebuilds
+		} `yaml:"maintenance"`
+
 		Sqlite struct {
-			File         string `yaml:"file" envconfig:"DATABASE_SQLITE_FILE"`
-			MaxOpenConns int    `yaml:"maxOpenConns" envconfig:"DATABASE_SQLITE_MAX_OPEN_CONNS"`
-			MaxIdleConns int    `yaml:"maxIdleConns" envconfig:"DATABASE_SQLITE_MAX_IDLE_CONNS"`
+			File            string        `yaml:"file" envconfig:"DATABASE_SQLITE_FILE"`
+			MaxOpenConns    int           `yaml:"maxOpenConns" envconfig:"DATABASE_SQLITE_MAX_OPEN_CONNS"`
+			MaxIdleConns    int           `yaml:"maxIdleConns" envconfig:"DATABASE_SQLITE_MAX_IDLE_CONNS"`
+			ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" envconfig:"DATABASE_SQLITE_CONN_MAX_LIFETIME"`
+			ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime" envconfig:"DATABASE_SQLITE_CONN_MAX_IDLE_TIME"`
 		} `yaml:"sqlite"`
 		Pgsql struct {
-			Username     string `yaml:"user" envconfig:"DATABASE_PGSQL_USERNAME"`
-			Password     string `yaml:"password" envconfig:"DATABASE_PGSQL_PASSWORD"`
-			Name         string `yaml:"name" envconfig:"DATABASE_PGSQL_NAME"`
-			Host         string `yaml:"host" envconfig:"DATABASE_PGSQL_HOST"`
-			Port         string `yaml:"port" envconfig:"DATABASE_PGSQL_PORT"`
-			MaxOpenConns int    `yaml:"maxOpenConns" envconfig:"DATABASE_PGSQL_MAX_OPEN_CONNS"`
-			MaxIdleConns int    `yaml:"maxIdleConns" envconfig:"DATABASE_PGSQL_MAX_IDLE_CONNS"`
+			Username        string        `yaml:"user" envconfig:"DATABASE_PGSQL_USERNAME"`
+			Password        string        `yaml:"password" envconfig:"DATABASE_PGSQL_PASSWORD"`
+			Name            string        `yaml:"name" envconfig:"DATABASE_PGSQL_NAME"`
+			Host            string        `yaml:"host" envconfig:"DATABASE_PGSQL_HOST"`
+			Port            string        `yaml:"port" envconfig:"DATABASE_PGSQL_PORT"`
+			MaxOpenConns    int           `yaml:"maxOpenConns" envconfig:"DATABASE_PGSQL_MAX_OPEN_CONNS"`
+			MaxIdleConns    int           `yaml:"maxIdleConns" envconfig:"DATABASE_PGSQL_MAX_IDLE_CONNS"`
+			ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" envconfig:"DATABASE_PGSQL_CONN_MAX_LIFETIME"`
+			ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime" envconfig:"DATABASE_PGSQL_CONN_MAX_IDLE_TIME"`
 		} `yaml:"pgsql"`
 		PgsqlWriter struct {
-			Username     string `yaml:"user" envconfig:"DATABASE_PGSQL_WRITER_USERNAME"`
-			Password     string `yaml:"password" envconfig:"DATABASE_PGSQL_WRITER_PASSWORD"`
-			Name         string `yaml:"name" envconfig:"DATABASE_PGSQL_WRITER_NAME"`
-			Host         string `yaml:"host" envconfig:"DATABASE_PGSQL_WRITER_HOST"`
-			Port         string `yaml:"port" envconfig:"DATABASE_PGSQL_WRITER_PORT"`
-			MaxOpenConns int    `yaml:"maxOpenConns" envconfig:"DATABASE_PGSQL_WRITER_MAX_OPEN_CONNS"`
-			MaxIdleConns int    `yaml:"maxIdleConns" envconfig:"DATABASE_PGSQL_WRITER_MAX_IDLE_CONNS"`
+			Username        string        `yaml:"user" envconfig:"DATABASE_PGSQL_WRITER_USERNAME"`
+			Password        string        `yaml:"password" envconfig:"DATABASE_PGSQL_WRITER_PASSWORD"`
+			Name            string        `yaml:"name" envconfig:"DATABASE_PGSQL_WRITER_NAME"`
+			Host            string        `yaml:"host" envconfig:"DATABASE_PGSQL_WRITER_HOST"`
+			Port            string        `yaml:"port" envconfig:"DATABASE_PGSQL_WRITER_PORT"`
+			MaxOpenConns    int           `yaml:"maxOpenConns" envconfig:"DATABASE_PGSQL_WRITER_MAX_OPEN_CONNS"`
+			MaxIdleConns    int           `yaml:"maxIdleConns" envconfig:"DATABASE_PGSQL_WRITER_MAX_IDLE_CONNS"`
+			ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" envconfig:"DATABASE_PGSQL_WRITER_CONN_MAX_LIFETIME"`
+			ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime" envconfig:"DATABASE_PGSQL_WRITER_CONN_MAX_IDLE_TIME"`
 		} `yaml:"pgsqlWriter"`
 	} `yaml:"database"`
 }
 
 type EndpointConfig struct {
-	Ssh            *EndpointSshConfig `yaml:"ssh"`
-	Url            string             `yaml:"url"`
-	Name           string             `yaml:"name"`
-	Archive        bool               `yaml:"archive"`
-	SkipValidators bool               `yaml:"skipValidators"`
-	Priority       int                `yaml:"priority"`
-	Headers        map[string]string  `yaml:"headers"`
+	Ssh            *EndpointSshConfig     `yaml:"ssh"`
+	Url            string                 `yaml:"url"`
+	Name           string                 `yaml:"name"`
+	Archive        bool                   `yaml:"archive"`
+	SkipValidators bool                   `yaml:"skipValidators"`
+	Priority       int                    `yaml:"priority"`
+	Headers        map[string]string      `yaml:"headers"`
+	Fixture        *EndpointFixtureConfig `yaml:"fixture"`
 }
 
 type EndpointSshConfig struct {
@@ -129,18 +246,93 @@ type EndpointSshConfig struct {
 	Keyfile  string `yaml:"keyfile"`
 }
 
+type EndpointFixtureConfig struct {
+	Mode string `yaml:"mode"` // record / replay
+	Path string `yaml:"path"`
+}
+
+type ValidatorNamesSourceConfig struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`     // yaml / inventory / deposit_data / mnemonic
+	Source   string `yaml:"source"`   // file path, ~internal/<file>, inventory api url, deposit data file / key directory or mnemonics file
+	Priority int    `yaml:"priority"` // names from sources with higher priority override lower ones
+}
+
+type ValidatorMetadataSourceConfig struct {
+	Name     string            `yaml:"name"`
+	Url      string            `yaml:"url"`      // enrichment api url, responds with {"ranges": {"<index>" or "<min>-<max>": {"<key>": "<value>"}}}
+	Headers  map[string]string `yaml:"headers"`  // additional request headers (eg. api keys)
+	Priority int               `yaml:"priority"` // values from sources with higher priority override lower ones
+}
+
+type WatchedAddressConfig struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+}
+
+type ListenerConfig struct {
+	Name   string   `yaml:"name"`
+	Host   string   `yaml:"host"`
+	Port   string   `yaml:"port"`
+	Routes []string `yaml:"routes"` // frontend, api, metrics, pprof
+	Tls    bool     `yaml:"tls"`
+}
+
+type MevRelayConfig struct {
+	Name string `yaml:"name"`
+	Url  string `yaml:"url"`
+}
+
+type FeeRecipientConfig struct {
+	Name    string `yaml:"name"` // validator name, a trailing "*" matches all names with that prefix
+	Address string `yaml:"address"`
+}
+
+type CustomForkConfig struct {
+	Name     string `yaml:"name"`     // consensus version name of the fork as returned by the beacon api (eg. "verkle")
+	Version  string `yaml:"version"`  // fork version
+	Epoch    uint64 `yaml:"epoch"`    // activation epoch
+	BaseFork string `yaml:"baseFork"` // known fork the block layout is based on (eg. "deneb")
+	Decoder  string `yaml:"decoder"`  // block decoder plugin, defaults to "extra-fields"
+}
+
+type IndexWidgetConfig struct {
+	Name         string        `yaml:"name"`
+	Column       string        `yaml:"column"`       // left, right or full (defaults to left)
+	Count        uint64        `yaml:"count"`        // number of shown entries, 0 for the widget default
+	CacheTimeout time.Duration `yaml:"cacheTimeout"` // 0 for the widget default
+}
+
+type ValidatorClientConfig struct {
+	Name            string   `yaml:"name"`
+	KeymanagerUrl   string   `yaml:"keymanagerUrl"`   // keymanager api of the validator client, used to get the managed pubkeys
+	KeymanagerToken string   `yaml:"keymanagerToken"` // bearer token for the keymanager api
+	MetricsUrl      string   `yaml:"metricsUrl"`      // optional prometheus metrics endpoint of the validator client
+	Metrics         []string `yaml:"metrics"`         // metric names to show, values of all label sets are summed up
+}
+
+type NotificationWebhookConfig struct {
+	Name    string            `yaml:"name"`
+	Url     string            `yaml:"url"`     // receives each event as json POST request
+	Headers map[string]string `yaml:"headers"` // additional request headers (eg. auth tokens)
+}
+
 type SqliteDatabaseConfig struct {
-	File         string
-	MaxOpenConns int
-	MaxIdleConns int
+	File            string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 }
 
 type PgsqlDatabaseConfig struct {
-	Username     string
-	Password     string
-	Name         string
-	Host         string
-	Port         string
-	MaxOpenConns int
-	MaxIdleConns int
+	Username        string
+	Password        string
+	Name            string
+	Host            string
+	Port            string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 }
@@ -60,6 +60,7 @@ type Meta struct {
 	Description string
 	Domain      string
 	Path        string
+	ShareUrl    string // absolute link to the current view including its query
 	Tlabel1     string
 	Tdata1      string
 	Tlabel2     string
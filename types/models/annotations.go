@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// AnnotationsPageData is a struct to hold info for the operator annotations page
+type AnnotationsPageData struct {
+	Type        string        `json:"type"`
+	Target      uint64        `json:"target"`
+	HasTarget   bool          `json:"has_target"`
+	Root        string        `json:"root"`
+	Author      string        `json:"author"`
+	Error       string        `json:"error,omitempty"`
+	Annotations []*Annotation `json:"annotations"`
+}
+
+// Annotation is an operator note shown on the slot, epoch & annotations pages
+type Annotation struct {
+	Id      uint64    `json:"id"`
+	Type    string    `json:"type"`
+	Target  uint64    `json:"target"`
+	Root    []byte    `json:"root,omitempty"`
+	Author  string    `json:"author"`
+	Note    string    `json:"note"`
+	Created time.Time `json:"created"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// BlobGasPageData is a struct to hold info for the blob gas market page
+type BlobGasPageData struct {
+	EpochCount uint64                `json:"epoch_count"`
+	DayCount   uint64                `json:"day_count"`
+	Sections   []*BlobGasPageSection `json:"sections"`
+}
+
+// BlobGasPageSection holds the blob gas charts & table for one period type (epochs / days)
+type BlobGasPageSection struct {
+	Title       string                 `json:"title"`
+	PeriodLabel string                 `json:"period_label"`
+	PeriodLink  string                 `json:"period_link"`
+	Charts      []*EpochsPageSparkline `json:"charts"`
+	Periods     []*BlobGasPagePeriod   `json:"periods"` // newest first
+}
+
+type BlobGasPagePeriod struct {
+	Period        uint64    `json:"period"`
+	Ts            time.Time `json:"ts"`
+	BlockCount    uint64    `json:"block_count"`
+	BlobGasUsed   uint64    `json:"blob_gas_used"`
+	BlobsPerBlock float64   `json:"blobs_per_block"`
+	ExcessBlobGas uint64    `json:"excess_blob_gas"`
+	BlobBaseFee   uint64    `json:"blob_base_fee"` // wei, at the average excess blob gas of the period
+}
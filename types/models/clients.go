@@ -1,9 +1,32 @@
 package models
 
+import (
+	"time"
+)
+
 // ClientsPageData is a struct to hold info for the clients page
 type ClientsPageData struct {
-	Clients     []*ClientsPageDataClient `json:"clients"`
-	ClientCount uint64                   `json:"client_count"`
+	Clients      []*ClientsPageDataClient `json:"clients"`
+	ClientCount  uint64                   `json:"client_count"`
+	ForkMismatch bool                     `json:"fork_mismatch"` // endpoints report different fork digests
+	ForkDigest   []byte                   `json:"fork_digest"`   // fork digest of the majority of the endpoints
+	Indexer      *ClientsPageDataIndexer  `json:"indexer"`
+}
+
+// ClientsPageDataIndexer is a summary of the indexer state (see /debug/indexer for the full state)
+type ClientsPageDataIndexer struct {
+	HeadSlot       uint64 `json:"head_slot"`
+	FinalizedEpoch int64  `json:"finalized_epoch"`
+	JustifiedEpoch int64  `json:"justified_epoch"`
+	ProcessingBusy bool   `json:"processing_busy"`
+	ProcessedEpoch int64  `json:"processed_epoch"`
+	PersistEpoch   int64  `json:"persist_epoch"`
+	CachedBlocks   uint64 `json:"cached_blocks"`
+	LowestSlot     int64  `json:"lowest_slot"`
+	HighestSlot    int64  `json:"highest_slot"`
+	ReorgCount     uint64 `json:"reorg_count"`
+	SyncRunning    bool   `json:"sync_running"`
+	SyncEpoch      uint64 `json:"sync_epoch"`
 }
 
 type ClientsPageDataClient struct {
@@ -13,4 +36,28 @@ type ClientsPageDataClient struct {
 	HeadSlot uint64 `json:"head_slot"`
 	HeadRoot []byte `json:"head_root"`
 	Status   string `json:"status"`
+
+	HasFork      bool      `json:"has_fork"`
+	ForkVersion  []byte    `json:"fork_version"`
+	ForkEpoch    uint64    `json:"fork_epoch"`
+	ForkDigest   []byte    `json:"fork_digest"`
+	ForkMismatch bool      `json:"fork_mismatch"`
+	StatusError  string    `json:"status_error"`
+	StatusTime   time.Time `json:"status_time"`
+
+	ClientType  string `json:"client_type"`
+	Unsupported string `json:"unsupported"`
+	AvgLatency  int64  `json:"avg_latency"`
+
+	ArrivalBlocks    uint64 `json:"arrival_blocks"`
+	ArrivalAvgBehind int64  `json:"arrival_avg_behind"`
+	ArrivalMaxBehind int64  `json:"arrival_max_behind"`
+
+	CircuitState     string    `json:"circuit_state"`
+	CircuitFailures  uint64    `json:"circuit_failures"`
+	CircuitOpenUntil time.Time `json:"circuit_open_until"`
+	LastError        string    `json:"last_error"`
+	LastErrorClass   string    `json:"last_error_class"`
+	LastErrorTime    time.Time `json:"last_error_time"`
+	ErrorCounts      string    `json:"error_counts"`
 }
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// ConsolidationRequestsPageData is a struct to hold info for the EIP-7251 consolidation requests page
+type ConsolidationRequestsPageData struct {
+	PageIndex uint64 `json:"page_index"`
+	PageSize  uint64 `json:"page_size"`
+	PrevPage  uint64 `json:"prev_page"`
+	NextPage  uint64 `json:"next_page"`
+	HasPrev   bool   `json:"has_prev"`
+	HasNext   bool   `json:"has_next"`
+
+	// totals of the shown requests
+	TotalAmount  uint64 `json:"total_amount"`
+	PendingCount uint64 `json:"pending_count"` // source validator not exited yet
+
+	Requests []*ConsolidationRequestsPageDataRequest `json:"requests"`
+}
+
+type ConsolidationRequestsPageDataRequest struct {
+	Slot          uint64    `json:"slot"`
+	Time          time.Time `json:"time"`
+	BlockRoot     []byte    `json:"block_root"`
+	BlockNumber   uint64    `json:"block_number"`
+	SourceAddress []byte    `json:"source_address"`
+	SourcePubkey  []byte    `json:"source_pubkey"`
+	HasSource     bool      `json:"has_source"`
+	SourceIndex   uint64    `json:"source_index"`
+	SourceName    string    `json:"source_name"`
+	SourceExited  bool      `json:"source_exited"`
+	TargetPubkey  []byte    `json:"target_pubkey"`
+	HasTarget     bool      `json:"has_target"`
+	TargetIndex   uint64    `json:"target_index"`
+	TargetName    string    `json:"target_name"`
+	Amount        uint64    `json:"amount"`         // effective balance of the source when the request was included
+	TargetBalance uint64    `json:"target_balance"` // effective balance of the target when the request was included
+	TargetCurrent uint64    `json:"target_current"` // current effective balance of the target
+	TargetChange  uint64    `json:"target_change"`  // absolute change of the target effective balance since the request
+	TargetDropped bool      `json:"target_dropped"`
+}
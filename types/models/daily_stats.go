@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// DailyStatsPageData is a struct to hold info for the daily chain stats page
+type DailyStatsPageData struct {
+	DayCount uint64                 `json:"day_count"`
+	Charts   []*EpochsPageSparkline `json:"charts"`
+	Days     []*DailyStatsPageDay   `json:"days"` // newest first
+}
+
+type DailyStatsPageDay struct {
+	Day                 uint64           `json:"day"`
+	Ts                  time.Time        `json:"ts"`
+	FirstEpoch          uint64           `json:"first_epoch"`
+	LastEpoch           uint64           `json:"last_epoch"`
+	EpochCount          uint64           `json:"epoch_count"`
+	Participation       float64          `json:"participation"`
+	BlockCount          uint64           `json:"block_count"`
+	MissedCount         uint64           `json:"missed_count"`
+	OrphanedCount       uint64           `json:"orphaned_count"`
+	DepositCount        uint64           `json:"deposit_count"`
+	ExitCount           uint64           `json:"exit_count"`
+	WithdrawCount       uint64           `json:"withdraw_count"`
+	WithdrawAmount      uint64           `json:"withdraw_amount"`
+	EthTransactionCount uint64           `json:"eth_transaction_count"`
+	BlobCount           uint64           `json:"blob_count"`
+	TxTypes             TransactionTypes `json:"tx_types"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// DebugRpcPageData is a struct to hold the beacon api response diagnostics for the operator debug page
+type DebugRpcPageData struct {
+	Ts      time.Time             `json:"ts"`
+	Enabled bool                  `json:"enabled"`
+	Clients []*DebugRpcPageClient `json:"clients"`
+}
+
+type DebugRpcPageClient struct {
+	Name      string                  `json:"name"`
+	Version   string                  `json:"version"`
+	Checked   uint64                  `json:"checked"`
+	Malformed uint64                  `json:"malformed"`
+	Endpoints []*DebugRpcPageEndpoint `json:"endpoints"`
+	Captures  []*DebugRpcPageCapture  `json:"captures"`
+}
+
+type DebugRpcPageEndpoint struct {
+	Endpoint      string    `json:"endpoint"`
+	Checked       uint64    `json:"checked"`
+	Malformed     uint64    `json:"malformed"`
+	ParseErrors   uint64    `json:"parse_errors"`
+	TypeMismatch  uint64    `json:"type_mismatch"`
+	MissingFields uint64    `json:"missing_fields"`
+	UnknownFields uint64    `json:"unknown_fields"`
+	LastMalformed time.Time `json:"last_malformed"`
+}
+
+type DebugRpcPageCapture struct {
+	Time       time.Time                `json:"time"`
+	Endpoint   string                   `json:"endpoint"`
+	Url        string                   `json:"url"`
+	Deviations []*DebugRpcPageDeviation `json:"deviations"`
+	Payload    string                   `json:"payload"`
+	Truncated  bool                     `json:"truncated"`
+}
+
+type DebugRpcPageDeviation struct {
+	Kind   string `json:"kind"`
+	Path   string `json:"path"`
+	Detail string `json:"detail"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+)
+
+// DebugRuntimePageData is a struct to hold the runtime diagnostics for the operator debug page
+type DebugRuntimePageData struct {
+	Ts            time.Time     `json:"ts"`
+	StartTime     time.Time     `json:"start_time"`
+	Uptime        time.Duration `json:"uptime"`
+	GoVersion     string        `json:"go_version"`
+	Version       string        `json:"version"`
+	NumCPU        int           `json:"num_cpu"`
+	GoMaxProcs    int           `json:"gomaxprocs"`
+	Goroutines    int           `json:"goroutines"`
+	HeapAlloc     uint64        `json:"heap_alloc"`
+	HeapInuse     uint64        `json:"heap_inuse"`
+	HeapIdle      uint64        `json:"heap_idle"`
+	HeapReleased  uint64        `json:"heap_released"`
+	HeapSys       uint64        `json:"heap_sys"`
+	HeapObjects   uint64        `json:"heap_objects"`
+	StackInuse    uint64        `json:"stack_inuse"`
+	Sys           uint64        `json:"sys"`
+	TotalAlloc    uint64        `json:"total_alloc"`
+	NumGC         uint32        `json:"num_gc"`
+	LastGC        time.Time     `json:"last_gc"`
+	LastGCPause   time.Duration `json:"last_gc_pause"`
+	TotalGCPause  time.Duration `json:"total_gc_pause"`
+	GCCPUFraction float64       `json:"gc_cpu_fraction"`
+	NextGC        uint64        `json:"next_gc"`
+
+	IndexerCache *DebugRuntimeIndexerCache `json:"indexer_cache"`
+	Crashes      []*DebugRuntimeCrash      `json:"crashes"`
+	Storage      *DebugRuntimeStorage      `json:"storage"`
+	Maintenance  *DebugRuntimeMaintenance  `json:"maintenance"`
+}
+
+type DebugRuntimeIndexerCache struct {
+	CachedSlots      uint64 `json:"cached_slots"`
+	CachedBlocks     uint64 `json:"cached_blocks"`
+	CachedEpochStats uint64 `json:"cached_epoch_stats"`
+	LowestSlot       int64  `json:"lowest_slot"`
+	HighestSlot      int64  `json:"highest_slot"`
+}
+
+// DebugRuntimeStorage is the db size & growth estimated from the daily table size samples
+type DebugRuntimeStorage struct {
+	SampleDay     time.Time                   `json:"sample_day"`
+	SampleDays    uint64                      `json:"sample_days"`
+	TotalSize     uint64                      `json:"total_size"`
+	DailyGrowth   int64                       `json:"daily_growth"`
+	Projected30d  uint64                      `json:"projected_30d"`
+	Projected90d  uint64                      `json:"projected_90d"`
+	Projected365d uint64                      `json:"projected_365d"`
+	Tables        []*DebugRuntimeStorageTable `json:"tables"`
+}
+
+type DebugRuntimeStorageTable struct {
+	Name        string `json:"name"`
+	RowCount    uint64 `json:"row_count"`
+	Size        uint64 `json:"size"`
+	DailyGrowth int64  `json:"daily_growth"`
+	DailyRows   int64  `json:"daily_rows"`
+}
+
+// DebugRuntimeCrash counts the recovered panics of a subroutine
+type DebugRuntimeCrash struct {
+	Identifier string    `json:"identifier"`
+	Crashes    uint64    `json:"crashes"`
+	Restarts   uint64    `json:"restarts"`
+	LastCrash  time.Time `json:"last_crash"`
+	LastError  string    `json:"last_error"`
+}
+
+// DebugRuntimeMaintenance is the schedule & the last runs of the db maintenance tasks
+type DebugRuntimeMaintenance struct {
+	Enabled  bool                           `json:"enabled"`
+	Window   string                         `json:"window"`
+	InWindow bool                           `json:"in_window"`
+	Tasks    []*DebugRuntimeMaintenanceTask `json:"tasks"`
+}
+
+type DebugRuntimeMaintenanceTask struct {
+	Name         string        `json:"name"`
+	Interval     time.Duration `json:"interval"`
+	Running      bool          `json:"running"`
+	LastRun      time.Time     `json:"last_run"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastError    string        `json:"last_error"`
+	NextRun      time.Time     `json:"next_run"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// DepositReceiptsPageData is a struct to hold info for the EIP-6110 deposit flow page
+type DepositReceiptsPageData struct {
+	PageIndex uint64 `json:"page_index"`
+	PageSize  uint64 `json:"page_size"`
+	PrevPage  uint64 `json:"prev_page"`
+	NextPage  uint64 `json:"next_page"`
+	HasPrev   bool   `json:"has_prev"`
+	HasNext   bool   `json:"has_next"`
+
+	// flow timing of the shown deposits that created a new validator
+	NewCount          uint64        `json:"new_count"`
+	TopUpCount        uint64        `json:"topup_count"`
+	EligibleCount     uint64        `json:"eligible_count"`
+	ActivatedCount    uint64        `json:"activated_count"`
+	AvgEligibleDelay  time.Duration `json:"avg_eligible_delay"`
+	AvgActivatedDelay time.Duration `json:"avg_activated_delay"`
+
+	Receipts []*DepositReceiptsPageDataReceipt `json:"receipts"`
+}
+
+type DepositReceiptsPageDataReceipt struct {
+	Slot                  uint64        `json:"slot"`
+	Time                  time.Time     `json:"time"`
+	BlockRoot             []byte        `json:"block_root"`
+	BlockNumber           uint64        `json:"block_number"`
+	DepositIndex          uint64        `json:"deposit_index"`
+	Pubkey                []byte        `json:"pubkey"`
+	WithdrawalCredentials []byte        `json:"withdrawal_credentials"`
+	Amount                uint64        `json:"amount"`
+	HasValidator          bool          `json:"has_validator"`
+	ValidatorIndex        uint64        `json:"validator_index"`
+	ValidatorName         string        `json:"validator_name"`
+	TopUp                 bool          `json:"topup"` // the validator existed before the deposit
+	IsEligible            bool          `json:"is_eligible"`
+	EligibleEpoch         uint64        `json:"eligible_epoch"`
+	EligibleDelay         time.Duration `json:"eligible_delay"`
+	IsActivated           bool          `json:"is_activated"`
+	ActivationEpoch       uint64        `json:"activation_epoch"`
+	ActivationDelay       time.Duration `json:"activation_delay"`
+}
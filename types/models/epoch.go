@@ -35,26 +35,117 @@ type EpochPageData struct {
 	ScheduledCount          uint64               `json:"scheduled_count"`
 	OrphanedCount           uint64               `json:"orphaned_count"`
 	EthTransactionCount     uint64               `json:"eth_transaction_count"`
+	TargetSplit             bool                 `json:"target_split"`
+	TargetVotes             []*EpochPageDataVote `json:"target_votes"`
 	Slots                   []*EpochPageDataSlot `json:"slots"`
+	Annotations             []*Annotation        `json:"annotations,omitempty"`
+	Aggregation             *AggregationStats    `json:"aggregation,omitempty"`
+	TxTypes                 *TransactionTypes    `json:"tx_types,omitempty"`
+	CommitteeChart          *EpochPageCommittees `json:"committee_chart,omitempty"`
+	ArrivalChart            *EpochPageArrivals   `json:"arrival_chart,omitempty"`
+	AttestationExport       bool                 `json:"attestation_export"`
+}
+
+// AggregationStats is the attestation aggregation redundancy of a block or epoch
+type AggregationStats struct {
+	AggregateCount     uint64  `json:"aggregate_count"`
+	CommitteeCount     uint64  `json:"committee_count"`
+	RedundantCount     uint64  `json:"redundant_count"`
+	VoteCount          uint64  `json:"vote_count"`
+	DuplicateVoteCount uint64  `json:"duplicate_vote_count"`
+	DuplicateRatio     float64 `json:"duplicate_ratio"`
+}
+
+// TransactionTypes is the number of execution transactions per EIP-2718 transaction type in a block or epoch
+type TransactionTypes struct {
+	Legacy     uint64 `json:"legacy"`
+	AccessList uint64 `json:"access_list"`
+	DynamicFee uint64 `json:"dynamic_fee"`
+	Blob       uint64 `json:"blob"`
+	SetCode    uint64 `json:"setcode"`
+}
+
+// EpochPageCommittees is the vote participation per slot & committee index of an epoch, drawn as heatmap
+type EpochPageCommittees struct {
+	SlotCount        uint64                    `json:"slot_count"`
+	CommitteeCount   uint64                    `json:"committee_count"`
+	Cells            []*EpochPageCommitteeCell `json:"cells"`
+	LowCount         uint64                    `json:"low_count"` // committees below 50% participation
+	MinSlot          uint64                    `json:"min_slot"`
+	MinCommittee     uint64                    `json:"min_committee"`
+	MinParticipation uint64                    `json:"min_participation"`
+	AvgParticipation float64                   `json:"avg_participation"`
+}
+
+type EpochPageCommitteeCell struct {
+	X             uint64 `json:"x"`
+	Y             uint64 `json:"y"`
+	Slot          uint64 `json:"slot"`
+	Committee     uint64 `json:"committee"`
+	Participation uint64 `json:"participation"`
+	Hue           uint64 `json:"hue"`
+}
+
+// EpochPageArrivals is the first arrival of the canonical blocks in the epoch against the slot deadlines (in ms since slot start)
+type EpochPageArrivals struct {
+	SlotCount           uint64                 `json:"slot_count"`
+	SlotDuration        int64                  `json:"slot_duration"`
+	AttestationDeadline int64                  `json:"attestation_deadline"`
+	AttestationLine     int64                  `json:"-"` // y position of the deadline in the chart
+	AggregateDeadline   int64                  `json:"aggregate_deadline"`
+	AggregateLine       int64                  `json:"-"`
+	BlockCount          uint64                 `json:"block_count"`
+	LateCount           uint64                 `json:"late_count"` // blocks that arrived after the attestation deadline
+	AvgDelay            int64                  `json:"avg_delay"`
+	MaxDelay            int64                  `json:"max_delay"`
+	Bars                []*EpochPageArrivalBar `json:"bars"`
+}
+
+type EpochPageArrivalBar struct {
+	X      uint64 `json:"x"`
+	Y      int64  `json:"-"`
+	Slot   uint64 `json:"slot"`
+	Delay  int64  `json:"delay"`
+	Height int64  `json:"-"`
+	Late   bool   `json:"late"`
+}
+
+type EpochPageDataVote struct {
+	Root          []byte  `json:"root"`
+	Canonical     bool    `json:"canonical"`
+	Amount        uint64  `json:"amount"`
+	Participation float64 `json:"participation"`
 }
 
 type EpochPageDataSlot struct {
-	Slot                  uint64    `json:"slot"`
-	Epoch                 uint64    `json:"epoch"`
-	Ts                    time.Time `json:"ts"`
-	Scheduled             bool      `json:"scheduled"`
-	Status                uint8     `json:"status"`
-	Proposer              uint64    `json:"proposer"`
-	ProposerName          string    `json:"proposer_name"`
-	AttestationCount      uint64    `json:"attestation_count"`
-	DepositCount          uint64    `json:"deposit_count"`
-	ExitCount             uint64    `json:"exit_count"`
-	ProposerSlashingCount uint64    `json:"proposer_slashing_count"`
-	AttesterSlashingCount uint64    `json:"attester_slashing_count"`
-	SyncParticipation     float64   `json:"sync_participation"`
-	EthTransactionCount   uint64    `json:"eth_transaction_count"`
-	EthBlockNumber        uint64    `json:"eth_block_number"`
-	WithEthBlock          bool      `json:"with_eth_block"`
-	Graffiti              []byte    `json:"graffiti"`
-	BlockRoot             []byte    `json:"block_root"`
+	Slot                  uint64        `json:"slot"`
+	Epoch                 uint64        `json:"epoch"`
+	Ts                    time.Time     `json:"ts"`
+	Scheduled             bool          `json:"scheduled"`
+	Status                SlotStatus    `json:"status"`
+	Proposer              uint64        `json:"proposer"`
+	ProposerName          string        `json:"proposer_name"`
+	AttestationCount      uint64        `json:"attestation_count"`
+	DepositCount          uint64        `json:"deposit_count"`
+	ExitCount             uint64        `json:"exit_count"`
+	ProposerSlashingCount uint64        `json:"proposer_slashing_count"`
+	AttesterSlashingCount uint64        `json:"attester_slashing_count"`
+	SyncParticipation     float64       `json:"sync_participation"`
+	EthTransactionCount   uint64        `json:"eth_transaction_count"`
+	EthBlockNumber        uint64        `json:"eth_block_number"`
+	WithEthBlock          bool          `json:"with_eth_block"`
+	Graffiti              []byte        `json:"graffiti"`
+	BlockRoot             []byte        `json:"block_root"`
+	ParentRoot            []byte        `json:"parent_root"`
+	StateRoot             []byte        `json:"state_root"`
+	BlobCount             uint64        `json:"blob_count"`
+	WithdrawalCount       uint64        `json:"withdrawal_count"`
+	WithdrawalAmount      uint64        `json:"withdrawal_amount"`
+	BLSChangeCount        uint64        `json:"bls_change_count"`
+	EthBlockHash          []byte        `json:"eth_block_hash"`
+	EthFeeRecipient       []byte        `json:"eth_fee_recipient"`
+	HasArrival            bool          `json:"has_arrival"`
+	ArrivalDelay          int64         `json:"arrival_delay"` // ms since slot start, first client that received the block
+	ArrivalClient         string        `json:"arrival_client"`
+	Annotations           []*Annotation `json:"annotations,omitempty"`
 }
@@ -10,6 +10,7 @@ type EpochsPageData struct {
 	EpochCount uint64
 	FirstEpoch uint64
 	LastEpoch  uint64
+	Charts     []*EpochsPageSparkline `json:"charts"`
 
 	IsDefaultPage    bool   `json:"default_page"`
 	TotalPages       uint64 `json:"total_pages"`
@@ -21,6 +22,9 @@ type EpochsPageData struct {
 	NextPageIndex    uint64 `json:"next_page_index"`
 	NextPageEpoch    uint64 `json:"next_page_epoch"`
 	LastPageEpoch    uint64 `json:"last_page_epoch"`
+
+	LightMode       bool   `json:"light_mode"` // running without db, history before LowestLiveEpoch is unavailable
+	LowestLiveEpoch uint64 `json:"lowest_live_epoch"`
 }
 
 type EpochsPageDataEpoch struct {
@@ -44,4 +48,30 @@ type EpochsPageDataEpoch struct {
 	HeadVoteParticipation   float64   `json:"head_vote_participation"`
 	TotalVoteParticipation  float64   `json:"total_vote_participation"`
 	EthTransactionCount     uint64    `json:"eth_transaction_count"`
+	BlobCount               uint64    `json:"blob_count"`
+	TargetSplit             bool      `json:"target_split"`
+	Alerts                  []string  `json:"alerts,omitempty"`
+	Annotations             []string  `json:"annotations,omitempty"`
+}
+
+// EpochsPageSparkline holds a small server-rendered chart for the epochs on the current page
+type EpochsPageSparkline struct {
+	Title   string                       `json:"title"`
+	Unit    string                       `json:"unit"`
+	Values  []float64                    `json:"values"` // oldest epoch first
+	Points  string                       `json:"points"` // svg polyline points
+	Width   int                          `json:"width"`
+	Height  int                          `json:"height"`
+	Last    float64                      `json:"last"`
+	Min     float64                      `json:"min"`
+	Max     float64                      `json:"max"`
+	Average float64                      `json:"average"`
+	Markers []*EpochsPageSparklineMarker `json:"markers,omitempty"`
+}
+
+// EpochsPageSparklineMarker is a vertical line in a sparkline for an annotated epoch
+type EpochsPageSparklineMarker struct {
+	Epoch uint64  `json:"epoch"`
+	X     float64 `json:"x"`
+	Title string  `json:"title"`
 }
@@ -0,0 +1,30 @@
+package models
+
+// EpochsFilteredPageData is a struct to hold info for the filtered epochs page
+type EpochsFilteredPageData struct {
+	FilterFrom             string `json:"filter_from"`
+	FilterTo               string `json:"filter_to"`
+	FilterMinEpoch         string `json:"filter_minepoch"`
+	FilterMaxEpoch         string `json:"filter_maxepoch"`
+	FilterFinalized        uint8  `json:"filter_finalized"`
+	FilterMinParticipation string `json:"filter_minpart"`
+	FilterMaxParticipation string `json:"filter_maxpart"`
+	FilterWithOrphaned     uint8  `json:"filter_orphaned"`
+	FilterError            string `json:"filter_error,omitempty"`
+
+	Epochs     []*EpochsPageDataEpoch `json:"epochs"`
+	EpochCount uint64                 `json:"epoch_count"`
+	FirstEpoch uint64                 `json:"first_epoch"`
+	LastEpoch  uint64                 `json:"last_epoch"`
+
+	IsDefaultPage    bool   `json:"default_page"`
+	TotalPages       uint64 `json:"total_pages"`
+	PageSize         uint64 `json:"page_size"`
+	CurrentPageIndex uint64 `json:"page_index"`
+	PrevPageIndex    uint64 `json:"prev_page_index"`
+	NextPageIndex    uint64 `json:"next_page_index"`
+
+	FirstPageLink string `json:"first_page_link"`
+	PrevPageLink  string `json:"prev_page_link"`
+	NextPageLink  string `json:"next_page_link"`
+}
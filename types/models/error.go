@@ -10,4 +10,10 @@ type ErrorPageData struct {
 	ErrorMsg   string
 	StackTrace string
 	Version    string
+	Code       string // error class (not_found, upstream_unavailable, data_pruned, page_timeout, internal_error)
+	StatusCode int
+	Title      string
+	Message    string
+	RequestId  string
+	Internal   bool // unexpected error, that should be reported
 }
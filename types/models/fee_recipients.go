@@ -0,0 +1,32 @@
+package models
+
+// FeeRecipientsPageData is a struct to hold info for the fee recipient compliance page
+type FeeRecipientsPageData struct {
+	EpochCount      uint64                    `json:"epoch_count"`
+	FirstSlot       uint64                    `json:"first_slot"`
+	LastSlot        uint64                    `json:"last_slot"`
+	TotalBlocks     uint64                    `json:"total_blocks"`
+	CheckedBlocks   uint64                    `json:"checked_blocks"`
+	DeviatingBlocks uint64                    `json:"deviating_blocks"`
+	Rules           []*FeeRecipientsPageRule  `json:"rules"`
+	Deviations      []*FeeRecipientsPageBlock `json:"deviations"`
+}
+
+type FeeRecipientsPageRule struct {
+	Name      string  `json:"name"`
+	Address   []byte  `json:"address"`
+	Blocks    uint64  `json:"blocks"`
+	Compliant uint64  `json:"compliant"`
+	Deviating uint64  `json:"deviating"`
+	Rate      float64 `json:"rate"`
+}
+
+type FeeRecipientsPageBlock struct {
+	Slot         uint64 `json:"slot"`
+	BlockRoot    []byte `json:"block_root"`
+	Proposer     uint64 `json:"proposer"`
+	ProposerName string `json:"proposer_name"`
+	Rule         string `json:"rule"`
+	Expected     []byte `json:"expected"`
+	FeeRecipient []byte `json:"fee_recipient"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// FinalityPageData is a struct to hold info for the finality history page
+type FinalityPageData struct {
+	EpochCount          uint64               `json:"epoch_count"`
+	JustifiedEpoch      uint64               `json:"justified_epoch"` // current justified checkpoint
+	FinalizedEpoch      uint64               `json:"finalized_epoch"` // current finalized checkpoint
+	AvgTimeToFinality   time.Duration        `json:"avg_time_to_finality"`
+	MaxTimeToFinality   time.Duration        `json:"max_time_to_finality"`
+	MaxFinalityEpoch    uint64               `json:"max_finality_epoch"`
+	FinalizedCount      uint64               `json:"finalized_count"` // epochs with an observed finalization
+	GapCount            uint64               `json:"gap_count"`
+	LastGapEpoch        uint64               `json:"last_gap_epoch"`
+	TimeToFinalityChart *EpochsPageSparkline `json:"time_to_finality_chart"`
+	Epochs              []*FinalityPageEpoch `json:"epochs"` // newest first
+}
+
+// FinalityPageEpoch holds the observed justification & finalization of an epoch.
+// The times are measured from the start of the epoch, they are only known for transitions seen by this explorer.
+type FinalityPageEpoch struct {
+	Epoch               uint64        `json:"epoch"`
+	Ts                  time.Time     `json:"ts"`
+	Justified           bool          `json:"justified"`
+	JustifiedTime       time.Time     `json:"justified_time"`
+	TimeToJustification time.Duration `json:"time_to_justification"`
+	JustificationGap    bool          `json:"justification_gap"` // skipped by a justification of a later epoch
+	Finalized           bool          `json:"finalized"`
+	FinalizedTime       time.Time     `json:"finalized_time"`
+	TimeToFinality      time.Duration `json:"time_to_finality"`
+	FinalizedRange      uint64        `json:"finalized_range"` // number of epochs finalized by the same transition
+	Pending             bool          `json:"pending"`         // not finalized yet
+}
@@ -36,6 +36,63 @@ type IndexPageData struct {
 	RecentSlots      []*IndexPageDataSlots  `json:"slots"`
 	RecentSlotCount  uint64                 `json:"slot_count"`
 	ForkTreeWidth    int                    `json:"forktree_width"`
+
+	LeftWidgets       []*IndexPageDataWidget          `json:"-"`
+	RightWidgets      []*IndexPageDataWidget          `json:"-"`
+	FullWidgets       []*IndexPageDataWidget          `json:"-"`
+	Participation     *IndexPageDataParticipation     `json:"participation"`
+	BlobUsage         *IndexPageDataBlobUsage         `json:"blob_usage"`
+	ClientDiversity   []*IndexPageDataClientShare     `json:"client_diversity"`
+	ElClientDiversity []*IndexPageDataClientShare     `json:"el_client_diversity"`
+	ClientPairs       []*IndexPageDataClientPair      `json:"client_pairs"`
+	WatchedValidators []*IndexPageDataValidatorClient `json:"watched_validators"`
+}
+
+// IndexPageDataWidget is a widget of the front page layout, Page refers back to the page data for the widget templates
+type IndexPageDataWidget struct {
+	Name string
+	Page *IndexPageData
+}
+
+type IndexPageDataParticipation struct {
+	Epoch         uint64  `json:"epoch"`
+	Finalized     bool    `json:"finalized"`
+	EligibleEther uint64  `json:"eligible"`
+	TargetPercent float64 `json:"target"`
+	HeadPercent   float64 `json:"head"`
+	TotalPercent  float64 `json:"total"`
+}
+
+type IndexPageDataBlobUsage struct {
+	FirstEpoch    uint64  `json:"first_epoch"`
+	LastEpoch     uint64  `json:"last_epoch"`
+	BlockCount    uint64  `json:"blocks"`
+	BlobCount     uint64  `json:"blobs"`
+	BlobsPerBlock float64 `json:"blobs_per_block"`
+	Usage         float64 `json:"usage"` // share of the max blob capacity
+}
+
+type IndexPageDataClientShare struct {
+	Client  string  `json:"client"`
+	Blocks  uint64  `json:"blocks"`
+	Percent float64 `json:"percent"`
+}
+
+// IndexPageDataClientPair is the share of blocks built by a consensus & execution client combination
+type IndexPageDataClientPair struct {
+	ClClient string  `json:"cl_client"`
+	ElClient string  `json:"el_client"`
+	Blocks   uint64  `json:"blocks"`
+	Percent  float64 `json:"percent"`
+}
+
+type IndexPageDataValidatorClient struct {
+	Name          string  `json:"name"`
+	Error         string  `json:"error"`
+	ActiveCount   uint64  `json:"active"`
+	OnlineCount   uint64  `json:"online"`
+	OfflineCount  uint64  `json:"offline"`
+	Participation float64 `json:"participation"`
 }
 
 type IndexPageDataForks struct {
@@ -56,16 +113,16 @@ type IndexPageDataEpochs struct {
 }
 
 type IndexPageDataBlocks struct {
-	Epoch        uint64    `json:"epoch"`
-	Slot         uint64    `json:"slot"`
-	WithEthBlock bool      `json:"has_block"`
-	EthBlock     uint64    `json:"eth_block"`
-	EthBlockLink string    `json:"eth_link"`
-	Ts           time.Time `json:"ts"`
-	Proposer     uint64    `json:"proposer"`
-	ProposerName string    `json:"proposer_name"`
-	Status       uint64    `json:"status"`
-	BlockRoot    []byte    `json:"block_root"`
+	Epoch        uint64     `json:"epoch"`
+	Slot         uint64     `json:"slot"`
+	WithEthBlock bool       `json:"has_block"`
+	EthBlock     uint64     `json:"eth_block"`
+	EthBlockLink string     `json:"eth_link"`
+	Ts           time.Time  `json:"ts"`
+	Proposer     uint64     `json:"proposer"`
+	ProposerName string     `json:"proposer_name"`
+	Status       SlotStatus `json:"status"`
+	BlockRoot    []byte     `json:"block_root"`
 }
 
 type IndexPageDataSlots struct {
@@ -75,7 +132,7 @@ type IndexPageDataSlots struct {
 	Ts           time.Time                 `json:"ts"`
 	Proposer     uint64                    `json:"proposer"`
 	ProposerName string                    `json:"proposer_name"`
-	Status       uint64                    `json:"status"`
+	Status       SlotStatus                `json:"status"`
 	BlockRoot    []byte                    `json:"block_root"`
 	ParentRoot   []byte                    `json:"-"`
 	ForkGraph    []*IndexPageDataForkGraph `json:"fork_graph"`
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// NameClaimsPageData is a struct to hold info for the validator name claim page
+type NameClaimsPageData struct {
+	Enabled        bool   `json:"enabled"`
+	ValidatorIndex uint64 `json:"validator_index"`
+	HasValidator   bool   `json:"has_validator"`
+	CurrentName    string `json:"current_name"`
+	Name           string `json:"name"`
+	Message        string `json:"message"`
+	Signature      string `json:"signature"`
+	Submitted      bool   `json:"submitted"`
+	Error          string `json:"error"`
+}
+
+// NameClaimsAdminPageData is a struct to hold info for the name claim moderation page
+type NameClaimsAdminPageData struct {
+	Claims []*NameClaimsAdminPageClaim `json:"claims"`
+}
+
+type NameClaimsAdminPageClaim struct {
+	ValidatorIndex uint64    `json:"validator_index"`
+	CurrentName    string    `json:"current_name"`
+	Name           string    `json:"name"`
+	Signature      []byte    `json:"signature"`
+	Created        time.Time `json:"created"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// ProposerRewardsPageData is a struct to hold info for the proposer rewards leaderboard
+type ProposerRewardsPageData struct {
+	Proposers   []*ProposerRewardsPageDataProposer `json:"proposers"`
+	TrackingOff bool                               `json:"tracking_off"`
+
+	TotalPages       uint64 `json:"total_pages"`
+	PageSize         uint64 `json:"page_size"`
+	CurrentPageIndex uint64 `json:"page_index"`
+	PrevPageIndex    uint64 `json:"prev_page_index"`
+	NextPageIndex    uint64 `json:"next_page_index"`
+	FirstRank        uint64 `json:"first_rank"`
+}
+
+type ProposerRewardsPageDataProposer struct {
+	Rank           uint64  `json:"rank"`
+	Index          uint64  `json:"index"`
+	Name           string  `json:"name"`
+	SlotCount      uint64  `json:"slot_count"`
+	ProposedCount  uint64  `json:"proposed_count"`
+	ExpectedReward uint64  `json:"expected_reward"`
+	ActualReward   uint64  `json:"actual_reward"`
+	Efficiency     float64 `json:"efficiency"`
+}
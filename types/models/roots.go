@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// RootsPageData is a struct to hold info for the state & block roots page
+type RootsPageData struct {
+	Roots     []*RootsPageDataSlot `json:"roots"`
+	RootCount uint64               `json:"root_count"`
+	FirstSlot uint64               `json:"first_slot"`
+	LastSlot  uint64               `json:"last_slot"`
+
+	SlotsPerHistoricalRoot uint64 `json:"slots_per_historical_root"`
+
+	IsDefaultPage bool   `json:"default_page"`
+	PageSize      uint64 `json:"page_size"`
+	HeadSlot      uint64 `json:"head_slot"`
+	PrevPageSlot  uint64 `json:"prev_page_slot"`
+	NextPageSlot  uint64 `json:"next_page_slot"`
+	HasPrevPage   bool   `json:"has_prev_page"`
+	HasNextPage   bool   `json:"has_next_page"`
+}
+
+type RootsPageDataSlot struct {
+	Slot             uint64    `json:"slot"`
+	Epoch            uint64    `json:"epoch"`
+	Ts               time.Time `json:"ts"`
+	Finalized        bool      `json:"finalized"`
+	Missed           bool      `json:"missed"`
+	HistoricalPeriod uint64    `json:"historical_period"` // index of the historical summary covering the slot
+	HistoricalIndex  uint64    `json:"historical_index"`  // index in the block_roots / state_roots vectors
+	BlockRoot        []byte    `json:"block_root"`
+	StateRoot        []byte    `json:"state_root"`
+}
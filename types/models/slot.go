@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/pk910/dora/types"
@@ -8,52 +9,136 @@ import (
 
 // SlotPageData is a struct to hold info for the slot details page
 type SlotPageData struct {
-	Slot                   uint64             `json:"slot"`
-	Epoch                  uint64             `json:"epoch"`
-	EpochFinalized         bool               `json:"epoch_finalized"`
-	EpochParticipationRate float64            `json:"epoch_participation_rate"`
-	Ts                     time.Time          `json:"time"`
-	NextSlot               uint64             `json:"next_slot"`
-	PreviousSlot           uint64             `json:"prev_slot"`
-	Status                 uint16             `json:"status"`
-	Future                 bool               `json:"future"`
-	Proposer               uint64             `json:"proposer"`
-	ProposerName           string             `json:"proposer_name"`
-	Block                  *SlotPageBlockData `json:"block"`
-}
-
-type SlotStatus uint16
-
-const (
-	SlotStatusMissed   SlotStatus = 0
-	SlotStatusFound    SlotStatus = 1
-	SlotStatusOrphaned SlotStatus = 2
-)
+	Slot                   uint64                `json:"slot"`
+	Epoch                  uint64                `json:"epoch"`
+	EpochFinalized         bool                  `json:"epoch_finalized"`
+	EpochParticipationRate float64               `json:"epoch_participation_rate"`
+	Ts                     time.Time             `json:"time"`
+	NextSlot               uint64                `json:"next_slot"`
+	PreviousSlot           uint64                `json:"prev_slot"`
+	Status                 SlotStatus            `json:"status"`
+	Future                 bool                  `json:"future"`
+	Proposer               uint64                `json:"proposer"`
+	ProposerName           string                `json:"proposer_name"`
+	Block                  *SlotPageBlockData    `json:"block"`
+	Rewards                *SlotPageRewards      `json:"rewards"`
+	BlockRewards           *SlotPageBlockRewards `json:"block_rewards"`
+	Annotations            []*Annotation         `json:"annotations,omitempty"`
+}
+
+// SlotPageBlockRewards holds the consensus reward breakdown of the block in gwei
+type SlotPageBlockRewards struct {
+	Total             uint64 `json:"total"`
+	Attestations      uint64 `json:"attestations"`
+	SyncAggregate     uint64 `json:"sync_aggregate"`
+	ProposerSlashings uint64 `json:"proposer_slashings"`
+	AttesterSlashings uint64 `json:"attester_slashings"`
+	SyncRewards       uint64 `json:"sync_rewards"`
+	SyncPenalties     uint64 `json:"sync_penalties"`
+	SyncMissed        uint32 `json:"sync_missed"`
+}
+
+// SlotPageRewards holds the expected & realized proposer rewards in gwei
+type SlotPageRewards struct {
+	ClReward       uint64  `json:"cl_reward"`
+	MevBid         uint64  `json:"mev_bid"`
+	MevPayment     uint64  `json:"mev_payment"`
+	MevRelay       string  `json:"mev_relay"`
+	ExpectedReward uint64  `json:"expected_reward"`
+	ActualReward   uint64  `json:"actual_reward"`
+	Efficiency     float64 `json:"efficiency"`
+}
+
+// SlotPageCustomField is a block field specific to a custom devnet fork
+type SlotPageCustomField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SlotPageWitness is the summary of the verkle execution witness of the block
+type SlotPageWitness struct {
+	StemCount   uint64 `json:"stem_count"`
+	KeyCount    uint64 `json:"key_count"`
+	ReadCount   uint64 `json:"read_count"`
+	WriteCount  uint64 `json:"write_count"`
+	ProofSize   uint64 `json:"proof_size"`
+	WitnessSize uint64 `json:"witness_size"`
+}
+
+// SlotPageBlockArrival is the time a client announced the block, relative to the slot start and the first client
+type SlotPageBlockArrival struct {
+	Client string `json:"client"`
+	Delay  int64  `json:"delay"`
+	Behind int64  `json:"behind"`
+}
+
+// SlotPageTimeline places the block arrivals on the slot timeline, next to the attestation & aggregate deadlines.
+// all offsets are in ms since slot start, the percentages are the positions on the timeline.
+type SlotPageTimeline struct {
+	SlotDuration        int64                    `json:"slot_duration"`
+	AttestationDeadline int64                    `json:"attestation_deadline"`
+	AttestationPercent  float64                  `json:"attestation_percent"`
+	AggregateDeadline   int64                    `json:"aggregate_deadline"`
+	AggregatePercent    float64                  `json:"aggregate_percent"`
+	FirstArrival        int64                    `json:"first_arrival"`
+	AttestationMargin   int64                    `json:"attestation_margin"` // negative if the block arrived after the attestation deadline
+	Points              []*SlotPageTimelinePoint `json:"points"`
+}
+
+type SlotPageTimelinePoint struct {
+	Client  string  `json:"client"`
+	Delay   int64   `json:"delay"`
+	Percent float64 `json:"percent"`
+	Late    bool    `json:"late"`
+}
+
+// SlotPageDataColumns is the availability of the PeerDAS data columns of the block across the connected clients
+type SlotPageDataColumns struct {
+	ColumnCount     uint64                `json:"column_count"`
+	AvailableCount  uint64                `json:"available_count"`
+	Reconstructable bool                  `json:"reconstructable"`
+	Clients         []string              `json:"clients"`
+	Columns         []*SlotPageDataColumn `json:"columns"`
+}
+
+type SlotPageDataColumn struct {
+	Index     uint64   `json:"index"`
+	Available bool     `json:"available"`
+	Custody   []string `json:"custody"`
+}
 
 type SlotPageBlockData struct {
-	BlockRoot              []byte                 `json:"blockroot"`
-	ParentRoot             []byte                 `json:"parentroot"`
-	StateRoot              []byte                 `json:"stateroot"`
-	Signature              []byte                 `json:"signature"`
-	RandaoReveal           []byte                 `json:"randaoreveal"`
-	Graffiti               []byte                 `json:"graffiti"`
-	Eth1dataDepositroot    []byte                 `json:"eth1data_depositroot"`
-	Eth1dataDepositcount   uint64                 `json:"eth1data_depositcount"`
-	Eth1dataBlockhash      []byte                 `json:"eth1data_blockhash"`
-	SyncAggregateBits      []byte                 `json:"syncaggregate_bits"`
-	SyncAggregateSignature []byte                 `json:"syncaggregate_signature"`
-	SyncAggParticipation   float64                `json:"syncaggregate_participation"`
-	SyncAggCommittee       []types.NamedValidator `json:"syncaggregate_committee"`
-	ProposerSlashingsCount uint64                 `json:"proposer_slashings_count"`
-	AttesterSlashingsCount uint64                 `json:"attester_slashings_count"`
-	AttestationsCount      uint64                 `json:"attestations_count"`
-	DepositsCount          uint64                 `json:"deposits_count"`
-	WithdrawalsCount       uint64                 `json:"withdrawals_count"`
-	BLSChangesCount        uint64                 `json:"bls_changes_count"`
-	VoluntaryExitsCount    uint64                 `json:"voluntaryexits_count"`
-	SlashingsCount         uint64                 `json:"slashings_count"`
-	BlobsCount             uint64                 `json:"blobs_count"`
-	DutiesLoaded           bool                   `json:"duties_loaded"`
+	BlockRoot              []byte                  `json:"blockroot"`
+	ParentRoot             []byte                  `json:"parentroot"`
+	StateRoot              []byte                  `json:"stateroot"`
+	Signature              []byte                  `json:"signature"`
+	RandaoReveal           []byte                  `json:"randaoreveal"`
+	Graffiti               []byte                  `json:"graffiti"`
+	Eth1dataDepositroot    []byte                  `json:"eth1data_depositroot"`
+	Eth1dataDepositcount   uint64                  `json:"eth1data_depositcount"`
+	Eth1dataBlockhash      []byte                  `json:"eth1data_blockhash"`
+	SyncAggregateBits      []byte                  `json:"syncaggregate_bits"`
+	SyncAggregateSignature []byte                  `json:"syncaggregate_signature"`
+	SyncAggParticipation   float64                 `json:"syncaggregate_participation"`
+	SyncAggCommittee       []types.NamedValidator  `json:"syncaggregate_committee"`
+	SyncAggMembers         []*SlotPageSyncMember   `json:"syncaggregate_members"`
+	SyncAggMissed          []types.NamedValidator  `json:"syncaggregate_missed"`
+	Arrivals               []*SlotPageBlockArrival `json:"arrivals"`
+	ArrivalSpread          int64                   `json:"arrival_spread"`
+	ArrivalTimeline        *SlotPageTimeline       `json:"arrival_timeline,omitempty"`
+	ProposerSlashingsCount uint64                  `json:"proposer_slashings_count"`
+	AttesterSlashingsCount uint64                  `json:"attester_slashings_count"`
+	AttestationsCount      uint64                  `json:"attestations_count"`
+	Aggregation            *AggregationStats       `json:"aggregation"`
+	DepositsCount          uint64                  `json:"deposits_count"`
+	WithdrawalsCount       uint64                  `json:"withdrawals_count"`
+	BLSChangesCount        uint64                  `json:"bls_changes_count"`
+	VoluntaryExitsCount    uint64                  `json:"voluntaryexits_count"`
+	SlashingsCount         uint64                  `json:"slashings_count"`
+	BlobsCount             uint64                  `json:"blobs_count"`
+	OperationProofs        bool                    `json:"operation_proofs"` // deposit & withdrawal proofs can be downloaded
+	DutiesLoaded           bool                    `json:"duties_loaded"`
+	ProposerDependentRoot  []byte                  `json:"proposer_dependent_root,omitempty"`
 
 	ExecutionData     *SlotPageExecutionData      `json:"execution_data"`
 	Attestations      []*SlotPageAttestation      `json:"attestations"`       // Attestations included in this block
@@ -64,24 +149,39 @@ type SlotPageBlockData struct {
 	BLSChanges        []*SlotPageBLSChange        `json:"bls_changes"`        // BLSChanges included in this block
 	Withdrawals       []*SlotPageWithdrawal       `json:"withdrawals"`        // Withdrawals included in this block
 	Blobs             []*SlotPageBlob             `json:"blobs"`              // Blob sidecars included in this block
+	CustomFork        string                      `json:"custom_fork,omitempty"`
+	CustomFields      []*SlotPageCustomField      `json:"custom_fields,omitempty"`
+	Witness           *SlotPageWitness            `json:"witness,omitempty"`
+	DataColumns       *SlotPageDataColumns        `json:"data_columns,omitempty"`
+}
+
+type SlotPageSyncMember struct {
+	Index        uint64 `json:"index"`
+	Name         string `json:"name"`
+	Participated bool   `json:"participated"`
 }
 
 type SlotPageExecutionData struct {
-	ParentHash        []byte    `json:"parent_hash"`
-	FeeRecipient      []byte    `json:"fee_recipient"`
-	StateRoot         []byte    `json:"state_root"`
-	ReceiptsRoot      []byte    `json:"receipts_root"`
-	LogsBloom         []byte    `json:"logs_bloom"`
-	Random            []byte    `json:"random"`
-	GasLimit          uint64    `json:"gas_limit"`
-	GasUsed           uint64    `json:"gas_used"`
-	Timestamp         uint64    `json:"timestamp"`
-	Time              time.Time `json:"time"`
-	ExtraData         []byte    `json:"extra_data"`
-	BaseFeePerGas     uint64    `json:"base_fee_per_gas"`
-	BlockHash         []byte    `json:"block_hash"`
-	BlockNumber       uint64    `json:"block_number"`
-	TransactionsCount uint64    `json:"transactions_count"`
+	ParentHash        []byte            `json:"parent_hash"`
+	FeeRecipient      []byte            `json:"fee_recipient"`
+	StateRoot         []byte            `json:"state_root"`
+	ReceiptsRoot      []byte            `json:"receipts_root"`
+	LogsBloom         []byte            `json:"logs_bloom"`
+	Random            []byte            `json:"random"`
+	GasLimit          uint64            `json:"gas_limit"`
+	GasUsed           uint64            `json:"gas_used"`
+	Timestamp         uint64            `json:"timestamp"`
+	Time              time.Time         `json:"time"`
+	ExtraData         []byte            `json:"extra_data"`
+	ElClient          string            `json:"el_client"`
+	BaseFeePerGas     uint64            `json:"base_fee_per_gas"`
+	BlockHash         []byte            `json:"block_hash"`
+	BlockNumber       uint64            `json:"block_number"`
+	TransactionsCount uint64            `json:"transactions_count"`
+	TxTypes           *TransactionTypes `json:"tx_types"`
+
+	ExpectedFeeRecipient []byte `json:"expected_fee_recipient,omitempty"`
+	FeeRecipientDeviates bool   `json:"fee_recipient_deviates"`
 }
 
 type SlotPageAttestation struct {
@@ -135,6 +235,9 @@ type SlotPageAttesterSlashing struct {
 	Attestation2TargetEpoch     uint64                 `json:"attestation2_target_epoch"`
 	Attestation2TargetRoot      []byte                 `json:"attestation2_target_root"`
 	SlashedValidators           []types.NamedValidator `json:"validators"`
+	SlashingType                string                 `json:"slashing_type"`
+	EvidenceValid               bool                   `json:"evidence_valid"`
+	EvidenceReason              string                 `json:"evidence_reason"`
 }
 
 // BlockPageProposerSlashing is a struct to hold data for proposer slashings on the block page
@@ -151,6 +254,9 @@ type SlotPageProposerSlashing struct {
 	Header2StateRoot  []byte `json:"header2_stateroot"`
 	Header2BodyRoot   []byte `json:"header2_bodyroot"`
 	Header2Signature  []byte `json:"header2_signature"`
+	SlashingType      string `json:"slashing_type"`
+	EvidenceValid     bool   `json:"evidence_valid"`
+	EvidenceReason    string `json:"evidence_reason"`
 }
 
 type SlotPageBLSChange struct {
@@ -170,13 +276,24 @@ type SlotPageWithdrawal struct {
 }
 
 type SlotPageBlob struct {
-	Index         uint64 `json:"index"`
-	KzgCommitment []byte `json:"kzg_commitment"`
-	HaveData      bool   `json:"have_data"`
-	IsShort       bool   `json:"is_short"`
-	BlobShort     []byte `json:"blob_short"`
-	Blob          []byte `json:"blob"`
-	KzgProof      []byte `json:"kzg_proof"`
+	Index         uint64                   `json:"index"`
+	KzgCommitment []byte                   `json:"kzg_commitment"`
+	VersionedHash []byte                   `json:"versioned_hash"`
+	Transaction   *SlotPageBlobTransaction `json:"transaction,omitempty"`
+	HaveData      bool                     `json:"have_data"`
+	IsShort       bool                     `json:"is_short"`
+	BlobShort     []byte                   `json:"blob_short"`
+	Blob          []byte                   `json:"blob"`
+	KzgProof      []byte                   `json:"kzg_proof"`
+}
+
+type SlotPageBlobTransaction struct {
+	Index     uint64 `json:"index"`
+	Hash      []byte `json:"hash"`
+	From      []byte `json:"from"`
+	To        []byte `json:"to"`
+	BlobIndex uint64 `json:"blob_index"` // index of the blob within the transaction
+	BlobCount uint64 `json:"blob_count"`
 }
 
 type SlotPageBlobDetails struct {
@@ -184,4 +301,31 @@ type SlotPageBlobDetails struct {
 	Blob          string `json:"blob"`
 	KzgCommitment string `json:"kzg_commitment"`
 	KzgProof      string `json:"kzg_proof"`
+	VersionedHash string `json:"versioned_hash"`
+	TxHash        string `json:"tx_hash,omitempty"`
+	TxFrom        string `json:"tx_from,omitempty"`
+	TxTo          string `json:"tx_to,omitempty"`
+}
+
+type SlotPageRawBlock struct {
+	Root    string          `json:"root"`
+	Version string          `json:"version"`
+	Ssz     string          `json:"ssz"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// SlotPageTransition holds the result of a state transition re-execution for a block
+type SlotPageTransition struct {
+	Slot              uint64 `json:"slot"`
+	BlockRoot         string `json:"block_root"`
+	ParentRoot        string `json:"parent_root"`
+	Version           string `json:"version"`
+	PreStateRoot      string `json:"pre_state_root"`
+	PreStateSize      uint64 `json:"pre_state_size"`
+	BlockSize         uint64 `json:"block_size"`
+	ExpectedStateRoot string `json:"expected_state_root"`
+	ToolStateRoot     string `json:"tool_state_root,omitempty"`
+	Executed          bool   `json:"executed"`
+	Match             bool   `json:"match"`
+	Error             string `json:"error,omitempty"`
 }
@@ -22,6 +22,25 @@ type SlotsPageData struct {
 	NextPageIndex    uint64 `json:"next_page_index"`
 	NextPageSlot     uint64 `json:"next_page_slot"`
 	LastPageSlot     uint64 `json:"last_page_slot"`
+
+	Pending *SlotsPendingData `json:"pending,omitempty"` // in-progress slot, only on the default page
+
+	LightMode       bool   `json:"light_mode"` // running without db, history before LowestLiveEpoch is unavailable
+	LowestLiveEpoch uint64 `json:"lowest_live_epoch"`
+}
+
+// SlotsPendingData holds the live state of the current in-progress slot
+type SlotsPendingData struct {
+	Slot         uint64    `json:"slot"`
+	Epoch        uint64    `json:"epoch"`
+	Ts           time.Time `json:"ts"`
+	EndTs        time.Time `json:"end_ts"`
+	HasProposer  bool      `json:"has_proposer"`
+	Proposer     uint64    `json:"proposer"`
+	ProposerName string    `json:"proposer_name"`
+	Status       string    `json:"status"` // awaiting / seen / missed
+	BlockRoot    string    `json:"block_root,omitempty"`
+	SeenDelay    float64   `json:"seen_delay,omitempty"` // seconds after slot start
 }
 
 type SlotsPageDataSlot struct {
@@ -30,7 +49,7 @@ type SlotsPageDataSlot struct {
 	Ts                    time.Time                 `json:"ts"`
 	Finalized             bool                      `json:"scheduled"`
 	Scheduled             bool                      `json:"finalized"`
-	Status                uint8                     `json:"status"`
+	Status                SlotStatus                `json:"status"`
 	Synchronized          bool                      `json:"synchronized"`
 	Proposer              uint64                    `json:"proposer"`
 	ProposerName          string                    `json:"proposer_name"`
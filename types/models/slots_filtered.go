@@ -6,11 +6,16 @@ import (
 
 // SlotsPageData is a struct to hold info for the slots page
 type SlotsFilteredPageData struct {
-	FilterGraffiti     string `json:"filter_graffiti"`
-	FilterProposer     string `json:"filter_proposer"`
-	FilterProposerName string `json:"filter_pname"`
-	FilterWithOrphaned uint8  `json:"filter_orphaned"`
-	FilterWithMissing  uint8  `json:"filter_missing"`
+	FilterGraffiti      string `json:"filter_graffiti"`
+	FilterGraffitiRe    bool   `json:"filter_graffiti_regex"`
+	FilterGraffitiPfx   bool   `json:"filter_graffiti_prefix"`
+	FilterGraffitiError string `json:"filter_graffiti_error,omitempty"`
+	FilterProposer      string `json:"filter_proposer"`
+	FilterProposerName  string `json:"filter_pname"`
+	FilterWithOrphaned  uint8  `json:"filter_orphaned"`
+	FilterWithMissing   uint8  `json:"filter_missing"`
+	FilterMinSlot       string `json:"filter_minslot"`
+	FilterMaxSlot       string `json:"filter_maxslot"`
 
 	Slots     []*SlotsFilteredPageDataSlot `json:"slots"`
 	SlotCount uint64                       `json:"slot_count"`
@@ -34,26 +39,33 @@ type SlotsFilteredPageData struct {
 	LastPageLink  string `json:"last_page_link"`
 }
 
+// SlotsFilteredApiData is a struct to hold the response of the filtered slots api
+type SlotsFilteredApiData struct {
+	Slots      []*SlotsFilteredPageDataSlot `json:"slots"`
+	Count      uint64                       `json:"count"`
+	NextCursor string                       `json:"next_cursor,omitempty"`
+}
+
 type SlotsFilteredPageDataSlot struct {
-	Slot                  uint64    `json:"slot"`
-	Epoch                 uint64    `json:"epoch"`
-	Ts                    time.Time `json:"ts"`
-	Finalized             bool      `json:"scheduled"`
-	Scheduled             bool      `json:"finalized"`
-	Status                uint8     `json:"status"`
-	Synchronized          bool      `json:"synchronized"`
-	Proposer              uint64    `json:"proposer"`
-	ProposerName          string    `json:"proposer_name"`
-	AttestationCount      uint64    `json:"attestation_count"`
-	DepositCount          uint64    `json:"deposit_count"`
-	ExitCount             uint64    `json:"exit_count"`
-	ProposerSlashingCount uint64    `json:"proposer_slashing_count"`
-	AttesterSlashingCount uint64    `json:"attester_slashing_count"`
-	SyncParticipation     float64   `json:"sync_participation"`
-	EthTransactionCount   uint64    `json:"eth_transaction_count"`
-	WithEthBlock          bool      `json:"with_eth_block"`
-	EthBlockNumber        uint64    `json:"eth_block_number"`
-	Graffiti              []byte    `json:"graffiti"`
-	BlockRoot             []byte    `json:"block_root"`
-	ParentRoot            []byte    `json:"parent_root"`
+	Slot                  uint64     `json:"slot"`
+	Epoch                 uint64     `json:"epoch"`
+	Ts                    time.Time  `json:"ts"`
+	Finalized             bool       `json:"scheduled"`
+	Scheduled             bool       `json:"finalized"`
+	Status                SlotStatus `json:"status"`
+	Synchronized          bool       `json:"synchronized"`
+	Proposer              uint64     `json:"proposer"`
+	ProposerName          string     `json:"proposer_name"`
+	AttestationCount      uint64     `json:"attestation_count"`
+	DepositCount          uint64     `json:"deposit_count"`
+	ExitCount             uint64     `json:"exit_count"`
+	ProposerSlashingCount uint64     `json:"proposer_slashing_count"`
+	AttesterSlashingCount uint64     `json:"attester_slashing_count"`
+	SyncParticipation     float64    `json:"sync_participation"`
+	EthTransactionCount   uint64     `json:"eth_transaction_count"`
+	WithEthBlock          bool       `json:"with_eth_block"`
+	EthBlockNumber        uint64     `json:"eth_block_number"`
+	Graffiti              []byte     `json:"graffiti"`
+	BlockRoot             []byte     `json:"block_root"`
+	ParentRoot            []byte     `json:"parent_root"`
 }
@@ -0,0 +1,31 @@
+package models
+
+// SlotStatus is the proposal status of a slot, shared by all slot & block lists.
+// the values are part of the json apis, so they must not change.
+type SlotStatus uint8
+
+const (
+	SlotStatusMissed   SlotStatus = 0
+	SlotStatusFound    SlotStatus = 1
+	SlotStatusOrphaned SlotStatus = 2
+)
+
+// NewSlotStatus returns the status of a slot with a block, orphaned is the db flag of the block
+func NewSlotStatus(orphaned uint8) SlotStatus {
+	if orphaned == 1 {
+		return SlotStatusOrphaned
+	}
+	return SlotStatusFound
+}
+
+func (status SlotStatus) IsMissed() bool {
+	return status == SlotStatusMissed
+}
+
+func (status SlotStatus) IsFound() bool {
+	return status == SlotStatusFound
+}
+
+func (status SlotStatus) IsOrphaned() bool {
+	return status == SlotStatusOrphaned
+}
@@ -9,6 +9,7 @@ type ValidatorPageData struct {
 	CurrentEpoch        uint64    `json:"current_epoch"`
 	Index               uint64    `json:"index"`
 	Name                string    `json:"name"`
+	NameSource          string    `json:"name_source"`
 	PublicKey           []byte    `json:"pubkey"`
 	Balance             uint64    `json:"balance"`
 	EffectiveBalance    uint64    `json:"eff_balance"`
@@ -31,17 +32,81 @@ type ValidatorPageData struct {
 	ShowWithdrawAddress bool      `json:"show_withdraw_address"`
 	WithdrawAddress     []byte    `json:"withdraw_address"`
 
+	Metadata []*ValidatorPageDataMetadata `json:"metadata"`
+
+	ShowVoteStats  bool    `json:"show_vote_stats"`
+	VoteDuties     uint64  `json:"vote_duties"`
+	VoteAttested   uint64  `json:"vote_attested"`
+	VoteFirstEpoch uint64  `json:"vote_first_epoch"`
+	VoteLastEpoch  uint64  `json:"vote_last_epoch"`
+	VoteTargetRate float64 `json:"vote_target_rate"`
+	VoteHeadRate   float64 `json:"vote_head_rate"`
+	VoteWrongRate  float64 `json:"vote_wrong_rate"`
+
+	ShowSummary          bool   `json:"show_summary"`
+	SummaryEpoch         uint64 `json:"summary_epoch"`
+	ProposedBlocks       uint64 `json:"proposed_blocks"`
+	MissedBlocks         uint64 `json:"missed_blocks"`
+	ShowLastAttestation  bool   `json:"show_last_attestation"`
+	LastAttestationEpoch uint64 `json:"last_attestation_epoch"`
+	WithdrawalCount      uint64 `json:"withdrawal_count"`
+	WithdrawnAmount      uint64 `json:"withdrawn_amount"`
+	ProposerIncome       uint64 `json:"proposer_income"`
+
+	Deposits            []*ValidatorPageDataDeposit      `json:"deposits"`
+	ConflictingDeposits bool                             `json:"conflicting_deposits"`
+	Doppelgangers       []*ValidatorPageDataDoppelganger `json:"doppelgangers"`
+	RelatedCount        uint64                           `json:"related_count"` // validators sharing the withdrawal address or deposit credentials
+
+	Lifecycle *ValidatorLifecycleTimeline `json:"lifecycle"`
+
 	RecentBlocks     []*ValidatorPageDataBlocks `json:"recent_blocks"`
 	RecentBlockCount uint64                     `json:"recent_block_count"`
+
+	Consolidations []*ValidatorPageDataConsolidation `json:"consolidations"`
+}
+
+// ValidatorPageDataConsolidation is a consolidation request with the validator as source or target
+type ValidatorPageDataConsolidation struct {
+	Slot          uint64 `json:"slot"`
+	BlockRoot     []byte `json:"block_root"`
+	IsSource      bool   `json:"is_source"`
+	HasOther      bool   `json:"has_other"`
+	OtherIndex    uint64 `json:"other_index"`
+	OtherName     string `json:"other_name"`
+	OtherPubkey   []byte `json:"other_pubkey"`
+	Amount        uint64 `json:"amount"`         // effective balance of the source when the request was included
+	TargetBalance uint64 `json:"target_balance"` // effective balance of the target when the request was included
+}
+
+type ValidatorPageDataMetadata struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type ValidatorPageDataDeposit struct {
+	Slot                  uint64 `json:"slot"`
+	BlockRoot             []byte `json:"block_root"`
+	WithdrawalCredentials []byte `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Receipt               bool   `json:"receipt"` // EIP-6110 deposit receipt from the execution payload
+}
+
+type ValidatorPageDataDoppelganger struct {
+	Epoch             uint64 `json:"epoch"`
+	Slot              uint64 `json:"slot"`
+	BlockRoot         []byte `json:"block_root"`
+	ConflictSlot      uint64 `json:"conflict_slot"`
+	ConflictBlockRoot []byte `json:"conflict_block_root"`
 }
 
 type ValidatorPageDataBlocks struct {
-	Epoch        uint64    `json:"epoch"`
-	Slot         uint64    `json:"slot"`
-	WithEthBlock bool      `json:"with_eth_block"`
-	EthBlock     uint64    `json:"eth_block"`
-	Ts           time.Time `json:"ts"`
-	Status       uint64    `json:"status"`
-	BlockRoot    string    `json:"block_root"`
-	Graffiti     []byte    `json:"graffiti"`
+	Epoch        uint64     `json:"epoch"`
+	Slot         uint64     `json:"slot"`
+	WithEthBlock bool       `json:"with_eth_block"`
+	EthBlock     uint64     `json:"eth_block"`
+	Ts           time.Time  `json:"ts"`
+	Status       SlotStatus `json:"status"`
+	BlockRoot    string     `json:"block_root"`
+	Graffiti     []byte     `json:"graffiti"`
 }
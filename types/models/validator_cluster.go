@@ -0,0 +1,36 @@
+package models
+
+// ValidatorClusterPageData is a struct to hold info for the related validators page of a validator
+type ValidatorClusterPageData struct {
+	Index          uint64                          `json:"index"`
+	Name           string                          `json:"name"`
+	Keys           []*ValidatorClusterPageDataKey  `json:"keys"`
+	ValidatorCount uint64                          `json:"validator_count"` // related validators, not including the validator itself
+	ActiveCount    uint64                          `json:"active_count"`
+	TotalBalance   uint64                          `json:"total_balance"`
+	Validators     []*ValidatorClusterPageDataItem `json:"validators"`
+
+	PageSize         uint64 `json:"page_size"`
+	CurrentPageIndex uint64 `json:"page_index"`
+	TotalPages       uint64 `json:"total_pages"`
+	PrevPageIndex    uint64 `json:"prev_page_index"`
+	NextPageIndex    uint64 `json:"next_page_index"`
+}
+
+// ValidatorClusterPageDataKey is a withdrawal address or credential the cluster is built from
+type ValidatorClusterPageDataKey struct {
+	Credentials []byte `json:"credentials"`
+	Address     []byte `json:"address,omitempty"` // withdrawal address of execution credentials (0x01 & 0x02 prefixes are matched alike)
+	Deposit     bool   `json:"deposit"`           // credentials of a deposit for the validator, which differ from the current credentials
+}
+
+type ValidatorClusterPageDataItem struct {
+	Index             uint64 `json:"index"`
+	Name              string `json:"name"`
+	PublicKey         []byte `json:"pubkey"`
+	State             string `json:"state"`
+	Balance           uint64 `json:"balance"`
+	EffectiveBalance  uint64 `json:"eff_balance"`
+	SharedCredentials bool   `json:"shared_credentials"` // same current withdrawal address / credentials
+	SharedDeposit     bool   `json:"shared_deposit"`     // deposited with the same withdrawal credentials
+}
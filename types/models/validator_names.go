@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// ValidatorNamesPageData is a struct to hold info for the name history page of a validator
+type ValidatorNamesPageData struct {
+	Index          uint64                        `json:"index"`
+	Name           string                        `json:"name"`
+	NameSource     string                        `json:"name_source"`
+	NameSourceName string                        `json:"name_source_name"`
+	History        []*ValidatorNamesPageDataItem `json:"history"`
+	HistoryCount   uint64                        `json:"history_count"`
+	LightMode      bool                          `json:"light_mode"` // names are not persisted, so there is no history
+
+	PageSize         uint64 `json:"page_size"`
+	CurrentPageIndex uint64 `json:"page_index"`
+	TotalPages       uint64 `json:"total_pages"`
+	PrevPageIndex    uint64 `json:"prev_page_index"`
+	NextPageIndex    uint64 `json:"next_page_index"`
+}
+
+type ValidatorNamesPageDataItem struct {
+	Changed      time.Time `json:"changed"`
+	Name         string    `json:"name"` // empty if the name has been removed
+	Source       string    `json:"source"`
+	SourceName   string    `json:"source_name"`
+	PreviousName string    `json:"previous_name"`
+}
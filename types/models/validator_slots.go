@@ -28,23 +28,23 @@ type ValidatorSlotsPageData struct {
 }
 
 type ValidatorSlotsPageDataSlot struct {
-	Slot                  uint64    `json:"slot"`
-	Epoch                 uint64    `json:"epoch"`
-	Ts                    time.Time `json:"ts"`
-	Finalized             bool      `json:"scheduled"`
-	Scheduled             bool      `json:"finalized"`
-	Status                uint8     `json:"status"`
-	Proposer              uint64    `json:"proposer"`
-	ProposerName          string    `json:"proposer_name"`
-	AttestationCount      uint64    `json:"attestation_count"`
-	DepositCount          uint64    `json:"deposit_count"`
-	ExitCount             uint64    `json:"exit_count"`
-	ProposerSlashingCount uint64    `json:"proposer_slashing_count"`
-	AttesterSlashingCount uint64    `json:"attester_slashing_count"`
-	SyncParticipation     float64   `json:"sync_participation"`
-	EthTransactionCount   uint64    `json:"eth_transaction_count"`
-	WithEthBlock          bool      `json:"with_eth_block"`
-	EthBlockNumber        uint64    `json:"eth_block_number"`
-	Graffiti              []byte    `json:"graffiti"`
-	BlockRoot             []byte    `json:"block_root"`
+	Slot                  uint64     `json:"slot"`
+	Epoch                 uint64     `json:"epoch"`
+	Ts                    time.Time  `json:"ts"`
+	Finalized             bool       `json:"scheduled"`
+	Scheduled             bool       `json:"finalized"`
+	Status                SlotStatus `json:"status"`
+	Proposer              uint64     `json:"proposer"`
+	ProposerName          string     `json:"proposer_name"`
+	AttestationCount      uint64     `json:"attestation_count"`
+	DepositCount          uint64     `json:"deposit_count"`
+	ExitCount             uint64     `json:"exit_count"`
+	ProposerSlashingCount uint64     `json:"proposer_slashing_count"`
+	AttesterSlashingCount uint64     `json:"attester_slashing_count"`
+	SyncParticipation     float64    `json:"sync_participation"`
+	EthTransactionCount   uint64     `json:"eth_transaction_count"`
+	WithEthBlock          bool       `json:"with_eth_block"`
+	EthBlockNumber        uint64     `json:"eth_block_number"`
+	Graffiti              []byte     `json:"graffiti"`
+	BlockRoot             []byte     `json:"block_root"`
 }
@@ -30,6 +30,7 @@ type ValidatorsPageData struct {
 	NextPageValIdx    uint64                         `json:"next_page_validx"`
 	LastPageValIdx    uint64                         `json:"last_page_validx"`
 	FilteredPageLink  string                         `json:"filtered_page_link"`
+	TablePartial      string                         `json:"-"`
 }
 
 type ValidatorsPageDataStatusOption struct {
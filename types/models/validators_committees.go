@@ -0,0 +1,45 @@
+package models
+
+// ValidatorsCommitteesPageData is a struct to hold info for the problem committees & operators report page
+type ValidatorsCommitteesPageData struct {
+	FirstEpoch       uint64                                   `json:"first_epoch"`
+	LastEpoch        uint64                                   `json:"last_epoch"`
+	EpochCount       uint64                                   `json:"epoch_count"`
+	CommitteeIndexes []*ValidatorsCommitteesPageDataIndex     `json:"committee_indexes"`
+	Operators        []*ValidatorsCommitteesPageDataOperator  `json:"operators"`
+	Committees       []*ValidatorsCommitteesPageDataCommittee `json:"committees"`
+}
+
+// ValidatorsCommitteesPageDataIndex holds the missing & late votes of all committees with the same index
+type ValidatorsCommitteesPageDataIndex struct {
+	CommitteeIndex uint64  `json:"committee_index"`
+	ProblemEpochs  uint64  `json:"problem_epochs"`
+	Duties         uint64  `json:"duties"`
+	Missed         uint64  `json:"missed"`
+	Late           uint64  `json:"late"`
+	MissedRate     float64 `json:"missed_rate"`
+	LateRate       float64 `json:"late_rate"`
+	TopName        string  `json:"top_name"`
+	TopNameCount   uint64  `json:"top_name_count"`
+}
+
+type ValidatorsCommitteesPageDataOperator struct {
+	Name          string  `json:"name"`
+	Duties        uint64  `json:"duties"`
+	Missed        uint64  `json:"missed"`
+	Late          uint64  `json:"late"`
+	MissedRate    float64 `json:"missed_rate"`
+	LateRate      float64 `json:"late_rate"`
+	TopCommittees uint64  `json:"top_committees"`
+}
+
+type ValidatorsCommitteesPageDataCommittee struct {
+	Epoch          uint64 `json:"epoch"`
+	Slot           uint64 `json:"slot"`
+	CommitteeIndex uint64 `json:"committee_index"`
+	Duties         uint64 `json:"duties"`
+	Missed         uint64 `json:"missed"`
+	Late           uint64 `json:"late"`
+	TopName        string `json:"top_name"`
+	TopNameCount   uint64 `json:"top_name_count"`
+}
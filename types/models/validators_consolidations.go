@@ -0,0 +1,31 @@
+package models
+
+// ValidatorsConsolidationsPageData is a struct to hold info for the consolidation planning page
+type ValidatorsConsolidationsPageData struct {
+	Name                string                                  `json:"name"`
+	MaxEffectiveBalance uint64                                  `json:"max_effective_balance"`
+	ValidatorCount      uint64                                  `json:"validator_count"`
+	SkippedCount        uint64                                  `json:"skipped_count"`
+	TargetCount         uint64                                  `json:"target_count"`
+	SourceCount         uint64                                  `json:"source_count"`
+	ConsolidatedCount   uint64                                  `json:"consolidated_count"`
+	Plans               []*ValidatorsConsolidationsPageDataPlan `json:"plans"`
+}
+
+// ValidatorsConsolidationsPageDataPlan is a suggested consolidation of some source validators into one target validator
+type ValidatorsConsolidationsPageDataPlan struct {
+	WithdrawAddress []byte                                       `json:"withdraw_address"`
+	Target          *ValidatorsConsolidationsPageDataValidator   `json:"target"`
+	Sources         []*ValidatorsConsolidationsPageDataValidator `json:"sources"`
+	ExpectedBalance uint64                                       `json:"expected_balance"`
+	Compounding     bool                                         `json:"compounding"`
+	Completed       bool                                         `json:"completed"`
+}
+
+type ValidatorsConsolidationsPageDataValidator struct {
+	Index   uint64 `json:"index"`
+	Name    string `json:"name"`
+	Pubkey  []byte `json:"pubkey"`
+	Balance uint64 `json:"balance"`
+	State   string `json:"state"`
+}
@@ -0,0 +1,46 @@
+package models
+
+// ValidatorsLifecyclePageData is a struct to hold info for the validator lifecycle page of a validator name group
+type ValidatorsLifecyclePageData struct {
+	Name           string                              `json:"name"`
+	ValidatorCount uint64                              `json:"validator_count"`
+	ShownCount     uint64                              `json:"shown_count"`
+	StatusCounts   []*ValidatorsLifecyclePageDataCount `json:"status_counts"`
+	Aggregate      *ValidatorLifecycleTimeline         `json:"aggregate"`
+	Timeline       *ValidatorLifecycleTimeline         `json:"timeline"`
+}
+
+type ValidatorsLifecyclePageDataCount struct {
+	Status string `json:"status"`
+	Count  uint64 `json:"count"`
+}
+
+// ValidatorLifecycleTimeline is a gantt chart of lifecycle states with a shared epoch axis
+type ValidatorLifecycleTimeline struct {
+	FirstEpoch uint64                      `json:"first_epoch"`
+	LastEpoch  uint64                      `json:"last_epoch"`
+	Markers    []*ValidatorLifecycleMarker `json:"markers"`
+	Rows       []*ValidatorLifecycleRow    `json:"rows"`
+}
+
+type ValidatorLifecycleMarker struct {
+	Epoch  uint64  `json:"epoch"`
+	Offset float64 `json:"offset"`
+}
+
+type ValidatorLifecycleRow struct {
+	Aggregate bool                       `json:"aggregate"`
+	Index     uint64                     `json:"index"`
+	Name      string                     `json:"name"`
+	Status    string                     `json:"status"`
+	Stages    []*ValidatorLifecycleStage `json:"stages"`
+}
+
+type ValidatorLifecycleStage struct {
+	Status     string  `json:"status"`
+	StartEpoch uint64  `json:"start_epoch"`
+	EndEpoch   uint64  `json:"end_epoch"`
+	Count      uint64  `json:"count,omitempty"` // number of validators that reached the state (aggregate rows only)
+	Offset     float64 `json:"offset"`
+	Width      float64 `json:"width"`
+}
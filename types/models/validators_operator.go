@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// ValidatorsOperatorPageData is a struct to hold info for the "my validators" operator dashboard
+type ValidatorsOperatorPageData struct {
+	Clients        []*ValidatorsOperatorPageDataClient `json:"clients"`
+	ActivityEpochs uint64                              `json:"activity_epochs"`
+}
+
+type ValidatorsOperatorPageDataClient struct {
+	Name           string                                 `json:"name"`
+	HasRefresh     bool                                   `json:"has_refresh"`
+	LastRefresh    time.Time                              `json:"last_refresh"`
+	Error          string                                 `json:"error"`
+	PubkeyCount    uint64                                 `json:"pubkey_count"`
+	ValidatorCount uint64                                 `json:"validator_count"`
+	ActiveCount    uint64                                 `json:"active_count"`
+	PendingCount   uint64                                 `json:"pending_count"`
+	ExitedCount    uint64                                 `json:"exited_count"`
+	SlashedCount   uint64                                 `json:"slashed_count"`
+	OnlineCount    uint64                                 `json:"online_count"`
+	OfflineCount   uint64                                 `json:"offline_count"`
+	Balance        uint64                                 `json:"balance"`
+	Participation  float64                                `json:"participation"`
+	Metrics        []*ValidatorsOperatorPageDataMetric    `json:"metrics"`
+	Validators     []*ValidatorsOperatorPageDataValidator `json:"validators"`
+}
+
+type ValidatorsOperatorPageDataMetric struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+type ValidatorsOperatorPageDataValidator struct {
+	Index           uint64 `json:"index"`
+	Name            string `json:"name"`
+	State           string `json:"state"`
+	Balance         uint64 `json:"balance"`
+	ShowUpcheck     bool   `json:"show_upcheck"`
+	UpcheckActivity uint8  `json:"upcheck_act"`
+	UpcheckMaximum  uint8  `json:"upcheck_max"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// ValidatorsProjectionPageData is a struct to hold info for the validator set projection page
+type ValidatorsProjectionPageData struct {
+	DayCount       uint64 `json:"day_count"`
+	DepositsPerDay uint64 `json:"deposits_per_day"`
+	ExitsPerDay    uint64 `json:"exits_per_day"`
+	DepositBalance uint64 `json:"deposit_balance"` // effective balance of the assumed new validators (ETH)
+
+	Epoch           uint64                         `json:"epoch"`
+	ActiveCount     uint64                         `json:"active_count"`
+	EligibleEther   uint64                         `json:"eligible_ether"`
+	EntryQueueCount uint64                         `json:"entry_queue_count"`
+	ExitQueueCount  uint64                         `json:"exit_queue_count"`
+	Charts          []*EpochsPageSparkline         `json:"charts"`
+	Days            []*ValidatorsProjectionPageDay `json:"days"`
+}
+
+// ValidatorsProjectionPageDay is the projected validator set at the end of a day
+type ValidatorsProjectionPageDay struct {
+	Day                  uint64    `json:"day"`
+	Epoch                uint64    `json:"epoch"`
+	Ts                   time.Time `json:"ts"`
+	ActiveCount          uint64    `json:"active_count"`
+	EligibleEther        uint64    `json:"eligible_ether"`
+	EntryQueueCount      uint64    `json:"entry_queue_count"`
+	ExitQueueCount       uint64    `json:"exit_queue_count"`
+	ActivationChurnLimit uint64    `json:"activation_churn_limit"`
+	ExitChurnLimit       uint64    `json:"exit_churn_limit"`
+	EntryQueueDays       float64   `json:"entry_queue_days"` // time a new deposit would wait for its activation
+	ExitQueueDays        float64   `json:"exit_queue_days"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// ValidatorsUptimePageData is a struct to hold info for the validator uptime report page
+type ValidatorsUptimePageData struct {
+	FirstDay  uint64                              `json:"first_day"`
+	LastDay   uint64                              `json:"last_day"`
+	DayCount  uint64                              `json:"day_count"`
+	Days      []*ValidatorsUptimePageDataDay      `json:"days"`
+	Operators []*ValidatorsUptimePageDataOperator `json:"operators"`
+}
+
+type ValidatorsUptimePageDataDay struct {
+	Day   uint64    `json:"day"`
+	Start time.Time `json:"start"`
+}
+
+type ValidatorsUptimePageDataOperator struct {
+	Name          string                            `json:"name"`
+	Duties        uint64                            `json:"duties"`
+	Attested      uint64                            `json:"attested"`
+	Uptime        float64                           `json:"uptime"`
+	CorrectTarget uint64                            `json:"correct_target"`
+	CorrectHead   uint64                            `json:"correct_head"`
+	TargetRate    float64                           `json:"target_rate"`
+	HeadRate      float64                           `json:"head_rate"`
+	Days          []*ValidatorsUptimePageDataUptime `json:"days"`
+}
+
+type ValidatorsUptimePageDataUptime struct {
+	Day      uint64  `json:"day"`
+	HasData  bool    `json:"has_data"`
+	Duties   uint64  `json:"duties"`
+	Attested uint64  `json:"attested"`
+	Uptime   float64 `json:"uptime"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// WithdrawalAddressesPageData is a struct to hold info for the withdrawal address reconciliation page
+type WithdrawalAddressesPageData struct {
+	Addresses        []*WithdrawalAddressesPageDataAddress `json:"addresses"`
+	HaveExecutionApi bool                                  `json:"have_execution_api"`
+	FinalizedBlock   uint64                                `json:"finalized_block"`
+}
+
+type WithdrawalAddressesPageDataAddress struct {
+	Name           string `json:"name"`
+	Address        []byte `json:"address"`
+	Tracked        bool   `json:"tracked"`
+	FirstEpoch     uint64 `json:"first_epoch"`
+	LastEpoch      uint64 `json:"last_epoch"`
+	WithdrawCount  uint64 `json:"withdraw_count"`
+	WithdrawAmount uint64 `json:"withdraw_amount"`
+	HaveBalance    bool   `json:"have_balance"`
+	Balance        uint64 `json:"balance"`
+	Difference     uint64 `json:"difference"`
+	DifferenceNeg  bool   `json:"difference_negative"`
+	Discrepancy    bool   `json:"discrepancy"`
+	BalanceError   string `json:"balance_error,omitempty"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// WithdrawalCredentialsPageData is a struct to hold info for the withdrawal credentials page
+type WithdrawalCredentialsPageData struct {
+	Epochs     []*WithdrawalCredentialsPageDataEpoch `json:"epochs"`
+	EpochCount uint64                                `json:"epoch_count"`
+	FirstEpoch uint64                                `json:"first_epoch"`
+	LastEpoch  uint64                                `json:"last_epoch"`
+	PageSize   uint64                                `json:"page_size"`
+
+	Latest *WithdrawalCredentialsPageDataEpoch `json:"latest"`
+
+	PrevPageEpoch *uint64 `json:"prev_page_epoch"`
+	NextPageEpoch *uint64 `json:"next_page_epoch"`
+}
+
+type WithdrawalCredentialsPageDataEpoch struct {
+	Epoch                 uint64    `json:"epoch"`
+	Ts                    time.Time `json:"ts"`
+	ValidatorCount        uint64    `json:"validator_count"`
+	BlsCount              uint64    `json:"bls_count"`
+	ExecutionCount        uint64    `json:"execution_count"`
+	CompoundingCount      uint64    `json:"compounding_count"`
+	OtherCount            uint64    `json:"other_count"`
+	BlsPercentage         float64   `json:"bls_percentage"`
+	ExecutionPercentage   float64   `json:"execution_percentage"`
+	CompoundingPercentage float64   `json:"compounding_percentage"`
+	OtherPercentage       float64   `json:"other_percentage"`
+}
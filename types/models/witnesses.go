@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// WitnessesPageData is a struct to hold info for the execution witnesses page
+type WitnessesPageData struct {
+	Sections []*WitnessesPageSection `json:"sections"`
+}
+
+// WitnessesPageSection holds the witness size charts & table for one period type (epochs / days)
+type WitnessesPageSection struct {
+	Title       string                 `json:"title"`
+	PeriodLabel string                 `json:"period_label"`
+	PeriodLink  string                 `json:"period_link"`
+	Charts      []*EpochsPageSparkline `json:"charts"`
+	Periods     []*WitnessesPagePeriod `json:"periods"` // newest first
+}
+
+type WitnessesPagePeriod struct {
+	Period      uint64    `json:"period"`
+	Ts          time.Time `json:"ts"`
+	BlockCount  uint64    `json:"block_count"`
+	StemCount   float64   `json:"stem_count"`   // average per block
+	KeyCount    float64   `json:"key_count"`    // average per block
+	ProofSize   float64   `json:"proof_size"`   // average bytes per block
+	WitnessSize float64   `json:"witness_size"` // average bytes per block
+	MaxSize     uint64    `json:"max_size"`
+}
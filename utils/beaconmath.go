@@ -18,6 +18,14 @@ func DayOfSlot(slot uint64) uint64 {
 	return Config.Chain.Config.SecondsPerSlot * slot / (24 * 3600)
 }
 
+// DayToEpochRange returns the first & last epoch that start on a day (see DayOfSlot)
+func DayToEpochRange(day uint64) (uint64, uint64) {
+	epochSeconds := Config.Chain.Config.SecondsPerSlot * Config.Chain.Config.SlotsPerEpoch
+	firstEpoch := (day*24*3600 + epochSeconds - 1) / epochSeconds
+	nextEpoch := ((day+1)*24*3600 + epochSeconds - 1) / epochSeconds
+	return firstEpoch, nextEpoch - 1
+}
+
 // WeekOfSlot returns the corresponding week of a slot
 func WeekOfSlot(slot uint64) uint64 {
 	return Config.Chain.Config.SecondsPerSlot * slot / (7 * 24 * 3600)
@@ -28,6 +36,17 @@ func SlotToTime(slot uint64) time.Time {
 	return time.Unix(int64(Config.Chain.GenesisTimestamp+slot*Config.Chain.Config.SecondsPerSlot), 0)
 }
 
+// SlotAttestationDeadline returns the offset from the slot start at which attesters vote, with or without the block.
+// slots are split into 3 intervals: block proposal, attestation & aggregation
+func SlotAttestationDeadline() time.Duration {
+	return time.Duration(Config.Chain.Config.SecondsPerSlot) * time.Second / 3
+}
+
+// SlotAggregateDeadline returns the offset from the slot start at which the aggregators publish their aggregates
+func SlotAggregateDeadline() time.Duration {
+	return time.Duration(Config.Chain.Config.SecondsPerSlot) * time.Second * 2 / 3
+}
+
 // TimeToSlot returns time to slot in seconds
 func TimeToSlot(timestamp uint64) uint64 {
 	if Config.Chain.GenesisTimestamp > timestamp {
@@ -81,3 +100,17 @@ func GWeiToEther(gwei *big.Int) decimal.Decimal {
 func GWeiBytesToEther(gwei []byte) decimal.Decimal {
 	return GWeiToEther(new(big.Int).SetBytes(gwei))
 }
+
+// IsPeerDASEpoch returns true if blobs are distributed as data column sidecars (EIP-7594) in the epoch
+func IsPeerDASEpoch(epoch uint64) bool {
+	forkEpoch := Config.Chain.Config.Eip7594ForkEpoch
+	return forkEpoch != nil && epoch >= *forkEpoch
+}
+
+// NumberOfDataColumns returns the number of data columns each blob is extended to (128 if not set in the chain config)
+func NumberOfDataColumns() uint64 {
+	if Config.Chain.Config.NumberOfColumns == 0 {
+		return 128
+	}
+	return Config.Chain.Config.NumberOfColumns
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const blobTxType = 0x03
+
+const (
+	minBlobBaseFee            = 1
+	blobBaseFeeUpdateFraction = 3338477
+)
+
+// BlobTransaction holds the parts of a EIP-4844 blob transaction that are needed to map blobs to their transaction.
+type BlobTransaction struct {
+	Hash       []byte
+	From       []byte
+	To         []byte
+	BlobHashes [][]byte
+}
+
+// KzgCommitmentToVersionedHash returns the versioned hash for a blob kzg commitment as referenced by blob transactions
+func KzgCommitmentToVersionedHash(commitment []byte) []byte {
+	hash := sha256.Sum256(commitment)
+	hash[0] = 0x01
+	return hash[:]
+}
+
+// DecodeBlobTransaction decodes a typed blob transaction from an execution payload.
+// go-ethereum does not support type 3 transactions in the version we use, so the rlp envelope is parsed manually:
+// [chain_id, nonce, max_priority_fee_per_gas, max_fee_per_gas, gas_limit, to, value, data, access_list, max_fee_per_blob_gas, blob_versioned_hashes, y_parity, r, s]
+// Returns nil without error for non-blob transactions.
+func DecodeBlobTransaction(txBytes []byte) (*BlobTransaction, error) {
+	if len(txBytes) == 0 || txBytes[0] != blobTxType {
+		return nil, nil
+	}
+
+	fields := []rlp.RawValue{}
+	if err := rlp.DecodeBytes(txBytes[1:], &fields); err != nil {
+		return nil, fmt.Errorf("could not decode blob transaction: %w", err)
+	}
+	if len(fields) != 14 {
+		return nil, fmt.Errorf("invalid blob transaction field count: %v", len(fields))
+	}
+
+	blobTx := &BlobTransaction{
+		Hash: crypto.Keccak256(txBytes),
+	}
+	if err := rlp.DecodeBytes(fields[5], &blobTx.To); err != nil {
+		return nil, fmt.Errorf("could not decode blob transaction recipient: %w", err)
+	}
+	if err := rlp.DecodeBytes(fields[10], &blobTx.BlobHashes); err != nil {
+		return nil, fmt.Errorf("could not decode blob versioned hashes: %w", err)
+	}
+
+	var yParity uint64
+	var sigR, sigS big.Int
+	if err := rlp.DecodeBytes(fields[11], &yParity); err != nil {
+		return nil, fmt.Errorf("could not decode blob transaction signature: %w", err)
+	}
+	if err := rlp.DecodeBytes(fields[12], &sigR); err != nil {
+		return nil, fmt.Errorf("could not decode blob transaction signature: %w", err)
+	}
+	if err := rlp.DecodeBytes(fields[13], &sigS); err != nil {
+		return nil, fmt.Errorf("could not decode blob transaction signature: %w", err)
+	}
+	if yParity > 1 || sigR.BitLen() > 256 || sigS.BitLen() > 256 {
+		return nil, fmt.Errorf("invalid blob transaction signature")
+	}
+
+	unsignedTx, err := rlp.EncodeToBytes(fields[:11])
+	if err != nil {
+		return nil, err
+	}
+	sigHash := crypto.Keccak256([]byte{blobTxType}, unsignedTx)
+	signature := make([]byte, 65)
+	sigR.FillBytes(signature[0:32])
+	sigS.FillBytes(signature[32:64])
+	signature[64] = byte(yParity)
+
+	pubkey, err := crypto.Ecrecover(sigHash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover blob transaction sender: %w", err)
+	}
+	blobTx.From = crypto.Keccak256(pubkey[1:])[12:]
+
+	return blobTx, nil
+}
+
+// GetBlobBaseFee returns the blob base fee (in wei) for the given excess blob gas (EIP-4844 fake_exponential)
+func GetBlobBaseFee(excessBlobGas uint64) *big.Int {
+	factor := big.NewInt(minBlobBaseFee)
+	numerator := new(big.Int).SetUint64(excessBlobGas)
+	denominator := big.NewInt(blobBaseFeeUpdateFraction)
+
+	output := new(big.Int)
+	accum := new(big.Int).Mul(factor, denominator)
+	for i := int64(1); accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(i))
+	}
+	return output.Div(output, denominator)
+}
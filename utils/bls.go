@@ -0,0 +1,289 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// BlsSignatureDST is the domain separation tag of the proof of possession scheme used by ethereum validators
+const BlsSignatureDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+// bls12-381 base field modulus
+var blsFieldModulus, _ = new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+
+// VerifyBlsSignature checks a BLS signature of a message for a compressed validator pubkey (48 bytes) and signature (96 bytes).
+// The message is hashed to G2 as specified by the ethereum consensus specs, so signatures of any standard BLS library can be verified.
+func VerifyBlsSignature(pubkey []byte, message []byte, signature []byte) (bool, error) {
+	g1 := bls12381.NewG1()
+	pubkeyPoint, err := decompressG1(g1, pubkey)
+	if err != nil {
+		return false, fmt.Errorf("invalid pubkey: %v", err)
+	}
+	return verifyBlsPoint(g1, pubkeyPoint, message, signature)
+}
+
+// VerifyBlsAggregateSignature checks an aggregate BLS signature of a message that has been signed by all given pubkeys
+// (FastAggregateVerify), as used for attestation aggregates & sync aggregates.
+func VerifyBlsAggregateSignature(pubkeys [][]byte, message []byte, signature []byte) (bool, error) {
+	if len(pubkeys) == 0 {
+		return false, errors.New("no pubkeys")
+	}
+	g1 := bls12381.NewG1()
+	aggregatePoint := g1.Zero()
+	for _, pubkey := range pubkeys {
+		pubkeyPoint, err := decompressG1(g1, pubkey)
+		if err != nil {
+			return false, fmt.Errorf("invalid pubkey 0x%x: %v", pubkey, err)
+		}
+		g1.Add(aggregatePoint, aggregatePoint, pubkeyPoint)
+	}
+	return verifyBlsPoint(g1, aggregatePoint, message, signature)
+}
+
+func verifyBlsPoint(g1 *bls12381.G1, pubkeyPoint *bls12381.PointG1, message []byte, signature []byte) (bool, error) {
+	g2 := bls12381.NewG2()
+	signaturePoint, err := decompressG2(g2, signature)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature: %v", err)
+	}
+	messagePoint, err := hashToG2(g2, message, []byte(BlsSignatureDST))
+	if err != nil {
+		return false, err
+	}
+
+	// e(pubkey, H(message)) == e(g1, signature)
+	engine := bls12381.NewPairingEngine()
+	engine.AddPair(pubkeyPoint, messagePoint)
+	engine.AddPairInv(g1.One(), signaturePoint)
+	return engine.Check(), nil
+}
+
+func blsFieldBytes(value *big.Int) []byte {
+	out := make([]byte, 48)
+	value.FillBytes(out)
+	return out
+}
+
+func isLargestFieldElement(value *big.Int) bool {
+	return new(big.Int).Lsh(value, 1).Cmp(blsFieldModulus) > 0
+}
+
+// decompressG1 decodes a compressed G1 point (zcash serialization format)
+func decompressG1(g1 *bls12381.G1, in []byte) (*bls12381.PointG1, error) {
+	if len(in) != 48 {
+		return nil, errors.New("compressed G1 point must be 48 bytes")
+	}
+	if in[0]&0x80 == 0 {
+		return nil, errors.New("point is not compressed")
+	}
+	if in[0]&0x40 != 0 {
+		return nil, errors.New("point at infinity")
+	}
+	largest := in[0]&0x20 != 0
+
+	xBytes := make([]byte, 48)
+	copy(xBytes, in)
+	xBytes[0] &= 0x1f
+	x := new(big.Int).SetBytes(xBytes)
+	if x.Cmp(blsFieldModulus) >= 0 {
+		return nil, errors.New("x coordinate exceeds field modulus")
+	}
+
+	// y^2 = x^3 + 4
+	y2 := new(big.Int).Exp(x, big.NewInt(3), blsFieldModulus)
+	y2.Add(y2, big.NewInt(4))
+	y2.Mod(y2, blsFieldModulus)
+	sqrtExp := new(big.Int).Add(blsFieldModulus, big.NewInt(1))
+	sqrtExp.Rsh(sqrtExp, 2)
+	y := new(big.Int).Exp(y2, sqrtExp, blsFieldModulus)
+	if new(big.Int).Exp(y, big.NewInt(2), blsFieldModulus).Cmp(y2) != 0 {
+		return nil, errors.New("point is not on curve")
+	}
+	if isLargestFieldElement(y) != largest {
+		y.Sub(blsFieldModulus, y)
+	}
+
+	point, err := g1.FromBytes(append(blsFieldBytes(x), blsFieldBytes(y)...))
+	if err != nil {
+		return nil, err
+	}
+	if !g1.InCorrectSubgroup(point) {
+		return nil, errors.New("point is not in the correct subgroup")
+	}
+	return point, nil
+}
+
+// blsFp2 is an element of the quadratic extension field (c0 + c1 * u, u^2 = -1)
+type blsFp2 struct {
+	c0, c1 *big.Int
+}
+
+func newBlsFp2(c0, c1 *big.Int) *blsFp2 {
+	return &blsFp2{
+		c0: new(big.Int).Mod(c0, blsFieldModulus),
+		c1: new(big.Int).Mod(c1, blsFieldModulus),
+	}
+}
+
+func (a *blsFp2) add(b *blsFp2) *blsFp2 {
+	return newBlsFp2(new(big.Int).Add(a.c0, b.c0), new(big.Int).Add(a.c1, b.c1))
+}
+
+func (a *blsFp2) mul(b *blsFp2) *blsFp2 {
+	c0 := new(big.Int).Sub(new(big.Int).Mul(a.c0, b.c0), new(big.Int).Mul(a.c1, b.c1))
+	c1 := new(big.Int).Add(new(big.Int).Mul(a.c0, b.c1), new(big.Int).Mul(a.c1, b.c0))
+	return newBlsFp2(c0, c1)
+}
+
+func (a *blsFp2) exp(e *big.Int) *blsFp2 {
+	res := newBlsFp2(big.NewInt(1), big.NewInt(0))
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		res = res.mul(res)
+		if e.Bit(i) == 1 {
+			res = res.mul(a)
+		}
+	}
+	return res
+}
+
+func (a *blsFp2) equal(b *blsFp2) bool {
+	return a.c0.Cmp(b.c0) == 0 && a.c1.Cmp(b.c1) == 0
+}
+
+// sqrt returns a square root of the element or nil if there is none (algorithm 9 of https://eprint.iacr.org/2012/685)
+func (a *blsFp2) sqrt() *blsFp2 {
+	exp1 := new(big.Int).Sub(blsFieldModulus, big.NewInt(3))
+	exp1.Rsh(exp1, 2)
+	a1 := a.exp(exp1)
+	alpha := a1.mul(a1).mul(a)
+	x0 := a1.mul(a)
+
+	var x *blsFp2
+	minusOne := newBlsFp2(big.NewInt(-1), big.NewInt(0))
+	if alpha.equal(minusOne) {
+		x = newBlsFp2(new(big.Int).Neg(x0.c1), x0.c0)
+	} else {
+		exp2 := new(big.Int).Sub(blsFieldModulus, big.NewInt(1))
+		exp2.Rsh(exp2, 1)
+		b := alpha.add(newBlsFp2(big.NewInt(1), big.NewInt(0))).exp(exp2)
+		x = b.mul(x0)
+	}
+	if !x.mul(x).equal(a) {
+		return nil
+	}
+	return x
+}
+
+// decompressG2 decodes a compressed G2 point (zcash serialization format)
+func decompressG2(g2 *bls12381.G2, in []byte) (*bls12381.PointG2, error) {
+	if len(in) != 96 {
+		return nil, errors.New("compressed G2 point must be 96 bytes")
+	}
+	if in[0]&0x80 == 0 {
+		return nil, errors.New("point is not compressed")
+	}
+	if in[0]&0x40 != 0 {
+		return nil, errors.New("point at infinity")
+	}
+	largest := in[0]&0x20 != 0
+
+	x1Bytes := make([]byte, 48)
+	copy(x1Bytes, in[:48])
+	x1Bytes[0] &= 0x1f
+	x1 := new(big.Int).SetBytes(x1Bytes)
+	x0 := new(big.Int).SetBytes(in[48:])
+	if x0.Cmp(blsFieldModulus) >= 0 || x1.Cmp(blsFieldModulus) >= 0 {
+		return nil, errors.New("x coordinate exceeds field modulus")
+	}
+	x := newBlsFp2(x0, x1)
+
+	// y^2 = x^3 + 4(1 + u)
+	y2 := x.mul(x).mul(x).add(newBlsFp2(big.NewInt(4), big.NewInt(4)))
+	y := y2.sqrt()
+	if y == nil {
+		return nil, errors.New("point is not on curve")
+	}
+	yLargest := isLargestFieldElement(y.c0)
+	if y.c1.Sign() != 0 {
+		yLargest = isLargestFieldElement(y.c1)
+	}
+	if yLargest != largest {
+		y = newBlsFp2(new(big.Int).Neg(y.c0), new(big.Int).Neg(y.c1))
+	}
+
+	pointBytes := make([]byte, 0, 192)
+	pointBytes = append(pointBytes, blsFieldBytes(x.c1)...)
+	pointBytes = append(pointBytes, blsFieldBytes(x.c0)...)
+	pointBytes = append(pointBytes, blsFieldBytes(y.c1)...)
+	pointBytes = append(pointBytes, blsFieldBytes(y.c0)...)
+	point, err := g2.FromBytes(pointBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !g2.InCorrectSubgroup(point) {
+		return nil, errors.New("point is not in the correct subgroup")
+	}
+	return point, nil
+}
+
+// expandMessageXmd implements expand_message_xmd with sha256 (RFC 9380, section 5.3.1)
+func expandMessageXmd(message []byte, dst []byte, length int) ([]byte, error) {
+	ell := (length + sha256.Size - 1) / sha256.Size
+	if ell > 255 || len(dst) > 255 {
+		return nil, errors.New("invalid expand_message_xmd parameters")
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	hash := sha256.New()
+	hash.Write(make([]byte, sha256.BlockSize))
+	hash.Write(message)
+	hash.Write([]byte{byte(length >> 8), byte(length), 0})
+	hash.Write(dstPrime)
+	b0 := hash.Sum(nil)
+
+	hash.Reset()
+	hash.Write(b0)
+	hash.Write([]byte{1})
+	hash.Write(dstPrime)
+	bi := hash.Sum(nil)
+	uniform := append([]byte{}, bi...)
+	for i := 2; i <= ell; i++ {
+		xored := make([]byte, sha256.Size)
+		for j := range xored {
+			xored[j] = b0[j] ^ bi[j]
+		}
+		hash.Reset()
+		hash.Write(xored)
+		hash.Write([]byte{byte(i)})
+		hash.Write(dstPrime)
+		bi = hash.Sum(nil)
+		uniform = append(uniform, bi...)
+	}
+	return uniform[:length], nil
+}
+
+// hashToG2 implements hash_to_curve for BLS12381G2_XMD:SHA-256_SSWU_RO_ (RFC 9380)
+func hashToG2(g2 *bls12381.G2, message []byte, dst []byte) (*bls12381.PointG2, error) {
+	uniform, err := expandMessageXmd(message, dst, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cofactor clearing of MapToCurve is linear, so clearing both points before adding them is equivalent
+	result := g2.Zero()
+	for i := 0; i < 2; i++ {
+		c0 := new(big.Int).SetBytes(uniform[i*128 : i*128+64])
+		c1 := new(big.Int).SetBytes(uniform[i*128+64 : i*128+128])
+		u := newBlsFp2(c0, c1)
+		point, err := g2.MapToCurve(append(blsFieldBytes(u.c1), blsFieldBytes(u.c0)...))
+		if err != nil {
+			return nil, err
+		}
+		g2.Add(result, result, point)
+	}
+	return g2.Affine(result), nil
+}
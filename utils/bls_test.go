@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// test vectors of the ethereum consensus spec tests (bls/verify & bls/fast_aggregate_verify), signed with the private keys
+// 0x263dbd79.., 0x47b8192d.. & 0x328388af..
+var blsTestPubkeys = []string{
+	"a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a",
+	"b301803f8b5ac4a1133581fc676dfedc60d891dd5fa99028805e5ea5b08d3491af75d0707adab3b70c6a6a580217bf81",
+	"b53d21a4cfd562c469cc81514d4ce5a6b577d8403d32a394dc265dd190b47fa9f829fdd7963afdf972e5e77854051f6f",
+}
+
+var (
+	blsTestMessage00 = bytes.Repeat([]byte{0x00}, 32)
+	blsTestMessage56 = bytes.Repeat([]byte{0x56}, 32)
+	blsTestMessageAb = bytes.Repeat([]byte{0xab}, 32)
+)
+
+func mustDecodeTestHex(t *testing.T, value string) []byte {
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		t.Fatalf("invalid test hex %v: %v", value, err)
+	}
+	return data
+}
+
+// RFC 9380, appendix K.1 (expand_message_xmd with SHA-256)
+func TestExpandMessageXmd(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	tests := []struct {
+		msg      string
+		length   int
+		expected string
+	}{
+		{"", 0x20, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"abc", 0x20, "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+		{"abcdef0123456789", 0x20, "eff31487c770a893cfb36f912fbfcbff40d5661771ca4b2cb4eafe524333f5c1"},
+		{"", 0x80, "af84c27ccfd45d41914fdff5df25293e221afc53d8ad2ac06d5e3e29485dadbee0d121587713a3e0dd4d5e69e93eb7cd4f5df4cd103e188cf60cb02edc3edf18eda8576c412b18ffb658e3dd6ec849469b979d444cf7b26911a08e63cf31f9dcc541708d3491184472c2c29bb749d4286b004ceb5ee6b9a7fa5b646c993f0ced"},
+	}
+	for _, test := range tests {
+		uniform, err := expandMessageXmd([]byte(test.msg), dst, test.length)
+		if err != nil {
+			t.Errorf("%q (%v bytes): unexpected error: %v", test.msg, test.length, err)
+			continue
+		}
+		if hex.EncodeToString(uniform) != test.expected {
+			t.Errorf("%q (%v bytes): unexpected output %x", test.msg, test.length, uniform)
+		}
+	}
+
+	if _, err := expandMessageXmd(nil, dst, 256*32); err == nil {
+		t.Errorf("expected error for an output longer than 255 hashes")
+	}
+}
+
+// RFC 9380, appendix J.10.1 (BLS12381G2_XMD:SHA-256_SSWU_RO_)
+func TestHashToG2(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	tests := []struct {
+		msg            string
+		x0, x1, y0, y1 string
+	}{
+		{
+			msg: "",
+			x0:  "0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a",
+			x1:  "05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d",
+			y0:  "0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92",
+			y1:  "12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6",
+		},
+		{
+			msg: "abc",
+			x0:  "02c2d18e033b960562aae3cab37a27ce00d80ccd5ba4b7fe0e7a210245129dbec7780ccc7954725f4168aff2787776e6",
+			x1:  "139cddbccdc5e91b9623efd38c49f81a6f83f175e80b06fc374de9eb4b41dfe4ca3a230ed250fbe3a2acf73a41177fd8",
+			y0:  "1787327b68159716a37440985269cf584bcb1e621d3a7202be6ea05c4cfe244aeb197642555a0645fb87bf7466b2ba48",
+			y1:  "00aa65dae3c8d732d10ecd2c50f8a1baf3001578f71c694e03866e9f3d49ac1e1ce70dd94a733534f106d4cec0eddd16",
+		},
+	}
+	g2 := bls12381.NewG2()
+	for _, test := range tests {
+		point, err := hashToG2(g2, []byte(test.msg), dst)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.msg, err)
+			continue
+		}
+		// the uncompressed encoding holds the imaginary part of each coordinate first
+		expected := test.x1 + test.x0 + test.y1 + test.y0
+		if encoded := hex.EncodeToString(g2.ToBytes(point)); encoded != expected {
+			t.Errorf("%q: unexpected point %v", test.msg, encoded)
+		}
+	}
+}
+
+func TestVerifyBlsSignature(t *testing.T) {
+	tests := []struct {
+		name      string
+		pubkey    string
+		message   []byte
+		signature string
+		valid     bool
+		err       bool
+	}{
+		{
+			name:      "valid 0x00",
+			pubkey:    blsTestPubkeys[0],
+			message:   blsTestMessage00,
+			signature: "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55",
+			valid:     true,
+		},
+		{
+			name:      "valid 0x56",
+			pubkey:    blsTestPubkeys[0],
+			message:   blsTestMessage56,
+			signature: "882730e5d03f6b42c3abc26d3372625034e1d871b65a8a6b900a56dae22da98abbe1b68f85e49fe7652a55ec3d0591c20767677e33e5cbb1207315c41a9ac03be39c2e7668edc043d6cb1d9fd93033caa8a1c5b0e84bedaeb6c64972503a43eb",
+			valid:     true,
+		},
+		{
+			name:      "valid 0xab",
+			pubkey:    blsTestPubkeys[2],
+			message:   blsTestMessageAb,
+			signature: "ae82747ddeefe4fd64cf9cedb9b04ae3e8a43420cd255e3c7cd06a8d88b7c7f8638543719981c5d16fa3527c468c25f0026704a6951bde891360c7e8d12ddee0559004ccdbe6046b55bae1b257ee97f7cdb955773d7cf29adf3ccbb9975e4eb9",
+			valid:     true,
+		},
+		{
+			name:      "wrong message",
+			pubkey:    blsTestPubkeys[0],
+			message:   blsTestMessage56,
+			signature: "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55",
+		},
+		{
+			name:      "wrong pubkey",
+			pubkey:    blsTestPubkeys[1],
+			message:   blsTestMessage00,
+			signature: "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55",
+		},
+		{
+			name:      "infinity pubkey & signature",
+			pubkey:    "c0" + hex.EncodeToString(make([]byte, 47)),
+			message:   blsTestMessageAb,
+			signature: "c0" + hex.EncodeToString(make([]byte, 95)),
+			err:       true,
+		},
+		{
+			name:      "uncompressed signature flag",
+			pubkey:    blsTestPubkeys[0],
+			message:   blsTestMessage00,
+			signature: "36ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55",
+			err:       true,
+		},
+	}
+	for _, test := range tests {
+		valid, err := VerifyBlsSignature(mustDecodeTestHex(t, test.pubkey), test.message, mustDecodeTestHex(t, test.signature))
+		if (err != nil) != test.err {
+			t.Errorf("%v: unexpected error: %v", test.name, err)
+		}
+		if valid != test.valid {
+			t.Errorf("%v: unexpected result %v", test.name, valid)
+		}
+	}
+}
+
+func TestVerifyBlsAggregateSignature(t *testing.T) {
+	pubkeys := make([][]byte, len(blsTestPubkeys))
+	for idx, pubkey := range blsTestPubkeys {
+		pubkeys[idx] = mustDecodeTestHex(t, pubkey)
+	}
+	signature := mustDecodeTestHex(t, "9712c3edd73a209c742b8250759db12549b3eaf43b5ca61376d9f30e2747dbcf842d8b2ac0901d2a093713e20284a7670fcf6954e9ab93de991bb9b313e664785a075fc285806fa5224c82bde146561b446ccfc706a64b8579513cfc4ff1d930")
+
+	valid, err := VerifyBlsAggregateSignature(pubkeys, blsTestMessageAb, signature)
+	if err != nil || !valid {
+		t.Errorf("valid aggregate not verified: %v, %v", valid, err)
+	}
+
+	// one of the signers missing
+	valid, err = VerifyBlsAggregateSignature(pubkeys[:2], blsTestMessageAb, signature)
+	if err != nil || valid {
+		t.Errorf("aggregate verified without a signer: %v, %v", valid, err)
+	}
+
+	// pubkey that did not sign
+	valid, err = VerifyBlsAggregateSignature(append(pubkeys, pubkeys[0]), blsTestMessageAb, signature)
+	if err != nil || valid {
+		t.Errorf("aggregate verified with an extra pubkey: %v, %v", valid, err)
+	}
+
+	valid, err = VerifyBlsAggregateSignature(pubkeys, blsTestMessage56, signature)
+	if err != nil || valid {
+		t.Errorf("aggregate verified for another message: %v, %v", valid, err)
+	}
+
+	if _, err := VerifyBlsAggregateSignature(nil, blsTestMessageAb, signature); err == nil {
+		t.Errorf("expected error without pubkeys")
+	}
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+)
+
+// known consensus client names, matched against the block graffiti
+var graffitiClients = []string{"Lighthouse", "Prysm", "Teku", "Nimbus", "Lodestar", "Grandine"}
+
+// known execution client fingerprints, matched against the execution payload extra data.
+// geth based clients write a rlp list with the client name, so the name is matched anywhere in the data.
+var extraDataClients = []struct {
+	fingerprint string
+	name        string
+}{
+	{"ethereumjs", "EthereumJS"},
+	{"nethermind", "Nethermind"},
+	{"besu", "Besu"},
+	{"erigon", "Erigon"},
+	{"reth", "Reth"},
+	{"nimbus", "Nimbus"},
+	{"geth", "Geth"},
+}
+
+// UnknownClient is the client name of blocks without a known fingerprint
+const UnknownClient = "Unknown"
+
+// GetGraffitiClient guesses the consensus client of a block by its graffiti text
+func GetGraffitiClient(graffiti string) string {
+	graffiti = strings.ToLower(graffiti)
+	for _, clientName := range graffitiClients {
+		if strings.Contains(graffiti, strings.ToLower(clientName)) {
+			return clientName
+		}
+	}
+	return UnknownClient
+}
+
+// GetExtraDataClient guesses the execution client that built a payload by its extra data
+func GetExtraDataClient(extraData []byte) string {
+	extraData = bytes.ToLower(extraData)
+	for _, client := range extraDataClients {
+		if bytes.Contains(extraData, []byte(client.fingerprint)) {
+			return client.name
+		}
+	}
+	return UnknownClient
+}
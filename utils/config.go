@@ -120,7 +120,7 @@ func ReadConfig(cfg *types.Config, path string) error {
 	}
 
 	// default validator names
-	if cfg.Frontend.ValidatorNamesYaml == "" && cfg.Frontend.ValidatorNamesInventory == "" {
+	if cfg.Frontend.ValidatorNamesYaml == "" && cfg.Frontend.ValidatorNamesInventory == "" && cfg.Frontend.ValidatorNamesSources == nil {
 		switch cfg.Chain.Name {
 		case "sepolia":
 			cfg.Frontend.ValidatorNamesYaml = "~internal/sepolia.names.yml"
@@ -129,6 +129,26 @@ func ReadConfig(cfg *types.Config, path string) error {
 		}
 	}
 
+	// validator name sources
+	if cfg.Frontend.ValidatorNamesSources == nil {
+		cfg.Frontend.ValidatorNamesSources = []types.ValidatorNamesSourceConfig{}
+		if cfg.Frontend.ValidatorNamesYaml != "" {
+			cfg.Frontend.ValidatorNamesSources = append(cfg.Frontend.ValidatorNamesSources, types.ValidatorNamesSourceConfig{
+				Name:   "yaml",
+				Type:   "yaml",
+				Source: cfg.Frontend.ValidatorNamesYaml,
+			})
+		}
+		if cfg.Frontend.ValidatorNamesInventory != "" {
+			cfg.Frontend.ValidatorNamesSources = append(cfg.Frontend.ValidatorNamesSources, types.ValidatorNamesSourceConfig{
+				Name:     "inventory",
+				Type:     "inventory",
+				Source:   cfg.Frontend.ValidatorNamesInventory,
+				Priority: 1,
+			})
+		}
+	}
+
 	// endpoints
 	if cfg.BeaconApi.Endpoints == nil && cfg.BeaconApi.Endpoint != "" {
 		cfg.BeaconApi.Endpoints = []types.EndpointConfig{
@@ -36,6 +36,25 @@ func FormatFullETHFromGwei(gwei uint64) string {
 	return fmt.Sprintf("%v ETH", uint64(float64(gwei)/math.Pow10(9)))
 }
 
+func FormatByteSize(size uint64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	value := float64(size)
+	unitIdx := 0
+	for value >= 1024 && unitIdx < len(units)-1 {
+		value /= 1024
+		unitIdx++
+	}
+	return fmt.Sprintf("%.2f %v", value, units[unitIdx])
+}
+
+// FormatByteSizeDelta formats a size difference with its sign (eg. "+1.20 MiB")
+func FormatByteSizeDelta(delta int64) string {
+	if delta < 0 {
+		return "-" + FormatByteSize(uint64(-delta))
+	}
+	return "+" + FormatByteSize(uint64(delta))
+}
+
 func FormatETHAddCommasFromGwei(gwei uint64) template.HTML {
 	return FormatAddCommas(uint64(float64(gwei) / math.Pow10(9)))
 }
@@ -297,6 +316,17 @@ func FormatEthBlockHashLink(blockHash []byte) template.HTML {
 	return template.HTML(caption)
 }
 
+func FormatEthTransactionLink(hash []byte) template.HTML {
+	caption := fmt.Sprintf("0x%x", hash)
+	if Config.Frontend.EthExplorerLink != "" {
+		link, err := url.JoinPath(Config.Frontend.EthExplorerLink, "tx", caption)
+		if err == nil {
+			return template.HTML(fmt.Sprintf(`<a href="%v">%v</a>`, link, caption))
+		}
+	}
+	return template.HTML(caption)
+}
+
 func FormatEthAddressLink(address []byte) template.HTML {
 	caption := common.BytesToAddress(address).String()
 	if Config.Frontend.EthExplorerLink != "" {
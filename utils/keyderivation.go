@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// bls12-381 curve order, secret keys are reduced modulo r
+var blsCurveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// MnemonicToSeed returns the BIP-39 seed of a mnemonic. The words are not checked against a word list,
+// so mnemonics of any language can be used as long as they're written the same way as for the key generation.
+func MnemonicToSeed(mnemonic string, passphrase string) []byte {
+	mnemonic = norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key([]byte(mnemonic), []byte(salt), 2048, 64, sha512.New)
+}
+
+// DeriveValidatorPubkey returns the compressed pubkey of the validator signing key at m/12381/3600/<index>/0/0 (EIP-2334)
+func DeriveValidatorPubkey(seed []byte, index uint64) ([]byte, error) {
+	if len(seed) < 32 {
+		return nil, fmt.Errorf("seed must be at least 32 bytes")
+	}
+	secretKey := hkdfModR(seed)
+	for _, pathIndex := range []uint32{12381, 3600, uint32(index), 0, 0} {
+		secretKey = deriveChildSecretKey(secretKey, pathIndex)
+	}
+	return BlsPublicKeyFromSecret(secretKey), nil
+}
+
+// deriveChildSecretKey implements derive_child_SK of EIP-2333
+func deriveChildSecretKey(parentKey *big.Int, index uint32) *big.Int {
+	salt := make([]byte, 4)
+	binary.BigEndian.PutUint32(salt, index)
+	ikm := make([]byte, 32)
+	parentKey.FillBytes(ikm)
+	notIkm := make([]byte, 32)
+	for i := range ikm {
+		notIkm[i] = ikm[i] ^ 0xff
+	}
+
+	// compressed lamport pubkey of both lamport secret keys
+	lamportPubkey := sha256.New()
+	for _, keyIkm := range [][]byte{ikm, notIkm} {
+		lamportKey := make([]byte, 32*255)
+		io.ReadFull(hkdf.New(sha256.New, keyIkm, salt, nil), lamportKey)
+		for i := 0; i < 255; i++ {
+			chunkHash := sha256.Sum256(lamportKey[i*32 : (i+1)*32])
+			lamportPubkey.Write(chunkHash[:])
+		}
+	}
+	return hkdfModR(lamportPubkey.Sum(nil))
+}
+
+// hkdfModR implements HKDF_mod_r of EIP-2333
+func hkdfModR(ikm []byte) *big.Int {
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	secretKey := new(big.Int)
+	for secretKey.Sign() == 0 {
+		saltHash := sha256.Sum256(salt)
+		salt = saltHash[:]
+		okm := make([]byte, 48)
+		io.ReadFull(hkdf.New(sha256.New, append(append([]byte{}, ikm...), 0x00), salt, []byte{0x00, 48}), okm)
+		secretKey.SetBytes(okm)
+		secretKey.Mod(secretKey, blsCurveOrder)
+	}
+	return secretKey
+}
+
+// BlsPublicKeyFromSecret returns the compressed G1 pubkey (48 bytes) of a BLS secret key
+func BlsPublicKeyFromSecret(secretKey *big.Int) []byte {
+	g1 := bls12381.NewG1()
+	point := g1.MulScalar(g1.New(), g1.One(), secretKey)
+	raw := g1.ToBytes(point)
+
+	pubkey := make([]byte, 48)
+	copy(pubkey, raw[:48])
+	pubkey[0] |= 0x80
+	if isLargestFieldElement(new(big.Int).SetBytes(raw[48:])) {
+		pubkey[0] |= 0x20
+	}
+	return pubkey
+}
@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"fmt"
 	"os"
 	"os/signal"
 	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -15,8 +19,121 @@ func WaitForCtrlC() {
 	<-c
 }
 
+// SubroutineCrashStats counts the recovered panics of a subroutine
+type SubroutineCrashStats struct {
+	Identifier string    `json:"identifier"`
+	Crashes    uint64    `json:"crashes"`
+	Restarts   uint64    `json:"restarts"`
+	LastCrash  time.Time `json:"last_crash"`
+	LastError  string    `json:"last_error"`
+}
+
+var subroutineCrashMutex sync.Mutex
+var subroutineCrashes = map[string]*SubroutineCrashStats{}
+
+// subroutine restart backoff, the backoff is reset if the subroutine ran without crashing for a while
+const (
+	subroutineRestartMinDelay = 1 * time.Second
+	subroutineRestartMaxDelay = 5 * time.Minute
+	subroutineStableRuntime   = 10 * time.Minute
+)
+
+func getSubroutineCrashStats(identifier string) *SubroutineCrashStats {
+	stats := subroutineCrashes[identifier]
+	if stats == nil {
+		stats = &SubroutineCrashStats{
+			Identifier: identifier,
+		}
+		subroutineCrashes[identifier] = stats
+	}
+	return stats
+}
+
+// recordSubroutinePanic logs the recovered panic with its stack trace and counts it in the crash stats
+func recordSubroutinePanic(identifier string, panicVal interface{}) error {
+	panicErr, isErr := panicVal.(error)
+	if !isErr {
+		panicErr = fmt.Errorf("%v", panicVal)
+	}
+	logrus.WithError(panicErr).Errorf("uncaught panic in %v subroutine: %v, stack: %v", identifier, panicVal, string(debug.Stack()))
+	CountSubroutineCrash(identifier, panicErr)
+	return panicErr
+}
+
+// CountSubroutineCrash counts a panic that has been recovered & logged by the caller in the crash stats
+func CountSubroutineCrash(identifier string, panicErr error) {
+	subroutineCrashMutex.Lock()
+	defer subroutineCrashMutex.Unlock()
+	stats := getSubroutineCrashStats(identifier)
+	stats.Crashes++
+	stats.LastCrash = time.Now()
+	stats.LastError = panicErr.Error()
+}
+
+// GetSubroutineCrashStats returns the crash stats of all subroutines that panicked since startup
+func GetSubroutineCrashStats() []*SubroutineCrashStats {
+	subroutineCrashMutex.Lock()
+	defer subroutineCrashMutex.Unlock()
+	crashStats := make([]*SubroutineCrashStats, 0, len(subroutineCrashes))
+	for _, stats := range subroutineCrashes {
+		statsCopy := *stats
+		crashStats = append(crashStats, &statsCopy)
+	}
+	sort.Slice(crashStats, func(a, b int) bool {
+		return crashStats[a].Identifier < crashStats[b].Identifier
+	})
+	return crashStats
+}
+
+// HandleSubroutinePanic recovers & logs a panic of a subroutine, it has to be deferred
 func HandleSubroutinePanic(identifier string) {
 	if err := recover(); err != nil {
-		logrus.WithError(err.(error)).Errorf("uncaught panic in %v subroutine: %v, stack: %v", identifier, err, string(debug.Stack()))
+		recordSubroutinePanic(identifier, err)
+	}
+}
+
+// CatchPanic runs the function and returns a panic as error, so it's handled by the retry logic of the caller
+func CatchPanic(identifier string, fn func() error) (err error) {
+	defer func() {
+		if panicVal := recover(); panicVal != nil {
+			err = fmt.Errorf("panic in %v: %w", identifier, recordSubroutinePanic(identifier, panicVal))
+		}
+	}()
+	return fn()
+}
+
+// RunWithRestart runs a long running subroutine and restarts it with an increasing delay when it panics.
+// It returns when the subroutine returns without panic.
+func RunWithRestart(identifier string, fn func()) {
+	restartDelay := subroutineRestartMinDelay
+	for {
+		startTime := time.Now()
+		crashed := func() (crashed bool) {
+			defer func() {
+				if panicVal := recover(); panicVal != nil {
+					recordSubroutinePanic(identifier, panicVal)
+					crashed = true
+				}
+			}()
+			fn()
+			return false
+		}()
+		if !crashed {
+			return
+		}
+
+		if time.Since(startTime) >= subroutineStableRuntime {
+			restartDelay = subroutineRestartMinDelay
+		}
+		logrus.Warnf("restarting %v subroutine in %v", identifier, restartDelay)
+		time.Sleep(restartDelay)
+		restartDelay *= 2
+		if restartDelay > subroutineRestartMaxDelay {
+			restartDelay = subroutineRestartMaxDelay
+		}
+
+		subroutineCrashMutex.Lock()
+		getSubroutineCrashStats(identifier).Restarts++
+		subroutineCrashMutex.Unlock()
 	}
 }
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// signature domain types of the consensus specs
+var (
+	DomainBeaconProposer = phase0.DomainType{0x00, 0x00, 0x00, 0x00}
+	DomainBeaconAttester = phase0.DomainType{0x01, 0x00, 0x00, 0x00}
+	DomainVoluntaryExit  = phase0.DomainType{0x04, 0x00, 0x00, 0x00}
+)
+
+// GetForkVersionAtEpoch returns the fork version that is active at the epoch according to the chain config
+func GetForkVersionAtEpoch(epoch uint64) phase0.Version {
+	chainConfig := Config.Chain.Config
+	forkVersion := chainConfig.GenesisForkVersion
+	forks := []struct {
+		epoch   uint64
+		version string
+	}{
+		{chainConfig.AltairForkEpoch, chainConfig.AltairForkVersion},
+		{chainConfig.BellatrixForkEpoch, chainConfig.BellatrixForkVersion},
+		{chainConfig.CappellaForkEpoch, chainConfig.CappellaForkVersion},
+		{chainConfig.DenebForkEpoch, chainConfig.DenebForkVersion},
+	}
+	for _, fork := range forks {
+		if fork.version != "" && epoch >= fork.epoch {
+			forkVersion = fork.version
+		}
+	}
+
+	version := phase0.Version{}
+	copy(version[:], MustParseHex(forkVersion))
+	return version
+}
+
+// ComputeDomain returns the signature domain for the domain type, fork version & genesis validators root (compute_domain)
+func ComputeDomain(domainType phase0.DomainType, forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.Domain, error) {
+	forkDataRoot, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+
+	domain := phase0.Domain{}
+	copy(domain[:4], domainType[:])
+	copy(domain[4:], forkDataRoot[:28])
+	return domain, nil
+}
+
+// ComputeSigningRoot returns the message that is signed for an ssz object root in a signature domain (compute_signing_root)
+func ComputeSigningRoot(objectRoot phase0.Root, domain phase0.Domain) (phase0.Root, error) {
+	return (&phase0.SigningData{
+		ObjectRoot: objectRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+}
@@ -39,6 +39,8 @@ func GetTemplateFuncs() template.FuncMap {
 		"contains":                   strings.Contains,
 		"formatAddCommas":            FormatAddCommas,
 		"formatFloat":                FormatFloat,
+		"formatByteSize":             FormatByteSize,
+		"formatByteSizeDelta":        FormatByteSizeDelta,
 		"formatBitlist":              FormatBitlist,
 		"formatBitvectorValidators":  formatBitvectorValidators,
 		"formatParticipation":        FormatParticipation,
@@ -50,6 +52,7 @@ func GetTemplateFuncs() template.FuncMap {
 		"ethBlockLink":               FormatEthBlockLink,
 		"ethBlockHashLink":           FormatEthBlockHashLink,
 		"ethAddressLink":             FormatEthAddressLink,
+		"ethTransactionLink":         FormatEthTransactionLink,
 		"formatValidator":            FormatValidator,
 		"formatValidatorWithIndex":   FormatValidatorWithIndex,
 		"formatSlashedValidator":     FormatSlashedValidator,
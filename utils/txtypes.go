@@ -0,0 +1,30 @@
+package utils
+
+// TransactionTypeCounts holds the number of execution payload transactions per EIP-2718 transaction type
+type TransactionTypeCounts struct {
+	Legacy     uint64 // untyped rlp transactions
+	AccessList uint64 // EIP-2930 (type 1)
+	DynamicFee uint64 // EIP-1559 (type 2)
+	Blob       uint64 // EIP-4844 (type 3)
+	SetCode    uint64 // EIP-7702 (type 4)
+}
+
+// AddTransaction classifies the transaction by its type prefix. Untyped legacy transactions start with a rlp list
+// header (>= 0xc0), typed transactions with their type byte. Unknown types are not counted.
+func (counts *TransactionTypeCounts) AddTransaction(txBytes []byte) {
+	if len(txBytes) == 0 {
+		return
+	}
+	switch txType := txBytes[0]; {
+	case txType >= 0xc0:
+		counts.Legacy++
+	case txType == 0x01:
+		counts.AccessList++
+	case txType == 0x02:
+		counts.DynamicFee++
+	case txType == blobTxType:
+		counts.Blob++
+	case txType == 0x04:
+		counts.SetCode++
+	}
+}
@@ -57,3 +57,13 @@ func GetValidatorChurnLimit(validatorCount uint64) uint64 {
 	}
 	return adaptable
 }
+
+// GetValidatorActivationChurnLimit returns the number of validators that can be activated per epoch, which is capped since deneb (EIP-7514)
+func GetValidatorActivationChurnLimit(validatorCount uint64, epoch uint64) uint64 {
+	churnLimit := GetValidatorChurnLimit(validatorCount)
+	maxChurn := Config.Chain.Config.MaxPerEpochActivationChurnLimit
+	if epoch >= Config.Chain.Config.DenebForkEpoch && maxChurn > 0 && churnLimit > maxChurn {
+		return maxChurn
+	}
+	return churnLimit
+}